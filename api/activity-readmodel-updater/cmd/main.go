@@ -34,6 +34,9 @@ type Config struct {
 	PubSubTopic        string
 	PubSubSubscription string
 
+	// Subscription to the urgency service's event topic; empty disables urgency syncing
+	UrgencyPubSubSubscription string
+
 	OutboxPollIntervalSeconds int
 
 	// Pub/Sub subscriber parallelism
@@ -70,6 +73,7 @@ func loadConfig() *Config {
 		FirebaseCredentialsPath:          credPath,
 		PubSubTopic:                      getEnvOrDefault("PUBSUB_TOPIC", "activity-events"),
 		PubSubSubscription:               getEnvOrDefault("PUBSUB_SUBSCRIPTION", "activity-events-sub"),
+		UrgencyPubSubSubscription:        getEnvOrDefault("URGENCY_PUBSUB_SUBSCRIPTION", ""),
 		OutboxPollIntervalSeconds:        getEnvAsIntOrDefault("OUTBOX_POLL_INTERVAL_SECONDS", 10),
 		SubscriberNumGoroutines:          getEnvAsIntOrDefault("SUBSCRIBER_NUM_GOROUTINES", 8),
 		SubscriberMaxOutstandingMessages: getEnvAsIntOrDefault("SUBSCRIBER_MAX_OUTSTANDING_MESSAGES", 1000),
@@ -189,81 +193,17 @@ func main() {
 	pubSubLog := log.WithContext(ctxWithCancel)
 	defer cancel()
 
-	// Start Pub/Sub subscriber
-	go func() {
-		subscription := pubsubClient.Subscription(cfg.PubSubSubscription)
-		subscription.ReceiveSettings.NumGoroutines = cfg.SubscriberNumGoroutines
-		subscription.ReceiveSettings.MaxOutstandingMessages = cfg.SubscriberMaxOutstandingMessages
-		subscription.ReceiveSettings.MaxOutstandingBytes = cfg.SubscriberMaxOutstandingBytes
-
-		pubSubLog.Infof("Starting Pub/Sub subscriber with config: subscription=%s num_goroutines=%d max_outstanding_messages=%d max_outstanding_bytes=%d", cfg.PubSubSubscription, cfg.SubscriberNumGoroutines, cfg.SubscriberMaxOutstandingMessages, cfg.SubscriberMaxOutstandingBytes)
-
-		// Sharded dispatcher ensures per-activity ordering, parallel across activities
-		dispatcher := events.NewShardedDispatcher(firebaseService, pubSubLog, cfg.ShardWorkers, cfg.ShardQueue)
-
-		backoff := time.Second
-		attempt := 0
-		for {
-			if ctxWithCancel.Err() != nil {
-				pubSubLog.Infof("Subscriber context canceled; exiting receive loop")
-				return
-			}
-			attempt++
-			pubSubLog.Infof("Starting Pub/Sub subscriber receive attempt=%d subscription=%s", attempt, cfg.PubSubSubscription)
-			err := subscription.Receive(ctxWithCancel, func(ctx context.Context, msg *pubsub.Message) {
-				defer func() {
-					if r := recover(); r != nil {
-						pubSubLog.Errorf("Panic in subscriber handler: %v\nstack=%s\nmessage_id=%s", r, string(debug.Stack()), msg.ID)
-						msg.Nack()
-					}
-				}()
-				ctx, reqID := utils.EnsureRequestID(ctx)
-				reqLog := pubSubLog.WithContext(ctx)
-				attempt := 0
-				if msg.DeliveryAttempt != nil {
-					attempt = *msg.DeliveryAttempt
-				}
-				agg := msg.Attributes["aggregateId"]
-				reqLog.Infof("Handling activity event: message_id=%s delivery_attempt=%d aggregate_id=%s publish_time=%s", msg.ID, attempt, agg, msg.PublishTime.Format(time.RFC3339))
-
-				if err := dispatcher.Process(ctx, msg); err != nil {
-					if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
-						reqLog.Warnf("Handler context canceled: message_id=%s delivery_attempt=%d; nacking for redelivery", msg.ID, attempt)
-					} else {
-						reqLog.Errorf("Failed to handle activity event: error=%v, message_id=%s, request_id=%s", err, msg.ID, reqID)
-					}
-					msg.Nack()
-				} else {
-					reqLog.Infof("Successfully handled activity event: message_id=%s", msg.ID)
-					msg.Ack()
-				}
-			})
-
-			// Handle Receive termination conditions explicitly
-			if err == nil {
-				pubSubLog.Warnf("Pub/Sub Receive returned nil (no error); restarting receive loop")
-				backoff = time.Second
-			} else if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
-				pubSubLog.Infof("Pub/Sub Receive stopped due to context cancellation: %v; exiting subscriber loop", err)
-				return
-			} else {
-				pubSubLog.Warnf("Pub/Sub Receive returned error: %v; will retry", err)
-			}
+	// Sharded dispatcher ensures per-activity ordering, parallel across activities
+	dispatcher := events.NewShardedDispatcher(firebaseService, pubSubLog, cfg.ShardWorkers, cfg.ShardQueue)
+	go runSubscriber(ctxWithCancel, pubSubLog, cfg, pubsubClient.Subscription(cfg.PubSubSubscription), "activity", dispatcher.Process)
 
-			sleep := backoff
-			if backoff < 30*time.Second {
-				backoff *= 2
-			}
-			pubSubLog.Infof("Retrying subscriber after backoff=%s (attempt=%d)", sleep, attempt)
-			select {
-			case <-time.After(sleep):
-				continue
-			case <-ctxWithCancel.Done():
-				pubSubLog.Infof("Context canceled during backoff; exiting subscriber loop")
-				return
-			}
-		}
-	}()
+	// Urgency lifecycle events keep the urgency fields of activity documents current
+	if cfg.UrgencyPubSubSubscription != "" {
+		urgencyHandler := events.NewUrgencyHandler(firebaseService, pubSubLog)
+		go runSubscriber(ctxWithCancel, pubSubLog, cfg, pubsubClient.Subscription(cfg.UrgencyPubSubSubscription), "urgency", urgencyHandler.Handle)
+	} else {
+		pubSubLog.Info("URGENCY_PUBSUB_SUBSCRIPTION not set; urgency events are not synced into the read model")
+	}
 
 	// Wait for interrupt signal
 	sigChan := make(chan os.Signal, 1)
@@ -280,6 +220,79 @@ func main() {
 	log.Infof("Activity Read Model Updater stopped")
 }
 
+// runSubscriber receives messages from the subscription until ctx is canceled, acking the ones handle
+// accepts and nacking the rest for redelivery. Receive is restarted with backoff when it stops on its own.
+func runSubscriber(ctx context.Context, log utils.Logger, cfg *Config, subscription *pubsub.Subscription, kind string, handle func(ctx context.Context, msg *pubsub.Message) error) {
+	subscription.ReceiveSettings.NumGoroutines = cfg.SubscriberNumGoroutines
+	subscription.ReceiveSettings.MaxOutstandingMessages = cfg.SubscriberMaxOutstandingMessages
+	subscription.ReceiveSettings.MaxOutstandingBytes = cfg.SubscriberMaxOutstandingBytes
+
+	log.Infof("Starting Pub/Sub subscriber with config: subscription=%s num_goroutines=%d max_outstanding_messages=%d max_outstanding_bytes=%d", subscription.ID(), cfg.SubscriberNumGoroutines, cfg.SubscriberMaxOutstandingMessages, cfg.SubscriberMaxOutstandingBytes)
+
+	backoff := time.Second
+	attempt := 0
+	for {
+		if ctx.Err() != nil {
+			log.Infof("Subscriber context canceled; exiting receive loop")
+			return
+		}
+		attempt++
+		log.Infof("Starting Pub/Sub subscriber receive attempt=%d subscription=%s", attempt, subscription.ID())
+		err := subscription.Receive(ctx, func(msgCtx context.Context, msg *pubsub.Message) {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Errorf("Panic in subscriber handler: %v\nstack=%s\nmessage_id=%s", r, string(debug.Stack()), msg.ID)
+					msg.Nack()
+				}
+			}()
+			msgCtx, reqID := utils.EnsureRequestID(msgCtx)
+			reqLog := log.WithContext(msgCtx)
+			attempt := 0
+			if msg.DeliveryAttempt != nil {
+				attempt = *msg.DeliveryAttempt
+			}
+			agg := msg.Attributes["aggregateId"]
+			reqLog.Infof("Handling %s event: message_id=%s delivery_attempt=%d aggregate_id=%s publish_time=%s", kind, msg.ID, attempt, agg, msg.PublishTime.Format(time.RFC3339))
+
+			if err := handle(msgCtx, msg); err != nil {
+				if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+					reqLog.Warnf("Handler context canceled: message_id=%s delivery_attempt=%d; nacking for redelivery", msg.ID, attempt)
+				} else {
+					reqLog.Errorf("Failed to handle %s event: error=%v, message_id=%s, request_id=%s", kind, err, msg.ID, reqID)
+				}
+				msg.Nack()
+			} else {
+				reqLog.Infof("Successfully handled %s event: message_id=%s", kind, msg.ID)
+				msg.Ack()
+			}
+		})
+
+		// Handle Receive termination conditions explicitly
+		if err == nil {
+			log.Warnf("Pub/Sub Receive returned nil (no error); restarting receive loop")
+			backoff = time.Second
+		} else if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			log.Infof("Pub/Sub Receive stopped due to context cancellation: %v; exiting subscriber loop", err)
+			return
+		} else {
+			log.Warnf("Pub/Sub Receive returned error: %v; will retry", err)
+		}
+
+		sleep := backoff
+		if backoff < 30*time.Second {
+			backoff *= 2
+		}
+		log.Infof("Retrying subscriber after backoff=%s (attempt=%d)", sleep, attempt)
+		select {
+		case <-time.After(sleep):
+			continue
+		case <-ctx.Done():
+			log.Infof("Context canceled during backoff; exiting subscriber loop")
+			return
+		}
+	}
+}
+
 func initFirestore(ctx context.Context, credentialsPath, projectID string) (*firestore.Client, error) {
 	var client *firestore.Client
 	var err error
@@ -8,6 +8,8 @@ import (
 	"strings"
 
 	activityV1 "github.com/pd120424d/mountain-service/api/contracts/activity/v1"
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	"github.com/pd120424d/mountain-service/api/shared/models"
 )
 
 // Parse tries multiple strategies to extract an ActivityEvent from raw Pub/Sub bytes
@@ -132,3 +134,20 @@ func postProcess(ev *activityV1.ActivityEvent, _ string) {
 	}
 	ev.Type = t
 }
+
+// ParseUrgency extracts an UrgencyEvent from an outbox envelope published by the urgency service
+func ParseUrgency(data []byte) (urgencyV1.UrgencyEvent, error) {
+	var env models.OutboxEvent
+	if err := json.Unmarshal(data, &env); err != nil || env.EventData == "" {
+		return urgencyV1.UrgencyEvent{}, fmt.Errorf("unrecognized urgency event payload format")
+	}
+	var ev urgencyV1.UrgencyEvent
+	if err := json.Unmarshal([]byte(env.EventData), &ev); err != nil {
+		return urgencyV1.UrgencyEvent{}, fmt.Errorf("invalid urgency event data: %w", err)
+	}
+	if ev.UrgencyID == 0 || !ev.Type.Valid() {
+		return urgencyV1.UrgencyEvent{}, fmt.Errorf("invalid urgency event: urgency_id=%d type=%q", ev.UrgencyID, ev.Type)
+	}
+	ev.ID = env.ID
+	return ev, nil
+}
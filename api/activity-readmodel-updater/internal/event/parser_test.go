@@ -9,7 +9,9 @@ import (
 	"time"
 
 	activityV1 "github.com/pd120424d/mountain-service/api/contracts/activity/v1"
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func evt(t string) activityV1.ActivityEvent {
@@ -169,3 +171,29 @@ func Test_tryLegacy_rejects_zero_id(t *testing.T) {
 	_, ok := tryLegacy(b, "", "legacy")
 	assert.False(t, ok)
 }
+
+func TestParseUrgency(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it parses an outbox envelope", func(t *testing.T) {
+		ev, err := ParseUrgency([]byte(`{"id":12,"aggregateId":"urgency-7","eventData":"{\"type\":\"urgency.assigned\",\"urgencyId\":7,\"title\":\"Marko Markovic\",\"level\":\"high\",\"status\":\"in_progress\",\"occurredAt\":\"2025-01-01T00:00:00Z\"}"}`))
+		require.NoError(t, err)
+		assert.Equal(t, uint(12), ev.ID)
+		assert.Equal(t, urgencyV1.EventUrgencyAssigned, ev.Type)
+		assert.Equal(t, uint(7), ev.UrgencyID)
+		assert.Equal(t, "Marko Markovic", ev.Title)
+		assert.Equal(t, urgencyV1.High, ev.Level)
+	})
+
+	t.Run("it rejects payloads that are not urgency events", func(t *testing.T) {
+		for _, payload := range []string{
+			`not json`,
+			`{"aggregateId":"urgency-7"}`,
+			`{"aggregateId":"urgency-7","eventData":"not json"}`,
+			`{"aggregateId":"activity-1","eventData":"{\"type\":\"CREATE\",\"activityId\":1}"}`,
+		} {
+			_, err := ParseUrgency([]byte(payload))
+			assert.Error(t, err, payload)
+		}
+	})
+}
@@ -0,0 +1,43 @@
+package events
+
+import (
+	"context"
+	"sync"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/pd120424d/mountain-service/api/activity-readmodel-updater/internal/service"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"go.uber.org/zap"
+)
+
+// UrgencyHandler applies events from the urgency event stream to the activity read model.
+// Events of the same urgency are applied one at a time.
+type UrgencyHandler struct {
+	fb     service.FirebaseService
+	logger utils.Logger
+	locks  sync.Map
+}
+
+func NewUrgencyHandler(fb service.FirebaseService, logger utils.Logger) *UrgencyHandler {
+	return &UrgencyHandler{fb: fb, logger: logger.WithName("urgencyEventHandler")}
+}
+
+func (h *UrgencyHandler) Handle(ctx context.Context, msg *pubsub.Message) error {
+	log := h.logger.WithContext(ctx)
+	ev, err := ParseUrgency(msg.Data)
+	if err != nil {
+		log.Errorf("Unrecognized urgency event payload, cannot parse message_id=%s: %v", msg.ID, err)
+		return err
+	}
+	defer utils.TimeOperation(log, "UrgencyHandler.Handle", zap.Int("urgency_id", int(ev.UrgencyID)), zap.String("type", string(ev.Type)))()
+
+	lock := h.lock(ev.UrgencyID)
+	lock.Lock()
+	defer lock.Unlock()
+	return h.fb.SyncUrgency(ctx, ev)
+}
+
+func (h *UrgencyHandler) lock(urgencyID uint) *sync.Mutex {
+	m, _ := h.locks.LoadOrStore(urgencyID, &sync.Mutex{})
+	return m.(*sync.Mutex)
+}
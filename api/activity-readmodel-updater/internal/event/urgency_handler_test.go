@@ -0,0 +1,48 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/pd120424d/mountain-service/api/activity-readmodel-updater/internal/service"
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUrgencyHandler_Handle(t *testing.T) {
+	t.Parallel()
+	logger := utils.NewTestLogger()
+	ctx := context.Background()
+	msg := &pubsub.Message{ID: "m1", Data: []byte(`{"id":1,"aggregateId":"urgency-7","eventData":"{\"type\":\"urgency.updated\",\"urgencyId\":7,\"level\":\"critical\"}"}`)}
+
+	t.Run("it syncs the parsed event", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockFB := service.NewMockFirebaseService(ctrl)
+		mockFB.EXPECT().SyncUrgency(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, ev urgencyV1.UrgencyEvent) error {
+			assert.Equal(t, uint(7), ev.UrgencyID)
+			assert.Equal(t, urgencyV1.Critical, ev.Level)
+			return nil
+		})
+
+		assert.NoError(t, NewUrgencyHandler(mockFB, logger).Handle(ctx, msg))
+	})
+
+	t.Run("it returns the sync error so the message is redelivered", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockFB := service.NewMockFirebaseService(ctrl)
+		mockFB.EXPECT().SyncUrgency(gomock.Any(), gomock.Any()).Return(errors.New("boom"))
+
+		assert.Error(t, NewUrgencyHandler(mockFB, logger).Handle(ctx, msg))
+	})
+
+	t.Run("it fails on an unparsable message without syncing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockFB := service.NewMockFirebaseService(ctrl)
+
+		assert.Error(t, NewUrgencyHandler(mockFB, logger).Handle(ctx, &pubsub.Message{ID: "m2", Data: []byte("x")}))
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	activityV1 "github.com/pd120424d/mountain-service/api/contracts/activity/v1"
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
 	"github.com/pd120424d/mountain-service/api/shared/firestorex"
 	"github.com/pd120424d/mountain-service/api/shared/models"
 	"github.com/pd120424d/mountain-service/api/shared/utils"
@@ -20,6 +21,7 @@ type FirebaseService interface {
 	GetActivitiesByUrgency(ctx context.Context, urgencyID uint) ([]*models.Activity, error)
 	GetAllActivities(ctx context.Context, limit int) ([]*models.Activity, error)
 	SyncActivity(ctx context.Context, eventData activityV1.ActivityEvent) error
+	SyncUrgency(ctx context.Context, event urgencyV1.UrgencyEvent) error
 	HealthCheck(ctx context.Context) error
 }
 
@@ -43,6 +45,8 @@ type FirebaseActivityDoc struct {
 	SyncedAt     time.Time `firestore:"synced_at"`
 	Version      int       `firestore:"version"`
 	LastEventAt  time.Time `firestore:"last_event_at"`
+	// UrgencyEventAt is when the urgency fields were last refreshed from the urgency event stream
+	UrgencyEventAt time.Time `firestore:"urgency_event_at"`
 }
 
 func NewFirebaseService(client firestorex.Client, logger utils.Logger) FirebaseService {
@@ -205,11 +209,22 @@ func (s *firebaseService) SyncActivity(ctx context.Context, eventData activityV1
 		// Drop non-idempotent counters and apply deterministic field updates only
 		updates := []firestorex.Update{
 			{Path: "description", Value: eventData.Description},
-			{Path: "employee_name", Value: eventData.EmployeeName},
-			{Path: "urgency_title", Value: eventData.UrgencyTitle},
-			{Path: "urgency_level", Value: eventData.UrgencyLevel},
 			{Path: "synced_at", Value: firestorex.ServerTimestamp()},
 		}
+		// Denormalized fields are only overwritten when the producer sent them, so a reassignment
+		// that only knows the new urgency does not blank the names
+		if eventData.EmployeeName != "" {
+			updates = append(updates, firestorex.Update{Path: "employee_name", Value: eventData.EmployeeName})
+		}
+		if eventData.UrgencyTitle != "" {
+			updates = append(updates, firestorex.Update{Path: "urgency_title", Value: eventData.UrgencyTitle})
+		}
+		if eventData.UrgencyLevel != "" {
+			updates = append(updates, firestorex.Update{Path: "urgency_level", Value: eventData.UrgencyLevel})
+		}
+		if eventData.UrgencyID != 0 {
+			updates = append(updates, firestorex.Update{Path: "urgency_id", Value: int64(eventData.UrgencyID)})
+		}
 		// If we have an event timestamp, use it to advance last_event_at, otherwise leave as-is
 		if !eventData.CreatedAt.IsZero() {
 			updates = append(updates, firestorex.Update{Path: "last_event_at", Value: eventData.CreatedAt.UTC()})
@@ -246,6 +261,70 @@ func (s *firebaseService) SyncActivity(ctx context.Context, eventData activityV1
 	return nil
 }
 
+// SyncUrgency refreshes the denormalized urgency fields of every activity of the urgency.
+// Activities that already reflect a newer urgency event are left alone, so redelivered or
+// reordered events cannot roll the fields back.
+func (s *firebaseService) SyncUrgency(ctx context.Context, event urgencyV1.UrgencyEvent) error {
+	if s.client == nil {
+		return fmt.Errorf("Firestore client is nil")
+	}
+	if event.UrgencyID == 0 {
+		return fmt.Errorf("invalid urgency id: 0")
+	}
+
+	log := s.logger.WithContext(ctx)
+	defer utils.TimeOperation(log, "FirebaseService.SyncUrgency", zap.Int("urgency_id", int(event.UrgencyID)), zap.String("type", string(event.Type)))()
+
+	if event.Type == urgencyV1.EventUrgencyDeleted {
+		// activities keep the last known urgency fields as a record of what they were logged against
+		log.Infof("Urgency %d was deleted; keeping its activities unchanged", event.UrgencyID)
+		return nil
+	}
+
+	iter := s.client.Collection(s.collection).
+		Where("urgency_id", "==", int64(event.UrgencyID)).
+		Documents(ctx)
+	defer iter.Stop()
+
+	occurredAt := event.OccurredAt.UTC()
+	updated := 0
+	for {
+		doc, err := iter.Next()
+		if isDone(err) {
+			break
+		}
+		if err != nil {
+			log.Errorf("Failed to iterate activities of urgency %d: %v", event.UrgencyID, err)
+			return fmt.Errorf("failed to get activities of urgency %d: %w", event.UrgencyID, err)
+		}
+
+		var cur FirebaseActivityDoc
+		if err := doc.DataTo(&cur); err == nil && !occurredAt.IsZero() && !cur.UrgencyEventAt.IsZero() && !occurredAt.After(cur.UrgencyEventAt) {
+			log.Infof("Ignoring stale %s for activity %s (incoming_at=%s <= urgency_event_at=%s)", event.Type, doc.ID(), occurredAt, cur.UrgencyEventAt.UTC())
+			continue
+		}
+
+		updates := []firestorex.Update{{Path: "synced_at", Value: firestorex.ServerTimestamp()}}
+		if event.Title != "" {
+			updates = append(updates, firestorex.Update{Path: "urgency_title", Value: event.Title})
+		}
+		if event.Level != "" {
+			updates = append(updates, firestorex.Update{Path: "urgency_level", Value: string(event.Level)})
+		}
+		if !occurredAt.IsZero() {
+			updates = append(updates, firestorex.Update{Path: "urgency_event_at", Value: occurredAt})
+		}
+		if _, err := s.client.Collection(s.collection).Doc(doc.ID()).Update(ctx, updates); err != nil {
+			log.Errorf("Failed to update urgency fields of activity %s: %v", doc.ID(), err)
+			return fmt.Errorf("failed to update activity %s: %w", doc.ID(), err)
+		}
+		updated++
+	}
+
+	log.Infof("Urgency %d synced to %d activities: type=%s", event.UrgencyID, updated, event.Type)
+	return nil
+}
+
 func (s *firebaseService) HealthCheck(ctx context.Context) error {
 	if s.client == nil {
 		return fmt.Errorf("failed to check health: Firestore client is nil")
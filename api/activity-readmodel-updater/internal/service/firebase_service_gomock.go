@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 
 	v1 "github.com/pd120424d/mountain-service/api/contracts/activity/v1"
+	v10 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
 	models "github.com/pd120424d/mountain-service/api/shared/models"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -99,3 +100,17 @@ func (mr *MockFirebaseServiceMockRecorder) SyncActivity(ctx, eventData any) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncActivity", reflect.TypeOf((*MockFirebaseService)(nil).SyncActivity), ctx, eventData)
 }
+
+// SyncUrgency mocks base method.
+func (m *MockFirebaseService) SyncUrgency(ctx context.Context, event v10.UrgencyEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncUrgency", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncUrgency indicates an expected call of SyncUrgency.
+func (mr *MockFirebaseServiceMockRecorder) SyncUrgency(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncUrgency", reflect.TypeOf((*MockFirebaseService)(nil).SyncUrgency), ctx, event)
+}
@@ -8,6 +8,7 @@ import (
 	"errors"
 
 	activityV1 "github.com/pd120424d/mountain-service/api/contracts/activity/v1"
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
 	"github.com/pd120424d/mountain-service/api/shared/firestoretest"
 	"github.com/pd120424d/mountain-service/api/shared/firestorex"
 	"github.com/pd120424d/mountain-service/api/shared/utils"
@@ -366,6 +367,27 @@ func TestFirebaseService_SyncActivity(t *testing.T) {
 		assert.Equal(t, "New", items[0].Description)
 	})
 
+	t.Run("it moves the document to another urgency on UPDATE", func(t *testing.T) {
+		ctx := context.Background()
+		fake := firestoretest.NewFake().WithCollection("activities", nil)
+		svc := NewFirebaseService(fake, logger)
+
+		create := activityV1.ActivityEvent{Type: "CREATE", ActivityID: 13, UrgencyID: 6, EmployeeID: 9, Description: "Found", EmployeeName: "Marko Markovic", CreatedAt: time.Now()}
+		assert.NoError(t, svc.SyncActivity(ctx, create))
+
+		move := activityV1.ActivityEvent{Type: "UPDATE", ActivityID: 13, UrgencyID: 8, EmployeeID: 9, Description: "Found", CreatedAt: time.Now().Add(time.Second)}
+		assert.NoError(t, svc.SyncActivity(ctx, move))
+
+		items, err := svc.GetActivitiesByUrgency(ctx, 8)
+		assert.NoError(t, err)
+		if assert.Len(t, items, 1) {
+			assert.Equal(t, uint(13), items[0].ID)
+		}
+		items, err = svc.GetActivitiesByUrgency(ctx, 6)
+		assert.NoError(t, err)
+		assert.Empty(t, items)
+	})
+
 	t.Run("it succeeds when SyncActivity DELETE removes document", func(t *testing.T) {
 		ctx := context.Background()
 		fake := firestoretest.NewFake().WithCollection("activities", nil)
@@ -657,3 +679,65 @@ func TestFirebaseService_HealthCheck(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestFirebaseService_SyncUrgency(t *testing.T) {
+	t.Parallel()
+	logger := utils.NewTestLogger()
+	ctx := context.Background()
+	occurredAt := time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC)
+
+	newFake := func() *firestoretest.Fake {
+		return firestoretest.NewFake().WithCollection("activities", []map[string]interface{}{
+			{"id": int64(1), "urgency_id": int64(2), "urgency_title": "Old Title", "urgency_level": "low"},
+			{"id": int64(2), "urgency_id": int64(3), "urgency_title": "Other", "urgency_level": "low"},
+			{"id": int64(3), "urgency_id": int64(2), "urgency_title": "Old Title", "urgency_level": "low", "urgency_event_at": occurredAt.Add(time.Hour)},
+		})
+	}
+	loadDoc := func(t *testing.T, fake *firestoretest.Fake, id string) FirebaseActivityDoc {
+		snap, err := fake.Collection("activities").Doc(id).Get(ctx)
+		if err != nil {
+			t.Fatalf("doc %s: %v", id, err)
+		}
+		var doc FirebaseActivityDoc
+		if err := snap.DataTo(&doc); err != nil {
+			t.Fatalf("doc %s: %v", id, err)
+		}
+		return doc
+	}
+
+	t.Run("it refreshes the urgency fields of the urgency's activities", func(t *testing.T) {
+		fake := newFake()
+		svc := NewFirebaseService(fake, logger)
+
+		err := svc.SyncUrgency(ctx, urgencyV1.UrgencyEvent{Type: urgencyV1.EventUrgencyUpdated, UrgencyID: 2, Title: "Marko Markovic", Level: urgencyV1.Critical, OccurredAt: occurredAt})
+		assert.NoError(t, err)
+
+		updated := loadDoc(t, fake, "1")
+		assert.Equal(t, "Marko Markovic", updated.UrgencyTitle)
+		assert.Equal(t, "critical", updated.UrgencyLevel)
+		assert.True(t, occurredAt.Equal(updated.UrgencyEventAt))
+		// another urgency's activity is untouched
+		assert.Equal(t, "Other", loadDoc(t, fake, "2").UrgencyTitle)
+		// an activity that already saw a newer urgency event is not rolled back
+		assert.Equal(t, "Old Title", loadDoc(t, fake, "3").UrgencyTitle)
+	})
+
+	t.Run("it keeps activities of a deleted urgency", func(t *testing.T) {
+		fake := newFake()
+		svc := NewFirebaseService(fake, logger)
+
+		err := svc.SyncUrgency(ctx, urgencyV1.UrgencyEvent{Type: urgencyV1.EventUrgencyDeleted, UrgencyID: 2, Level: urgencyV1.High, OccurredAt: occurredAt})
+		assert.NoError(t, err)
+		assert.Equal(t, "low", loadDoc(t, fake, "1").UrgencyLevel)
+	})
+
+	t.Run("it rejects events without an urgency id", func(t *testing.T) {
+		svc := NewFirebaseService(newFake(), logger)
+		assert.Error(t, svc.SyncUrgency(ctx, urgencyV1.UrgencyEvent{Type: urgencyV1.EventUrgencyUpdated}))
+	})
+
+	t.Run("it returns error when iterator fails", func(t *testing.T) {
+		svc := NewFirebaseService(&clientIterErr{}, logger)
+		assert.Error(t, svc.SyncUrgency(ctx, urgencyV1.UrgencyEvent{Type: urgencyV1.EventUrgencyUpdated, UrgencyID: 2}))
+	})
+}
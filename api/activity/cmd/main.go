@@ -8,18 +8,17 @@ import (
 	"time"
 
 	"cloud.google.com/go/firestore"
-	"cloud.google.com/go/pubsub"
 	_ "github.com/pd120424d/mountain-service/api/activity/cmd/docs"
 	"github.com/pd120424d/mountain-service/api/activity/internal/handler"
 	"github.com/pd120424d/mountain-service/api/activity/internal/middleware"
 	"github.com/pd120424d/mountain-service/api/activity/internal/model"
-	"github.com/pd120424d/mountain-service/api/activity/internal/publisher"
 	"github.com/pd120424d/mountain-service/api/activity/internal/repositories"
 	"github.com/pd120424d/mountain-service/api/activity/internal/service"
 	"github.com/pd120424d/mountain-service/api/shared/auth"
 	globConf "github.com/pd120424d/mountain-service/api/shared/config"
 	"github.com/pd120424d/mountain-service/api/shared/firestorex/googleadapter"
 	"github.com/pd120424d/mountain-service/api/shared/models"
+	"github.com/pd120424d/mountain-service/api/shared/outbox"
 	"github.com/pd120424d/mountain-service/api/shared/server"
 
 	s2semployee "github.com/pd120424d/mountain-service/api/shared/s2s/employee"
@@ -88,39 +87,15 @@ func main() {
 
 func startPublisherIfConfigured(log utils.Logger, db *gorm.DB) {
 	// Build Pub/Sub client if GOOGLE_APPLICATION_CREDENTIALS/FIREBASE creds are available
-	projectID := os.Getenv("FIREBASE_PROJECT_ID")
-	if projectID == "" {
-		projectID = os.Getenv("GOOGLE_CLOUD_PROJECT")
-	}
-	if projectID == "" {
-		log.Warn("Pub/Sub publisher disabled: no project ID in env")
-		return
-	}
-
-	var client *pubsub.Client
-	var err error
-	credsPath := os.Getenv("FIREBASE_CREDENTIALS_PATH")
-	if credsPath == "" {
-		credsPath = os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
-	}
-	useADC := false
-	if credsPath != "" {
-		if info, statErr := os.Stat(credsPath); statErr != nil || info.Size() == 0 {
-			log.Warnf("Credentials path set but file missing/empty (path=%s). Falling back to ADC.", credsPath)
-			useADC = true
-		}
-	}
-	if credsPath != "" && !useADC {
-		log.Infof("Initializing Pub/Sub client with credentials file: %s", credsPath)
-		client, err = pubsub.NewClient(context.Background(), projectID, option.WithCredentialsFile(credsPath))
-	} else {
-		log.Info("Initializing Pub/Sub client using Application Default Credentials (ADC)")
-		client, err = pubsub.NewClient(context.Background(), projectID)
-	}
+	client, err := outbox.NewPubSubClientFromEnv(context.Background(), log)
 	if err != nil {
 		log.Errorf("Failed to create Pub/Sub client: %v", err)
 		return
 	}
+	if client == nil {
+		log.Warn("Pub/Sub publisher disabled: no project ID in env")
+		return
+	}
 
 	repo := repositories.NewOutboxRepository(log, db)
 	topic := os.Getenv("PUBSUB_TOPIC")
@@ -142,7 +117,8 @@ func startPublisherIfConfigured(log utils.Logger, db *gorm.DB) {
 		}
 	}
 
-	pub := publisher.New(log, repo, client, publisher.Config{TopicName: topic, Interval: time.Duration(intervalSec) * time.Second, BatchSize: batchSize})
+	pub := outbox.NewPublisher(log, repo, outbox.Config{Name: topic, Interval: time.Duration(intervalSec) * time.Second, BatchSize: batchSize},
+		outbox.NewPubSubSink(log, client, topic))
 	ctx, _ := context.WithCancel(context.Background())
 	pub.Start(ctx)
 }
@@ -265,6 +241,7 @@ func setupRoutes(log utils.Logger, r *gin.Engine, db *gorm.DB) {
 	{
 		serviceGroup.POST("/activities", activityHandler.CreateActivity)
 		serviceGroup.GET("/activities", activityHandler.ListActivities)
+		serviceGroup.PUT("/activities/reassign", activityHandler.ReassignActivities)
 	}
 
 	// Admin-only routes
@@ -33,6 +33,9 @@ type ActivityHandler interface {
 	DeleteActivity(ctx *gin.Context)
 	ResetAllData(ctx *gin.Context)
 
+	// Service-to-service endpoints
+	ReassignActivities(ctx *gin.Context)
+
 	// Admin-only endpoints
 	AddActivitiesBatch(ctx *gin.Context)
 	GetActivitySourceFlag(ctx *gin.Context)
@@ -667,6 +670,37 @@ func (h *activityHandler) DeleteActivity(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"message": "Activity deleted successfully"})
 }
 
+// ReassignActivities moves activities between urgencies when the urgency service merges duplicates.
+// It is only exposed on the service-to-service route group.
+func (h *activityHandler) ReassignActivities(ctx *gin.Context) {
+	log := h.log.WithContext(ctx.Request.Context())
+	defer utils.TimeOperation(log, "ActivityHandler.ReassignActivities")()
+	log.Info("Received Reassign Activities request")
+
+	var req activityV1.ActivityReassignRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorf("Failed to bind request: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	if err := req.Validate(); err != nil {
+		log.Errorf("validation failed: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.svc.ReassignUrgency(ctx.Request.Context(), &req)
+	if err != nil {
+		log.Errorf("Failed to reassign activities: %v", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reassign activities", "details": err.Error()})
+		return
+	}
+
+	utils.WriteFreshWindow(ctx, config.DefaultFreshWindow)
+
+	ctx.JSON(http.StatusOK, response)
+}
+
 // ResetAllData Ресетовање свих података о активностима
 // @Summary Ресетовање свих података о активностима
 // @Description Брисање свих активности из система
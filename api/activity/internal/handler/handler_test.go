@@ -472,6 +472,44 @@ func TestActivityHandler_DeleteActivity(t *testing.T) {
 	})
 }
 
+func TestActivityHandler_ReassignActivities(t *testing.T) {
+	t.Parallel()
+	log := utils.NewTestLogger()
+
+	newCtx := func(body string) (*gin.Context, *httptest.ResponseRecorder) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest(http.MethodPut, "/service/activities/reassign", strings.NewReader(body))
+		ctx.Request.Header.Set("Content-Type", "application/json")
+		return ctx, w
+	}
+
+	t.Run("same urgency -> 400", func(t *testing.T) {
+		ctx, w := newCtx(`{"fromUrgencyId":2,"toUrgencyId":2}`)
+		newTestHandler(log, nil, nil, nil).ReassignActivities(ctx)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("service error -> 500", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx(`{"fromUrgencyId":2,"toUrgencyId":1}`)
+		svcMock := service.NewMockActivityService(ctrl)
+		svcMock.EXPECT().ReassignUrgency(gomock.Any(), &activityV1.ActivityReassignRequest{FromUrgencyID: 2, ToUrgencyID: 1}).Return(nil, fmt.Errorf("db down"))
+		newTestHandler(log, svcMock, nil, nil).ReassignActivities(ctx)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("success -> 200", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx(`{"fromUrgencyId":2,"toUrgencyId":1}`)
+		svcMock := service.NewMockActivityService(ctrl)
+		svcMock.EXPECT().ReassignUrgency(gomock.Any(), gomock.Any()).Return(&activityV1.ActivityReassignResponse{Moved: 3}, nil)
+		newTestHandler(log, svcMock, nil, nil).ReassignActivities(ctx)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"moved":3}`, w.Body.String())
+	})
+}
+
 func TestActivityHandler_ResetAllData(t *testing.T) {
 	t.Parallel()
 	log := utils.NewTestLogger()
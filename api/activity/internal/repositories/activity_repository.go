@@ -6,6 +6,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/pd120424d/mountain-service/api/activity/internal/model"
 	activityV1 "github.com/pd120424d/mountain-service/api/contracts/activity/v1"
@@ -18,6 +19,7 @@ type ActivityRepository interface {
 	Create(ctx context.Context, activity *model.Activity) error
 	CreateWithOutbox(ctx context.Context, activity *model.Activity, event *models.OutboxEvent) error
 	CreateBatchWithOutbox(ctx context.Context, activities []*model.Activity, events []*models.OutboxEvent) error
+	ReassignUrgencyWithOutbox(ctx context.Context, fromUrgencyID, toUrgencyID uint, at time.Time) (int, error)
 	GetByID(ctx context.Context, id uint) (*model.Activity, error)
 	List(ctx context.Context, filter *model.ActivityFilter) ([]model.Activity, int64, error)
 	Delete(ctx context.Context, id uint) error
@@ -122,6 +124,51 @@ func (r *activityRepository) CreateBatchWithOutbox(ctx context.Context, activiti
 	})
 }
 
+// ReassignUrgencyWithOutbox moves all activities of one urgency onto another and emits an UPDATE outbox
+// event per moved activity so the read model follows, all in a single transaction.
+func (r *activityRepository) ReassignUrgencyWithOutbox(ctx context.Context, fromUrgencyID, toUrgencyID uint, at time.Time) (int, error) {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "ActivityRepository.ReassignUrgencyWithOutbox")()
+	log.Infof("Reassigning activities from urgency %d to urgency %d", fromUrgencyID, toUrgencyID)
+
+	var moved int
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var activities []model.Activity
+		if err := tx.Where("urgency_id = ?", fromUrgencyID).Find(&activities).Error; err != nil {
+			return fmt.Errorf("failed to load activities: %w", err)
+		}
+		if len(activities) == 0 {
+			return nil
+		}
+		if err := tx.Model(&model.Activity{}).Where("urgency_id = ?", fromUrgencyID).Update("urgency_id", toUrgencyID).Error; err != nil {
+			return fmt.Errorf("failed to reassign activities: %w", err)
+		}
+		events := make([]*models.OutboxEvent, 0, len(activities))
+		for _, a := range activities {
+			events = append(events, (*models.OutboxEvent)(activityV1.CreateOutboxEvent(a.ID, activityV1.ActivityEvent{
+				Type:        "UPDATE",
+				ActivityID:  a.ID,
+				UrgencyID:   toUrgencyID,
+				EmployeeID:  a.EmployeeID,
+				Description: a.Description,
+				CreatedAt:   at,
+			})))
+		}
+		if err := tx.CreateInBatches(events, len(events)).Error; err != nil {
+			return fmt.Errorf("failed to create outbox events: %w", err)
+		}
+		moved = len(activities)
+		return nil
+	})
+	if err != nil {
+		log.Errorf("Failed to reassign activities: %v", err)
+		return 0, err
+	}
+
+	log.Infof("Reassigned %d activities from urgency %d to urgency %d", moved, fromUrgencyID, toUrgencyID)
+	return moved, nil
+}
+
 func (r *activityRepository) GetByID(ctx context.Context, id uint) (*model.Activity, error) {
 	log := r.log.WithContext(ctx)
 	defer utils.TimeOperation(log, "ActivityRepository.GetByID")()
@@ -12,6 +12,7 @@ package repositories
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	model "github.com/pd120424d/mountain-service/api/activity/internal/model"
 	models "github.com/pd120424d/mountain-service/api/shared/models"
@@ -56,32 +57,32 @@ func (mr *MockActivityRepositoryMockRecorder) Create(ctx, activity any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockActivityRepository)(nil).Create), ctx, activity)
 }
 
-// CreateWithOutbox mocks base method.
-func (m *MockActivityRepository) CreateWithOutbox(ctx context.Context, activity *model.Activity, event *models.OutboxEvent) error {
+// CreateBatchWithOutbox mocks base method.
+func (m *MockActivityRepository) CreateBatchWithOutbox(ctx context.Context, activities []*model.Activity, events []*models.OutboxEvent) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateWithOutbox", ctx, activity, event)
+	ret := m.ctrl.Call(m, "CreateBatchWithOutbox", ctx, activities, events)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CreateWithOutbox indicates an expected call of CreateWithOutbox.
-func (mr *MockActivityRepositoryMockRecorder) CreateWithOutbox(ctx, activity, event any) *gomock.Call {
+// CreateBatchWithOutbox indicates an expected call of CreateBatchWithOutbox.
+func (mr *MockActivityRepositoryMockRecorder) CreateBatchWithOutbox(ctx, activities, events any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithOutbox", reflect.TypeOf((*MockActivityRepository)(nil).CreateWithOutbox), ctx, activity, event)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatchWithOutbox", reflect.TypeOf((*MockActivityRepository)(nil).CreateBatchWithOutbox), ctx, activities, events)
 }
 
-// CreateBatchWithOutbox mocks base method.
-func (m *MockActivityRepository) CreateBatchWithOutbox(ctx context.Context, activities []*model.Activity, events []*models.OutboxEvent) error {
+// CreateWithOutbox mocks base method.
+func (m *MockActivityRepository) CreateWithOutbox(ctx context.Context, activity *model.Activity, event *models.OutboxEvent) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateBatchWithOutbox", ctx, activities, events)
+	ret := m.ctrl.Call(m, "CreateWithOutbox", ctx, activity, event)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CreateBatchWithOutbox indicates an expected call of CreateBatchWithOutbox.
-func (mr *MockActivityRepositoryMockRecorder) CreateBatchWithOutbox(ctx, activities, events any) *gomock.Call {
+// CreateWithOutbox indicates an expected call of CreateWithOutbox.
+func (mr *MockActivityRepositoryMockRecorder) CreateWithOutbox(ctx, activity, event any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatchWithOutbox", reflect.TypeOf((*MockActivityRepository)(nil).CreateBatchWithOutbox), ctx, activities, events)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithOutbox", reflect.TypeOf((*MockActivityRepository)(nil).CreateWithOutbox), ctx, activity, event)
 }
 
 // Delete mocks base method.
@@ -129,6 +130,21 @@ func (mr *MockActivityRepositoryMockRecorder) List(ctx, filter any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockActivityRepository)(nil).List), ctx, filter)
 }
 
+// ReassignUrgencyWithOutbox mocks base method.
+func (m *MockActivityRepository) ReassignUrgencyWithOutbox(ctx context.Context, fromUrgencyID, toUrgencyID uint, at time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReassignUrgencyWithOutbox", ctx, fromUrgencyID, toUrgencyID, at)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReassignUrgencyWithOutbox indicates an expected call of ReassignUrgencyWithOutbox.
+func (mr *MockActivityRepositoryMockRecorder) ReassignUrgencyWithOutbox(ctx, fromUrgencyID, toUrgencyID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignUrgencyWithOutbox", reflect.TypeOf((*MockActivityRepository)(nil).ReassignUrgencyWithOutbox), ctx, fromUrgencyID, toUrgencyID, at)
+}
+
 // ResetAllData mocks base method.
 func (m *MockActivityRepository) ResetAllData(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -170,6 +170,42 @@ func TestActivityRepository_CreateWithOutbox(t *testing.T) {
 
 }
 
+func TestActivityRepository_ReassignUrgencyWithOutbox(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it moves activities and writes an update event for each", func(t *testing.T) {
+		db := setupActivityTestDB(t)
+		repo := NewActivityRepository(utils.NewTestLogger(), db)
+		require.NoError(t, repo.Create(t.Context(), &model.Activity{Description: "a", EmployeeID: 1, UrgencyID: 2}))
+		require.NoError(t, repo.Create(t.Context(), &model.Activity{Description: "b", EmployeeID: 1, UrgencyID: 2}))
+		require.NoError(t, repo.Create(t.Context(), &model.Activity{Description: "c", EmployeeID: 1, UrgencyID: 3}))
+
+		moved, err := repo.ReassignUrgencyWithOutbox(t.Context(), 2, 1, time.Now())
+		assert.NoError(t, err)
+		assert.Equal(t, 2, moved)
+
+		var count int64
+		require.NoError(t, db.Model(&model.Activity{}).Where("urgency_id = ?", 1).Count(&count).Error)
+		assert.Equal(t, int64(2), count)
+
+		var events []models.OutboxEvent
+		require.NoError(t, db.Find(&events).Error)
+		if assert.Len(t, events, 2) {
+			assert.Contains(t, events[0].EventData, `"type":"UPDATE"`)
+			assert.Contains(t, events[0].EventData, `"urgencyId":1`)
+		}
+	})
+
+	t.Run("it does nothing when the urgency has no activities", func(t *testing.T) {
+		db := setupActivityTestDB(t)
+		repo := NewActivityRepository(utils.NewTestLogger(), db)
+
+		moved, err := repo.ReassignUrgencyWithOutbox(t.Context(), 2, 1, time.Now())
+		assert.NoError(t, err)
+		assert.Zero(t, moved)
+	})
+}
+
 func TestActivityRepository_GetByID(t *testing.T) {
 	t.Parallel()
 
@@ -24,6 +24,7 @@ type ActivityService interface {
 	GetActivityByID(ctx context.Context, id uint) (*activityV1.ActivityResponse, error)
 	ListActivities(ctx context.Context, req *activityV1.ActivityListRequest) (*activityV1.ActivityListResponse, error)
 	DeleteActivity(ctx context.Context, id uint) error
+	ReassignUrgency(ctx context.Context, req *activityV1.ActivityReassignRequest) (*activityV1.ActivityReassignResponse, error)
 	ResetAllData(ctx context.Context) error
 
 	LogActivity(ctx context.Context, description string, employeeID, urgencyID uint) error
@@ -386,6 +387,30 @@ func (s *activityService) ListActivities(ctx context.Context, req *activityV1.Ac
 	return response, nil
 }
 
+// ReassignUrgency moves all activities of a merged urgency onto the surviving one.
+// It is idempotent: calling it again after a successful move reports zero moved activities.
+func (s *activityService) ReassignUrgency(ctx context.Context, req *activityV1.ActivityReassignRequest) (*activityV1.ActivityReassignResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "ActivityService.ReassignUrgency")()
+
+	if req == nil {
+		return nil, commonv1.NewAppError("VALIDATION.INVALID_REQUEST", "request cannot be nil", nil)
+	}
+	if err := req.Validate(); err != nil {
+		log.Errorf("Activity reassign validation failed: %v", err)
+		return nil, commonv1.NewAppError("VALIDATION.INVALID_REQUEST", fmt.Sprintf("validation failed: %v", err), nil)
+	}
+
+	moved, err := s.repo.ReassignUrgencyWithOutbox(ctx, req.FromUrgencyID, req.ToUrgencyID, time.Now().UTC())
+	if err != nil {
+		log.Errorf("Failed to reassign activities: %v", err)
+		return nil, commonv1.NewAppError("ACTIVITY_ERRORS.REASSIGN_FAILED", "failed to reassign activities", map[string]interface{}{"cause": err.Error()})
+	}
+
+	log.Infof("Reassigned %d activities from urgency %d to urgency %d", moved, req.FromUrgencyID, req.ToUrgencyID)
+	return &activityV1.ActivityReassignResponse{Moved: moved}, nil
+}
+
 func (s *activityService) ResetAllData(ctx context.Context) error {
 	log := s.log.WithContext(ctx)
 	defer utils.TimeOperation(log, "ActivityService.ResetAllData")()
@@ -41,15 +41,6 @@ func (m *MockActivityService) EXPECT() *MockActivityServiceMockRecorder {
 	return m.recorder
 }
 
-// CreateActivity mocks base method.
-func (m *MockActivityService) CreateActivity(ctx context.Context, req *v1.ActivityCreateRequest) (*v1.ActivityResponse, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateActivity", ctx, req)
-	ret0, _ := ret[0].(*v1.ActivityResponse)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
 // CreateActivitiesBatch mocks base method.
 func (m *MockActivityService) CreateActivitiesBatch(ctx context.Context, items []v1.ActivityCreateRequest) ([]v1.BatchAddResult, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +56,15 @@ func (mr *MockActivityServiceMockRecorder) CreateActivitiesBatch(ctx, items any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateActivitiesBatch", reflect.TypeOf((*MockActivityService)(nil).CreateActivitiesBatch), ctx, items)
 }
 
+// CreateActivity mocks base method.
+func (m *MockActivityService) CreateActivity(ctx context.Context, req *v1.ActivityCreateRequest) (*v1.ActivityResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateActivity", ctx, req)
+	ret0, _ := ret[0].(*v1.ActivityResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // CreateActivity indicates an expected call of CreateActivity.
 func (mr *MockActivityServiceMockRecorder) CreateActivity(ctx, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
@@ -129,6 +129,21 @@ func (mr *MockActivityServiceMockRecorder) LogActivity(ctx, description, employe
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogActivity", reflect.TypeOf((*MockActivityService)(nil).LogActivity), ctx, description, employeeID, urgencyID)
 }
 
+// ReassignUrgency mocks base method.
+func (m *MockActivityService) ReassignUrgency(ctx context.Context, req *v1.ActivityReassignRequest) (*v1.ActivityReassignResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReassignUrgency", ctx, req)
+	ret0, _ := ret[0].(*v1.ActivityReassignResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReassignUrgency indicates an expected call of ReassignUrgency.
+func (mr *MockActivityServiceMockRecorder) ReassignUrgency(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignUrgency", reflect.TypeOf((*MockActivityService)(nil).ReassignUrgency), ctx, req)
+}
+
 // ResetAllData mocks base method.
 func (m *MockActivityService) ResetAllData(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -882,6 +882,39 @@ func TestActivityService_DeleteActivity(t *testing.T) {
 	})
 }
 
+func TestActivityService_ReassignUrgency(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it returns validation error for invalid request", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		service := NewActivityService(utils.NewTestLogger(), repositories.NewMockActivityRepository(ctrl), nil)
+
+		_, err := service.ReassignUrgency(t.Context(), &activityV1.ActivityReassignRequest{FromUrgencyID: 1, ToUrgencyID: 1})
+		assert.ErrorContains(t, err, "validation failed")
+	})
+
+	t.Run("it reports how many activities were moved", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repositories.NewMockActivityRepository(ctrl)
+		service := NewActivityService(utils.NewTestLogger(), mockRepo, nil)
+		mockRepo.EXPECT().ReassignUrgencyWithOutbox(gomock.Any(), uint(2), uint(1), gomock.Any()).Return(4, nil)
+
+		resp, err := service.ReassignUrgency(t.Context(), &activityV1.ActivityReassignRequest{FromUrgencyID: 2, ToUrgencyID: 1})
+		assert.NoError(t, err)
+		assert.Equal(t, 4, resp.Moved)
+	})
+
+	t.Run("it returns error when repository fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repositories.NewMockActivityRepository(ctrl)
+		service := NewActivityService(utils.NewTestLogger(), mockRepo, nil)
+		mockRepo.EXPECT().ReassignUrgencyWithOutbox(gomock.Any(), uint(2), uint(1), gomock.Any()).Return(0, assert.AnError)
+
+		_, err := service.ReassignUrgency(t.Context(), &activityV1.ActivityReassignRequest{FromUrgencyID: 2, ToUrgencyID: 1})
+		assert.ErrorContains(t, err, "failed to reassign activities")
+	})
+}
+
 func TestActivityService_ResetAllData(t *testing.T) {
 	t.Parallel()
 
@@ -61,6 +61,19 @@ type BatchAddActivitiesResponse struct {
 	Results []BatchAddResult `json:"results"`
 }
 
+// ActivityReassignRequest moves every activity of one urgency onto another (used when urgencies are merged)
+// swagger:model
+type ActivityReassignRequest struct {
+	FromUrgencyID uint `json:"fromUrgencyId" binding:"required"`
+	ToUrgencyID   uint `json:"toUrgencyId" binding:"required"`
+}
+
+// ActivityReassignResponse reports how many activities were moved
+// swagger:model
+type ActivityReassignResponse struct {
+	Moved int `json:"moved"`
+}
+
 // ActivityListRequest DTO for listing activities with filters
 // swagger:model
 type ActivityListRequest struct {
@@ -134,6 +147,25 @@ func (r *ActivityCreateRequest) Validate() error {
 	return nil
 }
 
+func (r *ActivityReassignRequest) Validate() error {
+	var errors validation.ValidationErrors
+
+	if r.FromUrgencyID == 0 {
+		errors.Add("fromUrgencyId", "fromUrgencyId is required and must be greater than 0")
+	}
+	if r.ToUrgencyID == 0 {
+		errors.Add("toUrgencyId", "toUrgencyId is required and must be greater than 0")
+	}
+	if r.FromUrgencyID != 0 && r.FromUrgencyID == r.ToUrgencyID {
+		errors.Add("toUrgencyId", "toUrgencyId must differ from fromUrgencyId")
+	}
+
+	if errors.HasErrors() {
+		return errors
+	}
+	return nil
+}
+
 func (r *ActivityListRequest) Validate() error {
 	if r.Page < 0 {
 		return fmt.Errorf("page must be non-negative")
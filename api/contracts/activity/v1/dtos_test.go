@@ -41,6 +41,28 @@ func TestActivityCreateRequest_Validate(t *testing.T) {
 	})
 }
 
+func TestActivityReassignRequest_Validate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it returns no error for a valid request", func(t *testing.T) {
+		req := &ActivityReassignRequest{FromUrgencyID: 2, ToUrgencyID: 1}
+		assert.NoError(t, req.Validate())
+	})
+
+	t.Run("it returns an error for missing urgency IDs", func(t *testing.T) {
+		req := &ActivityReassignRequest{}
+		err := req.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "fromUrgencyId is required")
+		assert.Contains(t, err.Error(), "toUrgencyId is required")
+	})
+
+	t.Run("it returns an error when both urgencies are the same", func(t *testing.T) {
+		req := &ActivityReassignRequest{FromUrgencyID: 3, ToUrgencyID: 3}
+		assert.ErrorContains(t, req.Validate(), "must differ")
+	})
+}
+
 func TestActivityListRequest_Validate(t *testing.T) {
 	t.Parallel()
 
@@ -41,6 +41,111 @@ type EmployeeResponse struct {
 	// this may be represented as a byte array if we read the picture from somewhere for an example
 	ProfilePicture string `json:"profilePicture"`
 	ProfileType    string `json:"profileType"`
+	// Last position reported by the employee's device, used to rank responders by distance
+	LastLatitude   *float64 `json:"lastLatitude,omitempty"`
+	LastLongitude  *float64 `json:"lastLongitude,omitempty"`
+	LastPositionAt string   `json:"lastPositionAt,omitempty"`
+	// Locale used for notifications sent to the employee; empty when no preference was set
+	PreferredLanguage string `json:"preferredLanguage,omitempty" example:"sr-cyr"`
+	// Channels and quiet hours the urgency service honors when notifying the employee
+	NotificationPreferences *NotificationPreferences `json:"notificationPreferences,omitempty"`
+	// Set by the on-call lookup for employees whose shift is running now; quiet hours do not apply to them
+	OnCall bool `json:"onCall,omitempty"`
+}
+
+// NotificationPreferences DTO describing how an employee wants to be notified about urgencies
+// swagger:model
+type NotificationPreferences struct {
+	SMS   ChannelPreference `json:"sms"`
+	Email ChannelPreference `json:"email"`
+	// Optional; nil means no quiet hours
+	QuietHours *QuietHours `json:"quietHours,omitempty"`
+}
+
+// ChannelPreference DTO for a single notification channel
+// swagger:model
+type ChannelPreference struct {
+	Enabled bool `json:"enabled"`
+	// Lowest urgency level (low, medium, high, critical) sent over the channel; empty means all levels
+	MinLevel string `json:"minLevel,omitempty" example:"medium"`
+}
+
+// QuietHours DTO for the daily window, in UTC "HH:MM", during which off-shift notifications are suppressed.
+// A window whose end is before its start wraps past midnight.
+// swagger:model
+type QuietHours struct {
+	Start string `json:"start" example:"22:00"`
+	End   string `json:"end" example:"07:00"`
+}
+
+var notificationLevels = []string{"low", "medium", "high", "critical"}
+
+// Validate validates the NotificationPreferences
+func (p *NotificationPreferences) Validate() error {
+	var errors validation.ValidationErrors
+
+	if err := validateMinLevel(p.SMS.MinLevel); err != nil {
+		errors.AddError("notificationPreferences.sms.minLevel", err)
+	}
+	if err := validateMinLevel(p.Email.MinLevel); err != nil {
+		errors.AddError("notificationPreferences.email.minLevel", err)
+	}
+	if p.QuietHours != nil {
+		if _, err := parseClock(p.QuietHours.Start); err != nil {
+			errors.AddError("notificationPreferences.quietHours.start", err)
+		}
+		if _, err := parseClock(p.QuietHours.End); err != nil {
+			errors.AddError("notificationPreferences.quietHours.end", err)
+		}
+	}
+
+	if errors.HasErrors() {
+		return errors
+	}
+	return nil
+}
+
+// Contains reports whether t falls inside the quiet hours window; an empty window never matches
+func (q *QuietHours) Contains(t time.Time) bool {
+	if q == nil {
+		return false
+	}
+	start, err := parseClock(q.Start)
+	if err != nil {
+		return false
+	}
+	end, err := parseClock(q.End)
+	if err != nil || start == end {
+		return false
+	}
+
+	t = t.UTC()
+	minute := t.Hour()*60 + t.Minute()
+	if start < end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
+
+func validateMinLevel(level string) error {
+	if level == "" {
+		return nil
+	}
+	for _, v := range notificationLevels {
+		if level == v {
+			return nil
+		}
+	}
+	return fmt.Errorf("level must be one of: low, medium, high, critical")
+}
+
+// parseClock converts "HH:MM" to minutes since midnight
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("time must be in HH:MM format")
+	}
+	return t.Hour()*60 + t.Minute(), nil
 }
 
 // EmployeeCreateRequest DTO for creating a new employee
@@ -55,6 +160,8 @@ type EmployeeCreateRequest struct {
 	Phone     string `json:"phone" binding:"required"`
 
 	ProfileType string `json:"profileType" binding:"required"`
+	// Optional; one of sr-cyr, sr-lat, en, ru
+	PreferredLanguage string `json:"preferredLanguage,omitempty"`
 }
 
 // Validate validates the EmployeeCreateRequest
@@ -93,6 +200,10 @@ func (r *EmployeeCreateRequest) Validate() error {
 		errors.AddError("profileType", err)
 	}
 
+	if err := utils.ValidateOptionalLocale(r.PreferredLanguage); err != nil {
+		errors.AddError("preferredLanguage", err)
+	}
+
 	if errors.HasErrors() {
 		return errors
 	}
@@ -120,6 +231,10 @@ type EmployeeUpdateRequest struct {
 	Phone          string `json:"phone"`
 	ProfilePicture string `json:"profilePicture"`
 	ProfileType    string `json:"profileType"`
+	// Optional; one of sr-cyr, sr-lat, en, ru
+	PreferredLanguage string `json:"preferredLanguage,omitempty"`
+	// Optional; replaces the stored preferences when present
+	NotificationPreferences *NotificationPreferences `json:"notificationPreferences,omitempty"`
 }
 
 // ShiftResponse DTO for returning shift data for a certain employee
@@ -177,6 +292,242 @@ type ShiftAvailability struct {
 	IsFullyBooked           bool `json:"isFullyBooked"`           // Whether the shift is at full capacity (2 medics + 4 technicians)
 }
 
+// AdminShiftAvailabilityResponse DTO for returning who works each shift of the requested days
+// swagger:model
+type AdminShiftAvailabilityResponse struct {
+	Days map[time.Time]AdminShiftAvailabilityPerDay `json:"days"`
+}
+
+// AdminShiftAvailabilityPerDay DTO for returning the rosters of the shifts of a certain day
+// swagger:model
+type AdminShiftAvailabilityPerDay struct {
+	FirstShift  AdminShiftAvailability `json:"firstShift"`
+	SecondShift AdminShiftAvailability `json:"secondShift"`
+	ThirdShift  AdminShiftAvailability `json:"thirdShift"`
+}
+
+// AdminShiftAvailability DTO for the roster of a certain shift. Employees, capacities and understaffing
+// are keyed by profile type and only list the requested profiles.
+// swagger:model
+type AdminShiftAvailability struct {
+	ShiftAvailability
+	StartTime            string                        `json:"startTime" example:"06:00"`
+	EndTime              string                        `json:"endTime" example:"14:00"`
+	Employees            map[string][]ShiftRosterEntry `json:"employees"`
+	Capacity             map[string]int                `json:"capacity"`
+	UnderstaffedProfiles []string                      `json:"understaffedProfiles"` // Profiles with fewer staff present than the capacity
+	IsUnderstaffed       bool                          `json:"isUnderstaffed"`
+}
+
+// ShiftRosterEntry DTO for an employee assigned to a shift
+// swagger:model
+type ShiftRosterEntry struct {
+	EmployeeID uint   `json:"employeeId"`
+	Name       string `json:"name"`
+	Username   string `json:"username"`
+	OnLeave    bool   `json:"onLeave"` // Approved leave that day; the employee does not count towards the staffing
+}
+
+// ShiftSwapStatus is the lifecycle state of a shift swap
+type ShiftSwapStatus string
+
+const (
+	// ShiftSwapOpen is offered and waiting for a colleague to take it or propose a swap
+	ShiftSwapOpen ShiftSwapStatus = "open"
+	// ShiftSwapProposed has a colleague's shift offered in exchange, waiting for the requester to confirm
+	ShiftSwapProposed ShiftSwapStatus = "proposed"
+	// ShiftSwapPendingApproval is agreed by both employees and waiting for an administrator
+	ShiftSwapPendingApproval ShiftSwapStatus = "pending_approval"
+	ShiftSwapCompleted       ShiftSwapStatus = "completed"
+	ShiftSwapCancelled       ShiftSwapStatus = "cancelled"
+	ShiftSwapRejected        ShiftSwapStatus = "rejected"
+)
+
+// Valid reports whether the status is one of the known shift swap states
+func (s ShiftSwapStatus) Valid() bool {
+	switch s {
+	case ShiftSwapOpen, ShiftSwapProposed, ShiftSwapPendingApproval, ShiftSwapCompleted, ShiftSwapCancelled, ShiftSwapRejected:
+		return true
+	}
+	return false
+}
+
+// OfferShiftRequest DTO for offering one of the employee's shifts to colleagues
+// swagger:model
+type OfferShiftRequest struct {
+	ShiftDate string `json:"shiftDate" binding:"required"`
+	ShiftType int    `json:"shiftType" binding:"required,min=1,max=3"`
+	Note      string `json:"note,omitempty"`
+}
+
+// ProposeShiftSwapRequest DTO for offering one of the colleague's own shifts in exchange for an offered shift
+// swagger:model
+type ProposeShiftSwapRequest struct {
+	ShiftDate string `json:"shiftDate" binding:"required"`
+	ShiftType int    `json:"shiftType" binding:"required,min=1,max=3"`
+}
+
+// ShiftSwapShift DTO identifying a shift taking part in a swap
+// swagger:model
+type ShiftSwapShift struct {
+	ShiftID   uint   `json:"shiftId"`
+	ShiftDate string `json:"shiftDate"`
+	ShiftType int    `json:"shiftType"`
+}
+
+// ShiftSwapResponse DTO for returning a shift swap
+// swagger:model
+type ShiftSwapResponse struct {
+	ID          uint            `json:"id"`
+	Status      ShiftSwapStatus `json:"status"`
+	ProfileType string          `json:"profileType"`
+	RequesterID uint            `json:"requesterId"`
+	Shift       ShiftSwapShift  `json:"shift"`
+	Note        string          `json:"note,omitempty"`
+	// ResponderID is the colleague taking the shift; ResponderShift is set when they offered a shift in exchange
+	ResponderID    *uint           `json:"responderId,omitempty"`
+	ResponderShift *ShiftSwapShift `json:"responderShift,omitempty"`
+	ReviewedBy     *uint           `json:"reviewedBy,omitempty"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	UpdatedAt      time.Time       `json:"updatedAt"`
+}
+
+// LeaveType is the reason an employee is away
+type LeaveType string
+
+const (
+	LeaveVacation LeaveType = "vacation"
+	LeaveSick     LeaveType = "sick"
+	LeaveTraining LeaveType = "training"
+	LeaveOther    LeaveType = "other"
+)
+
+// Valid reports whether the type is one of the known leave types
+func (t LeaveType) Valid() bool {
+	switch t {
+	case LeaveVacation, LeaveSick, LeaveTraining, LeaveOther:
+		return true
+	}
+	return false
+}
+
+// LeaveStatus is the lifecycle state of a leave request
+type LeaveStatus string
+
+const (
+	LeavePending   LeaveStatus = "pending"
+	LeaveApproved  LeaveStatus = "approved"
+	LeaveRejected  LeaveStatus = "rejected"
+	LeaveCancelled LeaveStatus = "cancelled"
+)
+
+// Valid reports whether the status is one of the known leave request states
+func (s LeaveStatus) Valid() bool {
+	switch s {
+	case LeavePending, LeaveApproved, LeaveRejected, LeaveCancelled:
+		return true
+	}
+	return false
+}
+
+// LeaveRequestCreate DTO for requesting leave; both dates are inclusive
+// swagger:model
+type LeaveRequestCreate struct {
+	Type      LeaveType `json:"type" binding:"required"`
+	StartDate string    `json:"startDate" binding:"required"`
+	EndDate   string    `json:"endDate" binding:"required"`
+	Reason    string    `json:"reason,omitempty"`
+}
+
+// LeaveReviewRequest DTO for an administrator's decision on a leave request
+// swagger:model
+type LeaveReviewRequest struct {
+	Note string `json:"note,omitempty"`
+}
+
+// LeaveRequestResponse DTO for returning a leave request
+// swagger:model
+type LeaveRequestResponse struct {
+	ID         uint        `json:"id"`
+	EmployeeID uint        `json:"employeeId"`
+	Type       LeaveType   `json:"type"`
+	Status     LeaveStatus `json:"status"`
+	StartDate  string      `json:"startDate"`
+	EndDate    string      `json:"endDate"`
+	Days       int         `json:"days"`
+	Reason     string      `json:"reason,omitempty"`
+	ReviewedBy *uint       `json:"reviewedBy,omitempty"`
+	ReviewNote string      `json:"reviewNote,omitempty"`
+	CreatedAt  time.Time   `json:"createdAt"`
+	UpdatedAt  time.Time   `json:"updatedAt"`
+}
+
+// RosterDraftRequest DTO for generating a roster for an inclusive date range
+// swagger:model
+type RosterDraftRequest struct {
+	StartDate string `json:"startDate" binding:"required"`
+	EndDate   string `json:"endDate" binding:"required"`
+}
+
+// RosterAssignment DTO for one employee on one shift
+// swagger:model
+type RosterAssignment struct {
+	EmployeeID uint   `json:"employeeId" binding:"required"`
+	ShiftDate  string `json:"shiftDate" binding:"required"`
+	ShiftType  int    `json:"shiftType" binding:"required,min=1,max=3"`
+}
+
+// RosterSlot DTO for the staffing of one profile on one shift: who is assigned now, who the draft adds
+// and how many places stay empty
+// swagger:model
+type RosterSlot struct {
+	ShiftDate   string `json:"shiftDate"`
+	ShiftType   int    `json:"shiftType"`
+	ProfileType string `json:"profileType"`
+	Capacity    int    `json:"capacity"`
+	Current     []uint `json:"current"`
+	Proposed    []uint `json:"proposed"`
+	Unfilled    int    `json:"unfilled"`
+}
+
+// RosterEmployeeSummary DTO for an employee's workload in the drafted period
+// swagger:model
+type RosterEmployeeSummary struct {
+	EmployeeID     uint   `json:"employeeId"`
+	Name           string `json:"name"`
+	ProfileType    string `json:"profileType"`
+	AvailableDays  int    `json:"availableDays"`
+	TargetDays     int    `json:"targetDays"`
+	CurrentShifts  int    `json:"currentShifts"`
+	ProposedShifts int    `json:"proposedShifts"`
+	WorkDays       int    `json:"workDays"`
+}
+
+// RosterDraftResponse DTO for a generated roster. Proposed lists only the assignments the draft adds on
+// top of the current ones; committing it applies them.
+// swagger:model
+type RosterDraftResponse struct {
+	StartDate     string                  `json:"startDate"`
+	EndDate       string                  `json:"endDate"`
+	Proposed      []RosterAssignment      `json:"proposed"`
+	Slots         []RosterSlot            `json:"slots"`
+	Employees     []RosterEmployeeSummary `json:"employees"`
+	UnfilledSlots int                     `json:"unfilledSlots"`
+}
+
+// RosterCommitRequest DTO for applying a reviewed roster draft
+// swagger:model
+type RosterCommitRequest struct {
+	Assignments []RosterAssignment `json:"assignments" binding:"required,min=1,dive"`
+}
+
+// RosterCommitResponse DTO for the assignments created from a roster
+// swagger:model
+type RosterCommitResponse struct {
+	Created     int                `json:"created"`
+	Assignments []RosterAssignment `json:"assignments"`
+}
+
 // OnCallEmployeesResponse DTO for returning on-call employees
 // swagger:model
 type OnCallEmployeesResponse struct {
@@ -198,7 +549,8 @@ type TokenResponse struct {
 // ActiveEmergenciesResponse DTO for returning active emergencies status
 // swagger:model
 type ActiveEmergenciesResponse struct {
-	HasActiveEmergencies bool `json:"hasActiveEmergencies"`
+	HasActiveEmergencies bool   `json:"hasActiveEmergencies"`
+	UrgencyIDs           []uint `json:"urgencyIds,omitempty"`
 }
 
 // Helper methods
@@ -235,6 +587,18 @@ func (r *EmployeeUpdateRequest) Validate() error {
 		}
 	}
 
+	if err := utils.ValidateOptionalLocale(r.PreferredLanguage); err != nil {
+		errors.AddError("preferredLanguage", err)
+	}
+
+	if r.NotificationPreferences != nil {
+		if err := r.NotificationPreferences.Validate(); err != nil {
+			if verrs, ok := err.(validation.ValidationErrors); ok {
+				errors = append(errors, verrs...)
+			}
+		}
+	}
+
 	if errors.HasErrors() {
 		return errors
 	}
@@ -266,6 +630,33 @@ func (e *EmployeeCreateRequest) ToString() string {
 	)
 }
 
+// EmployeePositionRequest DTO for reporting the last known position of an employee
+// swagger:model
+type EmployeePositionRequest struct {
+	Latitude  *float64 `json:"latitude" binding:"required"`
+	Longitude *float64 `json:"longitude" binding:"required"`
+}
+
+func (r *EmployeePositionRequest) Validate() error {
+	var errors validation.ValidationErrors
+
+	if r.Latitude == nil {
+		errors.Add("latitude", "latitude is required")
+	} else if *r.Latitude < -90 || *r.Latitude > 90 {
+		errors.Add("latitude", "latitude must be between -90 and 90")
+	}
+	if r.Longitude == nil {
+		errors.Add("longitude", "longitude is required")
+	} else if *r.Longitude < -180 || *r.Longitude > 180 {
+		errors.Add("longitude", "longitude must be between -180 and 180")
+	}
+
+	if errors.HasErrors() {
+		return errors
+	}
+	return nil
+}
+
 // Validate validates the EmployeeLogin request
 func (r *EmployeeLogin) Validate() error {
 	var errors validation.ValidationErrors
@@ -286,3 +677,65 @@ func (r *EmployeeLogin) Validate() error {
 	}
 	return nil
 }
+
+// ShiftTemplateRequest DTO for the hours of the three daily shifts, in UTC "HH:MM", from EffectiveFrom to
+// EffectiveTo (inclusive; empty for open-ended). Each shift ends when the next one starts.
+// swagger:model
+type ShiftTemplateRequest struct {
+	Name             string `json:"name"`
+	FirstShiftStart  string `json:"firstShiftStart" binding:"required" example:"07:00"`
+	SecondShiftStart string `json:"secondShiftStart" binding:"required" example:"15:00"`
+	ThirdShiftStart  string `json:"thirdShiftStart" binding:"required" example:"23:00"`
+	EffectiveFrom    string `json:"effectiveFrom" binding:"required" example:"2025-12-01"`
+	EffectiveTo      string `json:"effectiveTo,omitempty" example:"2026-03-31"`
+}
+
+// StartMinutes parses the shift starts to minutes since midnight; they must follow each other within the day
+func (r *ShiftTemplateRequest) StartMinutes() ([3]int, error) {
+	var starts [3]int
+	for i, raw := range []string{r.FirstShiftStart, r.SecondShiftStart, r.ThirdShiftStart} {
+		minute, err := parseClock(raw)
+		if err != nil {
+			return starts, err
+		}
+		starts[i] = minute
+	}
+	if starts[0] >= starts[1] || starts[1] >= starts[2] {
+		return starts, fmt.Errorf("shifts must start in order within the day")
+	}
+	return starts, nil
+}
+
+// ShiftTemplateResponse DTO for configured shift hours
+// swagger:model
+type ShiftTemplateResponse struct {
+	ID               uint      `json:"id"`
+	Name             string    `json:"name"`
+	FirstShiftStart  string    `json:"firstShiftStart"`
+	SecondShiftStart string    `json:"secondShiftStart"`
+	ThirdShiftStart  string    `json:"thirdShiftStart"`
+	EffectiveFrom    string    `json:"effectiveFrom"`
+	EffectiveTo      string    `json:"effectiveTo,omitempty"`
+	CreatedAt        time.Time `json:"createdAt"`
+}
+
+// ShiftCapacityRequest DTO for how many staff of a profile each shift takes from EffectiveFrom to
+// EffectiveTo (inclusive; empty for open-ended)
+// swagger:model
+type ShiftCapacityRequest struct {
+	ProfileType   string `json:"profileType" binding:"required" example:"Medic"`
+	Capacity      int    `json:"capacity" binding:"min=0" example:"3"`
+	EffectiveFrom string `json:"effectiveFrom" binding:"required" example:"2025-12-01"`
+	EffectiveTo   string `json:"effectiveTo,omitempty" example:"2026-03-31"`
+}
+
+// ShiftCapacityResponse DTO for a configured shift capacity
+// swagger:model
+type ShiftCapacityResponse struct {
+	ID            uint      `json:"id"`
+	ProfileType   string    `json:"profileType"`
+	Capacity      int       `json:"capacity"`
+	EffectiveFrom string    `json:"effectiveFrom"`
+	EffectiveTo   string    `json:"effectiveTo,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
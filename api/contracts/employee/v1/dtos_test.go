@@ -2,6 +2,7 @@ package v1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -172,6 +173,24 @@ func TestEmployeeCreateRequest_Validate(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "profile type is required")
 	})
+
+	t.Run("it returns an error for unsupported preferred language", func(t *testing.T) {
+		req := &EmployeeCreateRequest{
+			FirstName:         "John",
+			LastName:          "Doe",
+			Username:          "johndoe",
+			Password:          "Pass123!",
+			Email:             "john.doe@example.com",
+			Gender:            "M",
+			Phone:             "+1234567890",
+			ProfileType:       "Medic",
+			PreferredLanguage: "de",
+		}
+
+		err := req.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "language must be one of")
+	})
 }
 
 func TestEmployeeUpdateRequest_Validate(t *testing.T) {
@@ -239,6 +258,83 @@ func TestEmployeeUpdateRequest_Validate(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "profile type must be one of: Medic, Technical, Administrator")
 	})
+
+	t.Run("it accepts a supported preferred language", func(t *testing.T) {
+		req := &EmployeeUpdateRequest{
+			PreferredLanguage: "sr-lat",
+		}
+
+		assert.NoError(t, req.Validate())
+	})
+
+	t.Run("it returns an error for unsupported preferred language", func(t *testing.T) {
+		req := &EmployeeUpdateRequest{
+			PreferredLanguage: "sr",
+		}
+
+		err := req.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "language must be one of")
+	})
+
+	t.Run("it accepts valid notification preferences", func(t *testing.T) {
+		req := &EmployeeUpdateRequest{
+			NotificationPreferences: &NotificationPreferences{
+				SMS:        ChannelPreference{Enabled: true, MinLevel: "high"},
+				Email:      ChannelPreference{Enabled: false},
+				QuietHours: &QuietHours{Start: "22:00", End: "07:00"},
+			},
+		}
+
+		assert.NoError(t, req.Validate())
+	})
+
+	t.Run("it returns an error for invalid notification preferences", func(t *testing.T) {
+		req := &EmployeeUpdateRequest{
+			NotificationPreferences: &NotificationPreferences{
+				SMS:        ChannelPreference{Enabled: true, MinLevel: "urgent"},
+				QuietHours: &QuietHours{Start: "25:00", End: "07:00"},
+			},
+		}
+
+		err := req.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "level must be one of")
+		assert.Contains(t, err.Error(), "HH:MM")
+	})
+}
+
+func TestQuietHours_Contains(t *testing.T) {
+	t.Parallel()
+
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, 3, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	t.Run("it handles a window within the same day", func(t *testing.T) {
+		q := &QuietHours{Start: "12:00", End: "14:00"}
+
+		assert.True(t, q.Contains(at(12, 0)))
+		assert.True(t, q.Contains(at(13, 59)))
+		assert.False(t, q.Contains(at(14, 0)))
+		assert.False(t, q.Contains(at(11, 59)))
+	})
+
+	t.Run("it handles a window that wraps past midnight", func(t *testing.T) {
+		q := &QuietHours{Start: "22:00", End: "07:00"}
+
+		assert.True(t, q.Contains(at(23, 0)))
+		assert.True(t, q.Contains(at(3, 0)))
+		assert.False(t, q.Contains(at(7, 0)))
+		assert.False(t, q.Contains(at(12, 0)))
+	})
+
+	t.Run("it never matches a nil, empty or invalid window", func(t *testing.T) {
+		var q *QuietHours
+		assert.False(t, q.Contains(at(3, 0)))
+		assert.False(t, (&QuietHours{Start: "03:00", End: "03:00"}).Contains(at(3, 0)))
+		assert.False(t, (&QuietHours{Start: "bad", End: "07:00"}).Contains(at(3, 0)))
+	})
 }
 
 func TestEmployeeLogin_Validate(t *testing.T) {
@@ -356,3 +452,53 @@ func TestEmployeeLogin_Validate(t *testing.T) {
 		assert.Contains(t, errorMsg, "password is required")
 	})
 }
+
+func TestEmployeePositionRequest_Validate(t *testing.T) {
+	t.Parallel()
+
+	ptr := func(v float64) *float64 { return &v }
+
+	t.Run("it returns no error for a valid position", func(t *testing.T) {
+		req := &EmployeePositionRequest{Latitude: ptr(43.4), Longitude: ptr(22.66)}
+		assert.NoError(t, req.Validate())
+	})
+
+	t.Run("it returns an error when coordinates are missing", func(t *testing.T) {
+		req := &EmployeePositionRequest{Latitude: ptr(43.4)}
+		err := req.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "longitude is required")
+	})
+
+	t.Run("it returns an error when coordinates are out of range", func(t *testing.T) {
+		req := &EmployeePositionRequest{Latitude: ptr(-91), Longitude: ptr(181)}
+		err := req.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "latitude must be between -90 and 90")
+		assert.Contains(t, err.Error(), "longitude must be between -180 and 180")
+	})
+}
+
+func TestShiftTemplateRequest_StartMinutes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it parses the shift starts", func(t *testing.T) {
+		req := &ShiftTemplateRequest{FirstShiftStart: "07:00", SecondShiftStart: "15:30", ThirdShiftStart: "23:00"}
+
+		starts, err := req.StartMinutes()
+
+		assert.NoError(t, err)
+		assert.Equal(t, [3]int{420, 930, 1380}, starts)
+	})
+
+	t.Run("it rejects shifts out of order or with an invalid time", func(t *testing.T) {
+		for _, req := range []ShiftTemplateRequest{
+			{FirstShiftStart: "14:00", SecondShiftStart: "06:00", ThirdShiftStart: "22:00"},
+			{FirstShiftStart: "06:00", SecondShiftStart: "06:00", ThirdShiftStart: "22:00"},
+			{FirstShiftStart: "06:00", SecondShiftStart: "14:00", ThirdShiftStart: "25:00"},
+		} {
+			_, err := req.StartMinutes()
+			assert.Error(t, err, "%+v", req)
+		}
+	})
+}
@@ -2,6 +2,8 @@ package v1
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/pd120424d/mountain-service/api/shared/utils"
 	"github.com/pd120424d/mountain-service/api/shared/validation"
@@ -25,8 +27,71 @@ const (
 	InProgress UrgencyStatus = "in_progress"
 	Resolved   UrgencyStatus = "resolved"
 	Closed     UrgencyStatus = "closed"
+	Cancelled  UrgencyStatus = "cancelled"
 )
 
+// IntakeStatus tells whether a publicly reported urgency has been let through to responders
+type IntakeStatus string
+
+const (
+	IntakeAccepted IntakeStatus = "accepted"
+	// IntakePendingVerification waits for the reporter to confirm the phone number with a one-time code
+	IntakePendingVerification IntakeStatus = "pending_verification"
+	// IntakeQuarantined waits in the admin review queue because the report looks suspicious
+	IntakeQuarantined IntakeStatus = "quarantined"
+	IntakeRejected    IntakeStatus = "rejected"
+)
+
+// AssignmentRole represents the role of a responder on an urgency team
+type AssignmentRole string
+
+const (
+	// RoleLead is the single responder accountable for the urgency; it mirrors the urgency assignee
+	RoleLead      AssignmentRole = "lead"
+	RoleMedic     AssignmentRole = "medic"
+	RoleTechnical AssignmentRole = "technical"
+	RoleSupport   AssignmentRole = "support"
+)
+
+// UrgencySort represents the ordering of the urgency list
+type UrgencySort string
+
+const (
+	// SortPriority lists unassigned urgencies first, then newest first within each group
+	SortPriority UrgencySort = "priority"
+	SortNewest   UrgencySort = "newest"
+	SortOldest   UrgencySort = "oldest"
+	// SortLevel lists the most severe urgencies first, then newest first
+	SortLevel UrgencySort = "level"
+)
+
+// MaxSearchQueryLength limits the free-text search on the urgency list
+const MaxSearchQueryLength = 200
+
+// DefaultStatsRange is used when an urgency stats query has no lower bound
+const DefaultStatsRange = 30 * 24 * time.Hour
+
+// MaxStatsRange caps urgency stats queries so they stay cheap to compute
+const MaxStatsRange = 366 * 24 * time.Hour
+
+// MaxDeclineReasonLength limits the free-text reason given when declining an urgency
+const MaxDeclineReasonLength = 500
+
+// MaxGeoRadiusKm caps radius queries so they stay index friendly
+const MaxGeoRadiusKm = 500
+
+// MaxStatusNoteLength limits resolution notes and reopen/cancel reasons
+const MaxStatusNoteLength = 2000
+
+// MaxEtaNoteLength limits the arrival estimate responders share with the reporter
+const MaxEtaNoteLength = 500
+
+// MaxFollowUpLength limits the description a reporter adds through the tracking link
+const MaxFollowUpLength = 2000
+
+// VerificationCodeLength is the number of digits in the one-time code sent to the reporter's phone
+const VerificationCodeLength = 6
+
 // UrgencyCreateRequest DTO for creating a new urgency
 // swagger:model
 type UrgencyCreateRequest struct {
@@ -50,6 +115,8 @@ type UrgencyUpdateRequest struct {
 	Description  string        `json:"description"`
 	Level        UrgencyLevel  `json:"level"`
 	Status       UrgencyStatus `json:"status"`
+	// EtaNote is shown to the reporter on the public tracking page, e.g. "team arrives in ~40 min"
+	EtaNote string `json:"etaNote"`
 }
 
 // UrgencyResponse DTO for returning an urgency
@@ -66,14 +133,31 @@ type UrgencyResponse struct {
 	Status             UrgencyStatus `json:"status"`
 	AssignedEmployeeId *uint         `json:"assignedEmployeeId,omitempty"`
 	AssignedAt         string        `json:"assignedAt,omitempty"`
-	CreatedAt          string        `json:"createdAt"`
-	UpdatedAt          string        `json:"updatedAt"`
+	EscalationLevel    int           `json:"escalationLevel,omitempty"`
+	LastEscalatedAt    string        `json:"lastEscalatedAt,omitempty"`
+	ResolutionNote     string        `json:"resolutionNote,omitempty"`
+	ResolvedAt         string        `json:"resolvedAt,omitempty"`
+	ClosedAt           string        `json:"closedAt,omitempty"`
+	Latitude           *float64      `json:"latitude,omitempty"`
+	Longitude          *float64      `json:"longitude,omitempty"`
+	DistanceKm         *float64      `json:"distanceKm,omitempty"` // only set for radius queries
+	DuplicateOfId      *uint         `json:"duplicateOfId,omitempty"`
+	EtaNote            string        `json:"etaNote,omitempty"`
+	EtaUpdatedAt       string        `json:"etaUpdatedAt,omitempty"`
+	IntakeStatus       IntakeStatus  `json:"intakeStatus,omitempty"`
+	IntakeReason       string        `json:"intakeReason,omitempty"`
+	// TrackingToken is only returned when the urgency is created; it is not stored in plain text
+	TrackingToken string `json:"trackingToken,omitempty"`
+	CreatedAt     string `json:"createdAt"`
+	UpdatedAt     string `json:"updatedAt"`
 }
 
 // UrgencyList DTO for returning a list of urgencies
 // swagger:model
 type UrgencyList struct {
 	Urgencies []UrgencyResponse `json:"urgencies"`
+	// Truncated is set when more urgencies matched than the list holds
+	Truncated bool `json:"truncated,omitempty"`
 }
 
 // UrgencyListRequest DTO for listing urgencies with pagination
@@ -107,16 +191,422 @@ type NotificationResponse struct {
 	EmployeeID       uint   `json:"employeeId"`
 	NotificationType string `json:"notificationType"`
 	Recipient        string `json:"recipient"`
+	Subject          string `json:"subject,omitempty"`
 	Message          string `json:"message"`
 	Status           string `json:"status"`
 	Attempts         int    `json:"attempts"`
 	LastAttemptAt    string `json:"lastAttemptAt,omitempty"`
 	SentAt           string `json:"sentAt,omitempty"`
 	ErrorMessage     string `json:"errorMessage,omitempty"`
+	Reply            string `json:"reply,omitempty"`
+	RepliedAt        string `json:"repliedAt,omitempty"`
+	DeclineReason    string `json:"declineReason,omitempty"`
 	CreatedAt        string `json:"createdAt"`
 	UpdatedAt        string `json:"updatedAt"`
 }
 
+// NotificationListResponse DTO for the delivery history of notifications
+// swagger:model
+type NotificationListResponse struct {
+	Notifications []NotificationResponse `json:"notifications"`
+}
+
+// UrgencyDeclineRequest DTO for a notified employee declining an urgency
+// swagger:model
+type UrgencyDeclineRequest struct {
+	Reason string `json:"reason"`
+}
+
+// UrgencyReplyResponse DTO describing how a notified employee answered an urgency
+// swagger:model
+type UrgencyReplyResponse struct {
+	EmployeeID    uint   `json:"employeeId"`
+	Reply         string `json:"reply,omitempty"`
+	DeclineReason string `json:"declineReason,omitempty"`
+	RepliedAt     string `json:"repliedAt,omitempty"`
+}
+
+// UrgencyRepliesResponse DTO listing replies of all notified employees
+// swagger:model
+type UrgencyRepliesResponse struct {
+	Replies []UrgencyReplyResponse `json:"replies"`
+}
+
+// UrgencyEscalationResponse DTO for a single escalation step fired for an urgency
+// swagger:model
+type UrgencyEscalationResponse struct {
+	Step          int    `json:"step"`
+	Action        string `json:"action"`
+	NotifiedCount int    `json:"notifiedCount"`
+	CreatedAt     string `json:"createdAt"`
+}
+
+// TimelineEntry DTO for a single entry in an urgency timeline
+// Source is "urgency" for state changes and escalations, "activity" for activity service entries
+// and "reporter" for follow-ups sent through the public tracking link
+// swagger:model
+type TimelineEntry struct {
+	Type          string        `json:"type"`
+	Source        string        `json:"source"`
+	ActorID       *uint         `json:"actorId,omitempty"`
+	OldStatus     UrgencyStatus `json:"oldStatus,omitempty"`
+	NewStatus     UrgencyStatus `json:"newStatus,omitempty"`
+	OldAssigneeID *uint         `json:"oldAssigneeId,omitempty"`
+	NewAssigneeID *uint         `json:"newAssigneeId,omitempty"`
+	Description   string        `json:"description,omitempty"`
+	OccurredAt    string        `json:"occurredAt"`
+}
+
+// UrgencyTimelineResponse DTO for the chronological history of an urgency
+// Partial is set when activities could not be loaded from the activity service
+// swagger:model
+type UrgencyTimelineResponse struct {
+	UrgencyID uint            `json:"urgencyId"`
+	Entries   []TimelineEntry `json:"entries"`
+	Partial   bool            `json:"partial"`
+}
+
+// UrgencyGeoQuery DTO for listing urgencies in an area
+// Either Lat, Lng and RadiusKm or all four bounding box corners must be set
+// swagger:model
+type UrgencyGeoQuery struct {
+	Lat      *float64 `form:"lat"`
+	Lng      *float64 `form:"lng"`
+	RadiusKm *float64 `form:"radiusKm"`
+	MinLat   *float64 `form:"minLat"`
+	MinLng   *float64 `form:"minLng"`
+	MaxLat   *float64 `form:"maxLat"`
+	MaxLng   *float64 `form:"maxLng"`
+}
+
+// IsRadius reports whether the query is a radius query rather than a bounding box
+func (q *UrgencyGeoQuery) IsRadius() bool {
+	return q.Lat != nil || q.Lng != nil || q.RadiusKm != nil
+}
+
+func (q *UrgencyGeoQuery) Validate() error {
+	var errors validation.ValidationErrors
+
+	if q.IsRadius() {
+		if q.Lat == nil || q.Lng == nil || q.RadiusKm == nil {
+			return fmt.Errorf("lat, lng and radiusKm are required for a radius query")
+		}
+		validateLatLng(&errors, "lat", "lng", *q.Lat, *q.Lng)
+		if *q.RadiusKm <= 0 || *q.RadiusKm > MaxGeoRadiusKm {
+			errors.Add("radiusKm", fmt.Sprintf("radiusKm must be between 0 and %d", MaxGeoRadiusKm))
+		}
+	} else {
+		if q.MinLat == nil || q.MinLng == nil || q.MaxLat == nil || q.MaxLng == nil {
+			return fmt.Errorf("either lat, lng and radiusKm or minLat, minLng, maxLat and maxLng are required")
+		}
+		validateLatLng(&errors, "minLat", "minLng", *q.MinLat, *q.MinLng)
+		validateLatLng(&errors, "maxLat", "maxLng", *q.MaxLat, *q.MaxLng)
+		if *q.MinLat > *q.MaxLat || *q.MinLng > *q.MaxLng {
+			errors.Add("boundingBox", "min corner must be south-west of max corner")
+		}
+	}
+
+	if errors.HasErrors() {
+		return errors
+	}
+	return nil
+}
+
+func validateLatLng(errors *validation.ValidationErrors, latField, lngField string, lat, lng float64) {
+	if lat < -90 || lat > 90 {
+		errors.Add(latField, "latitude must be between -90 and 90")
+	}
+	if lng < -180 || lng > 180 {
+		errors.Add(lngField, "longitude must be between -180 and 180")
+	}
+}
+
+// UrgencyListQuery DTO for filtering and sorting the urgency list
+// Status and Level accept comma separated values. From and To accept RFC3339 timestamps or
+// YYYY-MM-DD dates; a date-only To includes the whole day.
+// swagger:model
+type UrgencyListQuery struct {
+	Status     string      `form:"status"`
+	Level      string      `form:"level"`
+	From       string      `form:"from"`
+	To         string      `form:"to"`
+	AssigneeID *uint       `form:"assigneeId"`
+	Unassigned bool        `form:"unassigned"`
+	Q          string      `form:"q"`
+	Sort       UrgencySort `form:"sort"`
+}
+
+// Statuses returns the requested statuses; call Validate first
+func (q *UrgencyListQuery) Statuses() []UrgencyStatus {
+	var out []UrgencyStatus
+	for _, v := range splitList(q.Status) {
+		out = append(out, UrgencyStatus(v))
+	}
+	return out
+}
+
+// Levels returns the requested levels; call Validate first
+func (q *UrgencyListQuery) Levels() []UrgencyLevel {
+	var out []UrgencyLevel
+	for _, v := range splitList(q.Level) {
+		out = append(out, UrgencyLevel(v))
+	}
+	return out
+}
+
+// TimeRange returns the creation time range as [from, to); call Validate first
+func (q *UrgencyListQuery) TimeRange() (from, to *time.Time) {
+	if t, _, err := parseListTime(q.From); err == nil && t != nil {
+		from = t
+	}
+	if t, dateOnly, err := parseListTime(q.To); err == nil && t != nil {
+		if dateOnly {
+			end := t.AddDate(0, 0, 1)
+			t = &end
+		}
+		to = t
+	}
+	return from, to
+}
+
+func (q *UrgencyListQuery) Validate() error {
+	var errors validation.ValidationErrors
+
+	for _, s := range q.Statuses() {
+		if !s.Valid() {
+			errors.Add("status", fmt.Sprintf("invalid status %q", s))
+		}
+	}
+	for _, l := range q.Levels() {
+		if !l.Valid() {
+			errors.Add("level", fmt.Sprintf("invalid level %q", l))
+		}
+	}
+	if _, _, err := parseListTime(q.From); err != nil {
+		errors.Add("from", "from must be an RFC3339 timestamp or a YYYY-MM-DD date")
+	}
+	if _, _, err := parseListTime(q.To); err != nil {
+		errors.Add("to", "to must be an RFC3339 timestamp or a YYYY-MM-DD date")
+	}
+	if from, to := q.TimeRange(); from != nil && to != nil && !from.Before(*to) {
+		errors.Add("to", "to must be after from")
+	}
+	if q.Unassigned && q.AssigneeID != nil {
+		errors.Add("unassigned", "unassigned cannot be combined with assigneeId")
+	}
+	if len([]rune(strings.TrimSpace(q.Q))) > MaxSearchQueryLength {
+		errors.Add("q", fmt.Sprintf("q must be at most %d characters", MaxSearchQueryLength))
+	}
+	if q.Sort != "" && !q.Sort.Valid() {
+		errors.Add("sort", fmt.Sprintf("sort must be one of %s, %s, %s, %s", SortPriority, SortNewest, SortOldest, SortLevel))
+	}
+
+	if errors.HasErrors() {
+		return errors
+	}
+	return nil
+}
+
+func splitList(raw string) []string {
+	var out []string
+	for _, v := range strings.Split(raw, ",") {
+		if v = strings.ToLower(strings.TrimSpace(v)); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func parseListTime(raw string) (*time.Time, bool, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, false, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		t = t.UTC()
+		return &t, false, nil
+	}
+	t, err := time.Parse(time.DateOnly, raw)
+	if err != nil {
+		return nil, false, err
+	}
+	return &t, true, nil
+}
+
+// UrgencyStatsQuery DTO for the urgency statistics date range
+// From and To accept the same formats as UrgencyListQuery; To defaults to now and From to DefaultStatsRange before To
+// swagger:model
+type UrgencyStatsQuery struct {
+	From string `form:"from"`
+	To   string `form:"to"`
+}
+
+// Range returns the resolved [from, to) range; call Validate first
+func (q *UrgencyStatsQuery) Range(now time.Time) (from, to time.Time) {
+	list := UrgencyListQuery{From: q.From, To: q.To}
+	f, t := list.TimeRange()
+	to = now.UTC()
+	if t != nil {
+		to = *t
+	}
+	from = to.Add(-DefaultStatsRange)
+	if f != nil {
+		from = *f
+	}
+	return from, to
+}
+
+func (q *UrgencyStatsQuery) Validate() error {
+	list := UrgencyListQuery{From: q.From, To: q.To}
+	if err := list.Validate(); err != nil {
+		return err
+	}
+	from, to := q.Range(time.Now())
+	if !from.Before(to) {
+		return fmt.Errorf("to must be after from")
+	}
+	if to.Sub(from) > MaxStatsRange {
+		return fmt.Errorf("date range must not exceed %d days", int(MaxStatsRange.Hours()/24))
+	}
+	return nil
+}
+
+// DurationStats DTO for a distribution of durations in seconds
+// Percentiles use the nearest-rank method and are omitted when Count is zero
+// swagger:model
+type DurationStats struct {
+	Count         int      `json:"count"`
+	MedianSeconds *float64 `json:"medianSeconds,omitempty"`
+	P90Seconds    *float64 `json:"p90Seconds,omitempty"`
+}
+
+// EmployeeHandledStats DTO for urgencies assigned to one employee within the range
+// swagger:model
+type EmployeeHandledStats struct {
+	EmployeeID uint `json:"employeeId"`
+	Handled    int  `json:"handled"`
+	Completed  int  `json:"completed"`
+}
+
+// SLALevelStats DTO for the SLA target and breaches of one urgency level
+// swagger:model
+type SLALevelStats struct {
+	Level         UrgencyLevel `json:"level"`
+	TargetSeconds int64        `json:"targetSeconds"`
+	Total         int          `json:"total"`
+	Breached      int          `json:"breached"`
+}
+
+// SLABreach DTO for an urgency that was not assigned within its level's SLA target
+// AssignedAt is empty when the urgency is still waiting for a responder
+// swagger:model
+type SLABreach struct {
+	UrgencyID     uint          `json:"urgencyId"`
+	Level         UrgencyLevel  `json:"level"`
+	Status        UrgencyStatus `json:"status"`
+	CreatedAt     string        `json:"createdAt"`
+	AssignedAt    string        `json:"assignedAt,omitempty"`
+	WaitSeconds   int64         `json:"waitSeconds"`
+	TargetSeconds int64         `json:"targetSeconds"`
+}
+
+// SLAStats DTO summarizing time-to-assign SLA compliance
+// swagger:model
+type SLAStats struct {
+	Levels   []SLALevelStats `json:"levels"`
+	Breaches int             `json:"breaches"`
+	Breached []SLABreach     `json:"breached"`
+}
+
+// UrgencyStatsResponse DTO for response-time statistics of urgencies created within a range
+// swagger:model
+type UrgencyStatsResponse struct {
+	From         string                 `json:"from"`
+	To           string                 `json:"to"`
+	Total        int                    `json:"total"`
+	ByStatus     map[UrgencyStatus]int  `json:"byStatus"`
+	ByLevel      map[UrgencyLevel]int   `json:"byLevel"`
+	TimeToAssign DurationStats          `json:"timeToAssign"`
+	TimeToClose  DurationStats          `json:"timeToClose"`
+	Employees    []EmployeeHandledStats `json:"employees"`
+	SLA          SLAStats               `json:"sla"`
+}
+
+// TeamMemberRequest DTO for adding a responder to an urgency team
+// swagger:model
+type TeamMemberRequest struct {
+	EmployeeID uint           `json:"employeeId" binding:"required"`
+	Role       AssignmentRole `json:"role" binding:"required"`
+}
+
+func (r *TeamMemberRequest) Validate() error {
+	if !r.Role.Valid() {
+		return fmt.Errorf("role must be one of %s, %s, %s, %s", RoleLead, RoleMedic, RoleTechnical, RoleSupport)
+	}
+	return nil
+}
+
+// TeamMemberResponse DTO for a responder on an urgency team
+// LeftAt is only set for former members
+// swagger:model
+type TeamMemberResponse struct {
+	EmployeeID uint           `json:"employeeId"`
+	Role       AssignmentRole `json:"role"`
+	JoinedAt   string         `json:"joinedAt"`
+	LeftAt     string         `json:"leftAt,omitempty"`
+}
+
+// UrgencyTeamResponse DTO for the current team of an urgency
+// swagger:model
+type UrgencyTeamResponse struct {
+	UrgencyID uint                 `json:"urgencyId"`
+	Members   []TeamMemberResponse `json:"members"`
+}
+
+// EmployeeActiveUrgenciesResponse DTO for the in-progress urgencies an employee is responding to
+// swagger:model
+type EmployeeActiveUrgenciesResponse struct {
+	EmployeeID uint   `json:"employeeId"`
+	HasActive  bool   `json:"hasActive"`
+	UrgencyIDs []uint `json:"urgencyIds"`
+}
+
+// ResponderSuggestion DTO for an on-call employee ranked by distance to an urgency
+// DistanceKm is empty when either the urgency or the employee has no known position
+// swagger:model
+type ResponderSuggestion struct {
+	EmployeeID     uint     `json:"employeeId"`
+	FirstName      string   `json:"firstName"`
+	LastName       string   `json:"lastName"`
+	ProfileType    string   `json:"profileType"`
+	Phone          string   `json:"phone,omitempty"`
+	DistanceKm     *float64 `json:"distanceKm,omitempty"`
+	LastPositionAt string   `json:"lastPositionAt,omitempty"`
+}
+
+// UrgencyMergeRequest DTO for merging a duplicate urgency into the one given in the path
+// swagger:model
+type UrgencyMergeRequest struct {
+	SourceID uint `json:"sourceId" binding:"required"`
+}
+
+// UrgencyMergeResponse DTO summarizing what was moved onto the surviving urgency
+// Partial is set when activities could not be moved; repeating the merge retries only that step
+// swagger:model
+type UrgencyMergeResponse struct {
+	UrgencyID          uint  `json:"urgencyId"`
+	MergedID           uint  `json:"mergedId"`
+	NotificationsMoved int64 `json:"notificationsMoved"`
+	ActivitiesMoved    int   `json:"activitiesMoved"`
+	Partial            bool  `json:"partial"`
+}
+
+// UrgencyRespondersResponse DTO for on-call responders ranked by distance
+// swagger:model
+type UrgencyRespondersResponse struct {
+	Responders []ResponderSuggestion `json:"responders"`
+}
+
 // AssignmentResponse DTO for returning minimal assignment info
 // swagger:model
 type AssignmentResponse struct {
@@ -136,8 +626,36 @@ func (l UrgencyLevel) Valid() bool {
 	return false
 }
 
+// Rank orders levels from low (1) to critical (4); unknown levels rank 0
+func (l UrgencyLevel) Rank() int {
+	for i, v := range []UrgencyLevel{Low, Medium, High, Critical} {
+		if l == v {
+			return i + 1
+		}
+	}
+	return 0
+}
+
 func (s UrgencyStatus) Valid() bool {
-	for _, v := range []UrgencyStatus{Open, InProgress, Resolved, Closed} {
+	for _, v := range []UrgencyStatus{Open, InProgress, Resolved, Closed, Cancelled} {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (r AssignmentRole) Valid() bool {
+	for _, v := range []AssignmentRole{RoleLead, RoleMedic, RoleTechnical, RoleSupport} {
+		if r == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (s UrgencySort) Valid() bool {
+	for _, v := range []UrgencySort{SortPriority, SortNewest, SortOldest, SortLevel} {
 		if s == v {
 			return true
 		}
@@ -182,6 +700,31 @@ func (r *UrgencyCreateRequest) Validate() error {
 	return nil
 }
 
+func (r *UrgencyDeclineRequest) Validate() error {
+	if len(r.Reason) > MaxDeclineReasonLength {
+		return fmt.Errorf("reason must be at most %d characters", MaxDeclineReasonLength)
+	}
+	return nil
+}
+
+// UrgencyStatusChangeRequest DTO for resolve, reopen and cancel requests
+// Note holds the resolution note when resolving and the reason when reopening or cancelling
+// swagger:model
+type UrgencyStatusChangeRequest struct {
+	Note string `json:"note" binding:"required"`
+}
+
+func (r *UrgencyStatusChangeRequest) Validate() error {
+	r.Note = strings.TrimSpace(r.Note)
+	if r.Note == "" {
+		return fmt.Errorf("note is required")
+	}
+	if len(r.Note) > MaxStatusNoteLength {
+		return fmt.Errorf("note must be at most %d characters", MaxStatusNoteLength)
+	}
+	return nil
+}
+
 func (r *UrgencyUpdateRequest) Validate() error {
 	// Validate enum types first
 	if r.Level != "" && !r.Level.Valid() {
@@ -210,8 +753,265 @@ func (r *UrgencyUpdateRequest) Validate() error {
 		}
 	}
 
+	r.EtaNote = strings.TrimSpace(r.EtaNote)
+	if len(r.EtaNote) > MaxEtaNoteLength {
+		errors.Add("etaNote", fmt.Sprintf("eta note must be at most %d characters", MaxEtaNoteLength))
+	}
+
+	if errors.HasErrors() {
+		return errors
+	}
+	return nil
+}
+
+// NotificationTemplatePreviewQuery DTO for rendering a notification template with sample data
+// Locale is optional; the service falls back to its default locale when it is empty
+// swagger:model
+type NotificationTemplatePreviewQuery struct {
+	Type   string `form:"type" binding:"required"`
+	Event  string `form:"event" binding:"required"`
+	Locale string `form:"locale"`
+}
+
+func (q *NotificationTemplatePreviewQuery) Validate() error {
+	if q.Type != "sms" && q.Type != "email" {
+		return fmt.Errorf("type must be one of sms, email")
+	}
+	return utils.ValidateOptionalLocale(q.Locale)
+}
+
+// NotificationTemplatePreviewResponse DTO for a rendered notification template
+// Locale is the locale that was actually used after fallback
+// swagger:model
+type NotificationTemplatePreviewResponse struct {
+	Type    string `json:"type"`
+	Event   string `json:"event"`
+	Locale  string `json:"locale"`
+	Subject string `json:"subject,omitempty"`
+	Body    string `json:"body"`
+}
+
+// UrgencyEventType names a lifecycle event published through the urgency outbox
+type UrgencyEventType string
+
+const (
+	EventUrgencyCreated    UrgencyEventType = "urgency.created"
+	EventUrgencyUpdated    UrgencyEventType = "urgency.updated"
+	EventUrgencyAssigned   UrgencyEventType = "urgency.assigned"
+	EventUrgencyUnassigned UrgencyEventType = "urgency.unassigned"
+	EventUrgencyClosed     UrgencyEventType = "urgency.closed"
+	EventUrgencyDeleted    UrgencyEventType = "urgency.deleted"
+)
+
+// UrgencyEventTypes lists the event types that can be subscribed to
+func UrgencyEventTypes() []UrgencyEventType {
+	return []UrgencyEventType{EventUrgencyCreated, EventUrgencyUpdated, EventUrgencyAssigned, EventUrgencyUnassigned, EventUrgencyClosed, EventUrgencyDeleted}
+}
+
+func (t UrgencyEventType) Valid() bool {
+	for _, v := range UrgencyEventTypes() {
+		if t == v {
+			return true
+		}
+	}
+	return false
+}
+
+// UrgencyEvent is the payload stored in the urgency outbox. It is published to the urgency
+// event stream and delivered to webhook subscribers; the state fields hold the urgency as it
+// was right after the change.
+// swagger:model
+type UrgencyEvent struct {
+	// ID is the outbox event ID; receivers can use it to drop duplicate deliveries
+	ID        uint             `json:"id"`
+	Type      UrgencyEventType `json:"type"`
+	UrgencyID uint             `json:"urgencyId"`
+	// Title is the denormalized urgency title used by read models; it is not sent to webhooks
+	Title              string        `json:"title,omitempty"`
+	Level              UrgencyLevel  `json:"level"`
+	Status             UrgencyStatus `json:"status"`
+	AssignedEmployeeID *uint         `json:"assignedEmployeeId,omitempty"`
+	ActorID            *uint         `json:"actorId,omitempty"`
+	OccurredAt         time.Time     `json:"occurredAt"`
+}
+
+// WebhookSubscriptionRequest DTO for creating or replacing a webhook subscription
+// swagger:model
+type WebhookSubscriptionRequest struct {
+	URL string `json:"url" binding:"required" example:"https://partner.example.com/hooks/urgencies"`
+	// Secret is used to sign deliveries with HMAC-SHA256; it is never returned by the API
+	Secret     string             `json:"secret" binding:"required"`
+	EventTypes []UrgencyEventType `json:"eventTypes" binding:"required"`
+	// Optional; new subscriptions are active by default
+	Active *bool `json:"active,omitempty"`
+}
+
+const minWebhookSecretLength = 16
+
+func (r *WebhookSubscriptionRequest) Validate() error {
+	var errors validation.ValidationErrors
+
+	if err := utils.ValidateRequiredField(r.URL, "url"); err != nil {
+		errors.AddError("url", err)
+	} else if !strings.HasPrefix(r.URL, "https://") && !strings.HasPrefix(r.URL, "http://") {
+		errors.Add("url", "url must start with http:// or https://")
+	}
+	if len(r.Secret) < minWebhookSecretLength {
+		errors.Add("secret", fmt.Sprintf("secret must be at least %d characters long", minWebhookSecretLength))
+	}
+	if len(r.EventTypes) == 0 {
+		errors.Add("eventTypes", "at least one event type is required")
+	}
+	for _, t := range r.EventTypes {
+		if !t.Valid() {
+			errors.Add("eventTypes", fmt.Sprintf("unknown event type %q", t))
+		}
+	}
+
+	if errors.HasErrors() {
+		return errors
+	}
+	return nil
+}
+
+// WebhookSubscriptionResponse DTO for a webhook subscription
+// swagger:model
+type WebhookSubscriptionResponse struct {
+	ID         uint               `json:"id"`
+	URL        string             `json:"url"`
+	EventTypes []UrgencyEventType `json:"eventTypes"`
+	Active     bool               `json:"active"`
+	CreatedAt  string             `json:"createdAt"`
+	UpdatedAt  string             `json:"updatedAt"`
+}
+
+// WebhookSubscriptionListResponse DTO for listing webhook subscriptions
+// swagger:model
+type WebhookSubscriptionListResponse struct {
+	Subscriptions []WebhookSubscriptionResponse `json:"subscriptions"`
+}
+
+// WebhookDeliveryResponse DTO for one entry of the webhook delivery log
+// swagger:model
+type WebhookDeliveryResponse struct {
+	ID             uint             `json:"id"`
+	SubscriptionID uint             `json:"subscriptionId"`
+	EventID        uint             `json:"eventId"`
+	EventType      UrgencyEventType `json:"eventType"`
+	Status         string           `json:"status"`
+	Attempts       int              `json:"attempts"`
+	ResponseStatus int              `json:"responseStatus,omitempty"`
+	Error          string           `json:"error,omitempty"`
+	CreatedAt      string           `json:"createdAt"`
+	LastAttemptAt  string           `json:"lastAttemptAt,omitempty"`
+	NextAttemptAt  string           `json:"nextAttemptAt,omitempty"`
+	DeliveredAt    string           `json:"deliveredAt,omitempty"`
+}
+
+// WebhookDeliveryListResponse DTO for the delivery log of a subscription
+// swagger:model
+type WebhookDeliveryListResponse struct {
+	Deliveries []WebhookDeliveryResponse `json:"deliveries"`
+}
+
+// UrgencyTrackingResponse DTO for the public status page opened with a tracking token
+// Responders are only counted so the reporter never sees who is on the team
+// swagger:model
+type UrgencyTrackingResponse struct {
+	Status       UrgencyStatus `json:"status"`
+	Level        UrgencyLevel  `json:"level"`
+	TeamSize     int           `json:"teamSize"`
+	EtaNote      string        `json:"etaNote,omitempty"`
+	EtaUpdatedAt string        `json:"etaUpdatedAt,omitempty"`
+	FollowUps    int           `json:"followUps"`
+	// VerificationRequired is set until the reporter confirms the phone number with the code sent by SMS
+	VerificationRequired bool   `json:"verificationRequired,omitempty"`
+	CreatedAt            string `json:"createdAt"`
+	UpdatedAt            string `json:"updatedAt"`
+}
+
+// UrgencyFollowUpRequest DTO for additional information sent by the reporter through the tracking link
+// At least one of Location and Description is required
+// swagger:model
+type UrgencyFollowUpRequest struct {
+	Location    string `json:"location"`
+	Description string `json:"description"`
+}
+
+func (r *UrgencyFollowUpRequest) Validate() error {
+	r.Location = strings.TrimSpace(r.Location)
+	r.Description = strings.TrimSpace(r.Description)
+
+	var errors validation.ValidationErrors
+	if r.Location == "" && r.Description == "" {
+		errors.Add("description", "location or description is required")
+	}
+	if r.Location != "" {
+		if err := utils.ValidateCoordinates(r.Location); err != nil {
+			errors.AddError("location", err)
+		}
+	}
+	if len(r.Description) > MaxFollowUpLength {
+		errors.Add("description", fmt.Sprintf("description must be at most %d characters", MaxFollowUpLength))
+	}
+
 	if errors.HasErrors() {
 		return errors
 	}
 	return nil
 }
+
+// UrgencyFollowUpResponse DTO for a stored reporter follow-up
+// swagger:model
+type UrgencyFollowUpResponse struct {
+	ID          uint   `json:"id"`
+	Location    string `json:"location,omitempty"`
+	Description string `json:"description,omitempty"`
+	CreatedAt   string `json:"createdAt"`
+}
+
+// UrgencyVerificationRequest DTO for the one-time code the reporter received by SMS
+// swagger:model
+type UrgencyVerificationRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+func (r *UrgencyVerificationRequest) Validate() error {
+	r.Code = strings.TrimSpace(r.Code)
+	if len(r.Code) != VerificationCodeLength {
+		return fmt.Errorf("code must have %d digits", VerificationCodeLength)
+	}
+	for _, c := range r.Code {
+		if c < '0' || c > '9' {
+			return fmt.Errorf("code must have %d digits", VerificationCodeLength)
+		}
+	}
+	return nil
+}
+
+// IntakeReviewList DTO for the admin queue of reports that have not been let through to responders
+// swagger:model
+type IntakeReviewList struct {
+	Urgencies []UrgencyResponse `json:"urgencies"`
+}
+
+// AttachmentResponse DTO for a photo or document attached to an urgency. The file itself is
+// downloaded through the attachment endpoint, never from storage directly.
+// swagger:model
+type AttachmentResponse struct {
+	ID          uint   `json:"id"`
+	UrgencyID   uint   `json:"urgencyId"`
+	ActivityID  *uint  `json:"activityId,omitempty"`
+	FileName    string `json:"fileName"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+	// UploadedBy is the employee who uploaded the file; empty when the reporter sent it through the tracking link
+	UploadedBy *uint  `json:"uploadedBy,omitempty"`
+	CreatedAt  string `json:"createdAt"`
+}
+
+// AttachmentListResponse DTO for the attachments of an urgency
+// swagger:model
+type AttachmentListResponse struct {
+	Attachments []AttachmentResponse `json:"attachments"`
+}
@@ -1,7 +1,9 @@
 package v1
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -218,6 +220,15 @@ func TestUrgencyLevel_Valid(t *testing.T) {
 	}
 }
 
+func TestUrgencyLevel_Rank(t *testing.T) {
+	t.Parallel()
+
+	assert.Less(t, Low.Rank(), Medium.Rank())
+	assert.Less(t, Medium.Rank(), High.Rank())
+	assert.Less(t, High.Rank(), Critical.Rank())
+	assert.Equal(t, 0, UrgencyLevel("Invalid").Rank())
+}
+
 func TestStatus_Valid(t *testing.T) {
 	t.Parallel()
 
@@ -229,6 +240,7 @@ func TestStatus_Valid(t *testing.T) {
 		{InProgress, true},
 		{Resolved, true},
 		{Closed, true},
+		{Cancelled, true},
 		{UrgencyStatus("Invalid"), false},
 		{UrgencyStatus(""), false},
 	}
@@ -240,3 +252,247 @@ func TestStatus_Valid(t *testing.T) {
 		})
 	}
 }
+
+func TestUrgencyStatusChangeRequest_Validate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it trims and accepts a note", func(t *testing.T) {
+		req := UrgencyStatusChangeRequest{Note: "  patient handed over to ambulance  "}
+		assert.NoError(t, req.Validate())
+		assert.Equal(t, "patient handed over to ambulance", req.Note)
+	})
+
+	t.Run("it rejects blank and too long notes", func(t *testing.T) {
+		blank := UrgencyStatusChangeRequest{Note: "   "}
+		assert.Error(t, blank.Validate())
+		long := UrgencyStatusChangeRequest{Note: strings.Repeat("a", MaxStatusNoteLength+1)}
+		assert.Error(t, long.Validate())
+	})
+}
+
+func TestUrgencyUpdateRequest_ValidateEtaNote(t *testing.T) {
+	t.Parallel()
+
+	req := UrgencyUpdateRequest{EtaNote: "  team arrives in ~40 min  "}
+	assert.NoError(t, req.Validate())
+	assert.Equal(t, "team arrives in ~40 min", req.EtaNote)
+
+	long := UrgencyUpdateRequest{EtaNote: strings.Repeat("a", MaxEtaNoteLength+1)}
+	assert.Error(t, long.Validate())
+}
+
+func TestUrgencyFollowUpRequest_Validate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it accepts a location or a description", func(t *testing.T) {
+		loc := UrgencyFollowUpRequest{Location: " N 43.401123 E 22.662756 "}
+		assert.NoError(t, loc.Validate())
+		assert.Equal(t, "N 43.401123 E 22.662756", loc.Location)
+
+		desc := UrgencyFollowUpRequest{Description: "  we moved below the ridge  "}
+		assert.NoError(t, desc.Validate())
+		assert.Equal(t, "we moved below the ridge", desc.Description)
+	})
+
+	t.Run("it rejects empty, badly located and too long follow-ups", func(t *testing.T) {
+		empty := UrgencyFollowUpRequest{Description: "   "}
+		assert.Error(t, empty.Validate())
+		badLocation := UrgencyFollowUpRequest{Location: "somewhere up"}
+		assert.Error(t, badLocation.Validate())
+		long := UrgencyFollowUpRequest{Description: strings.Repeat("a", MaxFollowUpLength+1)}
+		assert.Error(t, long.Validate())
+	})
+}
+
+func TestUrgencyVerificationRequest_Validate(t *testing.T) {
+	t.Parallel()
+
+	ok := UrgencyVerificationRequest{Code: " 012345 "}
+	assert.NoError(t, ok.Validate())
+	assert.Equal(t, "012345", ok.Code)
+
+	for _, code := range []string{"", "12345", "1234567", "12a456"} {
+		req := UrgencyVerificationRequest{Code: code}
+		assert.Error(t, req.Validate(), code)
+	}
+}
+
+func TestUrgencyGeoQuery_Validate(t *testing.T) {
+	t.Parallel()
+
+	f := func(v float64) *float64 { return &v }
+
+	t.Run("it accepts a radius query", func(t *testing.T) {
+		q := UrgencyGeoQuery{Lat: f(43.4), Lng: f(22.66), RadiusKm: f(10)}
+		assert.NoError(t, q.Validate())
+		assert.True(t, q.IsRadius())
+	})
+
+	t.Run("it accepts a bounding box query", func(t *testing.T) {
+		q := UrgencyGeoQuery{MinLat: f(43), MinLng: f(22), MaxLat: f(44), MaxLng: f(23)}
+		assert.NoError(t, q.Validate())
+		assert.False(t, q.IsRadius())
+	})
+
+	t.Run("it rejects incomplete queries", func(t *testing.T) {
+		radius := UrgencyGeoQuery{Lat: f(43.4), RadiusKm: f(10)}
+		assert.Error(t, radius.Validate())
+		empty := UrgencyGeoQuery{}
+		assert.Error(t, empty.Validate())
+	})
+
+	t.Run("it rejects out of range values", func(t *testing.T) {
+		q := UrgencyGeoQuery{Lat: f(95), Lng: f(22.66), RadiusKm: f(MaxGeoRadiusKm + 1)}
+		err := q.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "latitude must be between -90 and 90")
+		assert.Contains(t, err.Error(), "radiusKm must be between")
+	})
+
+	t.Run("it rejects inverted bounding boxes", func(t *testing.T) {
+		q := UrgencyGeoQuery{MinLat: f(44), MinLng: f(22), MaxLat: f(43), MaxLng: f(23)}
+		assert.Error(t, q.Validate())
+	})
+}
+
+func TestUrgencyListQuery_Validate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it accepts an empty query", func(t *testing.T) {
+		q := UrgencyListQuery{}
+		assert.NoError(t, q.Validate())
+		assert.Empty(t, q.Statuses())
+		from, to := q.TimeRange()
+		assert.Nil(t, from)
+		assert.Nil(t, to)
+	})
+
+	t.Run("it parses comma separated statuses and levels", func(t *testing.T) {
+		q := UrgencyListQuery{Status: "open, In_Progress,", Level: "critical,high", Sort: SortLevel}
+		assert.NoError(t, q.Validate())
+		assert.Equal(t, []UrgencyStatus{Open, InProgress}, q.Statuses())
+		assert.Equal(t, []UrgencyLevel{Critical, High}, q.Levels())
+	})
+
+	t.Run("it includes the whole day for a date-only upper bound", func(t *testing.T) {
+		q := UrgencyListQuery{From: "2025-01-01T08:00:00+01:00", To: "2025-01-31"}
+		assert.NoError(t, q.Validate())
+		from, to := q.TimeRange()
+		assert.Equal(t, time.Date(2025, 1, 1, 7, 0, 0, 0, time.UTC), *from)
+		assert.Equal(t, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), *to)
+	})
+
+	t.Run("it rejects invalid values", func(t *testing.T) {
+		assignee := uint(3)
+		q := UrgencyListQuery{Status: "open,done", Level: "urgent", From: "yesterday", Unassigned: true, AssigneeID: &assignee, Sort: "name"}
+		err := q.Validate()
+		assert.Error(t, err)
+		for _, field := range []string{"status", "level", "from", "unassigned", "sort"} {
+			assert.Contains(t, err.Error(), field)
+		}
+	})
+
+	t.Run("it rejects an empty time range", func(t *testing.T) {
+		q := UrgencyListQuery{From: "2025-02-01", To: "2025-01-01"}
+		assert.Error(t, q.Validate())
+	})
+}
+
+func TestUrgencyStatsQuery(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2025, 3, 15, 10, 0, 0, 0, time.UTC)
+
+	t.Run("it defaults to the last 30 days", func(t *testing.T) {
+		q := UrgencyStatsQuery{}
+		assert.NoError(t, q.Validate())
+		from, to := q.Range(now)
+		assert.Equal(t, now, to)
+		assert.Equal(t, now.Add(-DefaultStatsRange), from)
+	})
+
+	t.Run("it uses the given dates", func(t *testing.T) {
+		q := UrgencyStatsQuery{From: "2025-01-01", To: "2025-01-31"}
+		assert.NoError(t, q.Validate())
+		from, to := q.Range(now)
+		assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), from)
+		assert.Equal(t, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), to)
+	})
+
+	t.Run("it rejects invalid and too long ranges", func(t *testing.T) {
+		invalid := UrgencyStatsQuery{From: "last week"}
+		assert.Error(t, invalid.Validate())
+		inverted := UrgencyStatsQuery{From: "2025-02-01", To: "2025-01-01"}
+		assert.Error(t, inverted.Validate())
+		tooLong := UrgencyStatsQuery{From: "2023-01-01", To: "2025-01-01"}
+		assert.ErrorContains(t, tooLong.Validate(), "366 days")
+	})
+}
+
+func TestTeamMemberRequest_Validate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it accepts known roles", func(t *testing.T) {
+		for _, role := range []AssignmentRole{RoleLead, RoleMedic, RoleTechnical, RoleSupport} {
+			req := TeamMemberRequest{EmployeeID: 1, Role: role}
+			assert.NoError(t, req.Validate())
+		}
+	})
+
+	t.Run("it rejects unknown roles", func(t *testing.T) {
+		req := TeamMemberRequest{EmployeeID: 1, Role: "driver"}
+		assert.ErrorContains(t, req.Validate(), "role must be one of")
+	})
+}
+
+func TestNotificationTemplatePreviewQuery_Validate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it accepts a known type with or without a locale", func(t *testing.T) {
+		assert.NoError(t, (&NotificationTemplatePreviewQuery{Type: "sms", Event: "new_urgency"}).Validate())
+		assert.NoError(t, (&NotificationTemplatePreviewQuery{Type: "email", Event: "closed", Locale: "sr-cyr"}).Validate())
+	})
+
+	t.Run("it rejects unknown types and locales", func(t *testing.T) {
+		assert.ErrorContains(t, (&NotificationTemplatePreviewQuery{Type: "push", Event: "closed"}).Validate(), "type must be one of")
+		assert.ErrorContains(t, (&NotificationTemplatePreviewQuery{Type: "sms", Event: "closed", Locale: "de"}).Validate(), "language must be one of")
+	})
+}
+
+func TestUrgencyEventType_Valid(t *testing.T) {
+	t.Parallel()
+
+	for _, eventType := range UrgencyEventTypes() {
+		assert.True(t, eventType.Valid())
+	}
+	assert.False(t, UrgencyEventType("urgency.escalated").Valid())
+}
+
+func TestWebhookSubscriptionRequest_Validate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it accepts a valid subscription", func(t *testing.T) {
+		req := WebhookSubscriptionRequest{
+			URL:        "https://partner.example.com/hooks",
+			Secret:     "0123456789abcdef",
+			EventTypes: []UrgencyEventType{EventUrgencyCreated, EventUrgencyClosed},
+		}
+		assert.NoError(t, req.Validate())
+	})
+
+	t.Run("it rejects a bad url, short secret and unknown event types", func(t *testing.T) {
+		req := WebhookSubscriptionRequest{
+			URL:        "ftp://partner.example.com",
+			Secret:     "short",
+			EventTypes: []UrgencyEventType{"urgency.escalated"},
+		}
+		err := req.Validate()
+		assert.ErrorContains(t, err, "url must start with")
+		assert.ErrorContains(t, err, "secret must be at least 16")
+		assert.ErrorContains(t, err, "unknown event type")
+	})
+
+	t.Run("it requires at least one event type", func(t *testing.T) {
+		req := WebhookSubscriptionRequest{URL: "https://partner.example.com", Secret: "0123456789abcdef"}
+		assert.ErrorContains(t, req.Validate(), "at least one event type")
+	})
+}
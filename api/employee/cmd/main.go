@@ -14,6 +14,7 @@ import (
 
 	"github.com/pd120424d/mountain-service/api/shared/auth"
 	globConf "github.com/pd120424d/mountain-service/api/shared/config"
+	s2surgency "github.com/pd120424d/mountain-service/api/shared/s2s/urgency"
 	"github.com/pd120424d/mountain-service/api/shared/server"
 	"github.com/pd120424d/mountain-service/api/shared/storage"
 	"github.com/pd120424d/mountain-service/api/shared/utils"
@@ -64,7 +65,7 @@ func main() {
 		ServiceName: svcName,
 		Port:        globConf.EmployeeServicePort,
 		DatabaseConfig: server.GetDatabaseConfigWithDefaults(
-			[]interface{}{&model.Employee{}, &model.Shift{}, &model.EmployeeShift{}},
+			[]interface{}{&model.Employee{}, &model.Shift{}, &model.EmployeeShift{}, &model.ShiftSwap{}, &model.LeaveRequest{}, &model.ShiftTemplate{}, &model.ShiftCapacity{}},
 			globConf.EmployeeDBName,
 		),
 		CORSConfig: server.DefaultCORSConfig(),
@@ -118,46 +119,33 @@ func setupRoutes(log utils.Logger, r *gin.Engine, db *gorm.DB) {
 	}
 	log.Info("Successfully initialized Redis token blacklist")
 
-	// Initialize services
-	employeeService := service.NewEmployeeService(log, employeeRepo, tokenBlacklist)
-	shiftService := service.NewShiftService(log, employeeRepo, shiftsRepo)
-
-	// Initialize Azure Blob Storage service
-	containerName := os.Getenv("AZURE_STORAGE_CONTAINER_NAME")
-	if containerName == "" {
-		containerName = "employee-profiles"
-	}
-	blobConfig := storage.AzureBlobConfig{
-		AccountName:   os.Getenv("AZURE_STORAGE_ACCOUNT_NAME"),
-		AccountKey:    os.Getenv("AZURE_STORAGE_ACCOUNT_KEY"),
-		ContainerName: containerName,
-	}
-	log.Infof("Azure Storage Config - Account Name: %s, Container: %s, Key Present: %t",
-		blobConfig.AccountName,
-		blobConfig.ContainerName,
-		blobConfig.AccountKey != "")
-
-	var blobService storage.AzureBlobService
-	if blobConfig.AccountName != "" && blobConfig.AccountKey != "" {
-		clientWrapper, err := storage.NewAzureBlobClientWrapper(log, blobConfig)
-		if err != nil {
-			log.Warnf("Failed to create Azure Blob client wrapper: %v. File upload will be disabled.", err)
-		} else {
-			blobService, err = storage.NewAzureBlobService(log, clientWrapper)
-			if err != nil {
-				log.Warnf("Failed to initialize Azure Blob Storage: %v. File upload will be disabled.", err)
-				blobService = nil
-			} else {
-				log.Info("Azure Blob Storage initialized successfully")
-			}
-		}
-	} else {
-		log.Warn("Azure Storage credentials not provided. File upload will be disabled.")
+	serviceAuthSecret := os.Getenv("SERVICE_AUTH_SECRET")
+	if serviceAuthSecret == "" {
+		log.Warn("SERVICE_AUTH_SECRET not set, service-to-service authentication may not work properly")
 	}
+	serviceAuth := auth.NewServiceAuth(auth.ServiceAuthConfig{
+		Secret:      serviceAuthSecret,
+		ServiceName: "employee-service",
+		TokenTTL:    time.Hour,
+	})
 
-	// Initialize file handler
+	// Urgency service client, used to check whether an employee is responding to an active emergency
+	urgencyClient := s2surgency.NewFromEnv(log, serviceAuth)
+
+	// Initialize services
+	employeeService := service.NewEmployeeService(log, employeeRepo, tokenBlacklist, urgencyClient)
+	shiftOptions := service.DefaultShiftServiceOptions()
+	shiftOptions.Swaps.RequireApproval = os.Getenv("SHIFT_SWAP_REQUIRES_APPROVAL") == "true"
+	shiftService := service.NewShiftServiceWithOptions(log, employeeRepo, shiftsRepo, urgencyClient, shiftOptions)
+
+	// Initialize profile picture storage; local disk unless Azure is configured
+	storeConfig := storage.LoadStoreConfig()
 	var fileHandler handler.FileHandler
-	if blobService != nil {
+	blobService, err := storage.OpenProfilePictureService(log, storeConfig)
+	if err != nil {
+		log.Warnf("Failed to initialize %s storage: %v. File upload will be disabled.", storeConfig.Backend, err)
+	} else {
+		log.Infof("Profile pictures stored with the %s backend", storeConfig.Backend)
 		fileHandler = handler.NewFileHandler(log, blobService, employeeService)
 	}
 
@@ -175,21 +163,23 @@ func setupRoutes(log utils.Logger, r *gin.Engine, db *gorm.DB) {
 		authorized.DELETE("/employees/:id", employeeHandler.DeleteEmployee)
 		authorized.POST("/employees/:id/shifts", employeeHandler.AssignShift)
 		authorized.PUT("/employees/:id", employeeHandler.UpdateEmployee)
+		authorized.PUT("/employees/:id/position", employeeHandler.UpdatePosition)
 		authorized.GET("/employees/:id/shifts", employeeHandler.GetShifts)
 		authorized.GET("/employees/:id/shift-warnings", employeeHandler.GetShiftWarnings)
 		authorized.GET("/shifts/availability", employeeHandler.GetShiftsAvailability)
 		authorized.DELETE("/employees/:id/shifts", employeeHandler.RemoveShift)
+		authorized.POST("/shift-swaps", employeeHandler.OfferShift)
+		authorized.GET("/shift-swaps", employeeHandler.ListShiftSwaps)
+		authorized.POST("/shift-swaps/:id/accept", employeeHandler.AcceptShiftSwap)
+		authorized.POST("/shift-swaps/:id/propose", employeeHandler.ProposeShiftSwap)
+		authorized.POST("/shift-swaps/:id/confirm", employeeHandler.ConfirmShiftSwap)
+		authorized.POST("/shift-swaps/:id/decline", employeeHandler.DeclineShiftSwap)
+		authorized.DELETE("/shift-swaps/:id", employeeHandler.CancelShiftSwap)
+		authorized.POST("/leave-requests", employeeHandler.RequestLeave)
+		authorized.GET("/leave-requests", employeeHandler.ListLeaveRequests)
+		authorized.DELETE("/leave-requests/:id", employeeHandler.CancelLeaveRequest)
 
 		// Service-to-service endpoints with service authentication
-		serviceAuthSecret := os.Getenv("SERVICE_AUTH_SECRET")
-		if serviceAuthSecret == "" {
-			log.Warn("SERVICE_AUTH_SECRET not set, service-to-service authentication may not work properly")
-		}
-		serviceAuth := auth.NewServiceAuth(auth.ServiceAuthConfig{
-			Secret:      serviceAuthSecret,
-			ServiceName: "employee-service",
-			TokenTTL:    time.Hour,
-		})
 		serviceAuthMiddleware := auth.NewServiceAuthMiddleware(serviceAuth)
 
 		serviceRoutes := r.Group("/api/v1").Use(serviceAuthMiddleware)
@@ -205,6 +195,9 @@ func setupRoutes(log utils.Logger, r *gin.Engine, db *gorm.DB) {
 			authorized.POST("/employees/:id/profile-picture", fileHandler.UploadProfilePicture)
 			authorized.DELETE("/employees/:id/profile-picture", fileHandler.DeleteProfilePicture)
 			authorized.GET("/files/profile-picture/info", fileHandler.GetProfilePictureInfo)
+
+			// Signed download links carry their own authorization, browsers load them as plain image URLs
+			r.GET("/api/v1/files/blobs/*name", fileHandler.DownloadFile)
 		}
 
 		// Error catalog endpoint, no need for authorization
@@ -216,7 +209,21 @@ func setupRoutes(log utils.Logger, r *gin.Engine, db *gorm.DB) {
 	{
 		admin.DELETE("/reset", employeeHandler.ResetAllData)
 		admin.GET("/shifts/availability", employeeHandler.GetAdminShiftsAvailability)
+		admin.POST("/employees/:id/shifts", employeeHandler.AssignShift)
+		admin.DELETE("/employees/:id/shifts", employeeHandler.RemoveShift)
 		admin.GET("/employees/:id/shift-warnings", employeeHandler.GetShiftWarnings)
+		admin.POST("/shift-swaps/:id/approve", employeeHandler.ApproveShiftSwap)
+		admin.POST("/shift-swaps/:id/reject", employeeHandler.RejectShiftSwap)
+		admin.POST("/leave-requests/:id/approve", employeeHandler.ApproveLeaveRequest)
+		admin.POST("/leave-requests/:id/reject", employeeHandler.RejectLeaveRequest)
+		admin.POST("/rosters/draft", employeeHandler.DraftRoster)
+		admin.POST("/rosters/commit", employeeHandler.CommitRoster)
+		admin.GET("/shift-templates", employeeHandler.ListShiftTemplates)
+		admin.POST("/shift-templates", employeeHandler.CreateShiftTemplate)
+		admin.DELETE("/shift-templates/:id", employeeHandler.DeleteShiftTemplate)
+		admin.GET("/shift-capacities", employeeHandler.ListShiftCapacities)
+		admin.POST("/shift-capacities", employeeHandler.CreateShiftCapacity)
+		admin.DELETE("/shift-capacities/:id", employeeHandler.DeleteShiftCapacity)
 		// Admin K8s ops
 		admin.POST("/k8s/restart", employeeHandler.RestartDeployment)
 	}
@@ -3,8 +3,10 @@ package handler
 //go:generate mockgen -source=file_handler.go -destination=file_handler_gomock.go -package=handler -imports=gomock=go.uber.org/mock/gomock
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
@@ -17,15 +19,16 @@ type FileHandler interface {
 	UploadProfilePicture(ctx *gin.Context)
 	DeleteProfilePicture(ctx *gin.Context)
 	GetProfilePictureInfo(ctx *gin.Context)
+	DownloadFile(ctx *gin.Context)
 }
 
 type fileHandler struct {
 	log             utils.Logger
-	blobService     storage.AzureBlobService
+	blobService     storage.ProfilePictureService
 	employeeService service.EmployeeService
 }
 
-func NewFileHandler(log utils.Logger, blobService storage.AzureBlobService, employeeService service.EmployeeService) FileHandler {
+func NewFileHandler(log utils.Logger, blobService storage.ProfilePictureService, employeeService service.EmployeeService) FileHandler {
 	return &fileHandler{
 		log:             log.WithName("FileHandler"),
 		blobService:     blobService,
@@ -180,3 +183,42 @@ func (h *fileHandler) GetProfilePictureInfo(ctx *gin.Context) {
 		"status":   "exists", // This would be determined by checking Azure
 	})
 }
+
+// DownloadFile serves a stored file through a signed link
+// @Summary Download file
+// @Description Download a file kept on a storage backend without public URLs, using the signed link returned on upload
+// @Tags files
+// @Produce octet-stream
+// @Param name path string true "Blob name"
+// @Param expires query string false "Link expiry as a Unix timestamp, absent on links that do not expire"
+// @Param signature query string true "Link signature"
+// @Success 200 {file} file
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /files/blobs/{name} [get]
+func (h *fileHandler) DownloadFile(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	blobName := strings.TrimPrefix(ctx.Param("name"), "/")
+
+	body, contentType, err := h.blobService.OpenSigned(ctx.Request.Context(), blobName, ctx.Query("expires"), ctx.Query("signature"))
+	switch {
+	case errors.Is(err, storage.ErrInvalidSignature):
+		log.Warnf("Rejected download link for blob %s", blobName)
+		ctx.JSON(http.StatusForbidden, employeeV1.ErrorResponse{Error: "Invalid or expired download link"})
+		return
+	case errors.Is(err, storage.ErrBlobNotFound):
+		ctx.JSON(http.StatusNotFound, employeeV1.ErrorResponse{Error: "File not found"})
+		return
+	case err != nil:
+		log.Errorf("Failed to open blob %s: %v", blobName, err)
+		ctx.JSON(http.StatusInternalServerError, employeeV1.ErrorResponse{Error: "Failed to download file"})
+		return
+	}
+	defer body.Close()
+
+	ctx.DataFromReader(http.StatusOK, -1, contentType, body, map[string]string{
+		"X-Content-Type-Options": "nosniff",
+		"Cache-Control":          "private, max-age=3600",
+	})
+}
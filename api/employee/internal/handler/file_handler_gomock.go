@@ -52,6 +52,18 @@ func (mr *MockFileHandlerMockRecorder) DeleteProfilePicture(ctx any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProfilePicture", reflect.TypeOf((*MockFileHandler)(nil).DeleteProfilePicture), ctx)
 }
 
+// DownloadFile mocks base method.
+func (m *MockFileHandler) DownloadFile(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DownloadFile", ctx)
+}
+
+// DownloadFile indicates an expected call of DownloadFile.
+func (mr *MockFileHandlerMockRecorder) DownloadFile(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadFile", reflect.TypeOf((*MockFileHandler)(nil).DownloadFile), ctx)
+}
+
 // GetProfilePictureInfo mocks base method.
 func (m *MockFileHandler) GetProfilePictureInfo(ctx *gin.Context) {
 	m.ctrl.T.Helper()
@@ -3,6 +3,7 @@ package handler
 import (
 	"bytes"
 	"errors"
+	"io"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
@@ -25,7 +26,7 @@ func TestFileHandler_UploadProfilePicture(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		mockBlobService := storage.NewMockAzureBlobService(ctrl)
+		mockBlobService := storage.NewMockProfilePictureService(ctrl)
 		mockEmployeeService := service.NewMockEmployeeService(ctrl)
 		log := utils.NewTestLogger()
 		handler := NewFileHandler(log, mockBlobService, mockEmployeeService)
@@ -46,7 +47,7 @@ func TestFileHandler_UploadProfilePicture(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		mockBlobService := storage.NewMockAzureBlobService(ctrl)
+		mockBlobService := storage.NewMockProfilePictureService(ctrl)
 		mockEmployeeService := service.NewMockEmployeeService(ctrl)
 		log := utils.NewTestLogger()
 		handler := NewFileHandler(log, mockBlobService, mockEmployeeService)
@@ -66,7 +67,7 @@ func TestFileHandler_UploadProfilePicture(t *testing.T) {
 	tests := []struct {
 		name           string
 		employeeID     string
-		setupMocks     func(*storage.MockAzureBlobService, *service.MockEmployeeService)
+		setupMocks     func(*storage.MockProfilePictureService, *service.MockEmployeeService)
 		setupRequest   func() *http.Request
 		expectedStatus int
 		expectedBody   string
@@ -74,7 +75,7 @@ func TestFileHandler_UploadProfilePicture(t *testing.T) {
 		{
 			name:       "it succeeds when uploading valid image",
 			employeeID: "123",
-			setupMocks: func(mockBlob *storage.MockAzureBlobService, mockEmployee *service.MockEmployeeService) {
+			setupMocks: func(mockBlob *storage.MockProfilePictureService, mockEmployee *service.MockEmployeeService) {
 				expectedResult := &storage.UploadResult{
 					BlobURL:  "https://test.blob.core.windows.net/container/test.jpg",
 					BlobName: "employee-123/test.jpg",
@@ -106,7 +107,7 @@ func TestFileHandler_UploadProfilePicture(t *testing.T) {
 		{
 			name:       "it fails when blob service returns error",
 			employeeID: "123",
-			setupMocks: func(mockBlob *storage.MockAzureBlobService, mockEmployee *service.MockEmployeeService) {
+			setupMocks: func(mockBlob *storage.MockProfilePictureService, mockEmployee *service.MockEmployeeService) {
 				mockBlob.EXPECT().
 					UploadProfilePicture(gomock.Any(), gomock.Any(), gomock.Any(), uint(123)).
 					Return(nil, errors.New("upload failed"))
@@ -133,7 +134,7 @@ func TestFileHandler_UploadProfilePicture(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			mockBlobService := storage.NewMockAzureBlobService(ctrl)
+			mockBlobService := storage.NewMockProfilePictureService(ctrl)
 			mockEmployeeService := service.NewMockEmployeeService(ctrl)
 			tt.setupMocks(mockBlobService, mockEmployeeService)
 
@@ -161,7 +162,7 @@ func TestFileHandler_DeleteProfilePicture(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		mockBlobService := storage.NewMockAzureBlobService(ctrl)
+		mockBlobService := storage.NewMockProfilePictureService(ctrl)
 		mockEmployeeService := service.NewMockEmployeeService(ctrl)
 		log := utils.NewTestLogger()
 		handler := NewFileHandler(log, mockBlobService, mockEmployeeService)
@@ -182,7 +183,7 @@ func TestFileHandler_DeleteProfilePicture(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		mockBlobService := storage.NewMockAzureBlobService(ctrl)
+		mockBlobService := storage.NewMockProfilePictureService(ctrl)
 		mockEmployeeService := service.NewMockEmployeeService(ctrl)
 		log := utils.NewTestLogger()
 		handler := NewFileHandler(log, mockBlobService, mockEmployeeService)
@@ -203,7 +204,7 @@ func TestFileHandler_DeleteProfilePicture(t *testing.T) {
 		name           string
 		employeeID     string
 		blobName       string
-		setupMocks     func(*storage.MockAzureBlobService, *service.MockEmployeeService)
+		setupMocks     func(*storage.MockProfilePictureService, *service.MockEmployeeService)
 		expectedStatus int
 		expectedBody   string
 	}{
@@ -211,7 +212,7 @@ func TestFileHandler_DeleteProfilePicture(t *testing.T) {
 			name:       "it succeeds when deleting existing blob",
 			employeeID: "123",
 			blobName:   "employee-123/test.jpg",
-			setupMocks: func(mockBlob *storage.MockAzureBlobService, mockEmployee *service.MockEmployeeService) {
+			setupMocks: func(mockBlob *storage.MockProfilePictureService, mockEmployee *service.MockEmployeeService) {
 				mockBlob.EXPECT().
 					DeleteProfilePicture(gomock.Any(), "employee-123/test.jpg").
 					Return(nil)
@@ -224,7 +225,7 @@ func TestFileHandler_DeleteProfilePicture(t *testing.T) {
 			name:       "it fails when blob service returns error",
 			employeeID: "123",
 			blobName:   "employee-123/test.jpg",
-			setupMocks: func(mockBlob *storage.MockAzureBlobService, mockEmployee *service.MockEmployeeService) {
+			setupMocks: func(mockBlob *storage.MockProfilePictureService, mockEmployee *service.MockEmployeeService) {
 				mockBlob.EXPECT().
 					DeleteProfilePicture(gomock.Any(), "employee-123/test.jpg").
 					Return(errors.New("delete failed"))
@@ -240,7 +241,7 @@ func TestFileHandler_DeleteProfilePicture(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			mockBlobService := storage.NewMockAzureBlobService(ctrl)
+			mockBlobService := storage.NewMockProfilePictureService(ctrl)
 			mockEmployeeService := service.NewMockEmployeeService(ctrl)
 			tt.setupMocks(mockBlobService, mockEmployeeService)
 
@@ -268,7 +269,7 @@ func TestFileHandler_GetProfilePictureInfo(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		mockBlobService := storage.NewMockAzureBlobService(ctrl)
+		mockBlobService := storage.NewMockProfilePictureService(ctrl)
 		mockEmployeeService := service.NewMockEmployeeService(ctrl)
 		log := utils.NewTestLogger()
 		handler := NewFileHandler(log, mockBlobService, mockEmployeeService)
@@ -288,7 +289,7 @@ func TestFileHandler_GetProfilePictureInfo(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		mockBlobService := storage.NewMockAzureBlobService(ctrl)
+		mockBlobService := storage.NewMockProfilePictureService(ctrl)
 		mockEmployeeService := service.NewMockEmployeeService(ctrl)
 		log := utils.NewTestLogger()
 		handler := NewFileHandler(log, mockBlobService, mockEmployeeService)
@@ -305,3 +306,61 @@ func TestFileHandler_GetProfilePictureInfo(t *testing.T) {
 		assert.Contains(t, w.Body.String(), "status")
 	})
 }
+
+func TestFileHandler_DownloadFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "it returns forbidden when the link is invalid or expired", err: storage.ErrInvalidSignature, expectedStatus: http.StatusForbidden},
+		{name: "it returns not found when the file no longer exists", err: storage.ErrBlobNotFound, expectedStatus: http.StatusNotFound},
+		{name: "it returns an error when storage fails", err: errors.New("disk failure"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBlobService := storage.NewMockProfilePictureService(ctrl)
+			mockBlobService.EXPECT().OpenSigned(gomock.Any(), "employee-1/a.png", "123", "sig").Return(nil, "", tt.err)
+			handler := NewFileHandler(utils.NewTestLogger(), mockBlobService, service.NewMockEmployeeService(ctrl))
+
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest("GET", "/files/blobs/employee-1/a.png?expires=123&signature=sig", nil)
+			ctx.Params = gin.Params{{Key: "name", Value: "/employee-1/a.png"}}
+
+			handler.DownloadFile(ctx)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+
+	t.Run("it streams the file with its content type", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockBlobService := storage.NewMockProfilePictureService(ctrl)
+		mockBlobService.EXPECT().OpenSigned(gomock.Any(), "employee-1/a.png", "123", "sig").
+			Return(io.NopCloser(bytes.NewReader([]byte("png"))), "image/png", nil)
+		handler := NewFileHandler(utils.NewTestLogger(), mockBlobService, service.NewMockEmployeeService(ctrl))
+
+		gin.SetMode(gin.TestMode)
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest("GET", "/files/blobs/employee-1/a.png?expires=123&signature=sig", nil)
+		ctx.Params = gin.Params{{Key: "name", Value: "/employee-1/a.png"}}
+
+		handler.DownloadFile(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+		assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+		assert.Equal(t, "png", w.Body.String())
+	})
+}
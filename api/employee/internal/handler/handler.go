@@ -5,6 +5,7 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -16,6 +17,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/spf13/afero"
 
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
 	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
 	"github.com/pd120424d/mountain-service/api/employee/internal/model"
 	"github.com/pd120424d/mountain-service/api/employee/internal/service"
@@ -34,6 +36,7 @@ type AssignShiftRequest = employeeV1.AssignShiftRequest
 type AssignShiftResponse = employeeV1.AssignShiftResponse
 type ShiftResponse = employeeV1.ShiftResponse
 type ShiftAvailabilityResponse = employeeV1.ShiftAvailabilityResponse
+type AdminShiftAvailabilityResponse = employeeV1.AdminShiftAvailabilityResponse
 type RemoveShiftRequest = employeeV1.RemoveShiftRequest
 type OnCallEmployeesResponse = employeeV1.OnCallEmployeesResponse
 type ActiveEmergenciesResponse = employeeV1.ActiveEmergenciesResponse
@@ -50,6 +53,7 @@ type EmployeeHandler interface {
 	GetEmployee(ctx *gin.Context)
 	UpdateEmployee(ctx *gin.Context)
 	DeleteEmployee(ctx *gin.Context)
+	UpdatePosition(ctx *gin.Context)
 
 	// Shift operations
 	AssignShift(ctx *gin.Context)
@@ -58,6 +62,20 @@ type EmployeeHandler interface {
 	RemoveShift(ctx *gin.Context)
 	GetShiftWarnings(ctx *gin.Context)
 
+	// Shift swap operations
+	OfferShift(ctx *gin.Context)
+	ListShiftSwaps(ctx *gin.Context)
+	AcceptShiftSwap(ctx *gin.Context)
+	ProposeShiftSwap(ctx *gin.Context)
+	ConfirmShiftSwap(ctx *gin.Context)
+	DeclineShiftSwap(ctx *gin.Context)
+	CancelShiftSwap(ctx *gin.Context)
+
+	// Leave operations
+	RequestLeave(ctx *gin.Context)
+	ListLeaveRequests(ctx *gin.Context)
+	CancelLeaveRequest(ctx *gin.Context)
+
 	// Emergency operations
 	GetOnCallEmployees(ctx *gin.Context)
 	CheckActiveEmergencies(ctx *gin.Context)
@@ -65,6 +83,18 @@ type EmployeeHandler interface {
 	// Admin operations
 	ResetAllData(ctx *gin.Context)
 	GetAdminShiftsAvailability(ctx *gin.Context)
+	ApproveShiftSwap(ctx *gin.Context)
+	RejectShiftSwap(ctx *gin.Context)
+	ApproveLeaveRequest(ctx *gin.Context)
+	RejectLeaveRequest(ctx *gin.Context)
+	DraftRoster(ctx *gin.Context)
+	CommitRoster(ctx *gin.Context)
+	ListShiftTemplates(ctx *gin.Context)
+	CreateShiftTemplate(ctx *gin.Context)
+	DeleteShiftTemplate(ctx *gin.Context)
+	ListShiftCapacities(ctx *gin.Context)
+	CreateShiftCapacity(ctx *gin.Context)
+	DeleteShiftCapacity(ctx *gin.Context)
 	RestartDeployment(ctx *gin.Context)
 
 	// Catalog and metadata
@@ -484,14 +514,79 @@ func (h *employeeHandler) UpdateEmployee(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, response)
 }
 
+// UpdatePosition Пријава последње познате локације запосленог
+// @Summary Пријава последње познате локације запосленог
+// @Description Запослени пријављује своју тренутну локацију; користи се за рангирање дежурних по удаљености од ургентне ситуације
+// @Tags запослени
+// @Security OAuth2Password
+// @Accept  json
+// @Param id path int true "ID запосленог"
+// @Param position body EmployeePositionRequest true "Географска ширина и дужина"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /employees/{id}/position [put]
+func (h *employeeHandler) UpdatePosition(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "EmployeeHandler.UpdatePosition")()
+	log.Info("Received Update Position request")
+
+	employeeID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || employeeID <= 0 {
+		log.Errorf("failed to convert employee ID: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee ID"})
+		return
+	}
+
+	// Employees can only report their own position
+	callerValue, _ := ctx.Get("employeeID")
+	callerID, ok := callerValue.(uint)
+	if !ok || callerID != uint(employeeID) {
+		log.Errorf("employee %v attempted to report position for employee %d", callerValue, employeeID)
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
+		return
+	}
+
+	var req employeeV1.EmployeePositionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorf("invalid position payload: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	if err := req.Validate(); err != nil {
+		log.Errorf("validation failed: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.emplService.UpdatePosition(requestContext(ctx), uint(employeeID), req); err != nil {
+		log.Errorf("failed to update position: %v", err)
+		if err.Error() == "employee not found" {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Employee not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update position"})
+		return
+	}
+
+	utils.WriteFreshWindow(ctx, config.DefaultFreshWindow)
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
 // DeleteEmployee Брисање запосленог
 // @Summary Брисање запосленог
 // @Description Брисање запосленог по ID-ју
 // @Tags запослени
 // @Security OAuth2Password
 // @Param id path int true "ID запосленог"
+// @Param force query bool false "Брисање и када запослени учествује у ургентној ситуацији у току (само за админе)"
 // @Success 204
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
 // @Router /employees/{id} [delete]
 func (h *employeeHandler) DeleteEmployee(ctx *gin.Context) {
 	log := h.log.WithContext(requestContext(ctx))
@@ -505,10 +600,17 @@ func (h *employeeHandler) DeleteEmployee(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee ID"})
 		return
 	}
+	force, ok := forceParam(ctx)
+	if !ok {
+		return
+	}
 
-	err = h.emplService.DeleteEmployee(requestContext(ctx), uint(employeeID))
+	err = h.emplService.DeleteEmployee(requestContext(ctx), uint(employeeID), force)
 	if err != nil {
 		log.Errorf("failed to delete employee: %v", err)
+		if writeActiveEmergencyError(ctx, err) {
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete employee"})
 		return
 	}
@@ -522,14 +624,15 @@ func (h *employeeHandler) DeleteEmployee(ctx *gin.Context) {
 
 // AssignShift Додељује смену запосленом
 // @Summary Додељује смену запосленом
-// @Description Додељује смену запосленом по ID-ју
-// @Tags запослени
+// @Description Додељује смену запосленом по ID-ју. Админи додељују смене било ком запосленом и преко админ API-ја.
+// @Tags запослени, админ
 // @Security OAuth2Password
 // @Param id path int true "ID запосленог"
 // @Param shift body AssignShiftRequest true "Подаци о смени"
 // @Success 201 {object} AssignShiftResponse
 // @Failure 400 {object} ErrorResponse
 // @Router /employees/{id}/shifts [post]
+// @Router /admin/employees/{id}/shifts [post]
 func (h *employeeHandler) AssignShift(ctx *gin.Context) {
 	employeeIDParam := ctx.Param("id")
 	log := h.log.WithContext(requestContext(ctx))
@@ -575,6 +678,8 @@ func (h *employeeHandler) AssignShift(ctx *gin.Context) {
 			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		case "employee is already assigned to this shift":
 			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case "employee is on leave that day":
+			ctx.JSON(http.StatusConflict, gin.H{"error": service.ErrorEmployeeOnLeave, "message": err.Error()})
 		default:
 			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		}
@@ -676,14 +781,19 @@ func (h *employeeHandler) GetShiftsAvailability(ctx *gin.Context) {
 
 // RemoveShift Уклањање смене за запосленог
 // @Summary Уклањање смене за запосленог
-// @Description Уклањање смене за запосленог по ID-ју и подацима о смени
-// @Tags запослени
+// @Description Уклањање смене за запосленог по ID-ју и подацима о смени. Админи уклањају смене било ком запосленом и преко админ API-ја.
+// @Tags запослени, админ
 // @Security OAuth2Password
 // @Param id path int true "ID запосленог"
 // @Param shift body RemoveShiftRequest true "Подаци о смени"
+// @Param force query bool false "Уклањање и када запослени учествује у ургентној ситуацији у току (само за админе)"
 // @Success 204
 // @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
 // @Router /employees/{id}/shifts [delete]
+// @Router /admin/employees/{id}/shifts [delete]
 func (h *employeeHandler) RemoveShift(ctx *gin.Context) {
 	employeeIDParam := ctx.Param("id")
 	log := h.log.WithContext(requestContext(ctx))
@@ -704,9 +814,17 @@ func (h *employeeHandler) RemoveShift(ctx *gin.Context) {
 		return
 	}
 
-	err = h.shiftService.RemoveShift(requestContext(ctx), uint(employeeID), req)
+	force, ok := forceParam(ctx)
+	if !ok {
+		return
+	}
+
+	err = h.shiftService.RemoveShift(requestContext(ctx), uint(employeeID), req, force)
 	if err != nil {
 		log.Errorf("failed to remove shift: %v", err)
+		if writeActiveEmergencyError(ctx, err) {
+			return
+		}
 
 		if err.Error() == "invalid shift date format" {
 			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -754,11 +872,12 @@ func (h *employeeHandler) ResetAllData(ctx *gin.Context) {
 
 // GetAdminShiftsAvailability Дохватање доступности смена за админе
 // @Summary Дохватање доступности смена за админе
-// @Description Дохватање доступности смена за све запослене (само за админе)
+// @Description Дохватање распореда свих смена (само за админе): запослени по профилу, слободна места и смене са мање људи од капацитета
 // @Tags админ
 // @Security OAuth2Password
 // @Param days query int false "Број дана за које се проверава доступност (подразумевано 7)"
-// @Success 200 {object} ShiftAvailabilityResponse
+// @Param profileType query string false "Само профил Medic или Technical"
+// @Success 200 {object} AdminShiftAvailabilityResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /admin/shifts/availability [get]
@@ -779,9 +898,17 @@ func (h *employeeHandler) GetAdminShiftsAvailability(ctx *gin.Context) {
 		}
 	}
 
-	// For admin, we can get availability for all employees or system-wide availability
-	// For now, let's return system-wide availability (we can use employee ID 1 as a reference)
-	response, err := h.shiftService.GetShiftsAvailability(requestContext(ctx), 1, days)
+	var profileType model.ProfileType
+	if profileStr := ctx.Query("profileType"); profileStr != "" {
+		profileType = model.ProfileTypeFromString(profileStr)
+		if profileType != model.Medic && profileType != model.Technical {
+			log.Errorf("Invalid profile type parameter: %s", profileStr)
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Profile type must be Medic or Technical"})
+			return
+		}
+	}
+
+	response, err := h.shiftService.GetAdminShiftsAvailability(requestContext(ctx), days, profileType)
 	if err != nil {
 		log.Errorf("failed to get admin shifts availability: %v", err)
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve shifts availability"})
@@ -924,7 +1051,7 @@ func (h *employeeHandler) GetOnCallEmployees(ctx *gin.Context) {
 
 // CheckActiveEmergencies Провера активних хитних случајева за запосленог
 // @Summary Провера активних хитних случајева за запосленог
-// @Description Проверава да ли запослени има активне хитне случајеве
+// @Description Проверава код сервиса ургентних ситуација да ли запослени учествује у ургентној ситуацији у току
 // @Tags запослени
 // @Security OAuth2Password
 // @Accept  json
@@ -933,7 +1060,7 @@ func (h *employeeHandler) GetOnCallEmployees(ctx *gin.Context) {
 // @Success 200 {object} ActiveEmergenciesResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
 // @Router /employees/{id}/active-emergencies [get]
 func (h *employeeHandler) CheckActiveEmergencies(ctx *gin.Context) {
 	log := h.log.WithContext(requestContext(ctx))
@@ -955,10 +1082,11 @@ func (h *employeeHandler) CheckActiveEmergencies(ctx *gin.Context) {
 		return
 	}
 
-	// TODO: This will be implemented in the scope of integration with urgency service
-	// For now, return false as placeholder
-	response := employeeV1.ActiveEmergenciesResponse{
-		HasActiveEmergencies: false,
+	response, err := h.emplService.CheckActiveEmergencies(requestContext(ctx), uint(employeeID))
+	if err != nil {
+		log.Errorf("Failed to check active emergencies: %v", err)
+		ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": "Failed to check active emergencies"})
+		return
 	}
 
 	log.Infof("Employee %d has active emergencies: %v", employeeID, response.HasActiveEmergencies)
@@ -1026,6 +1154,32 @@ func (h *employeeHandler) GetErrorCatalog(ctx *gin.Context) {
 		{Code: "VALIDATION.SHIFT_IN_PAST", Service: "employee-service", HttpStatus: http.StatusBadRequest, DefaultMsg: "Shift date must be in the future"},
 		{Code: "VALIDATION.SHIFT_TOO_FAR", Service: "employee-service", HttpStatus: http.StatusBadRequest, DefaultMsg: "Shift date cannot be more than 3 months in the future"},
 		{Code: "EMPLOYEE_ERRORS.NOT_FOUND", Service: "employee-service", HttpStatus: http.StatusNotFound, DefaultMsg: "Employee not found"},
+		{Code: service.ErrorActiveEmergency, Service: "employee-service", HttpStatus: http.StatusConflict, DefaultMsg: "Employee is responding to an active emergency", DetailsSchema: map[string]string{"urgencyIds": "number[]"}},
+		{Code: service.ErrorActiveEmergencyCheckFailed, Service: "employee-service", HttpStatus: http.StatusServiceUnavailable, DefaultMsg: "Active emergencies could not be checked"},
+		{Code: service.ErrorSwapNotFound, Service: "employee-service", HttpStatus: http.StatusNotFound, DefaultMsg: "Shift swap not found"},
+		{Code: service.ErrorSwapNotAssigned, Service: "employee-service", HttpStatus: http.StatusBadRequest, DefaultMsg: "Employee is not assigned to this shift"},
+		{Code: service.ErrorSwapAlreadyOffered, Service: "employee-service", HttpStatus: http.StatusConflict, DefaultMsg: "Shift is already offered"},
+		{Code: service.ErrorSwapProfileMismatch, Service: "employee-service", HttpStatus: http.StatusForbidden, DefaultMsg: "Only staff of the same profile can take this shift", DetailsSchema: map[string]string{"role": "string"}},
+		{Code: service.ErrorSwapForbidden, Service: "employee-service", HttpStatus: http.StatusForbidden, DefaultMsg: "Not allowed to act on this shift swap"},
+		{Code: service.ErrorSwapInvalidState, Service: "employee-service", HttpStatus: http.StatusConflict, DefaultMsg: "Shift swap is not in a state that allows this action", DetailsSchema: map[string]string{"status": "string"}},
+		{Code: service.ErrorSwapInvalidStatus, Service: "employee-service", HttpStatus: http.StatusBadRequest, DefaultMsg: "Unknown shift swap status"},
+		{Code: service.ErrorEmployeeOnLeave, Service: "employee-service", HttpStatus: http.StatusConflict, DefaultMsg: "Employee is on leave that day", DetailsSchema: map[string]string{"date": "string"}},
+		{Code: service.ErrorLeaveNotFound, Service: "employee-service", HttpStatus: http.StatusNotFound, DefaultMsg: "Leave request not found"},
+		{Code: service.ErrorLeaveInvalidType, Service: "employee-service", HttpStatus: http.StatusBadRequest, DefaultMsg: "Unknown leave type"},
+		{Code: service.ErrorLeaveInvalidStatus, Service: "employee-service", HttpStatus: http.StatusBadRequest, DefaultMsg: "Unknown leave status"},
+		{Code: service.ErrorLeaveInvalidRange, Service: "employee-service", HttpStatus: http.StatusBadRequest, DefaultMsg: "Invalid leave dates", DetailsSchema: map[string]string{"max": "number"}},
+		{Code: service.ErrorLeaveOverlap, Service: "employee-service", HttpStatus: http.StatusConflict, DefaultMsg: "Leave overlaps an existing request", DetailsSchema: map[string]string{"leaveId": "number"}},
+		{Code: service.ErrorLeaveForbidden, Service: "employee-service", HttpStatus: http.StatusForbidden, DefaultMsg: "Not allowed to act on this leave request"},
+		{Code: service.ErrorLeaveInvalidState, Service: "employee-service", HttpStatus: http.StatusConflict, DefaultMsg: "Leave request is not in a state that allows this action", DetailsSchema: map[string]string{"status": "string"}},
+		{Code: service.ErrorLeaveShiftConflict, Service: "employee-service", HttpStatus: http.StatusConflict, DefaultMsg: "Employee is assigned to shifts during the leave", DetailsSchema: map[string]string{"shifts": "string[]"}},
+		{Code: service.ErrorRosterInvalidRange, Service: "employee-service", HttpStatus: http.StatusBadRequest, DefaultMsg: "Invalid roster period"},
+		{Code: service.ErrorRosterInvalidAssignment, Service: "employee-service", HttpStatus: http.StatusBadRequest, DefaultMsg: "Invalid roster assignment", DetailsSchema: map[string]string{"employeeId": "number", "shiftDate": "string", "shiftType": "number"}},
+		{Code: service.ErrorRosterConflict, Service: "employee-service", HttpStatus: http.StatusConflict, DefaultMsg: "Roster assignment conflicts with the current shifts", DetailsSchema: map[string]string{"employeeId": "number", "shiftDate": "string", "shiftType": "number", "reason": "string"}},
+		{Code: service.ErrorScheduleNotFound, Service: "employee-service", HttpStatus: http.StatusNotFound, DefaultMsg: "Shift template or capacity not found"},
+		{Code: service.ErrorScheduleInvalidHours, Service: "employee-service", HttpStatus: http.StatusBadRequest, DefaultMsg: "Shifts must start in order within the day"},
+		{Code: service.ErrorScheduleInvalidCapacity, Service: "employee-service", HttpStatus: http.StatusBadRequest, DefaultMsg: "Invalid shift capacity", DetailsSchema: map[string]string{"max": "number"}},
+		{Code: service.ErrorScheduleInvalidRange, Service: "employee-service", HttpStatus: http.StatusBadRequest, DefaultMsg: "Invalid effective period"},
+		{Code: service.ErrorScheduleOverlap, Service: "employee-service", HttpStatus: http.StatusConflict, DefaultMsg: "Another setting applies to some of these days"},
 	}
 	ctx.JSON(http.StatusOK, gin.H{
 		"service":  "employee-service",
@@ -1034,6 +1188,37 @@ func (h *employeeHandler) GetErrorCatalog(ctx *gin.Context) {
 	})
 }
 
+// forceParam reads the force query flag used to override the active emergency guard. Only administrators
+// may force; for anyone else it writes 403 and returns false.
+func forceParam(ctx *gin.Context) (bool, bool) {
+	if ctx.Query("force") != "true" {
+		return false, true
+	}
+	if role, _ := ctx.Get("role"); role != "Administrator" {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "Only administrators can force this action"})
+		return false, false
+	}
+	return true, true
+}
+
+// writeActiveEmergencyError writes the response for errors raised by the active emergency guard
+// and reports whether err was one of them.
+func writeActiveEmergencyError(ctx *gin.Context, err error) bool {
+	var appErr *commonv1.AppError
+	if !errors.As(err, &appErr) {
+		return false
+	}
+	switch appErr.Code {
+	case service.ErrorActiveEmergency:
+		ctx.JSON(http.StatusConflict, gin.H{"error": appErr.Code, "message": appErr.Message, "details": appErr.Details})
+	case service.ErrorActiveEmergencyCheckFailed:
+		ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": appErr.Code, "message": appErr.Message})
+	default:
+		return false
+	}
+	return true
+}
+
 // requestContext safely extracts a context from gin.Context; falls back to Background.
 func requestContext(ctx *gin.Context) context.Context {
 	if ctx != nil && ctx.Request != nil {
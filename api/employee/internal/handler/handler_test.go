@@ -2,6 +2,7 @@ package handler
 
 import (
 	"bytes"
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -22,6 +23,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
 	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
 	"github.com/pd120424d/mountain-service/api/employee/internal/model"
 	"github.com/pd120424d/mountain-service/api/employee/internal/service"
@@ -910,6 +912,70 @@ func TestEmployeeHandler_UpdateEmployee(t *testing.T) {
 	})
 }
 
+func TestEmployeeHandler_UpdatePosition(t *testing.T) {
+	t.Parallel()
+
+	newCtx := func(id string, caller uint, body string) (*gin.Context, *httptest.ResponseRecorder) {
+		gin.SetMode(gin.TestMode)
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = gin.Params{{Key: "id", Value: id}}
+		ctx.Request = httptest.NewRequest(http.MethodPut, "/employees/"+id+"/position", strings.NewReader(body))
+		ctx.Request.Header.Set("Content-Type", "application/json")
+		ctx.Set("employeeID", caller)
+		return ctx, w
+	}
+
+	t.Run("it returns forbidden when reporting for another employee", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		handler := NewEmployeeHandler(utils.NewTestLogger(), afero.NewMemMapFs(), service.NewMockEmployeeService(ctrl), service.NewMockShiftService(ctrl))
+		ctx, w := newCtx("2", 1, `{"latitude":43.4,"longitude":22.66}`)
+
+		handler.UpdatePosition(ctx)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("it returns bad request for out of range coordinates", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		handler := NewEmployeeHandler(utils.NewTestLogger(), afero.NewMemMapFs(), service.NewMockEmployeeService(ctrl), service.NewMockShiftService(ctrl))
+		ctx, w := newCtx("1", 1, `{"latitude":95,"longitude":22.66}`)
+
+		handler.UpdatePosition(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "latitude must be between -90 and 90")
+	})
+
+	t.Run("it returns not found when the employee does not exist", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockEmplSvc := service.NewMockEmployeeService(ctrl)
+		mockEmplSvc.EXPECT().UpdatePosition(gomock.Any(), uint(1), gomock.Any()).Return(fmt.Errorf("employee not found"))
+		handler := NewEmployeeHandler(utils.NewTestLogger(), afero.NewMemMapFs(), mockEmplSvc, service.NewMockShiftService(ctrl))
+		ctx, w := newCtx("1", 1, `{"latitude":43.4,"longitude":22.66}`)
+
+		handler.UpdatePosition(ctx)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("it stores the position of the caller", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockEmplSvc := service.NewMockEmployeeService(ctrl)
+		mockEmplSvc.EXPECT().UpdatePosition(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(func(_ context.Context, _ uint, req employeeV1.EmployeePositionRequest) error {
+			assert.Equal(t, 43.4, *req.Latitude)
+			assert.Equal(t, 22.66, *req.Longitude)
+			return nil
+		})
+		handler := NewEmployeeHandler(utils.NewTestLogger(), afero.NewMemMapFs(), mockEmplSvc, service.NewMockShiftService(ctrl))
+		ctx, w := newCtx("1", 1, `{"latitude":43.4,"longitude":22.66}`)
+
+		handler.UpdatePosition(ctx)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+}
+
 func TestEmployeeHandler_DeleteEmployee(t *testing.T) {
 	t.Parallel()
 
@@ -951,7 +1017,7 @@ func TestEmployeeHandler_DeleteEmployee(t *testing.T) {
 
 		ctx.Request = httptest.NewRequest(http.MethodDelete, "/employees/1", nil)
 
-		mockEmplSvc.EXPECT().DeleteEmployee(gomock.Any(), uint(1)).Return(fmt.Errorf("database error"))
+		mockEmplSvc.EXPECT().DeleteEmployee(gomock.Any(), uint(1), false).Return(fmt.Errorf("database error"))
 
 		handler.DeleteEmployee(ctx)
 
@@ -975,13 +1041,67 @@ func TestEmployeeHandler_DeleteEmployee(t *testing.T) {
 
 		ctx.Request = httptest.NewRequest(http.MethodDelete, "/employees/1", nil)
 
-		mockEmplSvc.EXPECT().DeleteEmployee(gomock.Any(), uint(1)).Return(nil)
+		mockEmplSvc.EXPECT().DeleteEmployee(gomock.Any(), uint(1), false).Return(nil)
 
 		handler.DeleteEmployee(ctx)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 		assert.Contains(t, w.Body.String(), "Employee deleted successfully")
 	})
+	t.Run("it returns StatusConflict when the employee is responding to an emergency", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEmplSvc := service.NewMockEmployeeService(ctrl)
+		mockShiftSvc := service.NewMockShiftService(ctrl)
+		log := utils.NewTestLogger()
+		handler := NewEmployeeHandler(log, afero.NewMemMapFs(), mockEmplSvc, mockShiftSvc)
+
+		gin.SetMode(gin.TestMode)
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = gin.Params{{Key: "id", Value: "1"}}
+
+		ctx.Request = httptest.NewRequest(http.MethodDelete, "/employees/1", nil)
+
+		mockEmplSvc.EXPECT().DeleteEmployee(gomock.Any(), uint(1), false).
+			Return(commonv1.NewAppError(service.ErrorActiveEmergency, "employee is responding to an active emergency", map[string]interface{}{"urgencyIds": []uint{4}}))
+
+		handler.DeleteEmployee(ctx)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), service.ErrorActiveEmergency)
+	})
+
+	t.Run("it only lets administrators force the deletion", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEmplSvc := service.NewMockEmployeeService(ctrl)
+		mockShiftSvc := service.NewMockShiftService(ctrl)
+		log := utils.NewTestLogger()
+		handler := NewEmployeeHandler(log, afero.NewMemMapFs(), mockEmplSvc, mockShiftSvc)
+
+		gin.SetMode(gin.TestMode)
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = gin.Params{{Key: "id", Value: "1"}}
+		ctx.Request = httptest.NewRequest(http.MethodDelete, "/employees/1?force=true", nil)
+		ctx.Set("role", "Medic")
+
+		handler.DeleteEmployee(ctx)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = httptest.NewRecorder()
+		ctx, _ = gin.CreateTestContext(w)
+		ctx.Params = gin.Params{{Key: "id", Value: "1"}}
+		ctx.Request = httptest.NewRequest(http.MethodDelete, "/employees/1?force=true", nil)
+		ctx.Set("role", "Administrator")
+		mockEmplSvc.EXPECT().DeleteEmployee(gomock.Any(), uint(1), true).Return(nil)
+
+		handler.DeleteEmployee(ctx)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
 }
 
 func TestEmployeeHandler_AssignShift(t *testing.T) {
@@ -1514,7 +1634,7 @@ func TestEmployeeHandler_RemoveShift(t *testing.T) {
 		ctx.Request = httptest.NewRequest(http.MethodDelete, "/employees/1/shifts", bytes.NewReader(payload))
 		ctx.Request.Header.Set("Content-Type", "application/json")
 
-		mockShiftSvc.EXPECT().RemoveShift(gomock.Any(), uint(1), req).Return(fmt.Errorf("invalid shift date format"))
+		mockShiftSvc.EXPECT().RemoveShift(gomock.Any(), uint(1), req, false).Return(fmt.Errorf("invalid shift date format"))
 
 		handler.RemoveShift(ctx)
 
@@ -1544,7 +1664,7 @@ func TestEmployeeHandler_RemoveShift(t *testing.T) {
 		ctx.Request = httptest.NewRequest(http.MethodDelete, "/employees/1/shifts", bytes.NewReader(payload))
 		ctx.Request.Header.Set("Content-Type", "application/json")
 
-		mockShiftSvc.EXPECT().RemoveShift(gomock.Any(), uint(1), req).Return(fmt.Errorf("database error"))
+		mockShiftSvc.EXPECT().RemoveShift(gomock.Any(), uint(1), req, false).Return(fmt.Errorf("database error"))
 
 		handler.RemoveShift(ctx)
 
@@ -1552,6 +1672,37 @@ func TestEmployeeHandler_RemoveShift(t *testing.T) {
 		assert.Contains(t, w.Body.String(), "internal server error")
 	})
 
+	t.Run("it returns StatusServiceUnavailable when active emergencies cannot be checked", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEmplSvc := service.NewMockEmployeeService(ctrl)
+		mockShiftSvc := service.NewMockShiftService(ctrl)
+		log := utils.NewTestLogger()
+		handler := NewEmployeeHandler(log, afero.NewMemMapFs(), mockEmplSvc, mockShiftSvc)
+
+		gin.SetMode(gin.TestMode)
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = gin.Params{{Key: "id", Value: "1"}}
+
+		req := employeeV1.RemoveShiftRequest{
+			ShiftDate: "2024-01-15",
+			ShiftType: 1,
+		}
+		payload, _ := json.Marshal(req)
+		ctx.Request = httptest.NewRequest(http.MethodDelete, "/employees/1/shifts", bytes.NewReader(payload))
+		ctx.Request.Header.Set("Content-Type", "application/json")
+
+		mockShiftSvc.EXPECT().RemoveShift(gomock.Any(), uint(1), req, false).
+			Return(commonv1.NewAppError(service.ErrorActiveEmergencyCheckFailed, "failed to check active emergencies", nil))
+
+		handler.RemoveShift(ctx)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Contains(t, w.Body.String(), service.ErrorActiveEmergencyCheckFailed)
+	})
+
 	t.Run("it successfully removes shift", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -1574,7 +1725,7 @@ func TestEmployeeHandler_RemoveShift(t *testing.T) {
 		ctx.Request = httptest.NewRequest(http.MethodDelete, "/employees/1/shifts", bytes.NewReader(payload))
 		ctx.Request.Header.Set("Content-Type", "application/json")
 
-		mockShiftSvc.EXPECT().RemoveShift(gomock.Any(), uint(1), req).Return(nil)
+		mockShiftSvc.EXPECT().RemoveShift(gomock.Any(), uint(1), req, false).Return(nil)
 
 		handler.RemoveShift(ctx)
 
@@ -1816,12 +1967,37 @@ func TestEmployeeHandler_CheckActiveEmergencies(t *testing.T) {
 		}
 
 		mockEmplSvc.EXPECT().GetEmployeeByID(gomock.Any(), uint(1)).Return(employee, nil)
+		mockEmplSvc.EXPECT().CheckActiveEmergencies(gomock.Any(), uint(1)).Return(&employeeV1.ActiveEmergenciesResponse{HasActiveEmergencies: true, UrgencyIDs: []uint{4}}, nil)
 
 		handler.CheckActiveEmergencies(ctx)
 
 		assert.Equal(t, http.StatusOK, w.Code)
-		assert.Contains(t, w.Body.String(), "hasActiveEmergencies")
-		assert.Contains(t, w.Body.String(), "false") // Currently always returns false as per TODO
+		assert.Contains(t, w.Body.String(), `"hasActiveEmergencies":true`)
+		assert.Contains(t, w.Body.String(), `"urgencyIds":[4]`)
+	})
+
+	t.Run("it returns StatusServiceUnavailable when the urgency service cannot be reached", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEmplSvc := service.NewMockEmployeeService(ctrl)
+		mockShiftSvc := service.NewMockShiftService(ctrl)
+		log := utils.NewTestLogger()
+		handler := NewEmployeeHandler(log, afero.NewMemMapFs(), mockEmplSvc, mockShiftSvc)
+
+		gin.SetMode(gin.TestMode)
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = gin.Params{{Key: "id", Value: "1"}}
+
+		ctx.Request = httptest.NewRequest(http.MethodGet, "/employees/1/emergencies", nil)
+
+		mockEmplSvc.EXPECT().GetEmployeeByID(gomock.Any(), uint(1)).Return(&model.Employee{ID: 1}, nil)
+		mockEmplSvc.EXPECT().CheckActiveEmergencies(gomock.Any(), uint(1)).Return(nil, fmt.Errorf("failed to check active emergencies"))
+
+		handler.CheckActiveEmergencies(ctx)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
 	})
 }
 
@@ -2042,6 +2218,26 @@ func TestEmployeeHandler_GetAdminShiftsAvailability(t *testing.T) {
 		assert.Contains(t, w.Body.String(), "Days must be a number between 1 and 90")
 	})
 
+	t.Run("it returns an error when profile type parameter is not staffed by shifts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEmplSvc := service.NewMockEmployeeService(ctrl)
+		mockShiftSvc := service.NewMockShiftService(ctrl)
+		log := utils.NewTestLogger()
+		handler := NewEmployeeHandler(log, afero.NewMemMapFs(), mockEmplSvc, mockShiftSvc)
+
+		gin.SetMode(gin.TestMode)
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest("GET", "/admin/shifts/availability?profileType=Administrator", nil)
+
+		handler.GetAdminShiftsAvailability(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Profile type must be Medic or Technical")
+	})
+
 	tests := []struct {
 		name           string
 		queryParams    string
@@ -2053,7 +2249,7 @@ func TestEmployeeHandler_GetAdminShiftsAvailability(t *testing.T) {
 			name:        "it returns StatusInternalServerError when service call fails",
 			queryParams: "?days=7",
 			setupMocks: func(mockShiftSvc *service.MockShiftService) {
-				mockShiftSvc.EXPECT().GetShiftsAvailability(gomock.Any(), uint(1), 7).Return(nil, fmt.Errorf("database error"))
+				mockShiftSvc.EXPECT().GetAdminShiftsAvailability(gomock.Any(), 7, model.ProfileType("")).Return(nil, fmt.Errorf("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody:   `"error":"Failed to retrieve shifts availability"`,
@@ -2063,31 +2259,31 @@ func TestEmployeeHandler_GetAdminShiftsAvailability(t *testing.T) {
 			queryParams: "",
 			setupMocks: func(mockShiftSvc *service.MockShiftService) {
 				testDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
-				response := &employeeV1.ShiftAvailabilityResponse{
-					Days: map[time.Time]employeeV1.ShiftAvailabilityPerDay{
+				response := &employeeV1.AdminShiftAvailabilityResponse{
+					Days: map[time.Time]employeeV1.AdminShiftAvailabilityPerDay{
 						testDate: {
-							FirstShift: employeeV1.ShiftAvailability{
+							FirstShift: employeeV1.AdminShiftAvailability{ShiftAvailability: employeeV1.ShiftAvailability{
 								MedicSlotsAvailable:     2,
 								TechnicalSlotsAvailable: 4,
 								IsAssignedToEmployee:    false,
 								IsFullyBooked:           false,
-							},
-							SecondShift: employeeV1.ShiftAvailability{
+							}},
+							SecondShift: employeeV1.AdminShiftAvailability{ShiftAvailability: employeeV1.ShiftAvailability{
 								MedicSlotsAvailable:     1,
 								TechnicalSlotsAvailable: 2,
 								IsAssignedToEmployee:    false,
 								IsFullyBooked:           false,
-							},
-							ThirdShift: employeeV1.ShiftAvailability{
+							}},
+							ThirdShift: employeeV1.AdminShiftAvailability{ShiftAvailability: employeeV1.ShiftAvailability{
 								MedicSlotsAvailable:     2,
 								TechnicalSlotsAvailable: 4,
 								IsAssignedToEmployee:    false,
 								IsFullyBooked:           false,
-							},
+							}},
 						},
 					},
 				}
-				mockShiftSvc.EXPECT().GetShiftsAvailability(gomock.Any(), uint(1), 7).Return(response, nil)
+				mockShiftSvc.EXPECT().GetAdminShiftsAvailability(gomock.Any(), 7, model.ProfileType("")).Return(response, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody:   `"days"`,
@@ -2097,35 +2293,58 @@ func TestEmployeeHandler_GetAdminShiftsAvailability(t *testing.T) {
 			queryParams: "?days=14",
 			setupMocks: func(mockShiftSvc *service.MockShiftService) {
 				testDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
-				response := &employeeV1.ShiftAvailabilityResponse{
-					Days: map[time.Time]employeeV1.ShiftAvailabilityPerDay{
+				response := &employeeV1.AdminShiftAvailabilityResponse{
+					Days: map[time.Time]employeeV1.AdminShiftAvailabilityPerDay{
 						testDate: {
-							FirstShift: employeeV1.ShiftAvailability{
+							FirstShift: employeeV1.AdminShiftAvailability{ShiftAvailability: employeeV1.ShiftAvailability{
 								MedicSlotsAvailable:     2,
 								TechnicalSlotsAvailable: 4,
 								IsAssignedToEmployee:    false,
 								IsFullyBooked:           false,
-							},
-							SecondShift: employeeV1.ShiftAvailability{
+							}},
+							SecondShift: employeeV1.AdminShiftAvailability{ShiftAvailability: employeeV1.ShiftAvailability{
 								MedicSlotsAvailable:     1,
 								TechnicalSlotsAvailable: 2,
 								IsAssignedToEmployee:    false,
 								IsFullyBooked:           false,
-							},
-							ThirdShift: employeeV1.ShiftAvailability{
+							}},
+							ThirdShift: employeeV1.AdminShiftAvailability{ShiftAvailability: employeeV1.ShiftAvailability{
 								MedicSlotsAvailable:     2,
 								TechnicalSlotsAvailable: 4,
 								IsAssignedToEmployee:    false,
 								IsFullyBooked:           false,
-							},
+							}},
 						},
 					},
 				}
-				mockShiftSvc.EXPECT().GetShiftsAvailability(gomock.Any(), uint(1), 14).Return(response, nil)
+				mockShiftSvc.EXPECT().GetAdminShiftsAvailability(gomock.Any(), 14, model.ProfileType("")).Return(response, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody:   `"days"`,
 		},
+		{
+			name:        "it passes the profile filter to the service",
+			queryParams: "?days=3&profileType=Medic",
+			setupMocks: func(mockShiftSvc *service.MockShiftService) {
+				testDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+				response := &employeeV1.AdminShiftAvailabilityResponse{
+					Days: map[time.Time]employeeV1.AdminShiftAvailabilityPerDay{
+						testDate: {
+							FirstShift: employeeV1.AdminShiftAvailability{
+								ShiftAvailability:    employeeV1.ShiftAvailability{MedicSlotsAvailable: 1},
+								Employees:            map[string][]employeeV1.ShiftRosterEntry{"Medic": {{EmployeeID: 3, Name: "Ana A"}}},
+								Capacity:             map[string]int{"Medic": 2},
+								UnderstaffedProfiles: []string{"Medic"},
+								IsUnderstaffed:       true,
+							},
+						},
+					},
+				}
+				mockShiftSvc.EXPECT().GetAdminShiftsAvailability(gomock.Any(), 3, model.Medic).Return(response, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"medicSlotsAvailable":1`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -2260,7 +2479,7 @@ func TestEmployeeHandler_GetErrorCatalog(t *testing.T) {
 		ctx, _ := gin.CreateTestContext(w)
 		ctx.Request = httptest.NewRequest(http.MethodGet, "/errors/catalog", nil)
 
-		expectedResult := `{"errors":[{"code":"SHIFT_ERRORS.CONSECUTIVE_SHIFTS_LIMIT","service":"employee-service","httpStatus":409,"defaultMessage":"Exceeded consecutive days limit","detailsSchema":{"limit":"number"}},{"code":"SHIFT_ERRORS.ALREADY_ASSIGNED","service":"employee-service","httpStatus":409,"defaultMessage":"Employee is already assigned to this shift"},{"code":"SHIFT_ERRORS.CAPACITY_FULL","service":"employee-service","httpStatus":409,"defaultMessage":"Shift capacity is full for role"},{"code":"VALIDATION.INVALID_SHIFT_DATE","service":"employee-service","httpStatus":400,"defaultMessage":"Invalid shift date format"},{"code":"VALIDATION.SHIFT_IN_PAST","service":"employee-service","httpStatus":400,"defaultMessage":"Shift date must be in the future"},{"code":"VALIDATION.SHIFT_TOO_FAR","service":"employee-service","httpStatus":400,"defaultMessage":"Shift date cannot be more than 3 months in the future"},{"code":"EMPLOYEE_ERRORS.NOT_FOUND","service":"employee-service","httpStatus":404,"defaultMessage":"Employee not found"}],"service":"employee-service","warnings":[{"code":"SHIFT_WARNINGS.INSUFFICIENT_SHIFTS","service":"employee-service","httpStatus":200,"defaultMessage":"Insufficient shifts in the next period","detailsSchema":{"count":"number","perWeek":"number","periodDays":"number"}}]}`
+		expectedResult := `{"errors":[{"code":"SHIFT_ERRORS.CONSECUTIVE_SHIFTS_LIMIT","service":"employee-service","httpStatus":409,"defaultMessage":"Exceeded consecutive days limit","detailsSchema":{"limit":"number"}},{"code":"SHIFT_ERRORS.ALREADY_ASSIGNED","service":"employee-service","httpStatus":409,"defaultMessage":"Employee is already assigned to this shift"},{"code":"SHIFT_ERRORS.CAPACITY_FULL","service":"employee-service","httpStatus":409,"defaultMessage":"Shift capacity is full for role"},{"code":"VALIDATION.INVALID_SHIFT_DATE","service":"employee-service","httpStatus":400,"defaultMessage":"Invalid shift date format"},{"code":"VALIDATION.SHIFT_IN_PAST","service":"employee-service","httpStatus":400,"defaultMessage":"Shift date must be in the future"},{"code":"VALIDATION.SHIFT_TOO_FAR","service":"employee-service","httpStatus":400,"defaultMessage":"Shift date cannot be more than 3 months in the future"},{"code":"EMPLOYEE_ERRORS.NOT_FOUND","service":"employee-service","httpStatus":404,"defaultMessage":"Employee not found"},{"code":"EMPLOYEE_ERRORS.ACTIVE_EMERGENCY","service":"employee-service","httpStatus":409,"defaultMessage":"Employee is responding to an active emergency","detailsSchema":{"urgencyIds":"number[]"}},{"code":"EMPLOYEE_ERRORS.ACTIVE_EMERGENCY_CHECK_FAILED","service":"employee-service","httpStatus":503,"defaultMessage":"Active emergencies could not be checked"},{"code":"SHIFT_SWAP_ERRORS.NOT_FOUND","service":"employee-service","httpStatus":404,"defaultMessage":"Shift swap not found"},{"code":"SHIFT_SWAP_ERRORS.NOT_ASSIGNED","service":"employee-service","httpStatus":400,"defaultMessage":"Employee is not assigned to this shift"},{"code":"SHIFT_SWAP_ERRORS.ALREADY_OFFERED","service":"employee-service","httpStatus":409,"defaultMessage":"Shift is already offered"},{"code":"SHIFT_SWAP_ERRORS.PROFILE_MISMATCH","service":"employee-service","httpStatus":403,"defaultMessage":"Only staff of the same profile can take this shift","detailsSchema":{"role":"string"}},{"code":"SHIFT_SWAP_ERRORS.FORBIDDEN","service":"employee-service","httpStatus":403,"defaultMessage":"Not allowed to act on this shift swap"},{"code":"SHIFT_SWAP_ERRORS.INVALID_STATE","service":"employee-service","httpStatus":409,"defaultMessage":"Shift swap is not in a state that allows this action","detailsSchema":{"status":"string"}},{"code":"SHIFT_SWAP_ERRORS.INVALID_STATUS","service":"employee-service","httpStatus":400,"defaultMessage":"Unknown shift swap status"},{"code":"SHIFT_ERRORS.EMPLOYEE_ON_LEAVE","service":"employee-service","httpStatus":409,"defaultMessage":"Employee is on leave that day","detailsSchema":{"date":"string"}},{"code":"LEAVE_ERRORS.NOT_FOUND","service":"employee-service","httpStatus":404,"defaultMessage":"Leave request not found"},{"code":"LEAVE_ERRORS.INVALID_TYPE","service":"employee-service","httpStatus":400,"defaultMessage":"Unknown leave type"},{"code":"LEAVE_ERRORS.INVALID_STATUS","service":"employee-service","httpStatus":400,"defaultMessage":"Unknown leave status"},{"code":"LEAVE_ERRORS.INVALID_RANGE","service":"employee-service","httpStatus":400,"defaultMessage":"Invalid leave dates","detailsSchema":{"max":"number"}},{"code":"LEAVE_ERRORS.OVERLAP","service":"employee-service","httpStatus":409,"defaultMessage":"Leave overlaps an existing request","detailsSchema":{"leaveId":"number"}},{"code":"LEAVE_ERRORS.FORBIDDEN","service":"employee-service","httpStatus":403,"defaultMessage":"Not allowed to act on this leave request"},{"code":"LEAVE_ERRORS.INVALID_STATE","service":"employee-service","httpStatus":409,"defaultMessage":"Leave request is not in a state that allows this action","detailsSchema":{"status":"string"}},{"code":"LEAVE_ERRORS.SHIFT_CONFLICT","service":"employee-service","httpStatus":409,"defaultMessage":"Employee is assigned to shifts during the leave","detailsSchema":{"shifts":"string[]"}},{"code":"ROSTER_ERRORS.INVALID_RANGE","service":"employee-service","httpStatus":400,"defaultMessage":"Invalid roster period"},{"code":"ROSTER_ERRORS.INVALID_ASSIGNMENT","service":"employee-service","httpStatus":400,"defaultMessage":"Invalid roster assignment","detailsSchema":{"employeeId":"number","shiftDate":"string","shiftType":"number"}},{"code":"ROSTER_ERRORS.CONFLICT","service":"employee-service","httpStatus":409,"defaultMessage":"Roster assignment conflicts with the current shifts","detailsSchema":{"employeeId":"number","reason":"string","shiftDate":"string","shiftType":"number"}},{"code":"SCHEDULE_ERRORS.NOT_FOUND","service":"employee-service","httpStatus":404,"defaultMessage":"Shift template or capacity not found"},{"code":"SCHEDULE_ERRORS.INVALID_HOURS","service":"employee-service","httpStatus":400,"defaultMessage":"Shifts must start in order within the day"},{"code":"SCHEDULE_ERRORS.INVALID_CAPACITY","service":"employee-service","httpStatus":400,"defaultMessage":"Invalid shift capacity","detailsSchema":{"max":"number"}},{"code":"SCHEDULE_ERRORS.INVALID_RANGE","service":"employee-service","httpStatus":400,"defaultMessage":"Invalid effective period"},{"code":"SCHEDULE_ERRORS.OVERLAP","service":"employee-service","httpStatus":409,"defaultMessage":"Another setting applies to some of these days"}],"service":"employee-service","warnings":[{"code":"SHIFT_WARNINGS.INSUFFICIENT_SHIFTS","service":"employee-service","httpStatus":200,"defaultMessage":"Insufficient shifts in the next period","detailsSchema":{"count":"number","perWeek":"number","periodDays":"number"}}]}`
 
 		handler.GetErrorCatalog(ctx)
 
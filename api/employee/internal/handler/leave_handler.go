@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
+	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+	"github.com/pd120424d/mountain-service/api/employee/internal/service"
+	"github.com/pd120424d/mountain-service/api/shared/config"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+type LeaveRequestCreate = employeeV1.LeaveRequestCreate
+type LeaveReviewRequest = employeeV1.LeaveReviewRequest
+type LeaveRequestResponse = employeeV1.LeaveRequestResponse
+
+// RequestLeave Захтев за одсуство
+// @Summary Захтев за одсуство
+// @Description Запослени подноси захтев за годишњи одмор, боловање, обуку или друго одсуство. Захтев чека одобрење администратора.
+// @Tags одсуства
+// @Security OAuth2Password
+// @Accept json
+// @Produce json
+// @Param leave body LeaveRequestCreate true "Врста и период одсуства (датуми укључени)"
+// @Success 201 {object} LeaveRequestResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /leave-requests [post]
+func (h *employeeHandler) RequestLeave(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "EmployeeHandler.RequestLeave")()
+
+	actorID, _, ok := requestActor(ctx)
+	if !ok {
+		return
+	}
+
+	var req employeeV1.LeaveRequestCreate
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorf("invalid leave request payload: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.shiftService.RequestLeave(requestContext(ctx), actorID, req)
+	if err != nil {
+		log.Errorf("failed to request leave: %v", err)
+		writeLeaveError(ctx, err)
+		return
+	}
+
+	utils.WriteFreshWindow(ctx, config.DefaultFreshWindow)
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// ListLeaveRequests Листа захтева за одсуство
+// @Summary Листа захтева за одсуство
+// @Description Враћа захтеве запосленог, администратори могу видети захтеве свих или изабраног запосленог
+// @Tags одсуства
+// @Security OAuth2Password
+// @Produce json
+// @Param status query string false "Филтер по статусу (pending, approved, rejected, cancelled)"
+// @Param employeeId query int false "ID запосленог (само за администраторе)"
+// @Success 200 {array} LeaveRequestResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /leave-requests [get]
+func (h *employeeHandler) ListLeaveRequests(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "EmployeeHandler.ListLeaveRequests")()
+
+	actorID, isAdmin, ok := requestActor(ctx)
+	if !ok {
+		return
+	}
+
+	var employeeID uint
+	if raw := ctx.Query("employeeId"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee ID"})
+			return
+		}
+		employeeID = uint(id)
+	}
+
+	resp, err := h.shiftService.ListLeaveRequests(requestContext(ctx), actorID, isAdmin, employeeID, employeeV1.LeaveStatus(ctx.Query("status")))
+	if err != nil {
+		log.Errorf("failed to list leave requests: %v", err)
+		writeLeaveError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// CancelLeaveRequest Отказивање одсуства
+// @Summary Отказивање одсуства
+// @Description Запослени или администратор отказује захтев на чекању или одобрено одсуство које још није завршено
+// @Tags одсуства
+// @Security OAuth2Password
+// @Produce json
+// @Param id path int true "ID захтева"
+// @Success 200 {object} LeaveRequestResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /leave-requests/{id} [delete]
+func (h *employeeHandler) CancelLeaveRequest(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "EmployeeHandler.CancelLeaveRequest")()
+
+	leaveID, ok := leaveIDParam(ctx)
+	if !ok {
+		return
+	}
+	actorID, isAdmin, ok := requestActor(ctx)
+	if !ok {
+		return
+	}
+
+	resp, err := h.shiftService.CancelLeaveRequest(requestContext(ctx), leaveID, actorID, isAdmin)
+	h.writeLeaveResult(ctx, log, resp, err)
+}
+
+// ApproveLeaveRequest Одобравање одсуства (само за админе)
+// @Summary Одобравање одсуства
+// @Description Администратор одобрава захтев за одсуство. Запослени не сме имати смене у том периоду.
+// @Tags админ
+// @Security OAuth2Password
+// @Accept json
+// @Produce json
+// @Param id path int true "ID захтева"
+// @Param review body LeaveReviewRequest false "Напомена администратора"
+// @Success 200 {object} LeaveRequestResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/leave-requests/{id}/approve [post]
+func (h *employeeHandler) ApproveLeaveRequest(ctx *gin.Context) {
+	h.reviewLeaveRequest(ctx, true)
+}
+
+// RejectLeaveRequest Одбијање одсуства (само за админе)
+// @Summary Одбијање одсуства
+// @Description Администратор одбија захтев за одсуство
+// @Tags админ
+// @Security OAuth2Password
+// @Accept json
+// @Produce json
+// @Param id path int true "ID захтева"
+// @Param review body LeaveReviewRequest false "Напомена администратора"
+// @Success 200 {object} LeaveRequestResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/leave-requests/{id}/reject [post]
+func (h *employeeHandler) RejectLeaveRequest(ctx *gin.Context) {
+	h.reviewLeaveRequest(ctx, false)
+}
+
+func (h *employeeHandler) reviewLeaveRequest(ctx *gin.Context, approve bool) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "EmployeeHandler.ReviewLeaveRequest")()
+
+	leaveID, ok := leaveIDParam(ctx)
+	if !ok {
+		return
+	}
+	adminID, _, ok := requestActor(ctx)
+	if !ok {
+		return
+	}
+
+	// the note is optional, so an empty body is fine
+	var req employeeV1.LeaveReviewRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			log.Errorf("invalid leave review payload: %v", err)
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	resp, err := h.shiftService.ReviewLeaveRequest(requestContext(ctx), leaveID, adminID, approve, req.Note)
+	h.writeLeaveResult(ctx, log, resp, err)
+}
+
+func (h *employeeHandler) writeLeaveResult(ctx *gin.Context, log utils.Logger, resp *employeeV1.LeaveRequestResponse, err error) {
+	if err != nil {
+		log.Errorf("leave request failed: %v", err)
+		writeLeaveError(ctx, err)
+		return
+	}
+	utils.WriteFreshWindow(ctx, config.DefaultFreshWindow)
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func leaveIDParam(ctx *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid leave request ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// writeLeaveError maps leave request errors to responses
+func writeLeaveError(ctx *gin.Context, err error) {
+	var appErr *commonv1.AppError
+	if !errors.As(err, &appErr) {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	status := http.StatusBadRequest
+	switch appErr.Code {
+	case service.ErrorLeaveNotFound, "EMPLOYEE_ERRORS.NOT_FOUND":
+		status = http.StatusNotFound
+	case service.ErrorLeaveForbidden:
+		status = http.StatusForbidden
+	case service.ErrorLeaveOverlap, service.ErrorLeaveInvalidState, service.ErrorLeaveShiftConflict:
+		status = http.StatusConflict
+	}
+	ctx.JSON(status, gin.H{"error": appErr.Code, "message": appErr.Message, "details": appErr.Details})
+}
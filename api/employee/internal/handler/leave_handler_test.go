@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
+	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+	"github.com/pd120424d/mountain-service/api/employee/internal/service"
+)
+
+func TestEmployeeHandler_RequestLeave(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it creates the leave request", func(t *testing.T) {
+		handler, mockShiftSvc, ctx, w := newShiftHandlerContext(t, http.MethodPost, "/leave-requests", `{"type":"vacation","startDate":"2030-01-10","endDate":"2030-01-14"}`)
+		ctx.Set("employeeID", uint(1))
+		mockShiftSvc.EXPECT().RequestLeave(gomock.Any(), uint(1), employeeV1.LeaveRequestCreate{Type: employeeV1.LeaveVacation, StartDate: "2030-01-10", EndDate: "2030-01-14"}).
+			Return(&employeeV1.LeaveRequestResponse{ID: 3, Status: employeeV1.LeavePending, Days: 5}, nil)
+
+		handler.RequestLeave(ctx)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"days":5`)
+	})
+
+	t.Run("it rejects a payload without dates", func(t *testing.T) {
+		handler, _, ctx, w := newShiftHandlerContext(t, http.MethodPost, "/leave-requests", `{"type":"sick"}`)
+		ctx.Set("employeeID", uint(1))
+
+		handler.RequestLeave(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("it maps overlapping leave to a conflict", func(t *testing.T) {
+		handler, mockShiftSvc, ctx, w := newShiftHandlerContext(t, http.MethodPost, "/leave-requests", `{"type":"sick","startDate":"2030-01-10","endDate":"2030-01-10"}`)
+		ctx.Set("employeeID", uint(1))
+		mockShiftSvc.EXPECT().RequestLeave(gomock.Any(), uint(1), gomock.Any()).
+			Return(nil, commonv1.NewAppError(service.ErrorLeaveOverlap, "overlap", nil))
+
+		handler.RequestLeave(ctx)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), service.ErrorLeaveOverlap)
+	})
+}
+
+func TestEmployeeHandler_ListLeaveRequests(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it passes the administrator's filters", func(t *testing.T) {
+		handler, mockShiftSvc, ctx, w := newShiftHandlerContext(t, http.MethodGet, "/leave-requests?employeeId=2&status=approved", "")
+		ctx.Set("employeeID", uint(9))
+		ctx.Set("role", "Administrator")
+		mockShiftSvc.EXPECT().ListLeaveRequests(gomock.Any(), uint(9), true, uint(2), employeeV1.LeaveApproved).Return([]employeeV1.LeaveRequestResponse{}, nil)
+
+		handler.ListLeaveRequests(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("it rejects an invalid employee ID", func(t *testing.T) {
+		handler, _, ctx, w := newShiftHandlerContext(t, http.MethodGet, "/leave-requests?employeeId=abc", "")
+		ctx.Set("employeeID", uint(9))
+
+		handler.ListLeaveRequests(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestEmployeeHandler_ReviewLeaveRequest(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it approves with the administrator's note", func(t *testing.T) {
+		handler, mockShiftSvc, ctx, w := newShiftHandlerContext(t, http.MethodPost, "/admin/leave-requests/3/approve", `{"note":"enjoy"}`)
+		ctx.Set("employeeID", uint(9))
+		ctx.Params = gin.Params{{Key: "id", Value: "3"}}
+		mockShiftSvc.EXPECT().ReviewLeaveRequest(gomock.Any(), uint(3), uint(9), true, "enjoy").
+			Return(&employeeV1.LeaveRequestResponse{ID: 3, Status: employeeV1.LeaveApproved}, nil)
+
+		handler.ApproveLeaveRequest(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("it rejects without a body", func(t *testing.T) {
+		handler, mockShiftSvc, ctx, w := newShiftHandlerContext(t, http.MethodPost, "/admin/leave-requests/3/reject", "")
+		ctx.Set("employeeID", uint(9))
+		ctx.Params = gin.Params{{Key: "id", Value: "3"}}
+		mockShiftSvc.EXPECT().ReviewLeaveRequest(gomock.Any(), uint(3), uint(9), false, "").
+			Return(&employeeV1.LeaveRequestResponse{ID: 3, Status: employeeV1.LeaveRejected}, nil)
+
+		handler.RejectLeaveRequest(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"shifts during the leave", commonv1.NewAppError(service.ErrorLeaveShiftConflict, "conflict", nil), http.StatusConflict},
+		{"missing request", commonv1.NewAppError(service.ErrorLeaveNotFound, "not found", nil), http.StatusNotFound},
+		{"unexpected failure", errors.New("db down"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run("it maps "+tt.name, func(t *testing.T) {
+			handler, mockShiftSvc, ctx, w := newShiftHandlerContext(t, http.MethodPost, "/admin/leave-requests/3/approve", "")
+			ctx.Set("employeeID", uint(9))
+			ctx.Params = gin.Params{{Key: "id", Value: "3"}}
+			mockShiftSvc.EXPECT().ReviewLeaveRequest(gomock.Any(), uint(3), uint(9), true, "").Return(nil, tt.err)
+
+			handler.ApproveLeaveRequest(ctx)
+
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+}
+
+func TestEmployeeHandler_CancelLeaveRequest(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it maps someone else's leave to forbidden", func(t *testing.T) {
+		handler, mockShiftSvc, ctx, w := newShiftHandlerContext(t, http.MethodDelete, "/leave-requests/3", "")
+		ctx.Set("employeeID", uint(2))
+		ctx.Params = gin.Params{{Key: "id", Value: "3"}}
+		mockShiftSvc.EXPECT().CancelLeaveRequest(gomock.Any(), uint(3), uint(2), false).
+			Return(nil, commonv1.NewAppError(service.ErrorLeaveForbidden, "forbidden", nil))
+
+		handler.CancelLeaveRequest(ctx)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestEmployeeHandler_AssignShiftDuringLeave(t *testing.T) {
+	t.Parallel()
+
+	handler, mockShiftSvc, ctx, w := newShiftHandlerContext(t, http.MethodPost, "/employees/1/shifts", `{"shiftDate":"2030-01-10","shiftType":1}`)
+	ctx.Params = gin.Params{{Key: "id", Value: "1"}}
+	mockShiftSvc.EXPECT().AssignShift(gomock.Any(), uint(1), gomock.Any()).
+		Return(nil, commonv1.NewAppError(service.ErrorEmployeeOnLeave, "employee is on leave that day", nil))
+
+	handler.AssignShift(ctx)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), service.ErrorEmployeeOnLeave)
+}
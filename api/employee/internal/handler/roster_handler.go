@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
+	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+	"github.com/pd120424d/mountain-service/api/employee/internal/service"
+	"github.com/pd120424d/mountain-service/api/shared/config"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+type RosterDraftRequest = employeeV1.RosterDraftRequest
+type RosterDraftResponse = employeeV1.RosterDraftResponse
+type RosterCommitRequest = employeeV1.RosterCommitRequest
+type RosterCommitResponse = employeeV1.RosterCommitResponse
+
+// DraftRoster Предлог распореда смена (само за админе)
+// @Summary Предлог распореда смена
+// @Description Прави предлог распореда за период на основу одсустава, норме радних дана и капацитета смена. Ништа се не чува док се предлог не потврди.
+// @Tags админ
+// @Security OAuth2Password
+// @Accept json
+// @Produce json
+// @Param roster body RosterDraftRequest true "Период распореда (датуми укључени)"
+// @Success 200 {object} RosterDraftResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/rosters/draft [post]
+func (h *employeeHandler) DraftRoster(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "EmployeeHandler.DraftRoster")()
+
+	var req employeeV1.RosterDraftRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorf("invalid roster draft payload: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.shiftService.DraftRoster(requestContext(ctx), req)
+	if err != nil {
+		log.Errorf("failed to draft roster: %v", err)
+		writeRosterError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// CommitRoster Потврда распореда смена (само за админе)
+// @Summary Потврда распореда смена
+// @Description Додељује све смене из прегледаног распореда у једној трансакцији. Ако било која додела крши правила, ниједна се не чува.
+// @Tags админ
+// @Security OAuth2Password
+// @Accept json
+// @Produce json
+// @Param roster body RosterCommitRequest true "Доделе смена из предлога"
+// @Success 201 {object} RosterCommitResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/rosters/commit [post]
+func (h *employeeHandler) CommitRoster(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "EmployeeHandler.CommitRoster")()
+
+	var req employeeV1.RosterCommitRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorf("invalid roster commit payload: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.shiftService.CommitRoster(requestContext(ctx), req)
+	if err != nil {
+		log.Errorf("failed to commit roster: %v", err)
+		writeRosterError(ctx, err)
+		return
+	}
+
+	utils.WriteFreshWindow(ctx, config.DefaultFreshWindow)
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// writeRosterError maps roster errors to responses
+func writeRosterError(ctx *gin.Context, err error) {
+	var appErr *commonv1.AppError
+	if !errors.As(err, &appErr) {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	status := http.StatusBadRequest
+	if appErr.Code == service.ErrorRosterConflict {
+		status = http.StatusConflict
+	}
+	ctx.JSON(status, gin.H{"error": appErr.Code, "message": appErr.Message, "details": appErr.Details})
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
+	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+	"github.com/pd120424d/mountain-service/api/employee/internal/service"
+)
+
+func TestEmployeeHandler_DraftRoster(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it returns the draft", func(t *testing.T) {
+		handler, mockShiftSvc, ctx, w := newShiftHandlerContext(t, http.MethodPost, "/admin/rosters/draft", `{"startDate":"2030-01-10","endDate":"2030-01-16"}`)
+		mockShiftSvc.EXPECT().DraftRoster(gomock.Any(), employeeV1.RosterDraftRequest{StartDate: "2030-01-10", EndDate: "2030-01-16"}).
+			Return(&employeeV1.RosterDraftResponse{StartDate: "2030-01-10", EndDate: "2030-01-16", UnfilledSlots: 4}, nil)
+
+		handler.DraftRoster(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"unfilledSlots":4`)
+	})
+
+	t.Run("it maps an invalid period to a bad request", func(t *testing.T) {
+		handler, mockShiftSvc, ctx, w := newShiftHandlerContext(t, http.MethodPost, "/admin/rosters/draft", `{"startDate":"2030-01-16","endDate":"2030-01-10"}`)
+		mockShiftSvc.EXPECT().DraftRoster(gomock.Any(), gomock.Any()).Return(nil, commonv1.NewAppError(service.ErrorRosterInvalidRange, "bad range", nil))
+
+		handler.DraftRoster(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), service.ErrorRosterInvalidRange)
+	})
+}
+
+func TestEmployeeHandler_CommitRoster(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it creates the assignments", func(t *testing.T) {
+		handler, mockShiftSvc, ctx, w := newShiftHandlerContext(t, http.MethodPost, "/admin/rosters/commit", `{"assignments":[{"employeeId":1,"shiftDate":"2030-01-10","shiftType":2}]}`)
+		assignments := []employeeV1.RosterAssignment{{EmployeeID: 1, ShiftDate: "2030-01-10", ShiftType: 2}}
+		mockShiftSvc.EXPECT().CommitRoster(gomock.Any(), employeeV1.RosterCommitRequest{Assignments: assignments}).
+			Return(&employeeV1.RosterCommitResponse{Created: 1, Assignments: assignments}, nil)
+
+		handler.CommitRoster(ctx)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"created":1`)
+	})
+
+	t.Run("it rejects an empty roster", func(t *testing.T) {
+		handler, _, ctx, w := newShiftHandlerContext(t, http.MethodPost, "/admin/rosters/commit", `{"assignments":[]}`)
+
+		handler.CommitRoster(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("it maps a rule violation to a conflict", func(t *testing.T) {
+		handler, mockShiftSvc, ctx, w := newShiftHandlerContext(t, http.MethodPost, "/admin/rosters/commit", `{"assignments":[{"employeeId":1,"shiftDate":"2030-01-10","shiftType":2}]}`)
+		mockShiftSvc.EXPECT().CommitRoster(gomock.Any(), gomock.Any()).
+			Return(nil, commonv1.NewAppError(service.ErrorRosterConflict, "roster assignment breaks the shift rules", map[string]interface{}{"reason": service.ErrorEmployeeOnLeave}))
+
+		handler.CommitRoster(ctx)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), service.ErrorEmployeeOnLeave)
+	})
+}
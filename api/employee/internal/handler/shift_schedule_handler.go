@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
+	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+	"github.com/pd120424d/mountain-service/api/employee/internal/service"
+	"github.com/pd120424d/mountain-service/api/shared/config"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+type ShiftTemplateRequest = employeeV1.ShiftTemplateRequest
+type ShiftTemplateResponse = employeeV1.ShiftTemplateResponse
+type ShiftCapacityRequest = employeeV1.ShiftCapacityRequest
+type ShiftCapacityResponse = employeeV1.ShiftCapacityResponse
+
+// ListShiftTemplates Листа распореда радног времена смена (само за админе)
+// @Summary Листа распореда радног времена смена
+// @Description Враћа подешена времена почетка смена по периодима. Дани без подешавања користе 06-14, 14-22 и 22-06.
+// @Tags админ
+// @Security OAuth2Password
+// @Produce json
+// @Success 200 {array} ShiftTemplateResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/shift-templates [get]
+func (h *employeeHandler) ListShiftTemplates(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "EmployeeHandler.ListShiftTemplates")()
+
+	resp, err := h.shiftService.ListShiftTemplates(requestContext(ctx))
+	if err != nil {
+		log.Errorf("failed to list shift templates: %v", err)
+		writeScheduleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// CreateShiftTemplate Подешавање радног времена смена (само за админе)
+// @Summary Подешавање радног времена смена
+// @Description Поставља времена почетка три дневне смене за период. Свака смена траје до почетка следеће.
+// @Tags админ
+// @Security OAuth2Password
+// @Accept json
+// @Produce json
+// @Param template body ShiftTemplateRequest true "Времена почетка смена и период важења"
+// @Success 201 {object} ShiftTemplateResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/shift-templates [post]
+func (h *employeeHandler) CreateShiftTemplate(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "EmployeeHandler.CreateShiftTemplate")()
+
+	var req employeeV1.ShiftTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorf("invalid shift template payload: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.shiftService.CreateShiftTemplate(requestContext(ctx), req)
+	if err != nil {
+		log.Errorf("failed to create shift template: %v", err)
+		writeScheduleError(ctx, err)
+		return
+	}
+
+	utils.WriteFreshWindow(ctx, config.DefaultFreshWindow)
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// DeleteShiftTemplate Брисање радног времена смена (само за админе)
+// @Summary Брисање радног времена смена
+// @Description Брише подешавање; дани из тог периода враћају се на подразумевана времена смена
+// @Tags админ
+// @Security OAuth2Password
+// @Param id path int true "ID подешавања"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/shift-templates/{id} [delete]
+func (h *employeeHandler) DeleteShiftTemplate(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "EmployeeHandler.DeleteShiftTemplate")()
+
+	id, ok := scheduleIDParam(ctx)
+	if !ok {
+		return
+	}
+	if err := h.shiftService.DeleteShiftTemplate(requestContext(ctx), id); err != nil {
+		log.Errorf("failed to delete shift template: %v", err)
+		writeScheduleError(ctx, err)
+		return
+	}
+
+	utils.WriteFreshWindow(ctx, config.DefaultFreshWindow)
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// ListShiftCapacities Листа капацитета смена (само за админе)
+// @Summary Листа капацитета смена
+// @Description Враћа подешене капацитете смена по профилу и периоду. Дани без подешавања користе 2 медицинара и 4 техничара.
+// @Tags админ
+// @Security OAuth2Password
+// @Produce json
+// @Success 200 {array} ShiftCapacityResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/shift-capacities [get]
+func (h *employeeHandler) ListShiftCapacities(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "EmployeeHandler.ListShiftCapacities")()
+
+	resp, err := h.shiftService.ListShiftCapacities(requestContext(ctx))
+	if err != nil {
+		log.Errorf("failed to list shift capacities: %v", err)
+		writeScheduleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// CreateShiftCapacity Подешавање капацитета смена (само за админе)
+// @Summary Подешавање капацитета смена
+// @Description Поставља број запослених једног профила по смени за период
+// @Tags админ
+// @Security OAuth2Password
+// @Accept json
+// @Produce json
+// @Param capacity body ShiftCapacityRequest true "Профил, капацитет и период важења"
+// @Success 201 {object} ShiftCapacityResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/shift-capacities [post]
+func (h *employeeHandler) CreateShiftCapacity(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "EmployeeHandler.CreateShiftCapacity")()
+
+	var req employeeV1.ShiftCapacityRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorf("invalid shift capacity payload: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.shiftService.CreateShiftCapacity(requestContext(ctx), req)
+	if err != nil {
+		log.Errorf("failed to create shift capacity: %v", err)
+		writeScheduleError(ctx, err)
+		return
+	}
+
+	utils.WriteFreshWindow(ctx, config.DefaultFreshWindow)
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// DeleteShiftCapacity Брисање капацитета смена (само за админе)
+// @Summary Брисање капацитета смена
+// @Description Брише подешавање; дани из тог периода враћају се на подразумевани капацитет профила
+// @Tags админ
+// @Security OAuth2Password
+// @Param id path int true "ID подешавања"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/shift-capacities/{id} [delete]
+func (h *employeeHandler) DeleteShiftCapacity(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "EmployeeHandler.DeleteShiftCapacity")()
+
+	id, ok := scheduleIDParam(ctx)
+	if !ok {
+		return
+	}
+	if err := h.shiftService.DeleteShiftCapacity(requestContext(ctx), id); err != nil {
+		log.Errorf("failed to delete shift capacity: %v", err)
+		writeScheduleError(ctx, err)
+		return
+	}
+
+	utils.WriteFreshWindow(ctx, config.DefaultFreshWindow)
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+func scheduleIDParam(ctx *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// writeScheduleError maps shift template and capacity errors to responses
+func writeScheduleError(ctx *gin.Context, err error) {
+	var appErr *commonv1.AppError
+	if !errors.As(err, &appErr) {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	status := http.StatusBadRequest
+	switch appErr.Code {
+	case service.ErrorScheduleNotFound:
+		status = http.StatusNotFound
+	case service.ErrorScheduleOverlap:
+		status = http.StatusConflict
+	}
+	ctx.JSON(status, gin.H{"error": appErr.Code, "message": appErr.Message, "details": appErr.Details})
+}
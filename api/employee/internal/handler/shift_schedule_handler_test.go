@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
+	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+	"github.com/pd120424d/mountain-service/api/employee/internal/service"
+)
+
+func TestEmployeeHandler_ShiftTemplates(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it lists the templates", func(t *testing.T) {
+		handler, mockShiftSvc, ctx, w := newShiftHandlerContext(t, http.MethodGet, "/admin/shift-templates", "")
+		mockShiftSvc.EXPECT().ListShiftTemplates(gomock.Any()).
+			Return([]employeeV1.ShiftTemplateResponse{{ID: 1, FirstShiftStart: "07:00", SecondShiftStart: "15:00", ThirdShiftStart: "23:00"}}, nil)
+
+		handler.ListShiftTemplates(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"firstShiftStart":"07:00"`)
+	})
+
+	t.Run("it creates a template", func(t *testing.T) {
+		body := `{"firstShiftStart":"07:00","secondShiftStart":"15:00","thirdShiftStart":"23:00","effectiveFrom":"2030-01-10"}`
+		handler, mockShiftSvc, ctx, w := newShiftHandlerContext(t, http.MethodPost, "/admin/shift-templates", body)
+		mockShiftSvc.EXPECT().CreateShiftTemplate(gomock.Any(), employeeV1.ShiftTemplateRequest{FirstShiftStart: "07:00", SecondShiftStart: "15:00", ThirdShiftStart: "23:00", EffectiveFrom: "2030-01-10"}).
+			Return(&employeeV1.ShiftTemplateResponse{ID: 5}, nil)
+
+		handler.CreateShiftTemplate(ctx)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"id":5`)
+	})
+
+	t.Run("it rejects a template without a start date", func(t *testing.T) {
+		handler, _, ctx, w := newShiftHandlerContext(t, http.MethodPost, "/admin/shift-templates", `{"firstShiftStart":"07:00","secondShiftStart":"15:00","thirdShiftStart":"23:00"}`)
+
+		handler.CreateShiftTemplate(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("it maps an overlapping template to a conflict", func(t *testing.T) {
+		body := `{"firstShiftStart":"07:00","secondShiftStart":"15:00","thirdShiftStart":"23:00","effectiveFrom":"2030-01-10"}`
+		handler, mockShiftSvc, ctx, w := newShiftHandlerContext(t, http.MethodPost, "/admin/shift-templates", body)
+		mockShiftSvc.EXPECT().CreateShiftTemplate(gomock.Any(), gomock.Any()).Return(nil, commonv1.NewAppError(service.ErrorScheduleOverlap, "overlap", nil))
+
+		handler.CreateShiftTemplate(ctx)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), service.ErrorScheduleOverlap)
+	})
+
+	t.Run("it deletes a template", func(t *testing.T) {
+		handler, mockShiftSvc, ctx, w := newShiftHandlerContext(t, http.MethodDelete, "/admin/shift-templates/3", "")
+		ctx.Params = gin.Params{{Key: "id", Value: "3"}}
+		mockShiftSvc.EXPECT().DeleteShiftTemplate(gomock.Any(), uint(3)).Return(nil)
+
+		handler.DeleteShiftTemplate(ctx)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("it maps a missing template to not found", func(t *testing.T) {
+		handler, mockShiftSvc, ctx, w := newShiftHandlerContext(t, http.MethodDelete, "/admin/shift-templates/3", "")
+		ctx.Params = gin.Params{{Key: "id", Value: "3"}}
+		mockShiftSvc.EXPECT().DeleteShiftTemplate(gomock.Any(), uint(3)).Return(commonv1.NewAppError(service.ErrorScheduleNotFound, "not found", nil))
+
+		handler.DeleteShiftTemplate(ctx)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("it rejects an invalid id", func(t *testing.T) {
+		handler, _, ctx, w := newShiftHandlerContext(t, http.MethodDelete, "/admin/shift-templates/abc", "")
+		ctx.Params = gin.Params{{Key: "id", Value: "abc"}}
+
+		handler.DeleteShiftTemplate(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestEmployeeHandler_ShiftCapacities(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it creates a capacity", func(t *testing.T) {
+		handler, mockShiftSvc, ctx, w := newShiftHandlerContext(t, http.MethodPost, "/admin/shift-capacities", `{"profileType":"Medic","capacity":3,"effectiveFrom":"2030-01-10"}`)
+		mockShiftSvc.EXPECT().CreateShiftCapacity(gomock.Any(), employeeV1.ShiftCapacityRequest{ProfileType: "Medic", Capacity: 3, EffectiveFrom: "2030-01-10"}).
+			Return(&employeeV1.ShiftCapacityResponse{ID: 2, ProfileType: "Medic", Capacity: 3}, nil)
+
+		handler.CreateShiftCapacity(ctx)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"capacity":3`)
+	})
+
+	t.Run("it maps an invalid capacity to a bad request", func(t *testing.T) {
+		handler, mockShiftSvc, ctx, w := newShiftHandlerContext(t, http.MethodPost, "/admin/shift-capacities", `{"profileType":"Administrator","capacity":3,"effectiveFrom":"2030-01-10"}`)
+		mockShiftSvc.EXPECT().CreateShiftCapacity(gomock.Any(), gomock.Any()).Return(nil, commonv1.NewAppError(service.ErrorScheduleInvalidCapacity, "bad profile", nil))
+
+		handler.CreateShiftCapacity(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), service.ErrorScheduleInvalidCapacity)
+	})
+
+	t.Run("it returns an internal error when listing fails", func(t *testing.T) {
+		handler, mockShiftSvc, ctx, w := newShiftHandlerContext(t, http.MethodGet, "/admin/shift-capacities", "")
+		mockShiftSvc.EXPECT().ListShiftCapacities(gomock.Any()).Return(nil, errors.New("db down"))
+
+		handler.ListShiftCapacities(ctx)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("it deletes a capacity", func(t *testing.T) {
+		handler, mockShiftSvc, ctx, w := newShiftHandlerContext(t, http.MethodDelete, "/admin/shift-capacities/4", "")
+		ctx.Params = gin.Params{{Key: "id", Value: "4"}}
+		mockShiftSvc.EXPECT().DeleteShiftCapacity(gomock.Any(), uint(4)).Return(nil)
+
+		handler.DeleteShiftCapacity(ctx)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+}
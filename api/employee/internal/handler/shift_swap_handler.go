@@ -0,0 +1,332 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
+	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+	"github.com/pd120424d/mountain-service/api/employee/internal/model"
+	"github.com/pd120424d/mountain-service/api/employee/internal/service"
+	"github.com/pd120424d/mountain-service/api/shared/config"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+type OfferShiftRequest = employeeV1.OfferShiftRequest
+type ProposeShiftSwapRequest = employeeV1.ProposeShiftSwapRequest
+type ShiftSwapResponse = employeeV1.ShiftSwapResponse
+
+// OfferShift Нуди смену колегама
+// @Summary Нуди смену колегама
+// @Description Запослени нуди своју предстојећу смену колегама истог профила, да је преузму или замене за своју
+// @Tags смене
+// @Security OAuth2Password
+// @Accept json
+// @Produce json
+// @Param offer body OfferShiftRequest true "Смена која се нуди"
+// @Success 201 {object} ShiftSwapResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /shift-swaps [post]
+func (h *employeeHandler) OfferShift(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "EmployeeHandler.OfferShift")()
+
+	actorID, _, ok := requestActor(ctx)
+	if !ok {
+		return
+	}
+
+	var req employeeV1.OfferShiftRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorf("invalid shift offer payload: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.shiftService.OfferShift(requestContext(ctx), actorID, req)
+	if err != nil {
+		log.Errorf("failed to offer shift: %v", err)
+		writeShiftSwapError(ctx, err)
+		return
+	}
+
+	utils.WriteFreshWindow(ctx, config.DefaultFreshWindow)
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// ListShiftSwaps Листа понуђених смена
+// @Summary Листа понуђених смена
+// @Description Враћа понуде и замене смена за профил запосленог, администратори виде све
+// @Tags смене
+// @Security OAuth2Password
+// @Produce json
+// @Param status query string false "Филтер по статусу (open, proposed, pending_approval, completed, cancelled, rejected)"
+// @Success 200 {array} ShiftSwapResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /shift-swaps [get]
+func (h *employeeHandler) ListShiftSwaps(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "EmployeeHandler.ListShiftSwaps")()
+
+	actorID, isAdmin, ok := requestActor(ctx)
+	if !ok {
+		return
+	}
+
+	resp, err := h.shiftService.ListShiftSwaps(requestContext(ctx), actorID, isAdmin, employeeV1.ShiftSwapStatus(ctx.Query("status")))
+	if err != nil {
+		log.Errorf("failed to list shift swaps: %v", err)
+		writeShiftSwapError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// AcceptShiftSwap Преузимање понуђене смене
+// @Summary Преузимање понуђене смене
+// @Description Колега истог профила преузима понуђену смену
+// @Tags смене
+// @Security OAuth2Password
+// @Produce json
+// @Param id path int true "ID замене"
+// @Success 200 {object} ShiftSwapResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /shift-swaps/{id}/accept [post]
+func (h *employeeHandler) AcceptShiftSwap(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "EmployeeHandler.AcceptShiftSwap")()
+
+	swapID, ok := swapIDParam(ctx)
+	if !ok {
+		return
+	}
+	actorID, _, ok := requestActor(ctx)
+	if !ok {
+		return
+	}
+
+	resp, err := h.shiftService.AcceptShiftSwap(requestContext(ctx), swapID, actorID)
+	h.writeSwapResult(ctx, log, resp, err)
+}
+
+// ProposeShiftSwap Предлог замене смене
+// @Summary Предлог замене смене
+// @Description Колега истог профила нуди своју смену у замену за понуђену, запослени који је понудио смену потврђује замену
+// @Tags смене
+// @Security OAuth2Password
+// @Accept json
+// @Produce json
+// @Param id path int true "ID замене"
+// @Param proposal body ProposeShiftSwapRequest true "Смена која се нуди у замену"
+// @Success 200 {object} ShiftSwapResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /shift-swaps/{id}/propose [post]
+func (h *employeeHandler) ProposeShiftSwap(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "EmployeeHandler.ProposeShiftSwap")()
+
+	swapID, ok := swapIDParam(ctx)
+	if !ok {
+		return
+	}
+	actorID, _, ok := requestActor(ctx)
+	if !ok {
+		return
+	}
+
+	var req employeeV1.ProposeShiftSwapRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorf("invalid shift swap proposal payload: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.shiftService.ProposeShiftSwap(requestContext(ctx), swapID, actorID, req)
+	h.writeSwapResult(ctx, log, resp, err)
+}
+
+// ConfirmShiftSwap Потврда предложене замене
+// @Summary Потврда предложене замене
+// @Description Запослени који је понудио смену прихвата предложену замену
+// @Tags смене
+// @Security OAuth2Password
+// @Produce json
+// @Param id path int true "ID замене"
+// @Success 200 {object} ShiftSwapResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /shift-swaps/{id}/confirm [post]
+func (h *employeeHandler) ConfirmShiftSwap(ctx *gin.Context) {
+	h.answerShiftSwap(ctx, true)
+}
+
+// DeclineShiftSwap Одбијање предложене замене
+// @Summary Одбијање предложене замене
+// @Description Запослени који је понудио смену одбија предложену замену, понуда поново постаје отворена
+// @Tags смене
+// @Security OAuth2Password
+// @Produce json
+// @Param id path int true "ID замене"
+// @Success 200 {object} ShiftSwapResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /shift-swaps/{id}/decline [post]
+func (h *employeeHandler) DeclineShiftSwap(ctx *gin.Context) {
+	h.answerShiftSwap(ctx, false)
+}
+
+// CancelShiftSwap Повлачење понуде смене
+// @Summary Повлачење понуде смене
+// @Description Запослени повлачи понуду пре него што је смена предата, администратори могу повући било коју понуду
+// @Tags смене
+// @Security OAuth2Password
+// @Produce json
+// @Param id path int true "ID замене"
+// @Success 200 {object} ShiftSwapResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /shift-swaps/{id} [delete]
+func (h *employeeHandler) CancelShiftSwap(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "EmployeeHandler.CancelShiftSwap")()
+
+	swapID, ok := swapIDParam(ctx)
+	if !ok {
+		return
+	}
+	actorID, isAdmin, ok := requestActor(ctx)
+	if !ok {
+		return
+	}
+
+	resp, err := h.shiftService.CancelShiftSwap(requestContext(ctx), swapID, actorID, isAdmin)
+	h.writeSwapResult(ctx, log, resp, err)
+}
+
+// ApproveShiftSwap Одобравање замене смене (само за админе)
+// @Summary Одобравање замене смене
+// @Description Администратор одобрава договорену замену и смене се предају
+// @Tags админ
+// @Security OAuth2Password
+// @Produce json
+// @Param id path int true "ID замене"
+// @Success 200 {object} ShiftSwapResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/shift-swaps/{id}/approve [post]
+func (h *employeeHandler) ApproveShiftSwap(ctx *gin.Context) {
+	h.reviewShiftSwap(ctx, true)
+}
+
+// RejectShiftSwap Одбијање замене смене (само за админе)
+// @Summary Одбијање замене смене
+// @Description Администратор одбија договорену замену, смене остају код досадашњих запослених
+// @Tags админ
+// @Security OAuth2Password
+// @Produce json
+// @Param id path int true "ID замене"
+// @Success 200 {object} ShiftSwapResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/shift-swaps/{id}/reject [post]
+func (h *employeeHandler) RejectShiftSwap(ctx *gin.Context) {
+	h.reviewShiftSwap(ctx, false)
+}
+
+func (h *employeeHandler) answerShiftSwap(ctx *gin.Context, accept bool) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "EmployeeHandler.ConfirmShiftSwap")()
+
+	swapID, ok := swapIDParam(ctx)
+	if !ok {
+		return
+	}
+	actorID, _, ok := requestActor(ctx)
+	if !ok {
+		return
+	}
+
+	resp, err := h.shiftService.ConfirmShiftSwap(requestContext(ctx), swapID, actorID, accept)
+	h.writeSwapResult(ctx, log, resp, err)
+}
+
+func (h *employeeHandler) reviewShiftSwap(ctx *gin.Context, approve bool) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "EmployeeHandler.ReviewShiftSwap")()
+
+	swapID, ok := swapIDParam(ctx)
+	if !ok {
+		return
+	}
+	adminID, _, ok := requestActor(ctx)
+	if !ok {
+		return
+	}
+
+	resp, err := h.shiftService.ReviewShiftSwap(requestContext(ctx), swapID, adminID, approve)
+	h.writeSwapResult(ctx, log, resp, err)
+}
+
+func (h *employeeHandler) writeSwapResult(ctx *gin.Context, log utils.Logger, resp *employeeV1.ShiftSwapResponse, err error) {
+	if err != nil {
+		log.Errorf("shift swap request failed: %v", err)
+		writeShiftSwapError(ctx, err)
+		return
+	}
+	utils.WriteFreshWindow(ctx, config.DefaultFreshWindow)
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// requestActor reads the authenticated employee; it writes 401 and returns false when there is none
+func requestActor(ctx *gin.Context) (uint, bool, bool) {
+	employeeIDValue, _ := ctx.Get("employeeID")
+	employeeID, ok := employeeIDValue.(uint)
+	if !ok || employeeID == 0 {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return 0, false, false
+	}
+	role, _ := ctx.Get("role")
+	return employeeID, role == "Administrator", true
+}
+
+func swapIDParam(ctx *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid shift swap ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// writeShiftSwapError maps shift swap and shift assignment errors to responses
+func writeShiftSwapError(ctx *gin.Context, err error) {
+	var appErr *commonv1.AppError
+	if !errors.As(err, &appErr) {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	status := http.StatusBadRequest
+	switch appErr.Code {
+	case service.ErrorSwapNotFound, "EMPLOYEE_ERRORS.NOT_FOUND":
+		status = http.StatusNotFound
+	case service.ErrorSwapForbidden, service.ErrorSwapProfileMismatch:
+		status = http.StatusForbidden
+	case service.ErrorSwapInvalidState, service.ErrorSwapAlreadyOffered, model.ErrorConsecutiveShiftsLimit,
+		"SHIFT_ERRORS.ALREADY_ASSIGNED", "SHIFT_ERRORS.CAPACITY_FULL", service.ErrorEmployeeOnLeave:
+		status = http.StatusConflict
+	}
+	ctx.JSON(status, gin.H{"error": appErr.Code, "message": appErr.Message, "details": appErr.Details})
+}
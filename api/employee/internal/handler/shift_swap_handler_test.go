@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
+	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+	"github.com/pd120424d/mountain-service/api/employee/internal/service"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+func newShiftHandlerContext(t *testing.T, method, target, body string) (EmployeeHandler, *service.MockShiftService, *gin.Context, *httptest.ResponseRecorder) {
+	ctrl := gomock.NewController(t)
+	mockShiftSvc := service.NewMockShiftService(ctrl)
+	handler := NewEmployeeHandler(utils.NewTestLogger(), afero.NewMemMapFs(), service.NewMockEmployeeService(ctrl), mockShiftSvc)
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(method, target, strings.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	return handler, mockShiftSvc, ctx, w
+}
+
+func TestEmployeeHandler_OfferShift(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it returns unauthorized without an employee in the context", func(t *testing.T) {
+		handler, _, ctx, w := newShiftHandlerContext(t, http.MethodPost, "/shift-swaps", `{}`)
+
+		handler.OfferShift(ctx)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("it creates the offer", func(t *testing.T) {
+		handler, mockShiftSvc, ctx, w := newShiftHandlerContext(t, http.MethodPost, "/shift-swaps", `{"shiftDate":"2030-01-10","shiftType":1}`)
+		ctx.Set("employeeID", uint(1))
+		mockShiftSvc.EXPECT().OfferShift(gomock.Any(), uint(1), employeeV1.OfferShiftRequest{ShiftDate: "2030-01-10", ShiftType: 1}).
+			Return(&employeeV1.ShiftSwapResponse{ID: 5, Status: employeeV1.ShiftSwapOpen}, nil)
+
+		handler.OfferShift(ctx)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var resp employeeV1.ShiftSwapResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, uint(5), resp.ID)
+	})
+
+	t.Run("it rejects an invalid payload", func(t *testing.T) {
+		handler, _, ctx, w := newShiftHandlerContext(t, http.MethodPost, "/shift-swaps", `{"shiftType":1}`)
+		ctx.Set("employeeID", uint(1))
+
+		handler.OfferShift(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestEmployeeHandler_AcceptShiftSwap(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"swap not found", commonv1.NewAppError(service.ErrorSwapNotFound, "not found", nil), http.StatusNotFound},
+		{"different profile", commonv1.NewAppError(service.ErrorSwapProfileMismatch, "mismatch", nil), http.StatusForbidden},
+		{"already taken", commonv1.NewAppError(service.ErrorSwapInvalidState, "taken", nil), http.StatusConflict},
+		{"shift full", commonv1.NewAppError("SHIFT_ERRORS.CAPACITY_FULL", "full", nil), http.StatusConflict},
+		{"unexpected failure", errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run("it maps "+tt.name, func(t *testing.T) {
+			handler, mockShiftSvc, ctx, w := newShiftHandlerContext(t, http.MethodPost, "/shift-swaps/5/accept", "")
+			ctx.Set("employeeID", uint(2))
+			ctx.Params = gin.Params{{Key: "id", Value: "5"}}
+			mockShiftSvc.EXPECT().AcceptShiftSwap(gomock.Any(), uint(5), uint(2)).Return(nil, tt.err)
+
+			handler.AcceptShiftSwap(ctx)
+
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+
+	t.Run("it rejects an invalid swap ID", func(t *testing.T) {
+		handler, _, ctx, w := newShiftHandlerContext(t, http.MethodPost, "/shift-swaps/abc/accept", "")
+		ctx.Set("employeeID", uint(2))
+		ctx.Params = gin.Params{{Key: "id", Value: "abc"}}
+
+		handler.AcceptShiftSwap(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestEmployeeHandler_ReviewShiftSwap(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it approves a pending swap", func(t *testing.T) {
+		handler, mockShiftSvc, ctx, w := newShiftHandlerContext(t, http.MethodPost, "/admin/shift-swaps/5/approve", "")
+		ctx.Set("employeeID", uint(9))
+		ctx.Set("role", "Administrator")
+		ctx.Params = gin.Params{{Key: "id", Value: "5"}}
+		mockShiftSvc.EXPECT().ReviewShiftSwap(gomock.Any(), uint(5), uint(9), true).
+			Return(&employeeV1.ShiftSwapResponse{ID: 5, Status: employeeV1.ShiftSwapCompleted}, nil)
+
+		handler.ApproveShiftSwap(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"completed"`)
+	})
+
+	t.Run("it rejects a pending swap", func(t *testing.T) {
+		handler, mockShiftSvc, ctx, w := newShiftHandlerContext(t, http.MethodPost, "/admin/shift-swaps/5/reject", "")
+		ctx.Set("employeeID", uint(9))
+		ctx.Set("role", "Administrator")
+		ctx.Params = gin.Params{{Key: "id", Value: "5"}}
+		mockShiftSvc.EXPECT().ReviewShiftSwap(gomock.Any(), uint(5), uint(9), false).
+			Return(&employeeV1.ShiftSwapResponse{ID: 5, Status: employeeV1.ShiftSwapRejected}, nil)
+
+		handler.RejectShiftSwap(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
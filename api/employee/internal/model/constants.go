@@ -5,6 +5,13 @@ import "fmt"
 var (
 	ErrAlreadyAssigned = fmt.Errorf("already assigned")
 	ErrCapacityReached = fmt.Errorf("capacity reached")
+	// ErrSwapChanged is returned when a shift swap left the expected state, or an assignment it hands over
+	// was removed, before the update could be applied
+	ErrSwapChanged = fmt.Errorf("shift swap changed concurrently")
+	// ErrLeaveChanged is returned when a leave request left the expected state before the update could be applied
+	ErrLeaveChanged = fmt.Errorf("leave request changed concurrently")
+	// ErrScheduleOverlap is returned when a shift template or capacity would apply to days another one already covers
+	ErrScheduleOverlap = fmt.Errorf("schedule setting overlaps an existing one")
 )
 
 const (
@@ -28,6 +28,61 @@ type Employee struct {
 	ProfilePicture string
 	ProfileType    ProfileType `gorm:"type:text;not null"`
 	Shifts         []Shift     `gorm:"many2many:employee_shifts;"`
+
+	// PreferredLanguage is one of the UI locales (sr-cyr, sr-lat, en, ru); empty means no preference
+	PreferredLanguage string `gorm:"type:text"`
+
+	NotificationPreferences NotificationPreferences `gorm:"embedded;embeddedPrefix:notify_"`
+
+	// Last position reported by the employee's device
+	LastLatitude   *float64
+	LastLongitude  *float64
+	LastPositionAt *time.Time
+}
+
+// OnCallEmployee is an employee found by the on-call lookup. OnCurrentShift is false for employees
+// included only because their shift starts within the lookup buffer.
+type OnCallEmployee struct {
+	Employee
+	OnCurrentShift bool
+}
+
+// NotificationPreferences are stored as opt-outs so that existing rows keep receiving every notification
+type NotificationPreferences struct {
+	SMSDisabled   bool
+	SMSMinLevel   string `gorm:"type:text"`
+	EmailDisabled bool
+	EmailMinLevel string `gorm:"type:text"`
+	// Quiet hours as UTC "HH:MM"; both empty means no quiet hours
+	QuietHoursStart string `gorm:"type:text"`
+	QuietHoursEnd   string `gorm:"type:text"`
+}
+
+// ToResponse maps the stored preferences to the DTO
+func (p NotificationPreferences) ToResponse() *employeeV1.NotificationPreferences {
+	resp := &employeeV1.NotificationPreferences{
+		SMS:   employeeV1.ChannelPreference{Enabled: !p.SMSDisabled, MinLevel: p.SMSMinLevel},
+		Email: employeeV1.ChannelPreference{Enabled: !p.EmailDisabled, MinLevel: p.EmailMinLevel},
+	}
+	if p.QuietHoursStart != "" && p.QuietHoursEnd != "" {
+		resp.QuietHours = &employeeV1.QuietHours{Start: p.QuietHoursStart, End: p.QuietHoursEnd}
+	}
+	return resp
+}
+
+// NotificationPreferencesFromRequest maps the DTO to the stored preferences
+func NotificationPreferencesFromRequest(req employeeV1.NotificationPreferences) NotificationPreferences {
+	prefs := NotificationPreferences{
+		SMSDisabled:   !req.SMS.Enabled,
+		SMSMinLevel:   req.SMS.MinLevel,
+		EmailDisabled: !req.Email.Enabled,
+		EmailMinLevel: req.Email.MinLevel,
+	}
+	if req.QuietHours != nil {
+		prefs.QuietHoursStart = req.QuietHours.Start
+		prefs.QuietHoursEnd = req.QuietHours.End
+	}
+	return prefs
 }
 
 type Shift struct {
@@ -108,7 +163,7 @@ func (e *Employee) GetRole() string {
 }
 
 func (e *Employee) UpdateResponseFromEmployee() employeeV1.EmployeeResponse {
-	return employeeV1.EmployeeResponse{
+	resp := employeeV1.EmployeeResponse{
 		ID:             e.ID,
 		Username:       e.Username,
 		FirstName:      e.FirstName,
@@ -118,5 +173,15 @@ func (e *Employee) UpdateResponseFromEmployee() employeeV1.EmployeeResponse {
 		Email:          e.Email,
 		ProfilePicture: e.ProfilePicture,
 		ProfileType:    e.ProfileType.String(),
+		LastLatitude:   e.LastLatitude,
+		LastLongitude:  e.LastLongitude,
+
+		PreferredLanguage: e.PreferredLanguage,
+
+		NotificationPreferences: e.NotificationPreferences.ToResponse(),
+	}
+	if e.LastPositionAt != nil {
+		resp.LastPositionAt = e.LastPositionAt.Format(time.RFC3339)
 	}
+	return resp
 }
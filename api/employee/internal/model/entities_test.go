@@ -86,6 +86,8 @@ func TestEmployee_UpdateResponseFromEmployee(t *testing.T) {
 		Email:          "test-user@example.com",
 		ProfilePicture: "https://example.com/profile.jpg",
 		ProfileType:    Medic,
+
+		PreferredLanguage: "sr-lat",
 	}
 
 	response := employee.UpdateResponseFromEmployee()
@@ -99,5 +101,39 @@ func TestEmployee_UpdateResponseFromEmployee(t *testing.T) {
 		Email:          "test-user@example.com",
 		ProfilePicture: "https://example.com/profile.jpg",
 		ProfileType:    "Medic",
+
+		PreferredLanguage: "sr-lat",
+
+		NotificationPreferences: &employeeV1.NotificationPreferences{
+			SMS:   employeeV1.ChannelPreference{Enabled: true},
+			Email: employeeV1.ChannelPreference{Enabled: true},
+		},
 	}, response)
 }
+
+func TestNotificationPreferences_ToResponse(t *testing.T) {
+	t.Run("it maps opt-outs, minimum levels and quiet hours", func(t *testing.T) {
+		prefs := NotificationPreferences{
+			SMSDisabled:     true,
+			EmailMinLevel:   "high",
+			QuietHoursStart: "22:00",
+			QuietHoursEnd:   "07:00",
+		}
+
+		assert.Equal(t, &employeeV1.NotificationPreferences{
+			SMS:        employeeV1.ChannelPreference{Enabled: false},
+			Email:      employeeV1.ChannelPreference{Enabled: true, MinLevel: "high"},
+			QuietHours: &employeeV1.QuietHours{Start: "22:00", End: "07:00"},
+		}, prefs.ToResponse())
+	})
+
+	t.Run("it round trips through the request mapping", func(t *testing.T) {
+		req := employeeV1.NotificationPreferences{
+			SMS:        employeeV1.ChannelPreference{Enabled: true, MinLevel: "critical"},
+			Email:      employeeV1.ChannelPreference{Enabled: false},
+			QuietHours: &employeeV1.QuietHours{Start: "23:30", End: "06:00"},
+		}
+
+		assert.Equal(t, &req, NotificationPreferencesFromRequest(req).ToResponse())
+	})
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"time"
+
+	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+)
+
+// LeaveRequest is a period an employee is away, such as vacation, sick leave or training. StartDate and
+// EndDate are inclusive and normalized to 00:00 UTC, like shift dates.
+type LeaveRequest struct {
+	ID         uint                   `gorm:"primaryKey"`
+	EmployeeID uint                   `gorm:"not null;index"`
+	Type       employeeV1.LeaveType   `gorm:"type:text;not null"`
+	Status     employeeV1.LeaveStatus `gorm:"type:text;not null;index"`
+	StartDate  time.Time              `gorm:"not null;index"`
+	EndDate    time.Time              `gorm:"not null;index"`
+	Reason     string
+	ReviewedBy *uint
+	ReviewNote string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Days returns the number of calendar days the leave covers
+func (l *LeaveRequest) Days() int {
+	return int(l.EndDate.Sub(l.StartDate).Hours()/24) + 1
+}
+
+// DaysWithin returns how many days of the leave fall in [start, end)
+func (l *LeaveRequest) DaysWithin(start, end time.Time) int {
+	from, to := l.StartDate, l.EndDate.AddDate(0, 0, 1)
+	if from.Before(start) {
+		from = start
+	}
+	if to.After(end) {
+		to = end
+	}
+	if !to.After(from) {
+		return 0
+	}
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// ToResponse maps the leave request to the DTO
+func (l *LeaveRequest) ToResponse() employeeV1.LeaveRequestResponse {
+	return employeeV1.LeaveRequestResponse{
+		ID:         l.ID,
+		EmployeeID: l.EmployeeID,
+		Type:       l.Type,
+		Status:     l.Status,
+		StartDate:  l.StartDate.Format("2006-01-02"),
+		EndDate:    l.EndDate.Format("2006-01-02"),
+		Days:       l.Days(),
+		Reason:     l.Reason,
+		ReviewedBy: l.ReviewedBy,
+		ReviewNote: l.ReviewNote,
+		CreatedAt:  l.CreatedAt,
+		UpdatedAt:  l.UpdatedAt,
+	}
+}
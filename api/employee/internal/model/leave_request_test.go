@@ -0,0 +1,33 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeaveRequest_DaysWithin(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2030, 1, d, 0, 0, 0, 0, time.UTC) }
+	leave := LeaveRequest{StartDate: day(10), EndDate: day(14)}
+
+	assert.Equal(t, 5, leave.Days())
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		expected   int
+	}{
+		{"window covers the whole leave", day(1), day(20), 5},
+		{"window starts during the leave", day(12), day(20), 3},
+		{"window ends during the leave", day(1), day(11), 1},
+		{"window inside the leave", day(11), day(13), 2},
+		{"window before the leave", day(1), day(10), 0},
+		{"window after the leave", day(15), day(20), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, leave.DaysWithin(tt.start, tt.end))
+		})
+	}
+}
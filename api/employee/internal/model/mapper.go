@@ -26,6 +26,12 @@ func MapUpdateRequestToEmployee(req *employeeV1.EmployeeUpdateRequest, existing
 	if req.ProfilePicture != "" {
 		existing.ProfilePicture = req.ProfilePicture
 	}
+	if req.PreferredLanguage != "" {
+		existing.PreferredLanguage = req.PreferredLanguage
+	}
+	if req.NotificationPreferences != nil {
+		existing.NotificationPreferences = NotificationPreferencesFromRequest(*req.NotificationPreferences)
+	}
 	if req.ProfileType != "" {
 		newProfileType := ProfileTypeFromString(req.ProfileType)
 		if newProfileType != "" {
@@ -18,6 +18,8 @@ func TestMapUpdateRequestToEmployee(t *testing.T) {
 			Phone:          "123456789",
 			ProfilePicture: "https://example.com/profile.jpg",
 			ProfileType:    "Medic",
+
+			PreferredLanguage: "ru",
 		}
 		existing := &Employee{
 			FirstName:      "Alice",
@@ -38,6 +40,30 @@ func TestMapUpdateRequestToEmployee(t *testing.T) {
 		assert.Equal(t, "123456789", existing.Phone)
 		assert.Equal(t, "https://example.com/profile.jpg", existing.ProfilePicture)
 		assert.Equal(t, Medic, existing.ProfileType)
+		assert.Equal(t, "ru", existing.PreferredLanguage)
+	})
+
+	t.Run("it keeps the preferred language when the request leaves it empty", func(t *testing.T) {
+		existing := &Employee{PreferredLanguage: "sr-cyr"}
+
+		MapUpdateRequestToEmployee(&employeeV1.EmployeeUpdateRequest{FirstName: "Bruce"}, existing)
+
+		assert.Equal(t, "sr-cyr", existing.PreferredLanguage)
+	})
+
+	t.Run("it replaces notification preferences only when provided", func(t *testing.T) {
+		existing := &Employee{NotificationPreferences: NotificationPreferences{EmailDisabled: true}}
+
+		MapUpdateRequestToEmployee(&employeeV1.EmployeeUpdateRequest{FirstName: "Bruce"}, existing)
+		assert.True(t, existing.NotificationPreferences.EmailDisabled)
+
+		MapUpdateRequestToEmployee(&employeeV1.EmployeeUpdateRequest{
+			NotificationPreferences: &employeeV1.NotificationPreferences{
+				SMS:   employeeV1.ChannelPreference{Enabled: false},
+				Email: employeeV1.ChannelPreference{Enabled: true, MinLevel: "medium"},
+			},
+		}, existing)
+		assert.Equal(t, NotificationPreferences{SMSDisabled: true, EmailMinLevel: "medium"}, existing.NotificationPreferences)
 	})
 }
 
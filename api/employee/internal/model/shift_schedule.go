@@ -0,0 +1,125 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+)
+
+// EffectivePeriod is the inclusive range of days a schedule setting applies to, normalized to 00:00 UTC.
+// A nil EffectiveTo never ends.
+type EffectivePeriod struct {
+	EffectiveFrom time.Time  `gorm:"not null;index"`
+	EffectiveTo   *time.Time `gorm:"index"`
+}
+
+// Covers reports whether the period applies to the day
+func (p EffectivePeriod) Covers(day time.Time) bool {
+	return !day.Before(p.EffectiveFrom) && (p.EffectiveTo == nil || !day.After(*p.EffectiveTo))
+}
+
+// Overlaps reports whether the two periods share at least one day
+func (p EffectivePeriod) Overlaps(o EffectivePeriod) bool {
+	return (p.EffectiveTo == nil || !p.EffectiveTo.Before(o.EffectiveFrom)) &&
+		(o.EffectiveTo == nil || !o.EffectiveTo.Before(p.EffectiveFrom))
+}
+
+func (p EffectivePeriod) effectiveToResponse() string {
+	if p.EffectiveTo == nil {
+		return ""
+	}
+	return p.EffectiveTo.Format("2006-01-02")
+}
+
+// ShiftTemplate sets when the three daily shifts start, in minutes after midnight UTC. Each shift ends when
+// the next one starts and the third one ends when the first one starts the following morning.
+type ShiftTemplate struct {
+	ID               uint `gorm:"primaryKey"`
+	Name             string
+	FirstShiftStart  int `gorm:"not null"`
+	SecondShiftStart int `gorm:"not null"`
+	ThirdShiftStart  int `gorm:"not null"`
+	EffectivePeriod  `gorm:"embedded"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ShiftCapacity is how many staff of a profile a single shift takes
+type ShiftCapacity struct {
+	ID              uint        `gorm:"primaryKey"`
+	ProfileType     ProfileType `gorm:"type:text;not null;index"`
+	Capacity        int         `gorm:"not null"`
+	EffectivePeriod `gorm:"embedded"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// DefaultShiftTemplate applies to days without a configured template: 6-14, 14-22 and 22-6
+var DefaultShiftTemplate = ShiftTemplate{Name: "default", FirstShiftStart: 6 * 60, SecondShiftStart: 14 * 60, ThirdShiftStart: 22 * 60}
+
+// DefaultShiftCapacity applies to days without a configured capacity for the profile
+var DefaultShiftCapacity = map[ProfileType]int{Medic: 2, Technical: 4}
+
+// StartMinutes returns the start of each shift type, indexed by shift type - 1
+func (t ShiftTemplate) StartMinutes() [3]int {
+	return [3]int{t.FirstShiftStart, t.SecondShiftStart, t.ThirdShiftStart}
+}
+
+// ShiftHours returns when the shift type starts and ends as "HH:MM"
+func (t ShiftTemplate) ShiftHours(shiftType int) (string, string) {
+	starts := t.StartMinutes()
+	return formatClock(starts[shiftType-1]), formatClock(starts[shiftType%3])
+}
+
+// ToResponse maps the shift template to the DTO
+func (t *ShiftTemplate) ToResponse() employeeV1.ShiftTemplateResponse {
+	return employeeV1.ShiftTemplateResponse{
+		ID:               t.ID,
+		Name:             t.Name,
+		FirstShiftStart:  formatClock(t.FirstShiftStart),
+		SecondShiftStart: formatClock(t.SecondShiftStart),
+		ThirdShiftStart:  formatClock(t.ThirdShiftStart),
+		EffectiveFrom:    t.EffectiveFrom.Format("2006-01-02"),
+		EffectiveTo:      t.effectiveToResponse(),
+		CreatedAt:        t.CreatedAt,
+	}
+}
+
+// ToResponse maps the shift capacity to the DTO
+func (c *ShiftCapacity) ToResponse() employeeV1.ShiftCapacityResponse {
+	return employeeV1.ShiftCapacityResponse{
+		ID:            c.ID,
+		ProfileType:   c.ProfileType.String(),
+		Capacity:      c.Capacity,
+		EffectiveFrom: c.EffectiveFrom.Format("2006-01-02"),
+		EffectiveTo:   c.effectiveToResponse(),
+		CreatedAt:     c.CreatedAt,
+	}
+}
+
+// ShiftTemplateOn returns the template in effect on the day, or the default one
+func ShiftTemplateOn(templates []ShiftTemplate, day time.Time) ShiftTemplate {
+	for _, t := range templates {
+		if t.Covers(day) {
+			return t
+		}
+	}
+	return DefaultShiftTemplate
+}
+
+// CapacityOn returns how many staff of the profile a shift on the day takes
+func CapacityOn(capacities []ShiftCapacity, day time.Time, profile ProfileType) int {
+	for _, c := range capacities {
+		if c.ProfileType == profile && c.Covers(day) {
+			return c.Capacity
+		}
+	}
+	return DefaultShiftCapacity[profile]
+}
+
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEffectivePeriod(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2030, 1, d, 0, 0, 0, 0, time.UTC) }
+	to := func(d int) *time.Time { v := day(d); return &v }
+	period := EffectivePeriod{EffectiveFrom: day(10), EffectiveTo: to(14)}
+	open := EffectivePeriod{EffectiveFrom: day(10)}
+
+	assert.False(t, period.Covers(day(9)))
+	assert.True(t, period.Covers(day(10)))
+	assert.True(t, period.Covers(day(14)))
+	assert.False(t, period.Covers(day(15)))
+	assert.True(t, open.Covers(day(31)))
+
+	tests := []struct {
+		name     string
+		other    EffectivePeriod
+		expected bool
+	}{
+		{"period ending on the first day", EffectivePeriod{EffectiveFrom: day(1), EffectiveTo: to(10)}, true},
+		{"period starting on the last day", EffectivePeriod{EffectiveFrom: day(14)}, true},
+		{"period ending the day before", EffectivePeriod{EffectiveFrom: day(1), EffectiveTo: to(9)}, false},
+		{"period starting the day after", EffectivePeriod{EffectiveFrom: day(15)}, false},
+		{"open period starting earlier", EffectivePeriod{EffectiveFrom: day(1)}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, period.Overlaps(tt.other))
+			assert.Equal(t, tt.expected, tt.other.Overlaps(period))
+		})
+	}
+}
+
+func TestShiftScheduleOn(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2030, 1, d, 0, 0, 0, 0, time.UTC) }
+	end := day(14)
+	winter := ShiftTemplate{ID: 1, FirstShiftStart: 7 * 60, SecondShiftStart: 15 * 60, ThirdShiftStart: 23 * 60, EffectivePeriod: EffectivePeriod{EffectiveFrom: day(10), EffectiveTo: &end}}
+	medics := ShiftCapacity{ID: 1, ProfileType: Medic, Capacity: 3, EffectivePeriod: EffectivePeriod{EffectiveFrom: day(10), EffectiveTo: &end}}
+
+	assert.Equal(t, uint(1), ShiftTemplateOn([]ShiftTemplate{winter}, day(12)).ID)
+	assert.Equal(t, DefaultShiftTemplate, ShiftTemplateOn([]ShiftTemplate{winter}, day(15)))
+	assert.Equal(t, DefaultShiftTemplate, ShiftTemplateOn(nil, day(12)))
+
+	assert.Equal(t, 3, CapacityOn([]ShiftCapacity{medics}, day(12), Medic))
+	assert.Equal(t, 2, CapacityOn([]ShiftCapacity{medics}, day(15), Medic))
+	assert.Equal(t, 4, CapacityOn([]ShiftCapacity{medics}, day(12), Technical))
+	assert.Equal(t, 0, CapacityOn(nil, day(12), Administrator))
+}
+
+func TestShiftTemplate_ToResponse(t *testing.T) {
+	template := ShiftTemplate{ID: 3, Name: "winter", FirstShiftStart: 7*60 + 30, SecondShiftStart: 15 * 60, ThirdShiftStart: 23 * 60,
+		EffectivePeriod: EffectivePeriod{EffectiveFrom: time.Date(2030, 1, 10, 0, 0, 0, 0, time.UTC)}}
+
+	resp := template.ToResponse()
+
+	assert.Equal(t, "07:30", resp.FirstShiftStart)
+	assert.Equal(t, "15:00", resp.SecondShiftStart)
+	assert.Equal(t, "23:00", resp.ThirdShiftStart)
+	assert.Equal(t, "2030-01-10", resp.EffectiveFrom)
+	assert.Empty(t, resp.EffectiveTo)
+
+	start, end := template.ShiftHours(1)
+	assert.Equal(t, "07:30", start)
+	assert.Equal(t, "15:00", end)
+	start, end = template.ShiftHours(3)
+	assert.Equal(t, "23:00", start)
+	assert.Equal(t, "07:30", end)
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"time"
+
+	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+)
+
+// ShiftSwap is a shift offered by its assignee to colleagues of the same profile, either to be covered
+// or exchanged for one of the colleague's shifts
+type ShiftSwap struct {
+	ID          uint                       `gorm:"primaryKey"`
+	ShiftID     uint                       `gorm:"not null;index"`
+	RequesterID uint                       `gorm:"not null;index"`
+	ProfileType ProfileType                `gorm:"type:text;not null;index"`
+	Status      employeeV1.ShiftSwapStatus `gorm:"type:text;not null;index"`
+	Note        string
+
+	ResponderID      *uint `gorm:"index"`
+	ResponderShiftID *uint
+	ReviewedBy       *uint
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Shift          Shift  `gorm:"foreignKey:ShiftID"`
+	ResponderShift *Shift `gorm:"foreignKey:ResponderShiftID"`
+}
+
+// Active reports whether the swap can still change hands
+func (s *ShiftSwap) Active() bool {
+	switch s.Status {
+	case employeeV1.ShiftSwapOpen, employeeV1.ShiftSwapProposed, employeeV1.ShiftSwapPendingApproval:
+		return true
+	}
+	return false
+}
+
+// ToResponse maps the swap to the DTO; Shift and ResponderShift must be loaded
+func (s *ShiftSwap) ToResponse() employeeV1.ShiftSwapResponse {
+	resp := employeeV1.ShiftSwapResponse{
+		ID:          s.ID,
+		Status:      s.Status,
+		ProfileType: s.ProfileType.String(),
+		RequesterID: s.RequesterID,
+		Shift:       s.Shift.toSwapShift(),
+		Note:        s.Note,
+		ResponderID: s.ResponderID,
+		ReviewedBy:  s.ReviewedBy,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+	if s.ResponderShift != nil {
+		shift := s.ResponderShift.toSwapShift()
+		resp.ResponderShift = &shift
+	}
+	return resp
+}
+
+func (s Shift) toSwapShift() employeeV1.ShiftSwapShift {
+	return employeeV1.ShiftSwapShift{ShiftID: s.ID, ShiftDate: s.ShiftDate.Format("2006-01-02"), ShiftType: s.ShiftType}
+}
@@ -22,6 +22,7 @@ type EmployeeRepository interface {
 	GetEmployeeByID(ctx context.Context, id uint, employee *model.Employee) error
 	GetEmployeeByUsername(ctx context.Context, username string) (*model.Employee, error)
 	UpdateEmployee(ctx context.Context, employee *model.Employee) error
+	UpdatePosition(ctx context.Context, employeeID uint, latitude, longitude float64, reportedAt time.Time) error
 	Delete(ctx context.Context, employeeID uint) error
 	ListEmployees(ctx context.Context, filters map[string]interface{}) ([]model.Employee, error)
 	ResetAllData(ctx context.Context) error
@@ -114,6 +115,27 @@ func (r *employeeRepository) UpdateEmployee(ctx context.Context, employee *model
 	return r.db.WithContext(ctx).Save(employee).Error
 }
 
+// UpdatePosition stores the last known position of the employee without touching other columns.
+func (r *employeeRepository) UpdatePosition(ctx context.Context, employeeID uint, latitude, longitude float64, reportedAt time.Time) error {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "EmployeeRepository.UpdatePosition")()
+	res := r.db.WithContext(ctx).
+		Model(&model.Employee{}).
+		Where("id = ? AND deleted_at IS NULL", employeeID).
+		Updates(map[string]interface{}{
+			"last_latitude":    latitude,
+			"last_longitude":   longitude,
+			"last_position_at": reportedAt,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
 func (r *employeeRepository) Delete(ctx context.Context, id uint) error {
 	log := r.log.WithContext(ctx)
 	defer utils.TimeOperation(log, "EmployeeRepository.Delete")()
@@ -151,6 +173,18 @@ func (r *employeeRepository) ResetAllData(ctx context.Context) error {
 	defer utils.TimeOperation(log, "EmployeeRepository.ResetAllData")()
 	log.Warn("Resetting all employee and shift data - this action cannot be undone")
 
+	if err := r.db.Unscoped().Delete(&model.ShiftSwap{}, "1=1").Error; err != nil {
+		r.log.Errorf("Failed to delete shift swaps: %v", err)
+		return err
+	}
+	r.log.Info("Successfully deleted all shift swaps")
+
+	if err := r.db.Unscoped().Delete(&model.LeaveRequest{}, "1=1").Error; err != nil {
+		r.log.Errorf("Failed to delete leave requests: %v", err)
+		return err
+	}
+	r.log.Info("Successfully deleted all leave requests")
+
 	if err := r.db.Unscoped().Delete(&model.EmployeeShift{}, "1=1").Error; err != nil {
 		r.log.Errorf("Failed to delete employee-shift associations: %v", err)
 		return err
@@ -44,7 +44,7 @@ func setupSQLiteTestDB(t *testing.T) *gorm.DB {
 	require.NoError(t, err, "failed to open sqlite in-memory db")
 
 	// require.NoError(t, db.Migrator().DropTable(&model.EmployeeShift{}, &model.Shift{}, &model.Employee{}))
-	require.NoError(t, db.AutoMigrate(&model.Employee{}, &model.Shift{}, &model.EmployeeShift{}))
+	require.NoError(t, db.AutoMigrate(&model.Employee{}, &model.Shift{}, &model.EmployeeShift{}, &model.ShiftSwap{}, &model.LeaveRequest{}, &model.ShiftTemplate{}, &model.ShiftCapacity{}))
 
 	return db
 }
@@ -233,6 +233,34 @@ func TestEmployeeRepository_UpdateEmployee(t *testing.T) {
 	})
 }
 
+func TestEmployeeRepository_UpdatePosition(t *testing.T) {
+	log := utils.NewTestLogger()
+
+	gormDB := setupSQLiteTestDB(t)
+	repo := NewEmployeeRepository(log, gormDB)
+
+	t.Run("it stores the last known position", func(t *testing.T) {
+		employee := &model.Employee{Username: "pos-user", FirstName: "Bruce", LastName: "Lee", Password: "Pass123!", Email: "pos@example.com", Gender: "M", ProfileType: model.Medic}
+		require.NoError(t, gormDB.Create(employee).Error)
+
+		at := time.Now().UTC().Truncate(time.Second)
+		require.NoError(t, repo.UpdatePosition(context.Background(), employee.ID, 43.4, 22.66, at))
+
+		var got model.Employee
+		require.NoError(t, gormDB.First(&got, employee.ID).Error)
+		require.NotNil(t, got.LastLatitude)
+		assert.Equal(t, 43.4, *got.LastLatitude)
+		assert.Equal(t, 22.66, *got.LastLongitude)
+		assert.True(t, got.LastPositionAt.Equal(at))
+		assert.Equal(t, "Bruce", got.FirstName)
+	})
+
+	t.Run("it returns not found for unknown employees", func(t *testing.T) {
+		err := repo.UpdatePosition(context.Background(), 9999, 1, 1, time.Now())
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+}
+
 func TestEmployeeRepository_Delete(t *testing.T) {
 	log := utils.NewTestLogger()
 
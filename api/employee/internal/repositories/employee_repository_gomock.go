@@ -12,6 +12,7 @@ package repositories
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	model "github.com/pd120424d/mountain-service/api/employee/internal/model"
 	gomock "go.uber.org/mock/gomock"
@@ -114,7 +115,7 @@ func (mr *MockEmployeeRepositoryMockRecorder) GetEmployeeByUsername(ctx, usernam
 }
 
 // ListEmployees mocks base method.
-func (m *MockEmployeeRepository) ListEmployees(ctx context.Context, filters map[string]interface{}) ([]model.Employee, error) {
+func (m *MockEmployeeRepository) ListEmployees(ctx context.Context, filters map[string]any) ([]model.Employee, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListEmployees", ctx, filters)
 	ret0, _ := ret[0].([]model.Employee)
@@ -155,3 +156,17 @@ func (mr *MockEmployeeRepositoryMockRecorder) UpdateEmployee(ctx, employee any)
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmployee", reflect.TypeOf((*MockEmployeeRepository)(nil).UpdateEmployee), ctx, employee)
 }
+
+// UpdatePosition mocks base method.
+func (m *MockEmployeeRepository) UpdatePosition(ctx context.Context, employeeID uint, latitude, longitude float64, reportedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePosition", ctx, employeeID, latitude, longitude, reportedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePosition indicates an expected call of UpdatePosition.
+func (mr *MockEmployeeRepositoryMockRecorder) UpdatePosition(ctx, employeeID, latitude, longitude, reportedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePosition", reflect.TypeOf((*MockEmployeeRepository)(nil).UpdatePosition), ctx, employeeID, latitude, longitude, reportedAt)
+}
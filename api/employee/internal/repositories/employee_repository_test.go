@@ -179,7 +179,46 @@ func TestEmployeeRepositoryMockDB_ResetAllData(t *testing.T) {
 	gormDB, mock := setupMockDB(t)
 	repo := NewEmployeeRepository(log, gormDB)
 
+	t.Run("it returns an error when it fails to delete shift swaps", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "shift_swaps" WHERE 1=1`).
+			WillReturnError(sqlmock.ErrCancelled)
+		mock.ExpectRollback()
+
+		err := repo.ResetAllData(context.Background())
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "canceling query due to user request")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("it returns an error when it fails to delete leave requests", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "shift_swaps" WHERE 1=1`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "leave_requests" WHERE 1=1`).
+			WillReturnError(sqlmock.ErrCancelled)
+		mock.ExpectRollback()
+
+		err := repo.ResetAllData(context.Background())
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "canceling query due to user request")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
 	t.Run("it returns an error when it fails to delete employee-shift associations", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "shift_swaps" WHERE 1=1`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "leave_requests" WHERE 1=1`).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
 		mock.ExpectBegin()
 		mock.ExpectExec(`DELETE FROM "employee_shifts" WHERE 1=1`).
 			WillReturnError(sqlmock.ErrCancelled)
@@ -192,6 +231,16 @@ func TestEmployeeRepositoryMockDB_ResetAllData(t *testing.T) {
 	})
 
 	t.Run("it returns an error when it fails to delete shifts", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "shift_swaps" WHERE 1=1`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "leave_requests" WHERE 1=1`).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
 		mock.ExpectBegin()
 		mock.ExpectExec(`DELETE FROM "employee_shifts" WHERE 1=1`).
 			WillReturnResult(sqlmock.NewResult(0, 5))
@@ -209,6 +258,16 @@ func TestEmployeeRepositoryMockDB_ResetAllData(t *testing.T) {
 	})
 
 	t.Run("it returns an error when it fails to delete employees", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "shift_swaps" WHERE 1=1`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "leave_requests" WHERE 1=1`).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
 		mock.ExpectBegin()
 		mock.ExpectExec(`DELETE FROM "employee_shifts" WHERE 1=1`).
 			WillReturnResult(sqlmock.NewResult(0, 5))
@@ -231,6 +290,16 @@ func TestEmployeeRepositoryMockDB_ResetAllData(t *testing.T) {
 	})
 
 	t.Run("it successfully resets all data", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "shift_swaps" WHERE 1=1`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "leave_requests" WHERE 1=1`).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
 		mock.ExpectBegin()
 		mock.ExpectExec(`DELETE FROM "employee_shifts" WHERE 1=1`).
 			WillReturnResult(sqlmock.NewResult(0, 5))
@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+	"github.com/pd120424d/mountain-service/api/employee/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// LeaveRequestFilter narrows ListLeaveRequests; zero values match everything. From and To select requests
+// overlapping the inclusive [From, To] range.
+type LeaveRequestFilter struct {
+	EmployeeID uint
+	Statuses   []employeeV1.LeaveStatus
+	From       time.Time
+	To         time.Time
+}
+
+// approvedLeaveOnShiftDate matches employees with approved leave covering the joined shift's date
+const approvedLeaveOnShiftDate = `NOT EXISTS (SELECT 1 FROM leave_requests WHERE leave_requests.employee_id = employees.id ` +
+	`AND leave_requests.status = ? AND leave_requests.start_date <= shifts.shift_date AND leave_requests.end_date >= shifts.shift_date)`
+
+func (r *shiftRepository) CreateLeaveRequest(ctx context.Context, leave *model.LeaveRequest) error {
+	if err := r.dbWrite.WithContext(ctx).Create(leave).Error; err != nil {
+		return fmt.Errorf("failed to create leave request: %w", err)
+	}
+	return nil
+}
+
+func (r *shiftRepository) GetLeaveRequest(ctx context.Context, id uint) (*model.LeaveRequest, error) {
+	var leave model.LeaveRequest
+	if err := r.dbWrite.WithContext(ctx).First(&leave, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get leave request: %w", err)
+	}
+	return &leave, nil
+}
+
+func (r *shiftRepository) ListLeaveRequests(ctx context.Context, filter LeaveRequestFilter) ([]model.LeaveRequest, error) {
+	var leaves []model.LeaveRequest
+	err := r.withRead(ctx, func(db *gorm.DB) error {
+		q := db.Model(&model.LeaveRequest{})
+		if filter.EmployeeID != 0 {
+			q = q.Where("employee_id = ?", filter.EmployeeID)
+		}
+		if len(filter.Statuses) > 0 {
+			q = q.Where("status IN ?", filter.Statuses)
+		}
+		if !filter.From.IsZero() {
+			q = q.Where("end_date >= ?", filter.From)
+		}
+		if !filter.To.IsZero() {
+			q = q.Where("start_date <= ?", filter.To)
+		}
+		return q.Order("start_date ASC, id ASC").Find(&leaves).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list leave requests: %w", err)
+	}
+	return leaves, nil
+}
+
+func (r *shiftRepository) HasApprovedLeave(ctx context.Context, employeeID uint, day time.Time) (bool, error) {
+	var count int64
+	err := r.dbWrite.WithContext(ctx).Model(&model.LeaveRequest{}).
+		Where("employee_id = ? AND status = ? AND start_date <= ? AND end_date >= ?", employeeID, employeeV1.LeaveApproved, day, day).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check leave: %w", err)
+	}
+	return count > 0, nil
+}
+
+// UpdateLeaveRequest saves the request's review state only if it is still in the from state, so a request
+// cannot be approved and cancelled at the same time; the loser gets model.ErrLeaveChanged
+func (r *shiftRepository) UpdateLeaveRequest(ctx context.Context, leave *model.LeaveRequest, from employeeV1.LeaveStatus) error {
+	return updateLeaveRequest(r.dbWrite.WithContext(ctx), leave, from)
+}
+
+// ApproveLeaveRequest saves the approval of a pending request unless the employee still holds shifts from
+// shiftsFrom to the end of the leave, in which case those shifts are returned and nothing is saved. The check
+// and the update run in one transaction.
+func (r *shiftRepository) ApproveLeaveRequest(ctx context.Context, leave *model.LeaveRequest, shiftsFrom time.Time) ([]model.Shift, error) {
+	var conflicts []model.Shift
+	err := r.dbWrite.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := employeeShiftsInRange(tx, leave.EmployeeID, shiftsFrom, leave.EndDate.AddDate(0, 0, 1)).Scan(&conflicts).Error; err != nil {
+			return fmt.Errorf("failed to get shifts during leave: %w", err)
+		}
+		if len(conflicts) > 0 {
+			return nil
+		}
+		leave.Status = employeeV1.LeaveApproved
+		return updateLeaveRequest(tx, leave, employeeV1.LeavePending)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return conflicts, nil
+}
+
+func updateLeaveRequest(db *gorm.DB, leave *model.LeaveRequest, from employeeV1.LeaveStatus) error {
+	leave.UpdatedAt = time.Now().UTC()
+	res := db.Model(&model.LeaveRequest{}).
+		Where("id = ? AND status = ?", leave.ID, from).
+		Updates(map[string]interface{}{
+			"status":      leave.Status,
+			"reviewed_by": leave.ReviewedBy,
+			"review_note": leave.ReviewNote,
+			"updated_at":  leave.UpdatedAt,
+		})
+	if res.Error != nil {
+		return fmt.Errorf("failed to update leave request: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return model.ErrLeaveChanged
+	}
+	return nil
+}
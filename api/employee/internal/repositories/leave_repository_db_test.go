@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+	"github.com/pd120424d/mountain-service/api/employee/internal/model"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+func leaveDay(d int) time.Time {
+	return time.Date(2030, 1, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestShiftRepository_LeaveRequests(t *testing.T) {
+	log := utils.NewTestLogger()
+	ctx := context.Background()
+
+	t.Run("it creates, loads and filters leave requests", func(t *testing.T) {
+		repo := NewShiftRepository(log, setupSQLiteTestDB(t))
+		vacation := &model.LeaveRequest{EmployeeID: 1, Type: employeeV1.LeaveVacation, Status: employeeV1.LeaveApproved, StartDate: leaveDay(10), EndDate: leaveDay(14)}
+		training := &model.LeaveRequest{EmployeeID: 1, Type: employeeV1.LeaveTraining, Status: employeeV1.LeavePending, StartDate: leaveDay(20), EndDate: leaveDay(20)}
+		other := &model.LeaveRequest{EmployeeID: 2, Type: employeeV1.LeaveSick, Status: employeeV1.LeaveApproved, StartDate: leaveDay(12), EndDate: leaveDay(13)}
+		for _, leave := range []*model.LeaveRequest{vacation, training, other} {
+			require.NoError(t, repo.CreateLeaveRequest(ctx, leave))
+		}
+
+		loaded, err := repo.GetLeaveRequest(ctx, vacation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 5, loaded.Days())
+
+		leaves, err := repo.ListLeaveRequests(ctx, LeaveRequestFilter{EmployeeID: 1})
+		require.NoError(t, err)
+		require.Len(t, leaves, 2)
+		assert.Equal(t, vacation.ID, leaves[0].ID)
+
+		leaves, err = repo.ListLeaveRequests(ctx, LeaveRequestFilter{Statuses: []employeeV1.LeaveStatus{employeeV1.LeaveApproved}})
+		require.NoError(t, err)
+		assert.Len(t, leaves, 2)
+
+		// ranges touching either end of the vacation overlap it
+		leaves, err = repo.ListLeaveRequests(ctx, LeaveRequestFilter{EmployeeID: 1, From: leaveDay(14), To: leaveDay(18)})
+		require.NoError(t, err)
+		require.Len(t, leaves, 1)
+		assert.Equal(t, vacation.ID, leaves[0].ID)
+
+		leaves, err = repo.ListLeaveRequests(ctx, LeaveRequestFilter{EmployeeID: 1, From: leaveDay(15), To: leaveDay(19)})
+		require.NoError(t, err)
+		assert.Empty(t, leaves)
+	})
+
+	t.Run("it returns record not found for a missing leave request", func(t *testing.T) {
+		repo := NewShiftRepository(log, setupSQLiteTestDB(t))
+
+		_, err := repo.GetLeaveRequest(ctx, 99)
+
+		assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+	})
+
+	t.Run("it only reports approved leave covering the day", func(t *testing.T) {
+		repo := NewShiftRepository(log, setupSQLiteTestDB(t))
+		require.NoError(t, repo.CreateLeaveRequest(ctx, &model.LeaveRequest{EmployeeID: 1, Type: employeeV1.LeaveVacation, Status: employeeV1.LeaveApproved, StartDate: leaveDay(10), EndDate: leaveDay(12)}))
+		require.NoError(t, repo.CreateLeaveRequest(ctx, &model.LeaveRequest{EmployeeID: 1, Type: employeeV1.LeaveVacation, Status: employeeV1.LeavePending, StartDate: leaveDay(20), EndDate: leaveDay(22)}))
+
+		for d, expected := range map[int]bool{9: false, 10: true, 12: true, 13: false, 21: false} {
+			onLeave, err := repo.HasApprovedLeave(ctx, 1, leaveDay(d))
+			require.NoError(t, err)
+			assert.Equal(t, expected, onLeave, "day %d", d)
+		}
+	})
+
+	t.Run("it refuses an update when the request left the expected state", func(t *testing.T) {
+		repo := NewShiftRepository(log, setupSQLiteTestDB(t))
+		leave := &model.LeaveRequest{EmployeeID: 1, Type: employeeV1.LeaveSick, Status: employeeV1.LeaveCancelled, StartDate: leaveDay(10), EndDate: leaveDay(10)}
+		require.NoError(t, repo.CreateLeaveRequest(ctx, leave))
+
+		leave.Status = employeeV1.LeaveApproved
+		err := repo.UpdateLeaveRequest(ctx, leave, employeeV1.LeavePending)
+
+		assert.ErrorIs(t, err, model.ErrLeaveChanged)
+	})
+
+	t.Run("it saves the review", func(t *testing.T) {
+		repo := NewShiftRepository(log, setupSQLiteTestDB(t))
+		leave := &model.LeaveRequest{EmployeeID: 1, Type: employeeV1.LeaveSick, Status: employeeV1.LeavePending, StartDate: leaveDay(10), EndDate: leaveDay(10)}
+		require.NoError(t, repo.CreateLeaveRequest(ctx, leave))
+
+		adminID := uint(9)
+		leave.Status, leave.ReviewedBy, leave.ReviewNote = employeeV1.LeaveApproved, &adminID, "get well"
+		require.NoError(t, repo.UpdateLeaveRequest(ctx, leave, employeeV1.LeavePending))
+
+		loaded, err := repo.GetLeaveRequest(ctx, leave.ID)
+		require.NoError(t, err)
+		assert.Equal(t, employeeV1.LeaveApproved, loaded.Status)
+		assert.Equal(t, "get well", loaded.ReviewNote)
+		require.NotNil(t, loaded.ReviewedBy)
+		assert.Equal(t, adminID, *loaded.ReviewedBy)
+	})
+
+	t.Run("it approves leave only while the employee holds no shifts during it", func(t *testing.T) {
+		db := setupSQLiteTestDB(t)
+		repo := NewShiftRepository(log, db)
+		seedSwapFixture(t, db)
+		leave := &model.LeaveRequest{EmployeeID: 1, Type: employeeV1.LeaveVacation, Status: employeeV1.LeavePending, StartDate: leaveDay(10), EndDate: leaveDay(11)}
+		require.NoError(t, repo.CreateLeaveRequest(ctx, leave))
+
+		conflicts, err := repo.ApproveLeaveRequest(ctx, leave, leaveDay(10))
+		require.NoError(t, err)
+		require.Len(t, conflicts, 1)
+		assert.Equal(t, uint(10), conflicts[0].ID)
+		loaded, err := repo.GetLeaveRequest(ctx, leave.ID)
+		require.NoError(t, err)
+		assert.Equal(t, employeeV1.LeavePending, loaded.Status)
+
+		// shifts before the given day no longer count
+		conflicts, err = repo.ApproveLeaveRequest(ctx, leave, leaveDay(11))
+		require.NoError(t, err)
+		assert.Empty(t, conflicts)
+		loaded, err = repo.GetLeaveRequest(ctx, leave.ID)
+		require.NoError(t, err)
+		assert.Equal(t, employeeV1.LeaveApproved, loaded.Status)
+
+		_, err = repo.ApproveLeaveRequest(ctx, leave, leaveDay(11))
+		assert.ErrorIs(t, err, model.ErrLeaveChanged)
+	})
+}
+
+func TestShiftRepository_GetOnCallEmployeesSkipsLeave(t *testing.T) {
+	log := utils.NewTestLogger()
+	ctx := context.Background()
+	db := setupSQLiteTestDB(t)
+	repo := NewShiftRepository(log, db)
+	seedSwapFixture(t, db)
+	require.NoError(t, db.Create(&model.EmployeeShift{EmployeeID: 2, ShiftID: 10}).Error)
+
+	// 10 AM falls in the first shift of the day
+	now := leaveDay(10).Add(10 * time.Hour)
+	employees, err := repo.GetOnCallEmployees(ctx, now, 0)
+	require.NoError(t, err)
+	assert.Len(t, employees, 2)
+
+	require.NoError(t, repo.CreateLeaveRequest(ctx, &model.LeaveRequest{EmployeeID: 2, Type: employeeV1.LeaveSick, Status: employeeV1.LeaveApproved, StartDate: leaveDay(10), EndDate: leaveDay(11)}))
+	require.NoError(t, repo.CreateLeaveRequest(ctx, &model.LeaveRequest{EmployeeID: 1, Type: employeeV1.LeaveVacation, Status: employeeV1.LeavePending, StartDate: leaveDay(10), EndDate: leaveDay(10)}))
+
+	employees, err = repo.GetOnCallEmployees(ctx, now, 0)
+	require.NoError(t, err)
+	require.Len(t, employees, 1)
+	assert.Equal(t, uint(1), employees[0].ID)
+}
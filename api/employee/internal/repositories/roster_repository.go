@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pd120424d/mountain-service/api/employee/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// ShiftAssignmentRow is one employee on one shift
+type ShiftAssignmentRow struct {
+	EmployeeID  uint
+	ProfileType model.ProfileType
+	ShiftID     uint
+	ShiftDate   time.Time
+	ShiftType   int
+}
+
+// GetAssignmentsInDateRange returns every assignment on shifts dated in [start, end)
+func (r *shiftRepository) GetAssignmentsInDateRange(ctx context.Context, start, end time.Time) ([]ShiftAssignmentRow, error) {
+	var rows []ShiftAssignmentRow
+	err := r.withRead(ctx, func(db *gorm.DB) error {
+		return db.Table("employee_shifts").
+			Select("employee_shifts.employee_id, employees.profile_type, shifts.id AS shift_id, shifts.shift_date, shifts.shift_type").
+			Joins("JOIN shifts ON employee_shifts.shift_id = shifts.id").
+			Joins("JOIN employees ON employee_shifts.employee_id = employees.id").
+			Where("shifts.shift_date >= ? AND shifts.shift_date < ? AND employees.deleted_at IS NULL", start, end).
+			Order("shifts.shift_date ASC, shifts.shift_type ASC, employee_shifts.employee_id ASC").
+			Scan(&rows).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignments: %w", err)
+	}
+	return rows, nil
+}
+
+// CreateRosterAssignments creates all the assignments in one transaction, creating shifts as needed. ShiftID
+// of the rows is ignored. Nothing is saved if an employee already holds one of the shifts
+// (model.ErrAlreadyAssigned) or a shift ends up over the capacity of a profile (model.ErrCapacityReached).
+func (r *shiftRepository) CreateRosterAssignments(ctx context.Context, assignments []ShiftAssignmentRow) error {
+	type shiftProfile struct {
+		shiftID   uint
+		shiftDate time.Time
+		profile   model.ProfileType
+	}
+
+	return r.dbWrite.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		touched := make(map[shiftProfile]struct{})
+		for _, a := range assignments {
+			var shift model.Shift
+			if err := tx.FirstOrCreate(&shift, model.Shift{ShiftDate: a.ShiftDate, ShiftType: a.ShiftType}).Error; err != nil {
+				return fmt.Errorf("failed to find or create shift: %w", err)
+			}
+
+			var existing model.EmployeeShift
+			err := tx.Where("employee_id = ? AND shift_id = ?", a.EmployeeID, shift.ID).First(&existing).Error
+			if err == nil {
+				return fmt.Errorf("employee %d on shift %d: %w", a.EmployeeID, shift.ID, model.ErrAlreadyAssigned)
+			}
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("failed to check assignment: %w", err)
+			}
+
+			if err := tx.Create(&model.EmployeeShift{EmployeeID: a.EmployeeID, ShiftID: shift.ID}).Error; err != nil {
+				return fmt.Errorf("failed to create assignment: %w", err)
+			}
+			touched[shiftProfile{shiftID: shift.ID, shiftDate: shift.ShiftDate, profile: a.ProfileType}] = struct{}{}
+		}
+
+		var capacities []model.ShiftCapacity
+		if err := tx.Find(&capacities).Error; err != nil {
+			return fmt.Errorf("failed to get shift capacities: %w", err)
+		}
+
+		// recount inside the transaction so assignments made since the roster was validated still count
+		for key := range touched {
+			var count int64
+			err := tx.Table("employee_shifts").
+				Joins("JOIN employees ON employee_shifts.employee_id = employees.id").
+				Where("employee_shifts.shift_id = ? AND employees.profile_type = ?", key.shiftID, key.profile).
+				Count(&count).Error
+			if err != nil {
+				return fmt.Errorf("failed to count assignments: %w", err)
+			}
+			if count > int64(model.CapacityOn(capacities, key.shiftDate, key.profile)) {
+				return fmt.Errorf("shift %d for %s: %w", key.shiftID, key.profile, model.ErrCapacityReached)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pd120424d/mountain-service/api/employee/internal/model"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+func TestShiftRepository_GetAssignmentsInDateRange(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	repo := NewShiftRepository(utils.NewTestLogger(), db)
+	seedSwapFixture(t, db)
+
+	rows, err := repo.GetAssignmentsInDateRange(context.Background(), leaveDay(10), leaveDay(12))
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, uint(1), rows[0].EmployeeID)
+	assert.Equal(t, model.Medic, rows[0].ProfileType)
+	assert.Equal(t, uint(10), rows[0].ShiftID)
+	assert.Equal(t, 1, rows[0].ShiftType)
+	assert.True(t, rows[0].ShiftDate.Equal(leaveDay(10)))
+
+	rows, err = repo.GetAssignmentsInDateRange(context.Background(), leaveDay(10), leaveDay(13))
+	require.NoError(t, err)
+	assert.Len(t, rows, 2)
+}
+
+func TestShiftRepository_CreateRosterAssignments(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it creates shifts and assignments together", func(t *testing.T) {
+		db := setupSQLiteTestDB(t)
+		repo := NewShiftRepository(utils.NewTestLogger(), db)
+		seedSwapFixture(t, db)
+
+		err := repo.CreateRosterAssignments(ctx, []ShiftAssignmentRow{
+			{EmployeeID: 2, ProfileType: model.Medic, ShiftDate: leaveDay(10), ShiftType: 1},
+			{EmployeeID: 1, ProfileType: model.Medic, ShiftDate: leaveDay(15), ShiftType: 3},
+		})
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []uint{1, 2}, assignedEmployee(t, db, 10))
+		var shift model.Shift
+		require.NoError(t, db.Where("shift_date = ? AND shift_type = ?", leaveDay(15), 3).First(&shift).Error)
+		assert.Equal(t, []uint{1}, assignedEmployee(t, db, shift.ID))
+	})
+
+	tests := []struct {
+		name     string
+		rows     []ShiftAssignmentRow
+		capacity int
+		expected error
+	}{
+		{
+			name: "an existing assignment",
+			rows: []ShiftAssignmentRow{
+				{EmployeeID: 2, ProfileType: model.Medic, ShiftDate: leaveDay(15), ShiftType: 1},
+				{EmployeeID: 1, ProfileType: model.Medic, ShiftDate: leaveDay(10), ShiftType: 1},
+			},
+			capacity: 2,
+			expected: model.ErrAlreadyAssigned,
+		},
+		{
+			name: "a shift over capacity",
+			rows: []ShiftAssignmentRow{
+				{EmployeeID: 2, ProfileType: model.Medic, ShiftDate: leaveDay(15), ShiftType: 1},
+				{EmployeeID: 2, ProfileType: model.Medic, ShiftDate: leaveDay(10), ShiftType: 1},
+			},
+			capacity: 1,
+			expected: model.ErrCapacityReached,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("it saves nothing for "+tt.name, func(t *testing.T) {
+			db := setupSQLiteTestDB(t)
+			repo := NewShiftRepository(utils.NewTestLogger(), db)
+			seedSwapFixture(t, db)
+			require.NoError(t, repo.CreateShiftCapacity(ctx, &model.ShiftCapacity{ProfileType: model.Medic, Capacity: tt.capacity, EffectivePeriod: model.EffectivePeriod{EffectiveFrom: leaveDay(1)}}))
+
+			err := repo.CreateRosterAssignments(ctx, tt.rows)
+
+			assert.True(t, errors.Is(err, tt.expected), "unexpected error %v", err)
+			var count int64
+			require.NoError(t, db.Model(&model.EmployeeShift{}).Count(&count).Error)
+			assert.Equal(t, int64(2), count)
+			require.NoError(t, db.Model(&model.Shift{}).Count(&count).Error)
+			assert.Equal(t, int64(2), count)
+		})
+	}
+}
@@ -7,8 +7,10 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"maps"
 	"time"
 
+	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
 	"github.com/pd120424d/mountain-service/api/employee/internal/model"
 	"github.com/pd120424d/mountain-service/api/shared/utils"
 
@@ -25,8 +27,33 @@ type ShiftRepository interface {
 	GetShiftAvailability(ctx context.Context, start, end time.Time) (*model.ShiftsAvailabilityRange, error)
 	GetShiftAvailabilityWithEmployeeStatus(ctx context.Context, employeeID uint, start, end time.Time) (*model.ShiftsAvailabilityWithEmployeeStatus, error)
 	RemoveEmployeeFromShiftByDetails(ctx context.Context, employeeID uint, shiftDate time.Time, shiftType int) error
-	GetOnCallEmployees(ctx context.Context, currentTime time.Time, shiftBuffer time.Duration) ([]model.Employee, error)
+	GetOnCallEmployees(ctx context.Context, currentTime time.Time, shiftBuffer time.Duration) ([]model.OnCallEmployee, error)
 	GetEmployeeShiftRowsByEmployeeID(ctx context.Context, employeeID uint) ([]EmployeeShiftRow, error)
+	FindShift(ctx context.Context, shiftDate time.Time, shiftType int) (*model.Shift, error)
+
+	CreateShiftSwap(ctx context.Context, swap *model.ShiftSwap) error
+	GetShiftSwap(ctx context.Context, id uint) (*model.ShiftSwap, error)
+	ListShiftSwaps(ctx context.Context, filter ShiftSwapFilter) ([]model.ShiftSwap, error)
+	HasActiveShiftSwap(ctx context.Context, shiftID, requesterID uint) (bool, error)
+	UpdateShiftSwap(ctx context.Context, swap *model.ShiftSwap, from employeeV1.ShiftSwapStatus) error
+	CompleteShiftSwap(ctx context.Context, swap *model.ShiftSwap, from employeeV1.ShiftSwapStatus) error
+
+	CreateLeaveRequest(ctx context.Context, leave *model.LeaveRequest) error
+	GetLeaveRequest(ctx context.Context, id uint) (*model.LeaveRequest, error)
+	ListLeaveRequests(ctx context.Context, filter LeaveRequestFilter) ([]model.LeaveRequest, error)
+	HasApprovedLeave(ctx context.Context, employeeID uint, day time.Time) (bool, error)
+	UpdateLeaveRequest(ctx context.Context, leave *model.LeaveRequest, from employeeV1.LeaveStatus) error
+	ApproveLeaveRequest(ctx context.Context, leave *model.LeaveRequest, shiftsFrom time.Time) ([]model.Shift, error)
+
+	GetAssignmentsInDateRange(ctx context.Context, start, end time.Time) ([]ShiftAssignmentRow, error)
+	CreateRosterAssignments(ctx context.Context, assignments []ShiftAssignmentRow) error
+
+	ListShiftTemplates(ctx context.Context, from, to time.Time) ([]model.ShiftTemplate, error)
+	CreateShiftTemplate(ctx context.Context, template *model.ShiftTemplate) error
+	DeleteShiftTemplate(ctx context.Context, id uint) error
+	ListShiftCapacities(ctx context.Context, from, to time.Time) ([]model.ShiftCapacity, error)
+	CreateShiftCapacity(ctx context.Context, capacity *model.ShiftCapacity) error
+	DeleteShiftCapacity(ctx context.Context, id uint) error
 }
 
 // EmployeeShiftRow is a projection combining shift and assignment metadata
@@ -135,28 +162,36 @@ func (r *shiftRepository) GetEmployeeShiftRowsByEmployeeID(ctx context.Context,
 
 func (r *shiftRepository) GetShiftsByEmployeeIDInDateRange(ctx context.Context, employeeID uint, startDate, endDate time.Time, result *[]model.Shift) error {
 	return r.withRead(ctx, func(db *gorm.DB) error {
-		return db.Table("employee_shifts").
-			Select("shifts.id, shifts.shift_date, shifts.shift_type, shifts.created_at").
-			Joins("JOIN shifts ON employee_shifts.shift_id = shifts.id").
-			Where("employee_shifts.employee_id = ? AND shifts.shift_date >= ? AND shifts.shift_date < ?", employeeID, startDate, endDate).
-			Order("shifts.shift_date ASC, shifts.shift_type ASC").
-			Scan(result).Error
+		return employeeShiftsInRange(db, employeeID, startDate, endDate).Scan(result).Error
 	})
 }
 
+func employeeShiftsInRange(db *gorm.DB, employeeID uint, startDate, endDate time.Time) *gorm.DB {
+	return db.Table("employee_shifts").
+		Select("shifts.id, shifts.shift_date, shifts.shift_type, shifts.created_at").
+		Joins("JOIN shifts ON employee_shifts.shift_id = shifts.id").
+		Where("employee_shifts.employee_id = ? AND shifts.shift_date >= ? AND shifts.shift_date < ?", employeeID, startDate, endDate).
+		Order("shifts.shift_date ASC, shifts.shift_type ASC")
+}
+
 func (r *shiftRepository) GetShiftAvailability(ctx context.Context, start, end time.Time) (*model.ShiftsAvailabilityRange, error) {
 	result := model.ShiftsAvailabilityRange{
 		Days: map[time.Time][]map[model.ProfileType]int{},
 	}
 
+	capacities, err := r.ListShiftCapacities(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
 	// Initial availability per shift
 	for d := start; d.Before(end); d = d.Add(24 * time.Hour) {
 		day := d.Truncate(24 * time.Hour)
-		result.Days[day] = []map[model.ProfileType]int{
-			{model.Medic: 2, model.Technical: 4},
-			{model.Medic: 2, model.Technical: 4},
-			{model.Medic: 2, model.Technical: 4},
+		perShift := map[model.ProfileType]int{
+			model.Medic:     model.CapacityOn(capacities, day, model.Medic),
+			model.Technical: model.CapacityOn(capacities, day, model.Technical),
 		}
+		result.Days[day] = []map[model.ProfileType]int{perShift, maps.Clone(perShift), maps.Clone(perShift)}
 	}
 
 	// Query assigned employees grouped by shift and role
@@ -167,7 +202,7 @@ func (r *shiftRepository) GetShiftAvailability(ctx context.Context, start, end t
 		Count        int
 	}
 
-	err := r.withRead(ctx, func(db *gorm.DB) error {
+	err = r.withRead(ctx, func(db *gorm.DB) error {
 		return db.Table("shifts").
 			Joins("JOIN employee_shifts ON shifts.id = employee_shifts.shift_id").
 			Joins("JOIN employees ON employee_shifts.employee_id = employees.id").
@@ -199,14 +234,19 @@ func (r *shiftRepository) GetShiftAvailabilityWithEmployeeStatus(ctx context.Con
 		Days: map[time.Time][]model.ShiftAvailabilityWithStatus{},
 	}
 
+	capacities, err := r.ListShiftCapacities(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
 	// Initial availability per shift
 	for d := start; d.Before(end); d = d.Add(24 * time.Hour) {
 		day := d.Truncate(24 * time.Hour)
-		result.Days[day] = []model.ShiftAvailabilityWithStatus{
-			{MedicSlotsAvailable: 2, TechnicalSlotsAvailable: 4, IsAssignedToEmployee: false, IsFullyBooked: false},
-			{MedicSlotsAvailable: 2, TechnicalSlotsAvailable: 4, IsAssignedToEmployee: false, IsFullyBooked: false},
-			{MedicSlotsAvailable: 2, TechnicalSlotsAvailable: 4, IsAssignedToEmployee: false, IsFullyBooked: false},
+		initial := model.ShiftAvailabilityWithStatus{
+			MedicSlotsAvailable:     model.CapacityOn(capacities, day, model.Medic),
+			TechnicalSlotsAvailable: model.CapacityOn(capacities, day, model.Technical),
 		}
+		result.Days[day] = []model.ShiftAvailabilityWithStatus{initial, initial, initial}
 	}
 
 	// Query assigned employees grouped by shift and role
@@ -217,7 +257,7 @@ func (r *shiftRepository) GetShiftAvailabilityWithEmployeeStatus(ctx context.Con
 		Count        int
 	}
 
-	err := r.dbRead.Table("shifts").
+	err = r.dbRead.Table("shifts").
 		Joins("JOIN employee_shifts ON shifts.id = employee_shifts.shift_id").
 		Joins("JOIN employees ON employee_shifts.employee_id = employees.id").
 		Select("shifts.shift_date, shifts.shift_type, employees.profile_type AS employee_role, COUNT(*) AS count").
@@ -236,9 +276,9 @@ func (r *shiftRepository) GetShiftAvailabilityWithEmployeeStatus(ctx context.Con
 			if shiftIndex >= 0 && shiftIndex < len(dayShifts) {
 				switch count.EmployeeRole {
 				case "Medic":
-					result.Days[day][shiftIndex].MedicSlotsAvailable = max(0, 2-count.Count)
+					result.Days[day][shiftIndex].MedicSlotsAvailable = max(0, model.CapacityOn(capacities, day, model.Medic)-count.Count)
 				case "Technical":
-					result.Days[day][shiftIndex].TechnicalSlotsAvailable = max(0, 4-count.Count)
+					result.Days[day][shiftIndex].TechnicalSlotsAvailable = max(0, model.CapacityOn(capacities, day, model.Technical)-count.Count)
 				}
 			}
 		}
@@ -310,13 +350,20 @@ func (r *shiftRepository) RemoveEmployeeFromShiftByDetails(ctx context.Context,
 // GetOnCallEmployees returns all emloyees who are assigned to the current shift with one exception:
 // If the current shift is ending soon (within the shiftBuffer), we also include employees assigned to the next shift
 // If the shiftBuffer is 0, we only include employees assigned to the current shift
-func (r *shiftRepository) GetOnCallEmployees(ctx context.Context, currentTime time.Time, shiftBuffer time.Duration) ([]model.Employee, error) {
+// Each employee reports whether they are on the current shift or only on the next one
+func (r *shiftRepository) GetOnCallEmployees(ctx context.Context, currentTime time.Time, shiftBuffer time.Duration) ([]model.OnCallEmployee, error) {
 	r.log.Infof("Getting on-call employees at %v with buffer %v", currentTime, shiftBuffer)
 
-	currentShiftType := r.getShiftTypeForTime(currentTime)
 	currentDate := currentTime.Truncate(24 * time.Hour)
+	templates, err := r.ListShiftTemplates(ctx, currentDate, currentDate)
+	if err != nil {
+		r.log.Errorf("Failed to get shift templates: %v", err)
+		return nil, fmt.Errorf("failed to get on-call employees: %w", err)
+	}
+	template := model.ShiftTemplateOn(templates, currentDate)
+	currentShiftType := r.getShiftTypeForTime(template, currentTime)
 
-	var employees []model.Employee
+	var employees []model.OnCallEmployee
 	var shiftDates []time.Time
 	var shiftTypes []int
 
@@ -325,7 +372,7 @@ func (r *shiftRepository) GetOnCallEmployees(ctx context.Context, currentTime ti
 
 	// if buffer is defined we may need to include the following shift as well
 	if shiftBuffer > 0 {
-		timeUntilShiftEnd := r.getTimeUntilShiftEnd(currentTime, currentShiftType)
+		timeUntilShiftEnd := r.getTimeUntilShiftEnd(template, currentTime, currentShiftType)
 		if timeUntilShiftEnd <= shiftBuffer {
 			r.log.Infof("Including next shift due to buffer: timeUntilShiftEnd=%v, buffer=%v", timeUntilShiftEnd, shiftBuffer)
 
@@ -337,15 +384,18 @@ func (r *shiftRepository) GetOnCallEmployees(ctx context.Context, currentTime ti
 
 	var queryErr error
 	_ = r.withRead(ctx, func(db *gorm.DB) error {
-		q := db.Distinct().
-			Select("employees.*").
+		onShift := db.Where("(shifts.shift_date = ? AND shifts.shift_type = ?)", shiftDates[0], shiftTypes[0])
+		for i := 1; i < len(shiftDates); i++ {
+			onShift = onShift.Or("(shifts.shift_date = ? AND shifts.shift_type = ?)", shiftDates[i], shiftTypes[i])
+		}
+		// employees on approved leave that day stay off call even if the shift still lists them
+		q := db.Select("employees.*, MAX(CASE WHEN shifts.shift_date = ? AND shifts.shift_type = ? THEN 1 ELSE 0 END) AS on_current_shift", shiftDates[0], shiftTypes[0]).
 			Table("employees").
 			Joins("JOIN employee_shifts ON employees.id = employee_shifts.employee_id").
 			Joins("JOIN shifts ON employee_shifts.shift_id = shifts.id").
-			Where("(shifts.shift_date = ? AND shifts.shift_type = ?)", shiftDates[0], shiftTypes[0])
-		for i := 1; i < len(shiftDates); i++ {
-			q = q.Or("(shifts.shift_date = ? AND shifts.shift_type = ?)", shiftDates[i], shiftTypes[i])
-		}
+			Where(onShift).
+			Where(approvedLeaveOnShiftDate, employeeV1.LeaveApproved).
+			Group("employees.id")
 		queryErr = q.Find(&employees).Error
 		return queryErr
 	})
@@ -358,43 +408,28 @@ func (r *shiftRepository) GetOnCallEmployees(ctx context.Context, currentTime ti
 	return employees, nil
 }
 
-func (r *shiftRepository) getShiftTypeForTime(currentTime time.Time) int {
-	hour := currentTime.Hour()
+// getShiftTypeForTime returns the shift of the template running at the time of day
+func (r *shiftRepository) getShiftTypeForTime(template model.ShiftTemplate, currentTime time.Time) int {
+	minute := currentTime.Hour()*60 + currentTime.Minute()
+	starts := template.StartMinutes()
 
-	// Shift types: 1: 6am-2pm, 2: 2pm-10pm, 3: 10pm-6am
-	if hour >= 6 && hour < 14 {
+	if minute >= starts[0] && minute < starts[1] {
 		return 1
-	} else if hour >= 14 && hour < 22 {
+	} else if minute >= starts[1] && minute < starts[2] {
 		return 2
 	}
 	return 3
 }
 
-func (r *shiftRepository) getTimeUntilShiftEnd(currentTime time.Time, shiftType int) time.Duration {
-	hour := currentTime.Hour()
-	minute := currentTime.Minute()
-	second := currentTime.Second()
-
-	currentMinutes := hour*60 + minute
-	currentSeconds := currentMinutes*60 + second
-
-	var shiftEndSeconds int
-	switch shiftType {
-	case 1: // 6am-2pm, ends at 14:00
-		shiftEndSeconds = 14 * 3600
-	case 2: // 2pm-10pm, ends at 22:00
-		shiftEndSeconds = 22 * 3600
-	case 3: // 10pm-6am, ends at 6:00 next day
-		if hour >= 22 {
-			// Same day, ends at 6am next day
-			shiftEndSeconds = 24*3600 + 6*3600
-		} else {
-			// Next day, ends at 6am
-			shiftEndSeconds = 6 * 3600
-		}
-	default:
+// getTimeUntilShiftEnd returns how long the shift runs after the time of day; each shift ends when the next
+// one of the template starts, the third one on the following morning
+func (r *shiftRepository) getTimeUntilShiftEnd(template model.ShiftTemplate, currentTime time.Time, shiftType int) time.Duration {
+	if shiftType < 1 || shiftType > 3 {
 		return 0
 	}
+	starts := template.StartMinutes()
+	shiftEndSeconds := starts[shiftType%3] * 60
+	currentSeconds := currentTime.Hour()*3600 + currentTime.Minute()*60 + currentTime.Second()
 
 	remainingSeconds := shiftEndSeconds - currentSeconds
 	if remainingSeconds < 0 {
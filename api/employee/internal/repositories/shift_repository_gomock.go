@@ -10,10 +10,11 @@
 package repositories
 
 import (
-	"context"
+	context "context"
 	reflect "reflect"
 	time "time"
 
+	v1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
 	model "github.com/pd120424d/mountain-service/api/employee/internal/model"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -42,6 +43,21 @@ func (m *MockShiftRepository) EXPECT() *MockShiftRepositoryMockRecorder {
 	return m.recorder
 }
 
+// ApproveLeaveRequest mocks base method.
+func (m *MockShiftRepository) ApproveLeaveRequest(ctx context.Context, leave *model.LeaveRequest, shiftsFrom time.Time) ([]model.Shift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveLeaveRequest", ctx, leave, shiftsFrom)
+	ret0, _ := ret[0].([]model.Shift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveLeaveRequest indicates an expected call of ApproveLeaveRequest.
+func (mr *MockShiftRepositoryMockRecorder) ApproveLeaveRequest(ctx, leave, shiftsFrom any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveLeaveRequest", reflect.TypeOf((*MockShiftRepository)(nil).ApproveLeaveRequest), ctx, leave, shiftsFrom)
+}
+
 // AssignedToShift mocks base method.
 func (m *MockShiftRepository) AssignedToShift(ctx context.Context, employeeID, shiftID uint) (bool, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +73,20 @@ func (mr *MockShiftRepositoryMockRecorder) AssignedToShift(ctx, employeeID, shif
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignedToShift", reflect.TypeOf((*MockShiftRepository)(nil).AssignedToShift), ctx, employeeID, shiftID)
 }
 
+// CompleteShiftSwap mocks base method.
+func (m *MockShiftRepository) CompleteShiftSwap(ctx context.Context, swap *model.ShiftSwap, from v1.ShiftSwapStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteShiftSwap", ctx, swap, from)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteShiftSwap indicates an expected call of CompleteShiftSwap.
+func (mr *MockShiftRepositoryMockRecorder) CompleteShiftSwap(ctx, swap, from any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteShiftSwap", reflect.TypeOf((*MockShiftRepository)(nil).CompleteShiftSwap), ctx, swap, from)
+}
+
 // CountAssignmentsByProfile mocks base method.
 func (m *MockShiftRepository) CountAssignmentsByProfile(ctx context.Context, shiftID uint, profileType model.ProfileType) (int64, error) {
 	m.ctrl.T.Helper()
@@ -87,11 +117,169 @@ func (mr *MockShiftRepositoryMockRecorder) CreateAssignment(ctx, employeeID, shi
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAssignment", reflect.TypeOf((*MockShiftRepository)(nil).CreateAssignment), ctx, employeeID, shiftID)
 }
 
+// CreateLeaveRequest mocks base method.
+func (m *MockShiftRepository) CreateLeaveRequest(ctx context.Context, leave *model.LeaveRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLeaveRequest", ctx, leave)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateLeaveRequest indicates an expected call of CreateLeaveRequest.
+func (mr *MockShiftRepositoryMockRecorder) CreateLeaveRequest(ctx, leave any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLeaveRequest", reflect.TypeOf((*MockShiftRepository)(nil).CreateLeaveRequest), ctx, leave)
+}
+
+// CreateRosterAssignments mocks base method.
+func (m *MockShiftRepository) CreateRosterAssignments(ctx context.Context, assignments []ShiftAssignmentRow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRosterAssignments", ctx, assignments)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRosterAssignments indicates an expected call of CreateRosterAssignments.
+func (mr *MockShiftRepositoryMockRecorder) CreateRosterAssignments(ctx, assignments any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRosterAssignments", reflect.TypeOf((*MockShiftRepository)(nil).CreateRosterAssignments), ctx, assignments)
+}
+
+// CreateShiftCapacity mocks base method.
+func (m *MockShiftRepository) CreateShiftCapacity(ctx context.Context, capacity *model.ShiftCapacity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShiftCapacity", ctx, capacity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateShiftCapacity indicates an expected call of CreateShiftCapacity.
+func (mr *MockShiftRepositoryMockRecorder) CreateShiftCapacity(ctx, capacity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShiftCapacity", reflect.TypeOf((*MockShiftRepository)(nil).CreateShiftCapacity), ctx, capacity)
+}
+
+// CreateShiftSwap mocks base method.
+func (m *MockShiftRepository) CreateShiftSwap(ctx context.Context, swap *model.ShiftSwap) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShiftSwap", ctx, swap)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateShiftSwap indicates an expected call of CreateShiftSwap.
+func (mr *MockShiftRepositoryMockRecorder) CreateShiftSwap(ctx, swap any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShiftSwap", reflect.TypeOf((*MockShiftRepository)(nil).CreateShiftSwap), ctx, swap)
+}
+
+// CreateShiftTemplate mocks base method.
+func (m *MockShiftRepository) CreateShiftTemplate(ctx context.Context, template *model.ShiftTemplate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShiftTemplate", ctx, template)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateShiftTemplate indicates an expected call of CreateShiftTemplate.
+func (mr *MockShiftRepositoryMockRecorder) CreateShiftTemplate(ctx, template any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShiftTemplate", reflect.TypeOf((*MockShiftRepository)(nil).CreateShiftTemplate), ctx, template)
+}
+
+// DeleteShiftCapacity mocks base method.
+func (m *MockShiftRepository) DeleteShiftCapacity(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteShiftCapacity", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteShiftCapacity indicates an expected call of DeleteShiftCapacity.
+func (mr *MockShiftRepositoryMockRecorder) DeleteShiftCapacity(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShiftCapacity", reflect.TypeOf((*MockShiftRepository)(nil).DeleteShiftCapacity), ctx, id)
+}
+
+// DeleteShiftTemplate mocks base method.
+func (m *MockShiftRepository) DeleteShiftTemplate(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteShiftTemplate", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteShiftTemplate indicates an expected call of DeleteShiftTemplate.
+func (mr *MockShiftRepositoryMockRecorder) DeleteShiftTemplate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShiftTemplate", reflect.TypeOf((*MockShiftRepository)(nil).DeleteShiftTemplate), ctx, id)
+}
+
+// FindShift mocks base method.
+func (m *MockShiftRepository) FindShift(ctx context.Context, shiftDate time.Time, shiftType int) (*model.Shift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindShift", ctx, shiftDate, shiftType)
+	ret0, _ := ret[0].(*model.Shift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindShift indicates an expected call of FindShift.
+func (mr *MockShiftRepositoryMockRecorder) FindShift(ctx, shiftDate, shiftType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindShift", reflect.TypeOf((*MockShiftRepository)(nil).FindShift), ctx, shiftDate, shiftType)
+}
+
+// GetAssignmentsInDateRange mocks base method.
+func (m *MockShiftRepository) GetAssignmentsInDateRange(ctx context.Context, start, end time.Time) ([]ShiftAssignmentRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssignmentsInDateRange", ctx, start, end)
+	ret0, _ := ret[0].([]ShiftAssignmentRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssignmentsInDateRange indicates an expected call of GetAssignmentsInDateRange.
+func (mr *MockShiftRepositoryMockRecorder) GetAssignmentsInDateRange(ctx, start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignmentsInDateRange", reflect.TypeOf((*MockShiftRepository)(nil).GetAssignmentsInDateRange), ctx, start, end)
+}
+
+// GetEmployeeShiftRowsByEmployeeID mocks base method.
+func (m *MockShiftRepository) GetEmployeeShiftRowsByEmployeeID(ctx context.Context, employeeID uint) ([]EmployeeShiftRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmployeeShiftRowsByEmployeeID", ctx, employeeID)
+	ret0, _ := ret[0].([]EmployeeShiftRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmployeeShiftRowsByEmployeeID indicates an expected call of GetEmployeeShiftRowsByEmployeeID.
+func (mr *MockShiftRepositoryMockRecorder) GetEmployeeShiftRowsByEmployeeID(ctx, employeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployeeShiftRowsByEmployeeID", reflect.TypeOf((*MockShiftRepository)(nil).GetEmployeeShiftRowsByEmployeeID), ctx, employeeID)
+}
+
+// GetLeaveRequest mocks base method.
+func (m *MockShiftRepository) GetLeaveRequest(ctx context.Context, id uint) (*model.LeaveRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLeaveRequest", ctx, id)
+	ret0, _ := ret[0].(*model.LeaveRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLeaveRequest indicates an expected call of GetLeaveRequest.
+func (mr *MockShiftRepositoryMockRecorder) GetLeaveRequest(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeaveRequest", reflect.TypeOf((*MockShiftRepository)(nil).GetLeaveRequest), ctx, id)
+}
+
 // GetOnCallEmployees mocks base method.
-func (m *MockShiftRepository) GetOnCallEmployees(ctx context.Context, currentTime time.Time, shiftBuffer time.Duration) ([]model.Employee, error) {
+func (m *MockShiftRepository) GetOnCallEmployees(ctx context.Context, currentTime time.Time, shiftBuffer time.Duration) ([]model.OnCallEmployee, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetOnCallEmployees", ctx, currentTime, shiftBuffer)
-	ret0, _ := ret[0].([]model.Employee)
+	ret0, _ := ret[0].([]model.OnCallEmployee)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -147,6 +335,21 @@ func (mr *MockShiftRepositoryMockRecorder) GetShiftAvailabilityWithEmployeeStatu
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShiftAvailabilityWithEmployeeStatus", reflect.TypeOf((*MockShiftRepository)(nil).GetShiftAvailabilityWithEmployeeStatus), ctx, employeeID, start, end)
 }
 
+// GetShiftSwap mocks base method.
+func (m *MockShiftRepository) GetShiftSwap(ctx context.Context, id uint) (*model.ShiftSwap, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShiftSwap", ctx, id)
+	ret0, _ := ret[0].(*model.ShiftSwap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShiftSwap indicates an expected call of GetShiftSwap.
+func (mr *MockShiftRepositoryMockRecorder) GetShiftSwap(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShiftSwap", reflect.TypeOf((*MockShiftRepository)(nil).GetShiftSwap), ctx, id)
+}
+
 // GetShiftsByEmployeeID mocks base method.
 func (m *MockShiftRepository) GetShiftsByEmployeeID(ctx context.Context, employeeID uint, result *[]model.Shift) error {
 	m.ctrl.T.Helper()
@@ -175,19 +378,94 @@ func (mr *MockShiftRepositoryMockRecorder) GetShiftsByEmployeeIDInDateRange(ctx,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShiftsByEmployeeIDInDateRange", reflect.TypeOf((*MockShiftRepository)(nil).GetShiftsByEmployeeIDInDateRange), ctx, employeeID, startDate, endDate, result)
 }
 
-// GetEmployeeShiftRowsByEmployeeID mocks base method.
-func (m *MockShiftRepository) GetEmployeeShiftRowsByEmployeeID(ctx context.Context, employeeID uint) ([]EmployeeShiftRow, error) {
+// HasActiveShiftSwap mocks base method.
+func (m *MockShiftRepository) HasActiveShiftSwap(ctx context.Context, shiftID, requesterID uint) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEmployeeShiftRowsByEmployeeID", ctx, employeeID)
-	ret0, _ := ret[0].([]EmployeeShiftRow)
+	ret := m.ctrl.Call(m, "HasActiveShiftSwap", ctx, shiftID, requesterID)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetEmployeeShiftRowsByEmployeeID indicates an expected call of GetEmployeeShiftRowsByEmployeeID.
-func (mr *MockShiftRepositoryMockRecorder) GetEmployeeShiftRowsByEmployeeID(ctx, employeeID any) *gomock.Call {
+// HasActiveShiftSwap indicates an expected call of HasActiveShiftSwap.
+func (mr *MockShiftRepositoryMockRecorder) HasActiveShiftSwap(ctx, shiftID, requesterID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployeeShiftRowsByEmployeeID", reflect.TypeOf((*MockShiftRepository)(nil).GetEmployeeShiftRowsByEmployeeID), ctx, employeeID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasActiveShiftSwap", reflect.TypeOf((*MockShiftRepository)(nil).HasActiveShiftSwap), ctx, shiftID, requesterID)
+}
+
+// HasApprovedLeave mocks base method.
+func (m *MockShiftRepository) HasApprovedLeave(ctx context.Context, employeeID uint, day time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasApprovedLeave", ctx, employeeID, day)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasApprovedLeave indicates an expected call of HasApprovedLeave.
+func (mr *MockShiftRepositoryMockRecorder) HasApprovedLeave(ctx, employeeID, day any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasApprovedLeave", reflect.TypeOf((*MockShiftRepository)(nil).HasApprovedLeave), ctx, employeeID, day)
+}
+
+// ListLeaveRequests mocks base method.
+func (m *MockShiftRepository) ListLeaveRequests(ctx context.Context, filter LeaveRequestFilter) ([]model.LeaveRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLeaveRequests", ctx, filter)
+	ret0, _ := ret[0].([]model.LeaveRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLeaveRequests indicates an expected call of ListLeaveRequests.
+func (mr *MockShiftRepositoryMockRecorder) ListLeaveRequests(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLeaveRequests", reflect.TypeOf((*MockShiftRepository)(nil).ListLeaveRequests), ctx, filter)
+}
+
+// ListShiftCapacities mocks base method.
+func (m *MockShiftRepository) ListShiftCapacities(ctx context.Context, from, to time.Time) ([]model.ShiftCapacity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListShiftCapacities", ctx, from, to)
+	ret0, _ := ret[0].([]model.ShiftCapacity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListShiftCapacities indicates an expected call of ListShiftCapacities.
+func (mr *MockShiftRepositoryMockRecorder) ListShiftCapacities(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShiftCapacities", reflect.TypeOf((*MockShiftRepository)(nil).ListShiftCapacities), ctx, from, to)
+}
+
+// ListShiftSwaps mocks base method.
+func (m *MockShiftRepository) ListShiftSwaps(ctx context.Context, filter ShiftSwapFilter) ([]model.ShiftSwap, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListShiftSwaps", ctx, filter)
+	ret0, _ := ret[0].([]model.ShiftSwap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListShiftSwaps indicates an expected call of ListShiftSwaps.
+func (mr *MockShiftRepositoryMockRecorder) ListShiftSwaps(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShiftSwaps", reflect.TypeOf((*MockShiftRepository)(nil).ListShiftSwaps), ctx, filter)
+}
+
+// ListShiftTemplates mocks base method.
+func (m *MockShiftRepository) ListShiftTemplates(ctx context.Context, from, to time.Time) ([]model.ShiftTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListShiftTemplates", ctx, from, to)
+	ret0, _ := ret[0].([]model.ShiftTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListShiftTemplates indicates an expected call of ListShiftTemplates.
+func (mr *MockShiftRepositoryMockRecorder) ListShiftTemplates(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShiftTemplates", reflect.TypeOf((*MockShiftRepository)(nil).ListShiftTemplates), ctx, from, to)
 }
 
 // RemoveEmployeeFromShiftByDetails mocks base method.
@@ -203,3 +481,31 @@ func (mr *MockShiftRepositoryMockRecorder) RemoveEmployeeFromShiftByDetails(ctx,
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveEmployeeFromShiftByDetails", reflect.TypeOf((*MockShiftRepository)(nil).RemoveEmployeeFromShiftByDetails), ctx, employeeID, shiftDate, shiftType)
 }
+
+// UpdateLeaveRequest mocks base method.
+func (m *MockShiftRepository) UpdateLeaveRequest(ctx context.Context, leave *model.LeaveRequest, from v1.LeaveStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLeaveRequest", ctx, leave, from)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLeaveRequest indicates an expected call of UpdateLeaveRequest.
+func (mr *MockShiftRepositoryMockRecorder) UpdateLeaveRequest(ctx, leave, from any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLeaveRequest", reflect.TypeOf((*MockShiftRepository)(nil).UpdateLeaveRequest), ctx, leave, from)
+}
+
+// UpdateShiftSwap mocks base method.
+func (m *MockShiftRepository) UpdateShiftSwap(ctx context.Context, swap *model.ShiftSwap, from v1.ShiftSwapStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateShiftSwap", ctx, swap, from)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateShiftSwap indicates an expected call of UpdateShiftSwap.
+func (mr *MockShiftRepositoryMockRecorder) UpdateShiftSwap(ctx, swap, from any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateShiftSwap", reflect.TypeOf((*MockShiftRepository)(nil).UpdateShiftSwap), ctx, swap, from)
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pd120424d/mountain-service/api/employee/internal/model"
 	"github.com/pd120424d/mountain-service/api/shared/utils"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -96,6 +97,7 @@ func TestShiftRepositoryMockDB_GetShiftAvailability(t *testing.T) {
 
 	t.Run("it fails to get shifts availability when the query fails", func(t *testing.T) {
 
+		mock.ExpectQuery(`SELECT \* FROM "shift_capacities"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
 		expectedSQL := `SELECT shifts.shift_date, shifts.shift_type, employees.profile_type AS employee_role, COUNT(*) AS count FROM "shifts" JOIN employee_shifts ON shifts.id = employee_shifts.shift_id JOIN employees ON employee_shifts.employee_id = employees.id WHERE shift_date >= $1 AND shift_date < $2 GROUP BY shifts.shift_date, shifts.shift_type, employees.profile_type`
 		mock.ExpectQuery(regexp.QuoteMeta(expectedSQL)).
 			WithArgs(
@@ -178,7 +180,8 @@ func TestShiftRepositoryMockDB_GetOnCallEmployees(t *testing.T) {
 	gormDB.Logger = gormDB.Logger.LogMode(logger.Info)
 
 	t.Run("it fails to get on-call employees when the query fails", func(t *testing.T) {
-		mock.ExpectQuery(`SELECT DISTINCT employees\.\* FROM "employees" JOIN employee_shifts ON employees\.id = employee_shifts\.employee_id JOIN shifts ON employee_shifts\.shift_id = shifts\.id WHERE \(\(shifts\.shift_date = \$1 AND shifts\.shift_type = \$2\)\) AND "employees"\."deleted_at" IS NULL`).
+		mock.ExpectQuery(`SELECT \* FROM "shift_templates"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(`SELECT employees\.\*, MAX\(CASE WHEN shifts\.shift_date = \$1 AND shifts\.shift_type = \$2 THEN 1 ELSE 0 END\) AS on_current_shift FROM "employees" JOIN employee_shifts ON employees\.id = employee_shifts\.employee_id JOIN shifts ON employee_shifts\.shift_id = shifts\.id WHERE \(\(shifts\.shift_date = \$3 AND shifts\.shift_type = \$4\)\) AND \(NOT EXISTS \(SELECT 1 FROM leave_requests WHERE leave_requests\.employee_id = employees\.id AND leave_requests\.status = \$5 AND leave_requests\.start_date <= shifts\.shift_date AND leave_requests\.end_date >= shifts\.shift_date\)\) AND "employees"\."deleted_at" IS NULL GROUP BY "employees"\."id"`).
 			WillReturnError(sqlmock.ErrCancelled)
 
 		testTime := time.Date(2023, 1, 15, 10, 0, 0, 0, time.UTC)            // 10 AM, should be shift 1
@@ -192,7 +195,8 @@ func TestShiftRepositoryMockDB_GetOnCallEmployees(t *testing.T) {
 			AddRow(1, time.Now(), time.Now(), nil, "petar_petrovic", "hashed_password", "Petar", "Petrovic", "M", "123456789", "petar@example.com", "", "Medic").
 			AddRow(2, time.Now(), time.Now(), nil, "marko_markovic", "hashed_password", "Marko", "Markovic", "F", "987654321", "marko@example.com", "", "Technical")
 
-		mock.ExpectQuery(`SELECT DISTINCT employees\.\* FROM "employees" JOIN employee_shifts ON employees\.id = employee_shifts\.employee_id JOIN shifts ON employee_shifts\.shift_id = shifts\.id WHERE \(\(shifts\.shift_date = \$1 AND shifts\.shift_type = \$2\)\) AND "employees"\."deleted_at" IS NULL`).
+		mock.ExpectQuery(`SELECT \* FROM "shift_templates"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(`SELECT employees\.\*, MAX\(CASE WHEN shifts\.shift_date = \$1 AND shifts\.shift_type = \$2 THEN 1 ELSE 0 END\) AS on_current_shift FROM "employees" JOIN employee_shifts ON employees\.id = employee_shifts\.employee_id JOIN shifts ON employee_shifts\.shift_id = shifts\.id WHERE \(\(shifts\.shift_date = \$3 AND shifts\.shift_type = \$4\)\) AND \(NOT EXISTS \(SELECT 1 FROM leave_requests WHERE leave_requests\.employee_id = employees\.id AND leave_requests\.status = \$5 AND leave_requests\.start_date <= shifts\.shift_date AND leave_requests\.end_date >= shifts\.shift_date\)\) AND "employees"\."deleted_at" IS NULL GROUP BY "employees"\."id"`).
 			WillReturnRows(rows)
 
 		testTime := time.Date(2023, 1, 15, 10, 0, 0, 0, time.UTC)                    // 10 AM, should be shift 1
@@ -206,12 +210,13 @@ func TestShiftRepositoryMockDB_GetOnCallEmployees(t *testing.T) {
 	})
 
 	t.Run("it includes next shift employees when within buffer", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "username", "password", "first_name", "last_name", "gender", "phone", "email", "profile_picture", "profile_type"}).
-			AddRow(1, time.Now(), time.Now(), nil, "current_shift", "hashed_password", "Current", "Shift", "M", "123456789", "current@example.com", "", "Medic").
-			AddRow(2, time.Now(), time.Now(), nil, "next_shift", "hashed_password", "Next", "Shift", "F", "987654321", "next@example.com", "", "Technical")
+		rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "username", "password", "first_name", "last_name", "gender", "phone", "email", "profile_picture", "profile_type", "on_current_shift"}).
+			AddRow(1, time.Now(), time.Now(), nil, "current_shift", "hashed_password", "Current", "Shift", "M", "123456789", "current@example.com", "", "Medic", 1).
+			AddRow(2, time.Now(), time.Now(), nil, "next_shift", "hashed_password", "Next", "Shift", "F", "987654321", "next@example.com", "", "Technical", 0)
 
 		// Note: GORM adds extra parentheses around each condition in OR clauses
-		mock.ExpectQuery(`SELECT DISTINCT employees\.\* FROM "employees" JOIN employee_shifts ON employees\.id = employee_shifts\.employee_id JOIN shifts ON employee_shifts\.shift_id = shifts\.id WHERE \(\(\(shifts\.shift_date = \$1 AND shifts\.shift_type = \$2\)\) OR \(\(shifts\.shift_date = \$3 AND shifts\.shift_type = \$4\)\)\) AND "employees"\."deleted_at" IS NULL`).
+		mock.ExpectQuery(`SELECT \* FROM "shift_templates"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(`SELECT employees\.\*, MAX\(CASE WHEN shifts\.shift_date = \$1 AND shifts\.shift_type = \$2 THEN 1 ELSE 0 END\) AS on_current_shift FROM "employees" JOIN employee_shifts ON employees\.id = employee_shifts\.employee_id JOIN shifts ON employee_shifts\.shift_id = shifts\.id WHERE \(\(\(shifts\.shift_date = \$3 AND shifts\.shift_type = \$4\)\) OR \(\(shifts\.shift_date = \$5 AND shifts\.shift_type = \$6\)\)\) AND \(NOT EXISTS \(SELECT 1 FROM leave_requests WHERE leave_requests\.employee_id = employees\.id AND leave_requests\.status = \$7 AND leave_requests\.start_date <= shifts\.shift_date AND leave_requests\.end_date >= shifts\.shift_date\)\) AND "employees"\."deleted_at" IS NULL GROUP BY "employees"\."id"`).
 			WillReturnRows(rows)
 
 		testTime := time.Date(2023, 1, 15, 13, 30, 0, 0, time.UTC)                             // 1:30 PM, 30 min before shift 1 ends
@@ -219,6 +224,8 @@ func TestShiftRepositoryMockDB_GetOnCallEmployees(t *testing.T) {
 
 		assert.NoError(t, err)
 		assert.Len(t, employees, 2)
+		assert.True(t, employees[0].OnCurrentShift)
+		assert.False(t, employees[1].OnCurrentShift)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
@@ -255,7 +262,7 @@ func TestShiftRepository_getShiftTypeForTime(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			testTime := time.Date(2023, 1, 1, tt.hour, tt.minute, 0, 0, time.UTC)
-			result := repo.getShiftTypeForTime(testTime)
+			result := repo.getShiftTypeForTime(model.DefaultShiftTemplate, testTime)
 			assert.Equal(t, tt.expected, result, "Expected shift type %d for time %s", tt.expected, testTime.Format("15:04"))
 		})
 	}
@@ -271,10 +278,21 @@ func TestShiftRepository_getShiftTypeForTime(t *testing.T) {
 		}
 
 		for _, b := range boundaries {
-			result := repo.getShiftTypeForTime(b.time)
+			result := repo.getShiftTypeForTime(model.DefaultShiftTemplate, b.time)
 			assert.Equal(t, b.shiftType, result, "Time %s should be shift %d", b.time.Format("15:04"), b.shiftType)
 		}
 	})
+
+	t.Run("it follows the hours of a configured template", func(t *testing.T) {
+		template := model.ShiftTemplate{FirstShiftStart: 7*60 + 30, SecondShiftStart: 15 * 60, ThirdShiftStart: 23 * 60}
+
+		assert.Equal(t, 3, repo.getShiftTypeForTime(template, time.Date(2023, 1, 1, 7, 29, 0, 0, time.UTC)))
+		assert.Equal(t, 1, repo.getShiftTypeForTime(template, time.Date(2023, 1, 1, 7, 30, 0, 0, time.UTC)))
+		assert.Equal(t, 1, repo.getShiftTypeForTime(template, time.Date(2023, 1, 1, 14, 30, 0, 0, time.UTC)))
+		assert.Equal(t, 2, repo.getShiftTypeForTime(template, time.Date(2023, 1, 1, 22, 30, 0, 0, time.UTC)))
+		assert.Equal(t, 30*time.Minute, repo.getTimeUntilShiftEnd(template, time.Date(2023, 1, 1, 14, 30, 0, 0, time.UTC), 1))
+		assert.Equal(t, 8*time.Hour, repo.getTimeUntilShiftEnd(template, time.Date(2023, 1, 1, 23, 30, 0, 0, time.UTC), 3))
+	})
 }
 
 func TestShiftRepository_getTimeUntilShiftEnd(t *testing.T) {
@@ -316,7 +334,7 @@ func TestShiftRepository_getTimeUntilShiftEnd(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			testTime := time.Date(2023, 1, 1, tt.hour, tt.minute, tt.second, 0, time.UTC)
-			result := repo.getTimeUntilShiftEnd(testTime, tt.shiftType)
+			result := repo.getTimeUntilShiftEnd(model.DefaultShiftTemplate, testTime, tt.shiftType)
 
 			expectedDuration := time.Duration(tt.expectedHours)*time.Hour + time.Duration(tt.expectedMins)*time.Minute
 
@@ -334,7 +352,7 @@ func TestShiftRepository_getTimeUntilShiftEnd(t *testing.T) {
 
 	t.Run("Invalid shift type", func(t *testing.T) {
 		testTime := time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)
-		result := repo.getTimeUntilShiftEnd(testTime, 999) // Invalid shift type
+		result := repo.getTimeUntilShiftEnd(model.DefaultShiftTemplate, testTime, 999) // Invalid shift type
 		assert.Equal(t, time.Duration(0), result, "Invalid shift type should return 0 duration")
 	})
 }
@@ -404,8 +422,8 @@ func TestShiftRepository_shiftBufferLogic(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			testTime := time.Date(2023, 1, 15, tt.currentHour, tt.currentMinute, 0, 0, time.UTC)
-			currentShiftType := repo.getShiftTypeForTime(testTime)
-			timeUntilEnd := repo.getTimeUntilShiftEnd(testTime, currentShiftType)
+			currentShiftType := repo.getShiftTypeForTime(model.DefaultShiftTemplate, testTime)
+			timeUntilEnd := repo.getTimeUntilShiftEnd(model.DefaultShiftTemplate, testTime, currentShiftType)
 
 			shouldInclude := tt.shiftBuffer > 0 && timeUntilEnd <= tt.shiftBuffer
 
@@ -429,6 +447,7 @@ func TestShiftRepositoryMockDB_GetShiftAvailabilityWithEmployeeStatus(t *testing
 		employeeID := uint(1)
 
 		// Mock the query for assigned employees grouped by shift and role
+		mock.ExpectQuery(`SELECT \* FROM "shift_capacities"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
 		mock.ExpectQuery(regexp.QuoteMeta(`SELECT shifts.shift_date, shifts.shift_type, employees.profile_type AS employee_role, COUNT(*) AS count FROM "shifts" JOIN employee_shifts ON shifts.id = employee_shifts.shift_id JOIN employees ON employee_shifts.employee_id = employees.id WHERE shift_date >= $1 AND shift_date < $2 GROUP BY shifts.shift_date, shifts.shift_type, employees.profile_type`)).
 			WithArgs(start, end).
 			WillReturnRows(sqlmock.NewRows([]string{"shift_date", "shift_type", "employee_role", "count"}).
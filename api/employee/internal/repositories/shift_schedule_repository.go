@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pd120424d/mountain-service/api/employee/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// effectiveBetween keeps schedule settings applying to at least one day of the inclusive [from, to] range;
+// a zero bound leaves that side open
+func effectiveBetween(from, to time.Time) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if !from.IsZero() {
+			db = db.Where("effective_to IS NULL OR effective_to >= ?", from)
+		}
+		if !to.IsZero() {
+			db = db.Where("effective_from <= ?", to)
+		}
+		return db
+	}
+}
+
+// ListShiftTemplates returns the templates applying to any day of the inclusive [from, to] range
+func (r *shiftRepository) ListShiftTemplates(ctx context.Context, from, to time.Time) ([]model.ShiftTemplate, error) {
+	var templates []model.ShiftTemplate
+	err := r.withRead(ctx, func(db *gorm.DB) error {
+		return db.Scopes(effectiveBetween(from, to)).Order("effective_from ASC, id ASC").Find(&templates).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shift templates: %w", err)
+	}
+	return templates, nil
+}
+
+// CreateShiftTemplate saves the template unless another one already applies to some of its days,
+// in which case model.ErrScheduleOverlap is returned
+func (r *shiftRepository) CreateShiftTemplate(ctx context.Context, template *model.ShiftTemplate) error {
+	return r.dbWrite.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&model.ShiftTemplate{}).Scopes(effectiveBetween(template.EffectiveFrom, toOrZero(template.EffectiveTo))).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to check shift templates: %w", err)
+		}
+		if count > 0 {
+			return model.ErrScheduleOverlap
+		}
+		if err := tx.Create(template).Error; err != nil {
+			return fmt.Errorf("failed to create shift template: %w", err)
+		}
+		return nil
+	})
+}
+
+// DeleteShiftTemplate removes the template; gorm.ErrRecordNotFound is returned if there is none
+func (r *shiftRepository) DeleteShiftTemplate(ctx context.Context, id uint) error {
+	res := r.dbWrite.WithContext(ctx).Delete(&model.ShiftTemplate{}, id)
+	if res.Error != nil {
+		return fmt.Errorf("failed to delete shift template: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ListShiftCapacities returns the capacities applying to any day of the inclusive [from, to] range
+func (r *shiftRepository) ListShiftCapacities(ctx context.Context, from, to time.Time) ([]model.ShiftCapacity, error) {
+	var capacities []model.ShiftCapacity
+	err := r.withRead(ctx, func(db *gorm.DB) error {
+		return db.Scopes(effectiveBetween(from, to)).Order("profile_type ASC, effective_from ASC, id ASC").Find(&capacities).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shift capacities: %w", err)
+	}
+	return capacities, nil
+}
+
+// CreateShiftCapacity saves the capacity unless another one of the same profile already applies to some
+// of its days, in which case model.ErrScheduleOverlap is returned
+func (r *shiftRepository) CreateShiftCapacity(ctx context.Context, capacity *model.ShiftCapacity) error {
+	return r.dbWrite.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		err := tx.Model(&model.ShiftCapacity{}).
+			Where("profile_type = ?", capacity.ProfileType).
+			Scopes(effectiveBetween(capacity.EffectiveFrom, toOrZero(capacity.EffectiveTo))).
+			Count(&count).Error
+		if err != nil {
+			return fmt.Errorf("failed to check shift capacities: %w", err)
+		}
+		if count > 0 {
+			return model.ErrScheduleOverlap
+		}
+		if err := tx.Create(capacity).Error; err != nil {
+			return fmt.Errorf("failed to create shift capacity: %w", err)
+		}
+		return nil
+	})
+}
+
+// DeleteShiftCapacity removes the capacity; gorm.ErrRecordNotFound is returned if there is none
+func (r *shiftRepository) DeleteShiftCapacity(ctx context.Context, id uint) error {
+	res := r.dbWrite.WithContext(ctx).Delete(&model.ShiftCapacity{}, id)
+	if res.Error != nil {
+		return fmt.Errorf("failed to delete shift capacity: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func toOrZero(to *time.Time) time.Time {
+	if to == nil {
+		return time.Time{}
+	}
+	return *to
+}
@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/pd120424d/mountain-service/api/employee/internal/model"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+func TestShiftRepository_ShiftTemplates(t *testing.T) {
+	ctx := context.Background()
+	winterEnd := leaveDay(14)
+	winter := func() *model.ShiftTemplate {
+		return &model.ShiftTemplate{Name: "winter", FirstShiftStart: 7 * 60, SecondShiftStart: 15 * 60, ThirdShiftStart: 23 * 60,
+			EffectivePeriod: model.EffectivePeriod{EffectiveFrom: leaveDay(10), EffectiveTo: &winterEnd}}
+	}
+
+	t.Run("it lists only the templates applying to the range", func(t *testing.T) {
+		repo := NewShiftRepository(utils.NewTestLogger(), setupSQLiteTestDB(t))
+		require.NoError(t, repo.CreateShiftTemplate(ctx, winter()))
+		require.NoError(t, repo.CreateShiftTemplate(ctx, &model.ShiftTemplate{Name: "spring", FirstShiftStart: 5 * 60, SecondShiftStart: 13 * 60, ThirdShiftStart: 21 * 60,
+			EffectivePeriod: model.EffectivePeriod{EffectiveFrom: leaveDay(15)}}))
+
+		templates, err := repo.ListShiftTemplates(ctx, leaveDay(14), leaveDay(14))
+		require.NoError(t, err)
+		require.Len(t, templates, 1)
+		assert.Equal(t, "winter", templates[0].Name)
+
+		templates, err = repo.ListShiftTemplates(ctx, leaveDay(20), time.Time{})
+		require.NoError(t, err)
+		require.Len(t, templates, 1)
+		assert.Equal(t, "spring", templates[0].Name)
+
+		templates, err = repo.ListShiftTemplates(ctx, time.Time{}, time.Time{})
+		require.NoError(t, err)
+		assert.Len(t, templates, 2)
+	})
+
+	t.Run("it refuses a template overlapping another one", func(t *testing.T) {
+		repo := NewShiftRepository(utils.NewTestLogger(), setupSQLiteTestDB(t))
+		require.NoError(t, repo.CreateShiftTemplate(ctx, winter()))
+
+		err := repo.CreateShiftTemplate(ctx, &model.ShiftTemplate{FirstShiftStart: 60, SecondShiftStart: 120, ThirdShiftStart: 180,
+			EffectivePeriod: model.EffectivePeriod{EffectiveFrom: leaveDay(1)}})
+
+		assert.True(t, errors.Is(err, model.ErrScheduleOverlap), "unexpected error %v", err)
+	})
+
+	t.Run("it deletes a template and reports a missing one", func(t *testing.T) {
+		repo := NewShiftRepository(utils.NewTestLogger(), setupSQLiteTestDB(t))
+		template := winter()
+		require.NoError(t, repo.CreateShiftTemplate(ctx, template))
+
+		require.NoError(t, repo.DeleteShiftTemplate(ctx, template.ID))
+		assert.True(t, errors.Is(repo.DeleteShiftTemplate(ctx, template.ID), gorm.ErrRecordNotFound))
+	})
+
+	t.Run("it finds who is on call by the template of the day", func(t *testing.T) {
+		db := setupSQLiteTestDB(t)
+		repo := NewShiftRepository(utils.NewTestLogger(), db)
+		seedSwapFixture(t, db)
+		now := leaveDay(10).Add(13*time.Hour + 30*time.Minute)
+
+		employees, err := repo.GetOnCallEmployees(ctx, now, 0)
+		require.NoError(t, err)
+		assert.Len(t, employees, 1)
+
+		// with shifts starting an hour earlier, 13:30 already belongs to the second shift
+		require.NoError(t, repo.CreateShiftTemplate(ctx, &model.ShiftTemplate{FirstShiftStart: 5 * 60, SecondShiftStart: 13 * 60, ThirdShiftStart: 21 * 60,
+			EffectivePeriod: model.EffectivePeriod{EffectiveFrom: leaveDay(10), EffectiveTo: &winterEnd}}))
+
+		employees, err = repo.GetOnCallEmployees(ctx, now, 0)
+		require.NoError(t, err)
+		assert.Empty(t, employees)
+	})
+
+	t.Run("it tells the current shift apart from the next one pulled in by the buffer", func(t *testing.T) {
+		db := setupSQLiteTestDB(t)
+		repo := NewShiftRepository(utils.NewTestLogger(), db)
+		seedSwapFixture(t, db)
+		require.NoError(t, db.Create(&model.Shift{ID: 12, ShiftDate: leaveDay(10), ShiftType: 2}).Error)
+		require.NoError(t, db.Create(&model.EmployeeShift{EmployeeID: 2, ShiftID: 12}).Error)
+		now := leaveDay(10).Add(13*time.Hour + 30*time.Minute)
+
+		employees, err := repo.GetOnCallEmployees(ctx, now, time.Hour)
+		require.NoError(t, err)
+		require.Len(t, employees, 2)
+		onCurrentShift := map[uint]bool{}
+		for _, e := range employees {
+			onCurrentShift[e.ID] = e.OnCurrentShift
+		}
+		assert.Equal(t, map[uint]bool{1: true, 2: false}, onCurrentShift)
+	})
+}
+
+func TestShiftRepository_ShiftCapacities(t *testing.T) {
+	ctx := context.Background()
+	end := leaveDay(14)
+
+	t.Run("it keeps capacities of different profiles apart", func(t *testing.T) {
+		repo := NewShiftRepository(utils.NewTestLogger(), setupSQLiteTestDB(t))
+		require.NoError(t, repo.CreateShiftCapacity(ctx, &model.ShiftCapacity{ProfileType: model.Medic, Capacity: 3, EffectivePeriod: model.EffectivePeriod{EffectiveFrom: leaveDay(10), EffectiveTo: &end}}))
+		require.NoError(t, repo.CreateShiftCapacity(ctx, &model.ShiftCapacity{ProfileType: model.Technical, Capacity: 5, EffectivePeriod: model.EffectivePeriod{EffectiveFrom: leaveDay(10)}}))
+
+		err := repo.CreateShiftCapacity(ctx, &model.ShiftCapacity{ProfileType: model.Medic, Capacity: 1, EffectivePeriod: model.EffectivePeriod{EffectiveFrom: leaveDay(14)}})
+		assert.True(t, errors.Is(err, model.ErrScheduleOverlap), "unexpected error %v", err)
+		require.NoError(t, repo.CreateShiftCapacity(ctx, &model.ShiftCapacity{ProfileType: model.Medic, Capacity: 1, EffectivePeriod: model.EffectivePeriod{EffectiveFrom: leaveDay(15)}}))
+
+		capacities, err := repo.ListShiftCapacities(ctx, leaveDay(12), leaveDay(12))
+		require.NoError(t, err)
+		assert.Len(t, capacities, 2)
+	})
+
+	t.Run("it reports availability against the capacity of the day", func(t *testing.T) {
+		db := setupSQLiteTestDB(t)
+		repo := NewShiftRepository(utils.NewTestLogger(), db)
+		seedSwapFixture(t, db)
+		firstDay := leaveDay(10)
+		require.NoError(t, repo.CreateShiftCapacity(ctx, &model.ShiftCapacity{ProfileType: model.Medic, Capacity: 3, EffectivePeriod: model.EffectivePeriod{EffectiveFrom: firstDay, EffectiveTo: &firstDay}}))
+
+		availability, err := repo.GetShiftAvailability(ctx, leaveDay(10), leaveDay(12))
+		require.NoError(t, err)
+		// one medic already works the first shift of the 10th, which takes three that day
+		assert.Equal(t, 2, availability.Days[leaveDay(10)][0][model.Medic])
+		assert.Equal(t, 3, availability.Days[leaveDay(10)][1][model.Medic])
+		assert.Equal(t, 2, availability.Days[leaveDay(11)][0][model.Medic])
+		assert.Equal(t, 4, availability.Days[leaveDay(11)][0][model.Technical])
+	})
+
+	t.Run("it deletes a capacity and reports a missing one", func(t *testing.T) {
+		repo := NewShiftRepository(utils.NewTestLogger(), setupSQLiteTestDB(t))
+		capacity := &model.ShiftCapacity{ProfileType: model.Medic, Capacity: 3, EffectivePeriod: model.EffectivePeriod{EffectiveFrom: leaveDay(10)}}
+		require.NoError(t, repo.CreateShiftCapacity(ctx, capacity))
+
+		require.NoError(t, repo.DeleteShiftCapacity(ctx, capacity.ID))
+		assert.True(t, errors.Is(repo.DeleteShiftCapacity(ctx, capacity.ID), gorm.ErrRecordNotFound))
+	})
+}
@@ -0,0 +1,132 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+	"github.com/pd120424d/mountain-service/api/employee/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// ShiftSwapFilter narrows ListShiftSwaps; zero values match everything
+type ShiftSwapFilter struct {
+	Status      employeeV1.ShiftSwapStatus
+	ProfileType model.ProfileType
+}
+
+func (r *shiftRepository) FindShift(ctx context.Context, shiftDate time.Time, shiftType int) (*model.Shift, error) {
+	var shift model.Shift
+	if err := r.getReadDB(ctx).Where("shift_date = ? AND shift_type = ?", shiftDate, shiftType).First(&shift).Error; err != nil {
+		return nil, fmt.Errorf("failed to find shift: %w", err)
+	}
+	return &shift, nil
+}
+
+func (r *shiftRepository) CreateShiftSwap(ctx context.Context, swap *model.ShiftSwap) error {
+	if err := r.dbWrite.WithContext(ctx).Omit("Shift", "ResponderShift").Create(swap).Error; err != nil {
+		return fmt.Errorf("failed to create shift swap: %w", err)
+	}
+	return nil
+}
+
+func (r *shiftRepository) GetShiftSwap(ctx context.Context, id uint) (*model.ShiftSwap, error) {
+	var swap model.ShiftSwap
+	err := r.dbWrite.WithContext(ctx).Preload("Shift").Preload("ResponderShift").First(&swap, id).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shift swap: %w", err)
+	}
+	return &swap, nil
+}
+
+func (r *shiftRepository) ListShiftSwaps(ctx context.Context, filter ShiftSwapFilter) ([]model.ShiftSwap, error) {
+	var swaps []model.ShiftSwap
+	err := r.withRead(ctx, func(db *gorm.DB) error {
+		q := db.Preload("Shift").Preload("ResponderShift")
+		if filter.Status != "" {
+			q = q.Where("status = ?", filter.Status)
+		}
+		if filter.ProfileType != "" {
+			q = q.Where("profile_type = ?", filter.ProfileType)
+		}
+		return q.Order("created_at DESC, id DESC").Find(&swaps).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shift swaps: %w", err)
+	}
+	return swaps, nil
+}
+
+func (r *shiftRepository) HasActiveShiftSwap(ctx context.Context, shiftID, requesterID uint) (bool, error) {
+	var count int64
+	err := r.dbWrite.WithContext(ctx).Model(&model.ShiftSwap{}).
+		Where("shift_id = ? AND requester_id = ? AND status IN ?", shiftID, requesterID,
+			[]employeeV1.ShiftSwapStatus{employeeV1.ShiftSwapOpen, employeeV1.ShiftSwapProposed, employeeV1.ShiftSwapPendingApproval}).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check shift swaps: %w", err)
+	}
+	return count > 0, nil
+}
+
+// UpdateShiftSwap saves the swap's state only if it is still in the from state, so two colleagues
+// answering the same offer cannot both win; the loser gets model.ErrSwapChanged
+func (r *shiftRepository) UpdateShiftSwap(ctx context.Context, swap *model.ShiftSwap, from employeeV1.ShiftSwapStatus) error {
+	return updateShiftSwap(r.dbWrite.WithContext(ctx), swap, from)
+}
+
+// CompleteShiftSwap hands the offered shift to the responder and, for an exchange, the responder's shift to the
+// requester, then marks the swap completed. Everything happens in one transaction, so nobody ends up with both
+// shifts or neither.
+func (r *shiftRepository) CompleteShiftSwap(ctx context.Context, swap *model.ShiftSwap, from employeeV1.ShiftSwapStatus) error {
+	if swap.ResponderID == nil {
+		return fmt.Errorf("shift swap %d has no responder", swap.ID)
+	}
+	return r.dbWrite.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := transferAssignment(tx, swap.ShiftID, swap.RequesterID, *swap.ResponderID); err != nil {
+			return err
+		}
+		if swap.ResponderShiftID != nil {
+			if err := transferAssignment(tx, *swap.ResponderShiftID, *swap.ResponderID, swap.RequesterID); err != nil {
+				return err
+			}
+		}
+		swap.Status = employeeV1.ShiftSwapCompleted
+		return updateShiftSwap(tx, swap, from)
+	})
+}
+
+func updateShiftSwap(db *gorm.DB, swap *model.ShiftSwap, from employeeV1.ShiftSwapStatus) error {
+	swap.UpdatedAt = time.Now().UTC()
+	res := db.Model(&model.ShiftSwap{}).
+		Where("id = ? AND status = ?", swap.ID, from).
+		Updates(map[string]interface{}{
+			"status":             swap.Status,
+			"responder_id":       swap.ResponderID,
+			"responder_shift_id": swap.ResponderShiftID,
+			"reviewed_by":        swap.ReviewedBy,
+			"updated_at":         swap.UpdatedAt,
+		})
+	if res.Error != nil {
+		return fmt.Errorf("failed to update shift swap: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return model.ErrSwapChanged
+	}
+	return nil
+}
+
+func transferAssignment(tx *gorm.DB, shiftID, fromEmployeeID, toEmployeeID uint) error {
+	res := tx.Model(&model.EmployeeShift{}).
+		Where("employee_id = ? AND shift_id = ?", fromEmployeeID, shiftID).
+		Updates(map[string]interface{}{"employee_id": toEmployeeID, "created_at": time.Now().UTC()})
+	if res.Error != nil {
+		return fmt.Errorf("failed to hand over shift %d: %w", shiftID, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return model.ErrSwapChanged
+	}
+	return nil
+}
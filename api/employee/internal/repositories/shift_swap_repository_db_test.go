@@ -0,0 +1,138 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+	"github.com/pd120424d/mountain-service/api/employee/internal/model"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+func seedSwapFixture(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	require.NoError(t, db.Create(&model.Employee{ID: 1, Username: "medic-1", FirstName: "Ana", LastName: "A", Email: "a@example.com", ProfileType: model.Medic}).Error)
+	require.NoError(t, db.Create(&model.Employee{ID: 2, Username: "medic-2", FirstName: "Bora", LastName: "B", Email: "b@example.com", ProfileType: model.Medic}).Error)
+	require.NoError(t, db.Create(&model.Shift{ID: 10, ShiftDate: time.Date(2030, 1, 10, 0, 0, 0, 0, time.UTC), ShiftType: 1}).Error)
+	require.NoError(t, db.Create(&model.Shift{ID: 11, ShiftDate: time.Date(2030, 1, 12, 0, 0, 0, 0, time.UTC), ShiftType: 2}).Error)
+	require.NoError(t, db.Create(&model.EmployeeShift{EmployeeID: 1, ShiftID: 10}).Error)
+	require.NoError(t, db.Create(&model.EmployeeShift{EmployeeID: 2, ShiftID: 11}).Error)
+}
+
+func assignedEmployee(t *testing.T, db *gorm.DB, shiftID uint) []uint {
+	t.Helper()
+	var ids []uint
+	require.NoError(t, db.Model(&model.EmployeeShift{}).Where("shift_id = ?", shiftID).Pluck("employee_id", &ids).Error)
+	return ids
+}
+
+func TestShiftRepository_ShiftSwaps(t *testing.T) {
+	log := utils.NewTestLogger()
+	ctx := context.Background()
+	responder := uint(2)
+
+	t.Run("it creates, loads and lists swaps with their shifts", func(t *testing.T) {
+		db := setupSQLiteTestDB(t)
+		seedSwapFixture(t, db)
+		repo := NewShiftRepository(log, db)
+
+		swap := &model.ShiftSwap{ShiftID: 10, RequesterID: 1, ProfileType: model.Medic, Status: employeeV1.ShiftSwapOpen, Note: "family event"}
+		require.NoError(t, repo.CreateShiftSwap(ctx, swap))
+
+		loaded, err := repo.GetShiftSwap(ctx, swap.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, loaded.Shift.ShiftType)
+		assert.Nil(t, loaded.ResponderShift)
+
+		swaps, err := repo.ListShiftSwaps(ctx, ShiftSwapFilter{ProfileType: model.Medic, Status: employeeV1.ShiftSwapOpen})
+		require.NoError(t, err)
+		assert.Len(t, swaps, 1)
+
+		swaps, err = repo.ListShiftSwaps(ctx, ShiftSwapFilter{ProfileType: model.Technical})
+		require.NoError(t, err)
+		assert.Empty(t, swaps)
+
+		active, err := repo.HasActiveShiftSwap(ctx, 10, 1)
+		require.NoError(t, err)
+		assert.True(t, active)
+	})
+
+	t.Run("it returns record not found for a missing swap", func(t *testing.T) {
+		repo := NewShiftRepository(log, setupSQLiteTestDB(t))
+
+		_, err := repo.GetShiftSwap(ctx, 99)
+
+		assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+	})
+
+	t.Run("it refuses an update when the swap left the expected state", func(t *testing.T) {
+		db := setupSQLiteTestDB(t)
+		seedSwapFixture(t, db)
+		repo := NewShiftRepository(log, db)
+		swap := &model.ShiftSwap{ShiftID: 10, RequesterID: 1, ProfileType: model.Medic, Status: employeeV1.ShiftSwapCancelled}
+		require.NoError(t, repo.CreateShiftSwap(ctx, swap))
+
+		swap.Status = employeeV1.ShiftSwapProposed
+		err := repo.UpdateShiftSwap(ctx, swap, employeeV1.ShiftSwapOpen)
+
+		assert.ErrorIs(t, err, model.ErrSwapChanged)
+	})
+
+	t.Run("it hands a covered shift to the responder", func(t *testing.T) {
+		db := setupSQLiteTestDB(t)
+		seedSwapFixture(t, db)
+		repo := NewShiftRepository(log, db)
+		swap := &model.ShiftSwap{ShiftID: 10, RequesterID: 1, ProfileType: model.Medic, Status: employeeV1.ShiftSwapOpen}
+		require.NoError(t, repo.CreateShiftSwap(ctx, swap))
+
+		swap.ResponderID = &responder
+		require.NoError(t, repo.CompleteShiftSwap(ctx, swap, employeeV1.ShiftSwapOpen))
+
+		assert.Equal(t, []uint{2}, assignedEmployee(t, db, 10))
+		assert.Equal(t, []uint{2}, assignedEmployee(t, db, 11))
+		loaded, err := repo.GetShiftSwap(ctx, swap.ID)
+		require.NoError(t, err)
+		assert.Equal(t, employeeV1.ShiftSwapCompleted, loaded.Status)
+		active, err := repo.HasActiveShiftSwap(ctx, 10, 1)
+		require.NoError(t, err)
+		assert.False(t, active)
+	})
+
+	t.Run("it exchanges both shifts", func(t *testing.T) {
+		db := setupSQLiteTestDB(t)
+		seedSwapFixture(t, db)
+		repo := NewShiftRepository(log, db)
+		responderShift := uint(11)
+		swap := &model.ShiftSwap{ShiftID: 10, RequesterID: 1, ProfileType: model.Medic, Status: employeeV1.ShiftSwapProposed, ResponderID: &responder, ResponderShiftID: &responderShift}
+		require.NoError(t, repo.CreateShiftSwap(ctx, swap))
+
+		require.NoError(t, repo.CompleteShiftSwap(ctx, swap, employeeV1.ShiftSwapProposed))
+
+		assert.Equal(t, []uint{2}, assignedEmployee(t, db, 10))
+		assert.Equal(t, []uint{1}, assignedEmployee(t, db, 11))
+	})
+
+	t.Run("it rolls the handover back when the responder's shift is no longer theirs", func(t *testing.T) {
+		db := setupSQLiteTestDB(t)
+		seedSwapFixture(t, db)
+		repo := NewShiftRepository(log, db)
+		responderShift := uint(11)
+		swap := &model.ShiftSwap{ShiftID: 10, RequesterID: 1, ProfileType: model.Medic, Status: employeeV1.ShiftSwapProposed, ResponderID: &responder, ResponderShiftID: &responderShift}
+		require.NoError(t, repo.CreateShiftSwap(ctx, swap))
+		require.NoError(t, db.Where("employee_id = ? AND shift_id = ?", 2, 11).Delete(&model.EmployeeShift{}).Error)
+
+		err := repo.CompleteShiftSwap(ctx, swap, employeeV1.ShiftSwapProposed)
+
+		assert.ErrorIs(t, err, model.ErrSwapChanged)
+		assert.Equal(t, []uint{1}, assignedEmployee(t, db, 10))
+		loaded, err := repo.GetShiftSwap(ctx, swap.ID)
+		require.NoError(t, err)
+		assert.Equal(t, employeeV1.ShiftSwapProposed, loaded.Status)
+	})
+}
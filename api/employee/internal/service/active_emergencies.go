@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
+	s2surgency "github.com/pd120424d/mountain-service/api/shared/s2s/urgency"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+const (
+	ErrorActiveEmergency            = "EMPLOYEE_ERRORS.ACTIVE_EMERGENCY"
+	ErrorActiveEmergencyCheckFailed = "EMPLOYEE_ERRORS.ACTIVE_EMERGENCY_CHECK_FAILED"
+)
+
+// ensureNotResponding refuses an action on an employee who is responding to an in-progress urgency.
+// Forced actions skip the check, and so does a service built without an urgency client. When the urgency
+// service cannot be reached the action is refused as well, since it is not known to be safe.
+func ensureNotResponding(ctx context.Context, log utils.Logger, urgencyClient s2surgency.Client, employeeID uint, force bool, action string) error {
+	if force {
+		log.Warnf("Forcing %s for employee %d without checking active emergencies", action, employeeID)
+		return nil
+	}
+	if urgencyClient == nil {
+		return nil
+	}
+	active, err := urgencyClient.GetEmployeeActiveUrgencies(ctx, employeeID)
+	if err != nil {
+		log.Errorf("failed to check active emergencies for employee %d: %v", employeeID, err)
+		return commonv1.NewAppError(ErrorActiveEmergencyCheckFailed, "failed to check active emergencies", map[string]interface{}{"cause": err.Error()})
+	}
+	if active.HasActive {
+		log.Warnf("Refusing %s for employee %d responding to urgencies %v", action, employeeID, active.UrgencyIDs)
+		return commonv1.NewAppError(ErrorActiveEmergency, "employee is responding to an active emergency", map[string]interface{}{"urgencyIds": active.UrgencyIDs})
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -10,20 +11,26 @@ import (
 	"github.com/pd120424d/mountain-service/api/employee/internal/model"
 	"github.com/pd120424d/mountain-service/api/employee/internal/repositories"
 	sharedAuth "github.com/pd120424d/mountain-service/api/shared/auth"
+	s2surgency "github.com/pd120424d/mountain-service/api/shared/s2s/urgency"
 	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"gorm.io/gorm"
 )
 
 type employeeService struct {
-	log       utils.Logger
-	emplRepo  repositories.EmployeeRepository
-	blacklist sharedAuth.TokenBlacklist
+	log           utils.Logger
+	emplRepo      repositories.EmployeeRepository
+	blacklist     sharedAuth.TokenBlacklist
+	urgencyClient s2surgency.Client
 }
 
-func NewEmployeeService(log utils.Logger, emplRepo repositories.EmployeeRepository, blacklist sharedAuth.TokenBlacklist) EmployeeService {
+// NewEmployeeService creates the employee service. urgencyClient may be nil, which skips the active
+// emergency check when deleting employees.
+func NewEmployeeService(log utils.Logger, emplRepo repositories.EmployeeRepository, blacklist sharedAuth.TokenBlacklist, urgencyClient s2surgency.Client) EmployeeService {
 	return &employeeService{
-		log:       log.WithName("employeeService"),
-		emplRepo:  emplRepo,
-		blacklist: blacklist,
+		log:           log.WithName("employeeService"),
+		emplRepo:      emplRepo,
+		blacklist:     blacklist,
+		urgencyClient: urgencyClient,
 	}
 }
 
@@ -47,7 +54,8 @@ func (s *employeeService) RegisterEmployee(ctx context.Context, req employeeV1.E
 		Phone:     req.Phone,
 		Email:     req.Email,
 
-		ProfileType: profileType,
+		ProfileType:       profileType,
+		PreferredLanguage: req.PreferredLanguage,
 	}
 
 	// Check for existing username
@@ -212,11 +220,30 @@ func (s *employeeService) UpdateEmployee(ctx context.Context, employeeID uint, r
 	return &response, nil
 }
 
-func (s *employeeService) DeleteEmployee(ctx context.Context, employeeID uint) error {
+func (s *employeeService) UpdatePosition(ctx context.Context, employeeID uint, req employeeV1.EmployeePositionRequest) error {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "EmployeeService.UpdatePosition")()
+
+	if err := s.emplRepo.UpdatePosition(ctx, employeeID, *req.Latitude, *req.Longitude, time.Now().UTC()); err != nil {
+		log.Errorf("failed to update position for employee %d: %v", employeeID, err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("employee not found")
+		}
+		return fmt.Errorf("failed to update employee position")
+	}
+	return nil
+}
+
+// DeleteEmployee deletes an employee unless they are responding to an in-progress urgency; force skips that check.
+func (s *employeeService) DeleteEmployee(ctx context.Context, employeeID uint, force bool) error {
 	log := s.log.WithContext(ctx)
 	defer utils.TimeOperation(log, "EmployeeService.DeleteEmployee")()
 	log.Infof("Deleting employee with ID %d", employeeID)
 
+	if err := ensureNotResponding(ctx, log, s.urgencyClient, employeeID, force, "delete"); err != nil {
+		return err
+	}
+
 	if err := s.emplRepo.Delete(ctx, employeeID); err != nil {
 		log.Errorf("failed to delete employee: %v", err)
 		return fmt.Errorf("failed to delete employee")
@@ -226,6 +253,22 @@ func (s *employeeService) DeleteEmployee(ctx context.Context, employeeID uint) e
 	return nil
 }
 
+// CheckActiveEmergencies asks the urgency service which in-progress urgencies the employee is responding to.
+func (s *employeeService) CheckActiveEmergencies(ctx context.Context, employeeID uint) (*employeeV1.ActiveEmergenciesResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "EmployeeService.CheckActiveEmergencies")()
+
+	if s.urgencyClient == nil {
+		return nil, fmt.Errorf("urgency service client is not configured")
+	}
+	active, err := s.urgencyClient.GetEmployeeActiveUrgencies(ctx, employeeID)
+	if err != nil {
+		log.Errorf("failed to check active emergencies for employee %d: %v", employeeID, err)
+		return nil, fmt.Errorf("failed to check active emergencies")
+	}
+	return &employeeV1.ActiveEmergenciesResponse{HasActiveEmergencies: active.HasActive, UrgencyIDs: active.UrgencyIDs}, nil
+}
+
 func (s *employeeService) GetEmployeeByID(ctx context.Context, employeeID uint) (*model.Employee, error) {
 	log := s.log.WithContext(ctx)
 	defer utils.TimeOperation(log, "EmployeeService.GetEmployeeByID")()
@@ -9,11 +9,15 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
 	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
 	"github.com/pd120424d/mountain-service/api/employee/internal/model"
 	"github.com/pd120424d/mountain-service/api/employee/internal/repositories"
 	sharedAuth "github.com/pd120424d/mountain-service/api/shared/auth"
+	s2surgency "github.com/pd120424d/mountain-service/api/shared/s2s/urgency"
 	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"gorm.io/gorm"
 )
 
 func TestEmployeeService_RegisterEmployee(t *testing.T) {
@@ -26,7 +30,7 @@ func TestEmployeeService_RegisterEmployee(t *testing.T) {
 		log := utils.NewTestLogger()
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 
-		service := NewEmployeeService(log, emplRepoMock, nil)
+		service := NewEmployeeService(log, emplRepoMock, nil, nil)
 
 		req := employeeV1.EmployeeCreateRequest{
 			Username:    "testuser",
@@ -51,7 +55,7 @@ func TestEmployeeService_RegisterEmployee(t *testing.T) {
 		log := utils.NewTestLogger()
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 
-		service := NewEmployeeService(log, emplRepoMock, nil)
+		service := NewEmployeeService(log, emplRepoMock, nil, nil)
 
 		req := employeeV1.EmployeeCreateRequest{
 			Username:    "testuser",
@@ -82,7 +86,7 @@ func TestEmployeeService_RegisterEmployee(t *testing.T) {
 		log := utils.NewTestLogger()
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 
-		service := NewEmployeeService(log, emplRepoMock, nil)
+		service := NewEmployeeService(log, emplRepoMock, nil, nil)
 
 		req := employeeV1.EmployeeCreateRequest{
 			Username:    "testuser",
@@ -116,7 +120,7 @@ func TestEmployeeService_RegisterEmployee(t *testing.T) {
 		log := utils.NewTestLogger()
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 
-		service := NewEmployeeService(log, emplRepoMock, nil)
+		service := NewEmployeeService(log, emplRepoMock, nil, nil)
 
 		req := employeeV1.EmployeeCreateRequest{
 			Username:    "testuser",
@@ -146,7 +150,7 @@ func TestEmployeeService_RegisterEmployee(t *testing.T) {
 		log := utils.NewTestLogger()
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 
-		service := NewEmployeeService(log, emplRepoMock, nil)
+		service := NewEmployeeService(log, emplRepoMock, nil, nil)
 
 		req := employeeV1.EmployeeCreateRequest{
 			Username:    "testuser",
@@ -177,7 +181,7 @@ func TestEmployeeService_RegisterEmployee(t *testing.T) {
 		log := utils.NewTestLogger()
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 
-		service := NewEmployeeService(log, emplRepoMock, nil)
+		service := NewEmployeeService(log, emplRepoMock, nil, nil)
 
 		req := employeeV1.EmployeeCreateRequest{
 			Username:    "testuser",
@@ -227,7 +231,7 @@ func TestEmployeeService_LoginEmployee(t *testing.T) {
 		log := utils.NewTestLogger()
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 
-		service := NewEmployeeService(log, emplRepoMock, nil)
+		service := NewEmployeeService(log, emplRepoMock, nil, nil)
 
 		req := employeeV1.EmployeeLogin{
 			Username: "nonexistent",
@@ -250,7 +254,7 @@ func TestEmployeeService_LoginEmployee(t *testing.T) {
 		log := utils.NewTestLogger()
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 
-		service := NewEmployeeService(log, emplRepoMock, nil)
+		service := NewEmployeeService(log, emplRepoMock, nil, nil)
 
 		req := employeeV1.EmployeeLogin{
 			Username: "testuser",
@@ -280,7 +284,7 @@ func TestEmployeeService_LoginEmployee(t *testing.T) {
 		log := utils.NewTestLogger()
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 
-		service := NewEmployeeService(log, emplRepoMock, nil)
+		service := NewEmployeeService(log, emplRepoMock, nil, nil)
 
 		req := employeeV1.EmployeeLogin{
 			Username: "testuser",
@@ -303,7 +307,7 @@ func TestEmployeeService_LoginEmployee(t *testing.T) {
 		log := utils.NewTestLogger()
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 
-		service := NewEmployeeService(log, emplRepoMock, nil)
+		service := NewEmployeeService(log, emplRepoMock, nil, nil)
 
 		req := employeeV1.EmployeeLogin{
 			Username: "testuser",
@@ -341,7 +345,7 @@ func TestEmployeeService_LogoutEmployee(t *testing.T) {
 		expiresAt := time.Now().Add(time.Hour)
 		mockBlacklist := sharedAuth.NewMockTokenBlacklist(ctrl)
 		mockBlacklist.EXPECT().BlacklistToken(gomock.Any(), tokenID, expiresAt).Return(nil)
-		service := NewEmployeeService(log, emplRepoMock, mockBlacklist)
+		service := NewEmployeeService(log, emplRepoMock, mockBlacklist, nil)
 
 		err := service.LogoutEmployee(context.Background(), tokenID, expiresAt)
 
@@ -355,7 +359,7 @@ func TestEmployeeService_LogoutEmployee(t *testing.T) {
 		log := utils.NewTestLogger()
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 
-		service := NewEmployeeService(log, emplRepoMock, nil)
+		service := NewEmployeeService(log, emplRepoMock, nil, nil)
 		// Don't set blacklist
 
 		tokenID := "test-token-123"
@@ -373,12 +377,12 @@ func TestEmployeeService_LogoutEmployee(t *testing.T) {
 		log := utils.NewTestLogger()
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 
-		service := NewEmployeeService(log, emplRepoMock, nil)
+		service := NewEmployeeService(log, emplRepoMock, nil, nil)
 
 		// Create a mock blacklist that returns error using gomock
 		mockBlacklist := sharedAuth.NewMockTokenBlacklist(ctrl)
 		mockBlacklist.EXPECT().BlacklistToken(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("mock blacklist error"))
-		service = NewEmployeeService(log, emplRepoMock, mockBlacklist)
+		service = NewEmployeeService(log, emplRepoMock, mockBlacklist, nil)
 
 		tokenID := "test-token-123"
 		expiresAt := time.Now().Add(time.Hour)
@@ -400,7 +404,7 @@ func TestEmployeeService_ListEmployees(t *testing.T) {
 		log := utils.NewTestLogger()
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 
-		service := NewEmployeeService(log, emplRepoMock, nil)
+		service := NewEmployeeService(log, emplRepoMock, nil, nil)
 
 		emplRepoMock.EXPECT().GetAll(gomock.Any()).Return(nil, assert.AnError)
 
@@ -418,7 +422,7 @@ func TestEmployeeService_ListEmployees(t *testing.T) {
 		log := utils.NewTestLogger()
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 
-		service := NewEmployeeService(log, emplRepoMock, nil)
+		service := NewEmployeeService(log, emplRepoMock, nil, nil)
 
 		employees := []model.Employee{
 			{
@@ -455,6 +459,40 @@ func TestEmployeeService_ListEmployees(t *testing.T) {
 	})
 }
 
+func TestEmployeeService_UpdatePosition(t *testing.T) {
+	t.Parallel()
+
+	lat, lng := 43.4, 22.66
+	req := employeeV1.EmployeePositionRequest{Latitude: &lat, Longitude: &lng}
+
+	t.Run("it stores the reported position", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
+		emplRepoMock.EXPECT().UpdatePosition(gomock.Any(), uint(1), lat, lng, gomock.Any()).Return(nil)
+
+		err := NewEmployeeService(utils.NewTestLogger(), emplRepoMock, nil, nil).UpdatePosition(context.Background(), 1, req)
+		assert.NoError(t, err)
+	})
+
+	t.Run("it returns employee not found when the employee does not exist", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
+		emplRepoMock.EXPECT().UpdatePosition(gomock.Any(), uint(1), lat, lng, gomock.Any()).Return(gorm.ErrRecordNotFound)
+
+		err := NewEmployeeService(utils.NewTestLogger(), emplRepoMock, nil, nil).UpdatePosition(context.Background(), 1, req)
+		assert.EqualError(t, err, "employee not found")
+	})
+
+	t.Run("it returns a generic error when the update fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
+		emplRepoMock.EXPECT().UpdatePosition(gomock.Any(), uint(1), lat, lng, gomock.Any()).Return(assert.AnError)
+
+		err := NewEmployeeService(utils.NewTestLogger(), emplRepoMock, nil, nil).UpdatePosition(context.Background(), 1, req)
+		assert.EqualError(t, err, "failed to update employee position")
+	})
+}
+
 func TestEmployeeService_UpdateEmployee(t *testing.T) {
 	t.Parallel()
 
@@ -465,7 +503,7 @@ func TestEmployeeService_UpdateEmployee(t *testing.T) {
 		log := utils.NewTestLogger()
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 
-		service := NewEmployeeService(log, emplRepoMock, nil)
+		service := NewEmployeeService(log, emplRepoMock, nil, nil)
 
 		req := employeeV1.EmployeeUpdateRequest{
 			FirstName: "Updated",
@@ -489,7 +527,7 @@ func TestEmployeeService_UpdateEmployee(t *testing.T) {
 		log := utils.NewTestLogger()
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 
-		service := NewEmployeeService(log, emplRepoMock, nil)
+		service := NewEmployeeService(log, emplRepoMock, nil, nil)
 
 		req := employeeV1.EmployeeUpdateRequest{
 			FirstName: "Updated",
@@ -522,7 +560,7 @@ func TestEmployeeService_UpdateEmployee(t *testing.T) {
 		log := utils.NewTestLogger()
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 
-		service := NewEmployeeService(log, emplRepoMock, nil)
+		service := NewEmployeeService(log, emplRepoMock, nil, nil)
 
 		req := employeeV1.EmployeeUpdateRequest{
 			FirstName: "Updated",
@@ -562,11 +600,11 @@ func TestEmployeeService_DeleteEmployee(t *testing.T) {
 		log := utils.NewTestLogger()
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 
-		service := NewEmployeeService(log, emplRepoMock, nil)
+		service := NewEmployeeService(log, emplRepoMock, nil, nil)
 
 		emplRepoMock.EXPECT().Delete(gomock.Any(), uint(1)).Return(assert.AnError)
 
-		err := service.DeleteEmployee(context.Background(), 1)
+		err := service.DeleteEmployee(context.Background(), 1, false)
 
 		assert.Error(t, err)
 		assert.Equal(t, "failed to delete employee", err.Error())
@@ -579,13 +617,135 @@ func TestEmployeeService_DeleteEmployee(t *testing.T) {
 		log := utils.NewTestLogger()
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 
-		service := NewEmployeeService(log, emplRepoMock, nil)
+		service := NewEmployeeService(log, emplRepoMock, nil, nil)
 
 		emplRepoMock.EXPECT().Delete(gomock.Any(), uint(1)).Return(nil)
 
-		err := service.DeleteEmployee(context.Background(), 1)
+		err := service.DeleteEmployee(context.Background(), 1, false)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("it refuses to delete an employee responding to an emergency", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		log := utils.NewTestLogger()
+		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
+		urgencyClientMock := s2surgency.NewMockClient(ctrl)
+
+		service := NewEmployeeService(log, emplRepoMock, nil, urgencyClientMock)
+
+		urgencyClientMock.EXPECT().GetEmployeeActiveUrgencies(gomock.Any(), uint(1)).
+			Return(&urgencyV1.EmployeeActiveUrgenciesResponse{EmployeeID: 1, HasActive: true, UrgencyIDs: []uint{4}}, nil)
+
+		err := service.DeleteEmployee(context.Background(), 1, false)
+
+		var appErr *commonv1.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, ErrorActiveEmergency, appErr.Code)
+		assert.Equal(t, []uint{4}, appErr.Details["urgencyIds"])
+	})
+
+	t.Run("it refuses to delete when active emergencies cannot be checked", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		log := utils.NewTestLogger()
+		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
+		urgencyClientMock := s2surgency.NewMockClient(ctrl)
+
+		service := NewEmployeeService(log, emplRepoMock, nil, urgencyClientMock)
+
+		urgencyClientMock.EXPECT().GetEmployeeActiveUrgencies(gomock.Any(), uint(1)).Return(nil, assert.AnError)
+
+		err := service.DeleteEmployee(context.Background(), 1, false)
+
+		var appErr *commonv1.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, ErrorActiveEmergencyCheckFailed, appErr.Code)
+	})
+
+	t.Run("it deletes a responding employee when forced", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		log := utils.NewTestLogger()
+		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
+		urgencyClientMock := s2surgency.NewMockClient(ctrl)
+
+		service := NewEmployeeService(log, emplRepoMock, nil, urgencyClientMock)
+
+		emplRepoMock.EXPECT().Delete(gomock.Any(), uint(1)).Return(nil)
+
+		err := service.DeleteEmployee(context.Background(), 1, true)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("it deletes an employee who is not responding", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		log := utils.NewTestLogger()
+		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
+		urgencyClientMock := s2surgency.NewMockClient(ctrl)
+
+		service := NewEmployeeService(log, emplRepoMock, nil, urgencyClientMock)
+
+		urgencyClientMock.EXPECT().GetEmployeeActiveUrgencies(gomock.Any(), uint(1)).
+			Return(&urgencyV1.EmployeeActiveUrgenciesResponse{EmployeeID: 1, UrgencyIDs: []uint{}}, nil)
+		emplRepoMock.EXPECT().Delete(gomock.Any(), uint(1)).Return(nil)
+
+		err := service.DeleteEmployee(context.Background(), 1, false)
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestEmployeeService_CheckActiveEmergencies(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it fails without an urgency client", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service := NewEmployeeService(utils.NewTestLogger(), repositories.NewMockEmployeeRepository(ctrl), nil, nil)
+
+		_, err := service.CheckActiveEmergencies(context.Background(), 1)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("it fails when the urgency service fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		urgencyClientMock := s2surgency.NewMockClient(ctrl)
+		service := NewEmployeeService(utils.NewTestLogger(), repositories.NewMockEmployeeRepository(ctrl), nil, urgencyClientMock)
+
+		urgencyClientMock.EXPECT().GetEmployeeActiveUrgencies(gomock.Any(), uint(1)).Return(nil, assert.AnError)
+
+		_, err := service.CheckActiveEmergencies(context.Background(), 1)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("it returns the urgencies the employee is responding to", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		urgencyClientMock := s2surgency.NewMockClient(ctrl)
+		service := NewEmployeeService(utils.NewTestLogger(), repositories.NewMockEmployeeRepository(ctrl), nil, urgencyClientMock)
+
+		urgencyClientMock.EXPECT().GetEmployeeActiveUrgencies(gomock.Any(), uint(1)).
+			Return(&urgencyV1.EmployeeActiveUrgenciesResponse{EmployeeID: 1, HasActive: true, UrgencyIDs: []uint{4, 5}}, nil)
+
+		resp, err := service.CheckActiveEmergencies(context.Background(), 1)
 
 		assert.NoError(t, err)
+		assert.True(t, resp.HasActiveEmergencies)
+		assert.Equal(t, []uint{4, 5}, resp.UrgencyIDs)
 	})
 }
 
@@ -599,7 +759,7 @@ func TestEmployeeService_GetEmployeeByID(t *testing.T) {
 		log := utils.NewTestLogger()
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 
-		service := NewEmployeeService(log, emplRepoMock, nil)
+		service := NewEmployeeService(log, emplRepoMock, nil, nil)
 
 		emplRepoMock.EXPECT().GetEmployeeByID(gomock.Any(), uint(1), gomock.Any()).Return(assert.AnError)
 
@@ -617,7 +777,7 @@ func TestEmployeeService_GetEmployeeByID(t *testing.T) {
 		log := utils.NewTestLogger()
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 
-		service := NewEmployeeService(log, emplRepoMock, nil)
+		service := NewEmployeeService(log, emplRepoMock, nil, nil)
 
 		expectedEmployee := model.Employee{
 			ID:        1,
@@ -650,7 +810,7 @@ func TestEmployeeService_GetEmployeeByUsername(t *testing.T) {
 		log := utils.NewTestLogger()
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 
-		service := NewEmployeeService(log, emplRepoMock, nil)
+		service := NewEmployeeService(log, emplRepoMock, nil, nil)
 
 		emplRepoMock.EXPECT().GetEmployeeByUsername(gomock.Any(), "nonexistent").Return(nil, assert.AnError)
 
@@ -668,7 +828,7 @@ func TestEmployeeService_GetEmployeeByUsername(t *testing.T) {
 		log := utils.NewTestLogger()
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 
-		service := NewEmployeeService(log, emplRepoMock, nil)
+		service := NewEmployeeService(log, emplRepoMock, nil, nil)
 
 		expectedEmployee := &model.Employee{
 			ID:        1,
@@ -698,7 +858,7 @@ func TestEmployeeService_ResetAllData(t *testing.T) {
 		log := utils.NewTestLogger()
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 
-		service := NewEmployeeService(log, emplRepoMock, nil)
+		service := NewEmployeeService(log, emplRepoMock, nil, nil)
 
 		emplRepoMock.EXPECT().ResetAllData(gomock.Any()).Return(assert.AnError)
 
@@ -715,7 +875,7 @@ func TestEmployeeService_ResetAllData(t *testing.T) {
 		log := utils.NewTestLogger()
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 
-		service := NewEmployeeService(log, emplRepoMock, nil)
+		service := NewEmployeeService(log, emplRepoMock, nil, nil)
 
 		emplRepoMock.EXPECT().ResetAllData(gomock.Any()).Return(nil)
 
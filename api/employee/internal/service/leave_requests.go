@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
+	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+	"github.com/pd120424d/mountain-service/api/employee/internal/model"
+	"github.com/pd120424d/mountain-service/api/employee/internal/repositories"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+const (
+	ErrorLeaveNotFound      = "LEAVE_ERRORS.NOT_FOUND"
+	ErrorLeaveInvalidType   = "LEAVE_ERRORS.INVALID_TYPE"
+	ErrorLeaveInvalidStatus = "LEAVE_ERRORS.INVALID_STATUS"
+	ErrorLeaveInvalidRange  = "LEAVE_ERRORS.INVALID_RANGE"
+	ErrorLeaveOverlap       = "LEAVE_ERRORS.OVERLAP"
+	ErrorLeaveForbidden     = "LEAVE_ERRORS.FORBIDDEN"
+	ErrorLeaveInvalidState  = "LEAVE_ERRORS.INVALID_STATE"
+	ErrorLeaveShiftConflict = "LEAVE_ERRORS.SHIFT_CONFLICT"
+	ErrorEmployeeOnLeave    = "SHIFT_ERRORS.EMPLOYEE_ON_LEAVE"
+
+	// maxLeaveDays caps a single request; longer absences are split into several requests
+	maxLeaveDays = 366
+)
+
+// RequestLeave records a pending leave request for the employee
+func (s *shiftService) RequestLeave(ctx context.Context, employeeID uint, req employeeV1.LeaveRequestCreate) (*employeeV1.LeaveRequestResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "ShiftService.RequestLeave")()
+	log.Infof("Employee %d requesting %s leave %s - %s", employeeID, req.Type, req.StartDate, req.EndDate)
+
+	if !req.Type.Valid() {
+		return nil, commonv1.NewAppError(ErrorLeaveInvalidType, fmt.Sprintf("invalid leave type %q", req.Type), nil)
+	}
+	start, end, err := parseLeaveRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	employee := &model.Employee{}
+	if err := s.emplRepo.GetEmployeeByID(ctx, employeeID, employee); err != nil {
+		log.Errorf("failed to get employee: %v", err)
+		return nil, commonv1.NewAppError("EMPLOYEE_ERRORS.NOT_FOUND", "employee not found", nil)
+	}
+
+	overlapping, err := s.shiftsRepo.ListLeaveRequests(ctx, repositories.LeaveRequestFilter{
+		EmployeeID: employeeID,
+		Statuses:   []employeeV1.LeaveStatus{employeeV1.LeavePending, employeeV1.LeaveApproved},
+		From:       start,
+		To:         end,
+	})
+	if err != nil {
+		log.Errorf("failed to check overlapping leave: %v", err)
+		return nil, fmt.Errorf("failed to request leave")
+	}
+	if len(overlapping) > 0 {
+		return nil, commonv1.NewAppError(ErrorLeaveOverlap, "leave overlaps an existing request", map[string]interface{}{"leaveId": overlapping[0].ID})
+	}
+
+	leave := &model.LeaveRequest{
+		EmployeeID: employeeID,
+		Type:       req.Type,
+		Status:     employeeV1.LeavePending,
+		StartDate:  start,
+		EndDate:    end,
+		Reason:     req.Reason,
+	}
+	if err := s.shiftsRepo.CreateLeaveRequest(ctx, leave); err != nil {
+		log.Errorf("failed to create leave request: %v", err)
+		return nil, fmt.Errorf("failed to request leave")
+	}
+
+	log.Infof("Employee %d requested leave %d", employeeID, leave.ID)
+	resp := leave.ToResponse()
+	return &resp, nil
+}
+
+// ListLeaveRequests returns the actor's own leave requests; administrators may list any employee's,
+// or everyone's when employeeID is 0
+func (s *shiftService) ListLeaveRequests(ctx context.Context, actorID uint, isAdmin bool, employeeID uint, status employeeV1.LeaveStatus) ([]employeeV1.LeaveRequestResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "ShiftService.ListLeaveRequests")()
+
+	if status != "" && !status.Valid() {
+		return nil, commonv1.NewAppError(ErrorLeaveInvalidStatus, fmt.Sprintf("invalid leave status %q", status), nil)
+	}
+
+	filter := repositories.LeaveRequestFilter{EmployeeID: actorID}
+	if isAdmin {
+		filter.EmployeeID = employeeID
+	}
+	if status != "" {
+		filter.Statuses = []employeeV1.LeaveStatus{status}
+	}
+
+	leaves, err := s.shiftsRepo.ListLeaveRequests(ctx, filter)
+	if err != nil {
+		log.Errorf("failed to list leave requests: %v", err)
+		return nil, fmt.Errorf("failed to retrieve leave requests")
+	}
+
+	response := make([]employeeV1.LeaveRequestResponse, 0, len(leaves))
+	for i := range leaves {
+		response = append(response, leaves[i].ToResponse())
+	}
+	return response, nil
+}
+
+// CancelLeaveRequest withdraws a pending or approved leave that has not ended yet. Only the employee or
+// an administrator may cancel it.
+func (s *shiftService) CancelLeaveRequest(ctx context.Context, leaveID, actorID uint, isAdmin bool) (*employeeV1.LeaveRequestResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "ShiftService.CancelLeaveRequest")()
+	log.Infof("Actor %d cancelling leave request %d", actorID, leaveID)
+
+	leave, err := s.getLeave(ctx, leaveID)
+	if err != nil {
+		return nil, err
+	}
+	if leave.EmployeeID != actorID && !isAdmin {
+		return nil, commonv1.NewAppError(ErrorLeaveForbidden, "only the employee or an administrator can cancel this leave", nil)
+	}
+	if leave.Status != employeeV1.LeavePending && leave.Status != employeeV1.LeaveApproved {
+		return nil, invalidLeaveStateError(leave)
+	}
+	if leave.EndDate.Before(time.Now().UTC().Truncate(24 * time.Hour)) {
+		return nil, commonv1.NewAppError(ErrorLeaveInvalidState, "leave has already ended", map[string]interface{}{"status": leave.Status})
+	}
+
+	from := leave.Status
+	leave.Status = employeeV1.LeaveCancelled
+	if err := s.shiftsRepo.UpdateLeaveRequest(ctx, leave, from); err != nil {
+		return nil, s.leaveUpdateError(log, leave, err)
+	}
+
+	resp := leave.ToResponse()
+	return &resp, nil
+}
+
+// ReviewLeaveRequest lets an administrator approve or reject a pending leave request. Approval is refused
+// while the employee still holds shifts during the leave, so those have to be removed or swapped first.
+func (s *shiftService) ReviewLeaveRequest(ctx context.Context, leaveID, adminID uint, approve bool, note string) (*employeeV1.LeaveRequestResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "ShiftService.ReviewLeaveRequest")()
+	log.Infof("Administrator %d reviewing leave request %d (approve=%t)", adminID, leaveID, approve)
+
+	leave, err := s.getLeave(ctx, leaveID)
+	if err != nil {
+		return nil, err
+	}
+	if leave.Status != employeeV1.LeavePending {
+		return nil, invalidLeaveStateError(leave)
+	}
+
+	leave.ReviewedBy = &adminID
+	leave.ReviewNote = note
+	if approve {
+		conflicts, err := s.shiftsRepo.ApproveLeaveRequest(ctx, leave, leaveShiftsFrom(leave))
+		if err != nil {
+			return nil, s.leaveUpdateError(log, leave, err)
+		}
+		if len(conflicts) > 0 {
+			return nil, leaveShiftConflictError(conflicts)
+		}
+	} else {
+		leave.Status = employeeV1.LeaveRejected
+		if err := s.shiftsRepo.UpdateLeaveRequest(ctx, leave, employeeV1.LeavePending); err != nil {
+			return nil, s.leaveUpdateError(log, leave, err)
+		}
+	}
+
+	log.Infof("Leave request %d is %s", leave.ID, leave.Status)
+	resp := leave.ToResponse()
+	return &resp, nil
+}
+
+// validateNotOnLeave refuses to put the employee on a shift during approved leave
+func (s *shiftService) validateNotOnLeave(ctx context.Context, employeeID uint, day time.Time) error {
+	onLeave, err := s.shiftsRepo.HasApprovedLeave(ctx, employeeID, day)
+	if err != nil {
+		s.log.WithContext(ctx).Errorf("failed to check leave: %v", err)
+		return fmt.Errorf("failed to check employee leave")
+	}
+	if onLeave {
+		return commonv1.NewAppError(ErrorEmployeeOnLeave, "employee is on leave that day", map[string]interface{}{"date": day.Format("2006-01-02")})
+	}
+	return nil
+}
+
+// leaveShiftsFrom is the first day on which shifts still conflict with the leave; past shifts no longer matter
+func leaveShiftsFrom(leave *model.LeaveRequest) time.Time {
+	if today := time.Now().UTC().Truncate(24 * time.Hour); leave.StartDate.Before(today) {
+		return today
+	}
+	return leave.StartDate
+}
+
+func leaveShiftConflictError(shifts []model.Shift) error {
+	conflicts := make([]string, 0, len(shifts))
+	for _, shift := range shifts {
+		conflicts = append(conflicts, fmt.Sprintf("%s/%d", shift.ShiftDate.Format("2006-01-02"), shift.ShiftType))
+	}
+	return commonv1.NewAppError(ErrorLeaveShiftConflict, "employee is assigned to shifts during the leave", map[string]interface{}{"shifts": conflicts})
+}
+
+// availableDays counts the days in [start, end) the employee is not on approved leave
+func (s *shiftService) availableDays(ctx context.Context, employeeID uint, start, end time.Time) (int, error) {
+	leaves, err := s.shiftsRepo.ListLeaveRequests(ctx, repositories.LeaveRequestFilter{
+		EmployeeID: employeeID,
+		Statuses:   []employeeV1.LeaveStatus{employeeV1.LeaveApproved},
+		From:       start,
+		To:         end.AddDate(0, 0, -1),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	days := int(end.Sub(start).Hours() / 24)
+	for i := range leaves {
+		days -= leaves[i].DaysWithin(start, end)
+	}
+	return max(days, 0), nil
+}
+
+func (s *shiftService) getLeave(ctx context.Context, leaveID uint) (*model.LeaveRequest, error) {
+	leave, err := s.shiftsRepo.GetLeaveRequest(ctx, leaveID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, commonv1.NewAppError(ErrorLeaveNotFound, "leave request not found", nil)
+	}
+	if err != nil {
+		s.log.WithContext(ctx).Errorf("failed to get leave request %d: %v", leaveID, err)
+		return nil, fmt.Errorf("failed to retrieve leave request")
+	}
+	return leave, nil
+}
+
+func (s *shiftService) leaveUpdateError(log utils.Logger, leave *model.LeaveRequest, err error) error {
+	if errors.Is(err, model.ErrLeaveChanged) {
+		log.Warnf("Leave request %d changed concurrently", leave.ID)
+		return commonv1.NewAppError(ErrorLeaveInvalidState, "leave request was changed by someone else, reload and try again", nil)
+	}
+	log.Errorf("failed to update leave request %d: %v", leave.ID, err)
+	return fmt.Errorf("failed to update leave request")
+}
+
+func invalidLeaveStateError(leave *model.LeaveRequest) error {
+	return commonv1.NewAppError(ErrorLeaveInvalidState, fmt.Sprintf("leave request is %s", leave.Status), map[string]interface{}{"status": leave.Status})
+}
+
+// parseLeaveRange parses the inclusive leave dates; leave may have started already but must not be over
+func parseLeaveRange(rawStart, rawEnd string) (time.Time, time.Time, error) {
+	start, err := time.ParseInLocation("2006-01-02", rawStart, time.UTC)
+	if err != nil {
+		return time.Time{}, time.Time{}, commonv1.NewAppError(ErrorLeaveInvalidRange, "invalid start date format", nil)
+	}
+	end, err := time.ParseInLocation("2006-01-02", rawEnd, time.UTC)
+	if err != nil {
+		return time.Time{}, time.Time{}, commonv1.NewAppError(ErrorLeaveInvalidRange, "invalid end date format", nil)
+	}
+
+	switch {
+	case end.Before(start):
+		return time.Time{}, time.Time{}, commonv1.NewAppError(ErrorLeaveInvalidRange, "end date must not be before start date", nil)
+	case end.Before(time.Now().UTC().Truncate(24 * time.Hour)):
+		return time.Time{}, time.Time{}, commonv1.NewAppError(ErrorLeaveInvalidRange, "leave must not end in the past", nil)
+	case end.Sub(start) >= maxLeaveDays*24*time.Hour:
+		return time.Time{}, time.Time{}, commonv1.NewAppError(ErrorLeaveInvalidRange, fmt.Sprintf("leave cannot be longer than %d days", maxLeaveDays), map[string]interface{}{"max": maxLeaveDays})
+	}
+	return start, end, nil
+}
@@ -0,0 +1,351 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+
+	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+	"github.com/pd120424d/mountain-service/api/employee/internal/model"
+	"github.com/pd120424d/mountain-service/api/employee/internal/repositories"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+func newLeaveService(t *testing.T) (ShiftService, *repositories.MockEmployeeRepository, *repositories.MockShiftRepository) {
+	ctrl := gomock.NewController(t)
+	emplRepo := repositories.NewMockEmployeeRepository(ctrl)
+	shiftRepo := repositories.NewMockShiftRepository(ctrl)
+	return NewShiftService(utils.NewTestLogger(), emplRepo, shiftRepo, nil), emplRepo, shiftRepo
+}
+
+func TestShiftService_RequestLeave(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	start, end := today.AddDate(0, 0, 3), today.AddDate(0, 0, 7)
+	req := employeeV1.LeaveRequestCreate{Type: employeeV1.LeaveVacation, StartDate: start.Format("2006-01-02"), EndDate: end.Format("2006-01-02"), Reason: "holiday"}
+
+	t.Run("it records a pending request", func(t *testing.T) {
+		svc, emplRepo, shiftRepo := newLeaveService(t)
+		expectEmployee(emplRepo, 1, model.Medic)
+		shiftRepo.EXPECT().ListLeaveRequests(gomock.Any(), repositories.LeaveRequestFilter{
+			EmployeeID: 1,
+			Statuses:   []employeeV1.LeaveStatus{employeeV1.LeavePending, employeeV1.LeaveApproved},
+			From:       start,
+			To:         end,
+		}).Return(nil, nil)
+		shiftRepo.EXPECT().CreateLeaveRequest(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, leave *model.LeaveRequest) error {
+			leave.ID = 3
+			return nil
+		})
+
+		resp, err := svc.RequestLeave(ctx, 1, req)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(3), resp.ID)
+		assert.Equal(t, employeeV1.LeavePending, resp.Status)
+		assert.Equal(t, 5, resp.Days)
+	})
+
+	t.Run("it refuses leave overlapping an existing request", func(t *testing.T) {
+		svc, emplRepo, shiftRepo := newLeaveService(t)
+		expectEmployee(emplRepo, 1, model.Medic)
+		shiftRepo.EXPECT().ListLeaveRequests(gomock.Any(), gomock.Any()).Return([]model.LeaveRequest{{ID: 2}}, nil)
+
+		_, err := svc.RequestLeave(ctx, 1, req)
+
+		assert.Equal(t, ErrorLeaveOverlap, appErrorCode(t, err))
+	})
+
+	tests := []struct {
+		name string
+		req  employeeV1.LeaveRequestCreate
+		code string
+	}{
+		{"unknown type", employeeV1.LeaveRequestCreate{Type: "sabbatical", StartDate: req.StartDate, EndDate: req.EndDate}, ErrorLeaveInvalidType},
+		{"bad date", employeeV1.LeaveRequestCreate{Type: employeeV1.LeaveSick, StartDate: "tomorrow", EndDate: req.EndDate}, ErrorLeaveInvalidRange},
+		{"end before start", employeeV1.LeaveRequestCreate{Type: employeeV1.LeaveSick, StartDate: req.EndDate, EndDate: req.StartDate}, ErrorLeaveInvalidRange},
+		{"leave already over", employeeV1.LeaveRequestCreate{Type: employeeV1.LeaveSick, StartDate: "2020-01-01", EndDate: "2020-01-02"}, ErrorLeaveInvalidRange},
+		{"leave too long", employeeV1.LeaveRequestCreate{Type: employeeV1.LeaveOther, StartDate: req.StartDate, EndDate: start.AddDate(1, 1, 0).Format("2006-01-02")}, ErrorLeaveInvalidRange},
+	}
+	for _, tt := range tests {
+		t.Run("it rejects "+tt.name, func(t *testing.T) {
+			svc, _, _ := newLeaveService(t)
+
+			_, err := svc.RequestLeave(ctx, 1, tt.req)
+
+			assert.Equal(t, tt.code, appErrorCode(t, err))
+		})
+	}
+}
+
+func TestShiftService_ReviewLeaveRequest(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	pending := func() *model.LeaveRequest {
+		return &model.LeaveRequest{ID: 3, EmployeeID: 1, Type: employeeV1.LeaveSick, Status: employeeV1.LeavePending, StartDate: today.AddDate(0, 0, 2), EndDate: today.AddDate(0, 0, 4)}
+	}
+
+	t.Run("it approves leave without shifts", func(t *testing.T) {
+		svc, _, shiftRepo := newLeaveService(t)
+		shiftRepo.EXPECT().GetLeaveRequest(gomock.Any(), uint(3)).Return(pending(), nil)
+		shiftRepo.EXPECT().ApproveLeaveRequest(gomock.Any(), gomock.Any(), today.AddDate(0, 0, 2)).DoAndReturn(func(_ context.Context, leave *model.LeaveRequest, _ time.Time) ([]model.Shift, error) {
+			leave.Status = employeeV1.LeaveApproved
+			return nil, nil
+		})
+
+		resp, err := svc.ReviewLeaveRequest(ctx, 3, 9, true, "get well")
+
+		require.NoError(t, err)
+		assert.Equal(t, employeeV1.LeaveApproved, resp.Status)
+		assert.Equal(t, uint(9), *resp.ReviewedBy)
+		assert.Equal(t, "get well", resp.ReviewNote)
+	})
+
+	t.Run("it refuses approval while the employee holds shifts during the leave", func(t *testing.T) {
+		svc, _, shiftRepo := newLeaveService(t)
+		shiftRepo.EXPECT().GetLeaveRequest(gomock.Any(), uint(3)).Return(pending(), nil)
+		shiftRepo.EXPECT().ApproveLeaveRequest(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]model.Shift{{ID: 10, ShiftDate: today.AddDate(0, 0, 3), ShiftType: 2}}, nil)
+
+		_, err := svc.ReviewLeaveRequest(ctx, 3, 9, true, "")
+
+		assert.Equal(t, ErrorLeaveShiftConflict, appErrorCode(t, err))
+	})
+
+	t.Run("it only checks shifts from today on for leave that already started", func(t *testing.T) {
+		svc, _, shiftRepo := newLeaveService(t)
+		leave := pending()
+		leave.StartDate = today.AddDate(0, 0, -2)
+		shiftRepo.EXPECT().GetLeaveRequest(gomock.Any(), uint(3)).Return(leave, nil)
+		shiftRepo.EXPECT().ApproveLeaveRequest(gomock.Any(), gomock.Any(), today).Return(nil, nil)
+
+		_, err := svc.ReviewLeaveRequest(ctx, 3, 9, true, "")
+
+		require.NoError(t, err)
+	})
+
+	t.Run("it reports a conflict when the request was cancelled before the approval", func(t *testing.T) {
+		svc, _, shiftRepo := newLeaveService(t)
+		shiftRepo.EXPECT().GetLeaveRequest(gomock.Any(), uint(3)).Return(pending(), nil)
+		shiftRepo.EXPECT().ApproveLeaveRequest(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, model.ErrLeaveChanged)
+
+		_, err := svc.ReviewLeaveRequest(ctx, 3, 9, true, "")
+
+		assert.Equal(t, ErrorLeaveInvalidState, appErrorCode(t, err))
+	})
+
+	t.Run("it rejects without checking shifts", func(t *testing.T) {
+		svc, _, shiftRepo := newLeaveService(t)
+		shiftRepo.EXPECT().GetLeaveRequest(gomock.Any(), uint(3)).Return(pending(), nil)
+		shiftRepo.EXPECT().UpdateLeaveRequest(gomock.Any(), gomock.Any(), employeeV1.LeavePending).Return(nil)
+
+		resp, err := svc.ReviewLeaveRequest(ctx, 3, 9, false, "short staffed")
+
+		require.NoError(t, err)
+		assert.Equal(t, employeeV1.LeaveRejected, resp.Status)
+	})
+
+	t.Run("it refuses to review a decided request", func(t *testing.T) {
+		svc, _, shiftRepo := newLeaveService(t)
+		leave := pending()
+		leave.Status = employeeV1.LeaveCancelled
+		shiftRepo.EXPECT().GetLeaveRequest(gomock.Any(), uint(3)).Return(leave, nil)
+
+		_, err := svc.ReviewLeaveRequest(ctx, 3, 9, true, "")
+
+		assert.Equal(t, ErrorLeaveInvalidState, appErrorCode(t, err))
+	})
+
+	t.Run("it returns not found for a missing request", func(t *testing.T) {
+		svc, _, shiftRepo := newLeaveService(t)
+		shiftRepo.EXPECT().GetLeaveRequest(gomock.Any(), uint(3)).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.ReviewLeaveRequest(ctx, 3, 9, true, "")
+
+		assert.Equal(t, ErrorLeaveNotFound, appErrorCode(t, err))
+	})
+
+	t.Run("it reports a conflict when the request was cancelled meanwhile", func(t *testing.T) {
+		svc, _, shiftRepo := newLeaveService(t)
+		shiftRepo.EXPECT().GetLeaveRequest(gomock.Any(), uint(3)).Return(pending(), nil)
+		shiftRepo.EXPECT().UpdateLeaveRequest(gomock.Any(), gomock.Any(), employeeV1.LeavePending).Return(model.ErrLeaveChanged)
+
+		_, err := svc.ReviewLeaveRequest(ctx, 3, 9, false, "")
+
+		assert.Equal(t, ErrorLeaveInvalidState, appErrorCode(t, err))
+	})
+}
+
+func TestShiftService_CancelLeaveRequest(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	approved := func() *model.LeaveRequest {
+		return &model.LeaveRequest{ID: 3, EmployeeID: 1, Type: employeeV1.LeaveVacation, Status: employeeV1.LeaveApproved, StartDate: today.AddDate(0, 0, -1), EndDate: today.AddDate(0, 0, 1)}
+	}
+
+	t.Run("it lets the employee cancel leave that is still running", func(t *testing.T) {
+		svc, _, shiftRepo := newLeaveService(t)
+		shiftRepo.EXPECT().GetLeaveRequest(gomock.Any(), uint(3)).Return(approved(), nil)
+		shiftRepo.EXPECT().UpdateLeaveRequest(gomock.Any(), gomock.Any(), employeeV1.LeaveApproved).Return(nil)
+
+		resp, err := svc.CancelLeaveRequest(ctx, 3, 1, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, employeeV1.LeaveCancelled, resp.Status)
+	})
+
+	t.Run("it refuses other employees", func(t *testing.T) {
+		svc, _, shiftRepo := newLeaveService(t)
+		shiftRepo.EXPECT().GetLeaveRequest(gomock.Any(), uint(3)).Return(approved(), nil)
+
+		_, err := svc.CancelLeaveRequest(ctx, 3, 2, false)
+
+		assert.Equal(t, ErrorLeaveForbidden, appErrorCode(t, err))
+	})
+
+	t.Run("it refuses leave that is over", func(t *testing.T) {
+		svc, _, shiftRepo := newLeaveService(t)
+		leave := approved()
+		leave.EndDate = today.AddDate(0, 0, -1)
+		shiftRepo.EXPECT().GetLeaveRequest(gomock.Any(), uint(3)).Return(leave, nil)
+
+		_, err := svc.CancelLeaveRequest(ctx, 3, 9, true)
+
+		assert.Equal(t, ErrorLeaveInvalidState, appErrorCode(t, err))
+	})
+}
+
+func TestShiftService_ListLeaveRequests(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("it limits employees to their own requests", func(t *testing.T) {
+		svc, _, shiftRepo := newLeaveService(t)
+		shiftRepo.EXPECT().ListLeaveRequests(gomock.Any(), repositories.LeaveRequestFilter{EmployeeID: 1, Statuses: []employeeV1.LeaveStatus{employeeV1.LeavePending}}).
+			Return([]model.LeaveRequest{{ID: 3, EmployeeID: 1}}, nil)
+
+		resp, err := svc.ListLeaveRequests(ctx, 1, false, 2, employeeV1.LeavePending)
+
+		require.NoError(t, err)
+		assert.Len(t, resp, 1)
+	})
+
+	t.Run("it lets administrators pick the employee", func(t *testing.T) {
+		svc, _, shiftRepo := newLeaveService(t)
+		shiftRepo.EXPECT().ListLeaveRequests(gomock.Any(), repositories.LeaveRequestFilter{EmployeeID: 2}).Return(nil, nil)
+
+		resp, err := svc.ListLeaveRequests(ctx, 9, true, 2, "")
+
+		require.NoError(t, err)
+		assert.Empty(t, resp)
+	})
+
+	t.Run("it rejects an unknown status", func(t *testing.T) {
+		svc, _, _ := newLeaveService(t)
+
+		_, err := svc.ListLeaveRequests(ctx, 1, false, 0, "gone")
+
+		assert.Equal(t, ErrorLeaveInvalidStatus, appErrorCode(t, err))
+	})
+}
+
+func TestShiftService_LeaveBlocksShifts(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	t.Run("it refuses to assign a shift during approved leave", func(t *testing.T) {
+		svc, emplRepo, shiftRepo := newLeaveService(t)
+		expectEmployee(emplRepo, 1, model.Medic)
+		shiftRepo.EXPECT().HasApprovedLeave(gomock.Any(), uint(1), today.AddDate(0, 0, 3)).Return(true, nil)
+
+		_, err := svc.AssignShift(ctx, 1, employeeV1.AssignShiftRequest{ShiftDate: today.AddDate(0, 0, 3).Format("2006-01-02"), ShiftType: 1})
+
+		assert.Equal(t, ErrorEmployeeOnLeave, appErrorCode(t, err))
+	})
+
+	t.Run("it refuses to hand a shift over to a colleague on leave", func(t *testing.T) {
+		svc, emplRepo, shiftRepo := newLeaveService(t)
+		shiftRepo.EXPECT().GetShiftSwap(gomock.Any(), uint(5)).Return(&model.ShiftSwap{ID: 5, ShiftID: 10, RequesterID: 1, ProfileType: model.Medic, Status: employeeV1.ShiftSwapOpen,
+			Shift: model.Shift{ID: 10, ShiftDate: today.AddDate(0, 0, 3), ShiftType: 1}}, nil)
+		expectEmployee(emplRepo, 2, model.Medic)
+		shiftRepo.EXPECT().HasApprovedLeave(gomock.Any(), uint(2), today.AddDate(0, 0, 3)).Return(true, nil)
+
+		_, err := svc.AcceptShiftSwap(ctx, 5, 2)
+
+		assert.Equal(t, ErrorEmployeeOnLeave, appErrorCode(t, err))
+	})
+}
+
+func TestShiftService_GetShiftWarningsWithLeave(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	uncovered := &model.ShiftsAvailabilityRange{
+		Days: map[time.Time][]map[model.ProfileType]int{
+			today: {{model.Medic: 2, model.Technical: 4}},
+		},
+	}
+
+	t.Run("it scales the expected workload to the available days", func(t *testing.T) {
+		svc, emplRepo, shiftRepo := newLeaveService(t)
+		expectEmployee(emplRepo, 1, model.Medic)
+		shiftRepo.EXPECT().GetShiftAvailability(gomock.Any(), gomock.Any(), gomock.Any()).Return(uncovered, nil)
+		shiftRepo.EXPECT().ListShiftCapacities(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		// a week of leave leaves 7 available days, so 5 scheduled days is enough
+		shiftRepo.EXPECT().ListLeaveRequests(gomock.Any(), gomock.Any()).Return([]model.LeaveRequest{
+			{StartDate: today.AddDate(0, 0, 7), EndDate: today.AddDate(0, 0, 13), Status: employeeV1.LeaveApproved},
+		}, nil)
+		shiftRepo.EXPECT().GetShiftsByEmployeeIDInDateRange(gomock.Any(), uint(1), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uint, _, _ time.Time, result *[]model.Shift) error {
+				for i := 0; i < 5; i++ {
+					*result = append(*result, model.Shift{ShiftDate: today.AddDate(0, 0, i), ShiftType: 1})
+				}
+				return nil
+			})
+
+		warnings, err := svc.GetShiftWarnings(ctx, 1)
+
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+	})
+
+	t.Run("it reports the shortfall against the available days", func(t *testing.T) {
+		svc, emplRepo, shiftRepo := newLeaveService(t)
+		expectEmployee(emplRepo, 1, model.Medic)
+		shiftRepo.EXPECT().GetShiftAvailability(gomock.Any(), gomock.Any(), gomock.Any()).Return(uncovered, nil)
+		shiftRepo.EXPECT().ListShiftCapacities(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		shiftRepo.EXPECT().ListLeaveRequests(gomock.Any(), gomock.Any()).Return([]model.LeaveRequest{
+			{StartDate: today.AddDate(0, 0, 10), EndDate: today.AddDate(0, 0, 20), Status: employeeV1.LeaveApproved},
+		}, nil)
+		shiftRepo.EXPECT().GetShiftsByEmployeeIDInDateRange(gomock.Any(), uint(1), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+		warnings, err := svc.GetShiftWarnings(ctx, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"SHIFT_WARNINGS.INSUFFICIENT_SHIFTS|0|10|5"}, warnings)
+	})
+
+	t.Run("it does not warn employees away for the whole period", func(t *testing.T) {
+		svc, emplRepo, shiftRepo := newLeaveService(t)
+		expectEmployee(emplRepo, 1, model.Medic)
+		shiftRepo.EXPECT().GetShiftAvailability(gomock.Any(), gomock.Any(), gomock.Any()).Return(uncovered, nil)
+		shiftRepo.EXPECT().ListShiftCapacities(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		shiftRepo.EXPECT().ListLeaveRequests(gomock.Any(), gomock.Any()).Return([]model.LeaveRequest{
+			{StartDate: today.AddDate(0, 0, -3), EndDate: today.AddDate(0, 0, 30), Status: employeeV1.LeaveApproved},
+		}, nil)
+
+		warnings, err := svc.GetShiftWarnings(ctx, 1)
+
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+	})
+}
@@ -0,0 +1,465 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
+	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+	"github.com/pd120424d/mountain-service/api/employee/internal/model"
+	"github.com/pd120424d/mountain-service/api/employee/internal/repositories"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+const (
+	ErrorRosterInvalidRange      = "ROSTER_ERRORS.INVALID_RANGE"
+	ErrorRosterInvalidAssignment = "ROSTER_ERRORS.INVALID_ASSIGNMENT"
+	ErrorRosterConflict          = "ROSTER_ERRORS.CONFLICT"
+)
+
+// rosterProfiles are the profiles staffed by shifts, in the order slots are filled
+var rosterProfiles = []model.ProfileType{model.Medic, model.Technical}
+
+type rosterSlotKey struct {
+	day       string
+	shiftType int
+	profile   model.ProfileType
+}
+
+type rosterEmployee struct {
+	employee model.Employee
+	// shifts holds current and proposed shifts from three days before the period to three days after it,
+	// enough to apply the consecutive shift rules anywhere inside the period
+	shifts   []model.Shift
+	leaves   []model.LeaveRequest
+	workDays map[string]bool
+	target   int
+	current  int
+	proposed int
+}
+
+// rosterPlan is the staffing of a period held in memory while a roster is drafted or checked
+type rosterPlan struct {
+	start, end time.Time // inclusive days
+	capacities []model.ShiftCapacity
+	employees  map[uint]*rosterEmployee
+	current    map[rosterSlotKey][]uint
+	proposed   map[rosterSlotKey][]uint
+}
+
+// DraftRoster proposes assignments filling the shifts of the inclusive period up to each profile's capacity.
+// Nothing is saved: the draft lists the current and proposed staff of every slot so it can be reviewed
+// before CommitRoster applies it.
+func (s *shiftService) DraftRoster(ctx context.Context, req employeeV1.RosterDraftRequest) (*employeeV1.RosterDraftResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "ShiftService.DraftRoster")()
+	log.Infof("Drafting roster for %s - %s", req.StartDate, req.EndDate)
+
+	start, end, err := parseRosterRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := s.loadRosterPlan(ctx, start, end)
+	if err != nil {
+		log.Errorf("failed to load roster data: %v", err)
+		return nil, fmt.Errorf("failed to draft roster")
+	}
+
+	var proposed []employeeV1.RosterAssignment
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		for shiftType := 1; shiftType <= 3; shiftType++ {
+			for _, profile := range rosterProfiles {
+				key := rosterSlotKey{day: day.Format("2006-01-02"), shiftType: shiftType, profile: profile}
+				open := model.CapacityOn(plan.capacities, day, profile) - len(plan.current[key])
+				for _, candidate := range plan.candidates(day, shiftType, profile) {
+					if open <= 0 {
+						break
+					}
+					plan.assign(candidate, day, shiftType)
+					proposed = append(proposed, employeeV1.RosterAssignment{EmployeeID: candidate.employee.ID, ShiftDate: key.day, ShiftType: shiftType})
+					open--
+				}
+			}
+		}
+	}
+
+	resp := s.rosterDraftResponse(plan)
+	resp.Proposed = proposed
+	if resp.Proposed == nil {
+		resp.Proposed = []employeeV1.RosterAssignment{}
+	}
+	log.Infof("Drafted %d assignments for %s - %s, %d places left unfilled", len(proposed), req.StartDate, req.EndDate, resp.UnfilledSlots)
+	return resp, nil
+}
+
+// CommitRoster checks the reviewed assignments against the current roster and creates them all in one
+// transaction. If any of them breaks a rule nothing is created.
+func (s *shiftService) CommitRoster(ctx context.Context, req employeeV1.RosterCommitRequest) (*employeeV1.RosterCommitResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "ShiftService.CommitRoster")()
+	log.Infof("Committing roster with %d assignments", len(req.Assignments))
+
+	if len(req.Assignments) == 0 {
+		return nil, commonv1.NewAppError(ErrorRosterInvalidAssignment, "roster has no assignments", nil)
+	}
+
+	type parsedAssignment struct {
+		employeeV1.RosterAssignment
+		day time.Time
+	}
+	assignments := make([]parsedAssignment, 0, len(req.Assignments))
+	for _, a := range req.Assignments {
+		day, err := time.ParseInLocation("2006-01-02", a.ShiftDate, time.UTC)
+		if err != nil || a.ShiftType < 1 || a.ShiftType > 3 {
+			return nil, commonv1.NewAppError(ErrorRosterInvalidAssignment, "invalid shift date or type", rosterAssignmentDetails(a, ""))
+		}
+		assignments = append(assignments, parsedAssignment{RosterAssignment: a, day: day})
+	}
+	sort.Slice(assignments, func(i, j int) bool {
+		if !assignments[i].day.Equal(assignments[j].day) {
+			return assignments[i].day.Before(assignments[j].day)
+		}
+		if assignments[i].ShiftType != assignments[j].ShiftType {
+			return assignments[i].ShiftType < assignments[j].ShiftType
+		}
+		return assignments[i].EmployeeID < assignments[j].EmployeeID
+	})
+
+	start, end := assignments[0].day, assignments[len(assignments)-1].day
+	if err := validateRosterRange(start, end); err != nil {
+		return nil, err
+	}
+
+	plan, err := s.loadRosterPlan(ctx, start, end)
+	if err != nil {
+		log.Errorf("failed to load roster data: %v", err)
+		return nil, fmt.Errorf("failed to commit roster")
+	}
+
+	rows := make([]repositories.ShiftAssignmentRow, 0, len(assignments))
+	for _, a := range assignments {
+		candidate, ok := plan.employees[a.EmployeeID]
+		if !ok {
+			return nil, commonv1.NewAppError(ErrorRosterInvalidAssignment, "employee cannot be rostered", rosterAssignmentDetails(a.RosterAssignment, ""))
+		}
+		if reason := s.rosterViolation(plan, candidate, a.day, a.ShiftType); reason != "" {
+			log.Warnf("roster assignment of employee %d to %s/%d rejected: %s", a.EmployeeID, a.ShiftDate, a.ShiftType, reason)
+			return nil, commonv1.NewAppError(ErrorRosterConflict, "roster assignment breaks the shift rules", rosterAssignmentDetails(a.RosterAssignment, reason))
+		}
+		plan.assign(candidate, a.day, a.ShiftType)
+		rows = append(rows, repositories.ShiftAssignmentRow{
+			EmployeeID:  a.EmployeeID,
+			ProfileType: candidate.employee.ProfileType,
+			ShiftDate:   a.day,
+			ShiftType:   a.ShiftType,
+		})
+	}
+
+	if err := s.shiftsRepo.CreateRosterAssignments(ctx, rows); err != nil {
+		if errors.Is(err, model.ErrAlreadyAssigned) || errors.Is(err, model.ErrCapacityReached) {
+			log.Warnf("roster changed while committing: %v", err)
+			return nil, commonv1.NewAppError(ErrorRosterConflict, "shifts changed while the roster was committed, draft it again", nil)
+		}
+		log.Errorf("failed to create roster assignments: %v", err)
+		return nil, fmt.Errorf("failed to commit roster")
+	}
+
+	created := make([]employeeV1.RosterAssignment, 0, len(assignments))
+	for _, a := range assignments {
+		created = append(created, a.RosterAssignment)
+	}
+	log.Infof("Committed roster with %d assignments for %s - %s", len(created), start.Format("2006-01-02"), end.Format("2006-01-02"))
+	return &employeeV1.RosterCommitResponse{Created: len(created), Assignments: created}, nil
+}
+
+// loadRosterPlan collects the rosterable employees with their shifts and approved leave around the period
+func (s *shiftService) loadRosterPlan(ctx context.Context, start, end time.Time) (*rosterPlan, error) {
+	employees, err := s.emplRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get employees: %w", err)
+	}
+	rows, err := s.shiftsRepo.GetAssignmentsInDateRange(ctx, start.AddDate(0, 0, -3), end.AddDate(0, 0, 4))
+	if err != nil {
+		return nil, err
+	}
+	capacities, err := s.shiftsRepo.ListShiftCapacities(ctx, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shift capacities: %w", err)
+	}
+	leaves, err := s.shiftsRepo.ListLeaveRequests(ctx, repositories.LeaveRequestFilter{
+		Statuses: []employeeV1.LeaveStatus{employeeV1.LeaveApproved},
+		From:     start,
+		To:       end,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leave: %w", err)
+	}
+
+	plan := &rosterPlan{
+		start:      start,
+		end:        end,
+		capacities: capacities,
+		employees:  make(map[uint]*rosterEmployee),
+		current:    make(map[rosterSlotKey][]uint),
+		proposed:   make(map[rosterSlotKey][]uint),
+	}
+	for _, e := range employees {
+		if !slices.Contains(rosterProfiles, e.ProfileType) {
+			continue
+		}
+		plan.employees[e.ID] = &rosterEmployee{employee: e, workDays: make(map[string]bool)}
+	}
+	for _, l := range leaves {
+		if re, ok := plan.employees[l.EmployeeID]; ok {
+			re.leaves = append(re.leaves, l)
+		}
+	}
+
+	for _, row := range rows {
+		day := row.ShiftDate.UTC().Truncate(24 * time.Hour)
+		inPeriod := plan.contains(day)
+		if inPeriod {
+			key := rosterSlotKey{day: day.Format("2006-01-02"), shiftType: row.ShiftType, profile: row.ProfileType}
+			plan.current[key] = append(plan.current[key], row.EmployeeID)
+		}
+		re, ok := plan.employees[row.EmployeeID]
+		if !ok {
+			continue
+		}
+		re.shifts = append(re.shifts, model.Shift{ID: row.ShiftID, ShiftDate: day, ShiftType: row.ShiftType})
+		if inPeriod {
+			re.workDays[day.Format("2006-01-02")] = true
+			re.current++
+		}
+	}
+
+	for _, re := range plan.employees {
+		re.target = requiredWorkdays(re.availableDays(plan))
+	}
+	return plan, nil
+}
+
+func (p *rosterPlan) contains(day time.Time) bool {
+	return !day.Before(p.start) && !day.After(p.end)
+}
+
+// candidates returns the employees of the profile who may take the shift, those furthest behind their
+// target first. The draft gives nobody more than one shift a day or more days than their target.
+func (p *rosterPlan) candidates(day time.Time, shiftType int, profile model.ProfileType) []*rosterEmployee {
+	dayKey := day.Format("2006-01-02")
+	var eligible []*rosterEmployee
+	for _, re := range p.employees {
+		if re.employee.ProfileType != profile || re.workDays[dayKey] || len(re.workDays) >= re.target {
+			continue
+		}
+		if re.onLeave(day) || checkConsecutiveShifts(re.shifts, day, shiftType) != nil {
+			continue
+		}
+		eligible = append(eligible, re)
+	}
+
+	sort.Slice(eligible, func(i, j int) bool {
+		a, b := eligible[i], eligible[j]
+		// compare the share of the target already worked without dividing
+		if l, r := len(a.workDays)*b.target, len(b.workDays)*a.target; l != r {
+			return l < r
+		}
+		if len(a.workDays) != len(b.workDays) {
+			return len(a.workDays) < len(b.workDays)
+		}
+		return a.employee.ID < b.employee.ID
+	})
+	return eligible
+}
+
+func (p *rosterPlan) assign(re *rosterEmployee, day time.Time, shiftType int) {
+	dayKey := day.Format("2006-01-02")
+	re.shifts = append(re.shifts, model.Shift{ShiftDate: day, ShiftType: shiftType})
+	re.workDays[dayKey] = true
+	re.proposed++
+	key := rosterSlotKey{day: dayKey, shiftType: shiftType, profile: re.employee.ProfileType}
+	p.proposed[key] = append(p.proposed[key], re.employee.ID)
+}
+
+func (re *rosterEmployee) onLeave(day time.Time) bool {
+	for i := range re.leaves {
+		if !day.Before(re.leaves[i].StartDate) && !day.After(re.leaves[i].EndDate) {
+			return true
+		}
+	}
+	return false
+}
+
+func (re *rosterEmployee) holds(day time.Time, shiftType int) bool {
+	for _, sh := range re.shifts {
+		if sh.ShiftDate.Equal(day) && sh.ShiftType == shiftType {
+			return true
+		}
+	}
+	return false
+}
+
+// shiftRoster lists the current staff of the profiles on the shift. Slots count every assignment, like
+// AssignShift does, while staff on approved leave do not count as present for understaffing.
+func (p *rosterPlan) shiftRoster(day time.Time, shiftType int, profiles []model.ProfileType) employeeV1.AdminShiftAvailability {
+	dayKey := day.Format("2006-01-02")
+	shift := employeeV1.AdminShiftAvailability{
+		Employees:            make(map[string][]employeeV1.ShiftRosterEntry, len(profiles)),
+		Capacity:             make(map[string]int, len(profiles)),
+		UnderstaffedProfiles: []string{},
+	}
+	shift.IsFullyBooked = true
+
+	for _, profile := range profiles {
+		capacity := model.CapacityOn(p.capacities, day, profile)
+		assigned := p.current[rosterSlotKey{day: dayKey, shiftType: shiftType, profile: profile}]
+		entries := make([]employeeV1.ShiftRosterEntry, 0, len(assigned))
+		present := 0
+		for _, id := range assigned {
+			re, ok := p.employees[id]
+			if !ok {
+				continue
+			}
+			onLeave := re.onLeave(day)
+			if !onLeave {
+				present++
+			}
+			entries = append(entries, employeeV1.ShiftRosterEntry{
+				EmployeeID: id,
+				Name:       re.employee.FirstName + " " + re.employee.LastName,
+				Username:   re.employee.Username,
+				OnLeave:    onLeave,
+			})
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i].EmployeeID < entries[j].EmployeeID })
+
+		slots := max(0, capacity-len(assigned))
+		switch profile {
+		case model.Medic:
+			shift.MedicSlotsAvailable = slots
+		case model.Technical:
+			shift.TechnicalSlotsAvailable = slots
+		}
+		if slots > 0 {
+			shift.IsFullyBooked = false
+		}
+		if present < capacity {
+			shift.UnderstaffedProfiles = append(shift.UnderstaffedProfiles, profile.String())
+		}
+		shift.Employees[profile.String()] = entries
+		shift.Capacity[profile.String()] = capacity
+	}
+
+	shift.IsUnderstaffed = len(shift.UnderstaffedProfiles) > 0
+	return shift
+}
+
+// rosterViolation returns the error code of the rule a committed assignment breaks, or "" if it is allowed
+func (s *shiftService) rosterViolation(plan *rosterPlan, re *rosterEmployee, day time.Time, shiftType int) string {
+	key := rosterSlotKey{day: day.Format("2006-01-02"), shiftType: shiftType, profile: re.employee.ProfileType}
+	switch {
+	case re.holds(day, shiftType):
+		return "SHIFT_ERRORS.ALREADY_ASSIGNED"
+	case re.onLeave(day):
+		return ErrorEmployeeOnLeave
+	case checkConsecutiveShifts(re.shifts, day, shiftType) != nil:
+		return model.ErrorConsecutiveShiftsLimit
+	case len(plan.current[key])+len(plan.proposed[key]) >= model.CapacityOn(plan.capacities, day, re.employee.ProfileType):
+		return "SHIFT_ERRORS.CAPACITY_FULL"
+	}
+	return ""
+}
+
+func (s *shiftService) rosterDraftResponse(plan *rosterPlan) *employeeV1.RosterDraftResponse {
+	resp := &employeeV1.RosterDraftResponse{
+		StartDate: plan.start.Format("2006-01-02"),
+		EndDate:   plan.end.Format("2006-01-02"),
+		Slots:     []employeeV1.RosterSlot{},
+		Employees: make([]employeeV1.RosterEmployeeSummary, 0, len(plan.employees)),
+	}
+
+	for day := plan.start; !day.After(plan.end); day = day.AddDate(0, 0, 1) {
+		for shiftType := 1; shiftType <= 3; shiftType++ {
+			for _, profile := range rosterProfiles {
+				key := rosterSlotKey{day: day.Format("2006-01-02"), shiftType: shiftType, profile: profile}
+				capacity := model.CapacityOn(plan.capacities, day, profile)
+				slot := employeeV1.RosterSlot{
+					ShiftDate:   key.day,
+					ShiftType:   shiftType,
+					ProfileType: profile.String(),
+					Capacity:    capacity,
+					Current:     append([]uint{}, plan.current[key]...),
+					Proposed:    append([]uint{}, plan.proposed[key]...),
+				}
+				slot.Unfilled = max(capacity-len(slot.Current)-len(slot.Proposed), 0)
+				resp.UnfilledSlots += slot.Unfilled
+				resp.Slots = append(resp.Slots, slot)
+			}
+		}
+	}
+
+	for _, re := range plan.employees {
+		resp.Employees = append(resp.Employees, employeeV1.RosterEmployeeSummary{
+			EmployeeID:     re.employee.ID,
+			Name:           re.employee.FirstName + " " + re.employee.LastName,
+			ProfileType:    re.employee.ProfileType.String(),
+			AvailableDays:  re.availableDays(plan),
+			TargetDays:     re.target,
+			CurrentShifts:  re.current,
+			ProposedShifts: re.proposed,
+			WorkDays:       len(re.workDays),
+		})
+	}
+	sort.Slice(resp.Employees, func(i, j int) bool { return resp.Employees[i].EmployeeID < resp.Employees[j].EmployeeID })
+	return resp
+}
+
+func (re *rosterEmployee) availableDays(plan *rosterPlan) int {
+	available := int(plan.end.Sub(plan.start).Hours()/24) + 1
+	for i := range re.leaves {
+		available -= re.leaves[i].DaysWithin(plan.start, plan.end.AddDate(0, 0, 1))
+	}
+	return max(available, 0)
+}
+
+// parseRosterRange parses the inclusive roster period
+func parseRosterRange(rawStart, rawEnd string) (time.Time, time.Time, error) {
+	start, err := time.ParseInLocation("2006-01-02", rawStart, time.UTC)
+	if err != nil {
+		return time.Time{}, time.Time{}, commonv1.NewAppError(ErrorRosterInvalidRange, "invalid start date format", nil)
+	}
+	end, err := time.ParseInLocation("2006-01-02", rawEnd, time.UTC)
+	if err != nil {
+		return time.Time{}, time.Time{}, commonv1.NewAppError(ErrorRosterInvalidRange, "invalid end date format", nil)
+	}
+	if err := validateRosterRange(start, end); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return start, end, nil
+}
+
+// validateRosterRange keeps rosters inside the window shifts can be assigned in
+func validateRosterRange(start, end time.Time) error {
+	switch {
+	case end.Before(start):
+		return commonv1.NewAppError(ErrorRosterInvalidRange, "end date must not be before start date", nil)
+	case start.Before(time.Now().UTC().Truncate(24 * time.Hour)):
+		return commonv1.NewAppError(ErrorRosterInvalidRange, "roster must not start in the past", nil)
+	case end.After(time.Now().UTC().AddDate(0, 3, 0)):
+		return commonv1.NewAppError(ErrorRosterInvalidRange, "roster cannot end more than 3 months in the future", nil)
+	}
+	return nil
+}
+
+func rosterAssignmentDetails(a employeeV1.RosterAssignment, reason string) map[string]interface{} {
+	details := map[string]interface{}{"employeeId": a.EmployeeID, "shiftDate": a.ShiftDate, "shiftType": a.ShiftType}
+	if reason != "" {
+		details["reason"] = reason
+	}
+	return details
+}
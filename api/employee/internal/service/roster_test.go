@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
+	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+	"github.com/pd120424d/mountain-service/api/employee/internal/model"
+	"github.com/pd120424d/mountain-service/api/employee/internal/repositories"
+)
+
+// expectRosterData stubs the employees, assignments, default capacities and approved leave a roster is planned from
+func expectRosterData(emplRepo *repositories.MockEmployeeRepository, shiftRepo *repositories.MockShiftRepository, employees []model.Employee, rows []repositories.ShiftAssignmentRow, leaves []model.LeaveRequest) {
+	emplRepo.EXPECT().GetAll(gomock.Any()).Return(employees, nil)
+	shiftRepo.EXPECT().GetAssignmentsInDateRange(gomock.Any(), gomock.Any(), gomock.Any()).Return(rows, nil)
+	shiftRepo.EXPECT().ListShiftCapacities(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	shiftRepo.EXPECT().ListLeaveRequests(gomock.Any(), gomock.Any()).Return(leaves, nil)
+}
+
+func TestShiftService_DraftRoster(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	start, end := today.AddDate(0, 0, 1), today.AddDate(0, 0, 7)
+	req := employeeV1.RosterDraftRequest{StartDate: start.Format("2006-01-02"), EndDate: end.Format("2006-01-02")}
+	staff := []model.Employee{
+		{ID: 1, FirstName: "Ana", LastName: "A", ProfileType: model.Medic},
+		{ID: 2, FirstName: "Bojan", LastName: "B", ProfileType: model.Medic},
+		{ID: 3, FirstName: "Ceca", LastName: "C", ProfileType: model.Technical},
+		{ID: 9, FirstName: "Admin", LastName: "A", ProfileType: model.Administrator},
+	}
+
+	t.Run("it spreads shifts up to each employee's target", func(t *testing.T) {
+		svc, emplRepo, shiftRepo := newLeaveService(t)
+		expectRosterData(emplRepo, shiftRepo, staff, nil, []model.LeaveRequest{
+			{ID: 4, EmployeeID: 2, Status: employeeV1.LeaveApproved, StartDate: start, EndDate: start.AddDate(0, 0, 2)},
+		})
+
+		resp, err := svc.DraftRoster(ctx, req)
+
+		require.NoError(t, err)
+		perDay := make(map[string]bool)
+		workDays := make(map[uint]int)
+		for _, a := range resp.Proposed {
+			key := fmt.Sprintf("%d|%s", a.EmployeeID, a.ShiftDate)
+			assert.False(t, perDay[key], "employee %d has two shifts on %s", a.EmployeeID, a.ShiftDate)
+			perDay[key] = true
+			workDays[a.EmployeeID]++
+			assert.NotEqual(t, uint(9), a.EmployeeID)
+			if a.EmployeeID == 2 {
+				day, _ := time.Parse("2006-01-02", a.ShiftDate)
+				assert.True(t, day.After(start.AddDate(0, 0, 2)), "employee 2 is on leave on %s", a.ShiftDate)
+			}
+		}
+		assert.Equal(t, 5, workDays[1])
+		assert.Equal(t, 3, workDays[2])
+		assert.Equal(t, 5, workDays[3])
+
+		require.Len(t, resp.Employees, 3)
+		assert.Equal(t, employeeV1.RosterEmployeeSummary{EmployeeID: 2, Name: "Bojan B", ProfileType: "Medic", AvailableDays: 4, TargetDays: 3, ProposedShifts: 3, WorkDays: 3}, resp.Employees[1])
+		assert.Len(t, resp.Slots, 7*3*2)
+		assert.Equal(t, 7*3*(2+4)-13, resp.UnfilledSlots)
+	})
+
+	t.Run("it keeps existing staff and respects capacity and rest days", func(t *testing.T) {
+		svc, emplRepo, shiftRepo := newLeaveService(t)
+		// employee 2 works a double on the first day, so the second day is a rest day
+		expectRosterData(emplRepo, shiftRepo, []model.Employee{
+			{ID: 1, ProfileType: model.Medic},
+			{ID: 2, ProfileType: model.Medic},
+			{ID: 3, ProfileType: model.Medic},
+		}, []repositories.ShiftAssignmentRow{
+			{EmployeeID: 2, ProfileType: model.Medic, ShiftID: 10, ShiftDate: start, ShiftType: 1},
+			{EmployeeID: 2, ProfileType: model.Medic, ShiftID: 11, ShiftDate: start, ShiftType: 2},
+			{EmployeeID: 7, ProfileType: model.Medic, ShiftID: 10, ShiftDate: start, ShiftType: 1},
+		}, nil)
+
+		resp, err := svc.DraftRoster(ctx, employeeV1.RosterDraftRequest{StartDate: req.StartDate, EndDate: start.AddDate(0, 0, 1).Format("2006-01-02")})
+
+		require.NoError(t, err)
+		first := resp.Slots[0]
+		assert.Equal(t, employeeV1.RosterSlot{ShiftDate: req.StartDate, ShiftType: 1, ProfileType: "Medic", Capacity: 2, Current: []uint{2, 7}, Proposed: []uint{}}, first)
+		for _, a := range resp.Proposed {
+			assert.NotEqual(t, uint(2), a.EmployeeID, "employee 2 must rest after the double")
+			assert.False(t, a.ShiftDate == req.StartDate && a.ShiftType == 1, "full slot got more staff")
+		}
+	})
+
+	t.Run("it rejects an invalid period", func(t *testing.T) {
+		svc, _, _ := newLeaveService(t)
+
+		_, err := svc.DraftRoster(ctx, employeeV1.RosterDraftRequest{StartDate: req.EndDate, EndDate: req.StartDate})
+		assert.Equal(t, ErrorRosterInvalidRange, appErrorCode(t, err))
+
+		_, err = svc.DraftRoster(ctx, employeeV1.RosterDraftRequest{StartDate: req.StartDate, EndDate: today.AddDate(0, 4, 0).Format("2006-01-02")})
+		assert.Equal(t, ErrorRosterInvalidRange, appErrorCode(t, err))
+	})
+}
+
+func TestShiftService_CommitRoster(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	day := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 2)
+	date := day.Format("2006-01-02")
+	staff := []model.Employee{{ID: 1, ProfileType: model.Medic}, {ID: 2, ProfileType: model.Technical}}
+
+	t.Run("it creates all assignments in one call", func(t *testing.T) {
+		svc, emplRepo, shiftRepo := newLeaveService(t)
+		expectRosterData(emplRepo, shiftRepo, staff, nil, nil)
+		shiftRepo.EXPECT().CreateRosterAssignments(gomock.Any(), []repositories.ShiftAssignmentRow{
+			{EmployeeID: 1, ProfileType: model.Medic, ShiftDate: day, ShiftType: 1},
+			{EmployeeID: 2, ProfileType: model.Technical, ShiftDate: day, ShiftType: 2},
+		}).Return(nil)
+
+		resp, err := svc.CommitRoster(ctx, employeeV1.RosterCommitRequest{Assignments: []employeeV1.RosterAssignment{
+			{EmployeeID: 2, ShiftDate: date, ShiftType: 2},
+			{EmployeeID: 1, ShiftDate: date, ShiftType: 1},
+		}})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, resp.Created)
+	})
+
+	conflicts := []struct {
+		name        string
+		rows        []repositories.ShiftAssignmentRow
+		leaves      []model.LeaveRequest
+		assignments []employeeV1.RosterAssignment
+		reason      string
+	}{
+		{
+			name:        "a triple shift",
+			assignments: []employeeV1.RosterAssignment{{EmployeeID: 1, ShiftDate: date, ShiftType: 1}, {EmployeeID: 1, ShiftDate: date, ShiftType: 2}, {EmployeeID: 1, ShiftDate: date, ShiftType: 3}},
+			reason:      model.ErrorConsecutiveShiftsLimit,
+		},
+		{
+			name:        "a shift during leave",
+			leaves:      []model.LeaveRequest{{EmployeeID: 1, Status: employeeV1.LeaveApproved, StartDate: day, EndDate: day}},
+			assignments: []employeeV1.RosterAssignment{{EmployeeID: 1, ShiftDate: date, ShiftType: 1}},
+			reason:      ErrorEmployeeOnLeave,
+		},
+		{
+			name:        "a full shift",
+			rows:        []repositories.ShiftAssignmentRow{{EmployeeID: 5, ProfileType: model.Medic, ShiftDate: day, ShiftType: 1}, {EmployeeID: 6, ProfileType: model.Medic, ShiftDate: day, ShiftType: 1}},
+			assignments: []employeeV1.RosterAssignment{{EmployeeID: 1, ShiftDate: date, ShiftType: 1}},
+			reason:      "SHIFT_ERRORS.CAPACITY_FULL",
+		},
+		{
+			name:        "a shift the employee already has",
+			rows:        []repositories.ShiftAssignmentRow{{EmployeeID: 1, ProfileType: model.Medic, ShiftDate: day, ShiftType: 1}},
+			assignments: []employeeV1.RosterAssignment{{EmployeeID: 1, ShiftDate: date, ShiftType: 1}},
+			reason:      "SHIFT_ERRORS.ALREADY_ASSIGNED",
+		},
+	}
+	for _, tt := range conflicts {
+		t.Run("it saves nothing for "+tt.name, func(t *testing.T) {
+			svc, emplRepo, shiftRepo := newLeaveService(t)
+			expectRosterData(emplRepo, shiftRepo, staff, tt.rows, tt.leaves)
+
+			_, err := svc.CommitRoster(ctx, employeeV1.RosterCommitRequest{Assignments: tt.assignments})
+
+			var appErr *commonv1.AppError
+			require.True(t, errors.As(err, &appErr))
+			assert.Equal(t, ErrorRosterConflict, appErr.Code)
+			assert.Equal(t, tt.reason, appErr.Details["reason"])
+		})
+	}
+
+	t.Run("it rejects employees who are not rostered", func(t *testing.T) {
+		svc, emplRepo, shiftRepo := newLeaveService(t)
+		expectRosterData(emplRepo, shiftRepo, staff, nil, nil)
+
+		_, err := svc.CommitRoster(ctx, employeeV1.RosterCommitRequest{Assignments: []employeeV1.RosterAssignment{{EmployeeID: 42, ShiftDate: date, ShiftType: 1}}})
+
+		assert.Equal(t, ErrorRosterInvalidAssignment, appErrorCode(t, err))
+	})
+
+	t.Run("it reports shifts that changed since the draft", func(t *testing.T) {
+		svc, emplRepo, shiftRepo := newLeaveService(t)
+		expectRosterData(emplRepo, shiftRepo, staff, nil, nil)
+		shiftRepo.EXPECT().CreateRosterAssignments(gomock.Any(), gomock.Any()).Return(fmt.Errorf("shift 3 for Medic: %w", model.ErrCapacityReached))
+
+		_, err := svc.CommitRoster(ctx, employeeV1.RosterCommitRequest{Assignments: []employeeV1.RosterAssignment{{EmployeeID: 1, ShiftDate: date, ShiftType: 1}}})
+
+		assert.Equal(t, ErrorRosterConflict, appErrorCode(t, err))
+	})
+
+	t.Run("it rejects a past shift", func(t *testing.T) {
+		svc, _, _ := newLeaveService(t)
+
+		_, err := svc.CommitRoster(ctx, employeeV1.RosterCommitRequest{Assignments: []employeeV1.RosterAssignment{{EmployeeID: 1, ShiftDate: "2020-01-01", ShiftType: 1}}})
+
+		assert.Equal(t, ErrorRosterInvalidRange, appErrorCode(t, err))
+	})
+}
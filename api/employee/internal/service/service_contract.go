@@ -1,6 +1,6 @@
 package service
 
-//go:generate mockgen -source=service.go -destination=service_gomock.go -package=service -imports=gomock=go.uber.org/mock/gomock
+//go:generate mockgen -source=service_contract.go -destination=service_gomock.go -package=service -imports=gomock=go.uber.org/mock/gomock
 
 import (
 	"context"
@@ -15,11 +15,38 @@ type ShiftService interface {
 	AssignShift(ctx context.Context, employeeID uint, req employeeV1.AssignShiftRequest) (*employeeV1.AssignShiftResponse, error)
 	GetShifts(ctx context.Context, employeeID uint) ([]employeeV1.ShiftResponse, error)
 	GetShiftsAvailability(ctx context.Context, employeeID uint, days int) (*employeeV1.ShiftAvailabilityResponse, error)
-	RemoveShift(ctx context.Context, employeeID uint, req employeeV1.RemoveShiftRequest) error
+	RemoveShift(ctx context.Context, employeeID uint, req employeeV1.RemoveShiftRequest, force bool) error
 	GetOnCallEmployees(ctx context.Context, currentTime time.Time, shiftBuffer time.Duration) ([]employeeV1.EmployeeResponse, error)
 	GetShiftWarnings(ctx context.Context, employeeID uint) ([]string, error)
 
-	GetAdminShiftsAvailability(ctx context.Context, days int) (*employeeV1.ShiftAvailabilityResponse, error)
+	// Shift swap operations
+	OfferShift(ctx context.Context, employeeID uint, req employeeV1.OfferShiftRequest) (*employeeV1.ShiftSwapResponse, error)
+	ListShiftSwaps(ctx context.Context, actorID uint, isAdmin bool, status employeeV1.ShiftSwapStatus) ([]employeeV1.ShiftSwapResponse, error)
+	AcceptShiftSwap(ctx context.Context, swapID, employeeID uint) (*employeeV1.ShiftSwapResponse, error)
+	ProposeShiftSwap(ctx context.Context, swapID, employeeID uint, req employeeV1.ProposeShiftSwapRequest) (*employeeV1.ShiftSwapResponse, error)
+	ConfirmShiftSwap(ctx context.Context, swapID, employeeID uint, accept bool) (*employeeV1.ShiftSwapResponse, error)
+	CancelShiftSwap(ctx context.Context, swapID, actorID uint, isAdmin bool) (*employeeV1.ShiftSwapResponse, error)
+	ReviewShiftSwap(ctx context.Context, swapID, adminID uint, approve bool) (*employeeV1.ShiftSwapResponse, error)
+
+	// Leave operations
+	RequestLeave(ctx context.Context, employeeID uint, req employeeV1.LeaveRequestCreate) (*employeeV1.LeaveRequestResponse, error)
+	ListLeaveRequests(ctx context.Context, actorID uint, isAdmin bool, employeeID uint, status employeeV1.LeaveStatus) ([]employeeV1.LeaveRequestResponse, error)
+	CancelLeaveRequest(ctx context.Context, leaveID, actorID uint, isAdmin bool) (*employeeV1.LeaveRequestResponse, error)
+	ReviewLeaveRequest(ctx context.Context, leaveID, adminID uint, approve bool, note string) (*employeeV1.LeaveRequestResponse, error)
+
+	// Roster operations
+	DraftRoster(ctx context.Context, req employeeV1.RosterDraftRequest) (*employeeV1.RosterDraftResponse, error)
+	CommitRoster(ctx context.Context, req employeeV1.RosterCommitRequest) (*employeeV1.RosterCommitResponse, error)
+
+	// Shift schedule operations
+	ListShiftTemplates(ctx context.Context) ([]employeeV1.ShiftTemplateResponse, error)
+	CreateShiftTemplate(ctx context.Context, req employeeV1.ShiftTemplateRequest) (*employeeV1.ShiftTemplateResponse, error)
+	DeleteShiftTemplate(ctx context.Context, id uint) error
+	ListShiftCapacities(ctx context.Context) ([]employeeV1.ShiftCapacityResponse, error)
+	CreateShiftCapacity(ctx context.Context, req employeeV1.ShiftCapacityRequest) (*employeeV1.ShiftCapacityResponse, error)
+	DeleteShiftCapacity(ctx context.Context, id uint) error
+
+	GetAdminShiftsAvailability(ctx context.Context, days int, profileType model.ProfileType) (*employeeV1.AdminShiftAvailabilityResponse, error)
 }
 
 // EmployeeService handles employee CRUD operations
@@ -29,8 +56,10 @@ type EmployeeService interface {
 	LogoutEmployee(ctx context.Context, tokenID string, expiresAt time.Time) error
 	ListEmployees(ctx context.Context) ([]employeeV1.EmployeeResponse, error)
 	UpdateEmployee(ctx context.Context, employeeID uint, req employeeV1.EmployeeUpdateRequest) (*employeeV1.EmployeeResponse, error)
-	DeleteEmployee(ctx context.Context, employeeID uint) error
+	UpdatePosition(ctx context.Context, employeeID uint, req employeeV1.EmployeePositionRequest) error
+	DeleteEmployee(ctx context.Context, employeeID uint, force bool) error
 	GetEmployeeByID(ctx context.Context, employeeID uint) (*model.Employee, error)
 	GetEmployeeByUsername(ctx context.Context, username string) (*model.Employee, error)
+	CheckActiveEmergencies(ctx context.Context, employeeID uint) (*employeeV1.ActiveEmergenciesResponse, error)
 	ResetAllData(ctx context.Context) error
 }
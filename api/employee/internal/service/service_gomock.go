@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: service.go
+// Source: service_contract.go
 //
 // Generated by this command:
 //
-//	mockgen -source=service.go -destination=service_gomock.go -package=service -imports=gomock=go.uber.org/mock/gomock
+//	mockgen -source=service_contract.go -destination=service_gomock.go -package=service -imports=gomock=go.uber.org/mock/gomock
 //
 
 // Package service is a generated GoMock package.
@@ -43,6 +43,21 @@ func (m *MockShiftService) EXPECT() *MockShiftServiceMockRecorder {
 	return m.recorder
 }
 
+// AcceptShiftSwap mocks base method.
+func (m *MockShiftService) AcceptShiftSwap(ctx context.Context, swapID, employeeID uint) (*v1.ShiftSwapResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptShiftSwap", ctx, swapID, employeeID)
+	ret0, _ := ret[0].(*v1.ShiftSwapResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptShiftSwap indicates an expected call of AcceptShiftSwap.
+func (mr *MockShiftServiceMockRecorder) AcceptShiftSwap(ctx, swapID, employeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptShiftSwap", reflect.TypeOf((*MockShiftService)(nil).AcceptShiftSwap), ctx, swapID, employeeID)
+}
+
 // AssignShift mocks base method.
 func (m *MockShiftService) AssignShift(ctx context.Context, employeeID uint, req v1.AssignShiftRequest) (*v1.AssignShiftResponse, error) {
 	m.ctrl.T.Helper()
@@ -58,19 +73,152 @@ func (mr *MockShiftServiceMockRecorder) AssignShift(ctx, employeeID, req any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignShift", reflect.TypeOf((*MockShiftService)(nil).AssignShift), ctx, employeeID, req)
 }
 
+// CancelLeaveRequest mocks base method.
+func (m *MockShiftService) CancelLeaveRequest(ctx context.Context, leaveID, actorID uint, isAdmin bool) (*v1.LeaveRequestResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelLeaveRequest", ctx, leaveID, actorID, isAdmin)
+	ret0, _ := ret[0].(*v1.LeaveRequestResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelLeaveRequest indicates an expected call of CancelLeaveRequest.
+func (mr *MockShiftServiceMockRecorder) CancelLeaveRequest(ctx, leaveID, actorID, isAdmin any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelLeaveRequest", reflect.TypeOf((*MockShiftService)(nil).CancelLeaveRequest), ctx, leaveID, actorID, isAdmin)
+}
+
+// CancelShiftSwap mocks base method.
+func (m *MockShiftService) CancelShiftSwap(ctx context.Context, swapID, actorID uint, isAdmin bool) (*v1.ShiftSwapResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelShiftSwap", ctx, swapID, actorID, isAdmin)
+	ret0, _ := ret[0].(*v1.ShiftSwapResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelShiftSwap indicates an expected call of CancelShiftSwap.
+func (mr *MockShiftServiceMockRecorder) CancelShiftSwap(ctx, swapID, actorID, isAdmin any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelShiftSwap", reflect.TypeOf((*MockShiftService)(nil).CancelShiftSwap), ctx, swapID, actorID, isAdmin)
+}
+
+// CommitRoster mocks base method.
+func (m *MockShiftService) CommitRoster(ctx context.Context, req v1.RosterCommitRequest) (*v1.RosterCommitResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitRoster", ctx, req)
+	ret0, _ := ret[0].(*v1.RosterCommitResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommitRoster indicates an expected call of CommitRoster.
+func (mr *MockShiftServiceMockRecorder) CommitRoster(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitRoster", reflect.TypeOf((*MockShiftService)(nil).CommitRoster), ctx, req)
+}
+
+// ConfirmShiftSwap mocks base method.
+func (m *MockShiftService) ConfirmShiftSwap(ctx context.Context, swapID, employeeID uint, accept bool) (*v1.ShiftSwapResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmShiftSwap", ctx, swapID, employeeID, accept)
+	ret0, _ := ret[0].(*v1.ShiftSwapResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmShiftSwap indicates an expected call of ConfirmShiftSwap.
+func (mr *MockShiftServiceMockRecorder) ConfirmShiftSwap(ctx, swapID, employeeID, accept any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmShiftSwap", reflect.TypeOf((*MockShiftService)(nil).ConfirmShiftSwap), ctx, swapID, employeeID, accept)
+}
+
+// CreateShiftCapacity mocks base method.
+func (m *MockShiftService) CreateShiftCapacity(ctx context.Context, req v1.ShiftCapacityRequest) (*v1.ShiftCapacityResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShiftCapacity", ctx, req)
+	ret0, _ := ret[0].(*v1.ShiftCapacityResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateShiftCapacity indicates an expected call of CreateShiftCapacity.
+func (mr *MockShiftServiceMockRecorder) CreateShiftCapacity(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShiftCapacity", reflect.TypeOf((*MockShiftService)(nil).CreateShiftCapacity), ctx, req)
+}
+
+// CreateShiftTemplate mocks base method.
+func (m *MockShiftService) CreateShiftTemplate(ctx context.Context, req v1.ShiftTemplateRequest) (*v1.ShiftTemplateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShiftTemplate", ctx, req)
+	ret0, _ := ret[0].(*v1.ShiftTemplateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateShiftTemplate indicates an expected call of CreateShiftTemplate.
+func (mr *MockShiftServiceMockRecorder) CreateShiftTemplate(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShiftTemplate", reflect.TypeOf((*MockShiftService)(nil).CreateShiftTemplate), ctx, req)
+}
+
+// DeleteShiftCapacity mocks base method.
+func (m *MockShiftService) DeleteShiftCapacity(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteShiftCapacity", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteShiftCapacity indicates an expected call of DeleteShiftCapacity.
+func (mr *MockShiftServiceMockRecorder) DeleteShiftCapacity(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShiftCapacity", reflect.TypeOf((*MockShiftService)(nil).DeleteShiftCapacity), ctx, id)
+}
+
+// DeleteShiftTemplate mocks base method.
+func (m *MockShiftService) DeleteShiftTemplate(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteShiftTemplate", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteShiftTemplate indicates an expected call of DeleteShiftTemplate.
+func (mr *MockShiftServiceMockRecorder) DeleteShiftTemplate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShiftTemplate", reflect.TypeOf((*MockShiftService)(nil).DeleteShiftTemplate), ctx, id)
+}
+
+// DraftRoster mocks base method.
+func (m *MockShiftService) DraftRoster(ctx context.Context, req v1.RosterDraftRequest) (*v1.RosterDraftResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DraftRoster", ctx, req)
+	ret0, _ := ret[0].(*v1.RosterDraftResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DraftRoster indicates an expected call of DraftRoster.
+func (mr *MockShiftServiceMockRecorder) DraftRoster(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DraftRoster", reflect.TypeOf((*MockShiftService)(nil).DraftRoster), ctx, req)
+}
+
 // GetAdminShiftsAvailability mocks base method.
-func (m *MockShiftService) GetAdminShiftsAvailability(ctx context.Context, days int) (*v1.ShiftAvailabilityResponse, error) {
+func (m *MockShiftService) GetAdminShiftsAvailability(ctx context.Context, days int, profileType model.ProfileType) (*v1.AdminShiftAvailabilityResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAdminShiftsAvailability", ctx, days)
-	ret0, _ := ret[0].(*v1.ShiftAvailabilityResponse)
+	ret := m.ctrl.Call(m, "GetAdminShiftsAvailability", ctx, days, profileType)
+	ret0, _ := ret[0].(*v1.AdminShiftAvailabilityResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAdminShiftsAvailability indicates an expected call of GetAdminShiftsAvailability.
-func (mr *MockShiftServiceMockRecorder) GetAdminShiftsAvailability(ctx, days any) *gomock.Call {
+func (mr *MockShiftServiceMockRecorder) GetAdminShiftsAvailability(ctx, days, profileType any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdminShiftsAvailability", reflect.TypeOf((*MockShiftService)(nil).GetAdminShiftsAvailability), ctx, days)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdminShiftsAvailability", reflect.TypeOf((*MockShiftService)(nil).GetAdminShiftsAvailability), ctx, days, profileType)
 }
 
 // GetOnCallEmployees mocks base method.
@@ -133,340 +281,336 @@ func (mr *MockShiftServiceMockRecorder) GetShiftsAvailability(ctx, employeeID, d
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShiftsAvailability", reflect.TypeOf((*MockShiftService)(nil).GetShiftsAvailability), ctx, employeeID, days)
 }
 
-// RemoveShift mocks base method.
-func (m *MockShiftService) RemoveShift(ctx context.Context, employeeID uint, req v1.RemoveShiftRequest) error {
+// ListLeaveRequests mocks base method.
+func (m *MockShiftService) ListLeaveRequests(ctx context.Context, actorID uint, isAdmin bool, employeeID uint, status v1.LeaveStatus) ([]v1.LeaveRequestResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveShift", ctx, employeeID, req)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListLeaveRequests", ctx, actorID, isAdmin, employeeID, status)
+	ret0, _ := ret[0].([]v1.LeaveRequestResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// RemoveShift indicates an expected call of RemoveShift.
-func (mr *MockShiftServiceMockRecorder) RemoveShift(ctx, employeeID, req any) *gomock.Call {
+// ListLeaveRequests indicates an expected call of ListLeaveRequests.
+func (mr *MockShiftServiceMockRecorder) ListLeaveRequests(ctx, actorID, isAdmin, employeeID, status any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveShift", reflect.TypeOf((*MockShiftService)(nil).RemoveShift), ctx, employeeID, req)
-}
-
-// MockEmployeeService is a mock of EmployeeService interface.
-type MockEmployeeService struct {
-	ctrl     *gomock.Controller
-	recorder *MockEmployeeServiceMockRecorder
-	isgomock struct{}
-}
-
-// MockEmployeeServiceMockRecorder is the mock recorder for MockEmployeeService.
-type MockEmployeeServiceMockRecorder struct {
-	mock *MockEmployeeService
-}
-
-// NewMockEmployeeService creates a new mock instance.
-func NewMockEmployeeService(ctrl *gomock.Controller) *MockEmployeeService {
-	mock := &MockEmployeeService{ctrl: ctrl}
-	mock.recorder = &MockEmployeeServiceMockRecorder{mock}
-	return mock
-}
-
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockEmployeeService) EXPECT() *MockEmployeeServiceMockRecorder {
-	return m.recorder
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLeaveRequests", reflect.TypeOf((*MockShiftService)(nil).ListLeaveRequests), ctx, actorID, isAdmin, employeeID, status)
 }
 
-// DeleteEmployee mocks base method.
-func (m *MockEmployeeService) DeleteEmployee(ctx context.Context, employeeID uint) error {
+// ListShiftCapacities mocks base method.
+func (m *MockShiftService) ListShiftCapacities(ctx context.Context) ([]v1.ShiftCapacityResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteEmployee", ctx, employeeID)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListShiftCapacities", ctx)
+	ret0, _ := ret[0].([]v1.ShiftCapacityResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteEmployee indicates an expected call of DeleteEmployee.
-func (mr *MockEmployeeServiceMockRecorder) DeleteEmployee(ctx, employeeID any) *gomock.Call {
+// ListShiftCapacities indicates an expected call of ListShiftCapacities.
+func (mr *MockShiftServiceMockRecorder) ListShiftCapacities(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmployee", reflect.TypeOf((*MockEmployeeService)(nil).DeleteEmployee), ctx, employeeID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShiftCapacities", reflect.TypeOf((*MockShiftService)(nil).ListShiftCapacities), ctx)
 }
 
-// GetEmployeeByID mocks base method.
-func (m *MockEmployeeService) GetEmployeeByID(ctx context.Context, employeeID uint) (*model.Employee, error) {
+// ListShiftSwaps mocks base method.
+func (m *MockShiftService) ListShiftSwaps(ctx context.Context, actorID uint, isAdmin bool, status v1.ShiftSwapStatus) ([]v1.ShiftSwapResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEmployeeByID", ctx, employeeID)
-	ret0, _ := ret[0].(*model.Employee)
+	ret := m.ctrl.Call(m, "ListShiftSwaps", ctx, actorID, isAdmin, status)
+	ret0, _ := ret[0].([]v1.ShiftSwapResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetEmployeeByID indicates an expected call of GetEmployeeByID.
-func (mr *MockEmployeeServiceMockRecorder) GetEmployeeByID(ctx, employeeID any) *gomock.Call {
+// ListShiftSwaps indicates an expected call of ListShiftSwaps.
+func (mr *MockShiftServiceMockRecorder) ListShiftSwaps(ctx, actorID, isAdmin, status any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployeeByID", reflect.TypeOf((*MockEmployeeService)(nil).GetEmployeeByID), ctx, employeeID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShiftSwaps", reflect.TypeOf((*MockShiftService)(nil).ListShiftSwaps), ctx, actorID, isAdmin, status)
 }
 
-// GetEmployeeByUsername mocks base method.
-func (m *MockEmployeeService) GetEmployeeByUsername(ctx context.Context, username string) (*model.Employee, error) {
+// ListShiftTemplates mocks base method.
+func (m *MockShiftService) ListShiftTemplates(ctx context.Context) ([]v1.ShiftTemplateResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEmployeeByUsername", ctx, username)
-	ret0, _ := ret[0].(*model.Employee)
+	ret := m.ctrl.Call(m, "ListShiftTemplates", ctx)
+	ret0, _ := ret[0].([]v1.ShiftTemplateResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetEmployeeByUsername indicates an expected call of GetEmployeeByUsername.
-func (mr *MockEmployeeServiceMockRecorder) GetEmployeeByUsername(ctx, username any) *gomock.Call {
+// ListShiftTemplates indicates an expected call of ListShiftTemplates.
+func (mr *MockShiftServiceMockRecorder) ListShiftTemplates(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployeeByUsername", reflect.TypeOf((*MockEmployeeService)(nil).GetEmployeeByUsername), ctx, username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShiftTemplates", reflect.TypeOf((*MockShiftService)(nil).ListShiftTemplates), ctx)
 }
 
-// ListEmployees mocks base method.
-func (m *MockEmployeeService) ListEmployees(ctx context.Context) ([]v1.EmployeeResponse, error) {
+// OfferShift mocks base method.
+func (m *MockShiftService) OfferShift(ctx context.Context, employeeID uint, req v1.OfferShiftRequest) (*v1.ShiftSwapResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListEmployees", ctx)
-	ret0, _ := ret[0].([]v1.EmployeeResponse)
+	ret := m.ctrl.Call(m, "OfferShift", ctx, employeeID, req)
+	ret0, _ := ret[0].(*v1.ShiftSwapResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListEmployees indicates an expected call of ListEmployees.
-func (mr *MockEmployeeServiceMockRecorder) ListEmployees(ctx any) *gomock.Call {
+// OfferShift indicates an expected call of OfferShift.
+func (mr *MockShiftServiceMockRecorder) OfferShift(ctx, employeeID, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEmployees", reflect.TypeOf((*MockEmployeeService)(nil).ListEmployees), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OfferShift", reflect.TypeOf((*MockShiftService)(nil).OfferShift), ctx, employeeID, req)
 }
 
-// LoginEmployee mocks base method.
-func (m *MockEmployeeService) LoginEmployee(ctx context.Context, req v1.EmployeeLogin) (string, error) {
+// ProposeShiftSwap mocks base method.
+func (m *MockShiftService) ProposeShiftSwap(ctx context.Context, swapID, employeeID uint, req v1.ProposeShiftSwapRequest) (*v1.ShiftSwapResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LoginEmployee", ctx, req)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "ProposeShiftSwap", ctx, swapID, employeeID, req)
+	ret0, _ := ret[0].(*v1.ShiftSwapResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// LoginEmployee indicates an expected call of LoginEmployee.
-func (mr *MockEmployeeServiceMockRecorder) LoginEmployee(ctx, req any) *gomock.Call {
+// ProposeShiftSwap indicates an expected call of ProposeShiftSwap.
+func (mr *MockShiftServiceMockRecorder) ProposeShiftSwap(ctx, swapID, employeeID, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginEmployee", reflect.TypeOf((*MockEmployeeService)(nil).LoginEmployee), ctx, req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposeShiftSwap", reflect.TypeOf((*MockShiftService)(nil).ProposeShiftSwap), ctx, swapID, employeeID, req)
 }
 
-// LogoutEmployee mocks base method.
-func (m *MockEmployeeService) LogoutEmployee(ctx context.Context, tokenID string, expiresAt time.Time) error {
+// RemoveShift mocks base method.
+func (m *MockShiftService) RemoveShift(ctx context.Context, employeeID uint, req v1.RemoveShiftRequest, force bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LogoutEmployee", ctx, tokenID, expiresAt)
+	ret := m.ctrl.Call(m, "RemoveShift", ctx, employeeID, req, force)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// LogoutEmployee indicates an expected call of LogoutEmployee.
-func (mr *MockEmployeeServiceMockRecorder) LogoutEmployee(ctx, tokenID, expiresAt any) *gomock.Call {
+// RemoveShift indicates an expected call of RemoveShift.
+func (mr *MockShiftServiceMockRecorder) RemoveShift(ctx, employeeID, req, force any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutEmployee", reflect.TypeOf((*MockEmployeeService)(nil).LogoutEmployee), ctx, tokenID, expiresAt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveShift", reflect.TypeOf((*MockShiftService)(nil).RemoveShift), ctx, employeeID, req, force)
 }
 
-// RegisterEmployee mocks base method.
-func (m *MockEmployeeService) RegisterEmployee(ctx context.Context, req v1.EmployeeCreateRequest) (*v1.EmployeeResponse, error) {
+// RequestLeave mocks base method.
+func (m *MockShiftService) RequestLeave(ctx context.Context, employeeID uint, req v1.LeaveRequestCreate) (*v1.LeaveRequestResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RegisterEmployee", ctx, req)
-	ret0, _ := ret[0].(*v1.EmployeeResponse)
+	ret := m.ctrl.Call(m, "RequestLeave", ctx, employeeID, req)
+	ret0, _ := ret[0].(*v1.LeaveRequestResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// RegisterEmployee indicates an expected call of RegisterEmployee.
-func (mr *MockEmployeeServiceMockRecorder) RegisterEmployee(ctx, req any) *gomock.Call {
+// RequestLeave indicates an expected call of RequestLeave.
+func (mr *MockShiftServiceMockRecorder) RequestLeave(ctx, employeeID, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterEmployee", reflect.TypeOf((*MockEmployeeService)(nil).RegisterEmployee), ctx, req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestLeave", reflect.TypeOf((*MockShiftService)(nil).RequestLeave), ctx, employeeID, req)
 }
 
-// ResetAllData mocks base method.
-func (m *MockEmployeeService) ResetAllData(ctx context.Context) error {
+// ReviewLeaveRequest mocks base method.
+func (m *MockShiftService) ReviewLeaveRequest(ctx context.Context, leaveID, adminID uint, approve bool, note string) (*v1.LeaveRequestResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ResetAllData", ctx)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ReviewLeaveRequest", ctx, leaveID, adminID, approve, note)
+	ret0, _ := ret[0].(*v1.LeaveRequestResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// ResetAllData indicates an expected call of ResetAllData.
-func (mr *MockEmployeeServiceMockRecorder) ResetAllData(ctx any) *gomock.Call {
+// ReviewLeaveRequest indicates an expected call of ReviewLeaveRequest.
+func (mr *MockShiftServiceMockRecorder) ReviewLeaveRequest(ctx, leaveID, adminID, approve, note any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetAllData", reflect.TypeOf((*MockEmployeeService)(nil).ResetAllData), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewLeaveRequest", reflect.TypeOf((*MockShiftService)(nil).ReviewLeaveRequest), ctx, leaveID, adminID, approve, note)
 }
 
-// UpdateEmployee mocks base method.
-func (m *MockEmployeeService) UpdateEmployee(ctx context.Context, employeeID uint, req v1.EmployeeUpdateRequest) (*v1.EmployeeResponse, error) {
+// ReviewShiftSwap mocks base method.
+func (m *MockShiftService) ReviewShiftSwap(ctx context.Context, swapID, adminID uint, approve bool) (*v1.ShiftSwapResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateEmployee", ctx, employeeID, req)
-	ret0, _ := ret[0].(*v1.EmployeeResponse)
+	ret := m.ctrl.Call(m, "ReviewShiftSwap", ctx, swapID, adminID, approve)
+	ret0, _ := ret[0].(*v1.ShiftSwapResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateEmployee indicates an expected call of UpdateEmployee.
-func (mr *MockEmployeeServiceMockRecorder) UpdateEmployee(ctx, employeeID, req any) *gomock.Call {
+// ReviewShiftSwap indicates an expected call of ReviewShiftSwap.
+func (mr *MockShiftServiceMockRecorder) ReviewShiftSwap(ctx, swapID, adminID, approve any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmployee", reflect.TypeOf((*MockEmployeeService)(nil).UpdateEmployee), ctx, employeeID, req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewShiftSwap", reflect.TypeOf((*MockShiftService)(nil).ReviewShiftSwap), ctx, swapID, adminID, approve)
 }
 
-// MockEmployeeServiceWithBlacklist is a mock of EmployeeServiceWithBlacklist interface.
-type MockEmployeeServiceWithBlacklist struct {
+// MockEmployeeService is a mock of EmployeeService interface.
+type MockEmployeeService struct {
 	ctrl     *gomock.Controller
-	recorder *MockEmployeeServiceWithBlacklistMockRecorder
+	recorder *MockEmployeeServiceMockRecorder
 	isgomock struct{}
 }
 
-// MockEmployeeServiceWithBlacklistMockRecorder is the mock recorder for MockEmployeeServiceWithBlacklist.
-type MockEmployeeServiceWithBlacklistMockRecorder struct {
-	mock *MockEmployeeServiceWithBlacklist
+// MockEmployeeServiceMockRecorder is the mock recorder for MockEmployeeService.
+type MockEmployeeServiceMockRecorder struct {
+	mock *MockEmployeeService
 }
 
-// NewMockEmployeeServiceWithBlacklist creates a new mock instance.
-func NewMockEmployeeServiceWithBlacklist(ctrl *gomock.Controller) *MockEmployeeServiceWithBlacklist {
-	mock := &MockEmployeeServiceWithBlacklist{ctrl: ctrl}
-	mock.recorder = &MockEmployeeServiceWithBlacklistMockRecorder{mock}
+// NewMockEmployeeService creates a new mock instance.
+func NewMockEmployeeService(ctrl *gomock.Controller) *MockEmployeeService {
+	mock := &MockEmployeeService{ctrl: ctrl}
+	mock.recorder = &MockEmployeeServiceMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockEmployeeServiceWithBlacklist) EXPECT() *MockEmployeeServiceWithBlacklistMockRecorder {
+func (m *MockEmployeeService) EXPECT() *MockEmployeeServiceMockRecorder {
 	return m.recorder
 }
 
+// CheckActiveEmergencies mocks base method.
+func (m *MockEmployeeService) CheckActiveEmergencies(ctx context.Context, employeeID uint) (*v1.ActiveEmergenciesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckActiveEmergencies", ctx, employeeID)
+	ret0, _ := ret[0].(*v1.ActiveEmergenciesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckActiveEmergencies indicates an expected call of CheckActiveEmergencies.
+func (mr *MockEmployeeServiceMockRecorder) CheckActiveEmergencies(ctx, employeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckActiveEmergencies", reflect.TypeOf((*MockEmployeeService)(nil).CheckActiveEmergencies), ctx, employeeID)
+}
+
 // DeleteEmployee mocks base method.
-func (m *MockEmployeeServiceWithBlacklist) DeleteEmployee(employeeID uint) error {
+func (m *MockEmployeeService) DeleteEmployee(ctx context.Context, employeeID uint, force bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteEmployee", employeeID)
+	ret := m.ctrl.Call(m, "DeleteEmployee", ctx, employeeID, force)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteEmployee indicates an expected call of DeleteEmployee.
-func (mr *MockEmployeeServiceWithBlacklistMockRecorder) DeleteEmployee(employeeID any) *gomock.Call {
+func (mr *MockEmployeeServiceMockRecorder) DeleteEmployee(ctx, employeeID, force any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmployee", reflect.TypeOf((*MockEmployeeServiceWithBlacklist)(nil).DeleteEmployee), employeeID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmployee", reflect.TypeOf((*MockEmployeeService)(nil).DeleteEmployee), ctx, employeeID, force)
 }
 
 // GetEmployeeByID mocks base method.
-func (m *MockEmployeeServiceWithBlacklist) GetEmployeeByID(employeeID uint) (*model.Employee, error) {
+func (m *MockEmployeeService) GetEmployeeByID(ctx context.Context, employeeID uint) (*model.Employee, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEmployeeByID", employeeID)
+	ret := m.ctrl.Call(m, "GetEmployeeByID", ctx, employeeID)
 	ret0, _ := ret[0].(*model.Employee)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetEmployeeByID indicates an expected call of GetEmployeeByID.
-func (mr *MockEmployeeServiceWithBlacklistMockRecorder) GetEmployeeByID(employeeID any) *gomock.Call {
+func (mr *MockEmployeeServiceMockRecorder) GetEmployeeByID(ctx, employeeID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployeeByID", reflect.TypeOf((*MockEmployeeServiceWithBlacklist)(nil).GetEmployeeByID), employeeID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployeeByID", reflect.TypeOf((*MockEmployeeService)(nil).GetEmployeeByID), ctx, employeeID)
 }
 
 // GetEmployeeByUsername mocks base method.
-func (m *MockEmployeeServiceWithBlacklist) GetEmployeeByUsername(username string) (*model.Employee, error) {
+func (m *MockEmployeeService) GetEmployeeByUsername(ctx context.Context, username string) (*model.Employee, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEmployeeByUsername", username)
+	ret := m.ctrl.Call(m, "GetEmployeeByUsername", ctx, username)
 	ret0, _ := ret[0].(*model.Employee)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetEmployeeByUsername indicates an expected call of GetEmployeeByUsername.
-func (mr *MockEmployeeServiceWithBlacklistMockRecorder) GetEmployeeByUsername(username any) *gomock.Call {
+func (mr *MockEmployeeServiceMockRecorder) GetEmployeeByUsername(ctx, username any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployeeByUsername", reflect.TypeOf((*MockEmployeeServiceWithBlacklist)(nil).GetEmployeeByUsername), username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployeeByUsername", reflect.TypeOf((*MockEmployeeService)(nil).GetEmployeeByUsername), ctx, username)
 }
 
 // ListEmployees mocks base method.
-func (m *MockEmployeeServiceWithBlacklist) ListEmployees() ([]v1.EmployeeResponse, error) {
+func (m *MockEmployeeService) ListEmployees(ctx context.Context) ([]v1.EmployeeResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListEmployees")
+	ret := m.ctrl.Call(m, "ListEmployees", ctx)
 	ret0, _ := ret[0].([]v1.EmployeeResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListEmployees indicates an expected call of ListEmployees.
-func (mr *MockEmployeeServiceWithBlacklistMockRecorder) ListEmployees() *gomock.Call {
+func (mr *MockEmployeeServiceMockRecorder) ListEmployees(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEmployees", reflect.TypeOf((*MockEmployeeServiceWithBlacklist)(nil).ListEmployees))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEmployees", reflect.TypeOf((*MockEmployeeService)(nil).ListEmployees), ctx)
 }
 
 // LoginEmployee mocks base method.
-func (m *MockEmployeeServiceWithBlacklist) LoginEmployee(req v1.EmployeeLogin) (string, error) {
+func (m *MockEmployeeService) LoginEmployee(ctx context.Context, req v1.EmployeeLogin) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LoginEmployee", req)
+	ret := m.ctrl.Call(m, "LoginEmployee", ctx, req)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // LoginEmployee indicates an expected call of LoginEmployee.
-func (mr *MockEmployeeServiceWithBlacklistMockRecorder) LoginEmployee(req any) *gomock.Call {
+func (mr *MockEmployeeServiceMockRecorder) LoginEmployee(ctx, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginEmployee", reflect.TypeOf((*MockEmployeeServiceWithBlacklist)(nil).LoginEmployee), req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginEmployee", reflect.TypeOf((*MockEmployeeService)(nil).LoginEmployee), ctx, req)
 }
 
 // LogoutEmployee mocks base method.
-func (m *MockEmployeeServiceWithBlacklist) LogoutEmployee(tokenID string, expiresAt time.Time) error {
+func (m *MockEmployeeService) LogoutEmployee(ctx context.Context, tokenID string, expiresAt time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LogoutEmployee", tokenID, expiresAt)
+	ret := m.ctrl.Call(m, "LogoutEmployee", ctx, tokenID, expiresAt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // LogoutEmployee indicates an expected call of LogoutEmployee.
-func (mr *MockEmployeeServiceWithBlacklistMockRecorder) LogoutEmployee(tokenID, expiresAt any) *gomock.Call {
+func (mr *MockEmployeeServiceMockRecorder) LogoutEmployee(ctx, tokenID, expiresAt any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutEmployee", reflect.TypeOf((*MockEmployeeServiceWithBlacklist)(nil).LogoutEmployee), tokenID, expiresAt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutEmployee", reflect.TypeOf((*MockEmployeeService)(nil).LogoutEmployee), ctx, tokenID, expiresAt)
 }
 
 // RegisterEmployee mocks base method.
-func (m *MockEmployeeServiceWithBlacklist) RegisterEmployee(req v1.EmployeeCreateRequest) (*v1.EmployeeResponse, error) {
+func (m *MockEmployeeService) RegisterEmployee(ctx context.Context, req v1.EmployeeCreateRequest) (*v1.EmployeeResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RegisterEmployee", req)
+	ret := m.ctrl.Call(m, "RegisterEmployee", ctx, req)
 	ret0, _ := ret[0].(*v1.EmployeeResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RegisterEmployee indicates an expected call of RegisterEmployee.
-func (mr *MockEmployeeServiceWithBlacklistMockRecorder) RegisterEmployee(req any) *gomock.Call {
+func (mr *MockEmployeeServiceMockRecorder) RegisterEmployee(ctx, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterEmployee", reflect.TypeOf((*MockEmployeeServiceWithBlacklist)(nil).RegisterEmployee), req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterEmployee", reflect.TypeOf((*MockEmployeeService)(nil).RegisterEmployee), ctx, req)
 }
 
 // ResetAllData mocks base method.
-func (m *MockEmployeeServiceWithBlacklist) ResetAllData() error {
+func (m *MockEmployeeService) ResetAllData(ctx context.Context) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ResetAllData")
+	ret := m.ctrl.Call(m, "ResetAllData", ctx)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ResetAllData indicates an expected call of ResetAllData.
-func (mr *MockEmployeeServiceWithBlacklistMockRecorder) ResetAllData() *gomock.Call {
+func (mr *MockEmployeeServiceMockRecorder) ResetAllData(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetAllData", reflect.TypeOf((*MockEmployeeServiceWithBlacklist)(nil).ResetAllData))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetAllData", reflect.TypeOf((*MockEmployeeService)(nil).ResetAllData), ctx)
 }
 
-// SetTokenBlacklist mocks base method.
-func (m *MockEmployeeServiceWithBlacklist) SetTokenBlacklist(blacklist any) {
+// UpdateEmployee mocks base method.
+func (m *MockEmployeeService) UpdateEmployee(ctx context.Context, employeeID uint, req v1.EmployeeUpdateRequest) (*v1.EmployeeResponse, error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetTokenBlacklist", blacklist)
+	ret := m.ctrl.Call(m, "UpdateEmployee", ctx, employeeID, req)
+	ret0, _ := ret[0].(*v1.EmployeeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// SetTokenBlacklist indicates an expected call of SetTokenBlacklist.
-func (mr *MockEmployeeServiceWithBlacklistMockRecorder) SetTokenBlacklist(blacklist any) *gomock.Call {
+// UpdateEmployee indicates an expected call of UpdateEmployee.
+func (mr *MockEmployeeServiceMockRecorder) UpdateEmployee(ctx, employeeID, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTokenBlacklist", reflect.TypeOf((*MockEmployeeServiceWithBlacklist)(nil).SetTokenBlacklist), blacklist)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmployee", reflect.TypeOf((*MockEmployeeService)(nil).UpdateEmployee), ctx, employeeID, req)
 }
 
-// UpdateEmployee mocks base method.
-func (m *MockEmployeeServiceWithBlacklist) UpdateEmployee(employeeID uint, req v1.EmployeeUpdateRequest) (*v1.EmployeeResponse, error) {
+// UpdatePosition mocks base method.
+func (m *MockEmployeeService) UpdatePosition(ctx context.Context, employeeID uint, req v1.EmployeePositionRequest) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateEmployee", employeeID, req)
-	ret0, _ := ret[0].(*v1.EmployeeResponse)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdatePosition", ctx, employeeID, req)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// UpdateEmployee indicates an expected call of UpdateEmployee.
-func (mr *MockEmployeeServiceWithBlacklistMockRecorder) UpdateEmployee(employeeID, req any) *gomock.Call {
+// UpdatePosition indicates an expected call of UpdatePosition.
+func (mr *MockEmployeeServiceMockRecorder) UpdatePosition(ctx, employeeID, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmployee", reflect.TypeOf((*MockEmployeeServiceWithBlacklist)(nil).UpdateEmployee), employeeID, req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePosition", reflect.TypeOf((*MockEmployeeService)(nil).UpdatePosition), ctx, employeeID, req)
 }
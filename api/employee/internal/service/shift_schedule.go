@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
+	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+	"github.com/pd120424d/mountain-service/api/employee/internal/model"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+const (
+	ErrorScheduleNotFound        = "SCHEDULE_ERRORS.NOT_FOUND"
+	ErrorScheduleInvalidHours    = "SCHEDULE_ERRORS.INVALID_HOURS"
+	ErrorScheduleInvalidCapacity = "SCHEDULE_ERRORS.INVALID_CAPACITY"
+	ErrorScheduleInvalidRange    = "SCHEDULE_ERRORS.INVALID_RANGE"
+	ErrorScheduleOverlap         = "SCHEDULE_ERRORS.OVERLAP"
+
+	// maxShiftCapacity guards against typos; no station staffs more people of one profile per shift
+	maxShiftCapacity = 50
+)
+
+// ListShiftTemplates returns all configured shift templates; days without one use model.DefaultShiftTemplate
+func (s *shiftService) ListShiftTemplates(ctx context.Context) ([]employeeV1.ShiftTemplateResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "ShiftService.ListShiftTemplates")()
+
+	templates, err := s.shiftsRepo.ListShiftTemplates(ctx, time.Time{}, time.Time{})
+	if err != nil {
+		log.Errorf("failed to list shift templates: %v", err)
+		return nil, fmt.Errorf("failed to retrieve shift templates")
+	}
+
+	response := make([]employeeV1.ShiftTemplateResponse, 0, len(templates))
+	for i := range templates {
+		response = append(response, templates[i].ToResponse())
+	}
+	return response, nil
+}
+
+// CreateShiftTemplate sets the shift hours for a period no other template covers. Shifts already assigned
+// on those days keep their date and type and simply run at the new hours.
+func (s *shiftService) CreateShiftTemplate(ctx context.Context, req employeeV1.ShiftTemplateRequest) (*employeeV1.ShiftTemplateResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "ShiftService.CreateShiftTemplate")()
+	log.Infof("Creating shift template %s - %s: %s, %s, %s", req.EffectiveFrom, req.EffectiveTo, req.FirstShiftStart, req.SecondShiftStart, req.ThirdShiftStart)
+
+	starts, err := req.StartMinutes()
+	if err != nil {
+		return nil, commonv1.NewAppError(ErrorScheduleInvalidHours, err.Error(), nil)
+	}
+	period, err := parseEffectivePeriod(req.EffectiveFrom, req.EffectiveTo)
+	if err != nil {
+		return nil, err
+	}
+
+	template := &model.ShiftTemplate{
+		Name:             req.Name,
+		FirstShiftStart:  starts[0],
+		SecondShiftStart: starts[1],
+		ThirdShiftStart:  starts[2],
+		EffectivePeriod:  period,
+	}
+	if err := s.shiftsRepo.CreateShiftTemplate(ctx, template); err != nil {
+		if errors.Is(err, model.ErrScheduleOverlap) {
+			return nil, commonv1.NewAppError(ErrorScheduleOverlap, "another shift template applies to some of these days", nil)
+		}
+		log.Errorf("failed to create shift template: %v", err)
+		return nil, fmt.Errorf("failed to create shift template")
+	}
+
+	log.Infof("Created shift template %d", template.ID)
+	resp := template.ToResponse()
+	return &resp, nil
+}
+
+// DeleteShiftTemplate removes a template; its days go back to the default shift hours
+func (s *shiftService) DeleteShiftTemplate(ctx context.Context, id uint) error {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "ShiftService.DeleteShiftTemplate")()
+	log.Infof("Deleting shift template %d", id)
+
+	if err := s.shiftsRepo.DeleteShiftTemplate(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return commonv1.NewAppError(ErrorScheduleNotFound, "shift template not found", nil)
+		}
+		log.Errorf("failed to delete shift template: %v", err)
+		return fmt.Errorf("failed to delete shift template")
+	}
+	return nil
+}
+
+// ListShiftCapacities returns all configured capacities; days without one use model.DefaultShiftCapacity
+func (s *shiftService) ListShiftCapacities(ctx context.Context) ([]employeeV1.ShiftCapacityResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "ShiftService.ListShiftCapacities")()
+
+	capacities, err := s.shiftsRepo.ListShiftCapacities(ctx, time.Time{}, time.Time{})
+	if err != nil {
+		log.Errorf("failed to list shift capacities: %v", err)
+		return nil, fmt.Errorf("failed to retrieve shift capacities")
+	}
+
+	response := make([]employeeV1.ShiftCapacityResponse, 0, len(capacities))
+	for i := range capacities {
+		response = append(response, capacities[i].ToResponse())
+	}
+	return response, nil
+}
+
+// CreateShiftCapacity sets how many staff of a profile each shift takes for a period no other capacity of
+// that profile covers. Lowering it does not remove anyone already assigned; it only blocks new assignments.
+func (s *shiftService) CreateShiftCapacity(ctx context.Context, req employeeV1.ShiftCapacityRequest) (*employeeV1.ShiftCapacityResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "ShiftService.CreateShiftCapacity")()
+	log.Infof("Creating %s shift capacity %d for %s - %s", req.ProfileType, req.Capacity, req.EffectiveFrom, req.EffectiveTo)
+
+	profile := model.ProfileTypeFromString(req.ProfileType)
+	if !slices.Contains(rosterProfiles, profile) {
+		return nil, commonv1.NewAppError(ErrorScheduleInvalidCapacity, fmt.Sprintf("shifts are not staffed by profile %q", req.ProfileType), nil)
+	}
+	if req.Capacity < 0 || req.Capacity > maxShiftCapacity {
+		return nil, commonv1.NewAppError(ErrorScheduleInvalidCapacity, fmt.Sprintf("capacity must be between 0 and %d", maxShiftCapacity), map[string]interface{}{"max": maxShiftCapacity})
+	}
+	period, err := parseEffectivePeriod(req.EffectiveFrom, req.EffectiveTo)
+	if err != nil {
+		return nil, err
+	}
+
+	capacity := &model.ShiftCapacity{ProfileType: profile, Capacity: req.Capacity, EffectivePeriod: period}
+	if err := s.shiftsRepo.CreateShiftCapacity(ctx, capacity); err != nil {
+		if errors.Is(err, model.ErrScheduleOverlap) {
+			return nil, commonv1.NewAppError(ErrorScheduleOverlap, fmt.Sprintf("another %s capacity applies to some of these days", profile), nil)
+		}
+		log.Errorf("failed to create shift capacity: %v", err)
+		return nil, fmt.Errorf("failed to create shift capacity")
+	}
+
+	log.Infof("Created shift capacity %d", capacity.ID)
+	resp := capacity.ToResponse()
+	return &resp, nil
+}
+
+// DeleteShiftCapacity removes a capacity; its days go back to the default capacity of the profile
+func (s *shiftService) DeleteShiftCapacity(ctx context.Context, id uint) error {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "ShiftService.DeleteShiftCapacity")()
+	log.Infof("Deleting shift capacity %d", id)
+
+	if err := s.shiftsRepo.DeleteShiftCapacity(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return commonv1.NewAppError(ErrorScheduleNotFound, "shift capacity not found", nil)
+		}
+		log.Errorf("failed to delete shift capacity: %v", err)
+		return fmt.Errorf("failed to delete shift capacity")
+	}
+	return nil
+}
+
+// parseEffectivePeriod parses the inclusive period of a schedule setting; an empty end leaves it open.
+// Settings may start in the past but must not be over already.
+func parseEffectivePeriod(rawFrom, rawTo string) (model.EffectivePeriod, error) {
+	from, err := time.ParseInLocation("2006-01-02", rawFrom, time.UTC)
+	if err != nil {
+		return model.EffectivePeriod{}, commonv1.NewAppError(ErrorScheduleInvalidRange, "invalid effective from date format", nil)
+	}
+	period := model.EffectivePeriod{EffectiveFrom: from}
+	if rawTo == "" {
+		return period, nil
+	}
+
+	to, err := time.ParseInLocation("2006-01-02", rawTo, time.UTC)
+	if err != nil {
+		return model.EffectivePeriod{}, commonv1.NewAppError(ErrorScheduleInvalidRange, "invalid effective to date format", nil)
+	}
+	switch {
+	case to.Before(from):
+		return model.EffectivePeriod{}, commonv1.NewAppError(ErrorScheduleInvalidRange, "effective to date must not be before effective from date", nil)
+	case to.Before(time.Now().UTC().Truncate(24 * time.Hour)):
+		return model.EffectivePeriod{}, commonv1.NewAppError(ErrorScheduleInvalidRange, "period must not end in the past", nil)
+	}
+	period.EffectiveTo = &to
+	return period, nil
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+
+	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+	"github.com/pd120424d/mountain-service/api/employee/internal/model"
+)
+
+func TestShiftService_CreateShiftTemplate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	valid := employeeV1.ShiftTemplateRequest{Name: "winter", FirstShiftStart: "07:00", SecondShiftStart: "15:00", ThirdShiftStart: "23:00", EffectiveFrom: "2030-01-10", EffectiveTo: "2030-03-31"}
+
+	t.Run("it saves the template", func(t *testing.T) {
+		svc, _, shiftRepo := newLeaveService(t)
+		shiftRepo.EXPECT().CreateShiftTemplate(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, template *model.ShiftTemplate) error {
+			assert.Equal(t, [3]int{420, 900, 1380}, template.StartMinutes())
+			assert.True(t, template.EffectiveFrom.Equal(time.Date(2030, 1, 10, 0, 0, 0, 0, time.UTC)))
+			require.NotNil(t, template.EffectiveTo)
+			assert.True(t, template.EffectiveTo.Equal(time.Date(2030, 3, 31, 0, 0, 0, 0, time.UTC)))
+			template.ID = 4
+			return nil
+		})
+
+		resp, err := svc.CreateShiftTemplate(ctx, valid)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(4), resp.ID)
+		assert.Equal(t, "07:00", resp.FirstShiftStart)
+		assert.Equal(t, "2030-03-31", resp.EffectiveTo)
+	})
+
+	tests := []struct {
+		name   string
+		modify func(*employeeV1.ShiftTemplateRequest)
+		code   string
+	}{
+		{"shifts out of order", func(r *employeeV1.ShiftTemplateRequest) { r.SecondShiftStart = "06:00" }, ErrorScheduleInvalidHours},
+		{"an invalid start date", func(r *employeeV1.ShiftTemplateRequest) { r.EffectiveFrom = "10.01.2030" }, ErrorScheduleInvalidRange},
+		{"a period ending before it starts", func(r *employeeV1.ShiftTemplateRequest) { r.EffectiveTo = "2030-01-09" }, ErrorScheduleInvalidRange},
+		{"a period already over", func(r *employeeV1.ShiftTemplateRequest) { r.EffectiveFrom, r.EffectiveTo = "2020-01-01", "2020-02-01" }, ErrorScheduleInvalidRange},
+	}
+	for _, tt := range tests {
+		t.Run("it rejects "+tt.name, func(t *testing.T) {
+			svc, _, _ := newLeaveService(t)
+			req := valid
+			tt.modify(&req)
+
+			_, err := svc.CreateShiftTemplate(ctx, req)
+
+			assert.Equal(t, tt.code, appErrorCode(t, err))
+		})
+	}
+
+	t.Run("it reports an overlapping template", func(t *testing.T) {
+		svc, _, shiftRepo := newLeaveService(t)
+		shiftRepo.EXPECT().CreateShiftTemplate(gomock.Any(), gomock.Any()).Return(model.ErrScheduleOverlap)
+
+		_, err := svc.CreateShiftTemplate(ctx, valid)
+
+		assert.Equal(t, ErrorScheduleOverlap, appErrorCode(t, err))
+	})
+}
+
+func TestShiftService_CreateShiftCapacity(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	valid := employeeV1.ShiftCapacityRequest{ProfileType: "Medic", Capacity: 3, EffectiveFrom: "2030-01-10"}
+
+	t.Run("it saves an open-ended capacity", func(t *testing.T) {
+		svc, _, shiftRepo := newLeaveService(t)
+		shiftRepo.EXPECT().CreateShiftCapacity(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, capacity *model.ShiftCapacity) error {
+			assert.Equal(t, model.Medic, capacity.ProfileType)
+			assert.Equal(t, 3, capacity.Capacity)
+			assert.Nil(t, capacity.EffectiveTo)
+			return nil
+		})
+
+		resp, err := svc.CreateShiftCapacity(ctx, valid)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Medic", resp.ProfileType)
+		assert.Empty(t, resp.EffectiveTo)
+	})
+
+	t.Run("it rejects a profile that does not work shifts", func(t *testing.T) {
+		svc, _, _ := newLeaveService(t)
+		req := valid
+		req.ProfileType = "Administrator"
+
+		_, err := svc.CreateShiftCapacity(ctx, req)
+
+		assert.Equal(t, ErrorScheduleInvalidCapacity, appErrorCode(t, err))
+	})
+
+	t.Run("it rejects a capacity above the limit", func(t *testing.T) {
+		svc, _, _ := newLeaveService(t)
+		req := valid
+		req.Capacity = maxShiftCapacity + 1
+
+		_, err := svc.CreateShiftCapacity(ctx, req)
+
+		assert.Equal(t, ErrorScheduleInvalidCapacity, appErrorCode(t, err))
+	})
+
+	t.Run("it reports an overlapping capacity", func(t *testing.T) {
+		svc, _, shiftRepo := newLeaveService(t)
+		shiftRepo.EXPECT().CreateShiftCapacity(gomock.Any(), gomock.Any()).Return(model.ErrScheduleOverlap)
+
+		_, err := svc.CreateShiftCapacity(ctx, valid)
+
+		assert.Equal(t, ErrorScheduleOverlap, appErrorCode(t, err))
+	})
+}
+
+func TestShiftService_DeleteShiftSchedule(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("it reports a missing template", func(t *testing.T) {
+		svc, _, shiftRepo := newLeaveService(t)
+		shiftRepo.EXPECT().DeleteShiftTemplate(gomock.Any(), uint(7)).Return(gorm.ErrRecordNotFound)
+
+		assert.Equal(t, ErrorScheduleNotFound, appErrorCode(t, svc.DeleteShiftTemplate(ctx, 7)))
+	})
+
+	t.Run("it reports a missing capacity", func(t *testing.T) {
+		svc, _, shiftRepo := newLeaveService(t)
+		shiftRepo.EXPECT().DeleteShiftCapacity(gomock.Any(), uint(7)).Return(gorm.ErrRecordNotFound)
+
+		assert.Equal(t, ErrorScheduleNotFound, appErrorCode(t, svc.DeleteShiftCapacity(ctx, 7)))
+	})
+
+	t.Run("it deletes a capacity", func(t *testing.T) {
+		svc, _, shiftRepo := newLeaveService(t)
+		shiftRepo.EXPECT().DeleteShiftCapacity(gomock.Any(), uint(7)).Return(nil)
+
+		assert.NoError(t, svc.DeleteShiftCapacity(ctx, 7))
+	})
+}
+
+func TestShiftService_ListShiftSchedule(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	from := time.Date(2030, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	svc, _, shiftRepo := newLeaveService(t)
+	shiftRepo.EXPECT().ListShiftTemplates(gomock.Any(), time.Time{}, time.Time{}).
+		Return([]model.ShiftTemplate{{ID: 1, FirstShiftStart: 420, SecondShiftStart: 900, ThirdShiftStart: 1380, EffectivePeriod: model.EffectivePeriod{EffectiveFrom: from}}}, nil)
+	shiftRepo.EXPECT().ListShiftCapacities(gomock.Any(), time.Time{}, time.Time{}).
+		Return([]model.ShiftCapacity{{ID: 2, ProfileType: model.Technical, Capacity: 6, EffectivePeriod: model.EffectivePeriod{EffectiveFrom: from}}}, nil)
+
+	templates, err := svc.ListShiftTemplates(ctx)
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	assert.Equal(t, "23:00", templates[0].ThirdShiftStart)
+
+	capacities, err := svc.ListShiftCapacities(ctx)
+	require.NoError(t, err)
+	require.Len(t, capacities, 1)
+	assert.Equal(t, 6, capacities[0].Capacity)
+}
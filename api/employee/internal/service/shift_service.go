@@ -3,26 +3,49 @@ package service
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
 	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
 	"github.com/pd120424d/mountain-service/api/employee/internal/model"
 	"github.com/pd120424d/mountain-service/api/employee/internal/repositories"
+	s2surgency "github.com/pd120424d/mountain-service/api/shared/s2s/urgency"
 	"github.com/pd120424d/mountain-service/api/shared/utils"
 )
 
 type shiftService struct {
-	log        utils.Logger
-	emplRepo   repositories.EmployeeRepository
-	shiftsRepo repositories.ShiftRepository
+	log           utils.Logger
+	emplRepo      repositories.EmployeeRepository
+	shiftsRepo    repositories.ShiftRepository
+	urgencyClient s2surgency.Client
+	swaps         SwapPolicy
 }
 
-func NewShiftService(log utils.Logger, emplRepo repositories.EmployeeRepository, shiftsRepo repositories.ShiftRepository) ShiftService {
+// ShiftServiceOptions holds the configurable policies of the shift service
+type ShiftServiceOptions struct {
+	Swaps SwapPolicy
+}
+
+// DefaultShiftServiceOptions returns the policies used when no overrides are configured
+func DefaultShiftServiceOptions() ShiftServiceOptions {
+	return ShiftServiceOptions{Swaps: DefaultSwapPolicy()}
+}
+
+// NewShiftService creates the shift service. urgencyClient may be nil, which skips the active emergency
+// check when removing shifts.
+func NewShiftService(log utils.Logger, emplRepo repositories.EmployeeRepository, shiftsRepo repositories.ShiftRepository, urgencyClient s2surgency.Client) ShiftService {
+	return NewShiftServiceWithOptions(log, emplRepo, shiftsRepo, urgencyClient, DefaultShiftServiceOptions())
+}
+
+// NewShiftServiceWithOptions allows overriding the shift swap policy
+func NewShiftServiceWithOptions(log utils.Logger, emplRepo repositories.EmployeeRepository, shiftsRepo repositories.ShiftRepository, urgencyClient s2surgency.Client, opts ShiftServiceOptions) ShiftService {
 	return &shiftService{
-		log:        log.WithName("shiftService"),
-		emplRepo:   emplRepo,
-		shiftsRepo: shiftsRepo,
+		log:           log.WithName("shiftService"),
+		emplRepo:      emplRepo,
+		shiftsRepo:    shiftsRepo,
+		urgencyClient: urgencyClient,
+		swaps:         opts.Swaps,
 	}
 }
 
@@ -61,20 +84,25 @@ func (s *shiftService) AssignShift(ctx context.Context, employeeID uint, req emp
 		return nil, commonv1.NewAppError("VALIDATION.SHIFT_TOO_FAR", "shift date cannot be more than 3 months in the future", nil)
 	}
 
-	// Step 5: Check consecutive shifts rule (max 2 consecutive shifts, then 1 day rest)
+	// Step 5: Check the employee is not on approved leave that day
+	if err := s.validateNotOnLeave(ctx, employeeID, shiftDate); err != nil {
+		return nil, err
+	}
+
+	// Step 6: Check consecutive shifts rule (max 2 consecutive shifts, then 1 day rest)
 	if err := s.validateConsecutiveShifts(ctx, employeeID, shiftDate, req.ShiftType); err != nil {
 		log.Errorf("consecutive shifts validation failed: %v", err)
 		return nil, err
 	}
 
-	// Step 6: Get or create shift
+	// Step 7: Get or create shift
 	shift, err := s.shiftsRepo.GetOrCreateShift(ctx, shiftDate, req.ShiftType)
 	if err != nil {
 		log.Errorf("failed to get or create shift: %v", err)
 		return nil, fmt.Errorf("failed to create shift")
 	}
 
-	// Step 7: Check if employee is already assigned
+	// Step 8: Check if employee is already assigned
 	assigned, err := s.shiftsRepo.AssignedToShift(ctx, employeeID, shift.ID)
 	if err != nil {
 		log.Errorf("failed to check assignment: %v", err)
@@ -85,20 +113,24 @@ func (s *shiftService) AssignShift(ctx context.Context, employeeID uint, req emp
 		return nil, commonv1.NewAppError("SHIFT_ERRORS.ALREADY_ASSIGNED", "employee is already assigned to this shift", nil)
 	}
 
-	// Step 8: Check shift capacity
+	// Step 9: Check shift capacity
 	currentCount, err := s.shiftsRepo.CountAssignmentsByProfile(ctx, shift.ID, employee.ProfileType)
 	if err != nil {
 		log.Errorf("failed to count assignments: %v", err)
 		return nil, fmt.Errorf("failed to check shift capacity")
 	}
 
-	maxCapacity := s.getMaxCapacityForProfile(employee.ProfileType)
+	maxCapacity, err := s.capacityOn(ctx, shiftDate, employee.ProfileType)
+	if err != nil {
+		log.Errorf("failed to get shift capacity: %v", err)
+		return nil, fmt.Errorf("failed to check shift capacity")
+	}
 	if currentCount >= int64(maxCapacity) {
 		log.Errorf("shift capacity full for profile type %s", employee.ProfileType.String())
 		return nil, commonv1.NewAppError("SHIFT_ERRORS.CAPACITY_FULL", fmt.Sprintf("shift capacity is full for %s staff", employee.ProfileType.String()), map[string]interface{}{"role": employee.ProfileType.String(), "max": maxCapacity})
 	}
 
-	// Step 9: Create assignment
+	// Step 10: Create assignment
 	assignmentID, err := s.shiftsRepo.CreateAssignment(ctx, employeeID, shift.ID)
 	if err != nil {
 		log.Errorf("failed to create assignment: %v", err)
@@ -215,7 +247,9 @@ func (s *shiftService) GetShiftsAvailability(ctx context.Context, employeeID uin
 	return response, nil
 }
 
-func (s *shiftService) RemoveShift(ctx context.Context, employeeID uint, req employeeV1.RemoveShiftRequest) error {
+// RemoveShift removes the employee from a shift unless they are responding to an in-progress urgency;
+// force skips that check.
+func (s *shiftService) RemoveShift(ctx context.Context, employeeID uint, req employeeV1.RemoveShiftRequest, force bool) error {
 	log := s.log.WithContext(ctx)
 	defer utils.TimeOperation(log, "ShiftService.RemoveShift")()
 	log.Infof("Removing shift for employee ID %d", employeeID)
@@ -228,6 +262,10 @@ func (s *shiftService) RemoveShift(ctx context.Context, employeeID uint, req emp
 	// normalize to midnight UTC for matching persisted shift_date
 	shiftDate = shiftDate.UTC().Truncate(24 * time.Hour)
 
+	if err := ensureNotResponding(ctx, log, s.urgencyClient, employeeID, force, "shift removal"); err != nil {
+		return err
+	}
+
 	err = s.shiftsRepo.RemoveEmployeeFromShiftByDetails(ctx, employeeID, shiftDate, req.ShiftType)
 	if err != nil {
 		log.Errorf("failed to remove shift: %v", err)
@@ -251,7 +289,10 @@ func (s *shiftService) GetOnCallEmployees(ctx context.Context, currentTime time.
 
 	var employeeResponses []employeeV1.EmployeeResponse
 	for _, emp := range employees {
-		employeeResponses = append(employeeResponses, emp.UpdateResponseFromEmployee())
+		resp := emp.UpdateResponseFromEmployee()
+		// employees pulled in by the buffer are not on duty yet, so their quiet hours still apply
+		resp.OnCall = emp.OnCurrentShift
+		employeeResponses = append(employeeResponses, resp)
 	}
 
 	log.Infof("Successfully retrieved %d on-call employees", len(employeeResponses))
@@ -284,19 +325,20 @@ func (s *shiftService) GetShiftWarnings(ctx context.Context, employeeID uint) ([
 		return nil, fmt.Errorf("failed to check shift coverage")
 	}
 
+	capacities, err := s.shiftsRepo.ListShiftCapacities(ctx, start, end)
+	if err != nil {
+		log.Errorf("failed to get shift capacities: %v", err)
+		return nil, fmt.Errorf("failed to check shift coverage")
+	}
+
 	// Only show warnings if there's at least one shift with zero staff for the employee's role
 	zeroRoleShiftExists := false
-	for _, shifts := range availability.Days {
+	for day, shifts := range availability.Days {
+		capacity := model.CapacityOn(capacities, day, employee.ProfileType)
 		for _, shift := range shifts {
-			switch employee.ProfileType {
-			case model.Medic:
-				if shift[model.Medic] == 2 { // 2 available => 0 assigned
-					zeroRoleShiftExists = true
-				}
-			case model.Technical:
-				if shift[model.Technical] == 4 { // 4 available => 0 assigned
-					zeroRoleShiftExists = true
-				}
+			// all places still available => nobody of the role assigned
+			if capacity > 0 && shift[employee.ProfileType] == capacity {
+				zeroRoleShiftExists = true
 			}
 			if zeroRoleShiftExists {
 				break
@@ -313,6 +355,17 @@ func (s *shiftService) GetShiftWarnings(ctx context.Context, employeeID uint) ([
 		return warnings, nil
 	}
 
+	// Days on approved leave do not count towards the expected workload
+	availableDays, err := s.availableDays(ctx, employeeID, start, end)
+	if err != nil {
+		log.Errorf("failed to get employee leave: %v", err)
+		return nil, fmt.Errorf("failed to check employee leave")
+	}
+	if availableDays == 0 {
+		log.Infof("Employee ID %d is on leave for the next two weeks; no warnings", employeeID)
+		return warnings, nil
+	}
+
 	// Compute distinct days scheduled by the employee in the next two weeks (total over 14 days)
 	var employeeShifts []model.Shift
 	err = s.shiftsRepo.GetShiftsByEmployeeIDInDateRange(ctx, employeeID, start, end, &employeeShifts)
@@ -330,38 +383,96 @@ func (s *shiftService) GetShiftWarnings(ctx context.Context, employeeID uint) ([
 
 	totalDistinctDays := len(distinctDays)
 
-	// Warn only if fewer than 10 distinct days are scheduled in the next 14 days
-	if totalDistinctDays < 10 {
-		warnings = append(warnings, fmt.Sprintf("%s|%d|14|5", model.WarningInsufficientShifts, totalDistinctDays))
+	// Warn only if fewer than 10 distinct days are scheduled in the next 14 days, scaled down to the days
+	// the employee is available
+	requiredDays := requiredWorkdays(availableDays)
+	if totalDistinctDays < requiredDays {
+		warnings = append(warnings, fmt.Sprintf("%s|%d|%d|5", model.WarningInsufficientShifts, totalDistinctDays, availableDays))
 	}
 
 	log.Infof("Successfully retrieved %d warnings for employee ID %d", len(warnings), employeeID)
 	return warnings, nil
 }
 
-func (s *shiftService) GetAdminShiftsAvailability(ctx context.Context, days int) (*employeeV1.ShiftAvailabilityResponse, error) {
+// GetAdminShiftsAvailability returns the roster of every shift from today on: who is assigned per profile,
+// the slots left and which profiles are understaffed. An empty profile type covers every profile working shifts.
+func (s *shiftService) GetAdminShiftsAvailability(ctx context.Context, days int, profileType model.ProfileType) (*employeeV1.AdminShiftAvailabilityResponse, error) {
 	log := s.log.WithContext(ctx)
 	defer utils.TimeOperation(log, "ShiftService.GetAdminShiftsAvailability")()
-	log.Infof("Getting admin shifts availability for %d days", days)
+	log.Infof("Getting admin shifts availability for %d days, profile %q", days, profileType)
 
-	// TODO: we need to return shift availability for all employees here, if it is possible somehow
-	return &employeeV1.ShiftAvailabilityResponse{
-		Days: make(map[time.Time]employeeV1.ShiftAvailabilityPerDay),
-	}, nil
+	if days <= 0 || days > 90 {
+		log.Errorf("invalid days parameter: %d", days)
+		return nil, fmt.Errorf("days must be between 1 and 90")
+	}
+	profiles := rosterProfiles
+	if profileType != "" {
+		if !slices.Contains(rosterProfiles, profileType) {
+			log.Errorf("invalid profile type: %s", profileType)
+			return nil, fmt.Errorf("shifts are not staffed by profile %s", profileType)
+		}
+		profiles = []model.ProfileType{profileType}
+	}
+
+	start := time.Now().UTC().Truncate(24 * time.Hour)
+	end := start.AddDate(0, 0, days-1)
+	plan, err := s.loadRosterPlan(ctx, start, end)
+	if err != nil {
+		log.Errorf("failed to load shift rosters: %v", err)
+		return nil, fmt.Errorf("failed to retrieve shift availability")
+	}
+	templates, err := s.shiftsRepo.ListShiftTemplates(ctx, start, end)
+	if err != nil {
+		log.Errorf("failed to get shift templates: %v", err)
+		return nil, fmt.Errorf("failed to retrieve shift availability")
+	}
+
+	response := &employeeV1.AdminShiftAvailabilityResponse{
+		Days: make(map[time.Time]employeeV1.AdminShiftAvailabilityPerDay),
+	}
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		template := model.ShiftTemplateOn(templates, day)
+		var shifts [3]employeeV1.AdminShiftAvailability
+		for i := range shifts {
+			shifts[i] = plan.shiftRoster(day, i+1, profiles)
+			shifts[i].StartTime, shifts[i].EndTime = template.ShiftHours(i + 1)
+		}
+		response.Days[day] = employeeV1.AdminShiftAvailabilityPerDay{FirstShift: shifts[0], SecondShift: shifts[1], ThirdShift: shifts[2]}
+	}
+
+	log.Infof("Successfully retrieved admin shifts availability for %d days", days)
+	return response, nil
 }
 
 // Helper methods
 
 func (s *shiftService) validateConsecutiveShifts(ctx context.Context, employeeID uint, shiftDate time.Time, shiftType int) error {
+	return s.validateConsecutiveShiftsWithout(ctx, employeeID, shiftDate, shiftType, 0)
+}
+
+// validateConsecutiveShiftsWithout checks the candidate shift as if the employee no longer had the shift
+// with ID releasedShiftID, which they give away in the same swap; 0 keeps all their shifts
+func (s *shiftService) validateConsecutiveShiftsWithout(ctx context.Context, employeeID uint, shiftDate time.Time, shiftType int, releasedShiftID uint) error {
 	// Build a small window around the candidate date to check adjacency and calendar-day rest rules
 	startDate := shiftDate.AddDate(0, 0, -3)
 	endDate := shiftDate.AddDate(0, 0, 3)
 
-	var shifts []model.Shift
-	if err := s.shiftsRepo.GetShiftsByEmployeeIDInDateRange(ctx, employeeID, startDate, endDate, &shifts); err != nil {
+	var assignedShifts []model.Shift
+	if err := s.shiftsRepo.GetShiftsByEmployeeIDInDateRange(ctx, employeeID, startDate, endDate, &assignedShifts); err != nil {
 		return fmt.Errorf("failed to get employee shifts: %w", err)
 	}
+	shifts := assignedShifts[:0]
+	for _, sh := range assignedShifts {
+		if releasedShiftID == 0 || sh.ID != releasedShiftID {
+			shifts = append(shifts, sh)
+		}
+	}
+	return checkConsecutiveShifts(shifts, shiftDate, shiftType)
+}
 
+// checkConsecutiveShifts applies the consecutive shift and rest day rules to a candidate shift, given the
+// employee's shifts from at least three days around it
+func checkConsecutiveShifts(shifts []model.Shift, shiftDate time.Time, shiftType int) error {
 	// Map assignments per slot and per day
 	dayKey := func(d time.Time) string { return d.Truncate(24 * time.Hour).Format("2006-01-02") }
 	slotKey := func(d time.Time, t int) string { return fmt.Sprintf("%s|%d", dayKey(d), t) }
@@ -487,15 +598,18 @@ func (s *shiftService) validateConsecutiveShifts(ctx context.Context, employeeID
 	return nil
 }
 
-func (s *shiftService) getMaxCapacityForProfile(profileType model.ProfileType) int {
-	switch profileType {
-	case model.Medic:
-		return 2
-	case model.Technical:
-		return 4
-	default:
-		return 0
+// requiredWorkdays is the 5 days a week quota prorated to the days an employee is available, rounded up
+func requiredWorkdays(availableDays int) int {
+	return (5*availableDays + 6) / 7
+}
+
+// capacityOn returns how many staff of the profile a shift on the day takes
+func (s *shiftService) capacityOn(ctx context.Context, day time.Time, profileType model.ProfileType) (int, error) {
+	capacities, err := s.shiftsRepo.ListShiftCapacities(ctx, day, day)
+	if err != nil {
+		return 0, err
 	}
+	return model.CapacityOn(capacities, day, profileType), nil
 }
 
 func max(a, b int) int {
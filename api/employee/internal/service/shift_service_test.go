@@ -7,11 +7,15 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
 	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
 	"github.com/pd120424d/mountain-service/api/employee/internal/model"
 	"github.com/pd120424d/mountain-service/api/employee/internal/repositories"
+	s2surgency "github.com/pd120424d/mountain-service/api/shared/s2s/urgency"
 	"github.com/pd120424d/mountain-service/api/shared/utils"
 )
 
@@ -26,7 +30,7 @@ func TestShiftService_GetShifts(t *testing.T) {
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
 
-		service := NewShiftService(log, emplRepoMock, shiftRepoMock)
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, nil)
 
 		shiftRepoMock.EXPECT().GetEmployeeShiftRowsByEmployeeID(gomock.Any(), uint(1)).Return(nil, assert.AnError)
 
@@ -45,7 +49,7 @@ func TestShiftService_GetShifts(t *testing.T) {
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
 
-		service := NewShiftService(log, emplRepoMock, shiftRepoMock)
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, nil)
 
 		rows := []repositories.EmployeeShiftRow{
 			{
@@ -93,7 +97,7 @@ func TestShiftService_AssignShift(t *testing.T) {
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
 
-		service := NewShiftService(log, emplRepoMock, shiftRepoMock)
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, nil)
 
 		futureDate := time.Now().AddDate(0, 0, 7)
 		futureDateStr := futureDate.Format("2006-01-02")
@@ -120,7 +124,7 @@ func TestShiftService_AssignShift(t *testing.T) {
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
 
-		service := NewShiftService(log, emplRepoMock, shiftRepoMock)
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, nil)
 
 		employee := &model.Employee{
 			ID:          1,
@@ -152,7 +156,7 @@ func TestShiftService_AssignShift(t *testing.T) {
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
 
-		service := NewShiftService(log, emplRepoMock, shiftRepoMock)
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, nil)
 
 		employee := &model.Employee{
 			ID:          1,
@@ -187,7 +191,7 @@ func TestShiftService_AssignShift(t *testing.T) {
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
 
-		service := NewShiftService(log, emplRepoMock, shiftRepoMock)
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, nil)
 
 		employee := &model.Employee{
 			ID:          1,
@@ -222,7 +226,7 @@ func TestShiftService_AssignShift(t *testing.T) {
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
 
-		service := NewShiftService(log, emplRepoMock, shiftRepoMock)
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, nil)
 
 		employee := &model.Employee{
 			ID:          1,
@@ -253,6 +257,8 @@ func TestShiftService_AssignShift(t *testing.T) {
 			return nil
 		})
 
+		shiftRepoMock.EXPECT().HasApprovedLeave(gomock.Any(), uint(1), gomock.Any()).Return(false, nil)
+
 		response, err := service.AssignShift(context.Background(), 1, req)
 
 		assert.Error(t, err)
@@ -268,7 +274,7 @@ func TestShiftService_AssignShift(t *testing.T) {
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
 
-		service := NewShiftService(log, emplRepoMock, shiftRepoMock)
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, nil)
 
 		employee := &model.Employee{ID: 1, ProfileType: model.Medic}
 		// Use a future base date to avoid flakiness when current date passes the fixed point
@@ -287,6 +293,8 @@ func TestShiftService_AssignShift(t *testing.T) {
 			return nil
 		}).Times(3)
 
+		shiftRepoMock.EXPECT().HasApprovedLeave(gomock.Any(), uint(1), gomock.Any()).Return(false, nil).Times(3)
+
 		for _, st := range []int{1, 2, 3} {
 			req := employeeV1.AssignShiftRequest{ShiftDate: D1.Format("2006-01-02"), ShiftType: st}
 			resp, err := service.AssignShift(context.Background(), 1, req)
@@ -304,7 +312,7 @@ func TestShiftService_AssignShift(t *testing.T) {
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
 
-		service := NewShiftService(log, emplRepoMock, shiftRepoMock)
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, nil)
 
 		employee := &model.Employee{ID: 1, ProfileType: model.Medic}
 		// Future-based dates to keep test stable over time
@@ -328,8 +336,11 @@ func TestShiftService_AssignShift(t *testing.T) {
 		shiftRepoMock.EXPECT().GetOrCreateShift(gomock.Any(), gomock.Any(), 1).Return(shift, nil)
 		shiftRepoMock.EXPECT().AssignedToShift(gomock.Any(), uint(1), uint(10)).Return(false, nil)
 		shiftRepoMock.EXPECT().CountAssignmentsByProfile(gomock.Any(), uint(10), model.Medic).Return(int64(0), nil)
+		shiftRepoMock.EXPECT().ListShiftCapacities(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
 		shiftRepoMock.EXPECT().CreateAssignment(gomock.Any(), uint(1), uint(10)).Return(uint(77), nil)
 
+		shiftRepoMock.EXPECT().HasApprovedLeave(gomock.Any(), uint(1), gomock.Any()).Return(false, nil)
+
 		resp, err := service.AssignShift(context.Background(), 1, req)
 		assert.NoError(t, err)
 		assert.NotNil(t, resp)
@@ -346,7 +357,7 @@ func TestShiftService_AssignShift(t *testing.T) {
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
 
-		service := NewShiftService(log, emplRepoMock, shiftRepoMock)
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, nil)
 
 		employee := &model.Employee{ID: 1, ProfileType: model.Medic}
 		// Future-based dates to keep test stable over time
@@ -370,8 +381,11 @@ func TestShiftService_AssignShift(t *testing.T) {
 		shiftRepoMock.EXPECT().GetOrCreateShift(gomock.Any(), gomock.Any(), 1).Return(shift, nil)
 		shiftRepoMock.EXPECT().AssignedToShift(gomock.Any(), uint(1), uint(42)).Return(false, nil)
 		shiftRepoMock.EXPECT().CountAssignmentsByProfile(gomock.Any(), uint(42), model.Medic).Return(int64(0), nil)
+		shiftRepoMock.EXPECT().ListShiftCapacities(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
 		shiftRepoMock.EXPECT().CreateAssignment(gomock.Any(), uint(1), uint(42)).Return(uint(99), nil)
 
+		shiftRepoMock.EXPECT().HasApprovedLeave(gomock.Any(), uint(1), gomock.Any()).Return(false, nil)
+
 		resp, err := service.AssignShift(context.Background(), 1, req)
 		assert.NoError(t, err)
 		assert.NotNil(t, resp)
@@ -388,7 +402,7 @@ func TestShiftService_AssignShift(t *testing.T) {
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
 
-		service := NewShiftService(log, emplRepoMock, shiftRepoMock)
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, nil)
 
 		employee := &model.Employee{ID: 1, ProfileType: model.Medic}
 		// Future-based dates to keep test stable over time
@@ -412,8 +426,11 @@ func TestShiftService_AssignShift(t *testing.T) {
 		shiftRepoMock.EXPECT().GetOrCreateShift(gomock.Any(), gomock.Any(), 1).Return(shift, nil)
 		shiftRepoMock.EXPECT().AssignedToShift(gomock.Any(), uint(1), uint(21)).Return(false, nil)
 		shiftRepoMock.EXPECT().CountAssignmentsByProfile(gomock.Any(), uint(21), model.Medic).Return(int64(0), nil)
+		shiftRepoMock.EXPECT().ListShiftCapacities(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
 		shiftRepoMock.EXPECT().CreateAssignment(gomock.Any(), uint(1), uint(21)).Return(uint(88), nil)
 
+		shiftRepoMock.EXPECT().HasApprovedLeave(gomock.Any(), uint(1), gomock.Any()).Return(false, nil)
+
 		resp, err := service.AssignShift(context.Background(), 1, req)
 		assert.NoError(t, err)
 		assert.NotNil(t, resp)
@@ -430,7 +447,7 @@ func TestShiftService_AssignShift(t *testing.T) {
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
 
-		service := NewShiftService(log, emplRepoMock, shiftRepoMock)
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, nil)
 
 		employee := &model.Employee{
 			ID:          1,
@@ -463,6 +480,8 @@ func TestShiftService_AssignShift(t *testing.T) {
 
 		shiftRepoMock.EXPECT().GetOrCreateShift(gomock.Any(), gomock.Any(), 1).Return(nil, fmt.Errorf("database error")).Times(1)
 
+		shiftRepoMock.EXPECT().HasApprovedLeave(gomock.Any(), uint(1), gomock.Any()).Return(false, nil)
+
 		response, err := service.AssignShift(context.Background(), 1, req)
 
 		assert.Error(t, err)
@@ -478,7 +497,7 @@ func TestShiftService_AssignShift(t *testing.T) {
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
 
-		service := NewShiftService(log, emplRepoMock, shiftRepoMock)
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, nil)
 
 		employee := &model.Employee{
 			ID:          1,
@@ -511,6 +530,8 @@ func TestShiftService_AssignShift(t *testing.T) {
 		shiftRepoMock.EXPECT().GetOrCreateShift(gomock.Any(), gomock.Any(), 1).Return(shift, nil)
 		shiftRepoMock.EXPECT().AssignedToShift(gomock.Any(), uint(1), uint(1)).Return(false, fmt.Errorf("database error")).Times(1)
 
+		shiftRepoMock.EXPECT().HasApprovedLeave(gomock.Any(), uint(1), gomock.Any()).Return(false, nil)
+
 		response, err := service.AssignShift(context.Background(), 1, req)
 
 		assert.Error(t, err)
@@ -526,7 +547,7 @@ func TestShiftService_AssignShift(t *testing.T) {
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
 
-		service := NewShiftService(log, emplRepoMock, shiftRepoMock)
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, nil)
 
 		employee := &model.Employee{
 			ID:          1,
@@ -559,6 +580,8 @@ func TestShiftService_AssignShift(t *testing.T) {
 		shiftRepoMock.EXPECT().GetOrCreateShift(gomock.Any(), gomock.Any(), 1).Return(shift, nil)
 		shiftRepoMock.EXPECT().AssignedToShift(gomock.Any(), uint(1), uint(1)).Return(true, nil)
 
+		shiftRepoMock.EXPECT().HasApprovedLeave(gomock.Any(), uint(1), gomock.Any()).Return(false, nil)
+
 		response, err := service.AssignShift(context.Background(), 1, req)
 
 		assert.Error(t, err)
@@ -574,7 +597,7 @@ func TestShiftService_AssignShift(t *testing.T) {
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
 
-		service := NewShiftService(log, emplRepoMock, shiftRepoMock)
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, nil)
 
 		employee := &model.Employee{
 			ID:          1,
@@ -608,6 +631,8 @@ func TestShiftService_AssignShift(t *testing.T) {
 		shiftRepoMock.EXPECT().AssignedToShift(gomock.Any(), uint(1), uint(1)).Return(false, nil)
 		shiftRepoMock.EXPECT().CountAssignmentsByProfile(gomock.Any(), uint(1), model.Medic).Return(int64(1), fmt.Errorf("database error")).Times(1)
 
+		shiftRepoMock.EXPECT().HasApprovedLeave(gomock.Any(), uint(1), gomock.Any()).Return(false, nil)
+
 		response, err := service.AssignShift(context.Background(), 1, req)
 
 		assert.Error(t, err)
@@ -622,7 +647,7 @@ func TestShiftService_AssignShift(t *testing.T) {
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
 
-		service := NewShiftService(log, emplRepoMock, shiftRepoMock)
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, nil)
 
 		employee := &model.Employee{
 			ID:          1,
@@ -655,6 +680,62 @@ func TestShiftService_AssignShift(t *testing.T) {
 		shiftRepoMock.EXPECT().GetOrCreateShift(gomock.Any(), gomock.Any(), 1).Return(shift, nil)
 		shiftRepoMock.EXPECT().AssignedToShift(gomock.Any(), uint(1), uint(1)).Return(false, nil)
 		shiftRepoMock.EXPECT().CountAssignmentsByProfile(gomock.Any(), uint(1), model.Medic).Return(int64(2), nil) // Full capacity
+		shiftRepoMock.EXPECT().ListShiftCapacities(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		shiftRepoMock.EXPECT().HasApprovedLeave(gomock.Any(), uint(1), gomock.Any()).Return(false, nil)
+
+		response, err := service.AssignShift(context.Background(), 1, req)
+
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		assert.Equal(t, "shift capacity is full for Medic staff", err.Error())
+	})
+
+	t.Run("it applies the capacity configured for the day", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		log := utils.NewTestLogger()
+		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
+		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
+
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, nil)
+
+		employee := &model.Employee{
+			ID:          1,
+			ProfileType: model.Medic,
+		}
+		futureDate := time.Now().AddDate(0, 0, 7) // 7 days from now
+		futureDateStr := futureDate.Format("2006-01-02")
+
+		shift := &model.Shift{
+			ID:        1,
+			ShiftDate: futureDate,
+			ShiftType: 1,
+		}
+
+		req := employeeV1.AssignShiftRequest{
+			ShiftDate: futureDateStr,
+			ShiftType: 1,
+		}
+
+		emplRepoMock.EXPECT().GetEmployeeByID(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, emp *model.Employee) error {
+			*emp = *employee
+			return nil
+		})
+
+		shiftRepoMock.EXPECT().GetShiftsByEmployeeIDInDateRange(gomock.Any(), uint(1), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, employeeID uint, startDate, endDate time.Time, result *[]model.Shift) error {
+			*result = []model.Shift{}
+			return nil
+		})
+
+		shiftRepoMock.EXPECT().GetOrCreateShift(gomock.Any(), gomock.Any(), 1).Return(shift, nil)
+		shiftRepoMock.EXPECT().AssignedToShift(gomock.Any(), uint(1), uint(1)).Return(false, nil)
+		shiftRepoMock.EXPECT().CountAssignmentsByProfile(gomock.Any(), uint(1), model.Medic).Return(int64(1), nil)
+		shiftRepoMock.EXPECT().ListShiftCapacities(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]model.ShiftCapacity{{ProfileType: model.Medic, Capacity: 1, EffectivePeriod: model.EffectivePeriod{EffectiveFrom: futureDate.AddDate(0, 0, -1).Truncate(24 * time.Hour)}}}, nil)
+
+		shiftRepoMock.EXPECT().HasApprovedLeave(gomock.Any(), uint(1), gomock.Any()).Return(false, nil)
 
 		response, err := service.AssignShift(context.Background(), 1, req)
 
@@ -671,7 +752,7 @@ func TestShiftService_AssignShift(t *testing.T) {
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
 
-		service := NewShiftService(log, emplRepoMock, shiftRepoMock)
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, nil)
 
 		employee := &model.Employee{
 			ID:          1,
@@ -704,8 +785,11 @@ func TestShiftService_AssignShift(t *testing.T) {
 		shiftRepoMock.EXPECT().GetOrCreateShift(gomock.Any(), gomock.Any(), 1).Return(shift, nil)
 		shiftRepoMock.EXPECT().AssignedToShift(gomock.Any(), uint(1), uint(1)).Return(false, nil)
 		shiftRepoMock.EXPECT().CountAssignmentsByProfile(gomock.Any(), uint(1), model.Medic).Return(int64(1), nil) // Available capacity
+		shiftRepoMock.EXPECT().ListShiftCapacities(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
 		shiftRepoMock.EXPECT().CreateAssignment(gomock.Any(), uint(1), uint(1)).Return(uint(10), nil)
 
+		shiftRepoMock.EXPECT().HasApprovedLeave(gomock.Any(), uint(1), gomock.Any()).Return(false, nil)
+
 		response, err := service.AssignShift(context.Background(), 1, req)
 
 		assert.NoError(t, err)
@@ -727,7 +811,7 @@ func TestShiftService_GetShiftsAvailability(t *testing.T) {
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
 
-		service := NewShiftService(log, emplRepoMock, shiftRepoMock)
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, nil)
 
 		emplId := uint(1)
 
@@ -746,7 +830,7 @@ func TestShiftService_GetShiftsAvailability(t *testing.T) {
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
 
-		service := NewShiftService(log, emplRepoMock, shiftRepoMock)
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, nil)
 
 		emplId := uint(1)
 
@@ -767,7 +851,7 @@ func TestShiftService_GetShiftsAvailability(t *testing.T) {
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
 
-		service := NewShiftService(log, emplRepoMock, shiftRepoMock)
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, nil)
 
 		today := time.Now().Truncate(24 * time.Hour)
 		tomorrow := today.AddDate(0, 0, 1)
@@ -825,14 +909,14 @@ func TestShiftService_RemoveShift(t *testing.T) {
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
 
-		service := NewShiftService(log, emplRepoMock, shiftRepoMock)
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, nil)
 
 		req := employeeV1.RemoveShiftRequest{
 			ShiftDate: "invalid-date",
 			ShiftType: 1,
 		}
 
-		err := service.RemoveShift(context.Background(), 1, req)
+		err := service.RemoveShift(context.Background(), 1, req, false)
 
 		assert.Error(t, err)
 		assert.Equal(t, "invalid shift date format", err.Error())
@@ -846,7 +930,7 @@ func TestShiftService_RemoveShift(t *testing.T) {
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
 
-		service := NewShiftService(log, emplRepoMock, shiftRepoMock)
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, nil)
 
 		futureDate := time.Now().AddDate(0, 0, 7) // 7 days from now
 		futureDateStr := futureDate.Format("2006-01-02")
@@ -858,7 +942,7 @@ func TestShiftService_RemoveShift(t *testing.T) {
 
 		shiftRepoMock.EXPECT().RemoveEmployeeFromShiftByDetails(gomock.Any(), uint(1), gomock.Any(), 1).Return(assert.AnError)
 
-		err := service.RemoveShift(context.Background(), 1, req)
+		err := service.RemoveShift(context.Background(), 1, req, false)
 
 		assert.Error(t, err)
 		assert.Equal(t, "failed to remove shift", err.Error())
@@ -872,7 +956,7 @@ func TestShiftService_RemoveShift(t *testing.T) {
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
 
-		service := NewShiftService(log, emplRepoMock, shiftRepoMock)
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, nil)
 
 		futureDate := time.Now().AddDate(0, 0, 7) // 7 days from now
 		futureDateStr := futureDate.Format("2006-01-02")
@@ -884,7 +968,56 @@ func TestShiftService_RemoveShift(t *testing.T) {
 
 		shiftRepoMock.EXPECT().RemoveEmployeeFromShiftByDetails(gomock.Any(), uint(1), gomock.Any(), 1).Return(nil)
 
-		err := service.RemoveShift(context.Background(), 1, req)
+		err := service.RemoveShift(context.Background(), 1, req, false)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("it refuses to remove the shift of an employee responding to an emergency", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		log := utils.NewTestLogger()
+		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
+		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
+		urgencyClientMock := s2surgency.NewMockClient(ctrl)
+
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, urgencyClientMock)
+
+		req := employeeV1.RemoveShiftRequest{
+			ShiftDate: time.Now().AddDate(0, 0, 7).Format("2006-01-02"),
+			ShiftType: 1,
+		}
+
+		urgencyClientMock.EXPECT().GetEmployeeActiveUrgencies(gomock.Any(), uint(1)).
+			Return(&urgencyV1.EmployeeActiveUrgenciesResponse{EmployeeID: 1, HasActive: true, UrgencyIDs: []uint{4}}, nil)
+
+		err := service.RemoveShift(context.Background(), 1, req, false)
+
+		var appErr *commonv1.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, ErrorActiveEmergency, appErr.Code)
+	})
+
+	t.Run("it removes the shift of a responding employee when forced", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		log := utils.NewTestLogger()
+		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
+		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
+		urgencyClientMock := s2surgency.NewMockClient(ctrl)
+
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, urgencyClientMock)
+
+		req := employeeV1.RemoveShiftRequest{
+			ShiftDate: time.Now().AddDate(0, 0, 7).Format("2006-01-02"),
+			ShiftType: 1,
+		}
+
+		shiftRepoMock.EXPECT().RemoveEmployeeFromShiftByDetails(gomock.Any(), uint(1), gomock.Any(), 1).Return(nil)
+
+		err := service.RemoveShift(context.Background(), 1, req, true)
 
 		assert.NoError(t, err)
 	})
@@ -901,7 +1034,7 @@ func TestShiftService_GetOnCallEmployees(t *testing.T) {
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
 
-		service := NewShiftService(log, emplRepoMock, shiftRepoMock)
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, nil)
 
 		currentTime := time.Now()
 		shiftBuffer := time.Hour
@@ -923,26 +1056,26 @@ func TestShiftService_GetOnCallEmployees(t *testing.T) {
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
 
-		service := NewShiftService(log, emplRepoMock, shiftRepoMock)
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, nil)
 
 		currentTime := time.Now()
 		shiftBuffer := time.Hour
 
-		employees := []model.Employee{
-			{
+		employees := []model.OnCallEmployee{
+			{Employee: model.Employee{
 				ID:          1,
 				Username:    "medic1",
 				FirstName:   "Marko",
 				LastName:    "Markovic",
 				ProfileType: model.Medic,
-			},
-			{
+			}, OnCurrentShift: true},
+			{Employee: model.Employee{
 				ID:          2,
 				Username:    "tech1",
 				FirstName:   "Marko",
 				LastName:    "Markovic",
 				ProfileType: model.Technical,
-			},
+			}, OnCurrentShift: true},
 		}
 
 		shiftRepoMock.EXPECT().GetOnCallEmployees(gomock.Any(), currentTime, shiftBuffer).Return(employees, nil)
@@ -956,6 +1089,33 @@ func TestShiftService_GetOnCallEmployees(t *testing.T) {
 		assert.Equal(t, "medic1", response[0].Username)
 		assert.Equal(t, uint(2), response[1].ID)
 		assert.Equal(t, "tech1", response[1].Username)
+		assert.True(t, response[0].OnCall)
+		assert.True(t, response[1].OnCall)
+	})
+
+	t.Run("it does not mark employees of the next shift as on call", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		log := utils.NewTestLogger()
+		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
+		service := NewShiftService(log, repositories.NewMockEmployeeRepository(ctrl), shiftRepoMock, nil)
+
+		currentTime := time.Date(2025, 3, 1, 21, 30, 0, 0, time.UTC)
+		quiet := model.NotificationPreferences{QuietHoursStart: "21:00", QuietHoursEnd: "06:00"}
+		shiftRepoMock.EXPECT().GetOnCallEmployees(gomock.Any(), currentTime, 8*time.Hour).Return([]model.OnCallEmployee{
+			{Employee: model.Employee{ID: 1, Username: "current", ProfileType: model.Medic}, OnCurrentShift: true},
+			{Employee: model.Employee{ID: 2, Username: "next", ProfileType: model.Medic, NotificationPreferences: quiet}},
+		}, nil)
+
+		response, err := service.GetOnCallEmployees(context.Background(), currentTime, 8*time.Hour)
+
+		require.NoError(t, err)
+		require.Len(t, response, 2)
+		assert.True(t, response[0].OnCall)
+		assert.False(t, response[1].OnCall)
+		require.NotNil(t, response[1].NotificationPreferences)
+		assert.NotNil(t, response[1].NotificationPreferences.QuietHours)
 	})
 }
 
@@ -970,7 +1130,7 @@ func TestShiftService_GetShiftWarnings(t *testing.T) {
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
 
-		service := NewShiftService(log, emplRepoMock, shiftRepoMock)
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, nil)
 
 		emplRepoMock.EXPECT().GetEmployeeByID(gomock.Any(), uint(1), gomock.Any()).Return(assert.AnError)
 
@@ -989,7 +1149,7 @@ func TestShiftService_GetShiftWarnings(t *testing.T) {
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
 
-		service := NewShiftService(log, emplRepoMock, shiftRepoMock)
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, nil)
 
 		employee := &model.Employee{
 			ID:          1,
@@ -1011,6 +1171,7 @@ func TestShiftService_GetShiftWarnings(t *testing.T) {
 				},
 			},
 		}, nil)
+		shiftRepoMock.EXPECT().ListShiftCapacities(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
 
 		warnings, err := service.GetShiftWarnings(context.Background(), 1)
 
@@ -1026,7 +1187,7 @@ func TestShiftService_GetShiftWarnings(t *testing.T) {
 		emplRepoMock := repositories.NewMockEmployeeRepository(ctrl)
 		shiftRepoMock := repositories.NewMockShiftRepository(ctrl)
 
-		service := NewShiftService(log, emplRepoMock, shiftRepoMock)
+		service := NewShiftService(log, emplRepoMock, shiftRepoMock, nil)
 
 		employee := &model.Employee{
 			ID:          1,
@@ -1048,6 +1209,9 @@ func TestShiftService_GetShiftWarnings(t *testing.T) {
 				},
 			},
 		}, nil)
+		shiftRepoMock.EXPECT().ListShiftCapacities(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		shiftRepoMock.EXPECT().ListLeaveRequests(gomock.Any(), gomock.Any()).Return(nil, nil)
 
 		// Mock employee shifts - less than 5 per week in next 2 weeks, dates within the 14-day window
 		shiftRepoMock.EXPECT().GetShiftsByEmployeeIDInDateRange(gomock.Any(), uint(1), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, employeeID uint, startDate, endDate time.Time, result *[]model.Shift) error {
@@ -1068,3 +1232,112 @@ func TestShiftService_GetShiftWarnings(t *testing.T) {
 		assert.Equal(t, "SHIFT_WARNINGS.INSUFFICIENT_SHIFTS|2|14|5", warnings[0])
 	})
 }
+
+func TestShiftService_GetAdminShiftsAvailability(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	tomorrow := today.AddDate(0, 0, 1)
+	staff := []model.Employee{
+		{ID: 1, FirstName: "Ana", LastName: "A", Username: "ana", ProfileType: model.Medic},
+		{ID: 2, FirstName: "Bojan", LastName: "B", Username: "bojan", ProfileType: model.Medic},
+		{ID: 3, FirstName: "Ceca", LastName: "C", Username: "ceca", ProfileType: model.Technical},
+	}
+	rows := []repositories.ShiftAssignmentRow{
+		{EmployeeID: 2, ProfileType: model.Medic, ShiftID: 10, ShiftDate: tomorrow, ShiftType: 1},
+		{EmployeeID: 1, ProfileType: model.Medic, ShiftID: 10, ShiftDate: tomorrow, ShiftType: 1},
+		{EmployeeID: 3, ProfileType: model.Technical, ShiftID: 11, ShiftDate: today, ShiftType: 2},
+	}
+	leaves := []model.LeaveRequest{{EmployeeID: 2, Status: employeeV1.LeaveApproved, StartDate: tomorrow, EndDate: tomorrow}}
+
+	t.Run("it returns the roster of every shift", func(t *testing.T) {
+		svc, emplRepo, shiftRepo := newLeaveService(t)
+		expectRosterData(emplRepo, shiftRepo, staff, rows, leaves)
+		shiftRepo.EXPECT().ListShiftTemplates(gomock.Any(), today, tomorrow).Return(nil, nil)
+
+		resp, err := svc.GetAdminShiftsAvailability(ctx, 2, "")
+
+		require.NoError(t, err)
+		require.Len(t, resp.Days, 2)
+
+		first := resp.Days[tomorrow].FirstShift
+		assert.Equal(t, "06:00", first.StartTime)
+		assert.Equal(t, "14:00", first.EndTime)
+		assert.Equal(t, []employeeV1.ShiftRosterEntry{
+			{EmployeeID: 1, Name: "Ana A", Username: "ana"},
+			{EmployeeID: 2, Name: "Bojan B", Username: "bojan", OnLeave: true},
+		}, first.Employees["Medic"])
+		assert.Empty(t, first.Employees["Technical"])
+		assert.Equal(t, map[string]int{"Medic": 2, "Technical": 4}, first.Capacity)
+		assert.Equal(t, 0, first.MedicSlotsAvailable)
+		assert.Equal(t, 4, first.TechnicalSlotsAvailable)
+		assert.False(t, first.IsFullyBooked)
+		// the medic on leave leaves the shift short even though both slots are taken
+		assert.Equal(t, []string{"Medic", "Technical"}, first.UnderstaffedProfiles)
+		assert.True(t, first.IsUnderstaffed)
+
+		second := resp.Days[today].SecondShift
+		assert.Equal(t, "22:00", second.EndTime)
+		assert.Len(t, second.Employees["Technical"], 1)
+		assert.Equal(t, 3, second.TechnicalSlotsAvailable)
+		assert.Equal(t, 2, second.MedicSlotsAvailable)
+	})
+
+	t.Run("it follows the configured capacities and shift hours", func(t *testing.T) {
+		svc, emplRepo, shiftRepo := newLeaveService(t)
+		emplRepo.EXPECT().GetAll(gomock.Any()).Return(staff, nil)
+		shiftRepo.EXPECT().GetAssignmentsInDateRange(gomock.Any(), gomock.Any(), gomock.Any()).Return(rows, nil)
+		shiftRepo.EXPECT().ListShiftCapacities(gomock.Any(), gomock.Any(), gomock.Any()).Return([]model.ShiftCapacity{
+			{ProfileType: model.Medic, Capacity: 1, EffectivePeriod: model.EffectivePeriod{EffectiveFrom: today}},
+			{ProfileType: model.Technical, Capacity: 0, EffectivePeriod: model.EffectivePeriod{EffectiveFrom: today}},
+		}, nil)
+		shiftRepo.EXPECT().ListLeaveRequests(gomock.Any(), gomock.Any()).Return(nil, nil)
+		shiftRepo.EXPECT().ListShiftTemplates(gomock.Any(), today, tomorrow).Return([]model.ShiftTemplate{
+			{FirstShiftStart: 7 * 60, SecondShiftStart: 15 * 60, ThirdShiftStart: 23 * 60, EffectivePeriod: model.EffectivePeriod{EffectiveFrom: today}},
+		}, nil)
+
+		resp, err := svc.GetAdminShiftsAvailability(ctx, 2, "")
+
+		require.NoError(t, err)
+		first := resp.Days[tomorrow].FirstShift
+		assert.Equal(t, "07:00", first.StartTime)
+		assert.Equal(t, 0, first.MedicSlotsAvailable)
+		assert.True(t, first.IsFullyBooked)
+		assert.False(t, first.IsUnderstaffed)
+		assert.Equal(t, "07:00", resp.Days[tomorrow].ThirdShift.EndTime)
+	})
+
+	t.Run("it lists only the requested profile", func(t *testing.T) {
+		svc, emplRepo, shiftRepo := newLeaveService(t)
+		expectRosterData(emplRepo, shiftRepo, staff, rows, leaves)
+		shiftRepo.EXPECT().ListShiftTemplates(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		resp, err := svc.GetAdminShiftsAvailability(ctx, 2, model.Technical)
+
+		require.NoError(t, err)
+		second := resp.Days[today].SecondShift
+		assert.Equal(t, map[string]int{"Technical": 4}, second.Capacity)
+		assert.NotContains(t, second.Employees, "Medic")
+		assert.Equal(t, 0, second.MedicSlotsAvailable)
+		assert.Equal(t, []string{"Technical"}, second.UnderstaffedProfiles)
+	})
+
+	t.Run("it rejects invalid parameters", func(t *testing.T) {
+		svc, _, _ := newLeaveService(t)
+
+		_, err := svc.GetAdminShiftsAvailability(ctx, 91, "")
+		assert.EqualError(t, err, "days must be between 1 and 90")
+
+		_, err = svc.GetAdminShiftsAvailability(ctx, 7, model.Administrator)
+		assert.Error(t, err)
+	})
+
+	t.Run("it fails when the rosters cannot be loaded", func(t *testing.T) {
+		svc, emplRepo, _ := newLeaveService(t)
+		emplRepo.EXPECT().GetAll(gomock.Any()).Return(nil, fmt.Errorf("db down"))
+
+		_, err := svc.GetAdminShiftsAvailability(ctx, 7, "")
+
+		assert.EqualError(t, err, "failed to retrieve shift availability")
+	})
+}
@@ -0,0 +1,440 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
+	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+	"github.com/pd120424d/mountain-service/api/employee/internal/model"
+	"github.com/pd120424d/mountain-service/api/employee/internal/repositories"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+const (
+	ErrorSwapNotFound        = "SHIFT_SWAP_ERRORS.NOT_FOUND"
+	ErrorSwapNotAssigned     = "SHIFT_SWAP_ERRORS.NOT_ASSIGNED"
+	ErrorSwapAlreadyOffered  = "SHIFT_SWAP_ERRORS.ALREADY_OFFERED"
+	ErrorSwapProfileMismatch = "SHIFT_SWAP_ERRORS.PROFILE_MISMATCH"
+	ErrorSwapForbidden       = "SHIFT_SWAP_ERRORS.FORBIDDEN"
+	ErrorSwapInvalidState    = "SHIFT_SWAP_ERRORS.INVALID_STATE"
+	ErrorSwapInvalidStatus   = "SHIFT_SWAP_ERRORS.INVALID_STATUS"
+)
+
+// SwapPolicy controls how agreed shift swaps are carried out
+type SwapPolicy struct {
+	// RequireApproval holds agreed swaps for an administrator instead of handing the shifts over right away
+	RequireApproval bool
+}
+
+// DefaultSwapPolicy hands shifts over as soon as both employees agree
+func DefaultSwapPolicy() SwapPolicy {
+	return SwapPolicy{}
+}
+
+// OfferShift puts one of the employee's upcoming shifts up for colleagues of the same profile to cover or swap
+func (s *shiftService) OfferShift(ctx context.Context, employeeID uint, req employeeV1.OfferShiftRequest) (*employeeV1.ShiftSwapResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "ShiftService.OfferShift")()
+	log.Infof("Employee %d offering shift %s/%d", employeeID, req.ShiftDate, req.ShiftType)
+
+	employee := &model.Employee{}
+	if err := s.emplRepo.GetEmployeeByID(ctx, employeeID, employee); err != nil {
+		log.Errorf("failed to get employee: %v", err)
+		return nil, commonv1.NewAppError("EMPLOYEE_ERRORS.NOT_FOUND", "employee not found", nil)
+	}
+
+	shift, err := s.ownedUpcomingShift(ctx, employeeID, req.ShiftDate, req.ShiftType)
+	if err != nil {
+		return nil, err
+	}
+
+	offered, err := s.shiftsRepo.HasActiveShiftSwap(ctx, shift.ID, employeeID)
+	if err != nil {
+		log.Errorf("failed to check existing offers: %v", err)
+		return nil, fmt.Errorf("failed to offer shift")
+	}
+	if offered {
+		return nil, commonv1.NewAppError(ErrorSwapAlreadyOffered, "shift is already offered", nil)
+	}
+
+	swap := &model.ShiftSwap{
+		ShiftID:     shift.ID,
+		RequesterID: employeeID,
+		ProfileType: employee.ProfileType,
+		Status:      employeeV1.ShiftSwapOpen,
+		Note:        req.Note,
+	}
+	if err := s.shiftsRepo.CreateShiftSwap(ctx, swap); err != nil {
+		log.Errorf("failed to create shift swap: %v", err)
+		return nil, fmt.Errorf("failed to offer shift")
+	}
+	swap.Shift = *shift
+
+	log.Infof("Employee %d offered shift %d as swap %d", employeeID, shift.ID, swap.ID)
+	resp := swap.ToResponse()
+	return &resp, nil
+}
+
+// ListShiftSwaps returns the swaps of the actor's profile, newest first; administrators see every profile
+func (s *shiftService) ListShiftSwaps(ctx context.Context, actorID uint, isAdmin bool, status employeeV1.ShiftSwapStatus) ([]employeeV1.ShiftSwapResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "ShiftService.ListShiftSwaps")()
+
+	if status != "" && !status.Valid() {
+		return nil, commonv1.NewAppError(ErrorSwapInvalidStatus, fmt.Sprintf("invalid shift swap status %q", status), nil)
+	}
+
+	filter := repositories.ShiftSwapFilter{Status: status}
+	if !isAdmin {
+		employee := &model.Employee{}
+		if err := s.emplRepo.GetEmployeeByID(ctx, actorID, employee); err != nil {
+			log.Errorf("failed to get employee: %v", err)
+			return nil, commonv1.NewAppError("EMPLOYEE_ERRORS.NOT_FOUND", "employee not found", nil)
+		}
+		filter.ProfileType = employee.ProfileType
+	}
+
+	swaps, err := s.shiftsRepo.ListShiftSwaps(ctx, filter)
+	if err != nil {
+		log.Errorf("failed to list shift swaps: %v", err)
+		return nil, fmt.Errorf("failed to retrieve shift swaps")
+	}
+
+	response := make([]employeeV1.ShiftSwapResponse, 0, len(swaps))
+	for i := range swaps {
+		response = append(response, swaps[i].ToResponse())
+	}
+	return response, nil
+}
+
+// AcceptShiftSwap lets a colleague cover an open offer by taking the shift over
+func (s *shiftService) AcceptShiftSwap(ctx context.Context, swapID, employeeID uint) (*employeeV1.ShiftSwapResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "ShiftService.AcceptShiftSwap")()
+	log.Infof("Employee %d accepting shift swap %d", employeeID, swapID)
+
+	swap, responder, err := s.swapForResponder(ctx, swapID, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateHandover(ctx, responder, swap.Shift, 0); err != nil {
+		return nil, err
+	}
+
+	swap.ResponderID = &employeeID
+	return s.settleSwap(ctx, swap, employeeV1.ShiftSwapOpen)
+}
+
+// ProposeShiftSwap offers one of the colleague's own shifts in exchange for an open offer; the requester
+// has to confirm it
+func (s *shiftService) ProposeShiftSwap(ctx context.Context, swapID, employeeID uint, req employeeV1.ProposeShiftSwapRequest) (*employeeV1.ShiftSwapResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "ShiftService.ProposeShiftSwap")()
+	log.Infof("Employee %d proposing shift %s/%d for swap %d", employeeID, req.ShiftDate, req.ShiftType, swapID)
+
+	swap, responder, err := s.swapForResponder(ctx, swapID, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	responderShift, err := s.ownedUpcomingShift(ctx, employeeID, req.ShiftDate, req.ShiftType)
+	if err != nil {
+		return nil, err
+	}
+
+	swap.ResponderID = &employeeID
+	swap.ResponderShiftID = &responderShift.ID
+	swap.ResponderShift = responderShift
+	if err := s.validateExchange(ctx, swap, responder); err != nil {
+		return nil, err
+	}
+
+	swap.Status = employeeV1.ShiftSwapProposed
+	if err := s.shiftsRepo.UpdateShiftSwap(ctx, swap, employeeV1.ShiftSwapOpen); err != nil {
+		return nil, s.swapUpdateError(log, swap, err)
+	}
+
+	log.Infof("Employee %d proposed shift %d for swap %d", employeeID, responderShift.ID, swapID)
+	resp := swap.ToResponse()
+	return &resp, nil
+}
+
+// ConfirmShiftSwap is the requester's answer to a proposed exchange. Declining reopens the offer for others.
+func (s *shiftService) ConfirmShiftSwap(ctx context.Context, swapID, employeeID uint, accept bool) (*employeeV1.ShiftSwapResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "ShiftService.ConfirmShiftSwap")()
+	log.Infof("Employee %d answering proposal on shift swap %d (accept=%t)", employeeID, swapID, accept)
+
+	swap, err := s.getSwap(ctx, swapID)
+	if err != nil {
+		return nil, err
+	}
+	if swap.RequesterID != employeeID {
+		return nil, commonv1.NewAppError(ErrorSwapForbidden, "only the employee who offered the shift can answer a proposal", nil)
+	}
+	if swap.Status != employeeV1.ShiftSwapProposed {
+		return nil, invalidSwapStateError(swap)
+	}
+
+	if !accept {
+		swap.Status = employeeV1.ShiftSwapOpen
+		swap.ResponderID, swap.ResponderShiftID, swap.ResponderShift = nil, nil, nil
+		if err := s.shiftsRepo.UpdateShiftSwap(ctx, swap, employeeV1.ShiftSwapProposed); err != nil {
+			return nil, s.swapUpdateError(log, swap, err)
+		}
+		resp := swap.ToResponse()
+		return &resp, nil
+	}
+
+	responder := &model.Employee{}
+	if err := s.emplRepo.GetEmployeeByID(ctx, *swap.ResponderID, responder); err != nil {
+		log.Errorf("failed to get responder %d: %v", *swap.ResponderID, err)
+		return nil, commonv1.NewAppError("EMPLOYEE_ERRORS.NOT_FOUND", "employee not found", nil)
+	}
+	if err := s.validateExchange(ctx, swap, responder); err != nil {
+		return nil, err
+	}
+	return s.settleSwap(ctx, swap, employeeV1.ShiftSwapProposed)
+}
+
+// CancelShiftSwap withdraws an offer that has not been handed over yet
+func (s *shiftService) CancelShiftSwap(ctx context.Context, swapID, actorID uint, isAdmin bool) (*employeeV1.ShiftSwapResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "ShiftService.CancelShiftSwap")()
+	log.Infof("Employee %d cancelling shift swap %d", actorID, swapID)
+
+	swap, err := s.getSwap(ctx, swapID)
+	if err != nil {
+		return nil, err
+	}
+	if swap.RequesterID != actorID && !isAdmin {
+		return nil, commonv1.NewAppError(ErrorSwapForbidden, "only the employee who offered the shift can cancel it", nil)
+	}
+	if !swap.Active() {
+		return nil, invalidSwapStateError(swap)
+	}
+
+	from := swap.Status
+	swap.Status = employeeV1.ShiftSwapCancelled
+	if err := s.shiftsRepo.UpdateShiftSwap(ctx, swap, from); err != nil {
+		return nil, s.swapUpdateError(log, swap, err)
+	}
+	resp := swap.ToResponse()
+	return &resp, nil
+}
+
+// ReviewShiftSwap is the administrator's decision on a swap held for approval
+func (s *shiftService) ReviewShiftSwap(ctx context.Context, swapID, adminID uint, approve bool) (*employeeV1.ShiftSwapResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "ShiftService.ReviewShiftSwap")()
+	log.Infof("Administrator %d reviewing shift swap %d (approve=%t)", adminID, swapID, approve)
+
+	swap, err := s.getSwap(ctx, swapID)
+	if err != nil {
+		return nil, err
+	}
+	if swap.Status != employeeV1.ShiftSwapPendingApproval {
+		return nil, invalidSwapStateError(swap)
+	}
+	swap.ReviewedBy = &adminID
+
+	if !approve {
+		swap.Status = employeeV1.ShiftSwapRejected
+		if err := s.shiftsRepo.UpdateShiftSwap(ctx, swap, employeeV1.ShiftSwapPendingApproval); err != nil {
+			return nil, s.swapUpdateError(log, swap, err)
+		}
+		resp := swap.ToResponse()
+		return &resp, nil
+	}
+
+	// Rosters may have changed while the swap waited, so the checks run again before handing over
+	responder := &model.Employee{}
+	if err := s.emplRepo.GetEmployeeByID(ctx, *swap.ResponderID, responder); err != nil {
+		log.Errorf("failed to get responder %d: %v", *swap.ResponderID, err)
+		return nil, commonv1.NewAppError("EMPLOYEE_ERRORS.NOT_FOUND", "employee not found", nil)
+	}
+	if swap.ResponderShiftID != nil {
+		err = s.validateExchange(ctx, swap, responder)
+	} else {
+		err = s.validateHandover(ctx, responder, swap.Shift, 0)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.completeSwap(ctx, swap, employeeV1.ShiftSwapPendingApproval)
+}
+
+// settleSwap either hands the shifts over or, when the policy asks for it, parks the agreed swap for approval
+func (s *shiftService) settleSwap(ctx context.Context, swap *model.ShiftSwap, from employeeV1.ShiftSwapStatus) (*employeeV1.ShiftSwapResponse, error) {
+	if !s.swaps.RequireApproval {
+		return s.completeSwap(ctx, swap, from)
+	}
+
+	log := s.log.WithContext(ctx)
+	swap.Status = employeeV1.ShiftSwapPendingApproval
+	if err := s.shiftsRepo.UpdateShiftSwap(ctx, swap, from); err != nil {
+		return nil, s.swapUpdateError(log, swap, err)
+	}
+	log.Infof("Shift swap %d agreed, waiting for administrator approval", swap.ID)
+	resp := swap.ToResponse()
+	return &resp, nil
+}
+
+func (s *shiftService) completeSwap(ctx context.Context, swap *model.ShiftSwap, from employeeV1.ShiftSwapStatus) (*employeeV1.ShiftSwapResponse, error) {
+	log := s.log.WithContext(ctx)
+	if err := s.shiftsRepo.CompleteShiftSwap(ctx, swap, from); err != nil {
+		return nil, s.swapUpdateError(log, swap, err)
+	}
+	log.Infof("Shift swap %d completed, shift %d handed to employee %d", swap.ID, swap.ShiftID, *swap.ResponderID)
+	resp := swap.ToResponse()
+	return &resp, nil
+}
+
+// swapForResponder loads an open swap and the colleague answering it, checking they may take it
+func (s *shiftService) swapForResponder(ctx context.Context, swapID, employeeID uint) (*model.ShiftSwap, *model.Employee, error) {
+	swap, err := s.getSwap(ctx, swapID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if swap.Status != employeeV1.ShiftSwapOpen {
+		return nil, nil, invalidSwapStateError(swap)
+	}
+	if swap.RequesterID == employeeID {
+		return nil, nil, commonv1.NewAppError(ErrorSwapForbidden, "employees cannot answer their own offer", nil)
+	}
+
+	responder := &model.Employee{}
+	if err := s.emplRepo.GetEmployeeByID(ctx, employeeID, responder); err != nil {
+		s.log.WithContext(ctx).Errorf("failed to get employee: %v", err)
+		return nil, nil, commonv1.NewAppError("EMPLOYEE_ERRORS.NOT_FOUND", "employee not found", nil)
+	}
+	if responder.ProfileType != swap.ProfileType {
+		return nil, nil, commonv1.NewAppError(ErrorSwapProfileMismatch,
+			fmt.Sprintf("only %s staff can take this shift", swap.ProfileType.String()),
+			map[string]interface{}{"role": swap.ProfileType.String()})
+	}
+	return swap, responder, nil
+}
+
+// validateExchange checks both directions of a proposed exchange, each employee as if they had already
+// given away the shift they hand over
+func (s *shiftService) validateExchange(ctx context.Context, swap *model.ShiftSwap, responder *model.Employee) error {
+	if err := s.validateHandover(ctx, responder, swap.Shift, *swap.ResponderShiftID); err != nil {
+		return err
+	}
+	requester := &model.Employee{}
+	if err := s.emplRepo.GetEmployeeByID(ctx, swap.RequesterID, requester); err != nil {
+		s.log.WithContext(ctx).Errorf("failed to get requester %d: %v", swap.RequesterID, err)
+		return commonv1.NewAppError("EMPLOYEE_ERRORS.NOT_FOUND", "employee not found", nil)
+	}
+	return s.validateHandover(ctx, requester, *swap.ResponderShift, swap.ShiftID)
+}
+
+// validateHandover runs the assignment rules of AssignShift for an employee taking over shift from a
+// same-profile colleague, ignoring releasedShiftID which they give up in the same swap
+func (s *shiftService) validateHandover(ctx context.Context, receiver *model.Employee, shift model.Shift, releasedShiftID uint) error {
+	log := s.log.WithContext(ctx)
+
+	if shift.ShiftDate.Before(time.Now().UTC().Truncate(24 * time.Hour)) {
+		return commonv1.NewAppError("VALIDATION.SHIFT_IN_PAST", "shift date must be in the future", nil)
+	}
+
+	if err := s.validateNotOnLeave(ctx, receiver.ID, shift.ShiftDate); err != nil {
+		return err
+	}
+
+	assigned, err := s.shiftsRepo.AssignedToShift(ctx, receiver.ID, shift.ID)
+	if err != nil {
+		log.Errorf("failed to check assignment: %v", err)
+		return fmt.Errorf("failed to check assignment")
+	}
+	if assigned {
+		return commonv1.NewAppError("SHIFT_ERRORS.ALREADY_ASSIGNED", "employee is already assigned to this shift", nil)
+	}
+
+	if err := s.validateConsecutiveShiftsWithout(ctx, receiver.ID, shift.ShiftDate, shift.ShiftType, releasedShiftID); err != nil {
+		log.Errorf("consecutive shifts validation failed for employee %d: %v", receiver.ID, err)
+		return err
+	}
+
+	// The colleague handing the shift over has the same profile, so their slot is the one being filled
+	currentCount, err := s.shiftsRepo.CountAssignmentsByProfile(ctx, shift.ID, receiver.ProfileType)
+	if err != nil {
+		log.Errorf("failed to count assignments: %v", err)
+		return fmt.Errorf("failed to check shift capacity")
+	}
+	maxCapacity, err := s.capacityOn(ctx, shift.ShiftDate, receiver.ProfileType)
+	if err != nil {
+		log.Errorf("failed to get shift capacity: %v", err)
+		return fmt.Errorf("failed to check shift capacity")
+	}
+	if currentCount-1 >= int64(maxCapacity) {
+		return commonv1.NewAppError("SHIFT_ERRORS.CAPACITY_FULL", fmt.Sprintf("shift capacity is full for %s staff", receiver.ProfileType.String()), map[string]interface{}{"role": receiver.ProfileType.String(), "max": maxCapacity})
+	}
+	return nil
+}
+
+// ownedUpcomingShift resolves a shift the employee is assigned to and that has not passed yet
+func (s *shiftService) ownedUpcomingShift(ctx context.Context, employeeID uint, rawDate string, shiftType int) (*model.Shift, error) {
+	log := s.log.WithContext(ctx)
+
+	shiftDate, err := time.ParseInLocation("2006-01-02", rawDate, time.UTC)
+	if err != nil {
+		log.Errorf("failed to parse shift date: %v", err)
+		return nil, commonv1.NewAppError("VALIDATION.INVALID_SHIFT_DATE", "invalid shift date format", nil)
+	}
+	shiftDate = shiftDate.UTC().Truncate(24 * time.Hour)
+	if shiftDate.Before(time.Now().UTC().Truncate(24 * time.Hour)) {
+		return nil, commonv1.NewAppError("VALIDATION.SHIFT_IN_PAST", "shift date must be in the future", nil)
+	}
+
+	notAssigned := commonv1.NewAppError(ErrorSwapNotAssigned, "employee is not assigned to this shift", nil)
+	shift, err := s.shiftsRepo.FindShift(ctx, shiftDate, shiftType)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, notAssigned
+	}
+	if err != nil {
+		log.Errorf("failed to find shift: %v", err)
+		return nil, fmt.Errorf("failed to find shift")
+	}
+
+	assigned, err := s.shiftsRepo.AssignedToShift(ctx, employeeID, shift.ID)
+	if err != nil {
+		log.Errorf("failed to check assignment: %v", err)
+		return nil, fmt.Errorf("failed to check assignment")
+	}
+	if !assigned {
+		return nil, notAssigned
+	}
+	return shift, nil
+}
+
+func (s *shiftService) getSwap(ctx context.Context, swapID uint) (*model.ShiftSwap, error) {
+	swap, err := s.shiftsRepo.GetShiftSwap(ctx, swapID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, commonv1.NewAppError(ErrorSwapNotFound, "shift swap not found", nil)
+	}
+	if err != nil {
+		s.log.WithContext(ctx).Errorf("failed to get shift swap %d: %v", swapID, err)
+		return nil, fmt.Errorf("failed to retrieve shift swap")
+	}
+	return swap, nil
+}
+
+// swapUpdateError maps a failed conditional update; losing a race to another answer reads as a state conflict
+func (s *shiftService) swapUpdateError(log utils.Logger, swap *model.ShiftSwap, err error) error {
+	if errors.Is(err, model.ErrSwapChanged) {
+		log.Warnf("Shift swap %d changed concurrently", swap.ID)
+		return commonv1.NewAppError(ErrorSwapInvalidState, "shift swap was changed by someone else, reload and try again", nil)
+	}
+	log.Errorf("failed to update shift swap %d: %v", swap.ID, err)
+	return fmt.Errorf("failed to update shift swap")
+}
+
+func invalidSwapStateError(swap *model.ShiftSwap) error {
+	return commonv1.NewAppError(ErrorSwapInvalidState, fmt.Sprintf("shift swap is %s", swap.Status), map[string]interface{}{"status": swap.Status})
+}
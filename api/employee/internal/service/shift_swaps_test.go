@@ -0,0 +1,438 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
+	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+	"github.com/pd120424d/mountain-service/api/employee/internal/model"
+	"github.com/pd120424d/mountain-service/api/employee/internal/repositories"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+func newSwapService(t *testing.T, policy SwapPolicy) (ShiftService, *repositories.MockEmployeeRepository, *repositories.MockShiftRepository) {
+	ctrl := gomock.NewController(t)
+	emplRepo := repositories.NewMockEmployeeRepository(ctrl)
+	shiftRepo := repositories.NewMockShiftRepository(ctrl)
+	svc := NewShiftServiceWithOptions(utils.NewTestLogger(), emplRepo, shiftRepo, nil, ShiftServiceOptions{Swaps: policy})
+	return svc, emplRepo, shiftRepo
+}
+
+func expectEmployee(emplRepo *repositories.MockEmployeeRepository, id uint, profile model.ProfileType) {
+	emplRepo.EXPECT().GetEmployeeByID(gomock.Any(), id, gomock.Any()).
+		DoAndReturn(func(_ context.Context, id uint, e *model.Employee) error {
+			e.ID = id
+			e.ProfileType = profile
+			return nil
+		}).AnyTimes()
+}
+
+// expectHandoverAllowed lets the receiving employee pass every assignment rule for the shift
+func expectHandoverAllowed(shiftRepo *repositories.MockShiftRepository, receiverID, shiftID uint, profile model.ProfileType) {
+	shiftRepo.EXPECT().HasApprovedLeave(gomock.Any(), receiverID, gomock.Any()).Return(false, nil)
+	shiftRepo.EXPECT().AssignedToShift(gomock.Any(), receiverID, shiftID).Return(false, nil)
+	shiftRepo.EXPECT().GetShiftsByEmployeeIDInDateRange(gomock.Any(), receiverID, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	shiftRepo.EXPECT().CountAssignmentsByProfile(gomock.Any(), shiftID, profile).Return(int64(2), nil)
+	shiftRepo.EXPECT().ListShiftCapacities(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+}
+
+func appErrorCode(t *testing.T, err error) string {
+	t.Helper()
+	appErr, ok := err.(*commonv1.AppError)
+	require.True(t, ok, "expected an AppError, got %v", err)
+	return appErr.Code
+}
+
+func TestShiftService_OfferShift(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	day := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 5)
+	req := employeeV1.OfferShiftRequest{ShiftDate: day.Format("2006-01-02"), ShiftType: 1, Note: "exam"}
+
+	t.Run("it opens an offer for the employee's shift", func(t *testing.T) {
+		svc, emplRepo, shiftRepo := newSwapService(t, DefaultSwapPolicy())
+		expectEmployee(emplRepo, 1, model.Medic)
+		shiftRepo.EXPECT().FindShift(gomock.Any(), day, 1).Return(&model.Shift{ID: 10, ShiftDate: day, ShiftType: 1}, nil)
+		shiftRepo.EXPECT().AssignedToShift(gomock.Any(), uint(1), uint(10)).Return(true, nil)
+		shiftRepo.EXPECT().HasActiveShiftSwap(gomock.Any(), uint(10), uint(1)).Return(false, nil)
+		shiftRepo.EXPECT().CreateShiftSwap(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, swap *model.ShiftSwap) error {
+			assert.Equal(t, model.Medic, swap.ProfileType)
+			assert.Equal(t, employeeV1.ShiftSwapOpen, swap.Status)
+			swap.ID = 5
+			return nil
+		})
+
+		resp, err := svc.OfferShift(ctx, 1, req)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(5), resp.ID)
+		assert.Equal(t, req.ShiftDate, resp.Shift.ShiftDate)
+		assert.Equal(t, "exam", resp.Note)
+	})
+
+	t.Run("it fails when the employee is not on the shift", func(t *testing.T) {
+		svc, emplRepo, shiftRepo := newSwapService(t, DefaultSwapPolicy())
+		expectEmployee(emplRepo, 1, model.Medic)
+		shiftRepo.EXPECT().FindShift(gomock.Any(), day, 1).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.OfferShift(ctx, 1, req)
+
+		assert.Equal(t, ErrorSwapNotAssigned, appErrorCode(t, err))
+	})
+
+	t.Run("it fails when the shift is already offered", func(t *testing.T) {
+		svc, emplRepo, shiftRepo := newSwapService(t, DefaultSwapPolicy())
+		expectEmployee(emplRepo, 1, model.Medic)
+		shiftRepo.EXPECT().FindShift(gomock.Any(), day, 1).Return(&model.Shift{ID: 10, ShiftDate: day, ShiftType: 1}, nil)
+		shiftRepo.EXPECT().AssignedToShift(gomock.Any(), uint(1), uint(10)).Return(true, nil)
+		shiftRepo.EXPECT().HasActiveShiftSwap(gomock.Any(), uint(10), uint(1)).Return(true, nil)
+
+		_, err := svc.OfferShift(ctx, 1, req)
+
+		assert.Equal(t, ErrorSwapAlreadyOffered, appErrorCode(t, err))
+	})
+
+	t.Run("it fails for a past shift", func(t *testing.T) {
+		svc, emplRepo, _ := newSwapService(t, DefaultSwapPolicy())
+		expectEmployee(emplRepo, 1, model.Medic)
+
+		_, err := svc.OfferShift(ctx, 1, employeeV1.OfferShiftRequest{ShiftDate: "2020-01-01", ShiftType: 1})
+
+		assert.Equal(t, "VALIDATION.SHIFT_IN_PAST", appErrorCode(t, err))
+	})
+}
+
+func TestShiftService_AcceptShiftSwap(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	day := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 5)
+	openSwap := func() *model.ShiftSwap {
+		return &model.ShiftSwap{ID: 5, ShiftID: 10, RequesterID: 1, ProfileType: model.Medic, Status: employeeV1.ShiftSwapOpen,
+			Shift: model.Shift{ID: 10, ShiftDate: day, ShiftType: 1}}
+	}
+
+	t.Run("it hands the shift over right away", func(t *testing.T) {
+		svc, emplRepo, shiftRepo := newSwapService(t, DefaultSwapPolicy())
+		shiftRepo.EXPECT().GetShiftSwap(gomock.Any(), uint(5)).Return(openSwap(), nil)
+		expectEmployee(emplRepo, 2, model.Medic)
+		expectHandoverAllowed(shiftRepo, 2, 10, model.Medic)
+		shiftRepo.EXPECT().CompleteShiftSwap(gomock.Any(), gomock.Any(), employeeV1.ShiftSwapOpen).
+			DoAndReturn(func(_ context.Context, swap *model.ShiftSwap, _ employeeV1.ShiftSwapStatus) error {
+				assert.Equal(t, uint(2), *swap.ResponderID)
+				swap.Status = employeeV1.ShiftSwapCompleted
+				return nil
+			})
+
+		resp, err := svc.AcceptShiftSwap(ctx, 5, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, employeeV1.ShiftSwapCompleted, resp.Status)
+	})
+
+	t.Run("it holds the swap for approval when the policy requires it", func(t *testing.T) {
+		svc, emplRepo, shiftRepo := newSwapService(t, SwapPolicy{RequireApproval: true})
+		shiftRepo.EXPECT().GetShiftSwap(gomock.Any(), uint(5)).Return(openSwap(), nil)
+		expectEmployee(emplRepo, 2, model.Medic)
+		expectHandoverAllowed(shiftRepo, 2, 10, model.Medic)
+		shiftRepo.EXPECT().UpdateShiftSwap(gomock.Any(), gomock.Any(), employeeV1.ShiftSwapOpen).Return(nil)
+
+		resp, err := svc.AcceptShiftSwap(ctx, 5, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, employeeV1.ShiftSwapPendingApproval, resp.Status)
+	})
+
+	t.Run("it refuses a colleague of another profile", func(t *testing.T) {
+		svc, emplRepo, shiftRepo := newSwapService(t, DefaultSwapPolicy())
+		shiftRepo.EXPECT().GetShiftSwap(gomock.Any(), uint(5)).Return(openSwap(), nil)
+		expectEmployee(emplRepo, 3, model.Technical)
+
+		_, err := svc.AcceptShiftSwap(ctx, 5, 3)
+
+		assert.Equal(t, ErrorSwapProfileMismatch, appErrorCode(t, err))
+	})
+
+	t.Run("it refuses the requester's own offer", func(t *testing.T) {
+		svc, _, shiftRepo := newSwapService(t, DefaultSwapPolicy())
+		shiftRepo.EXPECT().GetShiftSwap(gomock.Any(), uint(5)).Return(openSwap(), nil)
+
+		_, err := svc.AcceptShiftSwap(ctx, 5, 1)
+
+		assert.Equal(t, ErrorSwapForbidden, appErrorCode(t, err))
+	})
+
+	t.Run("it refuses a swap that is no longer open", func(t *testing.T) {
+		svc, _, shiftRepo := newSwapService(t, DefaultSwapPolicy())
+		swap := openSwap()
+		swap.Status = employeeV1.ShiftSwapCompleted
+		shiftRepo.EXPECT().GetShiftSwap(gomock.Any(), uint(5)).Return(swap, nil)
+
+		_, err := svc.AcceptShiftSwap(ctx, 5, 2)
+
+		assert.Equal(t, ErrorSwapInvalidState, appErrorCode(t, err))
+	})
+
+	t.Run("it returns not found for a missing swap", func(t *testing.T) {
+		svc, _, shiftRepo := newSwapService(t, DefaultSwapPolicy())
+		shiftRepo.EXPECT().GetShiftSwap(gomock.Any(), uint(5)).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.AcceptShiftSwap(ctx, 5, 2)
+
+		assert.Equal(t, ErrorSwapNotFound, appErrorCode(t, err))
+	})
+
+	t.Run("it enforces the consecutive shifts rule for the colleague", func(t *testing.T) {
+		svc, emplRepo, shiftRepo := newSwapService(t, DefaultSwapPolicy())
+		shiftRepo.EXPECT().GetShiftSwap(gomock.Any(), uint(5)).Return(openSwap(), nil)
+		expectEmployee(emplRepo, 2, model.Medic)
+		shiftRepo.EXPECT().HasApprovedLeave(gomock.Any(), uint(2), gomock.Any()).Return(false, nil)
+		shiftRepo.EXPECT().AssignedToShift(gomock.Any(), uint(2), uint(10)).Return(false, nil)
+		shiftRepo.EXPECT().GetShiftsByEmployeeIDInDateRange(gomock.Any(), uint(2), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uint, _, _ time.Time, result *[]model.Shift) error {
+				// the night before and the afternoon after would make three in a row
+				*result = []model.Shift{{ID: 20, ShiftDate: day.AddDate(0, 0, -1), ShiftType: 3}, {ID: 21, ShiftDate: day, ShiftType: 2}}
+				return nil
+			})
+
+		_, err := svc.AcceptShiftSwap(ctx, 5, 2)
+
+		assert.Equal(t, model.ErrorConsecutiveShiftsLimit, appErrorCode(t, err))
+	})
+
+	t.Run("it enforces shift capacity", func(t *testing.T) {
+		svc, emplRepo, shiftRepo := newSwapService(t, DefaultSwapPolicy())
+		shiftRepo.EXPECT().GetShiftSwap(gomock.Any(), uint(5)).Return(openSwap(), nil)
+		expectEmployee(emplRepo, 2, model.Medic)
+		shiftRepo.EXPECT().HasApprovedLeave(gomock.Any(), uint(2), gomock.Any()).Return(false, nil)
+		shiftRepo.EXPECT().AssignedToShift(gomock.Any(), uint(2), uint(10)).Return(false, nil)
+		shiftRepo.EXPECT().GetShiftsByEmployeeIDInDateRange(gomock.Any(), uint(2), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		shiftRepo.EXPECT().CountAssignmentsByProfile(gomock.Any(), uint(10), model.Medic).Return(int64(3), nil)
+		shiftRepo.EXPECT().ListShiftCapacities(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		_, err := svc.AcceptShiftSwap(ctx, 5, 2)
+
+		assert.Equal(t, "SHIFT_ERRORS.CAPACITY_FULL", appErrorCode(t, err))
+	})
+
+	t.Run("it reports a conflict when another colleague was faster", func(t *testing.T) {
+		svc, emplRepo, shiftRepo := newSwapService(t, DefaultSwapPolicy())
+		shiftRepo.EXPECT().GetShiftSwap(gomock.Any(), uint(5)).Return(openSwap(), nil)
+		expectEmployee(emplRepo, 2, model.Medic)
+		expectHandoverAllowed(shiftRepo, 2, 10, model.Medic)
+		shiftRepo.EXPECT().CompleteShiftSwap(gomock.Any(), gomock.Any(), employeeV1.ShiftSwapOpen).Return(model.ErrSwapChanged)
+
+		_, err := svc.AcceptShiftSwap(ctx, 5, 2)
+
+		assert.Equal(t, ErrorSwapInvalidState, appErrorCode(t, err))
+	})
+}
+
+func TestShiftService_ProposeAndConfirmShiftSwap(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	day := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 5)
+	offered := model.Shift{ID: 10, ShiftDate: day, ShiftType: 1}
+	other := model.Shift{ID: 11, ShiftDate: day, ShiftType: 2}
+	responderID, otherID := uint(2), uint(11)
+
+	t.Run("it records a proposal, ignoring the shift each side gives away", func(t *testing.T) {
+		svc, emplRepo, shiftRepo := newSwapService(t, DefaultSwapPolicy())
+		shiftRepo.EXPECT().GetShiftSwap(gomock.Any(), uint(5)).Return(&model.ShiftSwap{ID: 5, ShiftID: 10, RequesterID: 1, ProfileType: model.Medic, Status: employeeV1.ShiftSwapOpen, Shift: offered}, nil)
+		expectEmployee(emplRepo, 1, model.Medic)
+		expectEmployee(emplRepo, 2, model.Medic)
+		shiftRepo.EXPECT().FindShift(gomock.Any(), day, 2).Return(&other, nil)
+		shiftRepo.EXPECT().AssignedToShift(gomock.Any(), uint(2), uint(11)).Return(true, nil)
+
+		// Each employee's adjacent shift is the one they hand over, so the exchange must not count it
+		shiftRepo.EXPECT().HasApprovedLeave(gomock.Any(), uint(2), gomock.Any()).Return(false, nil)
+		shiftRepo.EXPECT().AssignedToShift(gomock.Any(), uint(2), uint(10)).Return(false, nil)
+		shiftRepo.EXPECT().GetShiftsByEmployeeIDInDateRange(gomock.Any(), uint(2), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uint, _, _ time.Time, result *[]model.Shift) error {
+				*result = []model.Shift{other, {ID: 30, ShiftDate: day, ShiftType: 3}}
+				return nil
+			})
+		shiftRepo.EXPECT().CountAssignmentsByProfile(gomock.Any(), uint(10), model.Medic).Return(int64(2), nil)
+		shiftRepo.EXPECT().ListShiftCapacities(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		shiftRepo.EXPECT().HasApprovedLeave(gomock.Any(), uint(1), gomock.Any()).Return(false, nil)
+		shiftRepo.EXPECT().AssignedToShift(gomock.Any(), uint(1), uint(11)).Return(false, nil)
+		shiftRepo.EXPECT().GetShiftsByEmployeeIDInDateRange(gomock.Any(), uint(1), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uint, _, _ time.Time, result *[]model.Shift) error {
+				*result = []model.Shift{offered}
+				return nil
+			})
+		shiftRepo.EXPECT().CountAssignmentsByProfile(gomock.Any(), uint(11), model.Medic).Return(int64(1), nil)
+		shiftRepo.EXPECT().ListShiftCapacities(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		shiftRepo.EXPECT().UpdateShiftSwap(gomock.Any(), gomock.Any(), employeeV1.ShiftSwapOpen).Return(nil)
+
+		resp, err := svc.ProposeShiftSwap(ctx, 5, 2, employeeV1.ProposeShiftSwapRequest{ShiftDate: day.Format("2006-01-02"), ShiftType: 2})
+
+		require.NoError(t, err)
+		assert.Equal(t, employeeV1.ShiftSwapProposed, resp.Status)
+		require.NotNil(t, resp.ResponderShift)
+		assert.Equal(t, uint(11), resp.ResponderShift.ShiftID)
+	})
+
+	proposed := func() *model.ShiftSwap {
+		return &model.ShiftSwap{ID: 5, ShiftID: 10, RequesterID: 1, ProfileType: model.Medic, Status: employeeV1.ShiftSwapProposed,
+			Shift: offered, ResponderID: &responderID, ResponderShiftID: &otherID, ResponderShift: &other}
+	}
+
+	t.Run("it only lets the requester answer", func(t *testing.T) {
+		svc, _, shiftRepo := newSwapService(t, DefaultSwapPolicy())
+		shiftRepo.EXPECT().GetShiftSwap(gomock.Any(), uint(5)).Return(proposed(), nil)
+
+		_, err := svc.ConfirmShiftSwap(ctx, 5, 2, true)
+
+		assert.Equal(t, ErrorSwapForbidden, appErrorCode(t, err))
+	})
+
+	t.Run("it reopens the offer when the requester declines", func(t *testing.T) {
+		svc, _, shiftRepo := newSwapService(t, DefaultSwapPolicy())
+		shiftRepo.EXPECT().GetShiftSwap(gomock.Any(), uint(5)).Return(proposed(), nil)
+		shiftRepo.EXPECT().UpdateShiftSwap(gomock.Any(), gomock.Any(), employeeV1.ShiftSwapProposed).
+			DoAndReturn(func(_ context.Context, swap *model.ShiftSwap, _ employeeV1.ShiftSwapStatus) error {
+				assert.Nil(t, swap.ResponderID)
+				assert.Nil(t, swap.ResponderShiftID)
+				return nil
+			})
+
+		resp, err := svc.ConfirmShiftSwap(ctx, 5, 1, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, employeeV1.ShiftSwapOpen, resp.Status)
+		assert.Nil(t, resp.ResponderShift)
+	})
+
+	t.Run("it exchanges the shifts when the requester confirms", func(t *testing.T) {
+		svc, emplRepo, shiftRepo := newSwapService(t, DefaultSwapPolicy())
+		shiftRepo.EXPECT().GetShiftSwap(gomock.Any(), uint(5)).Return(proposed(), nil)
+		expectEmployee(emplRepo, 1, model.Medic)
+		expectEmployee(emplRepo, 2, model.Medic)
+		expectHandoverAllowed(shiftRepo, 2, 10, model.Medic)
+		expectHandoverAllowed(shiftRepo, 1, 11, model.Medic)
+		shiftRepo.EXPECT().CompleteShiftSwap(gomock.Any(), gomock.Any(), employeeV1.ShiftSwapProposed).
+			DoAndReturn(func(_ context.Context, swap *model.ShiftSwap, _ employeeV1.ShiftSwapStatus) error {
+				swap.Status = employeeV1.ShiftSwapCompleted
+				return nil
+			})
+
+		resp, err := svc.ConfirmShiftSwap(ctx, 5, 1, true)
+
+		require.NoError(t, err)
+		assert.Equal(t, employeeV1.ShiftSwapCompleted, resp.Status)
+	})
+}
+
+func TestShiftService_CancelAndReviewShiftSwap(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	day := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 5)
+	responderID := uint(2)
+	pending := func() *model.ShiftSwap {
+		return &model.ShiftSwap{ID: 5, ShiftID: 10, RequesterID: 1, ProfileType: model.Medic, Status: employeeV1.ShiftSwapPendingApproval,
+			Shift: model.Shift{ID: 10, ShiftDate: day, ShiftType: 1}, ResponderID: &responderID}
+	}
+
+	t.Run("it refuses to cancel someone else's offer", func(t *testing.T) {
+		svc, _, shiftRepo := newSwapService(t, DefaultSwapPolicy())
+		shiftRepo.EXPECT().GetShiftSwap(gomock.Any(), uint(5)).Return(pending(), nil)
+
+		_, err := svc.CancelShiftSwap(ctx, 5, 2, false)
+
+		assert.Equal(t, ErrorSwapForbidden, appErrorCode(t, err))
+	})
+
+	t.Run("it lets an administrator cancel any active offer", func(t *testing.T) {
+		svc, _, shiftRepo := newSwapService(t, DefaultSwapPolicy())
+		shiftRepo.EXPECT().GetShiftSwap(gomock.Any(), uint(5)).Return(pending(), nil)
+		shiftRepo.EXPECT().UpdateShiftSwap(gomock.Any(), gomock.Any(), employeeV1.ShiftSwapPendingApproval).Return(nil)
+
+		resp, err := svc.CancelShiftSwap(ctx, 5, 9, true)
+
+		require.NoError(t, err)
+		assert.Equal(t, employeeV1.ShiftSwapCancelled, resp.Status)
+	})
+
+	t.Run("it rejects a pending swap", func(t *testing.T) {
+		svc, _, shiftRepo := newSwapService(t, SwapPolicy{RequireApproval: true})
+		shiftRepo.EXPECT().GetShiftSwap(gomock.Any(), uint(5)).Return(pending(), nil)
+		shiftRepo.EXPECT().UpdateShiftSwap(gomock.Any(), gomock.Any(), employeeV1.ShiftSwapPendingApproval).Return(nil)
+
+		resp, err := svc.ReviewShiftSwap(ctx, 5, 9, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, employeeV1.ShiftSwapRejected, resp.Status)
+		assert.Equal(t, uint(9), *resp.ReviewedBy)
+	})
+
+	t.Run("it re-checks the rules before completing an approved swap", func(t *testing.T) {
+		svc, emplRepo, shiftRepo := newSwapService(t, SwapPolicy{RequireApproval: true})
+		shiftRepo.EXPECT().GetShiftSwap(gomock.Any(), uint(5)).Return(pending(), nil)
+		expectEmployee(emplRepo, 2, model.Medic)
+		shiftRepo.EXPECT().HasApprovedLeave(gomock.Any(), uint(2), gomock.Any()).Return(false, nil)
+		shiftRepo.EXPECT().AssignedToShift(gomock.Any(), uint(2), uint(10)).Return(true, nil)
+
+		_, err := svc.ReviewShiftSwap(ctx, 5, 9, true)
+
+		assert.Equal(t, "SHIFT_ERRORS.ALREADY_ASSIGNED", appErrorCode(t, err))
+	})
+
+	t.Run("it completes an approved swap", func(t *testing.T) {
+		svc, emplRepo, shiftRepo := newSwapService(t, SwapPolicy{RequireApproval: true})
+		shiftRepo.EXPECT().GetShiftSwap(gomock.Any(), uint(5)).Return(pending(), nil)
+		expectEmployee(emplRepo, 2, model.Medic)
+		expectHandoverAllowed(shiftRepo, 2, 10, model.Medic)
+		shiftRepo.EXPECT().CompleteShiftSwap(gomock.Any(), gomock.Any(), employeeV1.ShiftSwapPendingApproval).
+			DoAndReturn(func(_ context.Context, swap *model.ShiftSwap, _ employeeV1.ShiftSwapStatus) error {
+				swap.Status = employeeV1.ShiftSwapCompleted
+				return nil
+			})
+
+		resp, err := svc.ReviewShiftSwap(ctx, 5, 9, true)
+
+		require.NoError(t, err)
+		assert.Equal(t, employeeV1.ShiftSwapCompleted, resp.Status)
+	})
+}
+
+func TestShiftService_ListShiftSwaps(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("it limits employees to their own profile", func(t *testing.T) {
+		svc, emplRepo, shiftRepo := newSwapService(t, DefaultSwapPolicy())
+		expectEmployee(emplRepo, 1, model.Technical)
+		shiftRepo.EXPECT().ListShiftSwaps(gomock.Any(), repositories.ShiftSwapFilter{ProfileType: model.Technical, Status: employeeV1.ShiftSwapOpen}).
+			Return([]model.ShiftSwap{{ID: 1, Status: employeeV1.ShiftSwapOpen, ProfileType: model.Technical}}, nil)
+
+		resp, err := svc.ListShiftSwaps(ctx, 1, false, employeeV1.ShiftSwapOpen)
+
+		require.NoError(t, err)
+		assert.Len(t, resp, 1)
+	})
+
+	t.Run("it lists every profile for administrators", func(t *testing.T) {
+		svc, _, shiftRepo := newSwapService(t, DefaultSwapPolicy())
+		shiftRepo.EXPECT().ListShiftSwaps(gomock.Any(), repositories.ShiftSwapFilter{}).Return(nil, nil)
+
+		resp, err := svc.ListShiftSwaps(ctx, 9, true, "")
+
+		require.NoError(t, err)
+		assert.Empty(t, resp)
+	})
+
+	t.Run("it rejects an unknown status", func(t *testing.T) {
+		svc, _, _ := newSwapService(t, DefaultSwapPolicy())
+
+		_, err := svc.ListShiftSwaps(ctx, 1, false, "someday")
+
+		assert.Equal(t, ErrorSwapInvalidStatus, appErrorCode(t, err))
+	})
+}
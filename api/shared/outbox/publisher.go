@@ -0,0 +1,177 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pd120424d/mountain-service/api/shared/models"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+// Repository reads the outbox of a service and records which events were published
+type Repository interface {
+	GetUnpublishedEvents(ctx context.Context, limit int) ([]*models.OutboxEvent, error)
+	MarkAsPublished(ctx context.Context, eventID uint) error
+}
+
+// Sink receives outbox events. Publish returns one error per event, in the same order;
+// a nil error means the event was accepted by the sink.
+type Sink interface {
+	Publish(ctx context.Context, events []*models.OutboxEvent) []error
+}
+
+type Config struct {
+	// Name identifies the publisher in logs, e.g. the topic it publishes to
+	Name      string
+	Interval  time.Duration
+	BatchSize int
+}
+
+// Publisher polls the outbox and hands unpublished events to its sinks. An event is marked
+// as published only when every sink accepted it, so a failing sink gets it again on the next
+// cycle; sinks must therefore tolerate duplicates.
+type Publisher struct {
+	log    utils.Logger
+	repo   Repository
+	sinks  []Sink
+	config Config
+}
+
+func NewPublisher(log utils.Logger, repo Repository, cfg Config, sinks ...Sink) *Publisher {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 1 * time.Second // lower default to reduce e2e latency
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	return &Publisher{log: log.WithName("outboxPublisher"), repo: repo, sinks: sinks, config: cfg}
+}
+
+func (p *Publisher) Start(ctx context.Context) {
+	ctx, _ = utils.EnsureRequestID(ctx)
+	// Adaptive polling: fast when backlog exists, exponential backoff when idle.
+	minInterval := 1 * time.Second
+	maxInterval := p.config.Interval
+	if maxInterval < minInterval {
+		maxInterval = minInterval
+	}
+	interval := minInterval
+
+	minBatch := 50
+	maxBatch := 2000
+	batch := p.config.BatchSize
+	if batch < minBatch {
+		batch = minBatch
+	}
+
+	p.log.Infof("Starting outbox publisher: name=%s sinks=%d minInterval=%s maxInterval=%s initialBatch=%d", p.config.Name, len(p.sinks), minInterval, maxInterval, batch)
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				p.log.WithContext(ctx).Info("Stopping outbox publisher")
+				return
+			default:
+			}
+
+			fetched, err := p.processBatch(ctx, batch)
+			if err != nil {
+				p.log.WithContext(ctx).Errorf("publisher cycle error: %v", err)
+			}
+
+			if err != nil || fetched == 0 {
+				// No backlog or a failing outbox: back off interval up to max and slowly shrink batch down
+				if interval < maxInterval {
+					interval *= 2
+					if interval > maxInterval {
+						interval = maxInterval
+					}
+				}
+				if batch > minBatch {
+					batch = batch / 2
+					if batch < minBatch {
+						batch = minBatch
+					}
+				}
+				time.Sleep(interval)
+				continue
+			}
+
+			// If we exactly filled the batch, likely more backlog -> speed up and grow batch
+			if fetched == batch {
+				if batch < maxBatch {
+					batch *= 2
+					if batch > maxBatch {
+						batch = maxBatch
+					}
+				}
+				interval = minInterval
+				// Immediately continue to drain without sleeping
+				continue
+			}
+
+			// Some backlog but less than batch: keep interval low for low latency and small sleep
+			interval = minInterval
+			time.Sleep(100 * time.Millisecond)
+		}
+	}()
+}
+
+// ProcessOnce publishes one batch of the configured size and returns how many events were marked as published
+func (p *Publisher) ProcessOnce(ctx context.Context) (int, error) {
+	ctx, _ = utils.EnsureRequestID(ctx)
+	events, err := p.repo.GetUnpublishedEvents(ctx, p.config.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("get unpublished: %w", err)
+	}
+	return p.publish(ctx, events), nil
+}
+
+// processBatch publishes one batch and returns how many events were fetched from the outbox
+func (p *Publisher) processBatch(ctx context.Context, batch int) (int, error) {
+	events, err := p.repo.GetUnpublishedEvents(ctx, batch)
+	if err != nil {
+		return 0, fmt.Errorf("get unpublished: %w", err)
+	}
+	p.publish(ctx, events)
+	return len(events), nil
+}
+
+func (p *Publisher) publish(ctx context.Context, events []*models.OutboxEvent) int {
+	log := p.log.WithContext(ctx)
+	if len(events) == 0 {
+		log.Debug("No unpublished events")
+		return 0
+	}
+	defer utils.TimeOperation(log, "OutboxPublisher.publish")()
+
+	failed := make([]bool, len(events))
+	for _, sink := range p.sinks {
+		errs := sink.Publish(ctx, events)
+		for i := range events {
+			if i >= len(errs) {
+				break
+			}
+			if errs[i] != nil {
+				log.Errorf("failed to publish event id=%d: %v", events[i].ID, errs[i])
+				failed[i] = true
+			}
+		}
+	}
+
+	sent := 0
+	for i, e := range events {
+		if failed[i] {
+			continue
+		}
+		if err := p.repo.MarkAsPublished(ctx, e.ID); err != nil {
+			log.Errorf("failed to mark published id=%d: %v", e.ID, err)
+			continue
+		}
+		sent++
+	}
+	log.Infof("Published %d/%d events", sent, len(events))
+	return sent
+}
@@ -0,0 +1,130 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pd120424d/mountain-service/api/shared/models"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+type fakeRepo struct {
+	events    []*models.OutboxEvent
+	published []uint
+	getErr    error
+}
+
+func (r *fakeRepo) GetUnpublishedEvents(_ context.Context, limit int) ([]*models.OutboxEvent, error) {
+	if r.getErr != nil {
+		return nil, r.getErr
+	}
+	if limit < len(r.events) {
+		return r.events[:limit], nil
+	}
+	return r.events, nil
+}
+
+func (r *fakeRepo) MarkAsPublished(_ context.Context, eventID uint) error {
+	r.published = append(r.published, eventID)
+	return nil
+}
+
+type fakeSink struct {
+	received []uint
+	failIDs  map[uint]bool
+}
+
+func (s *fakeSink) Publish(_ context.Context, events []*models.OutboxEvent) []error {
+	errs := make([]error, len(events))
+	for i, e := range events {
+		s.received = append(s.received, e.ID)
+		if s.failIDs[e.ID] {
+			errs[i] = errors.New("sink down")
+		}
+	}
+	return errs
+}
+
+func TestPublisher_ProcessOnce(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it marks events accepted by every sink as published", func(t *testing.T) {
+		repo := &fakeRepo{events: []*models.OutboxEvent{{ID: 1}, {ID: 2}, {ID: 3}}}
+		first := &fakeSink{}
+		second := &fakeSink{failIDs: map[uint]bool{2: true}}
+		p := NewPublisher(utils.NewTestLogger(), repo, Config{BatchSize: 10}, first, second)
+
+		sent, err := p.ProcessOnce(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, sent)
+		assert.Equal(t, []uint{1, 3}, repo.published)
+		assert.Equal(t, []uint{1, 2, 3}, first.received)
+		assert.Equal(t, []uint{1, 2, 3}, second.received)
+	})
+
+	t.Run("it respects the batch size", func(t *testing.T) {
+		repo := &fakeRepo{events: []*models.OutboxEvent{{ID: 1}, {ID: 2}, {ID: 3}}}
+		p := NewPublisher(utils.NewTestLogger(), repo, Config{BatchSize: 2}, &fakeSink{})
+
+		sent, err := p.ProcessOnce(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, sent)
+		assert.Equal(t, []uint{1, 2}, repo.published)
+	})
+
+	t.Run("it returns the outbox error", func(t *testing.T) {
+		repo := &fakeRepo{getErr: errors.New("db down")}
+		p := NewPublisher(utils.NewTestLogger(), repo, Config{}, &fakeSink{})
+
+		_, err := p.ProcessOnce(ctx)
+		assert.ErrorIs(t, err, repo.getErr)
+	})
+}
+
+type fakeResult struct{ err error }
+
+func (r fakeResult) Get(context.Context) (string, error) { return "server-id", r.err }
+
+type fakeTopic struct {
+	messages []*pubsub.Message
+	failAt   int
+	stopped  bool
+}
+
+func (t *fakeTopic) Publish(_ context.Context, m *pubsub.Message) publishResult {
+	t.messages = append(t.messages, m)
+	if len(t.messages) == t.failAt {
+		return fakeResult{err: errors.New("publish failed")}
+	}
+	return fakeResult{}
+}
+
+func (t *fakeTopic) Stop() { t.stopped = true }
+
+func TestPubSubSink_Publish(t *testing.T) {
+	ft := &fakeTopic{failAt: 2}
+	sink := &PubSubSink{log: utils.NewTestLogger(), topicName: "urgency-events", topicFactory: func(string) topic { return ft }}
+
+	errs := sink.Publish(context.Background(), []*models.OutboxEvent{
+		{ID: 1, AggregateID: "urgency-7", EventData: `{"type":"urgency.created"}`},
+		{ID: 2, AggregateID: "urgency-8", EventData: `{"type":"urgency.closed"}`},
+	})
+
+	require.Len(t, errs, 2)
+	assert.NoError(t, errs[0])
+	assert.Error(t, errs[1])
+	assert.True(t, ft.stopped)
+	require.Len(t, ft.messages, 2)
+	assert.Equal(t, "urgency-7", ft.messages[0].Attributes["aggregateId"])
+
+	var envelope models.OutboxEvent
+	require.NoError(t, json.Unmarshal(ft.messages[0].Data, &envelope))
+	assert.Equal(t, uint(1), envelope.ID)
+	assert.Equal(t, `{"type":"urgency.created"}`, envelope.EventData)
+}
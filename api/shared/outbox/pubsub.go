@@ -0,0 +1,115 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"google.golang.org/api/option"
+
+	"github.com/pd120424d/mountain-service/api/shared/models"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+// publishResult abstracts Pub/Sub publish result for testability
+type publishResult interface {
+	Get(ctx context.Context) (serverID string, err error)
+}
+
+// topic abstracts Pub/Sub topic for testability
+type topic interface {
+	Publish(ctx context.Context, m *pubsub.Message) publishResult
+	Stop()
+}
+
+// realTopic adapts *pubsub.Topic to the topic interface
+type realTopic struct{ t *pubsub.Topic }
+
+func (rt realTopic) Publish(ctx context.Context, m *pubsub.Message) publishResult {
+	return rt.t.Publish(ctx, m)
+}
+func (rt realTopic) Stop() { rt.t.Stop() }
+
+// PubSubSink publishes outbox rows to a Pub/Sub topic. Each message carries the whole row as
+// JSON with the aggregate ID as an attribute, so consumers can route and order by aggregate.
+type PubSubSink struct {
+	log          utils.Logger
+	topicName    string
+	topicFactory func(name string) topic
+}
+
+func NewPubSubSink(log utils.Logger, client *pubsub.Client, topicName string) *PubSubSink {
+	s := &PubSubSink{log: log.WithName("pubSubSink"), topicName: topicName}
+	s.topicFactory = func(name string) topic {
+		t := client.Topic(name)
+		// Moderate batching/concurrency to improve throughput while staying safe by default
+		t.PublishSettings.NumGoroutines = 4
+		t.PublishSettings.DelayThreshold = 25 * time.Millisecond // flush faster under low volume
+		t.PublishSettings.CountThreshold = 100                   // smaller threshold improves latency
+		return realTopic{t: t}
+	}
+	return s
+}
+
+func (s *PubSubSink) Publish(ctx context.Context, events []*models.OutboxEvent) []error {
+	errs := make([]error, len(events))
+	topic := s.topicFactory(s.topicName)
+	defer topic.Stop()
+
+	results := make([]publishResult, len(events))
+	// Queue all publishes first to enable client-side batching
+	for i, e := range events {
+		data, err := json.Marshal(e)
+		if err != nil {
+			errs[i] = fmt.Errorf("marshal outbox envelope: %w", err)
+			continue
+		}
+		results[i] = topic.Publish(ctx, &pubsub.Message{
+			Data:       data,
+			Attributes: map[string]string{"aggregateId": e.AggregateID},
+		})
+	}
+	for i, res := range results {
+		if res == nil {
+			continue
+		}
+		if _, err := res.Get(ctx); err != nil {
+			errs[i] = fmt.Errorf("publish to %s: %w", s.topicName, err)
+		}
+	}
+	return errs
+}
+
+// NewPubSubClientFromEnv builds a Pub/Sub client from FIREBASE_PROJECT_ID or GOOGLE_CLOUD_PROJECT and
+// the credentials file in FIREBASE_CREDENTIALS_PATH or GOOGLE_APPLICATION_CREDENTIALS, falling back to
+// Application Default Credentials. It returns a nil client when no project is configured.
+func NewPubSubClientFromEnv(ctx context.Context, log utils.Logger) (*pubsub.Client, error) {
+	projectID := os.Getenv("FIREBASE_PROJECT_ID")
+	if projectID == "" {
+		projectID = os.Getenv("GOOGLE_CLOUD_PROJECT")
+	}
+	if projectID == "" {
+		return nil, nil
+	}
+
+	credsPath := os.Getenv("FIREBASE_CREDENTIALS_PATH")
+	if credsPath == "" {
+		credsPath = os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
+	}
+	useADC := false
+	if credsPath != "" {
+		if info, statErr := os.Stat(credsPath); statErr != nil || info.Size() == 0 {
+			log.Warnf("Credentials path set but file missing/empty (path=%s). Falling back to ADC.", credsPath)
+			useADC = true
+		}
+	}
+	if credsPath != "" && !useADC {
+		log.Infof("Initializing Pub/Sub client with credentials file: %s", credsPath)
+		return pubsub.NewClient(ctx, projectID, option.WithCredentialsFile(credsPath))
+	}
+	log.Info("Initializing Pub/Sub client using Application Default Credentials (ADC)")
+	return pubsub.NewClient(ctx, projectID)
+}
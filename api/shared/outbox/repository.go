@@ -0,0 +1,36 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/pd120424d/mountain-service/api/shared/models"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+type gormRepository struct {
+	log utils.Logger
+	db  *gorm.DB
+}
+
+// NewRepository returns a Repository over the outbox_events table of the given database
+func NewRepository(log utils.Logger, db *gorm.DB) Repository {
+	return &gormRepository{log: log.WithName("outboxRepository"), db: db}
+}
+
+func (r *gormRepository) GetUnpublishedEvents(ctx context.Context, limit int) ([]*models.OutboxEvent, error) {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "OutboxRepository.GetUnpublishedEvents")()
+	var events []*models.OutboxEvent
+	err := r.db.WithContext(ctx).Where("published = ?", false).Order("id ASC").Limit(limit).Find(&events).Error
+	return events, err
+}
+
+func (r *gormRepository) MarkAsPublished(ctx context.Context, eventID uint) error {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "OutboxRepository.MarkAsPublished")()
+	return r.db.WithContext(ctx).Model(&models.OutboxEvent{}).Where("id = ?", eventID).
+		Updates(map[string]interface{}{"published": true, "published_at": time.Now().UTC()}).Error
+}
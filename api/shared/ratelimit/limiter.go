@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter counts hits per key in fixed windows. Allow records one hit and reports whether the key is
+// still within limit hits per window, and if not, how long until the window resets.
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, time.Duration, error)
+}
+
+type memoryWindow struct {
+	count   int
+	resetAt time.Time
+}
+
+// MemoryLimiter keeps counters in process memory. Each replica limits on its own, so the effective
+// limit grows with the number of replicas.
+type MemoryLimiter struct {
+	mu      sync.Mutex
+	windows map[string]*memoryWindow
+	now     func() time.Time
+	// nextSweep is when expired windows are dropped next, so idle keys do not pile up
+	nextSweep time.Time
+}
+
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{windows: make(map[string]*memoryWindow), now: time.Now}
+}
+
+func (l *MemoryLimiter) Allow(_ context.Context, key string, limit int, window time.Duration) (bool, time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.After(l.nextSweep) {
+		for k, w := range l.windows {
+			if !now.Before(w.resetAt) {
+				delete(l.windows, k)
+			}
+		}
+		l.nextSweep = now.Add(time.Minute)
+	}
+
+	w, ok := l.windows[key]
+	if !ok || !now.Before(w.resetAt) {
+		w = &memoryWindow{resetAt: now.Add(window)}
+		l.windows[key] = w
+	}
+	w.count++
+	if w.count > limit {
+		return false, w.resetAt.Sub(now), nil
+	}
+	return true, 0, nil
+}
@@ -0,0 +1,48 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryLimiter_Allow(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it allows up to the limit per window and key", func(t *testing.T) {
+		l := NewMemoryLimiter()
+		now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+		l.now = func() time.Time { return now }
+
+		for i := 0; i < 2; i++ {
+			allowed, _, err := l.Allow(context.Background(), "ip:1", 2, time.Minute)
+			require.NoError(t, err)
+			assert.True(t, allowed)
+		}
+		allowed, retryAfter, err := l.Allow(context.Background(), "ip:1", 2, time.Minute)
+		require.NoError(t, err)
+		assert.False(t, allowed)
+		assert.Equal(t, time.Minute, retryAfter)
+
+		allowed, _, _ = l.Allow(context.Background(), "ip:2", 2, time.Minute)
+		assert.True(t, allowed)
+
+		now = now.Add(time.Minute)
+		allowed, _, _ = l.Allow(context.Background(), "ip:1", 2, time.Minute)
+		assert.True(t, allowed)
+	})
+
+	t.Run("it drops expired windows", func(t *testing.T) {
+		l := NewMemoryLimiter()
+		now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+		l.now = func() time.Time { return now }
+		_, _, _ = l.Allow(context.Background(), "ip:1", 1, time.Second)
+
+		now = now.Add(2 * time.Minute)
+		_, _, _ = l.Allow(context.Background(), "ip:2", 1, time.Second)
+		assert.Len(t, l.windows, 1)
+	})
+}
@@ -0,0 +1,53 @@
+package ratelimit
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+// Rule limits a group of routes to Limit requests per Window. Name keeps counters of different
+// rules apart when they share a limiter.
+type Rule struct {
+	Name   string
+	Limit  int
+	Window time.Duration
+}
+
+// ByClientIP limits requests per client IP
+func ByClientIP(ctx *gin.Context) string {
+	return ctx.ClientIP()
+}
+
+// Middleware rejects requests over the rule's limit with 429 and a Retry-After header. Requests
+// without a key are not limited, and a failing limiter lets requests through rather than
+// taking the routes down with it.
+func Middleware(log utils.Logger, limiter Limiter, rule Rule, key func(ctx *gin.Context) string) gin.HandlerFunc {
+	log = log.WithName("rateLimit")
+	return func(ctx *gin.Context) {
+		k := key(ctx)
+		if k == "" || rule.Limit <= 0 {
+			ctx.Next()
+			return
+		}
+		allowed, retryAfter, err := limiter.Allow(ctx.Request.Context(), rule.Name+":"+k, rule.Limit, rule.Window)
+		if err != nil {
+			log.WithContext(ctx.Request.Context()).Errorf("rate limiter failed for rule %s, allowing request: %v", rule.Name, err)
+			ctx.Next()
+			return
+		}
+		if !allowed {
+			seconds := int(math.Ceil(retryAfter.Seconds()))
+			log.WithContext(ctx.Request.Context()).Warnf("rate limit %s exceeded by %s, retry after %ds", rule.Name, k, seconds)
+			ctx.Header("Retry-After", strconv.Itoa(seconds))
+			ctx.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "RATE_LIMITED", "details": "too many requests, try again later"})
+			return
+		}
+		ctx.Next()
+	}
+}
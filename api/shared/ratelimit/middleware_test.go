@@ -0,0 +1,57 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+type failingLimiter struct{}
+
+func (failingLimiter) Allow(context.Context, string, int, time.Duration) (bool, time.Duration, error) {
+	return false, 0, errors.New("redis down")
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(limiter Limiter, rule Rule) *gin.Engine {
+		r := gin.New()
+		r.GET("/track", Middleware(utils.NewTestLogger(), limiter, rule, ByClientIP), func(ctx *gin.Context) {
+			ctx.Status(http.StatusOK)
+		})
+		return r
+	}
+	get := func(r *gin.Engine, ip string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/track", nil)
+		req.RemoteAddr = ip + ":1234"
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("it rejects requests over the limit with retry after", func(t *testing.T) {
+		r := newRouter(NewMemoryLimiter(), Rule{Name: "tracking", Limit: 1, Window: time.Minute})
+
+		assert.Equal(t, http.StatusOK, get(r, "10.0.0.1").Code)
+		w := get(r, "10.0.0.1")
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "60", w.Header().Get("Retry-After"))
+		assert.Contains(t, w.Body.String(), "RATE_LIMITED")
+		assert.Equal(t, http.StatusOK, get(r, "10.0.0.2").Code)
+	})
+
+	t.Run("it lets requests through when the limiter fails", func(t *testing.T) {
+		r := newRouter(failingLimiter{}, Rule{Name: "tracking", Limit: 1, Window: time.Minute})
+
+		assert.Equal(t, http.StatusOK, get(r, "10.0.0.1").Code)
+	})
+}
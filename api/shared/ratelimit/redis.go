@@ -0,0 +1,72 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+const redisKeyPrefix = "ratelimit:"
+
+// allowScript increments the counter and starts the window on the first hit in one round trip,
+// so a crash between INCR and PEXPIRE cannot leave a counter without expiry
+var allowScript = redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+if count == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+local ttl = redis.call("PTTL", KEYS[1])
+if ttl < 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+	ttl = tonumber(ARGV[1])
+end
+return {count, ttl}
+`)
+
+// RedisLimiter keeps counters in Redis, so all replicas share one limit per key
+type RedisLimiter struct {
+	client *redis.Client
+}
+
+func NewRedisLimiter(client *redis.Client) *RedisLimiter {
+	return &RedisLimiter{client: client}
+}
+
+func (l *RedisLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, time.Duration, error) {
+	res, err := allowScript.Run(ctx, l.client, []string{redisKeyPrefix + key}, window.Milliseconds()).Int64Slice()
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to count hit for %s: %w", key, err)
+	}
+	if len(res) != 2 {
+		return false, 0, fmt.Errorf("unexpected rate limit reply for %s: %v", key, res)
+	}
+	if res[0] > int64(limit) {
+		return false, time.Duration(res[1]) * time.Millisecond, nil
+	}
+	return true, 0, nil
+}
+
+// FallbackLimiter asks the primary limiter and switches to the fallback for the calls where the
+// primary fails, so an unavailable Redis degrades to per-replica limits instead of none.
+type FallbackLimiter struct {
+	log      utils.Logger
+	primary  Limiter
+	fallback Limiter
+}
+
+func NewFallbackLimiter(log utils.Logger, primary, fallback Limiter) *FallbackLimiter {
+	return &FallbackLimiter{log: log.WithName("rateLimit"), primary: primary, fallback: fallback}
+}
+
+func (l *FallbackLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, time.Duration, error) {
+	allowed, retryAfter, err := l.primary.Allow(ctx, key, limit, window)
+	if err == nil {
+		return allowed, retryAfter, nil
+	}
+	l.log.WithContext(ctx).Warnf("primary rate limiter failed, using fallback: %v", err)
+	return l.fallback.Allow(ctx, key, limit, window)
+}
@@ -0,0 +1,84 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+func TestRedisLimiter_Integration(t *testing.T) {
+	t.Parallel()
+
+	client := redis.NewClient(&redis.Options{Addr: "localhost:6379", DB: 1})
+	defer client.Close()
+	ctx := context.Background()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skipf("Redis not available, skipping integration tests: %v", err)
+		return
+	}
+	l := NewRedisLimiter(client)
+
+	t.Run("it allows up to the limit and reports the remaining window", func(t *testing.T) {
+		key := fmt.Sprintf("test:%d", time.Now().UnixNano())
+		defer client.Del(ctx, redisKeyPrefix+key)
+
+		for i := 0; i < 2; i++ {
+			allowed, _, err := l.Allow(ctx, key, 2, time.Minute)
+			require.NoError(t, err)
+			assert.True(t, allowed)
+		}
+		allowed, retryAfter, err := l.Allow(ctx, key, 2, time.Minute)
+		require.NoError(t, err)
+		assert.False(t, allowed)
+		assert.True(t, retryAfter > 0 && retryAfter <= time.Minute)
+	})
+
+	t.Run("it starts a new window once the previous expires", func(t *testing.T) {
+		key := fmt.Sprintf("test:%d", time.Now().UnixNano())
+		defer client.Del(ctx, redisKeyPrefix+key)
+
+		allowed, _, err := l.Allow(ctx, key, 1, 50*time.Millisecond)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+		time.Sleep(100 * time.Millisecond)
+		allowed, _, err = l.Allow(ctx, key, 1, 50*time.Millisecond)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	})
+}
+
+func TestFallbackLimiter_Allow(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it uses the primary limiter while it works", func(t *testing.T) {
+		primary := NewMemoryLimiter()
+		fallback := NewMemoryLimiter()
+		l := NewFallbackLimiter(utils.NewTestLogger(), primary, fallback)
+
+		allowed, _, err := l.Allow(context.Background(), "ip:1", 1, time.Minute)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+		allowed, _, _ = l.Allow(context.Background(), "ip:1", 1, time.Minute)
+		assert.False(t, allowed)
+		assert.Empty(t, fallback.windows)
+	})
+
+	t.Run("it limits with the fallback when the primary fails", func(t *testing.T) {
+		l := NewFallbackLimiter(utils.NewTestLogger(), failingLimiter{}, NewMemoryLimiter())
+
+		allowed, _, err := l.Allow(context.Background(), "ip:1", 1, time.Minute)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+		allowed, retryAfter, err := l.Allow(context.Background(), "ip:1", 1, time.Minute)
+		require.NoError(t, err)
+		assert.False(t, allowed)
+		assert.Positive(t, retryAfter)
+	})
+}
@@ -1,5 +1,7 @@
 package urgency
 
+//go:generate mockgen -source=client.go -destination=client_gomock.go -package=urgency shared/s2s/urgency -imports=gomock=go.uber.org/mock/gomock -typed
+
 import (
 	"context"
 	"encoding/json"
@@ -19,6 +21,7 @@ import (
 // Client defines the S2S client for the urgency service.
 type Client interface {
 	GetUrgencyByID(ctx context.Context, id uint) (*urgencyV1.UrgencyResponse, error)
+	GetEmployeeActiveUrgencies(ctx context.Context, employeeID uint) (*urgencyV1.EmployeeActiveUrgenciesResponse, error)
 }
 
 // Config for constructing a Client.
@@ -91,6 +94,29 @@ func (c *clientImpl) GetUrgencyByID(ctx context.Context, id uint) (*urgencyV1.Ur
 	return &ur, nil
 }
 
+func (c *clientImpl) GetEmployeeActiveUrgencies(ctx context.Context, employeeID uint) (*urgencyV1.EmployeeActiveUrgenciesResponse, error) {
+	log := c.logger.WithContext(ctx)
+	endpoint := fmt.Sprintf("/api/v1/service/employees/%d/active-urgencies", employeeID)
+	resp, err := c.retryGet(ctx, endpoint)
+	if err != nil {
+		log.Errorf("urgency.employee_active http_error employee=%d err=%v", employeeID, err)
+		return nil, fmt.Errorf("failed to call urgency service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		log.Errorf("urgency.employee_active non_200 employee=%d status=%d", employeeID, resp.StatusCode)
+		return nil, fmt.Errorf("urgency service returned status %d", resp.StatusCode)
+	}
+
+	var out urgencyV1.EmployeeActiveUrgenciesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		log.Errorf("urgency.employee_active decode_error employee=%d err=%v", employeeID, err)
+		return nil, fmt.Errorf("failed to decode active urgencies response: %w", err)
+	}
+	return &out, nil
+}
+
 func (c *clientImpl) retryGet(ctx context.Context, endpoint string) (*http.Response, error) {
 	var lastErr error
 	backoff := 100 * time.Millisecond
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: client.go
+//
+// Generated by this command:
+//
+//	mockgen -source=client.go -destination=client_gomock.go -package=urgency shared/s2s/urgency -imports=gomock=go.uber.org/mock/gomock -typed
+//
+
+// Package urgency is a generated GoMock package.
+package urgency
+
+import (
+	context "context"
+	http "net/http"
+	reflect "reflect"
+
+	v1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockClient is a mock of Client interface.
+type MockClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientMockRecorder
+	isgomock struct{}
+}
+
+// MockClientMockRecorder is the mock recorder for MockClient.
+type MockClientMockRecorder struct {
+	mock *MockClient
+}
+
+// NewMockClient creates a new mock instance.
+func NewMockClient(ctrl *gomock.Controller) *MockClient {
+	mock := &MockClient{ctrl: ctrl}
+	mock.recorder = &MockClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClient) EXPECT() *MockClientMockRecorder {
+	return m.recorder
+}
+
+// GetEmployeeActiveUrgencies mocks base method.
+func (m *MockClient) GetEmployeeActiveUrgencies(ctx context.Context, employeeID uint) (*v1.EmployeeActiveUrgenciesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmployeeActiveUrgencies", ctx, employeeID)
+	ret0, _ := ret[0].(*v1.EmployeeActiveUrgenciesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmployeeActiveUrgencies indicates an expected call of GetEmployeeActiveUrgencies.
+func (mr *MockClientMockRecorder) GetEmployeeActiveUrgencies(ctx, employeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployeeActiveUrgencies", reflect.TypeOf((*MockClient)(nil).GetEmployeeActiveUrgencies), ctx, employeeID)
+}
+
+// GetUrgencyByID mocks base method.
+func (m *MockClient) GetUrgencyByID(ctx context.Context, id uint) (*v1.UrgencyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUrgencyByID", ctx, id)
+	ret0, _ := ret[0].(*v1.UrgencyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUrgencyByID indicates an expected call of GetUrgencyByID.
+func (mr *MockClientMockRecorder) GetUrgencyByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUrgencyByID", reflect.TypeOf((*MockClient)(nil).GetUrgencyByID), ctx, id)
+}
+
+// MockhttpClient is a mock of httpClient interface.
+type MockhttpClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockhttpClientMockRecorder
+	isgomock struct{}
+}
+
+// MockhttpClientMockRecorder is the mock recorder for MockhttpClient.
+type MockhttpClientMockRecorder struct {
+	mock *MockhttpClient
+}
+
+// NewMockhttpClient creates a new mock instance.
+func NewMockhttpClient(ctrl *gomock.Controller) *MockhttpClient {
+	mock := &MockhttpClient{ctrl: ctrl}
+	mock.recorder = &MockhttpClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockhttpClient) EXPECT() *MockhttpClientMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockhttpClient) Get(ctx context.Context, endpoint string) (*http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, endpoint)
+	ret0, _ := ret[0].(*http.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockhttpClientMockRecorder) Get(ctx, endpoint any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockhttpClient)(nil).Get), ctx, endpoint)
+}
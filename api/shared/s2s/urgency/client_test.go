@@ -66,3 +66,22 @@ func TestUrgencyClient_GetUrgencyByID(t *testing.T) {
 	})
 }
 
+
+func TestUrgencyClient_GetEmployeeActiveUrgencies(t *testing.T) {
+	t.Parallel()
+	log := utils.NewTestLogger()
+
+	t.Run("ok", func(t *testing.T) {
+		stub := &stubHTTP{responses: []*http.Response{jsonBody(urgencyV1.EmployeeActiveUrgenciesResponse{EmployeeID: 3, HasActive: true, UrgencyIDs: []uint{11}})}}
+		c := &clientImpl{http: stub, logger: log, maxRetries: 0}
+		got, err := c.GetEmployeeActiveUrgencies(t.Context(), 3)
+		if err != nil { t.Fatalf("err: %v", err) }
+		if !got.HasActive || len(got.UrgencyIDs) != 1 || got.UrgencyIDs[0] != 11 { t.Fatalf("bad resp: %+v", got) }
+	})
+
+	t.Run("non_200", func(t *testing.T) {
+		stub := &stubHTTP{responses: []*http.Response{status(400)}}
+		c := &clientImpl{http: stub, logger: log, maxRetries: 0}
+		if _, err := c.GetEmployeeActiveUrgencies(t.Context(), 3); err == nil { t.Fatalf("expected error") }
+	})
+}
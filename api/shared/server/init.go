@@ -34,7 +34,10 @@ func InitializeServer(log utils.Logger, config ServerConfig) error {
 
 	db := InitDb(log, config.ServiceName, config.DatabaseConfig)
 
-	r := gin.Default()
+	r, err := newEngine()
+	if err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
 	// Ensure every request carries a request ID and echo it back in the response
 	r.Use(utils.RequestIDMiddleware())
 	r.Use(log.RequestLogger())
@@ -0,0 +1,30 @@
+package server
+
+import (
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newEngine returns the gin engine of a service. Gin reads the client IP from X-Forwarded-For and
+// X-Real-IP only on requests coming from one of TRUSTED_PROXIES; other requests use their remote
+// address, so a client cannot pick the IP that per-IP rate limits count against by sending the header.
+func newEngine() (*gin.Engine, error) {
+	r := gin.Default()
+	if err := r.SetTrustedProxies(getTrustedProxies()); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// getTrustedProxies returns the comma separated IPs and CIDRs of TRUSTED_PROXIES; none are trusted by default
+func getTrustedProxies() []string {
+	var proxies []string
+	for _, p := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			proxies = append(proxies, p)
+		}
+	}
+	return proxies
+}
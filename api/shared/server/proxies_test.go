@@ -0,0 +1,64 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pd120424d/mountain-service/api/shared/ratelimit"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+func TestNewEngine_ClientIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(t *testing.T) *gin.Engine {
+		r, err := newEngine()
+		require.NoError(t, err)
+		rule := ratelimit.Rule{Name: "tracking", Limit: 1, Window: time.Minute}
+		r.GET("/track", ratelimit.Middleware(utils.NewTestLogger(), ratelimit.NewMemoryLimiter(), rule, ratelimit.ByClientIP), func(ctx *gin.Context) {
+			ctx.Status(http.StatusOK)
+		})
+		return r
+	}
+	get := func(r *gin.Engine, remoteIP, forwardedFor string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/track", nil)
+		req.RemoteAddr = remoteIP + ":1234"
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	t.Run("a spoofed forwarded header does not reset the counter", func(t *testing.T) {
+		t.Setenv("TRUSTED_PROXIES", "")
+		r := newRouter(t)
+
+		assert.Equal(t, http.StatusOK, get(r, "203.0.113.7", "198.51.100.1"))
+		assert.Equal(t, http.StatusTooManyRequests, get(r, "203.0.113.7", "198.51.100.2"))
+		assert.Equal(t, http.StatusTooManyRequests, get(r, "203.0.113.7", ""))
+	})
+
+	t.Run("it uses the forwarded client IP behind a trusted proxy", func(t *testing.T) {
+		t.Setenv("TRUSTED_PROXIES", "10.0.0.0/8")
+		r := newRouter(t)
+
+		assert.Equal(t, http.StatusOK, get(r, "10.1.2.3", "198.51.100.1"))
+		assert.Equal(t, http.StatusOK, get(r, "10.1.2.3", "198.51.100.2"))
+		// a client prepending its own entry is still counted by the address the proxy appended
+		assert.Equal(t, http.StatusTooManyRequests, get(r, "10.1.2.3", "192.0.2.9, 198.51.100.2"))
+	})
+
+	t.Run("it rejects invalid proxies", func(t *testing.T) {
+		t.Setenv("TRUSTED_PROXIES", "not-an-ip")
+		_, err := newEngine()
+		assert.Error(t, err)
+	})
+}
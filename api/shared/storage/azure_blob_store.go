@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+// AzureBlobStore keeps blobs in an Azure container
+type AzureBlobStore struct {
+	log    utils.Logger
+	client AzureBlobClientWrapper
+}
+
+// NewAzureBlobStore uses a private container, for blobs that are only ever served through the owning service
+func NewAzureBlobStore(log utils.Logger, client AzureBlobClientWrapper) (*AzureBlobStore, error) {
+	return newAzureBlobStore(log, client, nil)
+}
+
+// NewPublicAzureBlobStore uses a container with anonymous read access to blobs, so BlobURL links
+// can be handed straight to browsers
+func NewPublicAzureBlobStore(log utils.Logger, client AzureBlobClientWrapper) (*AzureBlobStore, error) {
+	publicAccess := azblob.PublicAccessTypeBlob
+	return newAzureBlobStore(log, client, &azblob.CreateContainerOptions{Access: &publicAccess})
+}
+
+func newAzureBlobStore(log utils.Logger, client AzureBlobClientWrapper, opts *azblob.CreateContainerOptions) (*AzureBlobStore, error) {
+	store := &AzureBlobStore{log: log.WithName("azureBlobStore"), client: client}
+	if _, err := client.CreateContainer(context.Background(), opts); err != nil && !bloberror.HasCode(err, bloberror.ContainerAlreadyExists) {
+		return nil, fmt.Errorf("failed to ensure container exists: %w", err)
+	}
+	return store, nil
+}
+
+// BlobURL returns the blob's direct URL; it is only readable without credentials in a public container
+func (s *AzureBlobStore) BlobURL(_ context.Context, name string) (string, error) {
+	return s.client.GetBlobURL(name), nil
+}
+
+func (s *AzureBlobStore) Put(ctx context.Context, name string, body io.Reader, contentType string) error {
+	_, err := s.client.UploadStream(ctx, name, body, &azblob.UploadStreamOptions{
+		BlockSize:   int64(1024 * 1024),
+		Concurrency: 3,
+		HTTPHeaders: &blob.HTTPHeaders{BlobContentType: &contentType},
+	})
+	if err != nil {
+		s.log.WithContext(ctx).Errorf("Failed to upload blob %s: %v", name, err)
+		return fmt.Errorf("failed to upload blob %s: %w", name, err)
+	}
+	return nil
+}
+
+func (s *AzureBlobStore) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	resp, err := s.client.DownloadStream(ctx, name, nil)
+	if bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return nil, ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to download blob %s: %w", name, err)
+	}
+	return resp.Body, nil
+}
+
+func (s *AzureBlobStore) Delete(ctx context.Context, name string) error {
+	_, err := s.client.DeleteBlob(ctx, name, nil)
+	if err != nil && !bloberror.HasCode(err, bloberror.BlobNotFound) {
+		s.log.WithContext(ctx).Errorf("Failed to delete blob %s: %v", name, err)
+		return fmt.Errorf("failed to delete blob %s: %w", name, err)
+	}
+	return nil
+}
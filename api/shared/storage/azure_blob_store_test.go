@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+func newTestAzureBlobStore(t *testing.T) (*AzureBlobStore, *MockAzureBlobClientWrapper) {
+	client := NewMockAzureBlobClientWrapper(gomock.NewController(t))
+	client.EXPECT().CreateContainer(gomock.Any(), nil).Return(azblob.CreateContainerResponse{}, nil)
+	store, err := NewAzureBlobStore(utils.NewTestLogger(), client)
+	require.NoError(t, err)
+	return store, client
+}
+
+func TestAzureBlobStore(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	notFound := &azcore.ResponseError{ErrorCode: string(bloberror.BlobNotFound)}
+
+	t.Run("it creates a private container and tolerates one that already exists", func(t *testing.T) {
+		client := NewMockAzureBlobClientWrapper(gomock.NewController(t))
+		client.EXPECT().CreateContainer(gomock.Any(), nil).
+			Return(azblob.CreateContainerResponse{}, &azcore.ResponseError{ErrorCode: string(bloberror.ContainerAlreadyExists)})
+
+		_, err := NewAzureBlobStore(utils.NewTestLogger(), client)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("it fails when the container cannot be created", func(t *testing.T) {
+		client := NewMockAzureBlobClientWrapper(gomock.NewController(t))
+		client.EXPECT().CreateContainer(gomock.Any(), nil).Return(azblob.CreateContainerResponse{}, errors.New("forbidden"))
+
+		_, err := NewAzureBlobStore(utils.NewTestLogger(), client)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("it uploads with the content type", func(t *testing.T) {
+		store, client := newTestAzureBlobStore(t)
+		client.EXPECT().UploadStream(gomock.Any(), "urgency-1/a.png", gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, _ io.Reader, opts *azblob.UploadStreamOptions) (azblob.UploadStreamResponse, error) {
+				assert.Equal(t, "image/png", *opts.HTTPHeaders.BlobContentType)
+				return azblob.UploadStreamResponse{}, nil
+			})
+
+		assert.NoError(t, store.Put(ctx, "urgency-1/a.png", strings.NewReader("x"), "image/png"))
+	})
+
+	t.Run("it returns the blob body", func(t *testing.T) {
+		store, client := newTestAzureBlobStore(t)
+		resp := azblob.DownloadStreamResponse{}
+		resp.Body = io.NopCloser(strings.NewReader("content"))
+		client.EXPECT().DownloadStream(gomock.Any(), "urgency-1/a.png", nil).Return(resp, nil)
+
+		r, err := store.Get(ctx, "urgency-1/a.png")
+
+		require.NoError(t, err)
+		body, _ := io.ReadAll(r)
+		assert.Equal(t, "content", string(body))
+	})
+
+	t.Run("it maps a missing blob to ErrBlobNotFound", func(t *testing.T) {
+		store, client := newTestAzureBlobStore(t)
+		client.EXPECT().DownloadStream(gomock.Any(), "missing", nil).Return(azblob.DownloadStreamResponse{}, notFound)
+
+		_, err := store.Get(ctx, "missing")
+
+		assert.ErrorIs(t, err, ErrBlobNotFound)
+	})
+
+	t.Run("it treats deleting a missing blob as done", func(t *testing.T) {
+		store, client := newTestAzureBlobStore(t)
+		client.EXPECT().DeleteBlob(gomock.Any(), "missing", nil).Return(azblob.DeleteBlobResponse{}, notFound)
+
+		assert.NoError(t, store.Delete(ctx, "missing"))
+	})
+
+	t.Run("it returns other delete failures", func(t *testing.T) {
+		store, client := newTestAzureBlobStore(t)
+		client.EXPECT().DeleteBlob(gomock.Any(), "a", nil).Return(azblob.DeleteBlobResponse{}, errors.New("timeout"))
+
+		assert.Error(t, store.Delete(ctx, "a"))
+	})
+}
@@ -22,6 +22,7 @@ type AzureBlobConfig struct {
 type AzureBlobClientWrapper interface {
 	UploadStream(ctx context.Context, blobName string, body io.Reader, options *azblob.UploadStreamOptions) (azblob.UploadStreamResponse, error)
 	DeleteBlob(ctx context.Context, blobName string, options *azblob.DeleteBlobOptions) (azblob.DeleteBlobResponse, error)
+	DownloadStream(ctx context.Context, blobName string, options *azblob.DownloadStreamOptions) (azblob.DownloadStreamResponse, error)
 	CreateContainer(ctx context.Context, options *azblob.CreateContainerOptions) (azblob.CreateContainerResponse, error)
 	GetBlobURL(blobName string) string
 }
@@ -70,6 +71,10 @@ func (w *azureBlobClientWrapper) DeleteBlob(ctx context.Context, blobName string
 	return w.client.DeleteBlob(ctx, w.containerName, blobName, options)
 }
 
+func (w *azureBlobClientWrapper) DownloadStream(ctx context.Context, blobName string, options *azblob.DownloadStreamOptions) (azblob.DownloadStreamResponse, error) {
+	return w.client.DownloadStream(ctx, w.containerName, blobName, options)
+}
+
 func (w *azureBlobClientWrapper) CreateContainer(ctx context.Context, options *azblob.CreateContainerOptions) (azblob.CreateContainerResponse, error) {
 	return w.client.CreateContainer(ctx, w.containerName, options)
 }
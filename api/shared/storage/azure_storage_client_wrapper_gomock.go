@@ -72,6 +72,21 @@ func (mr *MockAzureBlobClientWrapperMockRecorder) DeleteBlob(ctx, blobName, opti
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlob", reflect.TypeOf((*MockAzureBlobClientWrapper)(nil).DeleteBlob), ctx, blobName, options)
 }
 
+// DownloadStream mocks base method.
+func (m *MockAzureBlobClientWrapper) DownloadStream(ctx context.Context, blobName string, options *azblob.DownloadStreamOptions) (azblob.DownloadStreamResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadStream", ctx, blobName, options)
+	ret0, _ := ret[0].(azblob.DownloadStreamResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadStream indicates an expected call of DownloadStream.
+func (mr *MockAzureBlobClientWrapperMockRecorder) DownloadStream(ctx, blobName, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadStream", reflect.TypeOf((*MockAzureBlobClientWrapper)(nil).DownloadStream), ctx, blobName, options)
+}
+
 // GetBlobURL mocks base method.
 func (m *MockAzureBlobClientWrapper) GetBlobURL(blobName string) string {
 	m.ctrl.T.Helper()
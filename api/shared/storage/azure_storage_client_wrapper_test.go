@@ -220,10 +220,10 @@ func TestAzureBlobClientWrapper_Integration(t *testing.T) {
 		expectedURL := "https://testaccount.blob.core.windows.net/test-container/test-file.jpg"
 		assert.Equal(t, expectedURL, url)
 
-		// Step 3: In a real scenario, you would create the blob service
-		// service, err := NewAzureBlobService(log, wrapper)
+		// Step 3: In a real scenario, you would create the store and the profile picture service
+		// store, err := NewPublicAzureBlobStore(log, wrapper)
 		// assert.NoError(t, err)
-		// assert.NotNil(t, service)
+		// assert.NotNil(t, NewProfilePictureService(log, store, store))
 
 		// Note: Actual Azure operations (UploadStream, DeleteBlob, CreateContainer)
 		// would require integration testing with real Azure credentials
@@ -0,0 +1,25 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrBlobNotFound is returned when a blob does not exist in the store
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore keeps private files by name. Names are chosen by the caller and may contain slashes
+// to group related files; they are never derived from user input without sanitizing.
+type BlobStore interface {
+	Put(ctx context.Context, name string, body io.Reader, contentType string) error
+	// Get opens a stored blob; the caller closes the reader
+	Get(ctx context.Context, name string) (io.ReadCloser, error)
+	// Delete removes a blob; deleting a missing blob is not an error
+	Delete(ctx context.Context, name string) error
+}
+
+// BlobURLResolver returns a URL clients can download a blob from without further authentication
+type BlobURLResolver interface {
+	BlobURL(ctx context.Context, name string) (string, error)
+}
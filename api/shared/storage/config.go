@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+const (
+	BackendAzure  = "azure"
+	BackendLocal  = "local"
+	BackendMemory = "memory"
+)
+
+// ErrMissingURLSecret is returned when the local backend is opened without STORAGE_URL_SECRET. Saved
+// picture links are signed with it, so a secret that changes on restart would break all of them.
+var ErrMissingURLSecret = errors.New("STORAGE_URL_SECRET is required for the local storage backend")
+
+// StoreConfig selects the backend profile pictures are kept on
+type StoreConfig struct {
+	// Backend is BackendAzure, BackendLocal or BackendMemory
+	Backend  string
+	Azure    AzureBlobConfig
+	LocalDir string
+	// PublicBaseURL and URLSecret shape the signed links the local and memory backends hand out. The
+	// links are saved on the employee, so they do not expire.
+	PublicBaseURL string
+	URLSecret     string
+}
+
+// LoadStoreConfig loads storage settings from environment variables.
+// Without STORAGE_BACKEND, Azure is used when its credentials are present and local disk otherwise.
+func LoadStoreConfig() StoreConfig {
+	azure := loadConfigFromEnv()
+	backend := BackendLocal
+	if azure.AccountName != "" && azure.AccountKey != "" {
+		backend = BackendAzure
+	}
+
+	return StoreConfig{
+		Backend:       getEnvOrDefault("STORAGE_BACKEND", backend),
+		Azure:         azure,
+		LocalDir:      getEnvOrDefault("STORAGE_LOCAL_DIR", "/tmp/employee-profiles"),
+		PublicBaseURL: getEnvOrDefault("STORAGE_PUBLIC_BASE_URL", "/api/v1/files/blobs"),
+		URLSecret:     os.Getenv("STORAGE_URL_SECRET"),
+	}
+}
+
+// OpenProfilePictureService builds the profile picture service on the configured backend
+func OpenProfilePictureService(log utils.Logger, cfg StoreConfig) (ProfilePictureService, error) {
+	switch cfg.Backend {
+	case BackendAzure:
+		client, err := NewAzureBlobClientWrapper(log, cfg.Azure)
+		if err != nil {
+			return nil, err
+		}
+		store, err := NewPublicAzureBlobStore(log, client)
+		if err != nil {
+			return nil, err
+		}
+		return NewProfilePictureService(log, store, store), nil
+	case BackendLocal:
+		if cfg.URLSecret == "" {
+			return nil, ErrMissingURLSecret
+		}
+		store, err := NewLocalBlobStore(log, cfg.LocalDir)
+		if err != nil {
+			return nil, err
+		}
+		return NewSignedProfilePictureService(log, store, NewURLSigner(cfg.PublicBaseURL, []byte(cfg.URLSecret), 0)), nil
+	case BackendMemory:
+		return NewSignedProfilePictureService(log, NewMemoryBlobStore(), newMemorySigner(log, cfg)), nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.Backend)
+	}
+}
+
+// newMemorySigner may fall back to a random secret: memory blobs are gone after a restart anyway
+func newMemorySigner(log utils.Logger, cfg StoreConfig) *URLSigner {
+	secret := []byte(cfg.URLSecret)
+	if len(secret) == 0 {
+		log.Warn("STORAGE_URL_SECRET not set, download links will stop working when the service restarts")
+		secret = make([]byte, 32)
+		_, _ = rand.Read(secret)
+	}
+	return NewURLSigner(cfg.PublicBaseURL, secret, 0)
+}
@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+func TestLoadStoreConfig(t *testing.T) {
+	t.Run("it defaults to local storage without Azure credentials", func(t *testing.T) {
+		t.Setenv("AZURE_STORAGE_ACCOUNT_NAME", "")
+		t.Setenv("AZURE_STORAGE_ACCOUNT_KEY", "")
+		t.Setenv("STORAGE_BACKEND", "")
+
+		cfg := LoadStoreConfig()
+
+		assert.Equal(t, BackendLocal, cfg.Backend)
+		assert.Equal(t, "/tmp/employee-profiles", cfg.LocalDir)
+		assert.Equal(t, "/api/v1/files/blobs", cfg.PublicBaseURL)
+	})
+
+	t.Run("it defaults to Azure when credentials are present", func(t *testing.T) {
+		t.Setenv("AZURE_STORAGE_ACCOUNT_NAME", "account")
+		t.Setenv("AZURE_STORAGE_ACCOUNT_KEY", "key")
+		t.Setenv("STORAGE_BACKEND", "")
+
+		assert.Equal(t, BackendAzure, LoadStoreConfig().Backend)
+	})
+
+	t.Run("it honours an explicit backend and URL secret", func(t *testing.T) {
+		t.Setenv("AZURE_STORAGE_ACCOUNT_NAME", "account")
+		t.Setenv("AZURE_STORAGE_ACCOUNT_KEY", "key")
+		t.Setenv("STORAGE_BACKEND", BackendMemory)
+		t.Setenv("STORAGE_URL_SECRET", "secret")
+
+		cfg := LoadStoreConfig()
+
+		assert.Equal(t, BackendMemory, cfg.Backend)
+		assert.Equal(t, "secret", cfg.URLSecret)
+	})
+}
+
+func TestOpenProfilePictureService(t *testing.T) {
+	t.Parallel()
+	log := utils.NewTestLogger()
+
+	t.Run("it signs links that do not expire for the local backend", func(t *testing.T) {
+		service, err := OpenProfilePictureService(log, StoreConfig{Backend: BackendLocal, LocalDir: t.TempDir(), PublicBaseURL: "/files", URLSecret: "secret"})
+		require.NoError(t, err)
+
+		file, header, err := createTestFile("a.png", "png")
+		require.NoError(t, err)
+		defer file.Close()
+		header.Header.Set("Content-Type", "image/png")
+
+		result, err := service.UploadProfilePicture(context.Background(), file, header, 1)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(result.BlobURL, "/files/employee-1/"))
+		assert.Contains(t, result.BlobURL, "signature=")
+		assert.NotContains(t, result.BlobURL, "expires=")
+
+		u, err := url.Parse(result.BlobURL)
+		require.NoError(t, err)
+		body, _, err := service.OpenSigned(context.Background(), result.BlobName, "", u.Query().Get("signature"))
+		require.NoError(t, err)
+		body.Close()
+	})
+
+	t.Run("it refuses the local backend without a URL secret", func(t *testing.T) {
+		_, err := OpenProfilePictureService(log, StoreConfig{Backend: BackendLocal, LocalDir: t.TempDir(), PublicBaseURL: "/files"})
+		assert.ErrorIs(t, err, ErrMissingURLSecret)
+	})
+
+	t.Run("it opens the memory backend", func(t *testing.T) {
+		service, err := OpenProfilePictureService(log, StoreConfig{Backend: BackendMemory, URLSecret: "s"})
+		require.NoError(t, err)
+		assert.NotNil(t, service)
+	})
+
+	t.Run("it fails for Azure without credentials", func(t *testing.T) {
+		_, err := OpenProfilePictureService(log, StoreConfig{Backend: BackendAzure})
+		assert.Error(t, err)
+	})
+
+	t.Run("it fails for an unknown backend", func(t *testing.T) {
+		_, err := OpenProfilePictureService(log, StoreConfig{Backend: "ftp"})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,207 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"mime"
+	"net/http"
+)
+
+// ErrMalformedImage is returned when an image is too damaged to strip its metadata safely
+var ErrMalformedImage = errors.New("malformed image")
+
+var pngSignature = []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'}
+
+// DetectContentType sniffs the media type from the file body, so a client cannot pass off a file
+// as something else by naming or labelling it differently
+func DetectContentType(data []byte) string {
+	mediaType, _, err := mime.ParseMediaType(http.DetectContentType(data))
+	if err != nil {
+		return "application/octet-stream"
+	}
+	return mediaType
+}
+
+// StripImageMetadata removes EXIF, XMP, IPTC and text metadata from JPEG, PNG and WebP images
+// without re-encoding them. Photos taken on phones carry GPS coordinates, device serials and
+// timestamps that must not leak to everyone who can download the file. Other content types are
+// returned unchanged.
+func StripImageMetadata(contentType string, data []byte) ([]byte, error) {
+	switch contentType {
+	case "image/jpeg":
+		return stripJPEG(data)
+	case "image/png":
+		return stripPNG(data)
+	case "image/webp":
+		return stripWebP(data)
+	default:
+		return data, nil
+	}
+}
+
+// iccProfileID prefixes the APP2 segments that hold an ICC colour profile; other APP2 segments such
+// as the MPF index of embedded images are dropped
+var iccProfileID = []byte("ICC_PROFILE\x00")
+
+// stripJPEG drops comments and every APPn segment except JFIF (APP0), ICC colour profiles (APP2)
+// and the Adobe colour transform (APP14), which decoders need to render the image correctly.
+// Everything after the end of the image is dropped as well: phones append thumbnails, gain maps and
+// depth maps there, each with its own EXIF and GPS data.
+func stripJPEG(data []byte) ([]byte, error) {
+	if len(data) < 4 || data[0] != 0xff || data[1] != 0xd8 {
+		return nil, ErrMalformedImage
+	}
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:2])
+	i := 2
+	for i < len(data) {
+		if data[i] != 0xff {
+			return nil, ErrMalformedImage
+		}
+		// Markers may be preceded by any number of 0xff fill bytes
+		for i < len(data) && data[i] == 0xff {
+			i++
+		}
+		if i >= len(data) {
+			return nil, ErrMalformedImage
+		}
+		marker := data[i]
+		i++
+		if marker == 0xd9 || marker == 0x01 || (marker >= 0xd0 && marker <= 0xd7) {
+			out.Write([]byte{0xff, marker})
+			if marker == 0xd9 {
+				return out.Bytes(), nil
+			}
+			continue
+		}
+		if i+2 > len(data) {
+			return nil, ErrMalformedImage
+		}
+		length := int(binary.BigEndian.Uint16(data[i:]))
+		if length < 2 || i+length > len(data) {
+			return nil, ErrMalformedImage
+		}
+		segment := data[i-2 : i+length]
+		i += length
+		if marker == 0xda {
+			// Start of scan: the entropy-coded data is copied as is up to the next marker, which is
+			// either the end of the image or the header of the next progressive scan
+			end := scanEnd(data, i)
+			out.Write(segment)
+			out.Write(data[i:end])
+			i = end
+			continue
+		}
+		if marker == 0xe2 && !bytes.HasPrefix(segment[4:], iccProfileID) {
+			continue
+		}
+		if marker == 0xfe || (marker >= 0xe1 && marker <= 0xef && marker != 0xe2 && marker != 0xee) {
+			continue
+		}
+		out.Write(segment)
+	}
+	return nil, ErrMalformedImage
+}
+
+// scanEnd returns the offset of the first marker after the entropy-coded data starting at i. Stuffed
+// 0xff00 bytes and restart markers belong to the scan; a scan without a following marker ends the data.
+func scanEnd(data []byte, i int) int {
+	for i < len(data)-1 {
+		if data[i] != 0xff {
+			i++
+			continue
+		}
+		next := data[i+1]
+		if next == 0x00 || (next >= 0xd0 && next <= 0xd7) {
+			i += 2
+			continue
+		}
+		if next == 0xff {
+			i++
+			continue
+		}
+		return i
+	}
+	return len(data)
+}
+
+// pngMetadataChunks are ancillary chunks that carry EXIF, free text or the last modification time
+var pngMetadataChunks = map[string]bool{
+	"eXIf": true,
+	"tEXt": true,
+	"zTXt": true,
+	"iTXt": true,
+	"tIME": true,
+}
+
+func stripPNG(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return nil, ErrMalformedImage
+	}
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(pngSignature)
+	i := len(pngSignature)
+	for i < len(data) {
+		if i+8 > len(data) {
+			return nil, ErrMalformedImage
+		}
+		length := int(binary.BigEndian.Uint32(data[i:]))
+		chunkType := string(data[i+4 : i+8])
+		end := i + 12 + length
+		if length < 0 || end > len(data) || end < i {
+			return nil, ErrMalformedImage
+		}
+		if !pngMetadataChunks[chunkType] {
+			out.Write(data[i:end])
+		}
+		i = end
+		if chunkType == "IEND" {
+			return out.Bytes(), nil
+		}
+	}
+	return nil, ErrMalformedImage
+}
+
+const (
+	webpXMPFlag  = 0x04
+	webpEXIFFlag = 0x08
+)
+
+// stripWebP drops the EXIF and XMP chunks, clears their flags in the extended header and fixes the
+// RIFF size to match
+func stripWebP(data []byte) ([]byte, error) {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, ErrMalformedImage
+	}
+	body := bytes.NewBuffer(make([]byte, 0, len(data)))
+	body.WriteString("WEBP")
+	i := 12
+	for i < len(data) {
+		if i+8 > len(data) {
+			return nil, ErrMalformedImage
+		}
+		fourCC := string(data[i : i+4])
+		size := int(binary.LittleEndian.Uint32(data[i+4:]))
+		end := i + 8 + size + size%2
+		if size < 0 || end > len(data) || end < i {
+			return nil, ErrMalformedImage
+		}
+		switch fourCC {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			chunk := append([]byte(nil), data[i:end]...)
+			if size > 0 {
+				chunk[8] &^= webpXMPFlag | webpEXIFFlag
+			}
+			body.Write(chunk)
+		default:
+			body.Write(data[i:end])
+		}
+		i = end
+	}
+	out := make([]byte, 8, 8+body.Len())
+	copy(out, "RIFF")
+	binary.LittleEndian.PutUint32(out[4:], uint32(body.Len()))
+	return append(out, body.Bytes()...), nil
+}
@@ -0,0 +1,193 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testImage() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	img.Set(1, 1, color.RGBA{R: 200, A: 255})
+	return img
+}
+
+func jpegWithExif(t *testing.T) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, testImage(), nil))
+	encoded := buf.Bytes()
+
+	exif := append([]byte("Exif\x00\x00"), []byte("GPS 45.123N 19.456E")...)
+	segment := []byte{0xff, 0xe1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(exif)+2))
+	segment = append(segment, exif...)
+	comment := []byte{0xff, 0xfe, 0, 7, 'h', 'i', 'k', 'e', 'r'}
+
+	out := append([]byte{}, encoded[:2]...)
+	out = append(out, segment...)
+	out = append(out, comment...)
+	return append(out, encoded[2:]...)
+}
+
+func jpegSegment(marker byte, payload string) []byte {
+	segment := []byte{0xff, marker, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	return append(segment, payload...)
+}
+
+// jpegWithEmbeddedImage mimics a phone photo: an MPF index and an ICC profile in APP2 and a second
+// EXIF-bearing image appended after the end of the primary one
+func jpegWithEmbeddedImage(t *testing.T) []byte {
+	primary := jpegWithExif(t)
+	out := append([]byte{}, primary[:2]...)
+	out = append(out, jpegSegment(0xe2, "ICC_PROFILE\x00\x01\x01colours")...)
+	out = append(out, jpegSegment(0xe2, "MPF\x00MM\x00*offsets")...)
+	out = append(out, primary[2:]...)
+
+	embedded := jpegWithExif(t)
+	embedded = append(append([]byte{}, embedded[:2]...), append(jpegSegment(0xe1, "Exif\x00\x00GPS 44.816N 20.457E"), embedded[2:]...)...)
+	return append(out, embedded...)
+}
+
+func pngChunk(chunkType string, data []byte) []byte {
+	chunk := make([]byte, 8, 12+len(data))
+	binary.BigEndian.PutUint32(chunk, uint32(len(data)))
+	copy(chunk[4:], chunkType)
+	chunk = append(chunk, data...)
+	crc := crc32.ChecksumIEEE(chunk[4:])
+	return binary.BigEndian.AppendUint32(chunk, crc)
+}
+
+func pngWithText(t *testing.T) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, testImage()))
+	encoded := buf.Bytes()
+
+	// Insert after the IHDR chunk: signature (8) + length/type/data/crc (25)
+	out := append([]byte{}, encoded[:33]...)
+	out = append(out, pngChunk("tEXt", []byte("Location\x0045.123N 19.456E"))...)
+	out = append(out, pngChunk("eXIf", []byte("MM\x00*GPS"))...)
+	return append(out, encoded[33:]...)
+}
+
+func webpChunk(fourCC string, data []byte) []byte {
+	chunk := []byte(fourCC)
+	chunk = binary.LittleEndian.AppendUint32(chunk, uint32(len(data)))
+	chunk = append(chunk, data...)
+	if len(data)%2 == 1 {
+		chunk = append(chunk, 0)
+	}
+	return chunk
+}
+
+func webpFile(chunks ...[]byte) []byte {
+	body := []byte("WEBP")
+	for _, c := range chunks {
+		body = append(body, c...)
+	}
+	out := []byte("RIFF")
+	out = binary.LittleEndian.AppendUint32(out, uint32(len(body)))
+	return append(out, body...)
+}
+
+func TestDetectContentType(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "image/jpeg", DetectContentType(jpegWithExif(t)))
+	assert.Equal(t, "image/png", DetectContentType(pngWithText(t)))
+	assert.Equal(t, "application/pdf", DetectContentType([]byte("%PDF-1.7\n")))
+	assert.Equal(t, "text/plain", DetectContentType([]byte("just text")))
+}
+
+func TestStripImageMetadata(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it removes EXIF and comments from a JPEG and keeps it decodable", func(t *testing.T) {
+		original := jpegWithExif(t)
+
+		stripped, err := StripImageMetadata("image/jpeg", original)
+
+		require.NoError(t, err)
+		assert.NotContains(t, string(stripped), "GPS 45.123N")
+		assert.NotContains(t, string(stripped), "hiker")
+		_, err = jpeg.Decode(bytes.NewReader(stripped))
+		assert.NoError(t, err)
+	})
+
+	t.Run("it drops images appended after the end of a JPEG and keeps only the ICC profile in APP2", func(t *testing.T) {
+		original := jpegWithEmbeddedImage(t)
+
+		stripped, err := StripImageMetadata("image/jpeg", original)
+
+		require.NoError(t, err)
+		assert.NotContains(t, string(stripped), "GPS 44.816N")
+		assert.NotContains(t, string(stripped), "MPF")
+		assert.Contains(t, string(stripped), "ICC_PROFILE")
+		assert.Equal(t, 1, bytes.Count(stripped, []byte{0xff, 0xd8}))
+		assert.Equal(t, []byte{0xff, 0xd9}, stripped[len(stripped)-2:])
+		_, err = jpeg.Decode(bytes.NewReader(stripped))
+		assert.NoError(t, err)
+	})
+
+	t.Run("it removes text and EXIF chunks from a PNG and keeps it decodable", func(t *testing.T) {
+		original := pngWithText(t)
+
+		stripped, err := StripImageMetadata("image/png", original)
+
+		require.NoError(t, err)
+		assert.NotContains(t, string(stripped), "45.123N")
+		assert.NotContains(t, string(stripped), "eXIf")
+		_, err = png.Decode(bytes.NewReader(stripped))
+		assert.NoError(t, err)
+	})
+
+	t.Run("it removes EXIF and XMP chunks from a WebP and clears their flags", func(t *testing.T) {
+		vp8x := make([]byte, 10)
+		vp8x[0] = webpEXIFFlag | webpXMPFlag | 0x10
+		original := webpFile(
+			webpChunk("VP8X", vp8x),
+			webpChunk("VP8L", []byte{0x2f, 1, 2}),
+			webpChunk("EXIF", []byte("GPS 45.123N")),
+			webpChunk("XMP ", []byte("<x:xmpmeta/>")),
+		)
+
+		stripped, err := StripImageMetadata("image/webp", original)
+
+		require.NoError(t, err)
+		expected := webpFile(webpChunk("VP8X", append([]byte{0x10}, vp8x[1:]...)), webpChunk("VP8L", []byte{0x2f, 1, 2}))
+		assert.Equal(t, expected, stripped)
+	})
+
+	t.Run("it leaves other content types unchanged", func(t *testing.T) {
+		pdf := []byte("%PDF-1.7\n/Author (someone)")
+
+		stripped, err := StripImageMetadata("application/pdf", pdf)
+
+		require.NoError(t, err)
+		assert.Equal(t, pdf, stripped)
+	})
+
+	t.Run("it rejects truncated images", func(t *testing.T) {
+		original := jpegWithExif(t)
+
+		for _, tc := range []struct {
+			contentType string
+			data        []byte
+		}{
+			{"image/jpeg", original[:10]},
+			{"image/png", pngWithText(t)[:40]},
+			{"image/webp", []byte("RIFF\x00\x00\x00\x00WEBPVP8 ")},
+		} {
+			_, err := StripImageMetadata(tc.contentType, tc.data)
+			assert.ErrorIs(t, err, ErrMalformedImage, tc.contentType)
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+// LocalBlobStore keeps blobs as files under a root directory, for local development and single-node setups
+type LocalBlobStore struct {
+	log  utils.Logger
+	root string
+}
+
+func NewLocalBlobStore(log utils.Logger, root string) (*LocalBlobStore, error) {
+	if root == "" {
+		return nil, fmt.Errorf("local blob store root directory is required")
+	}
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create blob store directory: %w", err)
+	}
+	return &LocalBlobStore{log: log.WithName("localBlobStore"), root: root}, nil
+}
+
+// Put writes to a temporary file first so readers never see a partially written blob
+func (s *LocalBlobStore) Put(ctx context.Context, name string, body io.Reader, _ string) error {
+	path, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create blob directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create blob file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write blob %s: %w", name, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write blob %s: %w", name, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to store blob %s: %w", name, err)
+	}
+	s.log.WithContext(ctx).Infof("Stored blob %s", name)
+	return nil
+}
+
+func (s *LocalBlobStore) Get(_ context.Context, name string) (io.ReadCloser, error) {
+	path, err := s.path(name)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open blob %s: %w", name, err)
+	}
+	return f, nil
+}
+
+func (s *LocalBlobStore) Delete(ctx context.Context, name string) error {
+	path, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete blob %s: %w", name, err)
+	}
+	s.log.WithContext(ctx).Infof("Deleted blob %s", name)
+	return nil
+}
+
+// path maps a blob name to a file under the root and refuses names that would escape it
+func (s *LocalBlobStore) path(name string) (string, error) {
+	clean := filepath.Clean("/" + name)
+	if name == "" || strings.Contains(name, "..") || clean == "/" {
+		return "", fmt.Errorf("invalid blob name %q", name)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(clean)), nil
+}
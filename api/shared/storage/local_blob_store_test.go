@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+func TestLocalBlobStore(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("it stores, reads and deletes a blob", func(t *testing.T) {
+		store, err := NewLocalBlobStore(utils.NewTestLogger(), t.TempDir())
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put(ctx, "urgency-1/photo.jpg", strings.NewReader("content"), "image/jpeg"))
+		r, err := store.Get(ctx, "urgency-1/photo.jpg")
+		require.NoError(t, err)
+		body, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.NoError(t, r.Close())
+		assert.Equal(t, "content", string(body))
+
+		require.NoError(t, store.Delete(ctx, "urgency-1/photo.jpg"))
+		_, err = store.Get(ctx, "urgency-1/photo.jpg")
+		assert.ErrorIs(t, err, ErrBlobNotFound)
+	})
+
+	t.Run("it treats deleting a missing blob as done", func(t *testing.T) {
+		store, err := NewLocalBlobStore(utils.NewTestLogger(), t.TempDir())
+		require.NoError(t, err)
+
+		assert.NoError(t, store.Delete(ctx, "missing.jpg"))
+	})
+
+	t.Run("it keeps names inside the root directory", func(t *testing.T) {
+		root := t.TempDir()
+		store, err := NewLocalBlobStore(utils.NewTestLogger(), filepath.Join(root, "blobs"))
+		require.NoError(t, err)
+
+		assert.Error(t, store.Put(ctx, "../escape.txt", strings.NewReader("x"), "text/plain"))
+		assert.Error(t, store.Put(ctx, "", strings.NewReader("x"), "text/plain"))
+		_, err = os.Stat(filepath.Join(root, "escape.txt"))
+		assert.True(t, os.IsNotExist(err))
+
+		require.NoError(t, store.Put(ctx, "/absolute.txt", strings.NewReader("x"), "text/plain"))
+		_, err = os.Stat(filepath.Join(root, "blobs", "absolute.txt"))
+		assert.NoError(t, err)
+	})
+
+	t.Run("it fails without a root directory", func(t *testing.T) {
+		_, err := NewLocalBlobStore(utils.NewTestLogger(), "")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// MemoryBlobStore keeps blobs in memory; for tests and throwaway local runs, nothing survives a restart
+type MemoryBlobStore struct {
+	mu    sync.RWMutex
+	blobs map[string][]byte
+}
+
+func NewMemoryBlobStore() *MemoryBlobStore {
+	return &MemoryBlobStore{blobs: map[string][]byte{}}
+}
+
+func (s *MemoryBlobStore) Put(_ context.Context, name string, body io.Reader, _ string) error {
+	if name == "" {
+		return fmt.Errorf("invalid blob name %q", name)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("failed to read blob %s: %w", name, err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blobs[name] = data
+	return nil
+}
+
+func (s *MemoryBlobStore) Get(_ context.Context, name string) (io.ReadCloser, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	data, ok := s.blobs[name]
+	if !ok {
+		return nil, ErrBlobNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *MemoryBlobStore) Delete(_ context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.blobs, name)
+	return nil
+}
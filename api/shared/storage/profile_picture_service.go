@@ -0,0 +1,174 @@
+package storage
+
+//go:generate mockgen -source=profile_picture_service.go -destination=profile_picture_service_gomock.go -package=storage -imports=gomock=go.uber.org/mock/gomock
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+const (
+	maxSize              = int64(5 * 1024 * 1024) // 5MB
+	defaultContainerName = "employee-profiles"
+)
+
+// ProfilePictureService stores employee profile pictures on any BlobStore backend
+type ProfilePictureService interface {
+	UploadProfilePicture(ctx context.Context, file multipart.File, header *multipart.FileHeader, employeeID uint) (*UploadResult, error)
+	DeleteProfilePicture(ctx context.Context, blobName string) error
+	// OpenSigned returns the picture behind a signed download link; only backends without public URLs issue such links
+	OpenSigned(ctx context.Context, blobName, expires, signature string) (io.ReadCloser, string, error)
+}
+
+type profilePictureService struct {
+	log    utils.Logger
+	store  BlobStore
+	urls   BlobURLResolver
+	signer *URLSigner
+}
+
+type UploadResult struct {
+	BlobURL  string `json:"blobUrl"`
+	BlobName string `json:"blobName"`
+	Size     int64  `json:"size"`
+}
+
+// NewProfilePictureService serves pictures from URLs resolved by the store itself, e.g. a public Azure container
+func NewProfilePictureService(log utils.Logger, store BlobStore, urls BlobURLResolver) ProfilePictureService {
+	return &profilePictureService{
+		log:   log.WithName("ProfilePictureService"),
+		store: store,
+		urls:  urls,
+	}
+}
+
+// NewSignedProfilePictureService hands out signed links for stores that can't be reached directly,
+// such as local disk or memory; the links point back at the employee service, which calls OpenSigned
+func NewSignedProfilePictureService(log utils.Logger, store BlobStore, signer *URLSigner) ProfilePictureService {
+	return &profilePictureService{
+		log:    log.WithName("ProfilePictureService"),
+		store:  store,
+		urls:   signer,
+		signer: signer,
+	}
+}
+
+func (s *profilePictureService) UploadProfilePicture(ctx context.Context, file multipart.File, header *multipart.FileHeader, employeeID uint) (*UploadResult, error) {
+	if err := s.validateImageFile(header); err != nil {
+		return nil, err
+	}
+
+	blobName := s.generateBlobName(employeeID, header.Filename)
+
+	fileSize := header.Size
+
+	contentType := header.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	if err := s.store.Put(ctx, blobName, file, contentType); err != nil {
+		s.log.Errorf("Failed to upload blob %s: %v", blobName, err)
+		return nil, fmt.Errorf("failed to upload file to storage: %w", err)
+	}
+
+	blobURL, err := s.urls.BlobURL(ctx, blobName)
+	if err != nil {
+		s.log.Errorf("Failed to resolve URL for blob %s: %v", blobName, err)
+		return nil, fmt.Errorf("failed to resolve profile picture URL: %w", err)
+	}
+
+	result := &UploadResult{
+		BlobURL:  blobURL,
+		BlobName: blobName,
+		Size:     fileSize,
+	}
+
+	s.log.Infof("Successfully uploaded profile picture for employee %d: %s", employeeID, blobName)
+	return result, nil
+}
+
+func (s *profilePictureService) DeleteProfilePicture(ctx context.Context, blobName string) error {
+	if blobName == "" {
+		return nil // Nothing to delete
+	}
+
+	if err := s.store.Delete(ctx, blobName); err != nil {
+		s.log.Errorf("Failed to delete blob %s: %v", blobName, err)
+		return fmt.Errorf("failed to delete blob: %w", err)
+	}
+
+	s.log.Infof("Successfully deleted blob: %s", blobName)
+	return nil
+}
+
+func (s *profilePictureService) OpenSigned(ctx context.Context, blobName, expires, signature string) (io.ReadCloser, string, error) {
+	if s.signer == nil {
+		return nil, "", ErrInvalidSignature
+	}
+	if err := s.signer.Verify(blobName, expires, signature, time.Now()); err != nil {
+		return nil, "", err
+	}
+
+	body, err := s.store.Get(ctx, blobName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	contentType := mime.TypeByExtension(strings.ToLower(filepath.Ext(blobName)))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return body, contentType, nil
+}
+
+func (s *profilePictureService) validateImageFile(header *multipart.FileHeader) error {
+	if header.Size > maxSize {
+		return fmt.Errorf("file size exceeds maximum allowed size of 5MB")
+	}
+
+	ext := strings.ToLower(filepath.Ext(header.Filename))
+	allowedExts := map[string]bool{
+		".jpg":  true,
+		".jpeg": true,
+		".png":  true,
+		".gif":  true,
+		".webp": true,
+	}
+
+	if !allowedExts[ext] {
+		return fmt.Errorf("unsupported file type: %s. Allowed types: jpg, jpeg, png, gif, webp", ext)
+	}
+
+	contentType := header.Header.Get("Content-Type")
+	allowedMimes := map[string]bool{
+		"image/jpeg": true,
+		"image/jpg":  true,
+		"image/png":  true,
+		"image/gif":  true,
+		"image/webp": true,
+	}
+
+	if !allowedMimes[contentType] {
+		return fmt.Errorf("unsupported content type: %s", contentType)
+	}
+
+	return nil
+}
+
+func (s *profilePictureService) generateBlobName(employeeID uint, originalFilename string) string {
+	ext := filepath.Ext(originalFilename)
+	timestamp := time.Now().UTC().Format("20060102-150405")
+	uniqueID := uuid.New().String()[:8]
+
+	return fmt.Sprintf("employee-%d/%s-%s%s", employeeID, timestamp, uniqueID, ext)
+}
@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: profile_picture_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=profile_picture_service.go -destination=profile_picture_service_gomock.go -package=storage -imports=gomock=go.uber.org/mock/gomock
+//
+
+// Package storage is a generated GoMock package.
+package storage
+
+import (
+	context "context"
+	io "io"
+	multipart "mime/multipart"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProfilePictureService is a mock of ProfilePictureService interface.
+type MockProfilePictureService struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfilePictureServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockProfilePictureServiceMockRecorder is the mock recorder for MockProfilePictureService.
+type MockProfilePictureServiceMockRecorder struct {
+	mock *MockProfilePictureService
+}
+
+// NewMockProfilePictureService creates a new mock instance.
+func NewMockProfilePictureService(ctrl *gomock.Controller) *MockProfilePictureService {
+	mock := &MockProfilePictureService{ctrl: ctrl}
+	mock.recorder = &MockProfilePictureServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfilePictureService) EXPECT() *MockProfilePictureServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteProfilePicture mocks base method.
+func (m *MockProfilePictureService) DeleteProfilePicture(ctx context.Context, blobName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProfilePicture", ctx, blobName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProfilePicture indicates an expected call of DeleteProfilePicture.
+func (mr *MockProfilePictureServiceMockRecorder) DeleteProfilePicture(ctx, blobName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProfilePicture", reflect.TypeOf((*MockProfilePictureService)(nil).DeleteProfilePicture), ctx, blobName)
+}
+
+// OpenSigned mocks base method.
+func (m *MockProfilePictureService) OpenSigned(ctx context.Context, blobName, expires, signature string) (io.ReadCloser, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenSigned", ctx, blobName, expires, signature)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OpenSigned indicates an expected call of OpenSigned.
+func (mr *MockProfilePictureServiceMockRecorder) OpenSigned(ctx, blobName, expires, signature any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenSigned", reflect.TypeOf((*MockProfilePictureService)(nil).OpenSigned), ctx, blobName, expires, signature)
+}
+
+// UploadProfilePicture mocks base method.
+func (m *MockProfilePictureService) UploadProfilePicture(ctx context.Context, file multipart.File, header *multipart.FileHeader, employeeID uint) (*UploadResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadProfilePicture", ctx, file, header, employeeID)
+	ret0, _ := ret[0].(*UploadResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadProfilePicture indicates an expected call of UploadProfilePicture.
+func (mr *MockProfilePictureServiceMockRecorder) UploadProfilePicture(ctx, file, header, employeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadProfilePicture", reflect.TypeOf((*MockProfilePictureService)(nil).UploadProfilePicture), ctx, file, header, employeeID)
+}
@@ -4,29 +4,30 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"io"
 	"mime/multipart"
 	"net/textproto"
+	"net/url"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
 	"github.com/pd120424d/mountain-service/api/shared/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
-func TestAzureBlobService_NewAzureBlobService(t *testing.T) {
-	t.Parallel()
-
-}
-
 func TestValidateImageFile(t *testing.T) {
 	t.Parallel()
 	log := utils.NewTestLogger()
 
 	// Create a service instance for testing validation
-	service := &azureBlobService{
-		log: log.WithName("AzureBlobService"),
+	service := &profilePictureService{
+		log: log.WithName("ProfilePictureService"),
 	}
 
 	tests := []struct {
@@ -113,8 +114,8 @@ func TestGenerateBlobName(t *testing.T) {
 	t.Parallel()
 	log := utils.NewTestLogger()
 
-	service := &azureBlobService{
-		log: log.WithName("AzureBlobService"),
+	service := &profilePictureService{
+		log: log.WithName("ProfilePictureService"),
 	}
 
 	tests := []struct {
@@ -152,7 +153,7 @@ func TestGenerateBlobName(t *testing.T) {
 	}
 }
 
-func TestNewAzureBlobService(t *testing.T) {
+func TestNewProfilePictureService(t *testing.T) {
 	t.Parallel()
 	log := utils.NewTestLogger()
 
@@ -167,10 +168,10 @@ func TestNewAzureBlobService(t *testing.T) {
 			CreateContainer(gomock.Any(), gomock.Any()).
 			Return(azblob.CreateContainerResponse{}, nil)
 
-		service, err := NewAzureBlobService(log, mockClient)
+		store, err := NewPublicAzureBlobStore(log, mockClient)
 
 		assert.NoError(t, err)
-		assert.NotNil(t, service)
+		assert.NotNil(t, NewProfilePictureService(log, store, store))
 	})
 
 	t.Run("it fails when container creation fails", func(t *testing.T) {
@@ -184,11 +185,11 @@ func TestNewAzureBlobService(t *testing.T) {
 			CreateContainer(gomock.Any(), gomock.Any()).
 			Return(azblob.CreateContainerResponse{}, errors.New("access denied"))
 
-		service, err := NewAzureBlobService(log, mockClient)
+		store, err := NewPublicAzureBlobStore(log, mockClient)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to ensure container exists")
-		assert.Nil(t, service)
+		assert.Nil(t, store)
 	})
 }
 
@@ -233,13 +234,13 @@ func createTestFile(filename, content string) (multipart.File, *multipart.FileHe
 	return file, fileHeader, nil
 }
 
-func TestAzureBlobService_UploadProfilePicture(t *testing.T) {
+func TestProfilePictureService_UploadProfilePicture(t *testing.T) {
 	t.Parallel()
 
 	t.Run("it fails when file validation fails - file too large", func(t *testing.T) {
 		log := utils.NewTestLogger()
-		service := &azureBlobService{
-			log: log.WithName("AzureBlobService"),
+		service := &profilePictureService{
+			log: log.WithName("ProfilePictureService"),
 		}
 
 		// Create a file that's too large (over 5MB)
@@ -258,8 +259,8 @@ func TestAzureBlobService_UploadProfilePicture(t *testing.T) {
 
 	t.Run("it fails when file has invalid extension", func(t *testing.T) {
 		log := utils.NewTestLogger()
-		service := &azureBlobService{
-			log: log.WithName("AzureBlobService"),
+		service := &profilePictureService{
+			log: log.WithName("ProfilePictureService"),
 		}
 
 		file, header, err := createTestFile("test.txt", "test content")
@@ -276,8 +277,8 @@ func TestAzureBlobService_UploadProfilePicture(t *testing.T) {
 
 	t.Run("it fails when MIME type is invalid", func(t *testing.T) {
 		log := utils.NewTestLogger()
-		service := &azureBlobService{
-			log: log.WithName("AzureBlobService"),
+		service := &profilePictureService{
+			log: log.WithName("ProfilePictureService"),
 		}
 
 		// Create a file with .jpg extension but wrong MIME type
@@ -303,13 +304,13 @@ func TestAzureBlobService_UploadProfilePicture(t *testing.T) {
 	// These tests focus on validation logic that can be tested in isolation
 }
 
-func TestAzureBlobService_DeleteProfilePicture(t *testing.T) {
+func TestProfilePictureService_DeleteProfilePicture(t *testing.T) {
 	t.Parallel()
 
 	t.Run("it succeeds when blob name is empty", func(t *testing.T) {
 		log := utils.NewTestLogger()
-		service := &azureBlobService{
-			log: log.WithName("AzureBlobService"),
+		service := &profilePictureService{
+			log: log.WithName("ProfilePictureService"),
 		}
 
 		ctx := context.Background()
@@ -321,8 +322,8 @@ func TestAzureBlobService_DeleteProfilePicture(t *testing.T) {
 
 }
 
-// TestAzureBlobService_UploadProfilePicture_WithMocks tests the upload functionality with mocked Azure client
-func TestAzureBlobService_UploadProfilePicture_WithMocks(t *testing.T) {
+// TestProfilePictureService_UploadProfilePicture_WithMocks tests the upload functionality on Azure with a mocked client
+func TestProfilePictureService_UploadProfilePicture_WithMocks(t *testing.T) {
 	t.Parallel()
 
 	t.Run("it succeeds when uploading valid image with mocked client", func(t *testing.T) {
@@ -332,10 +333,7 @@ func TestAzureBlobService_UploadProfilePicture_WithMocks(t *testing.T) {
 		mockClient := NewMockAzureBlobClientWrapper(ctrl)
 		log := utils.NewTestLogger()
 
-		service := &azureBlobService{
-			client: mockClient,
-			log:    log.WithName("AzureBlobService"),
-		}
+		service := newAzureProfilePictureService(log, mockClient)
 
 		// Create a valid test file
 		file, header, err := createTestFile("test.jpg", "fake image content")
@@ -374,10 +372,7 @@ func TestAzureBlobService_UploadProfilePicture_WithMocks(t *testing.T) {
 		mockClient := NewMockAzureBlobClientWrapper(ctrl)
 		log := utils.NewTestLogger()
 
-		service := &azureBlobService{
-			client: mockClient,
-			log:    log.WithName("AzureBlobService"),
-		}
+		service := newAzureProfilePictureService(log, mockClient)
 
 		// Create a valid test file
 		file, header, err := createTestFile("test.jpg", "fake image content")
@@ -399,12 +394,12 @@ func TestAzureBlobService_UploadProfilePicture_WithMocks(t *testing.T) {
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
-		assert.Contains(t, err.Error(), "failed to upload file to Azure Blob Storage")
+		assert.Contains(t, err.Error(), "failed to upload file to storage")
 	})
 }
 
-// TestAzureBlobService_DeleteProfilePicture_WithMocks tests the delete functionality with mocked Azure client
-func TestAzureBlobService_DeleteProfilePicture_WithMocks(t *testing.T) {
+// TestProfilePictureService_DeleteProfilePicture_WithMocks tests the delete functionality on Azure with a mocked client
+func TestProfilePictureService_DeleteProfilePicture_WithMocks(t *testing.T) {
 	t.Parallel()
 
 	t.Run("it succeeds when deleting existing blob with mocked client", func(t *testing.T) {
@@ -414,10 +409,7 @@ func TestAzureBlobService_DeleteProfilePicture_WithMocks(t *testing.T) {
 		mockClient := NewMockAzureBlobClientWrapper(ctrl)
 		log := utils.NewTestLogger()
 
-		service := &azureBlobService{
-			client: mockClient,
-			log:    log.WithName("AzureBlobService"),
-		}
+		service := newAzureProfilePictureService(log, mockClient)
 
 		// Mock the DeleteBlob call
 		mockClient.EXPECT().
@@ -437,10 +429,7 @@ func TestAzureBlobService_DeleteProfilePicture_WithMocks(t *testing.T) {
 		mockClient := NewMockAzureBlobClientWrapper(ctrl)
 		log := utils.NewTestLogger()
 
-		service := &azureBlobService{
-			client: mockClient,
-			log:    log.WithName("AzureBlobService"),
-		}
+		service := newAzureProfilePictureService(log, mockClient)
 
 		// Mock the DeleteBlob call to return an error
 		mockClient.EXPECT().
@@ -461,10 +450,7 @@ func TestAzureBlobService_DeleteProfilePicture_WithMocks(t *testing.T) {
 		mockClient := NewMockAzureBlobClientWrapper(ctrl)
 		log := utils.NewTestLogger()
 
-		service := &azureBlobService{
-			client: mockClient,
-			log:    log.WithName("AzureBlobService"),
-		}
+		service := newAzureProfilePictureService(log, mockClient)
 
 		// No mock expectations since the method should return early
 
@@ -475,32 +461,25 @@ func TestAzureBlobService_DeleteProfilePicture_WithMocks(t *testing.T) {
 	})
 }
 
-// TestAzureBlobService_NewAzureBlobService_WithMocks tests the constructor with mocked Azure client
-func TestAzureBlobService_NewAzureBlobService_WithMocks(t *testing.T) {
+// TestPublicAzureBlobStore_WithMocks tests that the profile picture container is created with public blob access
+func TestPublicAzureBlobStore_WithMocks(t *testing.T) {
 	t.Parallel()
 
-	t.Run("it creates service and ensures container exists", func(t *testing.T) {
-		// Note: This test demonstrates how we could test the constructor if we refactored it
-		// to accept an AzureBlobClient interface instead of creating the client internally
+	t.Run("it creates a container with public blob access", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
 		mockClient := NewMockAzureBlobClientWrapper(ctrl)
 		log := utils.NewTestLogger()
 
-		// Mock the CreateContainer call (this would be called by ensureContainer)
 		mockClient.EXPECT().
 			CreateContainer(gomock.Any(), gomock.Any()).
-			Return(azblob.CreateContainerResponse{}, nil)
-
-		service := &azureBlobService{
-			client: mockClient,
-			log:    log.WithName("AzureBlobService"),
-		}
+			DoAndReturn(func(_ context.Context, opts *azblob.CreateContainerOptions) (azblob.CreateContainerResponse, error) {
+				assert.Equal(t, azblob.PublicAccessTypeBlob, *opts.Access)
+				return azblob.CreateContainerResponse{}, nil
+			})
 
-		// Test the ensureContainer method directly
-		ctx := context.Background()
-		err := service.ensureContainer(ctx)
+		_, err := NewPublicAzureBlobStore(log, mockClient)
 
 		assert.NoError(t, err)
 	})
@@ -512,21 +491,12 @@ func TestAzureBlobService_NewAzureBlobService_WithMocks(t *testing.T) {
 		mockClient := NewMockAzureBlobClientWrapper(ctrl)
 		log := utils.NewTestLogger()
 
-		// Mock the CreateContainer call to return a "ContainerAlreadyExists" error
 		mockClient.EXPECT().
 			CreateContainer(gomock.Any(), gomock.Any()).
-			Return(azblob.CreateContainerResponse{}, errors.New("ContainerAlreadyExists: The specified container already exists"))
-
-		service := &azureBlobService{
-			client: mockClient,
-			log:    log.WithName("AzureBlobService"),
-		}
+			Return(azblob.CreateContainerResponse{}, &azcore.ResponseError{ErrorCode: string(bloberror.ContainerAlreadyExists)})
 
-		// Test the ensureContainer method directly
-		ctx := context.Background()
-		err := service.ensureContainer(ctx)
+		_, err := NewPublicAzureBlobStore(log, mockClient)
 
-		// The method should handle the "ContainerAlreadyExists" error gracefully
 		assert.NoError(t, err)
 	})
 
@@ -537,22 +507,87 @@ func TestAzureBlobService_NewAzureBlobService_WithMocks(t *testing.T) {
 		mockClient := NewMockAzureBlobClientWrapper(ctrl)
 		log := utils.NewTestLogger()
 
-		// Mock the CreateContainer call to return a different error
 		mockClient.EXPECT().
 			CreateContainer(gomock.Any(), gomock.Any()).
 			Return(azblob.CreateContainerResponse{}, errors.New("access denied"))
 
-		service := &azureBlobService{
-			client: mockClient,
-			log:    log.WithName("AzureBlobService"),
-		}
-
-		// Test the ensureContainer method directly
-		ctx := context.Background()
-		err := service.ensureContainer(ctx)
+		_, err := NewPublicAzureBlobStore(log, mockClient)
 
-		// The method should return the error for non-"ContainerAlreadyExists" errors
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "access denied")
 	})
 }
+
+func TestProfilePictureService_Signed(t *testing.T) {
+	t.Parallel()
+	log := utils.NewTestLogger()
+	ctx := context.Background()
+
+	newService := func() ProfilePictureService {
+		signer := NewURLSigner("/api/v1/files/blobs", []byte("secret"), time.Hour)
+		return NewSignedProfilePictureService(log, NewMemoryBlobStore(), signer)
+	}
+
+	upload := func(t *testing.T, service ProfilePictureService) *UploadResult {
+		file, header, err := createTestFile("avatar.png", "fake image content")
+		require.NoError(t, err)
+		defer file.Close()
+		header.Header = textproto.MIMEHeader{"Content-Type": []string{"image/png"}}
+
+		result, err := service.UploadProfilePicture(ctx, file, header, 7)
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("it serves an uploaded picture through its signed URL", func(t *testing.T) {
+		service := newService()
+		result := upload(t, service)
+
+		u, err := url.Parse(result.BlobURL)
+		require.NoError(t, err)
+		assert.Equal(t, "/api/v1/files/blobs/"+result.BlobName, u.Path)
+
+		body, contentType, err := service.OpenSigned(ctx, result.BlobName, u.Query().Get("expires"), u.Query().Get("signature"))
+		require.NoError(t, err)
+		defer body.Close()
+		data, _ := io.ReadAll(body)
+		assert.Equal(t, "fake image content", string(data))
+		assert.Equal(t, "image/png", contentType)
+	})
+
+	t.Run("it rejects a signature issued for another picture", func(t *testing.T) {
+		service := newService()
+		first := upload(t, service)
+		second := upload(t, service)
+
+		u, _ := url.Parse(first.BlobURL)
+		_, _, err := service.OpenSigned(ctx, second.BlobName, u.Query().Get("expires"), u.Query().Get("signature"))
+
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("it returns ErrBlobNotFound once the picture is deleted", func(t *testing.T) {
+		service := newService()
+		result := upload(t, service)
+		require.NoError(t, service.DeleteProfilePicture(ctx, result.BlobName))
+
+		u, _ := url.Parse(result.BlobURL)
+		_, _, err := service.OpenSigned(ctx, result.BlobName, u.Query().Get("expires"), u.Query().Get("signature"))
+
+		assert.ErrorIs(t, err, ErrBlobNotFound)
+	})
+
+	t.Run("it refuses signed downloads when the store serves its own URLs", func(t *testing.T) {
+		store := NewMemoryBlobStore()
+		service := NewProfilePictureService(log, store, NewURLSigner("/files", []byte("secret"), time.Hour))
+
+		_, _, err := service.OpenSigned(ctx, "employee-1/a.png", "0", "")
+
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+}
+
+func newAzureProfilePictureService(log utils.Logger, client AzureBlobClientWrapper) *profilePictureService {
+	store := &AzureBlobStore{log: log, client: client}
+	return &profilePictureService{log: log.WithName("ProfilePictureService"), store: store, urls: store}
+}
@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURLSigner(t *testing.T) {
+	t.Parallel()
+	signer := NewURLSigner("https://api.example.com/files/", []byte("secret"), time.Hour)
+
+	link, err := signer.BlobURL(context.Background(), "employee-1/my photo.png")
+	require.NoError(t, err)
+	u, err := url.Parse(link)
+	require.NoError(t, err)
+	expires, signature := u.Query().Get("expires"), u.Query().Get("signature")
+
+	t.Run("it escapes the blob name into the path", func(t *testing.T) {
+		assert.Equal(t, "/files/employee-1/my photo.png", u.Path)
+		assert.Contains(t, link, "/files/employee-1/my%20photo.png?")
+	})
+
+	t.Run("it accepts its own link before expiry", func(t *testing.T) {
+		assert.NoError(t, signer.Verify("employee-1/my photo.png", expires, signature, time.Now()))
+	})
+
+	t.Run("it rejects an expired link", func(t *testing.T) {
+		unix, _ := strconv.ParseInt(expires, 10, 64)
+		err := signer.Verify("employee-1/my photo.png", expires, signature, time.Unix(unix, 0))
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("it rejects an extended expiry", func(t *testing.T) {
+		unix, _ := strconv.ParseInt(expires, 10, 64)
+		err := signer.Verify("employee-1/my photo.png", strconv.FormatInt(unix+3600, 10), signature, time.Now())
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("it rejects links signed with another secret", func(t *testing.T) {
+		other := NewURLSigner("https://api.example.com/files", []byte("other"), time.Hour)
+		err := other.Verify("employee-1/my photo.png", expires, signature, time.Now())
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("it rejects a malformed expiry", func(t *testing.T) {
+		assert.ErrorIs(t, signer.Verify("employee-1/my photo.png", "soon", signature, time.Now()), ErrInvalidSignature)
+	})
+
+	t.Run("it rejects an expiring link with the expiry removed", func(t *testing.T) {
+		assert.ErrorIs(t, signer.Verify("employee-1/my photo.png", "", signature, time.Now()), ErrInvalidSignature)
+	})
+
+	t.Run("a signer without a TTL issues links that never expire", func(t *testing.T) {
+		permanent := NewURLSigner("https://api.example.com/files", []byte("secret"), 0)
+		link, err := permanent.BlobURL(context.Background(), "employee-1/a.png")
+		require.NoError(t, err)
+		u, err := url.Parse(link)
+		require.NoError(t, err)
+
+		assert.Empty(t, u.Query().Get("expires"))
+		assert.NoError(t, permanent.Verify("employee-1/a.png", "", u.Query().Get("signature"), time.Now().Add(10*365*24*time.Hour)))
+		assert.ErrorIs(t, permanent.Verify("employee-1/b.png", "", u.Query().Get("signature"), time.Now()), ErrInvalidSignature)
+	})
+}
@@ -0,0 +1,33 @@
+package utils
+
+import "math"
+
+const earthRadiusKm = 6371.0
+
+// DistanceKm returns the great-circle (haversine) distance between two points in kilometers.
+func DistanceKm(lat1, lng1, lat2, lng2 float64) float64 {
+	dLat := toRadians(lat2 - lat1)
+	dLng := toRadians(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// BoundingBox returns the latitude/longitude box that contains every point within radiusKm of the center.
+// It is meant as a cheap index-friendly prefilter before an exact DistanceKm check.
+func BoundingBox(lat, lng, radiusKm float64) (minLat, maxLat, minLng, maxLng float64) {
+	dLat := radiusKm / earthRadiusKm * 180 / math.Pi
+	minLat = math.Max(lat-dLat, -90)
+	maxLat = math.Min(lat+dLat, 90)
+
+	cosLat := math.Cos(toRadians(lat))
+	if cosLat < 1e-9 || maxLat >= 90 || minLat <= -90 {
+		return minLat, maxLat, -180, 180
+	}
+	dLng := dLat / cosLat
+	return minLat, maxLat, math.Max(lng-dLng, -180), math.Min(lng+dLng, 180)
+}
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistanceKm(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it returns zero for the same point", func(t *testing.T) {
+		assert.InDelta(t, 0, DistanceKm(43.4, 22.66, 43.4, 22.66), 1e-9)
+	})
+
+	t.Run("it returns the great-circle distance between two cities", func(t *testing.T) {
+		// Belgrade - Nis, roughly 200 km apart
+		assert.InDelta(t, 201, DistanceKm(44.8125, 20.4612, 43.3209, 21.8958), 3)
+	})
+}
+
+func TestBoundingBox(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it contains points at the given radius", func(t *testing.T) {
+		minLat, maxLat, minLng, maxLng := BoundingBox(43.4, 22.66, 10)
+		assert.Less(t, minLat, 43.4)
+		assert.Greater(t, maxLat, 43.4)
+		assert.InDelta(t, 10, DistanceKm(43.4, 22.66, maxLat, 22.66), 0.01)
+		assert.InDelta(t, 10, DistanceKm(43.4, 22.66, 43.4, maxLng), 0.1)
+		assert.Less(t, minLng, 22.66)
+	})
+
+	t.Run("it spans all longitudes near the poles", func(t *testing.T) {
+		_, maxLat, minLng, maxLng := BoundingBox(89.99, 0, 50)
+		assert.Equal(t, 90.0, maxLat)
+		assert.Equal(t, -180.0, minLng)
+		assert.Equal(t, 180.0, maxLng)
+	})
+}
@@ -0,0 +1,24 @@
+package utils
+
+// Locales the web UI ships translations for; notification templates use the same identifiers
+const (
+	LocaleSerbianCyrillic = "sr-cyr"
+	LocaleSerbianLatin    = "sr-lat"
+	LocaleEnglish         = "en"
+	LocaleRussian         = "ru"
+)
+
+// SupportedLocales returns the locale identifiers in the order the UI lists them
+func SupportedLocales() []string {
+	return []string{LocaleSerbianCyrillic, LocaleSerbianLatin, LocaleEnglish, LocaleRussian}
+}
+
+// IsSupportedLocale reports whether locale is one of SupportedLocales
+func IsSupportedLocale(locale string) bool {
+	for _, l := range SupportedLocales() {
+		if l == locale {
+			return true
+		}
+	}
+	return false
+}
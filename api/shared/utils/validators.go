@@ -101,41 +101,62 @@ func ValidateGender(gender string) error {
 	return nil
 }
 
+// ValidateOptionalLocale validates a preferred language only if it's not empty
+func ValidateOptionalLocale(locale string) error {
+	if locale == "" || IsSupportedLocale(locale) {
+		return nil
+	}
+	return fmt.Errorf("language must be one of: %s", strings.Join(SupportedLocales(), ", "))
+}
+
 // ValidateCoordinates validates GPS coordinates in format "N 43.401123 E 22.662756"
 func ValidateCoordinates(coordinates string) error {
+	_, _, err := ParseCoordinates(coordinates)
+	return err
+}
+
+// ParseCoordinates parses GPS coordinates in format "N 43.401123 E 22.662756" into signed decimal degrees.
+// S latitudes and W longitudes are returned as negative values.
+func ParseCoordinates(coordinates string) (float64, float64, error) {
 	if coordinates == "" {
-		return fmt.Errorf("coordinates are required")
+		return 0, 0, fmt.Errorf("coordinates are required")
 	}
 
 	// Pattern for coordinates: N/S latitude E/W longitude
 	// Allow for optional negative signs and decimal points
-	pattern := `^[NS]\s*(-?\d+(?:\.\d+)?)\s*[EW]\s*(-?\d+(?:\.\d+)?)$`
+	pattern := `^([NS])\s*(-?\d+(?:\.\d+)?)\s*([EW])\s*(-?\d+(?:\.\d+)?)$`
 	re := regexp.MustCompile(pattern)
 
 	matches := re.FindStringSubmatch(coordinates)
-	if len(matches) != 3 {
-		return fmt.Errorf("coordinates must be in format 'N 43.401123 E 22.662756'")
+	if len(matches) != 5 {
+		return 0, 0, fmt.Errorf("coordinates must be in format 'N 43.401123 E 22.662756'")
 	}
 
 	// Validate latitude range (-90 to 90)
-	lat, err := strconv.ParseFloat(matches[1], 64)
+	lat, err := strconv.ParseFloat(matches[2], 64)
 	if err != nil {
-		return fmt.Errorf("invalid latitude value")
+		return 0, 0, fmt.Errorf("invalid latitude value")
 	}
 	if lat < -90 || lat > 90 {
-		return fmt.Errorf("latitude must be between -90 and 90")
+		return 0, 0, fmt.Errorf("latitude must be between -90 and 90")
 	}
 
 	// Validate longitude range (-180 to 180)
-	lng, err := strconv.ParseFloat(matches[2], 64)
+	lng, err := strconv.ParseFloat(matches[4], 64)
 	if err != nil {
-		return fmt.Errorf("invalid longitude value")
+		return 0, 0, fmt.Errorf("invalid longitude value")
 	}
 	if lng < -180 || lng > 180 {
-		return fmt.Errorf("longitude must be between -180 and 180")
+		return 0, 0, fmt.Errorf("longitude must be between -180 and 180")
 	}
 
-	return nil
+	if matches[1] == "S" {
+		lat = -lat
+	}
+	if matches[3] == "W" {
+		lng = -lng
+	}
+	return lat, lng, nil
 }
 
 // ValidateOptionalCoordinates validates coordinates only if not empty
@@ -225,6 +225,26 @@ func TestValidateGender(t *testing.T) {
 	})
 }
 
+func TestValidateOptionalLocale(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it returns no error when locale is empty", func(t *testing.T) {
+		assert.NoError(t, ValidateOptionalLocale(""))
+	})
+
+	t.Run("it returns no error for every supported locale", func(t *testing.T) {
+		for _, locale := range SupportedLocales() {
+			assert.NoError(t, ValidateOptionalLocale(locale))
+		}
+	})
+
+	t.Run("it returns an error for an unknown locale", func(t *testing.T) {
+		err := ValidateOptionalLocale("de")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "sr-cyr, sr-lat, en, ru")
+	})
+}
+
 func TestValidateCoordinates(t *testing.T) {
 	t.Parallel()
 
@@ -274,6 +294,29 @@ func TestValidateCoordinates(t *testing.T) {
 	})
 }
 
+func TestParseCoordinates(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it parses northern and eastern coordinates", func(t *testing.T) {
+		lat, lng, err := ParseCoordinates("N 43.401123 E 22.662756")
+		assert.NoError(t, err)
+		assert.InDelta(t, 43.401123, lat, 1e-9)
+		assert.InDelta(t, 22.662756, lng, 1e-9)
+	})
+
+	t.Run("it negates southern latitudes and western longitudes", func(t *testing.T) {
+		lat, lng, err := ParseCoordinates("S 43.5 W 22.25")
+		assert.NoError(t, err)
+		assert.Equal(t, -43.5, lat)
+		assert.Equal(t, -22.25, lng)
+	})
+
+	t.Run("it returns an error for invalid coordinates", func(t *testing.T) {
+		_, _, err := ParseCoordinates("N 91 E 22")
+		assert.Error(t, err)
+	})
+}
+
 func TestValidateOptionalCoordinates(t *testing.T) {
 	t.Run("it returns no error when coordinates are empty", func(t *testing.T) {
 		err := ValidateOptionalCoordinates("")
@@ -8,19 +8,28 @@ import (
 
 	"github.com/pd120424d/mountain-service/api/shared/auth"
 	globConf "github.com/pd120424d/mountain-service/api/shared/config"
+	"github.com/pd120424d/mountain-service/api/shared/models"
+	"github.com/pd120424d/mountain-service/api/shared/outbox"
+	"github.com/pd120424d/mountain-service/api/shared/ratelimit"
 	"github.com/pd120424d/mountain-service/api/shared/server"
+	"github.com/pd120424d/mountain-service/api/shared/storage"
 	"github.com/pd120424d/mountain-service/api/shared/utils"
 	_ "github.com/pd120424d/mountain-service/api/urgency/cmd/docs"
 	"github.com/pd120424d/mountain-service/api/urgency/internal"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/clients"
 	internalConfig "github.com/pd120424d/mountain-service/api/urgency/internal/config"
 	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/notifier"
 	"github.com/pd120424d/mountain-service/api/urgency/internal/repositories"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/templates"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/webhook"
 
+	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 
 	// Import contracts for Swagger documentation
 	_ "github.com/pd120424d/mountain-service/api/contracts/common/v1"
-	_ "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
 
 	"github.com/gin-gonic/gin"
 )
@@ -61,7 +70,8 @@ func main() {
 		ServiceName: svcName,
 		Port:        globConf.UrgencyServicePort,
 		DatabaseConfig: server.GetDatabaseConfigWithDefaults(
-			[]interface{}{&model.Urgency{}, &model.Notification{}},
+			[]interface{}{&model.Urgency{}, &model.Notification{}, &model.UrgencyEscalation{}, &model.UrgencyEvent{}, &model.UrgencyAssignment{},
+				&models.OutboxEvent{}, &model.WebhookSubscription{}, &model.WebhookDelivery{}, &model.ReporterFollowUp{}, &model.Attachment{}},
 			globConf.UrgencyDBName,
 		),
 		CORSConfig: server.DefaultCORSConfig(),
@@ -104,6 +114,7 @@ func setupRoutes(log utils.Logger, r *gin.Engine, db *gorm.DB) {
 	}
 
 	notificationRepo := repositories.NewNotificationRepository(log, db)
+	startNotificationDispatcher(log, notificationRepo)
 
 	// Initialize service clients using defaults-aware loader (env vars override)
 	serviceConfig := internalConfig.LoadServiceConfig()
@@ -113,14 +124,24 @@ func setupRoutes(log utils.Logger, r *gin.Engine, db *gorm.DB) {
 		log.Fatalf("Failed to initialize service clients: %v", err)
 	}
 
-	// Initialize service with all dependencies
-	urgencySvc := internal.NewUrgencyService(log, urgencyRepo, notificationRepo, serviceClients.EmployeeClient)
-	urgencyHandler := internal.NewUrgencyHandler(log, urgencySvc)
-
 	redisAddr := os.Getenv(globConf.REDIS_ADDR)
 	if redisAddr == "" {
 		redisAddr = "redis:6379"
 	}
+	// Public intake limits are shared by all replicas through Redis and fall back to per-replica
+	// counters while Redis is unavailable
+	limiter := ratelimit.NewFallbackLimiter(log, ratelimit.NewRedisLimiter(redis.NewClient(&redis.Options{Addr: redisAddr})), ratelimit.NewMemoryLimiter())
+
+	// Initialize service with all dependencies
+	serviceOptions := loadServiceOptions(log, limiter)
+	urgencySvc := internal.NewUrgencyServiceWithOptions(log, urgencyRepo, notificationRepo, serviceClients.EmployeeClient, serviceClients.ActivityClient, serviceOptions)
+	urgencyHandler := internal.NewUrgencyHandlerWithUploadLimit(log, urgencySvc, serviceOptions.Attachments.MaxSize)
+	startEscalator(log, urgencyRepo, notificationRepo, serviceClients.EmployeeClient, serviceOptions.Templates)
+
+	webhookRepo := repositories.NewWebhookRepository(log, db)
+	webhookHandler := internal.NewWebhookHandler(log, internal.NewWebhookService(log, webhookRepo))
+	startOutboxPublisher(log, db, startWebhookDispatcher(log, webhookRepo))
+
 	blacklistConfig := auth.TokenBlacklistConfig{RedisAddr: redisAddr, RedisDB: 0}
 	tokenBlacklist := auth.NewTokenBlacklist(blacklistConfig)
 	if err := tokenBlacklist.TestConnection(); err != nil {
@@ -128,32 +149,263 @@ func setupRoutes(log utils.Logger, r *gin.Engine, db *gorm.DB) {
 	}
 	log.Info("Successfully initialized Redis token blacklist")
 
-	// Public routes (no authentication required) - registering a new urgency
-	r.POST("/api/v1/urgencies", urgencyHandler.CreateUrgency)
+	// Public routes (no authentication required) - registering a new urgency, limited per client IP
+	intakeCfg := internalConfig.LoadIntakeConfig()
+	intakeLimit := ratelimit.Middleware(log, limiter, ratelimit.Rule{Name: "intake", Limit: intakeCfg.IPRateLimit, Window: intakeCfg.IPRateWindow}, ratelimit.ByClientIP)
+	r.POST("/api/v1/urgencies", intakeLimit, urgencyHandler.CreateUrgency)
+
+	// Public tracking link for reporters, limited per client IP against token guessing
+	trackingCfg := internalConfig.LoadTrackingConfig()
+	trackingLimit := ratelimit.Middleware(log, limiter, ratelimit.Rule{Name: "tracking", Limit: trackingCfg.RateLimit, Window: trackingCfg.RateWindow}, ratelimit.ByClientIP)
+	tracking := r.Group("/api/v1/urgencies/track").Use(trackingLimit)
+	{
+		tracking.GET("/:token", urgencyHandler.GetTrackingStatus)
+		tracking.POST("/:token/follow-ups", urgencyHandler.AddFollowUp)
+		tracking.POST("/:token/verify", urgencyHandler.VerifyPhone)
+		tracking.POST("/:token/verify/resend", urgencyHandler.ResendVerificationCode)
+		tracking.POST("/:token/attachments", urgencyHandler.UploadReporterAttachment)
+	}
 
 	// Protected routes (authentication required)
 	authorized := r.Group("/api/v1").Use(auth.AuthMiddleware(log, tokenBlacklist))
 	{
 		authorized.GET("/urgencies", urgencyHandler.ListUrgencies)
 		authorized.GET("/urgencies/unassigned-ids", urgencyHandler.UnassignedUrgencyIDs)
+		authorized.GET("/urgencies/geo", urgencyHandler.ListUrgenciesInArea)
+		authorized.GET("/urgencies/stats", urgencyHandler.GetStats)
 		authorized.GET("/urgencies/:id", urgencyHandler.GetUrgency)
 		authorized.PUT("/urgencies/:id", urgencyHandler.UpdateUrgency)
 		authorized.DELETE("/urgencies/:id", urgencyHandler.DeleteUrgency)
 		authorized.POST("/urgencies/:id/assign", urgencyHandler.AssignUrgency)
 		authorized.DELETE("/urgencies/:id/assign", urgencyHandler.UnassignUrgency)
 		authorized.PUT("/urgencies/:id/close", urgencyHandler.CloseUrgency)
+		authorized.PUT("/urgencies/:id/resolve", urgencyHandler.ResolveUrgency)
+		authorized.PUT("/urgencies/:id/reopen", urgencyHandler.ReopenUrgency)
+		authorized.PUT("/urgencies/:id/cancel", urgencyHandler.CancelUrgency)
+		authorized.POST("/urgencies/:id/accept", urgencyHandler.AcceptUrgency)
+		authorized.POST("/urgencies/:id/decline", urgencyHandler.DeclineUrgency)
+		authorized.GET("/urgencies/:id/replies", urgencyHandler.ListReplies)
+		authorized.GET("/urgencies/:id/notifications", urgencyHandler.ListUrgencyNotifications)
+		authorized.GET("/employees/:id/notifications", urgencyHandler.ListEmployeeNotifications)
+		authorized.GET("/urgencies/:id/escalations", urgencyHandler.ListEscalations)
+		authorized.GET("/urgencies/:id/timeline", urgencyHandler.GetTimeline)
+		authorized.GET("/urgencies/:id/responders", urgencyHandler.SuggestResponders)
+		authorized.GET("/urgencies/:id/team", urgencyHandler.GetTeam)
+		authorized.POST("/urgencies/:id/team", urgencyHandler.AddTeamMember)
+		authorized.DELETE("/urgencies/:id/team/:employeeId", urgencyHandler.RemoveTeamMember)
+		authorized.GET("/urgencies/:id/attachments", urgencyHandler.ListAttachments)
+		authorized.POST("/urgencies/:id/attachments", urgencyHandler.UploadAttachment)
+		authorized.GET("/urgencies/:id/attachments/:attachmentId", urgencyHandler.DownloadAttachment)
+		authorized.DELETE("/urgencies/:id/attachments/:attachmentId", urgencyHandler.DeleteAttachment)
 	}
 
 	// Admin-only routes
 	admin := r.Group("/api/v1/admin").Use(auth.AdminMiddleware(log, tokenBlacklist))
 	{
 		admin.DELETE("/urgencies/reset", urgencyHandler.ResetAllData)
+		admin.POST("/urgencies/:id/merge", urgencyHandler.MergeUrgency)
+		admin.GET("/urgencies/review", urgencyHandler.ListIntakeReview)
+		admin.POST("/urgencies/:id/release", urgencyHandler.ReleaseIntake)
+		admin.POST("/urgencies/:id/reject", urgencyHandler.RejectIntake)
+		admin.GET("/notification-templates/preview", urgencyHandler.PreviewNotificationTemplate)
+		admin.POST("/notifications/:id/retry", urgencyHandler.RetryNotification)
+		admin.GET("/webhooks", webhookHandler.ListSubscriptions)
+		admin.POST("/webhooks", webhookHandler.CreateSubscription)
+		admin.GET("/webhooks/:id", webhookHandler.GetSubscription)
+		admin.PUT("/webhooks/:id", webhookHandler.UpdateSubscription)
+		admin.DELETE("/webhooks/:id", webhookHandler.DeleteSubscription)
+		admin.GET("/webhooks/:id/deliveries", webhookHandler.ListDeliveries)
 	}
 
 	serviceAuth := auth.NewServiceAuth(auth.ServiceAuthConfig{Secret: internalConfig.LoadServiceConfig().ServiceAuthSecret, ServiceName: "urgency-service", TokenTTL: time.Hour})
 	serviceGroup := r.Group("/api/v1/service").Use(auth.NewServiceAuthMiddleware(serviceAuth))
 	{
 		serviceGroup.GET("/urgency/:id", urgencyHandler.GetUrgency)
+		serviceGroup.GET("/employees/:id/active-urgencies", urgencyHandler.GetEmployeeActiveUrgencies)
+	}
+}
+
+// startNotificationDispatcher launches the background worker that delivers pending notifications.
+// Emails go through SMTP when SMTP_HOST is set; otherwise, and for SMS, a log/file stand-in is used.
+func startNotificationDispatcher(log utils.Logger, notificationRepo repositories.NotificationRepository) {
+	cfg := internalConfig.LoadNotificationConfig()
+	if !cfg.Enabled {
+		log.Info("Notification dispatcher disabled (NOTIFICATION_DISPATCH_ENABLED=false)")
+		return
+	}
+
+	stub := notifier.NewLogSender(log, cfg.SinkFile)
+	var emailSender notifier.EmailSender = stub
+	if cfg.SMTPHost != "" {
+		smtpSender, err := notifier.NewSMTPEmailSender(log, notifier.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.SMTPFrom,
+		})
+		if err != nil {
+			log.Errorf("Failed to configure SMTP sender, falling back to log sender: %v", err)
+		} else {
+			emailSender = smtpSender
+		}
+	}
+
+	dispatcher := notifier.NewDispatcher(log, notificationRepo, stub, emailSender, notifier.Config{
+		Interval:    cfg.Interval,
+		BatchSize:   cfg.BatchSize,
+		MaxAttempts: cfg.MaxAttempts,
+		BaseBackoff: cfg.BaseBackoff,
+		MaxBackoff:  cfg.MaxBackoff,
+		ClaimLease:  cfg.ClaimLease,
+	})
+	dispatcher.Start(context.Background())
+}
+
+// startWebhookDispatcher launches the background worker that delivers webhook deliveries. The returned
+// dispatcher must be registered with the outbox publisher, which hands it the events to fan out; it is
+// nil when webhooks are disabled.
+func startWebhookDispatcher(log utils.Logger, webhookRepo repositories.WebhookRepository) *webhook.Dispatcher {
+	cfg := internalConfig.LoadWebhookConfig()
+	if !cfg.Enabled {
+		log.Info("Webhook dispatcher disabled (WEBHOOK_DISPATCH_ENABLED=false)")
+		return nil
+	}
+
+	dispatcher := webhook.NewDispatcher(log, webhookRepo, webhook.Config{
+		Interval:    cfg.Interval,
+		BatchSize:   cfg.BatchSize,
+		MaxAttempts: cfg.MaxAttempts,
+		BaseBackoff: cfg.BaseBackoff,
+		MaxBackoff:  cfg.MaxBackoff,
+		ClaimLease:  cfg.ClaimLease,
+		Timeout:     cfg.Timeout,
+	})
+	dispatcher.Start(context.Background())
+	return dispatcher
+}
+
+// startOutboxPublisher publishes the urgency outbox to the webhook dispatcher and, when a Google Cloud
+// project is configured, to the urgency events Pub/Sub topic. Without any sink the outbox is left as is.
+func startOutboxPublisher(log utils.Logger, db *gorm.DB, webhooks *webhook.Dispatcher) {
+	cfg := internalConfig.LoadOutboxConfig()
+	var sinks []outbox.Sink
+	if webhooks != nil {
+		sinks = append(sinks, webhooks)
 	}
 
+	client, err := outbox.NewPubSubClientFromEnv(context.Background(), log)
+	switch {
+	case err != nil:
+		log.Errorf("Failed to create Pub/Sub client, urgency events will not be streamed: %v", err)
+	case client == nil:
+		log.Warn("Urgency event stream disabled: no project ID in env")
+	default:
+		sinks = append(sinks, outbox.NewPubSubSink(log, client, cfg.Topic))
+	}
+
+	if len(sinks) == 0 {
+		log.Warn("Outbox publisher disabled: no webhook dispatcher or Pub/Sub topic configured")
+		return
+	}
+	outbox.NewPublisher(log, outbox.NewRepository(log, db), outbox.Config{
+		Name:      cfg.Topic,
+		Interval:  cfg.Interval,
+		BatchSize: cfg.BatchSize,
+	}, sinks...).Start(context.Background())
+}
+
+// loadServiceOptions builds the duplicate detection, SLA and intake policies and the notification templates from
+// environment variables. Broken template overrides stop startup instead of failing each notification later.
+func loadServiceOptions(log utils.Logger, limiter ratelimit.Limiter) internal.UrgencyServiceOptions {
+	dupCfg := internalConfig.LoadDuplicateDetectionConfig()
+	slaCfg := internalConfig.LoadSLAConfig()
+	notifCfg := internalConfig.LoadNotificationConfig()
+	intakeCfg := internalConfig.LoadIntakeConfig()
+	registry, err := templates.New(notifCfg.TemplatesDir, notifCfg.DefaultLocale)
+	if err != nil {
+		log.Fatalf("Failed to load notification templates: %v", err)
+	}
+	return internal.UrgencyServiceOptions{
+		Duplicates: internal.DuplicatePolicy{
+			Enabled:         dupCfg.Enabled,
+			PhoneWindow:     dupCfg.PhoneWindow,
+			ProximityWindow: dupCfg.ProximityWindow,
+			RadiusKm:        dupCfg.RadiusKm,
+			MinSimilarity:   dupCfg.MinSimilarity,
+		},
+		SLA: internal.SLAPolicy{
+			Targets: map[urgencyV1.UrgencyLevel]time.Duration{
+				urgencyV1.Critical: slaCfg.CriticalTarget,
+				urgencyV1.High:     slaCfg.HighTarget,
+				urgencyV1.Medium:   slaCfg.MediumTarget,
+				urgencyV1.Low:      slaCfg.LowTarget,
+			},
+		},
+		Templates: registry,
+		Intake: internal.IntakePolicy{
+			Limiter:             limiter,
+			PhoneLimit:          intakeCfg.PhoneRateLimit,
+			PhoneWindow:         intakeCfg.PhoneRateWindow,
+			SuspectPhoneReports: intakeCfg.SuspectPhoneReports,
+			VerifyPhone:         intakeCfg.PhoneVerificationEnabled,
+			CodeTTL:             intakeCfg.VerificationCodeTTL,
+			MaxCodeAttempts:     intakeCfg.VerificationMaxAttempts,
+			// Verification codes go through the same SMS stand-in as notifications
+			CodeSender: notifier.NewLogSender(log, notifCfg.SinkFile),
+		},
+		Attachments: loadAttachmentPolicy(log),
+	}
+}
+
+// loadAttachmentPolicy opens the configured attachment store. A store that cannot be opened only
+// disables attachments; reporting and dispatching urgencies must keep working without them.
+func loadAttachmentPolicy(log utils.Logger) internal.AttachmentPolicy {
+	cfg := internalConfig.LoadAttachmentsConfig()
+	policy := internal.AttachmentPolicy{MaxSize: cfg.MaxSizeBytes, MaxPerUrgency: cfg.MaxPerUrgency}
+
+	var err error
+	switch cfg.Backend {
+	case internalConfig.AttachmentStorageAzure:
+		var client storage.AzureBlobClientWrapper
+		client, err = storage.NewAzureBlobClientWrapper(log, storage.AzureBlobConfig{
+			AccountName:   cfg.AzureAccountName,
+			AccountKey:    cfg.AzureAccountKey,
+			ContainerName: cfg.AzureContainer,
+		})
+		if err == nil {
+			policy.Store, err = storage.NewAzureBlobStore(log, client)
+		}
+	case internalConfig.AttachmentStorageLocal:
+		policy.Store, err = storage.NewLocalBlobStore(log, cfg.LocalDir)
+	default:
+		err = fmt.Errorf("unknown attachment storage backend %q", cfg.Backend)
+	}
+	if err != nil {
+		log.Errorf("Attachments disabled, failed to open %s attachment storage: %v", cfg.Backend, err)
+		policy.Store = nil
+		return policy
+	}
+	log.Infof("Attachments stored with the %s backend", cfg.Backend)
+	return policy
+}
+
+// startEscalator launches the background worker that escalates open urgencies nobody has accepted in time.
+func startEscalator(log utils.Logger, urgencyRepo repositories.UrgencyRepository, notificationRepo repositories.NotificationRepository, employeeClient clients.EmployeeClient, registry *templates.Registry) {
+	cfg := internalConfig.LoadEscalationConfig()
+	if !cfg.Enabled {
+		log.Info("Urgency escalation disabled (ESCALATION_ENABLED=false)")
+		return
+	}
+
+	policy := internal.EscalationPolicy{
+		Delays: map[urgencyV1.UrgencyLevel]time.Duration{
+			urgencyV1.Critical: cfg.CriticalDelay,
+			urgencyV1.High:     cfg.HighDelay,
+			urgencyV1.Medium:   cfg.MediumDelay,
+			urgencyV1.Low:      cfg.LowDelay,
+		},
+		WidenedShiftBuffer: cfg.WidenedShiftBuffer,
+	}
+	internal.NewEscalator(log, urgencyRepo, notificationRepo, employeeClient, policy).WithTemplates(registry).Start(context.Background(), cfg.Interval)
 }
@@ -0,0 +1,18 @@
+package internal
+
+import "context"
+
+type actorKey struct{}
+
+// withActor stores the authenticated employee ID so service methods can attribute history events.
+func withActor(ctx context.Context, employeeID uint) context.Context {
+	return context.WithValue(ctx, actorKey{}, employeeID)
+}
+
+// actorFromContext returns the employee that triggered the request, or nil for system actions.
+func actorFromContext(ctx context.Context) *uint {
+	if id, ok := ctx.Value(actorKey{}).(uint); ok && id != 0 {
+		return &id
+	}
+	return nil
+}
@@ -0,0 +1,334 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	"github.com/pd120424d/mountain-service/api/shared/storage"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
+)
+
+// maxAttachmentFileNameLength keeps stored display names within what browsers and file systems accept
+const maxAttachmentFileNameLength = 255
+
+// attachmentTypes are the accepted content types with the extension their blobs are stored under.
+// The type is sniffed from the file body; what the client claims is ignored.
+var attachmentTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+}
+
+// AttachmentPolicy configures where urgency attachments are stored and how many of them are accepted
+type AttachmentPolicy struct {
+	// Store keeps the files; attachments are unavailable without it
+	Store         storage.BlobStore
+	MaxSize       int64
+	MaxPerUrgency int
+}
+
+// DefaultAttachmentPolicy returns the limits used when no overrides are configured. There is no
+// default store, so attachments stay off until one is provided.
+func DefaultAttachmentPolicy() AttachmentPolicy {
+	return AttachmentPolicy{MaxSize: 10 << 20, MaxPerUrgency: 20}
+}
+
+// AttachmentUpload is a file sent for an urgency, optionally for one of its activities
+type AttachmentUpload struct {
+	FileName   string
+	ActivityID *uint
+	Body       io.Reader
+}
+
+// UploadAttachment stores a photo or document for an urgency. Only employees working on the urgency may
+// upload: admins, the assignee, team members and employees who were notified about it.
+func (s *urgencyService) UploadAttachment(ctx context.Context, urgencyID, actorID uint, isAdmin bool, upload AttachmentUpload) (*urgencyV1.AttachmentResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.UploadAttachment")()
+
+	urg, err := s.GetUrgencyByID(ctx, urgencyID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeResponderAccess(ctx, urg, actorID, isAdmin, "access its attachments"); err != nil {
+		return nil, err
+	}
+	if err := s.ensureActivityOfUrgency(ctx, urg.ID, upload.ActivityID); err != nil {
+		return nil, err
+	}
+	return s.storeAttachment(ctx, urg.ID, &actorID, upload)
+}
+
+// UploadReporterAttachment stores a photo the reporter sends through the tracking link. Like follow-ups it is
+// only accepted while responders are working on the urgency, and not before the report was let through.
+func (s *urgencyService) UploadReporterAttachment(ctx context.Context, token string, upload AttachmentUpload) (*urgencyV1.AttachmentResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.UploadReporterAttachment")()
+
+	urg, err := s.trackedUrgency(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if !urg.IntakeAccepted() {
+		return nil, commonv1.NewAppError("AUTH_ERRORS.FORBIDDEN", "attachments are accepted once the report has been let through", map[string]interface{}{"intakeStatus": urg.IntakeStatus})
+	}
+	if urg.Status != urgencyV1.Open && urg.Status != urgencyV1.InProgress {
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.INVALID_TRANSITION", "attachments are only accepted while the urgency is open or in progress", map[string]interface{}{"status": urg.Status})
+	}
+	upload.ActivityID = nil
+	return s.storeAttachment(ctx, urg.ID, nil, upload)
+}
+
+// ListAttachments returns the attachments of an urgency in upload order, only those of one activity when activityID is set
+func (s *urgencyService) ListAttachments(ctx context.Context, urgencyID, actorID uint, isAdmin bool, activityID *uint) ([]urgencyV1.AttachmentResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.ListAttachments")()
+
+	urg, err := s.GetUrgencyByID(ctx, urgencyID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeResponderAccess(ctx, urg, actorID, isAdmin, "access its attachments"); err != nil {
+		return nil, err
+	}
+	attachments, err := s.repo.ListAttachments(ctx, urg.ID, activityID)
+	if err != nil {
+		log.Errorf("Failed to list attachments of urgency %d: %v", urg.ID, err)
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.DB_ERROR", "failed to list attachments", map[string]interface{}{"cause": err.Error()})
+	}
+	resp := make([]urgencyV1.AttachmentResponse, 0, len(attachments))
+	for i := range attachments {
+		resp = append(resp, attachments[i].ToResponse())
+	}
+	return resp, nil
+}
+
+// OpenAttachment returns the attachment and a reader for its content; the caller closes the reader
+func (s *urgencyService) OpenAttachment(ctx context.Context, urgencyID, attachmentID, actorID uint, isAdmin bool) (*urgencyV1.AttachmentResponse, io.ReadCloser, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.OpenAttachment")()
+
+	if s.attachments.Store == nil {
+		return nil, nil, attachmentsUnavailableError()
+	}
+	urg, err := s.GetUrgencyByID(ctx, urgencyID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.authorizeResponderAccess(ctx, urg, actorID, isAdmin, "access its attachments"); err != nil {
+		return nil, nil, err
+	}
+	attachment, err := s.getAttachment(ctx, urg.ID, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	body, err := s.attachments.Store.Get(ctx, attachment.BlobName)
+	if errors.Is(err, storage.ErrBlobNotFound) {
+		log.Warnf("Blob %s of attachment %d is missing", attachment.BlobName, attachment.ID)
+		return nil, nil, attachmentNotFoundError(attachmentID)
+	}
+	if err != nil {
+		log.Errorf("Failed to read blob %s of attachment %d: %v", attachment.BlobName, attachment.ID, err)
+		return nil, nil, commonv1.NewAppError("URGENCY_ERRORS.STORAGE_FAILED", "failed to read attachment", map[string]interface{}{"cause": err.Error()})
+	}
+	resp := attachment.ToResponse()
+	return &resp, body, nil
+}
+
+// DeleteAttachment removes an attachment. The uploader, the assignee and admins may delete it.
+func (s *urgencyService) DeleteAttachment(ctx context.Context, urgencyID, attachmentID, actorID uint, isAdmin bool) error {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.DeleteAttachment")()
+
+	if s.attachments.Store == nil {
+		return attachmentsUnavailableError()
+	}
+	urg, err := s.GetUrgencyByID(ctx, urgencyID)
+	if err != nil {
+		return err
+	}
+	attachment, err := s.getAttachment(ctx, urg.ID, attachmentID)
+	if err != nil {
+		return err
+	}
+	if attachment.UploadedBy == nil || *attachment.UploadedBy != actorID {
+		if err := authorizeAssigneeOrAdmin(urg, actorID, isAdmin, "delete attachments of others"); err != nil {
+			return err
+		}
+	}
+	if err := s.repo.DeleteAttachment(ctx, attachment.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return attachmentNotFoundError(attachmentID)
+		}
+		log.Errorf("Failed to delete attachment %d: %v", attachment.ID, err)
+		return commonv1.NewAppError("URGENCY_ERRORS.DB_ERROR", "failed to delete attachment", map[string]interface{}{"cause": err.Error()})
+	}
+	// The row is gone, so the file is no longer reachable; a blob left behind is only wasted space
+	if err := s.attachments.Store.Delete(ctx, attachment.BlobName); err != nil {
+		log.Errorf("Failed to delete blob %s of attachment %d: %v", attachment.BlobName, attachment.ID, err)
+	}
+	log.Infof("Employee %d deleted attachment %d of urgency %d", actorID, attachment.ID, urg.ID)
+	return nil
+}
+
+// storeAttachment validates the file, strips image metadata and stores it under a generated name
+func (s *urgencyService) storeAttachment(ctx context.Context, urgencyID uint, uploadedBy *uint, upload AttachmentUpload) (*urgencyV1.AttachmentResponse, error) {
+	log := s.log.WithContext(ctx)
+	p := s.attachments
+	if p.Store == nil {
+		return nil, attachmentsUnavailableError()
+	}
+
+	count, err := s.repo.CountAttachments(ctx, urgencyID)
+	if err != nil {
+		log.Errorf("Failed to count attachments of urgency %d: %v", urgencyID, err)
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.DB_ERROR", "failed to store attachment", map[string]interface{}{"cause": err.Error()})
+	}
+	if p.MaxPerUrgency > 0 && count >= int64(p.MaxPerUrgency) {
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.ATTACHMENT_LIMIT_REACHED", fmt.Sprintf("an urgency can have at most %d attachments", p.MaxPerUrgency), map[string]interface{}{"max": p.MaxPerUrgency})
+	}
+
+	data, err := io.ReadAll(io.LimitReader(upload.Body, p.MaxSize+1))
+	if err != nil {
+		return nil, commonv1.NewAppError("VALIDATION.INVALID_REQUEST", "failed to read uploaded file", map[string]interface{}{"cause": err.Error()})
+	}
+	if int64(len(data)) > p.MaxSize {
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.ATTACHMENT_TOO_LARGE", fmt.Sprintf("file exceeds the maximum size of %d bytes", p.MaxSize), map[string]interface{}{"maxBytes": p.MaxSize})
+	}
+	if len(data) == 0 {
+		return nil, commonv1.NewAppError("VALIDATION.INVALID_REQUEST", "file is empty", nil)
+	}
+	contentType := storage.DetectContentType(data)
+	ext, ok := attachmentTypes[contentType]
+	if !ok {
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.UNSUPPORTED_ATTACHMENT_TYPE", fmt.Sprintf("unsupported file type %s, allowed are JPEG, PNG, WebP and PDF", contentType), map[string]interface{}{"contentType": contentType})
+	}
+	data, err = storage.StripImageMetadata(contentType, data)
+	if err != nil {
+		return nil, commonv1.NewAppError("VALIDATION.INVALID_REQUEST", "file is not a valid image", map[string]interface{}{"cause": err.Error()})
+	}
+
+	attachment := &model.Attachment{
+		UrgencyID:   urgencyID,
+		ActivityID:  upload.ActivityID,
+		FileName:    attachmentFileName(upload.FileName, ext),
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		BlobName:    fmt.Sprintf("urgency-%d/%s%s", urgencyID, uuid.New().String(), ext),
+		UploadedBy:  uploadedBy,
+	}
+	if err := p.Store.Put(ctx, attachment.BlobName, bytes.NewReader(data), contentType); err != nil {
+		log.Errorf("Failed to store attachment for urgency %d: %v", urgencyID, err)
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.STORAGE_FAILED", "failed to store attachment", map[string]interface{}{"cause": err.Error()})
+	}
+	if err := s.repo.CreateAttachment(ctx, attachment); err != nil {
+		log.Errorf("Failed to save attachment of urgency %d: %v", urgencyID, err)
+		if derr := p.Store.Delete(ctx, attachment.BlobName); derr != nil {
+			log.Errorf("Failed to clean up blob %s: %v", attachment.BlobName, derr)
+		}
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.DB_ERROR", "failed to store attachment", map[string]interface{}{"cause": err.Error()})
+	}
+	log.Infof("Stored attachment %d (%s, %d bytes) for urgency %d", attachment.ID, contentType, attachment.Size, urgencyID)
+	resp := attachment.ToResponse()
+	return &resp, nil
+}
+
+// authorizeResponderAccess lets admins and the employees working on the urgency through: the assignee,
+// team members and employees who were notified about it
+func (s *urgencyService) authorizeResponderAccess(ctx context.Context, urg *model.Urgency, actorID uint, isAdmin bool, action string) error {
+	if isAdmin || (urg.AssignedEmployeeID != nil && *urg.AssignedEmployeeID == actorID) {
+		return nil
+	}
+	members, err := s.repo.ListTeam(ctx, urg.ID)
+	if err != nil {
+		s.log.WithContext(ctx).Errorf("Failed to list team of urgency %d: %v", urg.ID, err)
+		return commonv1.NewAppError("URGENCY_ERRORS.DB_ERROR", "failed to check urgency access", map[string]interface{}{"cause": err.Error()})
+	}
+	for _, m := range members {
+		if m.EmployeeID == actorID {
+			return nil
+		}
+	}
+	if err := s.ensureNotified(ctx, urg.ID, actorID); err != nil {
+		if aerr, ok := err.(*commonv1.AppError); ok && aerr.Code == "URGENCY_ERRORS.NOT_NOTIFIED" {
+			return commonv1.NewAppError("AUTH_ERRORS.FORBIDDEN", fmt.Sprintf("only employees working on the urgency can %s", action), nil)
+		}
+		return err
+	}
+	return nil
+}
+
+// ensureActivityOfUrgency checks that an attachment is tied to an activity of the same urgency
+func (s *urgencyService) ensureActivityOfUrgency(ctx context.Context, urgencyID uint, activityID *uint) error {
+	if activityID == nil {
+		return nil
+	}
+	if s.activityClient == nil {
+		return commonv1.NewAppError("URGENCY_ERRORS.ACTIVITY_FETCH_FAILED", "activities are not available", nil)
+	}
+	activities, err := s.activityClient.GetActivitiesByUrgency(ctx, urgencyID)
+	if err != nil {
+		s.log.WithContext(ctx).Errorf("Failed to get activities for urgency %d: %v", urgencyID, err)
+		return commonv1.NewAppError("URGENCY_ERRORS.ACTIVITY_FETCH_FAILED", "failed to fetch activities", map[string]interface{}{"cause": err.Error()})
+	}
+	for _, a := range activities {
+		if a.ID == *activityID {
+			return nil
+		}
+	}
+	return commonv1.NewAppError("VALIDATION.INVALID_REQUEST", "activity does not belong to this urgency", map[string]interface{}{"activityId": *activityID})
+}
+
+func (s *urgencyService) getAttachment(ctx context.Context, urgencyID, attachmentID uint) (*model.Attachment, error) {
+	var attachment model.Attachment
+	if err := s.repo.GetAttachment(ctx, urgencyID, attachmentID, &attachment); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, attachmentNotFoundError(attachmentID)
+		}
+		s.log.WithContext(ctx).Errorf("Failed to fetch attachment %d: %v", attachmentID, err)
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.DB_ERROR", "failed to fetch attachment", map[string]interface{}{"cause": err.Error()})
+	}
+	return &attachment, nil
+}
+
+// attachmentFileName keeps only the base name of what the client sent, without control characters or quotes,
+// so it can be shown and put into a Content-Disposition header safely
+func attachmentFileName(name, ext string) string {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' || r == utf8.RuneError {
+			return -1
+		}
+		return r
+	}, name))
+	if name == "" || name == "." || name == "/" {
+		return "attachment" + ext
+	}
+	for len(name) > maxAttachmentFileNameLength {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return name
+}
+
+func attachmentNotFoundError(id uint) error {
+	return commonv1.NewAppError("URGENCY_ERRORS.ATTACHMENT_NOT_FOUND", "attachment not found", map[string]interface{}{"attachmentId": id})
+}
+
+func attachmentsUnavailableError() error {
+	return commonv1.NewAppError("URGENCY_ERRORS.ATTACHMENTS_UNAVAILABLE", "attachment storage is not configured", nil)
+}
@@ -0,0 +1,313 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/jpeg"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	activityV1 "github.com/pd120424d/mountain-service/api/contracts/activity/v1"
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	"github.com/pd120424d/mountain-service/api/shared/storage"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/clients"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/repositories"
+)
+
+type attachmentMocks struct {
+	repo     *repositories.MockUrgencyRepository
+	notif    *repositories.MockNotificationRepository
+	activity *clients.MockActivityClient
+	store    *storage.LocalBlobStore
+}
+
+func newAttachmentService(t *testing.T, configure func(p *AttachmentPolicy)) (UrgencyService, attachmentMocks) {
+	ctrl := gomock.NewController(t)
+	store, err := storage.NewLocalBlobStore(utils.NewTestLogger(), t.TempDir())
+	require.NoError(t, err)
+	m := attachmentMocks{
+		repo:     repositories.NewMockUrgencyRepository(ctrl),
+		notif:    repositories.NewMockNotificationRepository(ctrl),
+		activity: clients.NewMockActivityClient(ctrl),
+		store:    store,
+	}
+	opts := DefaultUrgencyServiceOptions()
+	opts.Attachments.Store = store
+	if configure != nil {
+		configure(&opts.Attachments)
+	}
+	return NewUrgencyServiceWithOptions(utils.NewTestLogger(), m.repo, m.notif, clients.NewMockEmployeeClient(ctrl), m.activity, opts), m
+}
+
+func expectAttachmentUrgency(repo *repositories.MockUrgencyRepository, urg model.Urgency) {
+	repo.EXPECT().GetByID(gomock.Any(), urg.ID, gomock.Any()).DoAndReturn(func(_ context.Context, _ uint, u *model.Urgency) error {
+		*u = urg
+		return nil
+	}).AnyTimes()
+}
+
+// photoWithLocation returns a JPEG with an EXIF segment holding GPS coordinates
+func photoWithLocation(t *testing.T) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, 2, 2)), nil))
+	exif := []byte("Exif\x00\x00GPS 43.4011N 22.6627E")
+	segment := append([]byte{0xff, 0xe1, 0, byte(len(exif) + 2)}, exif...)
+	encoded := buf.Bytes()
+	return append(append(append([]byte{}, encoded[:2]...), segment...), encoded[2:]...)
+}
+
+func TestUrgencyService_UploadAttachment(t *testing.T) {
+	t.Parallel()
+	assignee := uint(5)
+	urg := model.Urgency{ID: 1, Status: urgencyV1.InProgress, AssignedEmployeeID: &assignee}
+
+	t.Run("it stores a photo of the assignee without its location metadata", func(t *testing.T) {
+		svc, m := newAttachmentService(t, nil)
+		expectAttachmentUrgency(m.repo, urg)
+		m.repo.EXPECT().CountAttachments(gomock.Any(), uint(1)).Return(int64(0), nil)
+		var stored model.Attachment
+		m.repo.EXPECT().CreateAttachment(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, a *model.Attachment) error {
+			a.ID = 9
+			stored = *a
+			return nil
+		})
+
+		resp, err := svc.UploadAttachment(context.Background(), 1, assignee, false, AttachmentUpload{FileName: `C:\photos\ankle.jpeg`, Body: bytes.NewReader(photoWithLocation(t))})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(9), resp.ID)
+		assert.Equal(t, "ankle.jpeg", resp.FileName)
+		assert.Equal(t, "image/jpeg", resp.ContentType)
+		assert.Equal(t, &assignee, resp.UploadedBy)
+		assert.True(t, strings.HasPrefix(stored.BlobName, "urgency-1/"))
+		assert.True(t, strings.HasSuffix(stored.BlobName, ".jpg"))
+
+		r, err := m.store.Get(context.Background(), stored.BlobName)
+		require.NoError(t, err)
+		defer r.Close()
+		content, _ := io.ReadAll(r)
+		assert.NotContains(t, string(content), "GPS")
+		assert.Equal(t, stored.Size, int64(len(content)))
+	})
+
+	t.Run("it lets notified employees upload", func(t *testing.T) {
+		svc, m := newAttachmentService(t, nil)
+		expectAttachmentUrgency(m.repo, urg)
+		m.repo.EXPECT().ListTeam(gomock.Any(), uint(1)).Return(nil, nil)
+		m.notif.EXPECT().GetByUrgencyID(gomock.Any(), uint(1)).Return([]model.Notification{{EmployeeID: 8}}, nil)
+		m.repo.EXPECT().CountAttachments(gomock.Any(), uint(1)).Return(int64(0), nil)
+		m.repo.EXPECT().CreateAttachment(gomock.Any(), gomock.Any()).Return(nil)
+
+		_, err := svc.UploadAttachment(context.Background(), 1, 8, false, AttachmentUpload{FileName: "report.pdf", Body: strings.NewReader("%PDF-1.7\n")})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("it forbids employees not working on the urgency", func(t *testing.T) {
+		svc, m := newAttachmentService(t, nil)
+		expectAttachmentUrgency(m.repo, urg)
+		m.repo.EXPECT().ListTeam(gomock.Any(), uint(1)).Return([]model.UrgencyAssignment{{EmployeeID: 6}}, nil)
+		m.notif.EXPECT().GetByUrgencyID(gomock.Any(), uint(1)).Return(nil, nil)
+
+		_, err := svc.UploadAttachment(context.Background(), 1, 8, false, AttachmentUpload{FileName: "x.pdf", Body: strings.NewReader("%PDF-1.7\n")})
+
+		assert.Equal(t, "AUTH_ERRORS.FORBIDDEN", appErrorCode(t, err))
+	})
+
+	t.Run("it rejects files by their content, size and count", func(t *testing.T) {
+		svc, m := newAttachmentService(t, func(p *AttachmentPolicy) {
+			p.MaxSize = 16
+			p.MaxPerUrgency = 2
+		})
+		expectAttachmentUrgency(m.repo, urg)
+		m.repo.EXPECT().CountAttachments(gomock.Any(), uint(1)).Return(int64(1), nil).Times(3)
+		m.repo.EXPECT().CountAttachments(gomock.Any(), uint(1)).Return(int64(2), nil)
+
+		_, err := svc.UploadAttachment(context.Background(), 1, assignee, true, AttachmentUpload{FileName: "photo.jpg", Body: strings.NewReader("<html>not a photo")})
+		assert.Equal(t, "URGENCY_ERRORS.ATTACHMENT_TOO_LARGE", appErrorCode(t, err))
+
+		_, err = svc.UploadAttachment(context.Background(), 1, assignee, true, AttachmentUpload{FileName: "photo.jpg", Body: strings.NewReader("<html></html>")})
+		assert.Equal(t, "URGENCY_ERRORS.UNSUPPORTED_ATTACHMENT_TYPE", appErrorCode(t, err))
+
+		_, err = svc.UploadAttachment(context.Background(), 1, assignee, true, AttachmentUpload{FileName: "photo.jpg", Body: strings.NewReader("\xff\xd8\xff\xe0\x00")})
+		assert.Equal(t, "VALIDATION.INVALID_REQUEST", appErrorCode(t, err))
+
+		_, err = svc.UploadAttachment(context.Background(), 1, assignee, true, AttachmentUpload{FileName: "a.pdf", Body: strings.NewReader("%PDF-1.7\n")})
+		assert.Equal(t, "URGENCY_ERRORS.ATTACHMENT_LIMIT_REACHED", appErrorCode(t, err))
+	})
+
+	t.Run("it only ties attachments to activities of the same urgency", func(t *testing.T) {
+		svc, m := newAttachmentService(t, nil)
+		expectAttachmentUrgency(m.repo, urg)
+		m.activity.EXPECT().GetActivitiesByUrgency(gomock.Any(), uint(1)).Return([]activityV1.ActivityResponse{{ID: 3, UrgencyID: 1}}, nil).Times(2)
+		m.repo.EXPECT().CountAttachments(gomock.Any(), uint(1)).Return(int64(0), nil)
+		m.repo.EXPECT().CreateAttachment(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, a *model.Attachment) error {
+			assert.Equal(t, uint(3), *a.ActivityID)
+			return nil
+		})
+
+		own, other := uint(3), uint(4)
+		_, err := svc.UploadAttachment(context.Background(), 1, assignee, false, AttachmentUpload{FileName: "a.pdf", ActivityID: &own, Body: strings.NewReader("%PDF-1.7\n")})
+		require.NoError(t, err)
+
+		_, err = svc.UploadAttachment(context.Background(), 1, assignee, false, AttachmentUpload{FileName: "a.pdf", ActivityID: &other, Body: strings.NewReader("%PDF-1.7\n")})
+		assert.Equal(t, "VALIDATION.INVALID_REQUEST", appErrorCode(t, err))
+	})
+
+	t.Run("it removes the stored file when the attachment cannot be saved", func(t *testing.T) {
+		svc, m := newAttachmentService(t, nil)
+		expectAttachmentUrgency(m.repo, urg)
+		m.repo.EXPECT().CountAttachments(gomock.Any(), uint(1)).Return(int64(0), nil)
+		var blobName string
+		m.repo.EXPECT().CreateAttachment(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, a *model.Attachment) error {
+			blobName = a.BlobName
+			return errors.New("db down")
+		})
+
+		_, err := svc.UploadAttachment(context.Background(), 1, assignee, false, AttachmentUpload{FileName: "a.pdf", Body: strings.NewReader("%PDF-1.7\n")})
+
+		assert.Equal(t, "URGENCY_ERRORS.DB_ERROR", appErrorCode(t, err))
+		_, err = m.store.Get(context.Background(), blobName)
+		assert.ErrorIs(t, err, storage.ErrBlobNotFound)
+	})
+
+	t.Run("it reports attachments as unavailable without a store", func(t *testing.T) {
+		svc, m := newAttachmentService(t, func(p *AttachmentPolicy) { p.Store = nil })
+		expectAttachmentUrgency(m.repo, urg)
+
+		_, err := svc.UploadAttachment(context.Background(), 1, assignee, false, AttachmentUpload{FileName: "a.pdf", Body: strings.NewReader("%PDF-1.7\n")})
+
+		assert.Equal(t, "URGENCY_ERRORS.ATTACHMENTS_UNAVAILABLE", appErrorCode(t, err))
+	})
+}
+
+func TestUrgencyService_UploadReporterAttachment(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it stores a photo from the reporter without an uploader", func(t *testing.T) {
+		svc, m := newAttachmentService(t, nil)
+		expectTrackedUrgency(m.repo, "tok", model.Urgency{ID: 1, Status: urgencyV1.Open, IntakeStatus: urgencyV1.IntakeAccepted})
+		m.repo.EXPECT().CountAttachments(gomock.Any(), uint(1)).Return(int64(0), nil)
+		m.repo.EXPECT().CreateAttachment(gomock.Any(), gomock.Any()).Return(nil)
+
+		resp, err := svc.UploadReporterAttachment(context.Background(), "tok", AttachmentUpload{FileName: "leg.jpg", Body: bytes.NewReader(photoWithLocation(t))})
+
+		require.NoError(t, err)
+		assert.Nil(t, resp.UploadedBy)
+	})
+
+	t.Run("it refuses photos for reports that were not let through or are finished", func(t *testing.T) {
+		svc, m := newAttachmentService(t, nil)
+		expectTrackedUrgency(m.repo, "held", model.Urgency{ID: 1, Status: urgencyV1.Open, IntakeStatus: urgencyV1.IntakeQuarantined})
+		expectTrackedUrgency(m.repo, "done", model.Urgency{ID: 2, Status: urgencyV1.Resolved})
+
+		_, err := svc.UploadReporterAttachment(context.Background(), "held", AttachmentUpload{Body: strings.NewReader("%PDF-1.7\n")})
+		assert.Equal(t, "AUTH_ERRORS.FORBIDDEN", appErrorCode(t, err))
+
+		_, err = svc.UploadReporterAttachment(context.Background(), "done", AttachmentUpload{Body: strings.NewReader("%PDF-1.7\n")})
+		assert.Equal(t, "URGENCY_ERRORS.INVALID_TRANSITION", appErrorCode(t, err))
+	})
+}
+
+func TestUrgencyService_OpenAttachment(t *testing.T) {
+	t.Parallel()
+	urg := model.Urgency{ID: 1, Status: urgencyV1.InProgress}
+
+	t.Run("it returns the content to team members", func(t *testing.T) {
+		svc, m := newAttachmentService(t, nil)
+		require.NoError(t, m.store.Put(context.Background(), "urgency-1/a.pdf", strings.NewReader("%PDF-1.7\n"), "application/pdf"))
+		expectAttachmentUrgency(m.repo, urg)
+		m.repo.EXPECT().ListTeam(gomock.Any(), uint(1)).Return([]model.UrgencyAssignment{{EmployeeID: 6}}, nil)
+		m.repo.EXPECT().GetAttachment(gomock.Any(), uint(1), uint(2), gomock.Any()).DoAndReturn(func(_ context.Context, _, _ uint, a *model.Attachment) error {
+			*a = model.Attachment{ID: 2, UrgencyID: 1, FileName: "a.pdf", ContentType: "application/pdf", Size: 9, BlobName: "urgency-1/a.pdf"}
+			return nil
+		})
+
+		resp, body, err := svc.OpenAttachment(context.Background(), 1, 2, 6, false)
+
+		require.NoError(t, err)
+		defer body.Close()
+		content, _ := io.ReadAll(body)
+		assert.Equal(t, "%PDF-1.7\n", string(content))
+		assert.Equal(t, "application/pdf", resp.ContentType)
+	})
+
+	t.Run("it reports a missing file as a missing attachment", func(t *testing.T) {
+		svc, m := newAttachmentService(t, nil)
+		expectAttachmentUrgency(m.repo, urg)
+		m.repo.EXPECT().GetAttachment(gomock.Any(), uint(1), uint(2), gomock.Any()).DoAndReturn(func(_ context.Context, _, _ uint, a *model.Attachment) error {
+			*a = model.Attachment{ID: 2, UrgencyID: 1, BlobName: "urgency-1/gone.pdf"}
+			return nil
+		})
+
+		_, _, err := svc.OpenAttachment(context.Background(), 1, 2, 1, true)
+
+		assert.Equal(t, "URGENCY_ERRORS.ATTACHMENT_NOT_FOUND", appErrorCode(t, err))
+	})
+}
+
+func TestUrgencyService_DeleteAttachment(t *testing.T) {
+	t.Parallel()
+	assignee := uint(5)
+	uploader := uint(6)
+	urg := model.Urgency{ID: 1, Status: urgencyV1.InProgress, AssignedEmployeeID: &assignee}
+	attachment := model.Attachment{ID: 2, UrgencyID: 1, BlobName: "urgency-1/a.pdf", UploadedBy: &uploader}
+	expectAttachment := func(repo *repositories.MockUrgencyRepository) {
+		repo.EXPECT().GetAttachment(gomock.Any(), uint(1), uint(2), gomock.Any()).DoAndReturn(func(_ context.Context, _, _ uint, a *model.Attachment) error {
+			*a = attachment
+			return nil
+		})
+	}
+
+	for _, tc := range []struct {
+		name    string
+		actorID uint
+		isAdmin bool
+	}{
+		{"it lets the uploader delete the attachment", uploader, false},
+		{"it lets the assignee delete the attachment", assignee, false},
+		{"it lets an admin delete the attachment", 99, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			svc, m := newAttachmentService(t, nil)
+			require.NoError(t, m.store.Put(context.Background(), attachment.BlobName, strings.NewReader("x"), "application/pdf"))
+			expectAttachmentUrgency(m.repo, urg)
+			expectAttachment(m.repo)
+			m.repo.EXPECT().DeleteAttachment(gomock.Any(), uint(2)).Return(nil)
+
+			require.NoError(t, svc.DeleteAttachment(context.Background(), 1, 2, tc.actorID, tc.isAdmin))
+
+			_, err := m.store.Get(context.Background(), attachment.BlobName)
+			assert.ErrorIs(t, err, storage.ErrBlobNotFound)
+		})
+	}
+
+	t.Run("it forbids other team members", func(t *testing.T) {
+		svc, m := newAttachmentService(t, nil)
+		expectAttachmentUrgency(m.repo, urg)
+		expectAttachment(m.repo)
+
+		err := svc.DeleteAttachment(context.Background(), 1, 2, 7, false)
+
+		assert.Equal(t, "AUTH_ERRORS.FORBIDDEN", appErrorCode(t, err))
+	})
+}
+
+func TestAttachmentFileName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "photo.jpg", attachmentFileName("../../etc/photo.jpg", ".jpg"))
+	assert.Equal(t, "photo.jpg", attachmentFileName(`C:\Users\me\photo.jpg`, ".jpg"))
+	assert.Equal(t, "badname.pdf", attachmentFileName("bad\"name\r\n.pdf", ".pdf"))
+	assert.Equal(t, "attachment.png", attachmentFileName("", ".png"))
+	assert.Len(t, attachmentFileName(strings.Repeat("ж", 200)+".jpg", ".jpg"), maxAttachmentFileNameLength-1)
+}
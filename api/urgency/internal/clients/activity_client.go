@@ -28,6 +28,7 @@ type ActivityClient interface {
 	CreateActivity(ctx context.Context, req *activityV1.ActivityCreateRequest) (*activityV1.ActivityResponse, error)
 	GetActivitiesByUrgency(ctx context.Context, urgencyID uint) ([]activityV1.ActivityResponse, error)
 	LogActivity(ctx context.Context, description string, employeeID, urgencyID uint) error
+	ReassignActivities(ctx context.Context, fromUrgencyID, toUrgencyID uint) (int, error)
 }
 
 // activityClient implements the ActivityClient interface
@@ -125,3 +126,31 @@ func (c *activityClient) LogActivity(ctx context.Context, description string, em
 	_, err := c.CreateActivity(ctx, req)
 	return err
 }
+
+// ReassignActivities moves all activities of one urgency onto another and returns how many were moved
+func (c *activityClient) ReassignActivities(ctx context.Context, fromUrgencyID, toUrgencyID uint) (int, error) {
+	log := c.logger.WithContext(ctx)
+	log.Infof("Reassigning activities from urgency %d to urgency %d", fromUrgencyID, toUrgencyID)
+
+	req := &activityV1.ActivityReassignRequest{FromUrgencyID: fromUrgencyID, ToUrgencyID: toUrgencyID}
+	resp, err := c.httpClient.Put(ctx, "/api/v1/service/activities/reassign", req)
+	if err != nil {
+		log.Errorf("Failed to reassign activities: %v", err)
+		return 0, fmt.Errorf("failed to reassign activities: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		log.Errorf("Activity service returned status %d", resp.StatusCode)
+		return 0, fmt.Errorf("activity service returned status %d", resp.StatusCode)
+	}
+
+	var result activityV1.ActivityReassignResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		log.Errorf("Failed to decode reassign response: %v", err)
+		return 0, fmt.Errorf("failed to decode reassign response: %w", err)
+	}
+
+	log.Infof("Reassigned %d activities from urgency %d to urgency %d", result.Moved, fromUrgencyID, toUrgencyID)
+	return result.Moved, nil
+}
@@ -72,15 +72,30 @@ func (mr *MockActivityClientMockRecorder) GetActivitiesByUrgency(ctx, urgencyID
 }
 
 // LogActivity mocks base method.
-func (m *MockActivityClient) LogActivity(ctx context.Context, level, description string, employeeID, urgencyID uint) error {
+func (m *MockActivityClient) LogActivity(ctx context.Context, description string, employeeID, urgencyID uint) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LogActivity", ctx, level, description, employeeID, urgencyID)
+	ret := m.ctrl.Call(m, "LogActivity", ctx, description, employeeID, urgencyID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // LogActivity indicates an expected call of LogActivity.
-func (mr *MockActivityClientMockRecorder) LogActivity(ctx, level, description, employeeID, urgencyID any) *gomock.Call {
+func (mr *MockActivityClientMockRecorder) LogActivity(ctx, description, employeeID, urgencyID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogActivity", reflect.TypeOf((*MockActivityClient)(nil).LogActivity), ctx, level, description, employeeID, urgencyID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogActivity", reflect.TypeOf((*MockActivityClient)(nil).LogActivity), ctx, description, employeeID, urgencyID)
+}
+
+// ReassignActivities mocks base method.
+func (m *MockActivityClient) ReassignActivities(ctx context.Context, fromUrgencyID, toUrgencyID uint) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReassignActivities", ctx, fromUrgencyID, toUrgencyID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReassignActivities indicates an expected call of ReassignActivities.
+func (mr *MockActivityClientMockRecorder) ReassignActivities(ctx, fromUrgencyID, toUrgencyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignActivities", reflect.TypeOf((*MockActivityClient)(nil).ReassignActivities), ctx, fromUrgencyID, toUrgencyID)
 }
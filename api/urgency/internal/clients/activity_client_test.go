@@ -442,6 +442,43 @@ func TestActivityClient_LogActivity(t *testing.T) {
 	})
 }
 
+func TestActivityClient_ReassignActivities(t *testing.T) {
+	t.Parallel()
+
+	newClient := func(t *testing.T) (*activityClient, *MockHTTPClient) {
+		ctrl := gomock.NewController(t)
+		mockHTTP := NewMockHTTPClient(ctrl)
+		return &activityClient{httpClient: mockHTTP, logger: utils.NewTestLogger().WithName("activityClient")}, mockHTTP
+	}
+
+	t.Run("it returns the number of moved activities", func(t *testing.T) {
+		client, mockHTTP := newClient(t)
+		mockResp := &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader([]byte(`{"moved":2}`)))}
+		mockHTTP.EXPECT().Put(gomock.Any(), "/api/v1/service/activities/reassign", &activityV1.ActivityReassignRequest{FromUrgencyID: 5, ToUrgencyID: 4}).Return(mockResp, nil)
+
+		moved, err := client.ReassignActivities(context.Background(), 5, 4)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, moved)
+	})
+
+	t.Run("it returns error for non-OK status", func(t *testing.T) {
+		client, mockHTTP := newClient(t)
+		mockResp := &http.Response{StatusCode: http.StatusInternalServerError, Body: io.NopCloser(bytes.NewReader(nil))}
+		mockHTTP.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResp, nil)
+
+		_, err := client.ReassignActivities(context.Background(), 5, 4)
+		assert.ErrorContains(t, err, "status 500")
+	})
+
+	t.Run("it returns error when HTTP request fails", func(t *testing.T) {
+		client, mockHTTP := newClient(t)
+		mockHTTP.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("network error"))
+
+		_, err := client.ReassignActivities(context.Background(), 5, 4)
+		assert.ErrorContains(t, err, "network error")
+	})
+}
+
 func TestNewActivityClient(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,35 @@
+package config
+
+import "os"
+
+const (
+	AttachmentStorageLocal = "local"
+	AttachmentStorageAzure = "azure"
+)
+
+// AttachmentsConfig holds where urgency attachments are stored and how large they may be
+type AttachmentsConfig struct {
+	// Backend is AttachmentStorageLocal or AttachmentStorageAzure
+	Backend  string
+	LocalDir string
+	// AzureAccountName, AzureAccountKey and AzureContainer select the private container used by the Azure backend
+	AzureAccountName string
+	AzureAccountKey  string
+	AzureContainer   string
+	MaxSizeBytes     int64
+	MaxPerUrgency    int
+}
+
+// LoadAttachmentsConfig loads attachment storage settings from environment variables.
+// The Azure backend reuses the storage account of the employee service.
+func LoadAttachmentsConfig() AttachmentsConfig {
+	return AttachmentsConfig{
+		Backend:          getEnvOrDefault("ATTACHMENT_STORAGE_BACKEND", AttachmentStorageLocal),
+		LocalDir:         getEnvOrDefault("ATTACHMENT_LOCAL_DIR", "/tmp/urgency-attachments"),
+		AzureAccountName: os.Getenv("AZURE_STORAGE_ACCOUNT_NAME"),
+		AzureAccountKey:  os.Getenv("AZURE_STORAGE_ACCOUNT_KEY"),
+		AzureContainer:   getEnvOrDefault("ATTACHMENT_AZURE_CONTAINER", "urgency-attachments"),
+		MaxSizeBytes:     int64(getEnvIntOrDefault("ATTACHMENT_MAX_SIZE_MB", 10)) << 20,
+		MaxPerUrgency:    getEnvIntOrDefault("ATTACHMENT_MAX_PER_URGENCY", 20),
+	}
+}
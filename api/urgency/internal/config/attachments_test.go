@@ -0,0 +1,36 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadAttachmentsConfig(t *testing.T) {
+	t.Run("it returns default values when environment variables are not set", func(t *testing.T) {
+		cfg := LoadAttachmentsConfig()
+		assert.Equal(t, AttachmentStorageLocal, cfg.Backend)
+		assert.Equal(t, "/tmp/urgency-attachments", cfg.LocalDir)
+		assert.Equal(t, "urgency-attachments", cfg.AzureContainer)
+		assert.Equal(t, int64(10<<20), cfg.MaxSizeBytes)
+		assert.Equal(t, 20, cfg.MaxPerUrgency)
+	})
+
+	t.Run("it returns environment variable values when set", func(t *testing.T) {
+		t.Setenv("ATTACHMENT_STORAGE_BACKEND", "azure")
+		t.Setenv("ATTACHMENT_LOCAL_DIR", "/data/attachments")
+		t.Setenv("AZURE_STORAGE_ACCOUNT_NAME", "account")
+		t.Setenv("AZURE_STORAGE_ACCOUNT_KEY", "key")
+		t.Setenv("ATTACHMENT_AZURE_CONTAINER", "evidence")
+		t.Setenv("ATTACHMENT_MAX_SIZE_MB", "4")
+		t.Setenv("ATTACHMENT_MAX_PER_URGENCY", "5")
+		cfg := LoadAttachmentsConfig()
+		assert.Equal(t, AttachmentStorageAzure, cfg.Backend)
+		assert.Equal(t, "/data/attachments", cfg.LocalDir)
+		assert.Equal(t, "account", cfg.AzureAccountName)
+		assert.Equal(t, "key", cfg.AzureAccountKey)
+		assert.Equal(t, "evidence", cfg.AzureContainer)
+		assert.Equal(t, int64(4<<20), cfg.MaxSizeBytes)
+		assert.Equal(t, 5, cfg.MaxPerUrgency)
+	})
+}
@@ -0,0 +1,25 @@
+package config
+
+import "time"
+
+// DuplicateDetectionConfig holds settings for linking repeated public reports to an existing urgency
+type DuplicateDetectionConfig struct {
+	Enabled bool
+	// PhoneWindow is how far back a report from the same contact phone counts as a duplicate
+	PhoneWindow time.Duration
+	// ProximityWindow is how far back a nearby report with a similar description counts as a duplicate
+	ProximityWindow time.Duration
+	RadiusKm        float64
+	MinSimilarity   float64
+}
+
+// LoadDuplicateDetectionConfig loads duplicate detection thresholds from environment variables
+func LoadDuplicateDetectionConfig() DuplicateDetectionConfig {
+	return DuplicateDetectionConfig{
+		Enabled:         getEnvOrDefault("DUPLICATE_DETECTION_ENABLED", "true") != "false",
+		PhoneWindow:     time.Duration(getEnvIntOrDefault("DUPLICATE_PHONE_WINDOW_MINUTES", 30)) * time.Minute,
+		ProximityWindow: time.Duration(getEnvIntOrDefault("DUPLICATE_PROXIMITY_WINDOW_MINUTES", 120)) * time.Minute,
+		RadiusKm:        float64(getEnvIntOrDefault("DUPLICATE_RADIUS_METERS", 500)) / 1000,
+		MinSimilarity:   float64(getEnvIntOrDefault("DUPLICATE_MIN_SIMILARITY_PERCENT", 30)) / 100,
+	}
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadDuplicateDetectionConfig(t *testing.T) {
+	t.Run("it returns default values when environment variables are not set", func(t *testing.T) {
+		cfg := LoadDuplicateDetectionConfig()
+		assert.True(t, cfg.Enabled)
+		assert.Equal(t, 30*time.Minute, cfg.PhoneWindow)
+		assert.Equal(t, 2*time.Hour, cfg.ProximityWindow)
+		assert.Equal(t, 0.5, cfg.RadiusKm)
+		assert.Equal(t, 0.3, cfg.MinSimilarity)
+	})
+
+	t.Run("it returns environment variable values when set", func(t *testing.T) {
+		t.Setenv("DUPLICATE_DETECTION_ENABLED", "false")
+		t.Setenv("DUPLICATE_PHONE_WINDOW_MINUTES", "10")
+		t.Setenv("DUPLICATE_RADIUS_METERS", "250")
+		cfg := LoadDuplicateDetectionConfig()
+		assert.False(t, cfg.Enabled)
+		assert.Equal(t, 10*time.Minute, cfg.PhoneWindow)
+		assert.Equal(t, 0.25, cfg.RadiusKm)
+	})
+}
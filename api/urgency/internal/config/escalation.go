@@ -0,0 +1,27 @@
+package config
+
+import "time"
+
+// EscalationConfig holds settings for escalating urgencies that stay open and unassigned
+type EscalationConfig struct {
+	Enabled            bool
+	Interval           time.Duration
+	CriticalDelay      time.Duration
+	HighDelay          time.Duration
+	MediumDelay        time.Duration
+	LowDelay           time.Duration
+	WidenedShiftBuffer time.Duration
+}
+
+// LoadEscalationConfig loads the per-level escalation policy from environment variables
+func LoadEscalationConfig() EscalationConfig {
+	return EscalationConfig{
+		Enabled:            getEnvOrDefault("ESCALATION_ENABLED", "true") != "false",
+		Interval:           time.Duration(getEnvIntOrDefault("ESCALATION_INTERVAL_SECONDS", 30)) * time.Second,
+		CriticalDelay:      time.Duration(getEnvIntOrDefault("ESCALATION_CRITICAL_MINUTES", 5)) * time.Minute,
+		HighDelay:          time.Duration(getEnvIntOrDefault("ESCALATION_HIGH_MINUTES", 15)) * time.Minute,
+		MediumDelay:        time.Duration(getEnvIntOrDefault("ESCALATION_MEDIUM_MINUTES", 30)) * time.Minute,
+		LowDelay:           time.Duration(getEnvIntOrDefault("ESCALATION_LOW_MINUTES", 60)) * time.Minute,
+		WidenedShiftBuffer: time.Duration(getEnvIntOrDefault("ESCALATION_WIDENED_SHIFT_BUFFER_HOURS", 8)) * time.Hour,
+	}
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadEscalationConfig(t *testing.T) {
+	t.Run("it returns default values when environment variables are not set", func(t *testing.T) {
+		cfg := LoadEscalationConfig()
+		assert.True(t, cfg.Enabled)
+		assert.Equal(t, 30*time.Second, cfg.Interval)
+		assert.Equal(t, 5*time.Minute, cfg.CriticalDelay)
+		assert.Equal(t, 15*time.Minute, cfg.HighDelay)
+		assert.Equal(t, 30*time.Minute, cfg.MediumDelay)
+		assert.Equal(t, 60*time.Minute, cfg.LowDelay)
+		assert.Equal(t, 8*time.Hour, cfg.WidenedShiftBuffer)
+	})
+
+	t.Run("it returns environment variable values when set", func(t *testing.T) {
+		t.Setenv("ESCALATION_ENABLED", "false")
+		t.Setenv("ESCALATION_CRITICAL_MINUTES", "2")
+		t.Setenv("ESCALATION_WIDENED_SHIFT_BUFFER_HOURS", "12")
+		cfg := LoadEscalationConfig()
+		assert.False(t, cfg.Enabled)
+		assert.Equal(t, 2*time.Minute, cfg.CriticalDelay)
+		assert.Equal(t, 12*time.Hour, cfg.WidenedShiftBuffer)
+	})
+}
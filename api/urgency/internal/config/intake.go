@@ -0,0 +1,33 @@
+package config
+
+import "time"
+
+// IntakeConfig holds settings that protect the public report endpoint from floods and abuse
+type IntakeConfig struct {
+	// IPRateLimit is the number of reports one client IP may submit per IPRateWindow
+	IPRateLimit  int
+	IPRateWindow time.Duration
+	// PhoneRateLimit is the number of reports one contact phone may submit per PhoneRateWindow
+	PhoneRateLimit  int
+	PhoneRateWindow time.Duration
+	// SuspectPhoneReports quarantines new incidents from one phone beyond this many per PhoneRateWindow
+	SuspectPhoneReports int
+	// PhoneVerificationEnabled holds low and medium reports until the reporter confirms the phone by SMS code
+	PhoneVerificationEnabled bool
+	VerificationCodeTTL      time.Duration
+	VerificationMaxAttempts  int
+}
+
+// LoadIntakeConfig loads public report limits and verification settings from environment variables
+func LoadIntakeConfig() IntakeConfig {
+	return IntakeConfig{
+		IPRateLimit:              getEnvIntOrDefault("INTAKE_IP_RATE_LIMIT", 20),
+		IPRateWindow:             time.Duration(getEnvIntOrDefault("INTAKE_IP_RATE_WINDOW_MINUTES", 60)) * time.Minute,
+		PhoneRateLimit:           getEnvIntOrDefault("INTAKE_PHONE_RATE_LIMIT", 5),
+		PhoneRateWindow:          time.Duration(getEnvIntOrDefault("INTAKE_PHONE_RATE_WINDOW_MINUTES", 60)) * time.Minute,
+		SuspectPhoneReports:      getEnvIntOrDefault("INTAKE_SUSPECT_PHONE_REPORTS", 3),
+		PhoneVerificationEnabled: getEnvOrDefault("INTAKE_PHONE_VERIFICATION_ENABLED", "false") == "true",
+		VerificationCodeTTL:      time.Duration(getEnvIntOrDefault("INTAKE_VERIFICATION_CODE_TTL_MINUTES", 10)) * time.Minute,
+		VerificationMaxAttempts:  getEnvIntOrDefault("INTAKE_VERIFICATION_MAX_ATTEMPTS", 5),
+	}
+}
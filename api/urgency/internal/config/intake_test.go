@@ -0,0 +1,42 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadIntakeConfig(t *testing.T) {
+	t.Run("it returns default values when environment variables are not set", func(t *testing.T) {
+		cfg := LoadIntakeConfig()
+		assert.Equal(t, 20, cfg.IPRateLimit)
+		assert.Equal(t, time.Hour, cfg.IPRateWindow)
+		assert.Equal(t, 5, cfg.PhoneRateLimit)
+		assert.Equal(t, time.Hour, cfg.PhoneRateWindow)
+		assert.Equal(t, 3, cfg.SuspectPhoneReports)
+		assert.False(t, cfg.PhoneVerificationEnabled)
+		assert.Equal(t, 10*time.Minute, cfg.VerificationCodeTTL)
+		assert.Equal(t, 5, cfg.VerificationMaxAttempts)
+	})
+
+	t.Run("it returns environment variable values when set", func(t *testing.T) {
+		t.Setenv("INTAKE_IP_RATE_LIMIT", "3")
+		t.Setenv("INTAKE_IP_RATE_WINDOW_MINUTES", "5")
+		t.Setenv("INTAKE_PHONE_RATE_LIMIT", "2")
+		t.Setenv("INTAKE_PHONE_RATE_WINDOW_MINUTES", "30")
+		t.Setenv("INTAKE_SUSPECT_PHONE_REPORTS", "1")
+		t.Setenv("INTAKE_PHONE_VERIFICATION_ENABLED", "true")
+		t.Setenv("INTAKE_VERIFICATION_CODE_TTL_MINUTES", "3")
+		t.Setenv("INTAKE_VERIFICATION_MAX_ATTEMPTS", "2")
+		cfg := LoadIntakeConfig()
+		assert.Equal(t, 3, cfg.IPRateLimit)
+		assert.Equal(t, 5*time.Minute, cfg.IPRateWindow)
+		assert.Equal(t, 2, cfg.PhoneRateLimit)
+		assert.Equal(t, 30*time.Minute, cfg.PhoneRateWindow)
+		assert.Equal(t, 1, cfg.SuspectPhoneReports)
+		assert.True(t, cfg.PhoneVerificationEnabled)
+		assert.Equal(t, 3*time.Minute, cfg.VerificationCodeTTL)
+		assert.Equal(t, 2, cfg.VerificationMaxAttempts)
+	})
+}
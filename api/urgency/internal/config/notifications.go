@@ -0,0 +1,62 @@
+package config
+
+import (
+	"strconv"
+	"time"
+)
+
+// NotificationConfig holds settings for the notification dispatcher and its delivery channels
+type NotificationConfig struct {
+	Enabled     bool
+	Interval    time.Duration
+	BatchSize   int
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	ClaimLease  time.Duration
+
+	// SMTP settings; when SMTPHost is empty emails go to the log/file stand-in sender
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+
+	// SinkFile is an optional file where the stand-in sender appends delivered messages
+	SinkFile string
+
+	// TemplatesDir may hold "<locale>.tmpl" files that override the embedded message templates
+	TemplatesDir string
+	// DefaultLocale is used for employees without a preferred language
+	DefaultLocale string
+}
+
+// LoadNotificationConfig loads notification dispatcher configuration from environment variables
+func LoadNotificationConfig() NotificationConfig {
+	return NotificationConfig{
+		Enabled:      getEnvOrDefault("NOTIFICATION_DISPATCH_ENABLED", "true") != "false",
+		Interval:     time.Duration(getEnvIntOrDefault("NOTIFICATION_DISPATCH_INTERVAL_SECONDS", 5)) * time.Second,
+		BatchSize:    getEnvIntOrDefault("NOTIFICATION_DISPATCH_BATCH_SIZE", 50),
+		MaxAttempts:  getEnvIntOrDefault("NOTIFICATION_MAX_ATTEMPTS", 5),
+		BaseBackoff:  time.Duration(getEnvIntOrDefault("NOTIFICATION_BACKOFF_SECONDS", 10)) * time.Second,
+		MaxBackoff:   time.Duration(getEnvIntOrDefault("NOTIFICATION_MAX_BACKOFF_SECONDS", 600)) * time.Second,
+		ClaimLease:   time.Duration(getEnvIntOrDefault("NOTIFICATION_CLAIM_LEASE_SECONDS", 120)) * time.Second,
+		SMTPHost:     getEnvOrDefault("SMTP_HOST", ""),
+		SMTPPort:     getEnvOrDefault("SMTP_PORT", "587"),
+		SMTPUsername: getEnvOrDefault("SMTP_USERNAME", ""),
+		SMTPPassword: getEnvOrDefault("SMTP_PASSWORD", ""),
+		SMTPFrom:     getEnvOrDefault("SMTP_FROM", "no-reply@mountain-service.local"),
+		SinkFile:     getEnvOrDefault("NOTIFICATION_SINK_FILE", ""),
+
+		TemplatesDir:  getEnvOrDefault("NOTIFICATION_TEMPLATES_DIR", ""),
+		DefaultLocale: getEnvOrDefault("NOTIFICATION_DEFAULT_LOCALE", "en"),
+	}
+}
+
+// getEnvIntOrDefault returns a positive integer environment variable or the default value
+func getEnvIntOrDefault(key string, defaultValue int) int {
+	if v, err := strconv.Atoi(getEnvOrDefault(key, "")); err == nil && v > 0 {
+		return v
+	}
+	return defaultValue
+}
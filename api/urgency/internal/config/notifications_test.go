@@ -0,0 +1,55 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadNotificationConfig(t *testing.T) {
+	t.Run("it returns default values when environment variables are not set", func(t *testing.T) {
+		cfg := LoadNotificationConfig()
+		assert.True(t, cfg.Enabled)
+		assert.Equal(t, 5*time.Second, cfg.Interval)
+		assert.Equal(t, 50, cfg.BatchSize)
+		assert.Equal(t, 5, cfg.MaxAttempts)
+		assert.Equal(t, 10*time.Second, cfg.BaseBackoff)
+		assert.Equal(t, 10*time.Minute, cfg.MaxBackoff)
+		assert.Equal(t, 2*time.Minute, cfg.ClaimLease)
+		assert.Empty(t, cfg.SMTPHost)
+		assert.Equal(t, "587", cfg.SMTPPort)
+		assert.Empty(t, cfg.TemplatesDir)
+		assert.Equal(t, "en", cfg.DefaultLocale)
+	})
+
+	t.Run("it returns environment variable values when set", func(t *testing.T) {
+		t.Setenv("NOTIFICATION_DISPATCH_ENABLED", "false")
+		t.Setenv("NOTIFICATION_DISPATCH_INTERVAL_SECONDS", "30")
+		t.Setenv("NOTIFICATION_MAX_ATTEMPTS", "3")
+		t.Setenv("NOTIFICATION_MAX_BACKOFF_SECONDS", "300")
+		t.Setenv("NOTIFICATION_CLAIM_LEASE_SECONDS", "90")
+		t.Setenv("SMTP_HOST", "smtp.example.com")
+		t.Setenv("NOTIFICATION_SINK_FILE", "/tmp/notifications.log")
+		t.Setenv("NOTIFICATION_TEMPLATES_DIR", "/etc/urgency/templates")
+		t.Setenv("NOTIFICATION_DEFAULT_LOCALE", "sr-cyr")
+		cfg := LoadNotificationConfig()
+		assert.False(t, cfg.Enabled)
+		assert.Equal(t, 30*time.Second, cfg.Interval)
+		assert.Equal(t, 3, cfg.MaxAttempts)
+		assert.Equal(t, 5*time.Minute, cfg.MaxBackoff)
+		assert.Equal(t, 90*time.Second, cfg.ClaimLease)
+		assert.Equal(t, "smtp.example.com", cfg.SMTPHost)
+		assert.Equal(t, "/tmp/notifications.log", cfg.SinkFile)
+		assert.Equal(t, "/etc/urgency/templates", cfg.TemplatesDir)
+		assert.Equal(t, "sr-cyr", cfg.DefaultLocale)
+	})
+
+	t.Run("it ignores invalid numeric values", func(t *testing.T) {
+		t.Setenv("NOTIFICATION_DISPATCH_BATCH_SIZE", "abc")
+		t.Setenv("NOTIFICATION_BACKOFF_SECONDS", "-1")
+		cfg := LoadNotificationConfig()
+		assert.Equal(t, 50, cfg.BatchSize)
+		assert.Equal(t, 10*time.Second, cfg.BaseBackoff)
+	})
+}
@@ -0,0 +1,20 @@
+package config
+
+import "time"
+
+// OutboxConfig holds settings for publishing the urgency outbox. Events go to the webhook
+// dispatcher and, when a Google Cloud project is configured, to the Pub/Sub topic.
+type OutboxConfig struct {
+	Topic     string
+	Interval  time.Duration
+	BatchSize int
+}
+
+// LoadOutboxConfig loads outbox publisher configuration from environment variables
+func LoadOutboxConfig() OutboxConfig {
+	return OutboxConfig{
+		Topic:     getEnvOrDefault("PUBSUB_TOPIC", "urgency-events"),
+		Interval:  time.Duration(getEnvIntOrDefault("OUTBOX_PUBLISH_INTERVAL_SECONDS", 10)) * time.Second,
+		BatchSize: getEnvIntOrDefault("OUTBOX_PUBLISH_BATCH_SIZE", 100),
+	}
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadOutboxConfig(t *testing.T) {
+	t.Run("it returns default values when environment variables are not set", func(t *testing.T) {
+		cfg := LoadOutboxConfig()
+		assert.Equal(t, "urgency-events", cfg.Topic)
+		assert.Equal(t, 10*time.Second, cfg.Interval)
+		assert.Equal(t, 100, cfg.BatchSize)
+	})
+
+	t.Run("it returns environment variable values when set", func(t *testing.T) {
+		t.Setenv("PUBSUB_TOPIC", "urgency-events-staging")
+		t.Setenv("OUTBOX_PUBLISH_INTERVAL_SECONDS", "2")
+		t.Setenv("OUTBOX_PUBLISH_BATCH_SIZE", "20")
+		cfg := LoadOutboxConfig()
+		assert.Equal(t, "urgency-events-staging", cfg.Topic)
+		assert.Equal(t, 2*time.Second, cfg.Interval)
+		assert.Equal(t, 20, cfg.BatchSize)
+	})
+}
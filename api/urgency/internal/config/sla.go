@@ -0,0 +1,21 @@
+package config
+
+import "time"
+
+// SLAConfig holds the per-level targets for how quickly an urgency must be assigned to a responder
+type SLAConfig struct {
+	CriticalTarget time.Duration
+	HighTarget     time.Duration
+	MediumTarget   time.Duration
+	LowTarget      time.Duration
+}
+
+// LoadSLAConfig loads the time-to-assign SLA targets from environment variables
+func LoadSLAConfig() SLAConfig {
+	return SLAConfig{
+		CriticalTarget: time.Duration(getEnvIntOrDefault("SLA_CRITICAL_MINUTES", 10)) * time.Minute,
+		HighTarget:     time.Duration(getEnvIntOrDefault("SLA_HIGH_MINUTES", 30)) * time.Minute,
+		MediumTarget:   time.Duration(getEnvIntOrDefault("SLA_MEDIUM_MINUTES", 60)) * time.Minute,
+		LowTarget:      time.Duration(getEnvIntOrDefault("SLA_LOW_MINUTES", 240)) * time.Minute,
+	}
+}
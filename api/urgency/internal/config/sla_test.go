@@ -0,0 +1,26 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadSLAConfig(t *testing.T) {
+	t.Run("it returns default values when environment variables are not set", func(t *testing.T) {
+		cfg := LoadSLAConfig()
+		assert.Equal(t, 10*time.Minute, cfg.CriticalTarget)
+		assert.Equal(t, 30*time.Minute, cfg.HighTarget)
+		assert.Equal(t, 60*time.Minute, cfg.MediumTarget)
+		assert.Equal(t, 240*time.Minute, cfg.LowTarget)
+	})
+
+	t.Run("it returns environment variable values when set", func(t *testing.T) {
+		t.Setenv("SLA_CRITICAL_MINUTES", "5")
+		t.Setenv("SLA_LOW_MINUTES", "120")
+		cfg := LoadSLAConfig()
+		assert.Equal(t, 5*time.Minute, cfg.CriticalTarget)
+		assert.Equal(t, 120*time.Minute, cfg.LowTarget)
+	})
+}
@@ -0,0 +1,18 @@
+package config
+
+import "time"
+
+// TrackingConfig holds settings for the public tracking link handed to reporters. Lookups and
+// follow-ups are limited per client IP so tokens cannot be guessed by brute force.
+type TrackingConfig struct {
+	RateLimit  int
+	RateWindow time.Duration
+}
+
+// LoadTrackingConfig loads tracking link configuration from environment variables
+func LoadTrackingConfig() TrackingConfig {
+	return TrackingConfig{
+		RateLimit:  getEnvIntOrDefault("TRACKING_RATE_LIMIT", 30),
+		RateWindow: time.Duration(getEnvIntOrDefault("TRACKING_RATE_WINDOW_SECONDS", 60)) * time.Second,
+	}
+}
@@ -0,0 +1,24 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadTrackingConfig(t *testing.T) {
+	t.Run("it returns default values when environment variables are not set", func(t *testing.T) {
+		cfg := LoadTrackingConfig()
+		assert.Equal(t, 30, cfg.RateLimit)
+		assert.Equal(t, time.Minute, cfg.RateWindow)
+	})
+
+	t.Run("it returns environment variable values when set", func(t *testing.T) {
+		t.Setenv("TRACKING_RATE_LIMIT", "5")
+		t.Setenv("TRACKING_RATE_WINDOW_SECONDS", "10")
+		cfg := LoadTrackingConfig()
+		assert.Equal(t, 5, cfg.RateLimit)
+		assert.Equal(t, 10*time.Second, cfg.RateWindow)
+	})
+}
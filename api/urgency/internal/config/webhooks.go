@@ -0,0 +1,29 @@
+package config
+
+import "time"
+
+// WebhookConfig holds settings for delivering urgency lifecycle events to webhook subscribers
+type WebhookConfig struct {
+	Enabled     bool
+	Interval    time.Duration
+	BatchSize   int
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	ClaimLease  time.Duration
+	Timeout     time.Duration
+}
+
+// LoadWebhookConfig loads webhook dispatcher configuration from environment variables
+func LoadWebhookConfig() WebhookConfig {
+	return WebhookConfig{
+		Enabled:     getEnvOrDefault("WEBHOOK_DISPATCH_ENABLED", "true") != "false",
+		Interval:    time.Duration(getEnvIntOrDefault("WEBHOOK_DISPATCH_INTERVAL_SECONDS", 5)) * time.Second,
+		BatchSize:   getEnvIntOrDefault("WEBHOOK_DISPATCH_BATCH_SIZE", 50),
+		MaxAttempts: getEnvIntOrDefault("WEBHOOK_MAX_ATTEMPTS", 8),
+		BaseBackoff: time.Duration(getEnvIntOrDefault("WEBHOOK_BACKOFF_SECONDS", 30)) * time.Second,
+		MaxBackoff:  time.Duration(getEnvIntOrDefault("WEBHOOK_MAX_BACKOFF_MINUTES", 60)) * time.Minute,
+		ClaimLease:  time.Duration(getEnvIntOrDefault("WEBHOOK_CLAIM_LEASE_SECONDS", 120)) * time.Second,
+		Timeout:     time.Duration(getEnvIntOrDefault("WEBHOOK_TIMEOUT_SECONDS", 10)) * time.Second,
+	}
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadWebhookConfig(t *testing.T) {
+	t.Run("it returns default values when environment variables are not set", func(t *testing.T) {
+		cfg := LoadWebhookConfig()
+		assert.True(t, cfg.Enabled)
+		assert.Equal(t, 5*time.Second, cfg.Interval)
+		assert.Equal(t, 50, cfg.BatchSize)
+		assert.Equal(t, 8, cfg.MaxAttempts)
+		assert.Equal(t, 30*time.Second, cfg.BaseBackoff)
+		assert.Equal(t, time.Hour, cfg.MaxBackoff)
+		assert.Equal(t, 2*time.Minute, cfg.ClaimLease)
+		assert.Equal(t, 10*time.Second, cfg.Timeout)
+	})
+
+	t.Run("it returns environment variable values when set", func(t *testing.T) {
+		t.Setenv("WEBHOOK_DISPATCH_ENABLED", "false")
+		t.Setenv("WEBHOOK_MAX_ATTEMPTS", "3")
+		t.Setenv("WEBHOOK_TIMEOUT_SECONDS", "2")
+		cfg := LoadWebhookConfig()
+		assert.False(t, cfg.Enabled)
+		assert.Equal(t, 3, cfg.MaxAttempts)
+		assert.Equal(t, 2*time.Second, cfg.Timeout)
+	})
+}
@@ -0,0 +1,117 @@
+package internal
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
+)
+
+// DuplicatePolicy decides when a new public report is likely the same incident as an active urgency.
+// A report matches when it comes from the same contact phone within PhoneWindow, or when it is within
+// RadiusKm of the other urgency inside ProximityWindow and the descriptions share enough words.
+type DuplicatePolicy struct {
+	Enabled         bool
+	PhoneWindow     time.Duration
+	ProximityWindow time.Duration
+	RadiusKm        float64
+	// MinSimilarity is the minimum share of common description words (0-1)
+	MinSimilarity float64
+}
+
+// DefaultDuplicatePolicy returns the thresholds used when no overrides are configured.
+func DefaultDuplicatePolicy() DuplicatePolicy {
+	return DuplicatePolicy{
+		Enabled:         true,
+		PhoneWindow:     30 * time.Minute,
+		ProximityWindow: 2 * time.Hour,
+		RadiusKm:        0.5,
+		MinSimilarity:   0.3,
+	}
+}
+
+func (p DuplicatePolicy) lookback() time.Duration {
+	if p.PhoneWindow > p.ProximityWindow {
+		return p.PhoneWindow
+	}
+	return p.ProximityWindow
+}
+
+// matches reports whether the incoming report duplicates the existing urgency at the given time.
+func (p DuplicatePolicy) matches(incoming, existing *model.Urgency, now time.Time) bool {
+	age := now.Sub(existing.CreatedAt)
+
+	if age <= p.PhoneWindow {
+		if phone := normalizePhone(incoming.ContactPhone); phone != "" && phone == normalizePhone(existing.ContactPhone) {
+			return true
+		}
+	}
+
+	if age > p.ProximityWindow || incoming.Latitude == nil || incoming.Longitude == nil || existing.Latitude == nil || existing.Longitude == nil {
+		return false
+	}
+	if utils.DistanceKm(*incoming.Latitude, *incoming.Longitude, *existing.Latitude, *existing.Longitude) > p.RadiusKm {
+		return false
+	}
+	return descriptionSimilarity(incoming.Description, existing.Description) >= p.MinSimilarity
+}
+
+// normalizePhone keeps only digits so that formatting differences do not hide a repeated caller.
+func normalizePhone(phone string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, phone)
+}
+
+// descriptionSimilarity is the Jaccard index of the words (three letters or longer) in both descriptions.
+func descriptionSimilarity(a, b string) float64 {
+	wordsA, wordsB := descriptionWords(a), descriptionWords(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return 0
+	}
+	common := 0
+	for w := range wordsA {
+		if wordsB[w] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(wordsA)+len(wordsB)-common)
+}
+
+func descriptionWords(s string) map[string]bool {
+	words := make(map[string]bool)
+	for _, w := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		if len([]rune(w)) >= 3 {
+			words[w] = true
+		}
+	}
+	return words
+}
+
+// findDuplicate returns the active urgency the report most likely duplicates, or nil.
+// Lookup failures are logged and treated as no match so that a real emergency is never suppressed.
+func (s *urgencyService) findDuplicate(ctx context.Context, urgency *model.Urgency) *model.Urgency {
+	if !s.duplicates.Enabled {
+		return nil
+	}
+	log := s.log.WithContext(ctx)
+
+	now := time.Now().UTC()
+	candidates, err := s.repo.ListRecentActive(ctx, now.Add(-s.duplicates.lookback()))
+	if err != nil {
+		log.Warnf("Duplicate lookup failed, treating report as new: %v", err)
+		return nil
+	}
+	for i := range candidates {
+		if s.duplicates.matches(urgency, &candidates[i], now) {
+			return &candidates[i]
+		}
+	}
+	return nil
+}
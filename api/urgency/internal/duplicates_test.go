@@ -0,0 +1,80 @@
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/repositories"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestDuplicatePolicy_matches(t *testing.T) {
+	t.Parallel()
+	f := func(v float64) *float64 { return &v }
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy := DefaultDuplicatePolicy()
+
+	t.Run("it matches the same phone within the phone window", func(t *testing.T) {
+		incoming := &model.Urgency{ContactPhone: "+381 64 123-4567"}
+		existing := &model.Urgency{ContactPhone: "+381641234567", Model: gorm.Model{CreatedAt: now.Add(-10 * time.Minute)}}
+		assert.True(t, policy.matches(incoming, existing, now))
+	})
+
+	t.Run("it ignores the same phone outside the phone window", func(t *testing.T) {
+		incoming := &model.Urgency{ContactPhone: "+381641234567"}
+		existing := &model.Urgency{ContactPhone: "+381641234567", Model: gorm.Model{CreatedAt: now.Add(-45 * time.Minute)}}
+		assert.False(t, policy.matches(incoming, existing, now))
+	})
+
+	t.Run("it matches a nearby report with a similar description", func(t *testing.T) {
+		incoming := &model.Urgency{Description: "Injured hiker with broken leg near the summit", Latitude: f(43.4000), Longitude: f(22.6600)}
+		existing := &model.Urgency{Description: "Hiker broken leg, cannot walk from summit", Latitude: f(43.4010), Longitude: f(22.6610), Model: gorm.Model{CreatedAt: now.Add(-time.Hour)}}
+		assert.True(t, policy.matches(incoming, existing, now))
+	})
+
+	t.Run("it does not match a nearby report with a different description", func(t *testing.T) {
+		incoming := &model.Urgency{Description: "Lost child at the parking lot", Latitude: f(43.4000), Longitude: f(22.6600)}
+		existing := &model.Urgency{Description: "Hiker broken leg, cannot walk from summit", Latitude: f(43.4010), Longitude: f(22.6610), Model: gorm.Model{CreatedAt: now.Add(-time.Hour)}}
+		assert.False(t, policy.matches(incoming, existing, now))
+	})
+
+	t.Run("it does not match by proximity when coordinates are missing or too far", func(t *testing.T) {
+		existing := &model.Urgency{Description: "hiker broken leg", Latitude: f(43.40), Longitude: f(22.66), Model: gorm.Model{CreatedAt: now.Add(-time.Hour)}}
+		assert.False(t, policy.matches(&model.Urgency{Description: "hiker broken leg"}, existing, now))
+		assert.False(t, policy.matches(&model.Urgency{Description: "hiker broken leg", Latitude: f(43.45), Longitude: f(22.66)}, existing, now))
+	})
+}
+
+func TestDescriptionSimilarity(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it compares words case-insensitively and ignores short words", func(t *testing.T) {
+		assert.Equal(t, 1.0, descriptionSimilarity("Broken LEG at ski", "broken leg, on SKI"))
+		assert.Equal(t, 0.0, descriptionSimilarity("", "broken leg"))
+		assert.InDelta(t, 1.0/3.0, descriptionSimilarity("broken leg", "broken arm"), 1e-9)
+	})
+}
+
+func TestUrgencyService_findDuplicate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it skips the lookup when detection is disabled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		svc := NewUrgencyServiceWithOptions(utils.NewTestLogger(), repo, nil, nil, nil, UrgencyServiceOptions{}).(*urgencyService)
+		assert.Nil(t, svc.findDuplicate(context.Background(), &model.Urgency{ContactPhone: "123"}))
+	})
+
+	t.Run("it treats lookup errors as no match", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		repo.EXPECT().ListRecentActive(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, nil, nil, nil).(*urgencyService)
+		assert.Nil(t, svc.findDuplicate(context.Background(), &model.Urgency{ContactPhone: "123"}))
+	})
+}
@@ -0,0 +1,234 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/clients"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/repositories"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/templates"
+)
+
+const (
+	// defaultShiftBuffer matches the on-call window used for the initial fan-out in CreateUrgency
+	defaultShiftBuffer = 1 * time.Hour
+	adminProfileType   = "Administrator"
+)
+
+// EscalationPolicy defines how long an open, unassigned urgency may wait before the next escalation step.
+// The same delay applies between consecutive steps.
+type EscalationPolicy struct {
+	Delays map[urgencyV1.UrgencyLevel]time.Duration
+	// WidenedShiftBuffer is the on-call lookahead used when widening to the next shift
+	WidenedShiftBuffer time.Duration
+}
+
+// DefaultEscalationPolicy returns the escalation delays used when no overrides are configured.
+func DefaultEscalationPolicy() EscalationPolicy {
+	return EscalationPolicy{
+		Delays: map[urgencyV1.UrgencyLevel]time.Duration{
+			urgencyV1.Critical: 5 * time.Minute,
+			urgencyV1.High:     15 * time.Minute,
+			urgencyV1.Medium:   30 * time.Minute,
+			urgencyV1.Low:      60 * time.Minute,
+		},
+		WidenedShiftBuffer: 8 * time.Hour,
+	}
+}
+
+func (p EscalationPolicy) delayFor(level urgencyV1.UrgencyLevel) (time.Duration, bool) {
+	d, ok := p.Delays[level]
+	return d, ok && d > 0
+}
+
+// Escalator periodically escalates urgencies that nobody has accepted in time.
+type Escalator struct {
+	log            utils.Logger
+	repo           repositories.UrgencyRepository
+	employeeClient clients.EmployeeClient
+	notifier       *urgencyService
+	policy         EscalationPolicy
+	now            func() time.Time
+}
+
+func NewEscalator(
+	log utils.Logger,
+	repo repositories.UrgencyRepository,
+	notificationRepo repositories.NotificationRepository,
+	employeeClient clients.EmployeeClient,
+	policy EscalationPolicy,
+) *Escalator {
+	if policy.WidenedShiftBuffer <= defaultShiftBuffer {
+		policy.WidenedShiftBuffer = DefaultEscalationPolicy().WidenedShiftBuffer
+	}
+	return &Escalator{
+		log:            log.WithName("escalator"),
+		repo:           repo,
+		employeeClient: employeeClient,
+		notifier:       NewUrgencyService(log, repo, notificationRepo, employeeClient, nil).(*urgencyService),
+		policy:         policy,
+		now:            time.Now,
+	}
+}
+
+// WithTemplates makes escalation alerts use the given registry, e.g. one with overrides loaded
+func (e *Escalator) WithTemplates(registry *templates.Registry) *Escalator {
+	if registry != nil {
+		e.notifier.templates = registry
+	}
+	return e
+}
+
+// Start runs escalation checks in the background every interval until ctx is cancelled.
+func (e *Escalator) Start(ctx context.Context, interval time.Duration) {
+	ctx, _ = utils.EnsureRequestID(ctx)
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	e.log.Infof("Starting urgency escalator: interval=%s", interval)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				e.log.WithContext(ctx).Info("Stopping urgency escalator")
+				return
+			case <-ticker.C:
+				if _, err := e.RunOnce(ctx); err != nil {
+					e.log.WithContext(ctx).Errorf("escalation cycle error: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// RunOnce fires the next escalation step for every overdue urgency and returns how many steps fired.
+func (e *Escalator) RunOnce(ctx context.Context) (int, error) {
+	log := e.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "Escalator.RunOnce")()
+
+	candidates, err := e.repo.ListEscalationCandidates(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("list escalation candidates: %w", err)
+	}
+
+	now := e.now().UTC()
+	fired := 0
+	for i := range candidates {
+		urg := &candidates[i]
+		if !e.isDue(urg, now) {
+			continue
+		}
+		ok, err := e.escalate(ctx, urg, urg.EscalationLevel+1, now)
+		if err != nil {
+			log.Errorf("Failed to escalate urgency %d: %v", urg.ID, err)
+			continue
+		}
+		if ok {
+			fired++
+		}
+	}
+	return fired, nil
+}
+
+func (e *Escalator) isDue(urg *model.Urgency, now time.Time) bool {
+	delay, ok := e.policy.delayFor(urg.Level)
+	if !ok {
+		return false
+	}
+	since := urg.CreatedAt
+	if urg.LastEscalatedAt != nil {
+		since = *urg.LastEscalatedAt
+	}
+	return !now.Before(since.Add(delay))
+}
+
+func (e *Escalator) escalate(ctx context.Context, urg *model.Urgency, step model.EscalationStep, now time.Time) (bool, error) {
+	log := e.log.WithContext(ctx)
+
+	// recipients are resolved before the step is claimed, so a failed lookup leaves the step to be
+	// retried on the next tick instead of burning it without alerting anyone
+	recipients, err := e.recipientsFor(ctx, urg, step)
+	if err != nil {
+		return false, fmt.Errorf("resolve recipients for step %s: %w", step, err)
+	}
+
+	record := &model.UrgencyEscalation{
+		UrgencyID:     urg.ID,
+		Step:          step,
+		Action:        step.String(),
+		NotifiedCount: len(recipients),
+		CreatedAt:     now,
+	}
+	claimed, err := e.repo.ClaimEscalationStep(ctx, record)
+	if err != nil {
+		return false, fmt.Errorf("claim step %d: %w", step, err)
+	}
+	if !claimed {
+		// accepted meanwhile or another instance already fired this step
+		return false, nil
+	}
+	for _, employee := range recipients {
+		if err := e.notifier.createAssignmentAndNotification(ctx, urg, employee, templates.EventEscalation); err != nil {
+			log.Errorf("Failed to notify employee %d for urgency %d: %v", employee.ID, urg.ID, err)
+		}
+	}
+
+	log.Infof("Escalated urgency %d (level=%s) to step %s, notified %d employees", urg.ID, urg.Level, step, len(recipients))
+	return true, nil
+}
+
+// recipientsFor resolves who should be alerted for a step. On-call employees that already declined are
+// skipped, and widening only targets employees that were not notified before.
+func (e *Escalator) recipientsFor(ctx context.Context, urg *model.Urgency, step model.EscalationStep) ([]employeeV1.EmployeeResponse, error) {
+	existing, err := e.notifier.notificationRepo.GetByUrgencyID(ctx, urg.ID)
+	if err != nil {
+		return nil, err
+	}
+	notified := make(map[uint]bool, len(existing))
+	declined := make(map[uint]bool)
+	for _, n := range existing {
+		notified[n.EmployeeID] = true
+		if n.Reply == model.NotificationDeclined {
+			declined[n.EmployeeID] = true
+		}
+	}
+
+	var candidates []employeeV1.EmployeeResponse
+	switch step {
+	case model.EscalationRenotify:
+		candidates, err = e.employeeClient.GetOnCallEmployees(ctx, defaultShiftBuffer)
+	case model.EscalationWiden:
+		candidates, err = e.employeeClient.GetOnCallEmployees(ctx, e.policy.WidenedShiftBuffer)
+	case model.EscalationAdmins:
+		var all []employeeV1.EmployeeResponse
+		all, err = e.employeeClient.GetAllEmployees(ctx)
+		for _, emp := range all {
+			if emp.ProfileType == adminProfileType {
+				candidates = append(candidates, emp)
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]employeeV1.EmployeeResponse, 0, len(candidates))
+	for _, emp := range candidates {
+		if declined[emp.ID] && step != model.EscalationAdmins {
+			continue
+		}
+		if step == model.EscalationWiden && notified[emp.ID] {
+			continue
+		}
+		out = append(out, emp)
+	}
+	return out, nil
+}
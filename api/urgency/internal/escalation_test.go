@@ -0,0 +1,170 @@
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/clients"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/repositories"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type escalatorMocks struct {
+	repo  *repositories.MockUrgencyRepository
+	nrepo *repositories.MockNotificationRepository
+	ecli  *clients.MockEmployeeClient
+}
+
+func newTestEscalator(t *testing.T, now time.Time) (*Escalator, escalatorMocks) {
+	ctrl := gomock.NewController(t)
+	m := escalatorMocks{
+		repo:  repositories.NewMockUrgencyRepository(ctrl),
+		nrepo: repositories.NewMockNotificationRepository(ctrl),
+		ecli:  clients.NewMockEmployeeClient(ctrl),
+	}
+	e := NewEscalator(utils.NewTestLogger(), m.repo, m.nrepo, m.ecli, DefaultEscalationPolicy())
+	e.now = func() time.Time { return now }
+	return e, m
+}
+
+func TestEscalator_RunOnce(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("it does nothing for urgencies that are not overdue yet", func(t *testing.T) {
+		e, m := newTestEscalator(t, now)
+		m.repo.EXPECT().ListEscalationCandidates(ctx).Return([]model.Urgency{
+			{Model: gorm.Model{ID: 1, CreatedAt: now.Add(-4 * time.Minute)}, ID: 1, Level: urgencyV1.Critical},
+			{Model: gorm.Model{ID: 2, CreatedAt: now.Add(-14 * time.Minute)}, ID: 2, Level: urgencyV1.High},
+		}, nil)
+
+		fired, err := e.RunOnce(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, fired)
+	})
+
+	t.Run("it re-notifies current on-call employees except those who declined", func(t *testing.T) {
+		e, m := newTestEscalator(t, now)
+		m.repo.EXPECT().ListEscalationCandidates(ctx).Return([]model.Urgency{
+			{Model: gorm.Model{ID: 1, CreatedAt: now.Add(-6 * time.Minute)}, ID: 1, Level: urgencyV1.Critical},
+		}, nil)
+		m.repo.EXPECT().ClaimEscalationStep(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, rec *model.UrgencyEscalation) (bool, error) {
+			assert.Equal(t, uint(1), rec.UrgencyID)
+			assert.Equal(t, model.EscalationRenotify, rec.Step)
+			assert.Equal(t, "renotify_on_call", rec.Action)
+			assert.Equal(t, 1, rec.NotifiedCount)
+			assert.Equal(t, now, rec.CreatedAt)
+			return true, nil
+		})
+		m.nrepo.EXPECT().GetByUrgencyID(ctx, uint(1)).Return([]model.Notification{
+			{EmployeeID: 10},
+			{EmployeeID: 11, Reply: model.NotificationDeclined},
+		}, nil)
+		m.ecli.EXPECT().GetOnCallEmployees(ctx, time.Hour).Return([]employeeV1.EmployeeResponse{
+			{ID: 10, Phone: "+381641111111"},
+			{ID: 11, Phone: "+381642222222"},
+		}, nil)
+		m.nrepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, n *model.Notification) error {
+			assert.Equal(t, uint(10), n.EmployeeID)
+			return nil
+		})
+
+		fired, err := e.RunOnce(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, fired)
+	})
+
+	t.Run("it widens to the next shift and only notifies new employees", func(t *testing.T) {
+		e, m := newTestEscalator(t, now)
+		last := now.Add(-16 * time.Minute)
+		m.repo.EXPECT().ListEscalationCandidates(ctx).Return([]model.Urgency{
+			{Model: gorm.Model{ID: 2, CreatedAt: now.Add(-time.Hour)}, ID: 2, Level: urgencyV1.High, EscalationLevel: model.EscalationRenotify, LastEscalatedAt: &last},
+		}, nil)
+		m.repo.EXPECT().ClaimEscalationStep(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, rec *model.UrgencyEscalation) (bool, error) {
+			assert.Equal(t, model.EscalationWiden, rec.Step)
+			assert.Equal(t, 1, rec.NotifiedCount)
+			return true, nil
+		})
+		m.nrepo.EXPECT().GetByUrgencyID(ctx, uint(2)).Return([]model.Notification{{EmployeeID: 10}}, nil)
+		m.ecli.EXPECT().GetOnCallEmployees(ctx, 8*time.Hour).Return([]employeeV1.EmployeeResponse{
+			{ID: 10, Email: "a@example.com"},
+			{ID: 20, Email: "b@example.com"},
+		}, nil)
+		m.nrepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, n *model.Notification) error {
+			assert.Equal(t, uint(20), n.EmployeeID)
+			return nil
+		})
+
+		fired, err := e.RunOnce(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, fired)
+	})
+
+	t.Run("it alerts administrators at the last step", func(t *testing.T) {
+		e, m := newTestEscalator(t, now)
+		last := now.Add(-31 * time.Minute)
+		m.repo.EXPECT().ListEscalationCandidates(ctx).Return([]model.Urgency{
+			{Model: gorm.Model{ID: 3}, ID: 3, Level: urgencyV1.Medium, EscalationLevel: model.EscalationWiden, LastEscalatedAt: &last},
+		}, nil)
+		m.repo.EXPECT().ClaimEscalationStep(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, rec *model.UrgencyEscalation) (bool, error) {
+			assert.Equal(t, model.EscalationAdmins, rec.Step)
+			return true, nil
+		})
+		m.nrepo.EXPECT().GetByUrgencyID(ctx, uint(3)).Return(nil, nil)
+		m.ecli.EXPECT().GetAllEmployees(ctx).Return([]employeeV1.EmployeeResponse{
+			{ID: 1, ProfileType: "Administrator", Phone: "+381643333333"},
+			{ID: 2, ProfileType: "Medic", Phone: "+381644444444"},
+		}, nil)
+		m.nrepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, n *model.Notification) error {
+			assert.Equal(t, uint(1), n.EmployeeID)
+			return nil
+		})
+
+		fired, err := e.RunOnce(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, fired)
+	})
+
+	t.Run("it skips the step when another instance already claimed it", func(t *testing.T) {
+		e, m := newTestEscalator(t, now)
+		m.repo.EXPECT().ListEscalationCandidates(ctx).Return([]model.Urgency{
+			{Model: gorm.Model{ID: 4, CreatedAt: now.Add(-time.Hour)}, ID: 4, Level: urgencyV1.Low},
+		}, nil)
+		m.nrepo.EXPECT().GetByUrgencyID(ctx, uint(4)).Return(nil, nil)
+		m.ecli.EXPECT().GetOnCallEmployees(ctx, time.Hour).Return([]employeeV1.EmployeeResponse{{ID: 10, Phone: "+381641111111"}}, nil)
+		m.repo.EXPECT().ClaimEscalationStep(ctx, gomock.Any()).Return(false, nil)
+
+		fired, err := e.RunOnce(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, fired)
+	})
+
+	t.Run("it leaves the step unclaimed when recipients cannot be resolved", func(t *testing.T) {
+		e, m := newTestEscalator(t, now)
+		m.repo.EXPECT().ListEscalationCandidates(ctx).Return([]model.Urgency{
+			{Model: gorm.Model{ID: 5, CreatedAt: now.Add(-time.Hour)}, ID: 5, Level: urgencyV1.Low},
+		}, nil)
+		m.nrepo.EXPECT().GetByUrgencyID(ctx, uint(5)).Return(nil, nil)
+		m.ecli.EXPECT().GetOnCallEmployees(ctx, time.Hour).Return(nil, assert.AnError)
+
+		fired, err := e.RunOnce(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, fired)
+	})
+
+	t.Run("it returns error when candidates cannot be loaded", func(t *testing.T) {
+		e, m := newTestEscalator(t, now)
+		m.repo.EXPECT().ListEscalationCandidates(ctx).Return(nil, assert.AnError)
+
+		_, err := e.RunOnce(ctx)
+		assert.Error(t, err)
+	})
+}
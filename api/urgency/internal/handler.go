@@ -2,8 +2,12 @@ package internal
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"mime"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
@@ -18,34 +22,84 @@ type UrgencyHandler interface {
 	CreateUrgency(ctx *gin.Context)
 	ListUrgencies(ctx *gin.Context)
 	UnassignedUrgencyIDs(ctx *gin.Context)
+	ListUrgenciesInArea(ctx *gin.Context)
+	GetStats(ctx *gin.Context)
+	PreviewNotificationTemplate(ctx *gin.Context)
+	SuggestResponders(ctx *gin.Context)
 	GetUrgency(ctx *gin.Context)
 	UpdateUrgency(ctx *gin.Context)
 	DeleteUrgency(ctx *gin.Context)
 	ResetAllData(ctx *gin.Context)
+	MergeUrgency(ctx *gin.Context)
 
 	AssignUrgency(ctx *gin.Context)
 	UnassignUrgency(ctx *gin.Context)
 	CloseUrgency(ctx *gin.Context)
+	ResolveUrgency(ctx *gin.Context)
+	ReopenUrgency(ctx *gin.Context)
+	CancelUrgency(ctx *gin.Context)
+
+	AcceptUrgency(ctx *gin.Context)
+	DeclineUrgency(ctx *gin.Context)
+	ListReplies(ctx *gin.Context)
+	ListUrgencyNotifications(ctx *gin.Context)
+	ListEmployeeNotifications(ctx *gin.Context)
+	RetryNotification(ctx *gin.Context)
+	ListEscalations(ctx *gin.Context)
+	GetTimeline(ctx *gin.Context)
+
+	GetTeam(ctx *gin.Context)
+	AddTeamMember(ctx *gin.Context)
+	RemoveTeamMember(ctx *gin.Context)
+
+	GetEmployeeActiveUrgencies(ctx *gin.Context)
+
+	GetTrackingStatus(ctx *gin.Context)
+	AddFollowUp(ctx *gin.Context)
+	VerifyPhone(ctx *gin.Context)
+	ResendVerificationCode(ctx *gin.Context)
+
+	ListIntakeReview(ctx *gin.Context)
+	ReleaseIntake(ctx *gin.Context)
+	RejectIntake(ctx *gin.Context)
+
+	UploadAttachment(ctx *gin.Context)
+	UploadReporterAttachment(ctx *gin.Context)
+	ListAttachments(ctx *gin.Context)
+	DownloadAttachment(ctx *gin.Context)
+	DeleteAttachment(ctx *gin.Context)
 }
 
+// attachmentFormOverhead leaves room for the multipart boundaries, part headers and form fields
+// sent along with an attachment
+const attachmentFormOverhead = 1 << 20
+
 type urgencyHandler struct {
-	log utils.Logger
-	svc UrgencyService
+	log           utils.Logger
+	svc           UrgencyService
+	maxUploadSize int64
 }
 
 func NewUrgencyHandler(log utils.Logger, svc UrgencyService) UrgencyHandler {
-	return &urgencyHandler{log: log.WithName("urgencyHandler"), svc: svc}
+	return NewUrgencyHandlerWithUploadLimit(log, svc, DefaultAttachmentPolicy().MaxSize)
+}
+
+// NewUrgencyHandlerWithUploadLimit rejects attachment requests whose body is larger than the given file
+// size before the form is parsed
+func NewUrgencyHandlerWithUploadLimit(log utils.Logger, svc UrgencyService, maxUploadSize int64) UrgencyHandler {
+	return &urgencyHandler{log: log.WithName("urgencyHandler"), svc: svc, maxUploadSize: maxUploadSize}
 }
 
 // CreateUrgency Креирање нове ургентне ситуације
 // @Summary Креирање нове ургентне ситуације
-// @Description Креирање нове ургентне ситуације са свим потребним подацима
+// @Description Креирање нове ургентне ситуације са свим потребним подацима; одговор садржи токен за праћење који се враћа само овај пут
 // @Tags urgency
 // @Security OAuth2Password
 // @Accept  json
 // @Produce  json
 // @Param urgency body urgencyV1.UrgencyCreateRequest true "Urgency data"
 // @Success 201 {object} urgencyV1.UrgencyResponse
+// @Failure 429 {object} map[string]interface{}
 // @Router /urgencies [post]
 func (h *urgencyHandler) CreateUrgency(ctx *gin.Context) {
 	log := h.log.WithContext(requestContext(ctx))
@@ -78,6 +132,10 @@ func (h *urgencyHandler) CreateUrgency(ctx *gin.Context) {
 
 	if err := h.svc.CreateUrgency(requestContext(ctx), &urgency); err != nil {
 		log.Errorf("failed to create urgency: %v", err)
+		if aerr, ok := err.(*commonv1.AppError); ok && aerr.Code == "URGENCY_ERRORS.RATE_LIMITED" {
+			writeAppError(ctx, err)
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "URGENCY_ERRORS.CREATE_FAILED", "details": err.Error()})
 		return
 	}
@@ -92,14 +150,23 @@ func (h *urgencyHandler) CreateUrgency(ctx *gin.Context) {
 
 // ListUrgencies Извлачење листе ургентних ситуација
 // @Summary Извлачење листе ургентних ситуација
-// @Description Извлачење свих ургентних ситуација са пагинацијом
+// @Description Извлачење ургентних ситуација са пагинацијом, филтерима, претрагом и сортирањем
 // @Tags urgency
 // @Security OAuth2Password
 // @Produce  json
 // @Param page query int false "Page number (min 1)" default(1)
 // @Param pageSize query int false "Page size (1-1000)" default(20)
-// @Param myUrgencies query bool false "Only urgencies assigned to the current user" default(false)
+// @Param myUrgencies query bool false "Only urgencies the current user leads or is a team member of" default(false)
+// @Param status query string false "Comma separated statuses (open, in_progress, resolved, closed, cancelled)"
+// @Param level query string false "Comma separated levels (low, medium, high, critical)"
+// @Param from query string false "Created at or after (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Created before (RFC3339, or YYYY-MM-DD to include the whole day)"
+// @Param assigneeId query int false "Only urgencies assigned to this employee"
+// @Param unassigned query bool false "Only unassigned urgencies" default(false)
+// @Param q query string false "Search in name, location and description"
+// @Param sort query string false "Sort key (priority, newest, oldest, level)"
 // @Success 200 {object} urgencyV1.UrgencyListResponse
+// @Failure 400 {object} map[string]interface{}
 // @Router /urgencies [get]
 func (h *urgencyHandler) ListUrgencies(ctx *gin.Context) {
 	base := requestContext(ctx)
@@ -123,16 +190,45 @@ func (h *urgencyHandler) ListUrgencies(ctx *gin.Context) {
 		}
 	}
 
-	var assignedEmployeeID *uint
+	var query urgencyV1.UrgencyListQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		log.Errorf("failed to bind query: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := query.Validate(); err != nil {
+		log.Errorf("validation failed: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	from, to := query.TimeRange()
+	filter := model.UrgencyFilter{
+		Page:               page,
+		PageSize:           pageSize,
+		Statuses:           query.Statuses(),
+		Levels:             query.Levels(),
+		From:               from,
+		To:                 to,
+		AssignedEmployeeID: query.AssigneeID,
+		UnassignedOnly:     query.Unassigned,
+		Query:              strings.TrimSpace(query.Q),
+		Sort:               query.Sort,
+	}
+	// reports held back by the intake checks are only for admins to review
+	if roleVal, _ := ctx.Get("role"); roleVal != "Administrator" {
+		filter.AcceptedIntakeOnly = true
+	}
 	if ctx.Query("myUrgencies") == "true" {
 		if v, exists := ctx.Get("employeeID"); exists {
 			if id, ok := v.(uint); ok {
-				assignedEmployeeID = &id
+				filter.AssignedEmployeeID = nil
+				filter.TeamMemberID = &id
 			}
 		}
 	}
 
-	urgencies, total, err := h.svc.ListUrgencies(cctx, page, pageSize, assignedEmployeeID)
+	urgencies, total, err := h.svc.ListUrgencies(cctx, filter)
 	if err != nil {
 		log.Errorf("failed to retrieve urgencies: %v", err)
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "URGENCY_ERRORS.LIST_FAILED", "details": err.Error()})
@@ -178,6 +274,127 @@ func (h *urgencyHandler) UnassignedUrgencyIDs(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"ids": ids})
 }
 
+// ListUrgenciesInArea Извлачење ургентних ситуација у области
+// @Summary Извлачење ургентних ситуација у области
+// @Description Ургентне ситуације унутар радијуса (сортиране по удаљености) или унутар правоугаоне области. Враћа се највише 500 најновијих, а truncated означава да их у области има више
+// @Tags urgency
+// @Security OAuth2Password
+// @Produce  json
+// @Param lat query number false "Center latitude"
+// @Param lng query number false "Center longitude"
+// @Param radiusKm query number false "Radius in kilometers"
+// @Param minLat query number false "Bounding box south edge"
+// @Param minLng query number false "Bounding box west edge"
+// @Param maxLat query number false "Bounding box north edge"
+// @Param maxLng query number false "Bounding box east edge"
+// @Success 200 {object} urgencyV1.UrgencyList
+// @Failure 400 {object} map[string]interface{}
+// @Router /urgencies/geo [get]
+func (h *urgencyHandler) ListUrgenciesInArea(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "UrgencyHandler.ListUrgenciesInArea")()
+	log.Info("Received List Urgencies In Area request")
+
+	var query urgencyV1.UrgencyGeoQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		log.Errorf("failed to bind geo query: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := query.Validate(); err != nil {
+		log.Errorf("geo query validation failed: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	roleVal, _ := ctx.Get("role")
+	isAdmin := roleVal == "Administrator"
+
+	resp, err := h.svc.ListUrgenciesInArea(requestContext(ctx), query, isAdmin)
+	if err != nil {
+		log.Errorf("failed to list urgencies in area: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+
+	log.Infof("Successfully retrieved %d urgencies in area (truncated=%t)", len(resp.Urgencies), resp.Truncated)
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetStats Статистика времена одзива ургентних ситуација
+// @Summary Статистика времена одзива ургентних ситуација
+// @Description Број ургентних ситуација по статусу и нивоу, медијана и p90 времена до доделе и затварања, број обрађених по запосленом и прекорачења SLA циљева
+// @Tags urgency
+// @Security OAuth2Password
+// @Produce  json
+// @Param from query string false "Created at or after (RFC3339 or YYYY-MM-DD), defaults to 30 days before to"
+// @Param to query string false "Created before (RFC3339, or YYYY-MM-DD to include the whole day), defaults to now"
+// @Success 200 {object} urgencyV1.UrgencyStatsResponse
+// @Failure 400 {object} map[string]interface{}
+// @Router /urgencies/stats [get]
+func (h *urgencyHandler) GetStats(ctx *gin.Context) {
+	base := requestContext(ctx)
+	cctx, cancel := context.WithTimeout(base, config.DefaultListTimeout)
+	defer cancel()
+	log := h.log.WithContext(cctx)
+	defer utils.TimeOperation(log, "UrgencyHandler.GetStats")()
+	log.Info("Received Get Urgency Stats request")
+
+	var query urgencyV1.UrgencyStatsQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		log.Errorf("failed to bind stats query: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := query.Validate(); err != nil {
+		log.Errorf("stats query validation failed: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	stats, err := h.svc.GetStats(cctx, query)
+	if err != nil {
+		log.Errorf("failed to compute urgency stats: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, stats)
+}
+
+// SuggestResponders Предлог дежурних спасилаца по удаљености
+// @Summary Предлог дежурних спасилаца по удаљености
+// @Description Дежурни запослени рангирани по удаљености од ургентне ситуације; запослени без познате позиције су на крају
+// @Tags urgency
+// @Security OAuth2Password
+// @Produce  json
+// @Param id path int true "Urgency ID"
+// @Success 200 {object} urgencyV1.UrgencyRespondersResponse
+// @Failure 404 {object} map[string]interface{}
+// @Router /urgencies/{id}/responders [get]
+func (h *urgencyHandler) SuggestResponders(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "UrgencyHandler.SuggestResponders")()
+	log.Info("Received Suggest Responders request")
+
+	idParam := ctx.Param("id")
+	urgencyID64, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil || urgencyID64 == 0 {
+		log.Errorf("invalid urgency ID: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid urgency ID"})
+		return
+	}
+
+	responders, err := h.svc.SuggestResponders(requestContext(ctx), uint(urgencyID64))
+	if err != nil {
+		log.Errorf("suggest responders failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, urgencyV1.UrgencyRespondersResponse{Responders: responders})
+}
+
 // GetUrgency Извлачење ургентне ситуације по ID
 // @Summary Извлачење ургентне ситуације по ID
 // @Description Извлачење ургентне ситуације по њеном ID
@@ -260,17 +477,13 @@ func (h *urgencyHandler) UpdateUrgency(ctx *gin.Context) {
 		return
 	}
 
-	urgency, err := h.svc.GetUrgencyByID(requestContext(ctx), uint(urgencyID))
+	urgency, err := h.svc.UpdateUrgency(requestContext(ctx), uint(urgencyID), &req)
 	if err != nil {
-		log.Errorf("failed to get urgency with ID %d: %v", urgencyID, err)
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "urgency not found"})
-		return
-	}
-
-	urgency.UpdateWithRequest(&req)
-
-	if err := h.svc.UpdateUrgency(requestContext(ctx), urgency); err != nil {
 		log.Errorf("failed to update urgency: %v", err)
+		if _, ok := err.(*commonv1.AppError); ok {
+			writeAppError(ctx, err)
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "URGENCY_ERRORS.UPDATE_FAILED", "details": err.Error()})
 		return
 	}
@@ -317,6 +530,91 @@ func (h *urgencyHandler) DeleteUrgency(ctx *gin.Context) {
 	ctx.JSON(http.StatusNoContent, nil)
 }
 
+// MergeUrgency Спајање дуплиране ургентне ситуације
+// @Summary Спајање дуплиране ургентне ситуације
+// @Description Дупликат се отказује и повезује са ургентном ситуацијом из путање, а његова обавештења и активности се премештају на њу (само за администраторе)
+// @Tags urgency
+// @Security OAuth2Password
+// @Accept  json
+// @Produce  json
+// @Param id path int true "ID of the surviving urgency"
+// @Param request body urgencyV1.UrgencyMergeRequest true "Duplicate urgency"
+// @Success 200 {object} urgencyV1.UrgencyMergeResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /admin/urgencies/{id}/merge [post]
+func (h *urgencyHandler) MergeUrgency(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "UrgencyHandler.MergeUrgency")()
+	log.Info("Received Merge Urgency request")
+
+	idParam := ctx.Param("id")
+	targetID64, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil || targetID64 == 0 {
+		log.Errorf("invalid urgency ID: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid urgency ID"})
+		return
+	}
+
+	var req urgencyV1.UrgencyMergeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorf("failed to bind JSON: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.svc.MergeUrgency(requestContext(ctx), uint(targetID64), req.SourceID)
+	if err != nil {
+		log.Errorf("merge urgency failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+
+	utils.WriteFreshWindow(ctx, config.DefaultFreshWindow)
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// PreviewNotificationTemplate Преглед шаблона обавештења
+// @Summary Преглед шаблона обавештења
+// @Description Приказ SMS или email обавештења за дати догађај и језик, попуњеног примерним подацима (само за администраторе)
+// @Tags urgency
+// @Security OAuth2Password
+// @Produce  json
+// @Param type query string true "Notification type (sms, email)"
+// @Param event query string true "Event (new_urgency, escalation, reassigned, closed)"
+// @Param locale query string false "Locale (sr-cyr, sr-lat, en, ru), defaults to the service default"
+// @Success 200 {object} urgencyV1.NotificationTemplatePreviewResponse
+// @Failure 400 {object} map[string]interface{}
+// @Router /admin/notification-templates/preview [get]
+func (h *urgencyHandler) PreviewNotificationTemplate(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "UrgencyHandler.PreviewNotificationTemplate")()
+	log.Info("Received Preview Notification Template request")
+
+	var query urgencyV1.NotificationTemplatePreviewQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		log.Errorf("failed to bind template preview query: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := query.Validate(); err != nil {
+		log.Errorf("template preview query validation failed: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	preview, err := h.svc.PreviewNotificationTemplate(requestContext(ctx), query)
+	if err != nil {
+		log.Errorf("failed to preview notification template: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, preview)
+}
+
 // ResetAllData Ресетовање свих података
 // @Summary Ресетовање свих података
 // @Description Брисање свих ургентних ситуација (само за администраторе)
@@ -467,9 +765,1085 @@ func (h *urgencyHandler) CloseUrgency(ctx *gin.Context) {
 	log.Info("Successfully closed urgency")
 }
 
-func requestContext(ctx *gin.Context) context.Context {
-	if ctx != nil && ctx.Request != nil {
-		return ctx.Request.Context()
+// ResolveUrgency Решавање ургентне ситуације
+// @Summary Решавање ургентне ситуације
+// @Description Задужени запослени или администратор означава ургентну ситуацију као решену уз белешку о решењу
+// @Tags urgency
+// @Security OAuth2Password
+// @Accept  json
+// @Param id path int true "Urgency ID"
+// @Param request body urgencyV1.UrgencyStatusChangeRequest true "Белешка о решењу"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /urgencies/{id}/resolve [put]
+func (h *urgencyHandler) ResolveUrgency(ctx *gin.Context) {
+	h.changeStatus(ctx, "ResolveUrgency", h.svc.ResolveUrgency)
+}
+
+// ReopenUrgency Поновно отварање ургентне ситуације
+// @Summary Поновно отварање ургентне ситуације
+// @Description Решена, затворена или отказана ургентна ситуација се поново отвара уз навођење разлога
+// @Tags urgency
+// @Security OAuth2Password
+// @Accept  json
+// @Param id path int true "Urgency ID"
+// @Param request body urgencyV1.UrgencyStatusChangeRequest true "Разлог поновног отварања"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /urgencies/{id}/reopen [put]
+func (h *urgencyHandler) ReopenUrgency(ctx *gin.Context) {
+	h.changeStatus(ctx, "ReopenUrgency", h.svc.ReopenUrgency)
+}
+
+// CancelUrgency Отказивање ургентне ситуације
+// @Summary Отказивање ургентне ситуације
+// @Description Отворена ургентна ситуација или она у току се отказује уз навођење разлога (нпр. лажна узбуна)
+// @Tags urgency
+// @Security OAuth2Password
+// @Accept  json
+// @Param id path int true "Urgency ID"
+// @Param request body urgencyV1.UrgencyStatusChangeRequest true "Разлог отказивања"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /urgencies/{id}/cancel [put]
+func (h *urgencyHandler) CancelUrgency(ctx *gin.Context) {
+	h.changeStatus(ctx, "CancelUrgency", h.svc.CancelUrgency)
+}
+
+type statusChangeFunc func(ctx context.Context, urgencyID uint, actorID uint, isAdmin bool, note string) error
+
+// changeStatus handles the shared request parsing for the resolve, reopen and cancel endpoints.
+func (h *urgencyHandler) changeStatus(ctx *gin.Context, op string, apply statusChangeFunc) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "UrgencyHandler."+op)()
+	log.Infof("Received %s request", op)
+
+	idParam := ctx.Param("id")
+	urgencyID64, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil || urgencyID64 == 0 {
+		log.Errorf("invalid urgency ID: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid urgency ID"})
+		return
+	}
+	actorIDVal, _ := ctx.Get("employeeID")
+	roleVal, _ := ctx.Get("role")
+	actorID, _ := actorIDVal.(uint)
+	isAdmin := roleVal == "Administrator"
+	if actorID == 0 {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "AUTH_ERRORS.UNAUTHORIZED"})
+		return
+	}
+
+	var req urgencyV1.UrgencyStatusChangeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorf("failed to bind json: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "VALIDATION.INVALID_REQUEST", "details": err.Error()})
+		return
+	}
+	if err := req.Validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "VALIDATION.INVALID_REQUEST", "details": err.Error()})
+		return
+	}
+
+	if err := apply(requestContext(ctx), uint(urgencyID64), actorID, isAdmin, req.Note); err != nil {
+		log.Errorf("%s failed: %v", op, err)
+		writeAppError(ctx, err)
+		return
+	}
+
+	utils.WriteFreshWindow(ctx, config.DefaultFreshWindow)
+
+	ctx.JSON(http.StatusNoContent, nil)
+
+	log.Infof("Successfully handled %s for urgency %d", op, urgencyID64)
+}
+
+// AcceptUrgency Прихватање ургентне ситуације од стране обавештеног запосленог
+// @Summary Прихватање ургентне ситуације
+// @Description Обавештени дежурни запослени прихвата ургентну ситуацију; први који прихвати постаје задужен
+// @Tags urgency
+// @Security OAuth2Password
+// @Param id path int true "Urgency ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /urgencies/{id}/accept [post]
+func (h *urgencyHandler) AcceptUrgency(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "UrgencyHandler.AcceptUrgency")()
+	log.Info("Received Accept Urgency request")
+
+	idParam := ctx.Param("id")
+	urgencyID64, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil || urgencyID64 == 0 {
+		log.Errorf("invalid urgency ID: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid urgency ID"})
+		return
+	}
+	actorIDVal, _ := ctx.Get("employeeID")
+	actorID, _ := actorIDVal.(uint)
+	if actorID == 0 {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "AUTH_ERRORS.UNAUTHORIZED"})
+		return
+	}
+
+	if err := h.svc.AcceptUrgency(requestContext(ctx), uint(urgencyID64), actorID); err != nil {
+		log.Errorf("accept failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+
+	utils.WriteFreshWindow(ctx, config.DefaultFreshWindow)
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "accepted"})
+
+	log.Info("Successfully accepted urgency")
+}
+
+// DeclineUrgency Одбијање ургентне ситуације од стране обавештеног запосленог
+// @Summary Одбијање ургентне ситуације
+// @Description Обавештени дежурни запослени одбија ургентну ситуацију уз опциони разлог
+// @Tags urgency
+// @Security OAuth2Password
+// @Param id path int true "Urgency ID"
+// @Param payload body urgencyV1.UrgencyDeclineRequest false "Decline reason"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Router /urgencies/{id}/decline [post]
+func (h *urgencyHandler) DeclineUrgency(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "UrgencyHandler.DeclineUrgency")()
+	log.Info("Received Decline Urgency request")
+
+	idParam := ctx.Param("id")
+	urgencyID64, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil || urgencyID64 == 0 {
+		log.Errorf("invalid urgency ID: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid urgency ID"})
+		return
+	}
+	actorIDVal, _ := ctx.Get("employeeID")
+	actorID, _ := actorIDVal.(uint)
+	if actorID == 0 {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "AUTH_ERRORS.UNAUTHORIZED"})
+		return
+	}
+
+	var req urgencyV1.UrgencyDeclineRequest
+	if ctx.Request != nil && ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			log.Errorf("failed to bind json: %v", err)
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+	}
+	if err := req.Validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "VALIDATION.INVALID_REQUEST", "details": err.Error()})
+		return
+	}
+
+	if err := h.svc.DeclineUrgency(requestContext(ctx), uint(urgencyID64), actorID, req.Reason); err != nil {
+		log.Errorf("decline failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+
+	utils.WriteFreshWindow(ctx, config.DefaultFreshWindow)
+
+	ctx.JSON(http.StatusOK, gin.H{"status": "declined"})
+
+	log.Info("Successfully declined urgency")
+}
+
+// ListReplies Одговори обавештених запослених на ургентну ситуацију
+// @Summary Одговори обавештених запослених
+// @Description Листа обавештених запослених са њиховим одговором (прихваћено/одбијено) и разлогом одбијања
+// @Tags urgency
+// @Security OAuth2Password
+// @Produce  json
+// @Param id path int true "Urgency ID"
+// @Success 200 {object} urgencyV1.UrgencyRepliesResponse
+// @Router /urgencies/{id}/replies [get]
+func (h *urgencyHandler) ListReplies(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "UrgencyHandler.ListReplies")()
+	log.Info("Received List Replies request")
+
+	idParam := ctx.Param("id")
+	urgencyID64, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil || urgencyID64 == 0 {
+		log.Errorf("invalid urgency ID: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid urgency ID"})
+		return
+	}
+
+	replies, err := h.svc.ListReplies(requestContext(ctx), uint(urgencyID64))
+	if err != nil {
+		log.Errorf("list replies failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, urgencyV1.UrgencyRepliesResponse{Replies: replies})
+}
+
+// ListUrgencyNotifications Историја обавештења за ургентну ситуацију
+// @Summary Историја обавештења за ургентну ситуацију
+// @Description Сва SMS и email обавештења послата за ургентну ситуацију са статусом доставе, бројем покушаја и грешком (само за администраторе и запослене који раде на ургентној ситуацији)
+// @Tags urgency
+// @Security OAuth2Password
+// @Produce  json
+// @Param id path int true "Urgency ID"
+// @Success 200 {object} urgencyV1.NotificationListResponse
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /urgencies/{id}/notifications [get]
+func (h *urgencyHandler) ListUrgencyNotifications(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "UrgencyHandler.ListUrgencyNotifications")()
+	log.Info("Received List Urgency Notifications request")
+
+	urgencyID64, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil || urgencyID64 == 0 {
+		log.Errorf("invalid urgency ID: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid urgency ID"})
+		return
+	}
+
+	actorIDVal, _ := ctx.Get("employeeID")
+	roleVal, _ := ctx.Get("role")
+	actorID, _ := actorIDVal.(uint)
+	isAdmin := roleVal == "Administrator"
+
+	notifications, err := h.svc.ListUrgencyNotifications(requestContext(ctx), uint(urgencyID64), actorID, isAdmin)
+	if err != nil {
+		log.Errorf("list urgency notifications failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, urgencyV1.NotificationListResponse{Notifications: notifications})
+}
+
+// ListEmployeeNotifications Историја обавештења запосленог
+// @Summary Историја обавештења запосленог
+// @Description Обавештења послата запосленом, од најновијег, са статусом доставе (запослени види своја, администратор свачија)
+// @Tags urgency
+// @Security OAuth2Password
+// @Produce  json
+// @Param id path int true "Employee ID"
+// @Success 200 {object} urgencyV1.NotificationListResponse
+// @Failure 403 {object} map[string]interface{}
+// @Router /employees/{id}/notifications [get]
+func (h *urgencyHandler) ListEmployeeNotifications(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "UrgencyHandler.ListEmployeeNotifications")()
+	log.Info("Received List Employee Notifications request")
+
+	employeeID64, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil || employeeID64 == 0 {
+		log.Errorf("invalid employee ID: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid employee ID"})
+		return
+	}
+	actorIDVal, _ := ctx.Get("employeeID")
+	roleVal, _ := ctx.Get("role")
+	actorID, _ := actorIDVal.(uint)
+	isAdmin := roleVal == "Administrator"
+
+	notifications, err := h.svc.ListEmployeeNotifications(requestContext(ctx), uint(employeeID64), actorID, isAdmin)
+	if err != nil {
+		log.Errorf("list employee notifications failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, urgencyV1.NotificationListResponse{Notifications: notifications})
+}
+
+// RetryNotification Поновно слање неуспелог обавештења
+// @Summary Поновно слање неуспелог обавештења
+// @Description Неуспело обавештење се враћа у ред за слање са новим бројем покушаја (само за администраторе)
+// @Tags urgency
+// @Security OAuth2Password
+// @Produce  json
+// @Param id path int true "Notification ID"
+// @Success 200 {object} urgencyV1.NotificationResponse
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /admin/notifications/{id}/retry [post]
+func (h *urgencyHandler) RetryNotification(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "UrgencyHandler.RetryNotification")()
+	log.Info("Received Retry Notification request")
+
+	notificationID64, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil || notificationID64 == 0 {
+		log.Errorf("invalid notification ID: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid notification ID"})
+		return
+	}
+
+	notification, err := h.svc.RetryNotification(requestContext(ctx), uint(notificationID64))
+	if err != nil {
+		log.Errorf("retry notification failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+
+	utils.WriteFreshWindow(ctx, config.DefaultFreshWindow)
+	ctx.JSON(http.StatusOK, notification)
+}
+
+// ListEscalations Историја ескалација ургентне ситуације
+// @Summary Историја ескалација ургентне ситуације
+// @Description Листа корака ескалације који су покренути док ургентна ситуација није била прихваћена
+// @Tags urgency
+// @Security OAuth2Password
+// @Produce  json
+// @Param id path int true "Urgency ID"
+// @Success 200 {object} map[string][]urgencyV1.UrgencyEscalationResponse
+// @Router /urgencies/{id}/escalations [get]
+func (h *urgencyHandler) ListEscalations(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "UrgencyHandler.ListEscalations")()
+	log.Info("Received List Escalations request")
+
+	idParam := ctx.Param("id")
+	urgencyID64, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil || urgencyID64 == 0 {
+		log.Errorf("invalid urgency ID: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid urgency ID"})
+		return
+	}
+
+	escalations, err := h.svc.ListEscalations(requestContext(ctx), uint(urgencyID64))
+	if err != nil {
+		log.Errorf("list escalations failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+
+	items := make([]urgencyV1.UrgencyEscalationResponse, 0, len(escalations))
+	for _, e := range escalations {
+		items = append(items, e.ToResponse())
+	}
+	ctx.JSON(http.StatusOK, gin.H{"escalations": items})
+}
+
+// GetTimeline Хронологија ургентне ситуације
+// @Summary Хронологија ургентне ситуације
+// @Description Промене статуса, ескалације и активности за ургентну ситуацију сортиране по времену
+// @Tags urgency
+// @Security OAuth2Password
+// @Produce  json
+// @Param id path int true "Urgency ID"
+// @Success 200 {object} urgencyV1.UrgencyTimelineResponse
+// @Failure 404 {object} map[string]interface{}
+// @Router /urgencies/{id}/timeline [get]
+func (h *urgencyHandler) GetTimeline(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "UrgencyHandler.GetTimeline")()
+	log.Info("Received Get Timeline request")
+
+	idParam := ctx.Param("id")
+	urgencyID64, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil || urgencyID64 == 0 {
+		log.Errorf("invalid urgency ID: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid urgency ID"})
+		return
+	}
+
+	timeline, err := h.svc.GetTimeline(requestContext(ctx), uint(urgencyID64))
+	if err != nil {
+		log.Errorf("get timeline failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, timeline)
+}
+
+// GetTeam Тим на ургентној ситуацији
+// @Summary Тим на ургентној ситуацији
+// @Description Запослени који тренутно раде на ургентној ситуацији са својим улогама; вођа тима је задужени запослени
+// @Tags urgency
+// @Security OAuth2Password
+// @Produce  json
+// @Param id path int true "Urgency ID"
+// @Success 200 {object} urgencyV1.UrgencyTeamResponse
+// @Failure 404 {object} map[string]interface{}
+// @Router /urgencies/{id}/team [get]
+func (h *urgencyHandler) GetTeam(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "UrgencyHandler.GetTeam")()
+	log.Info("Received Get Team request")
+
+	idParam := ctx.Param("id")
+	urgencyID64, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil || urgencyID64 == 0 {
+		log.Errorf("invalid urgency ID: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid urgency ID"})
+		return
+	}
+
+	team, err := h.svc.GetTeam(requestContext(ctx), uint(urgencyID64))
+	if err != nil {
+		log.Errorf("get team failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, team)
+}
+
+// AddTeamMember Додавање члана тима на ургентну ситуацију
+// @Summary Додавање члана тима на ургентну ситуацију
+// @Description Вођа тима или администратор додаје запосленог са улогом; улогу вође може преузети било ко док ургентна ситуација нема задуженог
+// @Tags urgency
+// @Security OAuth2Password
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Urgency ID"
+// @Param request body urgencyV1.TeamMemberRequest true "Запослени и улога"
+// @Success 201 {object} urgencyV1.UrgencyTeamResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /urgencies/{id}/team [post]
+func (h *urgencyHandler) AddTeamMember(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "UrgencyHandler.AddTeamMember")()
+	log.Info("Received Add Team Member request")
+
+	idParam := ctx.Param("id")
+	urgencyID64, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil || urgencyID64 == 0 {
+		log.Errorf("invalid urgency ID: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid urgency ID"})
+		return
+	}
+	var req urgencyV1.TeamMemberRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorf("invalid team member payload: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := req.Validate(); err != nil {
+		log.Errorf("team member validation failed: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	actorIDVal, _ := ctx.Get("employeeID")
+	roleVal, _ := ctx.Get("role")
+	actorID, _ := actorIDVal.(uint)
+	isAdmin := roleVal == "Administrator"
+
+	cctx := requestContext(ctx)
+	if err := h.svc.AddTeamMember(cctx, uint(urgencyID64), actorID, isAdmin, req); err != nil {
+		log.Errorf("add team member failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+	team, err := h.svc.GetTeam(cctx, uint(urgencyID64))
+	if err != nil {
+		log.Errorf("get team failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+
+	utils.WriteFreshWindow(ctx, config.DefaultFreshWindow)
+	ctx.JSON(http.StatusCreated, team)
+	log.Infof("Employee %d added to urgency %d as %s", req.EmployeeID, urgencyID64, req.Role)
+}
+
+// RemoveTeamMember Уклањање члана тима са ургентне ситуације
+// @Summary Уклањање члана тима са ургентне ситуације
+// @Description Вођа тима или администратор уклања члана, а члан може и сам да напусти тим; уклањањем вође ургентна ситуација остаје без задуженог
+// @Tags urgency
+// @Security OAuth2Password
+// @Param id path int true "Urgency ID"
+// @Param employeeId path int true "Employee ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /urgencies/{id}/team/{employeeId} [delete]
+func (h *urgencyHandler) RemoveTeamMember(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "UrgencyHandler.RemoveTeamMember")()
+	log.Info("Received Remove Team Member request")
+
+	urgencyID64, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil || urgencyID64 == 0 {
+		log.Errorf("invalid urgency ID: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid urgency ID"})
+		return
+	}
+	employeeID64, err := strconv.ParseUint(ctx.Param("employeeId"), 10, 32)
+	if err != nil || employeeID64 == 0 {
+		log.Errorf("invalid employee ID: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid employee ID"})
+		return
+	}
+	actorIDVal, _ := ctx.Get("employeeID")
+	roleVal, _ := ctx.Get("role")
+	actorID, _ := actorIDVal.(uint)
+	isAdmin := roleVal == "Administrator"
+
+	if err := h.svc.RemoveTeamMember(requestContext(ctx), uint(urgencyID64), uint(employeeID64), actorID, isAdmin); err != nil {
+		log.Errorf("remove team member failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+
+	utils.WriteFreshWindow(ctx, config.DefaultFreshWindow)
+	ctx.JSON(http.StatusNoContent, nil)
+	log.Infof("Employee %d removed from urgency %d", employeeID64, urgencyID64)
+}
+
+// GetEmployeeActiveUrgencies Активне ургентне ситуације запосленог (сервис-сервис)
+// @Summary Активне ургентне ситуације запосленог
+// @Description Ургентне ситуације у току које запослени води или на којима је члан тима; користи их сервис запослених
+// @Tags urgency
+// @Produce  json
+// @Param id path int true "Employee ID"
+// @Success 200 {object} urgencyV1.EmployeeActiveUrgenciesResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /service/employees/{id}/active-urgencies [get]
+func (h *urgencyHandler) GetEmployeeActiveUrgencies(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "UrgencyHandler.GetEmployeeActiveUrgencies")()
+
+	employeeID64, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil || employeeID64 == 0 {
+		log.Errorf("invalid employee ID: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid employee ID"})
+		return
+	}
+
+	resp, err := h.svc.GetEmployeeActiveUrgencies(requestContext(ctx), uint(employeeID64))
+	if err != nil {
+		log.Errorf("get active urgencies failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetTrackingStatus Праћење пријављене ургентне ситуације
+// @Summary Праћење пријављене ургентне ситуације
+// @Description Јавни преглед статуса, броја спасилаца у тиму и процене доласка помоћу токена за праћење добијеног при пријави
+// @Tags urgency
+// @Produce  json
+// @Param token path string true "Tracking token"
+// @Success 200 {object} urgencyV1.UrgencyTrackingResponse
+// @Failure 404 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{}
+// @Router /urgencies/track/{token} [get]
+func (h *urgencyHandler) GetTrackingStatus(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "UrgencyHandler.GetTrackingStatus")()
+	log.Info("Received Get Tracking Status request")
+
+	status, err := h.svc.GetTrackingStatus(requestContext(ctx), ctx.Param("token"))
+	if err != nil {
+		log.Errorf("get tracking status failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, status)
+}
+
+// AddFollowUp Допуна пријаве ургентне ситуације
+// @Summary Допуна пријаве ургентне ситуације
+// @Description Пријавилац помоћу токена за праћење шаље нову локацију или додатни опис док је ургентна ситуација у току
+// @Tags urgency
+// @Accept  json
+// @Produce  json
+// @Param token path string true "Tracking token"
+// @Param request body urgencyV1.UrgencyFollowUpRequest true "Нова локација и/или опис"
+// @Success 201 {object} urgencyV1.UrgencyFollowUpResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{}
+// @Router /urgencies/track/{token}/follow-ups [post]
+func (h *urgencyHandler) AddFollowUp(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "UrgencyHandler.AddFollowUp")()
+	log.Info("Received Add Follow-Up request")
+
+	var req urgencyV1.UrgencyFollowUpRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorf("invalid follow-up payload: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	followUp, err := h.svc.AddFollowUp(requestContext(ctx), ctx.Param("token"), req)
+	if err != nil {
+		log.Errorf("add follow-up failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, followUp)
+}
+
+// VerifyPhone Потврда броја телефона пријавиоца
+// @Summary Потврда броја телефона пријавиоца
+// @Description Пријавилац помоћу токена за праћење шаље једнократни код добијен SMS поруком, након чега се обавештавају дежурни спасиоци
+// @Tags urgency
+// @Accept  json
+// @Param token path string true "Tracking token"
+// @Param request body urgencyV1.UrgencyVerificationRequest true "Једнократни код"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{}
+// @Router /urgencies/track/{token}/verify [post]
+func (h *urgencyHandler) VerifyPhone(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "UrgencyHandler.VerifyPhone")()
+	log.Info("Received Verify Phone request")
+
+	var req urgencyV1.UrgencyVerificationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorf("invalid verification payload: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.svc.VerifyPhone(requestContext(ctx), ctx.Param("token"), req); err != nil {
+		log.Errorf("verify phone failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// ResendVerificationCode Слање новог кода за потврду
+// @Summary Слање новог кода за потврду
+// @Description Пријавилац помоћу токена за праћење тражи нови једнократни код; претходни код престаје да важи
+// @Tags urgency
+// @Param token path string true "Tracking token"
+// @Success 204
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{}
+// @Router /urgencies/track/{token}/verify/resend [post]
+func (h *urgencyHandler) ResendVerificationCode(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "UrgencyHandler.ResendVerificationCode")()
+	log.Info("Received Resend Verification Code request")
+
+	if err := h.svc.ResendVerificationCode(requestContext(ctx), ctx.Param("token")); err != nil {
+		log.Errorf("resend verification code failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// ListIntakeReview Листа пријава на провери
+// @Summary Листа пријава на провери
+// @Description Пријаве које чекају потврду броја телефона или су задржане као сумњиве, од најстарије (само за администраторе)
+// @Tags urgency
+// @Security OAuth2Password
+// @Produce  json
+// @Success 200 {object} urgencyV1.IntakeReviewList
+// @Failure 500 {object} map[string]interface{}
+// @Router /admin/urgencies/review [get]
+func (h *urgencyHandler) ListIntakeReview(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "UrgencyHandler.ListIntakeReview")()
+	log.Info("Received List Intake Review request")
+
+	resp, err := h.svc.ListIntakeReview(requestContext(ctx))
+	if err != nil {
+		log.Errorf("list intake review failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ReleaseIntake Пропуштање пријаве дежурним спасиоцима
+// @Summary Пропуштање пријаве дежурним спасиоцима
+// @Description Задржана или непотврђена пријава се прихвата и дежурни спасиоци се обавештавају (само за администраторе)
+// @Tags urgency
+// @Security OAuth2Password
+// @Param id path int true "Urgency ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /admin/urgencies/{id}/release [post]
+func (h *urgencyHandler) ReleaseIntake(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "UrgencyHandler.ReleaseIntake")()
+	log.Info("Received Release Intake request")
+
+	idParam := ctx.Param("id")
+	urgencyID64, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil || urgencyID64 == 0 {
+		log.Errorf("invalid urgency ID: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid urgency ID"})
+		return
+	}
+	actorIDVal, _ := ctx.Get("employeeID")
+	roleVal, _ := ctx.Get("role")
+	actorID, _ := actorIDVal.(uint)
+	if actorID == 0 {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "AUTH_ERRORS.UNAUTHORIZED"})
+		return
+	}
+	isAdmin := roleVal == "Administrator"
+
+	if err := h.svc.ReleaseIntake(requestContext(ctx), uint(urgencyID64), actorID, isAdmin); err != nil {
+		log.Errorf("release intake failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+
+	utils.WriteFreshWindow(ctx, config.DefaultFreshWindow)
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// RejectIntake Одбацивање пријаве
+// @Summary Одбацивање пријаве
+// @Description Задржана или непотврђена пријава се отказује уз навођење разлога, без обавештавања спасилаца (само за администраторе)
+// @Tags urgency
+// @Security OAuth2Password
+// @Accept  json
+// @Param id path int true "Urgency ID"
+// @Param request body urgencyV1.UrgencyStatusChangeRequest true "Разлог одбацивања"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /admin/urgencies/{id}/reject [post]
+func (h *urgencyHandler) RejectIntake(ctx *gin.Context) {
+	h.changeStatus(ctx, "RejectIntake", h.svc.RejectIntake)
+}
+
+// UploadAttachment Додавање фотографије или документа ургентној ситуацији
+// @Summary Додавање фотографије или документа ургентној ситуацији
+// @Description Запослени који раде на ургентној ситуацији додају JPEG, PNG, WebP или PDF датотеку, по жељи везану за активност; из слика се уклањају метаподаци (EXIF, GPS)
+// @Tags urgency
+// @Security OAuth2Password
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param id path int true "Urgency ID"
+// @Param file formData file true "Датотека"
+// @Param activityId formData int false "Activity ID"
+// @Success 201 {object} urgencyV1.AttachmentResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 413 {object} map[string]interface{}
+// @Failure 415 {object} map[string]interface{}
+// @Router /urgencies/{id}/attachments [post]
+func (h *urgencyHandler) UploadAttachment(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "UrgencyHandler.UploadAttachment")()
+	log.Info("Received Upload Attachment request")
+
+	urgencyID64, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil || urgencyID64 == 0 {
+		log.Errorf("invalid urgency ID: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid urgency ID"})
+		return
+	}
+	upload, closeFile, ok := h.attachmentUpload(ctx)
+	if !ok {
+		return
+	}
+	defer closeFile()
+	if raw := ctx.PostForm("activityId"); raw != "" {
+		id64, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil || id64 == 0 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid activity ID"})
+			return
+		}
+		id := uint(id64)
+		upload.ActivityID = &id
+	}
+	actorIDVal, _ := ctx.Get("employeeID")
+	roleVal, _ := ctx.Get("role")
+	actorID, _ := actorIDVal.(uint)
+	isAdmin := roleVal == "Administrator"
+
+	attachment, err := h.svc.UploadAttachment(requestContext(ctx), uint(urgencyID64), actorID, isAdmin, upload)
+	if err != nil {
+		log.Errorf("upload attachment failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+
+	utils.WriteFreshWindow(ctx, config.DefaultFreshWindow)
+	ctx.JSON(http.StatusCreated, attachment)
+}
+
+// UploadReporterAttachment Слање фотографије уз пријаву
+// @Summary Слање фотографије уз пријаву
+// @Description Пријавилац помоћу токена за праћење шаље фотографију терена или повреде док је ургентна ситуација у току; из слика се уклањају метаподаци (EXIF, GPS)
+// @Tags urgency
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param token path string true "Tracking token"
+// @Param file formData file true "Датотека"
+// @Success 201 {object} urgencyV1.AttachmentResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 413 {object} map[string]interface{}
+// @Failure 415 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{}
+// @Router /urgencies/track/{token}/attachments [post]
+func (h *urgencyHandler) UploadReporterAttachment(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "UrgencyHandler.UploadReporterAttachment")()
+	log.Info("Received Upload Reporter Attachment request")
+
+	upload, closeFile, ok := h.attachmentUpload(ctx)
+	if !ok {
+		return
+	}
+	defer closeFile()
+
+	attachment, err := h.svc.UploadReporterAttachment(requestContext(ctx), ctx.Param("token"), upload)
+	if err != nil {
+		log.Errorf("upload reporter attachment failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, attachment)
+}
+
+// ListAttachments Листа прилога ургентне ситуације
+// @Summary Листа прилога ургентне ситуације
+// @Description Фотографије и документи ургентне ситуације редом додавања, по жељи само за једну активност
+// @Tags urgency
+// @Security OAuth2Password
+// @Produce  json
+// @Param id path int true "Urgency ID"
+// @Param activityId query int false "Activity ID"
+// @Success 200 {object} urgencyV1.AttachmentListResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /urgencies/{id}/attachments [get]
+func (h *urgencyHandler) ListAttachments(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "UrgencyHandler.ListAttachments")()
+	log.Info("Received List Attachments request")
+
+	urgencyID64, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil || urgencyID64 == 0 {
+		log.Errorf("invalid urgency ID: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid urgency ID"})
+		return
+	}
+	var activityID *uint
+	if raw := ctx.Query("activityId"); raw != "" {
+		id64, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil || id64 == 0 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid activity ID"})
+			return
+		}
+		id := uint(id64)
+		activityID = &id
+	}
+	actorIDVal, _ := ctx.Get("employeeID")
+	roleVal, _ := ctx.Get("role")
+	actorID, _ := actorIDVal.(uint)
+	isAdmin := roleVal == "Administrator"
+
+	attachments, err := h.svc.ListAttachments(requestContext(ctx), uint(urgencyID64), actorID, isAdmin, activityID)
+	if err != nil {
+		log.Errorf("list attachments failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, urgencyV1.AttachmentListResponse{Attachments: attachments})
+}
+
+// DownloadAttachment Преузимање прилога ургентне ситуације
+// @Summary Преузимање прилога ургентне ситуације
+// @Description Садржај фотографије или документа; датотеке се никада не служе директно из складишта
+// @Tags urgency
+// @Security OAuth2Password
+// @Produce  application/octet-stream
+// @Param id path int true "Urgency ID"
+// @Param attachmentId path int true "Attachment ID"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /urgencies/{id}/attachments/{attachmentId} [get]
+func (h *urgencyHandler) DownloadAttachment(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "UrgencyHandler.DownloadAttachment")()
+	log.Info("Received Download Attachment request")
+
+	urgencyID, attachmentID, ok := h.attachmentIDs(ctx)
+	if !ok {
+		return
+	}
+	actorIDVal, _ := ctx.Get("employeeID")
+	roleVal, _ := ctx.Get("role")
+	actorID, _ := actorIDVal.(uint)
+	isAdmin := roleVal == "Administrator"
+
+	attachment, body, err := h.svc.OpenAttachment(requestContext(ctx), urgencyID, attachmentID, actorID, isAdmin)
+	if err != nil {
+		log.Errorf("download attachment failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+	defer body.Close()
+
+	ctx.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, body, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}),
+		"X-Content-Type-Options": "nosniff",
+		"Cache-Control":          "private, no-store",
+	})
+}
+
+// DeleteAttachment Брисање прилога ургентне ситуације
+// @Summary Брисање прилога ургентне ситуације
+// @Description Прилог брише запослени који га је додао, задужени за ургентну ситуацију или администратор
+// @Tags urgency
+// @Security OAuth2Password
+// @Param id path int true "Urgency ID"
+// @Param attachmentId path int true "Attachment ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /urgencies/{id}/attachments/{attachmentId} [delete]
+func (h *urgencyHandler) DeleteAttachment(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "UrgencyHandler.DeleteAttachment")()
+	log.Info("Received Delete Attachment request")
+
+	urgencyID, attachmentID, ok := h.attachmentIDs(ctx)
+	if !ok {
+		return
+	}
+	actorIDVal, _ := ctx.Get("employeeID")
+	roleVal, _ := ctx.Get("role")
+	actorID, _ := actorIDVal.(uint)
+	isAdmin := roleVal == "Administrator"
+
+	if err := h.svc.DeleteAttachment(requestContext(ctx), urgencyID, attachmentID, actorID, isAdmin); err != nil {
+		log.Errorf("delete attachment failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+
+	utils.WriteFreshWindow(ctx, config.DefaultFreshWindow)
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// attachmentUpload opens the uploaded file from the "file" form field; the caller closes it when done
+func (h *urgencyHandler) attachmentUpload(ctx *gin.Context) (AttachmentUpload, func(), bool) {
+	// The form is buffered while it is parsed, so an oversized body is cut off before it reaches memory or disk
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, h.maxUploadSize+attachmentFormOverhead)
+	header, err := ctx.FormFile("file")
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		h.log.WithContext(requestContext(ctx)).Errorf("attachment request too large: %v", err)
+		writeAppError(ctx, commonv1.NewAppError("URGENCY_ERRORS.ATTACHMENT_TOO_LARGE", fmt.Sprintf("file exceeds the maximum size of %d bytes", h.maxUploadSize), map[string]interface{}{"maxBytes": h.maxUploadSize}))
+		return AttachmentUpload{}, nil, false
+	}
+	if err != nil {
+		h.log.WithContext(requestContext(ctx)).Errorf("missing attachment file: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return AttachmentUpload{}, nil, false
+	}
+	file, err := header.Open()
+	if err != nil {
+		h.log.WithContext(requestContext(ctx)).Errorf("failed to open attachment file: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "failed to read file"})
+		return AttachmentUpload{}, nil, false
+	}
+	return AttachmentUpload{FileName: header.Filename, Body: file}, func() { file.Close() }, true
+}
+
+func (h *urgencyHandler) attachmentIDs(ctx *gin.Context) (uint, uint, bool) {
+	urgencyID64, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil || urgencyID64 == 0 {
+		h.log.WithContext(requestContext(ctx)).Errorf("invalid urgency ID: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid urgency ID"})
+		return 0, 0, false
+	}
+	attachmentID64, err := strconv.ParseUint(ctx.Param("attachmentId"), 10, 32)
+	if err != nil || attachmentID64 == 0 {
+		h.log.WithContext(requestContext(ctx)).Errorf("invalid attachment ID: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid attachment ID"})
+		return 0, 0, false
+	}
+	return uint(urgencyID64), uint(attachmentID64), true
+}
+
+// writeAppError maps service errors to HTTP status codes, defaulting to 400 for unknown app errors.
+func writeAppError(ctx *gin.Context, err error) {
+	aerr, ok := err.(*commonv1.AppError)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	status := http.StatusBadRequest
+	switch aerr.Code {
+	case "URGENCY_ERRORS.RATE_LIMITED":
+		status = http.StatusTooManyRequests
+		if seconds, ok := aerr.Details["retryAfterSeconds"].(int); ok {
+			ctx.Header("Retry-After", strconv.Itoa(seconds))
+		}
+	case "URGENCY_ERRORS.NOT_FOUND", "URGENCY_ERRORS.NOT_ON_TEAM", "URGENCY_ERRORS.NOTIFICATION_NOT_FOUND", "URGENCY_ERRORS.WEBHOOK_NOT_FOUND", "URGENCY_ERRORS.ATTACHMENT_NOT_FOUND":
+		status = http.StatusNotFound
+	case "URGENCY_ERRORS.ATTACHMENT_TOO_LARGE":
+		status = http.StatusRequestEntityTooLarge
+	case "URGENCY_ERRORS.UNSUPPORTED_ATTACHMENT_TYPE":
+		status = http.StatusUnsupportedMediaType
+	case "URGENCY_ERRORS.ATTACHMENTS_UNAVAILABLE":
+		status = http.StatusServiceUnavailable
+	case "URGENCY_ERRORS.NOT_NOTIFIED", "AUTH_ERRORS.FORBIDDEN":
+		status = http.StatusForbidden
+	case "URGENCY_ERRORS.ALREADY_ASSIGNED", "URGENCY_ERRORS.ALREADY_ON_TEAM", "URGENCY_ERRORS.INVALID_TRANSITION", "URGENCY_ERRORS.NOTIFICATION_NOT_FAILED",
+		"URGENCY_ERRORS.ATTACHMENT_LIMIT_REACHED":
+		status = http.StatusConflict
+	case "URGENCY_ERRORS.DB_ERROR", "URGENCY_ERRORS.UPDATE_FAILED", "URGENCY_ERRORS.LIST_FAILED", "URGENCY_ERRORS.ON_CALL_FETCH_FAILED", "URGENCY_ERRORS.TEMPLATE_RENDER_FAILED",
+		"URGENCY_ERRORS.STORAGE_FAILED", "URGENCY_ERRORS.ACTIVITY_FETCH_FAILED":
+		status = http.StatusInternalServerError
+	}
+	ctx.JSON(status, gin.H{"error": aerr.Code, "details": aerr.Error()})
+}
+
+func requestContext(ctx *gin.Context) context.Context {
+	if ctx == nil || ctx.Request == nil {
+		return context.Background()
+	}
+	base := ctx.Request.Context()
+	if v, ok := ctx.Get("employeeID"); ok {
+		if id, ok := v.(uint); ok {
+			base = withActor(base, id)
+		}
 	}
-	return context.Background()
+	return base
 }
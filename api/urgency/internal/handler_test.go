@@ -1,8 +1,11 @@
 package internal
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -263,10 +266,11 @@ func TestUrgencyHandler_ListUrgencies(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest(http.MethodGet, "/urgencies", nil)
 
 		// No query params -> defaults page=1,pageSize=20
 		mockService := NewMockUrgencyService(ctrl)
-		mockService.EXPECT().ListUrgencies(gomock.Any(), 1, 20, gomock.Nil()).Return(nil, int64(0), errors.New("database error")).Times(1)
+		mockService.EXPECT().ListUrgencies(gomock.Any(), model.UrgencyFilter{Page: 1, PageSize: 20, AcceptedIntakeOnly: true}).Return(nil, int64(0), errors.New("database error")).Times(1)
 
 		handler := NewUrgencyHandler(log, mockService)
 		handler.ListUrgencies(ctx)
@@ -281,9 +285,10 @@ func TestUrgencyHandler_ListUrgencies(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest(http.MethodGet, "/urgencies", nil)
 
 		mockService := NewMockUrgencyService(ctrl)
-		mockService.EXPECT().ListUrgencies(gomock.Any(), 1, 20, gomock.Nil()).Return([]model.Urgency{}, int64(0), nil).Times(1)
+		mockService.EXPECT().ListUrgencies(gomock.Any(), model.UrgencyFilter{Page: 1, PageSize: 20, AcceptedIntakeOnly: true}).Return([]model.Urgency{}, int64(0), nil).Times(1)
 
 		handler := NewUrgencyHandler(log, mockService)
 		handler.ListUrgencies(ctx)
@@ -299,6 +304,7 @@ func TestUrgencyHandler_ListUrgencies(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest(http.MethodGet, "/urgencies", nil)
 
 		urgencies := []model.Urgency{
 			{
@@ -326,7 +332,7 @@ func TestUrgencyHandler_ListUrgencies(t *testing.T) {
 		}
 
 		mockService := NewMockUrgencyService(ctrl)
-		mockService.EXPECT().ListUrgencies(gomock.Any(), 1, 20, gomock.Nil()).Return(urgencies, int64(len(urgencies)), nil).Times(1)
+		mockService.EXPECT().ListUrgencies(gomock.Any(), model.UrgencyFilter{Page: 1, PageSize: 20, AcceptedIntakeOnly: true}).Return(urgencies, int64(len(urgencies)), nil).Times(1)
 
 		handler := NewUrgencyHandler(log, mockService)
 		handler.ListUrgencies(ctx)
@@ -341,6 +347,73 @@ func TestUrgencyHandler_ListUrgencies(t *testing.T) {
 	})
 }
 
+func TestUrgencyHandler_ListUrgencies_Filters(t *testing.T) {
+	t.Parallel()
+	log := utils.NewTestLogger()
+
+	newCtx := func(rawQuery string) (*gin.Context, *httptest.ResponseRecorder) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest(http.MethodGet, "/urgencies?"+rawQuery, nil)
+		return ctx, w
+	}
+
+	t.Run("it passes filters, search and sort to the service", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx("page=2&pageSize=5&status=open,in_progress&level=critical&from=2025-01-01&to=2025-01-31&unassigned=true&q=%20ski%20&sort=newest")
+		from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().ListUrgencies(gomock.Any(), model.UrgencyFilter{
+			Page:               2,
+			PageSize:           5,
+			Statuses:           []urgencyV1.UrgencyStatus{urgencyV1.Open, urgencyV1.InProgress},
+			Levels:             []urgencyV1.UrgencyLevel{urgencyV1.Critical},
+			From:               &from,
+			To:                 &to,
+			UnassignedOnly:     true,
+			AcceptedIntakeOnly: true,
+			Query:              "ski",
+			Sort:               urgencyV1.SortNewest,
+		}).Return([]model.Urgency{}, int64(6), nil)
+
+		NewUrgencyHandler(log, svc).ListUrgencies(ctx)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "\"totalPages\":2")
+	})
+
+	t.Run("it uses the current employee for myUrgencies", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx("myUrgencies=true&assigneeId=9")
+		ctx.Set("employeeID", uint(4))
+		me := uint(4)
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().ListUrgencies(gomock.Any(), model.UrgencyFilter{Page: 1, PageSize: 20, TeamMemberID: &me, AcceptedIntakeOnly: true}).Return(nil, int64(0), nil)
+
+		NewUrgencyHandler(log, svc).ListUrgencies(ctx)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("it lists reports held back by the intake checks to admins", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx("")
+		ctx.Set("role", "Administrator")
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().ListUrgencies(gomock.Any(), model.UrgencyFilter{Page: 1, PageSize: 20}).Return(nil, int64(0), nil)
+
+		NewUrgencyHandler(log, svc).ListUrgencies(ctx)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("it returns status 400 for invalid filters", func(t *testing.T) {
+		for _, rawQuery := range []string{"status=done", "sort=name", "from=yesterday", "assigneeId=abc", "unassigned=true&assigneeId=3"} {
+			ctx, w := newCtx(rawQuery)
+			NewUrgencyHandler(log, NewMockUrgencyService(gomock.NewController(t))).ListUrgencies(ctx)
+			assert.Equal(t, http.StatusBadRequest, w.Code, rawQuery)
+		}
+	})
+}
+
 func TestUrgencyHandler_GetUrgency(t *testing.T) {
 	t.Parallel()
 
@@ -543,7 +616,7 @@ func TestUrgencyHandler_UpdateUrgency(t *testing.T) {
 		ctx.Request.Header.Set("Content-Type", "application/json")
 
 		mockService := NewMockUrgencyService(ctrl)
-		mockService.EXPECT().GetUrgencyByID(gomock.Any(), uint(1)).Return(nil, gorm.ErrRecordNotFound).Times(1)
+		mockService.EXPECT().UpdateUrgency(gomock.Any(), uint(1), gomock.Any()).Return(nil, commonv1.NewAppError("URGENCY_ERRORS.NOT_FOUND", "urgency not found", nil)).Times(1)
 
 		handler := NewUrgencyHandler(log, mockService)
 		handler.UpdateUrgency(ctx)
@@ -567,21 +640,8 @@ func TestUrgencyHandler_UpdateUrgency(t *testing.T) {
 		ctx.Request = httptest.NewRequest(http.MethodPut, "/urgencies/1", strings.NewReader(payload))
 		ctx.Request.Header.Set("Content-Type", "application/json")
 
-		existingUrgency := &model.Urgency{
-			ID:           1,
-			FirstName:    "Marko",
-			LastName:     "Markovic",
-			Email:        "test@example.com",
-			ContactPhone: "123456789",
-			Location:     "N 43.401123 E 22.662756",
-			Description:  "Test description",
-			Level:        urgencyV1.High,
-			Status:       urgencyV1.Open,
-		}
-
 		mockService := NewMockUrgencyService(ctrl)
-		mockService.EXPECT().GetUrgencyByID(gomock.Any(), uint(1)).Return(existingUrgency, nil).Times(1)
-		mockService.EXPECT().UpdateUrgency(gomock.Any(), gomock.Any()).Return(errors.New("database error")).Times(1)
+		mockService.EXPECT().UpdateUrgency(gomock.Any(), uint(1), gomock.Any()).Return(nil, errors.New("database error")).Times(1)
 
 		handler := NewUrgencyHandler(log, mockService)
 		handler.UpdateUrgency(ctx)
@@ -621,8 +681,11 @@ func TestUrgencyHandler_UpdateUrgency(t *testing.T) {
 		}
 
 		mockService := NewMockUrgencyService(ctrl)
-		mockService.EXPECT().GetUrgencyByID(gomock.Any(), uint(1)).Return(existingUrgency, nil).Times(1)
-		mockService.EXPECT().UpdateUrgency(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockService.EXPECT().UpdateUrgency(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ uint, req *urgencyV1.UrgencyUpdateRequest) (*model.Urgency, error) {
+				existingUrgency.UpdateWithRequest(req)
+				return existingUrgency, nil
+			}).Times(1)
 
 		handler := NewUrgencyHandler(log, mockService)
 		handler.UpdateUrgency(ctx)
@@ -1030,3 +1093,953 @@ func TestUrgencyHandler_CloseUrgency(t *testing.T) {
 		}
 	})
 }
+
+func TestUrgencyHandler_AcceptUrgency(t *testing.T) {
+	t.Parallel()
+	log := utils.NewTestLogger()
+
+	t.Run("it returns status 400 for invalid urgency ID", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = []gin.Param{{Key: "id", Value: "abc"}}
+		NewUrgencyHandler(log, nil).AcceptUrgency(ctx)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("it returns status 401 when employee is missing from context", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = []gin.Param{{Key: "id", Value: "1"}}
+		NewUrgencyHandler(log, nil).AcceptUrgency(ctx)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("it returns status 409 when urgency was already accepted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = []gin.Param{{Key: "id", Value: "1"}}
+		ctx.Set("employeeID", uint(2))
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().AcceptUrgency(gomock.Any(), uint(1), uint(2)).Return(commonv1.NewAppError("URGENCY_ERRORS.ALREADY_ASSIGNED", "urgency already accepted by another employee", nil))
+		NewUrgencyHandler(log, svc).AcceptUrgency(ctx)
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "ALREADY_ASSIGNED")
+	})
+
+	t.Run("it returns status 403 when employee was not notified", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = []gin.Param{{Key: "id", Value: "1"}}
+		ctx.Set("employeeID", uint(2))
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().AcceptUrgency(gomock.Any(), uint(1), uint(2)).Return(commonv1.NewAppError("URGENCY_ERRORS.NOT_NOTIFIED", "employee was not notified about this urgency", nil))
+		NewUrgencyHandler(log, svc).AcceptUrgency(ctx)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("it successfully accepts urgency", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = []gin.Param{{Key: "id", Value: "1"}}
+		ctx.Set("employeeID", uint(2))
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().AcceptUrgency(gomock.Any(), uint(1), uint(2)).Return(nil)
+		NewUrgencyHandler(log, svc).AcceptUrgency(ctx)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "\"status\":\"accepted\"")
+		assert.NotEmpty(t, w.Header().Get(config.FreshWindowHeader))
+	})
+}
+
+func TestUrgencyHandler_DeclineUrgency(t *testing.T) {
+	t.Parallel()
+	log := utils.NewTestLogger()
+
+	t.Run("it returns status 400 for too long reason", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = []gin.Param{{Key: "id", Value: "1"}}
+		ctx.Set("employeeID", uint(2))
+		body := `{"reason":"` + strings.Repeat("x", urgencyV1.MaxDeclineReasonLength+1) + `"}`
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/urgencies/1/decline", strings.NewReader(body))
+		ctx.Request.Header.Set("Content-Type", "application/json")
+		NewUrgencyHandler(log, nil).DeclineUrgency(ctx)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("it accepts a request without body", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = []gin.Param{{Key: "id", Value: "1"}}
+		ctx.Set("employeeID", uint(2))
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/urgencies/1/decline", nil)
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().DeclineUrgency(gomock.Any(), uint(1), uint(2), "").Return(nil)
+		NewUrgencyHandler(log, svc).DeclineUrgency(ctx)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("it successfully declines urgency with a reason", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = []gin.Param{{Key: "id", Value: "1"}}
+		ctx.Set("employeeID", uint(2))
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/urgencies/1/decline", strings.NewReader(`{"reason":"injured"}`))
+		ctx.Request.Header.Set("Content-Type", "application/json")
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().DeclineUrgency(gomock.Any(), uint(1), uint(2), "injured").Return(nil)
+		NewUrgencyHandler(log, svc).DeclineUrgency(ctx)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "\"status\":\"declined\"")
+	})
+}
+
+func TestUrgencyHandler_ListReplies(t *testing.T) {
+	t.Parallel()
+	log := utils.NewTestLogger()
+
+	t.Run("it returns replies of notified employees", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = []gin.Param{{Key: "id", Value: "1"}}
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().ListReplies(gomock.Any(), uint(1)).Return([]urgencyV1.UrgencyReplyResponse{{EmployeeID: 2, Reply: "declined", DeclineReason: "injured"}}, nil)
+		NewUrgencyHandler(log, svc).ListReplies(ctx)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "\"declineReason\":\"injured\"")
+	})
+
+	t.Run("it returns status 500 when service fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = []gin.Param{{Key: "id", Value: "1"}}
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().ListReplies(gomock.Any(), uint(1)).Return(nil, commonv1.NewAppError("URGENCY_ERRORS.DB_ERROR", "failed to fetch notifications", nil))
+		NewUrgencyHandler(log, svc).ListReplies(ctx)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestUrgencyHandler_ListUrgenciesInArea(t *testing.T) {
+	t.Parallel()
+	log := utils.NewTestLogger()
+
+	newCtx := func(query string) (*gin.Context, *httptest.ResponseRecorder) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest(http.MethodGet, "/urgencies/geo?"+query, nil)
+		return ctx, w
+	}
+
+	t.Run("it returns status 400 for an incomplete query", func(t *testing.T) {
+		ctx, w := newCtx("lat=43.4&lng=22.66")
+		NewUrgencyHandler(log, nil).ListUrgenciesInArea(ctx)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("it returns status 400 for non-numeric values", func(t *testing.T) {
+		ctx, w := newCtx("lat=north&lng=22.66&radiusKm=5")
+		NewUrgencyHandler(log, nil).ListUrgenciesInArea(ctx)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("it returns urgencies within the radius", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx("lat=43.4&lng=22.66&radiusKm=5")
+		svc := NewMockUrgencyService(ctrl)
+		distance := 1.2
+		svc.EXPECT().ListUrgenciesInArea(gomock.Any(), gomock.Any(), false).DoAndReturn(func(_ context.Context, q urgencyV1.UrgencyGeoQuery, _ bool) (*urgencyV1.UrgencyList, error) {
+			assert.Equal(t, 5.0, *q.RadiusKm)
+			return &urgencyV1.UrgencyList{Urgencies: []urgencyV1.UrgencyResponse{{ID: 7, DistanceKm: &distance}}, Truncated: true}, nil
+		})
+		NewUrgencyHandler(log, svc).ListUrgenciesInArea(ctx)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "\"distanceKm\":1.2")
+		assert.Contains(t, w.Body.String(), "\"truncated\":true")
+	})
+}
+
+func TestUrgencyHandler_SuggestResponders(t *testing.T) {
+	t.Parallel()
+	log := utils.NewTestLogger()
+
+	t.Run("it returns status 400 for invalid urgency ID", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = []gin.Param{{Key: "id", Value: "x"}}
+		NewUrgencyHandler(log, nil).SuggestResponders(ctx)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("it returns ranked responders", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = []gin.Param{{Key: "id", Value: "1"}}
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().SuggestResponders(gomock.Any(), uint(1)).Return([]urgencyV1.ResponderSuggestion{{EmployeeID: 3, FirstName: "Near"}}, nil)
+		NewUrgencyHandler(log, svc).SuggestResponders(ctx)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "\"responders\":[{\"employeeId\":3")
+	})
+
+	t.Run("it returns status 404 when urgency does not exist", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = []gin.Param{{Key: "id", Value: "9"}}
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().SuggestResponders(gomock.Any(), uint(9)).Return(nil, commonv1.NewAppError("URGENCY_ERRORS.NOT_FOUND", "urgency not found", nil))
+		NewUrgencyHandler(log, svc).SuggestResponders(ctx)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestUrgencyHandler_ListEscalations(t *testing.T) {
+	t.Parallel()
+	log := utils.NewTestLogger()
+
+	t.Run("it returns status 400 for invalid urgency ID", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = []gin.Param{{Key: "id", Value: "0"}}
+		NewUrgencyHandler(log, nil).ListEscalations(ctx)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("it returns escalation history", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = []gin.Param{{Key: "id", Value: "1"}}
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().ListEscalations(gomock.Any(), uint(1)).Return([]model.UrgencyEscalation{{UrgencyID: 1, Step: model.EscalationWiden, Action: "widen_to_next_shift", NotifiedCount: 3}}, nil)
+		NewUrgencyHandler(log, svc).ListEscalations(ctx)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "\"action\":\"widen_to_next_shift\"")
+		assert.Contains(t, w.Body.String(), "\"notifiedCount\":3")
+	})
+}
+
+func TestUrgencyHandler_GetTimeline(t *testing.T) {
+	t.Parallel()
+	log := utils.NewTestLogger()
+
+	t.Run("it returns status 400 for invalid urgency ID", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = []gin.Param{{Key: "id", Value: "abc"}}
+		NewUrgencyHandler(log, nil).GetTimeline(ctx)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("it returns status 404 when urgency does not exist", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = []gin.Param{{Key: "id", Value: "5"}}
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().GetTimeline(gomock.Any(), uint(5)).Return(nil, commonv1.NewAppError("URGENCY_ERRORS.NOT_FOUND", "urgency not found", nil))
+		NewUrgencyHandler(log, svc).GetTimeline(ctx)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("it returns the timeline", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = []gin.Param{{Key: "id", Value: "1"}}
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().GetTimeline(gomock.Any(), uint(1)).Return(&urgencyV1.UrgencyTimelineResponse{
+			UrgencyID: 1,
+			Entries:   []urgencyV1.TimelineEntry{{Type: "created", Source: "urgency", OccurredAt: "2025-01-01T10:00:00Z"}},
+			Partial:   true,
+		}, nil)
+		NewUrgencyHandler(log, svc).GetTimeline(ctx)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "\"type\":\"created\"")
+		assert.Contains(t, w.Body.String(), "\"partial\":true")
+	})
+}
+
+func TestUrgencyHandler_StatusChange(t *testing.T) {
+	t.Parallel()
+	log := utils.NewTestLogger()
+
+	newCtx := func(body string) (*gin.Context, *httptest.ResponseRecorder) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = []gin.Param{{Key: "id", Value: "1"}}
+		ctx.Request = httptest.NewRequest(http.MethodPut, "/urgencies/1/resolve", strings.NewReader(body))
+		ctx.Request.Header.Set("Content-Type", "application/json")
+		ctx.Set("employeeID", uint(5))
+		ctx.Set("role", "Medic")
+		return ctx, w
+	}
+
+	t.Run("it returns status 400 when the note is missing", func(t *testing.T) {
+		ctx, w := newCtx(`{"note":"  "}`)
+		NewUrgencyHandler(log, NewMockUrgencyService(gomock.NewController(t))).ResolveUrgency(ctx)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("it resolves the urgency", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx(`{"note":"handed over to ambulance"}`)
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().ResolveUrgency(gomock.Any(), uint(1), uint(5), false, "handed over to ambulance").Return(nil)
+		NewUrgencyHandler(log, svc).ResolveUrgency(ctx)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("it returns status 409 for invalid transitions", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx(`{"note":"still missing"}`)
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().ReopenUrgency(gomock.Any(), uint(1), uint(5), false, "still missing").
+			Return(commonv1.NewAppError("URGENCY_ERRORS.INVALID_TRANSITION", "cannot change status", nil))
+		NewUrgencyHandler(log, svc).ReopenUrgency(ctx)
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "INVALID_TRANSITION")
+	})
+
+	t.Run("it returns status 403 when the actor may not cancel", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx(`{"note":"false alarm"}`)
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().CancelUrgency(gomock.Any(), uint(1), uint(5), false, "false alarm").
+			Return(commonv1.NewAppError("AUTH_ERRORS.FORBIDDEN", "only assignee or admin can cancel", nil))
+		NewUrgencyHandler(log, svc).CancelUrgency(ctx)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestUrgencyHandler_MergeUrgency(t *testing.T) {
+	t.Parallel()
+	log := utils.NewTestLogger()
+
+	newCtx := func(id, body string) (*gin.Context, *httptest.ResponseRecorder) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = []gin.Param{{Key: "id", Value: id}}
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/admin/urgencies/"+id+"/merge", strings.NewReader(body))
+		ctx.Request.Header.Set("Content-Type", "application/json")
+		return ctx, w
+	}
+
+	t.Run("it returns status 400 for an invalid ID or body", func(t *testing.T) {
+		handler := NewUrgencyHandler(log, NewMockUrgencyService(gomock.NewController(t)))
+
+		ctx, w := newCtx("abc", `{"sourceId":2}`)
+		handler.MergeUrgency(ctx)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		ctx, w = newCtx("1", `{}`)
+		handler.MergeUrgency(ctx)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("it merges the duplicate into the urgency", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx("1", `{"sourceId":2}`)
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().MergeUrgency(gomock.Any(), uint(1), uint(2)).Return(&urgencyV1.UrgencyMergeResponse{UrgencyID: 1, MergedID: 2, ActivitiesMoved: 1}, nil)
+		NewUrgencyHandler(log, svc).MergeUrgency(ctx)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "\"mergedId\":2")
+	})
+
+	t.Run("it returns status 409 when the duplicate cannot be cancelled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx("1", `{"sourceId":2}`)
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().MergeUrgency(gomock.Any(), uint(1), uint(2)).
+			Return(nil, commonv1.NewAppError("URGENCY_ERRORS.INVALID_TRANSITION", "cannot change status", nil))
+		NewUrgencyHandler(log, svc).MergeUrgency(ctx)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestUrgencyHandler_GetStats(t *testing.T) {
+	t.Parallel()
+	log := utils.NewTestLogger()
+
+	newCtx := func(rawQuery string) (*gin.Context, *httptest.ResponseRecorder) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest(http.MethodGet, "/urgencies/stats?"+rawQuery, nil)
+		return ctx, w
+	}
+
+	t.Run("it returns status 400 for an invalid range", func(t *testing.T) {
+		ctx, w := newCtx("from=2025-02-01&to=2025-01-01")
+		NewUrgencyHandler(log, NewMockUrgencyService(gomock.NewController(t))).GetStats(ctx)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("it returns the stats", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx("from=2025-01-01&to=2025-01-31")
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().GetStats(gomock.Any(), urgencyV1.UrgencyStatsQuery{From: "2025-01-01", To: "2025-01-31"}).
+			Return(&urgencyV1.UrgencyStatsResponse{Total: 3, SLA: urgencyV1.SLAStats{Breaches: 1}}, nil)
+		NewUrgencyHandler(log, svc).GetStats(ctx)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "\"total\":3")
+		assert.Contains(t, w.Body.String(), "\"breaches\":1")
+	})
+
+	t.Run("it returns status 500 when stats cannot be loaded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx("")
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().GetStats(gomock.Any(), gomock.Any()).
+			Return(nil, commonv1.NewAppError("URGENCY_ERRORS.LIST_FAILED", "failed to load urgency statistics", nil))
+		NewUrgencyHandler(log, svc).GetStats(ctx)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestUrgencyHandler_PreviewNotificationTemplate(t *testing.T) {
+	t.Parallel()
+	log := utils.NewTestLogger()
+
+	newCtx := func(rawQuery string) (*gin.Context, *httptest.ResponseRecorder) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest(http.MethodGet, "/admin/notification-templates/preview?"+rawQuery, nil)
+		return ctx, w
+	}
+
+	t.Run("it returns status 400 when the type is missing or unknown", func(t *testing.T) {
+		for _, q := range []string{"event=closed", "type=push&event=closed", "type=sms&event=closed&locale=de"} {
+			ctx, w := newCtx(q)
+			NewUrgencyHandler(log, NewMockUrgencyService(gomock.NewController(t))).PreviewNotificationTemplate(ctx)
+			assert.Equal(t, http.StatusBadRequest, w.Code, q)
+		}
+	})
+
+	t.Run("it returns the rendered template", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx("type=email&event=closed&locale=sr-cyr")
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().PreviewNotificationTemplate(gomock.Any(), urgencyV1.NotificationTemplatePreviewQuery{Type: "email", Event: "closed", Locale: "sr-cyr"}).
+			Return(&urgencyV1.NotificationTemplatePreviewResponse{Type: "email", Event: "closed", Locale: "sr-cyr", Subject: "s", Body: "b"}, nil)
+		NewUrgencyHandler(log, svc).PreviewNotificationTemplate(ctx)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"locale":"sr-cyr"`)
+		assert.Contains(t, w.Body.String(), `"subject":"s"`)
+	})
+
+	t.Run("it returns status 400 for an unknown event", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx("type=sms&event=lunch")
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().PreviewNotificationTemplate(gomock.Any(), gomock.Any()).
+			Return(nil, commonv1.NewAppError("VALIDATION.INVALID_REQUEST", "unknown notification event", nil))
+		NewUrgencyHandler(log, svc).PreviewNotificationTemplate(ctx)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestUrgencyHandler_Notifications(t *testing.T) {
+	t.Parallel()
+	log := utils.NewTestLogger()
+
+	newCtx := func(method string, id string, role string) (*gin.Context, *httptest.ResponseRecorder) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = []gin.Param{{Key: "id", Value: id}}
+		ctx.Request = httptest.NewRequest(method, "/notifications", nil)
+		ctx.Set("employeeID", uint(5))
+		ctx.Set("role", role)
+		return ctx, w
+	}
+
+	t.Run("it lists the notifications of an urgency", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx(http.MethodGet, "1", "Medic")
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().ListUrgencyNotifications(gomock.Any(), uint(1), uint(5), false).Return([]urgencyV1.NotificationResponse{{ID: 2, Status: "failed", Attempts: 5, ErrorMessage: "gateway down"}}, nil)
+		NewUrgencyHandler(log, svc).ListUrgencyNotifications(ctx)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"errorMessage":"gateway down"`)
+	})
+
+	t.Run("it returns status 400 for an invalid urgency ID", func(t *testing.T) {
+		ctx, w := newCtx(http.MethodGet, "abc", "Medic")
+		NewUrgencyHandler(log, NewMockUrgencyService(gomock.NewController(t))).ListUrgencyNotifications(ctx)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("it passes the caller to the employee history and maps forbidden to 403", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx(http.MethodGet, "6", "Medic")
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().ListEmployeeNotifications(gomock.Any(), uint(6), uint(5), false).Return(nil, commonv1.NewAppError("AUTH_ERRORS.FORBIDDEN", "forbidden", nil))
+		NewUrgencyHandler(log, svc).ListEmployeeNotifications(ctx)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("it returns the employee history to an admin", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx(http.MethodGet, "6", "Administrator")
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().ListEmployeeNotifications(gomock.Any(), uint(6), uint(5), true).Return([]urgencyV1.NotificationResponse{}, nil)
+		NewUrgencyHandler(log, svc).ListEmployeeNotifications(ctx)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"notifications":[]}`, w.Body.String())
+	})
+
+	t.Run("it retries a failed notification", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx(http.MethodPost, "9", "Administrator")
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().RetryNotification(gomock.Any(), uint(9)).Return(&urgencyV1.NotificationResponse{ID: 9, Status: "pending"}, nil)
+		NewUrgencyHandler(log, svc).RetryNotification(ctx)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"status":"pending"`)
+	})
+
+	t.Run("it maps retry errors to 404 and 409", func(t *testing.T) {
+		for code, status := range map[string]int{
+			"URGENCY_ERRORS.NOTIFICATION_NOT_FOUND":  http.StatusNotFound,
+			"URGENCY_ERRORS.NOTIFICATION_NOT_FAILED": http.StatusConflict,
+		} {
+			ctrl := gomock.NewController(t)
+			ctx, w := newCtx(http.MethodPost, "9", "Administrator")
+			svc := NewMockUrgencyService(ctrl)
+			svc.EXPECT().RetryNotification(gomock.Any(), uint(9)).Return(nil, commonv1.NewAppError(code, "x", nil))
+			NewUrgencyHandler(log, svc).RetryNotification(ctx)
+			assert.Equal(t, status, w.Code, code)
+		}
+	})
+}
+
+func TestUrgencyHandler_Team(t *testing.T) {
+	t.Parallel()
+	log := utils.NewTestLogger()
+
+	newCtx := func(method string, params []gin.Param, body string) (*gin.Context, *httptest.ResponseRecorder) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = params
+		ctx.Request = httptest.NewRequest(method, "/urgencies/1/team", strings.NewReader(body))
+		ctx.Request.Header.Set("Content-Type", "application/json")
+		ctx.Set("employeeID", uint(5))
+		ctx.Set("role", "Medic")
+		return ctx, w
+	}
+	urgencyParam := []gin.Param{{Key: "id", Value: "1"}}
+
+	t.Run("it returns the current team", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx(http.MethodGet, urgencyParam, "")
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().GetTeam(gomock.Any(), uint(1)).Return(&urgencyV1.UrgencyTeamResponse{UrgencyID: 1, Members: []urgencyV1.TeamMemberResponse{{EmployeeID: 5, Role: urgencyV1.RoleLead}}}, nil)
+		NewUrgencyHandler(log, svc).GetTeam(ctx)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"role":"lead"`)
+	})
+
+	t.Run("it returns status 404 when the urgency does not exist", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx(http.MethodGet, urgencyParam, "")
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().GetTeam(gomock.Any(), uint(1)).Return(nil, commonv1.NewAppError("URGENCY_ERRORS.NOT_FOUND", "urgency not found", nil))
+		NewUrgencyHandler(log, svc).GetTeam(ctx)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("it returns status 400 for an invalid role", func(t *testing.T) {
+		ctx, w := newCtx(http.MethodPost, urgencyParam, `{"employeeId":6,"role":"driver"}`)
+		NewUrgencyHandler(log, NewMockUrgencyService(gomock.NewController(t))).AddTeamMember(ctx)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("it adds a member and returns the team", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx(http.MethodPost, urgencyParam, `{"employeeId":6,"role":"medic"}`)
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().AddTeamMember(gomock.Any(), uint(1), uint(5), false, urgencyV1.TeamMemberRequest{EmployeeID: 6, Role: urgencyV1.RoleMedic}).Return(nil)
+		svc.EXPECT().GetTeam(gomock.Any(), uint(1)).Return(&urgencyV1.UrgencyTeamResponse{UrgencyID: 1}, nil)
+		NewUrgencyHandler(log, svc).AddTeamMember(ctx)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("it returns status 409 when the employee is already on the team", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx(http.MethodPost, urgencyParam, `{"employeeId":6,"role":"medic"}`)
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().AddTeamMember(gomock.Any(), uint(1), uint(5), false, gomock.Any()).Return(commonv1.NewAppError("URGENCY_ERRORS.ALREADY_ON_TEAM", "already on team", nil))
+		NewUrgencyHandler(log, svc).AddTeamMember(ctx)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("it returns status 400 for an invalid employee ID on removal", func(t *testing.T) {
+		ctx, w := newCtx(http.MethodDelete, []gin.Param{{Key: "id", Value: "1"}, {Key: "employeeId", Value: "x"}}, "")
+		NewUrgencyHandler(log, nil).RemoveTeamMember(ctx)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("it removes a member", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx(http.MethodDelete, []gin.Param{{Key: "id", Value: "1"}, {Key: "employeeId", Value: "6"}}, "")
+		ctx.Set("role", "Administrator")
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().RemoveTeamMember(gomock.Any(), uint(1), uint(6), uint(5), true).Return(nil)
+		NewUrgencyHandler(log, svc).RemoveTeamMember(ctx)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("it returns status 404 when the employee is not on the team", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx(http.MethodDelete, []gin.Param{{Key: "id", Value: "1"}, {Key: "employeeId", Value: "6"}}, "")
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().RemoveTeamMember(gomock.Any(), uint(1), uint(6), uint(5), false).Return(commonv1.NewAppError("URGENCY_ERRORS.NOT_ON_TEAM", "not on team", nil))
+		NewUrgencyHandler(log, svc).RemoveTeamMember(ctx)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestUrgencyHandler_GetEmployeeActiveUrgencies(t *testing.T) {
+	t.Parallel()
+	log := utils.NewTestLogger()
+
+	t.Run("it returns status 400 for an invalid employee ID", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = []gin.Param{{Key: "id", Value: "0"}}
+		NewUrgencyHandler(log, nil).GetEmployeeActiveUrgencies(ctx)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("it returns the active urgencies", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = []gin.Param{{Key: "id", Value: "7"}}
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().GetEmployeeActiveUrgencies(gomock.Any(), uint(7)).Return(&urgencyV1.EmployeeActiveUrgenciesResponse{EmployeeID: 7, HasActive: true, UrgencyIDs: []uint{3}}, nil)
+		NewUrgencyHandler(log, svc).GetEmployeeActiveUrgencies(ctx)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"urgencyIds":[3]`)
+	})
+}
+
+func TestUrgencyHandler_Tracking(t *testing.T) {
+	t.Parallel()
+	log := utils.NewTestLogger()
+
+	newCtx := func(method, body string) (*gin.Context, *httptest.ResponseRecorder) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = []gin.Param{{Key: "token", Value: "tok"}}
+		ctx.Request = httptest.NewRequest(method, "/urgencies/track/tok", strings.NewReader(body))
+		ctx.Request.Header.Set("Content-Type", "application/json")
+		return ctx, w
+	}
+
+	t.Run("it returns the public status", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx(http.MethodGet, "")
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().GetTrackingStatus(gomock.Any(), "tok").Return(&urgencyV1.UrgencyTrackingResponse{Status: urgencyV1.InProgress, TeamSize: 2}, nil)
+		NewUrgencyHandler(log, svc).GetTrackingStatus(ctx)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"teamSize":2`)
+	})
+
+	t.Run("it returns status 404 for an unknown token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx(http.MethodGet, "")
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().GetTrackingStatus(gomock.Any(), "tok").Return(nil, commonv1.NewAppError("URGENCY_ERRORS.NOT_FOUND", "urgency not found", nil))
+		NewUrgencyHandler(log, svc).GetTrackingStatus(ctx)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("it stores a follow-up", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx(http.MethodPost, `{"description":"we moved below the ridge"}`)
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().AddFollowUp(gomock.Any(), "tok", urgencyV1.UrgencyFollowUpRequest{Description: "we moved below the ridge"}).Return(&urgencyV1.UrgencyFollowUpResponse{ID: 3}, nil)
+		NewUrgencyHandler(log, svc).AddFollowUp(ctx)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("it returns status 409 for a follow-up on a finished urgency", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx(http.MethodPost, `{"description":"still here"}`)
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().AddFollowUp(gomock.Any(), "tok", gomock.Any()).Return(nil, commonv1.NewAppError("URGENCY_ERRORS.INVALID_TRANSITION", "finished", nil))
+		NewUrgencyHandler(log, svc).AddFollowUp(ctx)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("it returns status 400 for invalid json", func(t *testing.T) {
+		ctx, w := newCtx(http.MethodPost, `{`)
+		NewUrgencyHandler(log, nil).AddFollowUp(ctx)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestUrgencyHandler_Intake(t *testing.T) {
+	t.Parallel()
+	log := utils.NewTestLogger()
+
+	newCtx := func(method, path string, params gin.Params, body string) (*gin.Context, *httptest.ResponseRecorder) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = params
+		ctx.Request = httptest.NewRequest(method, path, strings.NewReader(body))
+		ctx.Request.Header.Set("Content-Type", "application/json")
+		return ctx, w
+	}
+	tokenParams := gin.Params{{Key: "token", Value: "tok"}}
+
+	t.Run("it returns status 429 with Retry-After when the phone is over its limit", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx(http.MethodPost, "/urgencies", nil, `{"firstName":"Marko","lastName":"Markovic","contactPhone":"+381641234567","location":"N 43.401123 E 22.662756","description":"Injured hiker","level":"low"}`)
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().CreateUrgency(gomock.Any(), gomock.Any()).Return(commonv1.NewAppError("URGENCY_ERRORS.RATE_LIMITED", "too many reports", map[string]interface{}{"retryAfterSeconds": 120}))
+		NewUrgencyHandler(log, svc).CreateUrgency(ctx)
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "120", w.Header().Get("Retry-After"))
+	})
+
+	t.Run("it verifies the phone", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx(http.MethodPost, "/urgencies/track/tok/verify", tokenParams, `{"code":"123456"}`)
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().VerifyPhone(gomock.Any(), "tok", urgencyV1.UrgencyVerificationRequest{Code: "123456"}).Return(nil)
+		NewUrgencyHandler(log, svc).VerifyPhone(ctx)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("it returns status 400 for a wrong code and for invalid json", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx(http.MethodPost, "/urgencies/track/tok/verify", tokenParams, `{"code":"654321"}`)
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().VerifyPhone(gomock.Any(), "tok", gomock.Any()).Return(commonv1.NewAppError("URGENCY_ERRORS.VERIFICATION_FAILED", "wrong verification code", nil))
+		NewUrgencyHandler(log, svc).VerifyPhone(ctx)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		ctx, w = newCtx(http.MethodPost, "/urgencies/track/tok/verify", tokenParams, `{`)
+		NewUrgencyHandler(log, nil).VerifyPhone(ctx)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("it resends the code", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx(http.MethodPost, "/urgencies/track/tok/verify/resend", tokenParams, "")
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().ResendVerificationCode(gomock.Any(), "tok").Return(nil)
+		NewUrgencyHandler(log, svc).ResendVerificationCode(ctx)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("it lists the review queue", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx(http.MethodGet, "/admin/urgencies/review", nil, "")
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().ListIntakeReview(gomock.Any()).Return(&urgencyV1.IntakeReviewList{Urgencies: []urgencyV1.UrgencyResponse{{ID: 4, IntakeStatus: urgencyV1.IntakeQuarantined}}}, nil)
+		NewUrgencyHandler(log, svc).ListIntakeReview(ctx)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"intakeStatus":"quarantined"`)
+	})
+
+	t.Run("it releases a report and returns status 409 when it is not in review", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().ReleaseIntake(gomock.Any(), uint(4), uint(9), true).Return(nil)
+		svc.EXPECT().ReleaseIntake(gomock.Any(), uint(5), uint(9), true).Return(commonv1.NewAppError("URGENCY_ERRORS.INVALID_TRANSITION", "urgency is not waiting for review", nil))
+		handler := NewUrgencyHandler(log, svc)
+
+		ctx, w := newCtx(http.MethodPost, "/admin/urgencies/4/release", gin.Params{{Key: "id", Value: "4"}}, "")
+		ctx.Set("employeeID", uint(9))
+		ctx.Set("role", "Administrator")
+		handler.ReleaseIntake(ctx)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		ctx, w = newCtx(http.MethodPost, "/admin/urgencies/5/release", gin.Params{{Key: "id", Value: "5"}}, "")
+		ctx.Set("employeeID", uint(9))
+		ctx.Set("role", "Administrator")
+		handler.ReleaseIntake(ctx)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		ctx, w = newCtx(http.MethodPost, "/admin/urgencies/x/release", gin.Params{{Key: "id", Value: "x"}}, "")
+		handler.ReleaseIntake(ctx)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("it rejects a report with a reason", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx(http.MethodPost, "/admin/urgencies/4/reject", gin.Params{{Key: "id", Value: "4"}}, `{"note":"prank call"}`)
+		ctx.Set("employeeID", uint(9))
+		ctx.Set("role", "Administrator")
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().RejectIntake(gomock.Any(), uint(4), uint(9), true, "prank call").Return(nil)
+		NewUrgencyHandler(log, svc).RejectIntake(ctx)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+}
+
+func TestUrgencyHandler_Attachments(t *testing.T) {
+	t.Parallel()
+	log := utils.NewTestLogger()
+
+	newUpload := func(t *testing.T, path string, params gin.Params, fields map[string]string, content string) (*gin.Context, *httptest.ResponseRecorder) {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		for k, v := range fields {
+			assert.NoError(t, mw.WriteField(k, v))
+		}
+		if content != "" {
+			fw, err := mw.CreateFormFile("file", "ankle.jpg")
+			assert.NoError(t, err)
+			_, _ = fw.Write([]byte(content))
+		}
+		assert.NoError(t, mw.Close())
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = params
+		ctx.Request = httptest.NewRequest(http.MethodPost, path, &body)
+		ctx.Request.Header.Set("Content-Type", mw.FormDataContentType())
+		ctx.Set("employeeID", uint(5))
+		return ctx, w
+	}
+	idParams := gin.Params{{Key: "id", Value: "1"}}
+	attachmentParams := gin.Params{{Key: "id", Value: "1"}, {Key: "attachmentId", Value: "2"}}
+
+	t.Run("it uploads a file for an activity of the urgency", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newUpload(t, "/urgencies/1/attachments", idParams, map[string]string{"activityId": "3"}, "photo")
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().UploadAttachment(gomock.Any(), uint(1), uint(5), false, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _, _ uint, _ bool, upload AttachmentUpload) (*urgencyV1.AttachmentResponse, error) {
+				assert.Equal(t, "ankle.jpg", upload.FileName)
+				assert.Equal(t, uint(3), *upload.ActivityID)
+				content, _ := io.ReadAll(upload.Body)
+				assert.Equal(t, "photo", string(content))
+				return &urgencyV1.AttachmentResponse{ID: 2, UrgencyID: 1}, nil
+			})
+		NewUrgencyHandler(log, svc).UploadAttachment(ctx)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"id":2`)
+	})
+
+	t.Run("it returns status 400 without a file or with an invalid activity", func(t *testing.T) {
+		ctx, w := newUpload(t, "/urgencies/1/attachments", idParams, nil, "")
+		NewUrgencyHandler(log, nil).UploadAttachment(ctx)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		ctx, w = newUpload(t, "/urgencies/1/attachments", idParams, map[string]string{"activityId": "x"}, "photo")
+		NewUrgencyHandler(log, nil).UploadAttachment(ctx)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("it maps rejected files to 413 and 415", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().UploadAttachment(gomock.Any(), uint(1), uint(5), false, gomock.Any()).Return(nil, commonv1.NewAppError("URGENCY_ERRORS.ATTACHMENT_TOO_LARGE", "too large", nil))
+		svc.EXPECT().UploadAttachment(gomock.Any(), uint(1), uint(5), false, gomock.Any()).Return(nil, commonv1.NewAppError("URGENCY_ERRORS.UNSUPPORTED_ATTACHMENT_TYPE", "unsupported", nil))
+
+		ctx, w := newUpload(t, "/urgencies/1/attachments", idParams, nil, "photo")
+		NewUrgencyHandler(log, svc).UploadAttachment(ctx)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+		ctx, w = newUpload(t, "/urgencies/1/attachments", idParams, nil, "photo")
+		NewUrgencyHandler(log, svc).UploadAttachment(ctx)
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+
+	t.Run("it uploads a photo from the reporter", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newUpload(t, "/urgencies/track/tok/attachments", gin.Params{{Key: "token", Value: "tok"}}, nil, "photo")
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().UploadReporterAttachment(gomock.Any(), "tok", gomock.Any()).Return(&urgencyV1.AttachmentResponse{ID: 2}, nil)
+		NewUrgencyHandler(log, svc).UploadReporterAttachment(ctx)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("it returns status 413 before reading an oversized body", func(t *testing.T) {
+		ctx, w := newUpload(t, "/urgencies/track/tok/attachments", gin.Params{{Key: "token", Value: "tok"}}, nil, strings.Repeat("x", attachmentFormOverhead+64))
+		NewUrgencyHandlerWithUploadLimit(log, nil, 32).UploadReporterAttachment(ctx)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Contains(t, w.Body.String(), "URGENCY_ERRORS.ATTACHMENT_TOO_LARGE")
+	})
+
+	t.Run("it lists the attachments of one activity", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = idParams
+		ctx.Request = httptest.NewRequest(http.MethodGet, "/urgencies/1/attachments?activityId=3", nil)
+		ctx.Set("employeeID", uint(5))
+		ctx.Set("role", "Administrator")
+		svc := NewMockUrgencyService(ctrl)
+		activityID := uint(3)
+		svc.EXPECT().ListAttachments(gomock.Any(), uint(1), uint(5), true, &activityID).Return([]urgencyV1.AttachmentResponse{{ID: 2}}, nil)
+		NewUrgencyHandler(log, svc).ListAttachments(ctx)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"attachments":[{"id":2`)
+	})
+
+	t.Run("it streams the file as a download", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = attachmentParams
+		ctx.Request = httptest.NewRequest(http.MethodGet, "/urgencies/1/attachments/2", nil)
+		ctx.Set("employeeID", uint(5))
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().OpenAttachment(gomock.Any(), uint(1), uint(2), uint(5), false).Return(
+			&urgencyV1.AttachmentResponse{ID: 2, FileName: "ankle photo.jpg", ContentType: "image/jpeg", Size: 5}, io.NopCloser(strings.NewReader("photo")), nil)
+		NewUrgencyHandler(log, svc).DownloadAttachment(ctx)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "photo", w.Body.String())
+		assert.Equal(t, "image/jpeg", w.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="ankle photo.jpg"`, w.Header().Get("Content-Disposition"))
+		assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+	})
+
+	t.Run("it returns status 403 when the employee may not see the attachment", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = attachmentParams
+		ctx.Request = httptest.NewRequest(http.MethodGet, "/urgencies/1/attachments/2", nil)
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().OpenAttachment(gomock.Any(), uint(1), uint(2), uint(0), false).Return(nil, nil, commonv1.NewAppError("AUTH_ERRORS.FORBIDDEN", "forbidden", nil))
+		NewUrgencyHandler(log, svc).DownloadAttachment(ctx)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("it deletes an attachment", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Params = attachmentParams
+		ctx.Request = httptest.NewRequest(http.MethodDelete, "/urgencies/1/attachments/2", nil)
+		ctx.Set("employeeID", uint(5))
+		svc := NewMockUrgencyService(ctrl)
+		svc.EXPECT().DeleteAttachment(gomock.Any(), uint(1), uint(2), uint(5), false).Return(nil)
+		NewUrgencyHandler(log, svc).DeleteAttachment(ctx)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		w = httptest.NewRecorder()
+		ctx, _ = gin.CreateTestContext(w)
+		ctx.Params = gin.Params{{Key: "id", Value: "1"}, {Key: "attachmentId", Value: "x"}}
+		ctx.Request = httptest.NewRequest(http.MethodDelete, "/urgencies/1/attachments/x", nil)
+		NewUrgencyHandler(log, nil).DeleteAttachment(ctx)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,342 @@
+package internal
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"math"
+	"regexp"
+	"time"
+
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	"github.com/pd120424d/mountain-service/api/shared/ratelimit"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/notifier"
+)
+
+// maxCodeSendsPerTTL limits how many verification codes one phone can be sent within the code lifetime
+const maxCodeSendsPerTTL = 3
+
+// IntakePolicy guards the public report endpoint. Reports from one contact phone are limited, reports that
+// look like abuse are quarantined for admin review, and low and medium reports can be held back until the
+// reporter confirms the phone with a one-time code. High and critical reports are never delayed by verification.
+type IntakePolicy struct {
+	// Limiter counts reports per contact phone; nil disables the phone limits
+	Limiter ratelimit.Limiter
+	// PhoneLimit refuses reports from the same phone beyond this many per PhoneWindow
+	PhoneLimit  int
+	PhoneWindow time.Duration
+	// SuspectPhoneReports quarantines new incidents from the same phone beyond this many per PhoneWindow
+	SuspectPhoneReports int
+
+	VerifyPhone     bool
+	CodeTTL         time.Duration
+	MaxCodeAttempts int
+	// CodeSender delivers one-time codes; verification is skipped without it
+	CodeSender notifier.SMSSender
+}
+
+// DefaultIntakePolicy returns the thresholds used when no overrides are configured.
+// Phone limits and verification stay off until a limiter and a code sender are provided.
+func DefaultIntakePolicy() IntakePolicy {
+	return IntakePolicy{
+		PhoneLimit:          5,
+		PhoneWindow:         time.Hour,
+		SuspectPhoneReports: 3,
+		CodeTTL:             10 * time.Minute,
+		MaxCodeAttempts:     5,
+	}
+}
+
+// linkPattern finds links, which genuine reports practically never contain but spam always does
+var linkPattern = regexp.MustCompile(`(?i)\b(https?://|www\.)`)
+
+// checkPhoneLimit refuses the report once its contact phone went over the hard limit. A failing limiter
+// lets the report through; a lost emergency costs more than a missed flood.
+func (s *urgencyService) checkPhoneLimit(ctx context.Context, urgency *model.Urgency) error {
+	p := s.intake
+	phone := normalizePhone(urgency.ContactPhone)
+	if p.Limiter == nil || p.PhoneLimit <= 0 || phone == "" {
+		return nil
+	}
+	allowed, retryAfter, err := p.Limiter.Allow(ctx, "intake-phone:"+phone, p.PhoneLimit, p.PhoneWindow)
+	if err != nil {
+		s.log.WithContext(ctx).Errorf("Phone rate limiter failed, accepting report: %v", err)
+		return nil
+	}
+	if !allowed {
+		s.log.WithContext(ctx).Warnf("Too many reports from phone %s, refusing report", urgency.ContactPhone)
+		return commonv1.NewAppError("URGENCY_ERRORS.RATE_LIMITED", "too many reports from this phone, try again later",
+			map[string]interface{}{"retryAfterSeconds": int(math.Ceil(retryAfter.Seconds()))})
+	}
+	return nil
+}
+
+// screenIntake sets the intake status of a new incident. Suspicious reports are quarantined, low and medium
+// reports wait for phone verification when it is enabled, and everything else is accepted. It returns the
+// one-time code to send when verification is required.
+func (s *urgencyService) screenIntake(ctx context.Context, urgency *model.Urgency) (string, error) {
+	if reason := s.suspicionReason(ctx, urgency); reason != "" {
+		urgency.IntakeStatus, urgency.IntakeReason = urgencyV1.IntakeQuarantined, reason
+		return "", nil
+	}
+	if !s.requiresVerification(urgency) {
+		urgency.IntakeStatus = urgencyV1.IntakeAccepted
+		return "", nil
+	}
+	code, err := model.NewVerificationCode()
+	if err != nil {
+		return "", err
+	}
+	expiresAt := time.Now().UTC().Add(s.intake.CodeTTL)
+	urgency.IntakeStatus = urgencyV1.IntakePendingVerification
+	urgency.VerificationCodeHash = model.HashVerificationCode(urgency.TrackingTokenHash, code)
+	urgency.VerificationExpiresAt = &expiresAt
+	return code, nil
+}
+
+func (s *urgencyService) suspicionReason(ctx context.Context, urgency *model.Urgency) string {
+	for _, text := range []string{urgency.Description, urgency.Location, urgency.FirstName, urgency.LastName} {
+		if linkPattern.MatchString(text) {
+			return "report contains a link"
+		}
+	}
+	p := s.intake
+	phone := normalizePhone(urgency.ContactPhone)
+	if p.Limiter == nil || p.SuspectPhoneReports <= 0 || phone == "" {
+		return ""
+	}
+	allowed, _, err := p.Limiter.Allow(ctx, "intake-suspect:"+phone, p.SuspectPhoneReports, p.PhoneWindow)
+	if err != nil {
+		s.log.WithContext(ctx).Errorf("Phone rate limiter failed, skipping repeated report check: %v", err)
+		return ""
+	}
+	if !allowed {
+		return "repeated reports of different incidents from the same phone"
+	}
+	return ""
+}
+
+func (s *urgencyService) requiresVerification(urgency *model.Urgency) bool {
+	if !s.intake.VerifyPhone || s.intake.CodeSender == nil || normalizePhone(urgency.ContactPhone) == "" {
+		return false
+	}
+	// An unset level is stored with the database default, which is not above medium
+	return urgency.Level != urgencyV1.High && urgency.Level != urgencyV1.Critical
+}
+
+func (s *urgencyService) sendVerificationCode(ctx context.Context, urgency *model.Urgency, code string) error {
+	minutes := int(math.Ceil(s.intake.CodeTTL.Minutes()))
+	message := fmt.Sprintf("Mountain service: your verification code is %s. It is valid for %d minutes.", code, minutes)
+	if err := s.intake.CodeSender.SendSMS(ctx, urgency.ContactPhone, message); err != nil {
+		s.log.WithContext(ctx).Errorf("Failed to send verification code for urgency %d: %v", urgency.ID, err)
+		return err
+	}
+	return nil
+}
+
+// acceptUnverified lets a report through when its verification code cannot be delivered, since the
+// reporter would otherwise have no way to reach responders
+func (s *urgencyService) acceptUnverified(ctx context.Context, urgency *model.Urgency) error {
+	log := s.log.WithContext(ctx)
+	event := &model.UrgencyEvent{Type: model.UrgencyEventIntakeReleased, Reason: "verification code could not be sent"}
+	moved, err := s.repo.UpdateIntake(ctx, urgency.ID, []urgencyV1.IntakeStatus{urgencyV1.IntakePendingVerification}, urgencyV1.IntakeAccepted, event)
+	if err != nil {
+		// The report stays in the review queue, where an admin can still release it
+		log.Errorf("Failed to accept unverified urgency %d: %v", urgency.ID, err)
+		return nil
+	}
+	if !moved {
+		return nil
+	}
+	urgency.IntakeStatus = urgencyV1.IntakeAccepted
+	return s.notifyOnCall(ctx, urgency)
+}
+
+// VerifyPhone checks the one-time code sent to the reporter and lets the report through to responders.
+// Too many wrong codes move the report to the review queue instead of dropping it.
+func (s *urgencyService) VerifyPhone(ctx context.Context, token string, req urgencyV1.UrgencyVerificationRequest) error {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.VerifyPhone")()
+
+	if err := req.Validate(); err != nil {
+		return commonv1.NewAppError("VALIDATION.INVALID_REQUEST", err.Error(), nil)
+	}
+	urg, err := s.urgencyByTrackingToken(ctx, token)
+	if err != nil {
+		return err
+	}
+	if urg.IntakeAccepted() {
+		return nil
+	}
+	if urg.IntakeStatus != urgencyV1.IntakePendingVerification {
+		return notPendingVerificationError(urg)
+	}
+	if urg.VerificationExpired(time.Now().UTC()) {
+		return commonv1.NewAppError("URGENCY_ERRORS.VERIFICATION_EXPIRED", "verification code expired, request a new one", nil)
+	}
+
+	hash := model.HashVerificationCode(urg.TrackingTokenHash, req.Code)
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(urg.VerificationCodeHash)) != 1 {
+		attempts, err := s.repo.RecordVerificationAttempt(ctx, urg.ID)
+		if err != nil {
+			log.Errorf("Failed to record verification attempt for urgency %d: %v", urg.ID, err)
+			return commonv1.NewAppError("URGENCY_ERRORS.DB_ERROR", "failed to verify code", map[string]interface{}{"cause": err.Error()})
+		}
+		left := s.intake.MaxCodeAttempts - attempts
+		if left > 0 {
+			return commonv1.NewAppError("URGENCY_ERRORS.VERIFICATION_FAILED", "wrong verification code", map[string]interface{}{"attemptsLeft": left})
+		}
+		event := &model.UrgencyEvent{Type: model.UrgencyEventIntakeQuarantined, Reason: "too many wrong verification codes"}
+		if _, err := s.repo.UpdateIntake(ctx, urg.ID, []urgencyV1.IntakeStatus{urgencyV1.IntakePendingVerification}, urgencyV1.IntakeQuarantined, event); err != nil {
+			log.Errorf("Failed to quarantine urgency %d after wrong codes: %v", urg.ID, err)
+			return commonv1.NewAppError("URGENCY_ERRORS.DB_ERROR", "failed to verify code", map[string]interface{}{"cause": err.Error()})
+		}
+		log.Warnf("Urgency %d quarantined after %d wrong verification codes", urg.ID, attempts)
+		return commonv1.NewAppError("URGENCY_ERRORS.VERIFICATION_FAILED", "too many wrong verification codes, the report was passed to staff for review", map[string]interface{}{"attemptsLeft": 0})
+	}
+
+	moved, err := s.repo.UpdateIntake(ctx, urg.ID, []urgencyV1.IntakeStatus{urgencyV1.IntakePendingVerification}, urgencyV1.IntakeAccepted, &model.UrgencyEvent{Type: model.UrgencyEventIntakeVerified})
+	if err != nil {
+		log.Errorf("Failed to accept verified urgency %d: %v", urg.ID, err)
+		return commonv1.NewAppError("URGENCY_ERRORS.UPDATE_FAILED", "failed to verify code", map[string]interface{}{"cause": err.Error()})
+	}
+	if !moved {
+		// a concurrent request already verified or quarantined the report
+		return nil
+	}
+	log.Infof("Reporter verified phone for urgency %d", urg.ID)
+	urg.IntakeStatus = urgencyV1.IntakeAccepted
+	return s.notifyOnCall(ctx, urg)
+}
+
+// ResendVerificationCode replaces the one-time code of a report that still waits for verification
+func (s *urgencyService) ResendVerificationCode(ctx context.Context, token string) error {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.ResendVerificationCode")()
+
+	urg, err := s.urgencyByTrackingToken(ctx, token)
+	if err != nil {
+		return err
+	}
+	if urg.IntakeStatus != urgencyV1.IntakePendingVerification {
+		return notPendingVerificationError(urg)
+	}
+	if s.intake.Limiter != nil {
+		allowed, retryAfter, err := s.intake.Limiter.Allow(ctx, "intake-code:"+normalizePhone(urg.ContactPhone), maxCodeSendsPerTTL, s.intake.CodeTTL)
+		if err != nil {
+			log.Errorf("Code resend limiter failed, sending code: %v", err)
+		} else if !allowed {
+			return commonv1.NewAppError("URGENCY_ERRORS.RATE_LIMITED", "too many verification codes requested, try again later",
+				map[string]interface{}{"retryAfterSeconds": int(math.Ceil(retryAfter.Seconds()))})
+		}
+	}
+
+	code, err := model.NewVerificationCode()
+	if err != nil {
+		log.Errorf("Failed to generate verification code: %v", err)
+		return commonv1.NewAppError("URGENCY_ERRORS.UPDATE_FAILED", "failed to send a new code", map[string]interface{}{"cause": err.Error()})
+	}
+	set, err := s.repo.SetVerificationCode(ctx, urg.ID, model.HashVerificationCode(urg.TrackingTokenHash, code), time.Now().UTC().Add(s.intake.CodeTTL))
+	if err != nil {
+		log.Errorf("Failed to store verification code for urgency %d: %v", urg.ID, err)
+		return commonv1.NewAppError("URGENCY_ERRORS.UPDATE_FAILED", "failed to send a new code", map[string]interface{}{"cause": err.Error()})
+	}
+	if !set {
+		return notPendingVerificationError(urg)
+	}
+	if s.intake.CodeSender == nil {
+		return s.acceptUnverified(ctx, urg)
+	}
+	if err := s.sendVerificationCode(ctx, urg, code); err != nil {
+		return s.acceptUnverified(ctx, urg)
+	}
+	return nil
+}
+
+// ListIntakeReview returns the reports held back from responders, oldest first
+func (s *urgencyService) ListIntakeReview(ctx context.Context) (*urgencyV1.IntakeReviewList, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.ListIntakeReview")()
+
+	urgencies, err := s.repo.ListIntakeReview(ctx)
+	if err != nil {
+		log.Errorf("Failed to list intake review queue: %v", err)
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.LIST_FAILED", "failed to list reports waiting for review", map[string]interface{}{"cause": err.Error()})
+	}
+	resp := &urgencyV1.IntakeReviewList{Urgencies: make([]urgencyV1.UrgencyResponse, 0, len(urgencies))}
+	for _, u := range urgencies {
+		resp.Urgencies = append(resp.Urgencies, u.ToResponse())
+	}
+	return resp, nil
+}
+
+// ReleaseIntake lets a quarantined or unverified report through to responders and notifies the on-call employees
+func (s *urgencyService) ReleaseIntake(ctx context.Context, urgencyID uint, actorID uint, isAdmin bool) error {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.ReleaseIntake")()
+
+	if !isAdmin {
+		return commonv1.NewAppError("AUTH_ERRORS.FORBIDDEN", "only admin can release reports", nil)
+	}
+	urg, err := s.GetUrgencyByIDPrimary(ctx, urgencyID)
+	if err != nil {
+		return err
+	}
+	event := &model.UrgencyEvent{Type: model.UrgencyEventIntakeReleased, ActorID: &actorID}
+	moved, err := s.repo.UpdateIntake(ctx, urgencyID, reviewableIntake(), urgencyV1.IntakeAccepted, event)
+	if err != nil {
+		log.Errorf("Failed to release urgency %d: %v", urgencyID, err)
+		return commonv1.NewAppError("URGENCY_ERRORS.UPDATE_FAILED", "failed to release urgency", map[string]interface{}{"cause": err.Error()})
+	}
+	if !moved {
+		return notInReviewError(urg)
+	}
+	log.Infof("Employee %d released urgency %d from intake review", actorID, urgencyID)
+	urg.IntakeStatus = urgencyV1.IntakeAccepted
+	return s.notifyOnCall(ctx, urg)
+}
+
+// RejectIntake discards a quarantined or unverified report; the urgency is cancelled with the reason
+// and responders are never notified. A report somebody already took over cannot be rejected
+func (s *urgencyService) RejectIntake(ctx context.Context, urgencyID uint, actorID uint, isAdmin bool, reason string) error {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.RejectIntake")()
+
+	if !isAdmin {
+		return commonv1.NewAppError("AUTH_ERRORS.FORBIDDEN", "only admin can reject reports", nil)
+	}
+	urg, err := s.GetUrgencyByIDPrimary(ctx, urgencyID)
+	if err != nil {
+		return err
+	}
+	event := &model.UrgencyEvent{Type: model.UrgencyEventIntakeRejected, ActorID: &actorID, Reason: reason}
+	moved, err := s.repo.UpdateIntake(ctx, urgencyID, reviewableIntake(), urgencyV1.IntakeRejected, event)
+	if err != nil {
+		log.Errorf("Failed to reject urgency %d: %v", urgencyID, err)
+		return commonv1.NewAppError("URGENCY_ERRORS.UPDATE_FAILED", "failed to reject urgency", map[string]interface{}{"cause": err.Error()})
+	}
+	if !moved {
+		return notInReviewError(urg)
+	}
+	log.Infof("Employee %d rejected urgency %d", actorID, urgencyID)
+	return nil
+}
+
+func reviewableIntake() []urgencyV1.IntakeStatus {
+	return []urgencyV1.IntakeStatus{urgencyV1.IntakePendingVerification, urgencyV1.IntakeQuarantined}
+}
+
+func notPendingVerificationError(urg *model.Urgency) error {
+	return commonv1.NewAppError("URGENCY_ERRORS.INVALID_TRANSITION", "urgency is not waiting for verification", map[string]interface{}{"intakeStatus": urg.IntakeStatus})
+}
+
+func notInReviewError(urg *model.Urgency) error {
+	return commonv1.NewAppError("URGENCY_ERRORS.INVALID_TRANSITION", "urgency is not waiting for review", map[string]interface{}{"intakeStatus": urg.IntakeStatus})
+}
+
+func intakeNotAcceptedError(urg *model.Urgency) error {
+	return commonv1.NewAppError("URGENCY_ERRORS.INVALID_TRANSITION", "urgency has not been let through to responders yet", map[string]interface{}{"intakeStatus": urg.IntakeStatus})
+}
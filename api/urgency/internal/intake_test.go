@@ -0,0 +1,313 @@
+package internal
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
+	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	"github.com/pd120424d/mountain-service/api/shared/ratelimit"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/clients"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/notifier"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/repositories"
+)
+
+type intakeMocks struct {
+	repo *repositories.MockUrgencyRepository
+	emp  *clients.MockEmployeeClient
+	sms  *notifier.MockSMSSender
+}
+
+func newIntakeService(t *testing.T, configure func(p *IntakePolicy)) (UrgencyService, intakeMocks) {
+	ctrl := gomock.NewController(t)
+	m := intakeMocks{
+		repo: repositories.NewMockUrgencyRepository(ctrl),
+		emp:  clients.NewMockEmployeeClient(ctrl),
+		sms:  notifier.NewMockSMSSender(ctrl),
+	}
+	opts := DefaultUrgencyServiceOptions()
+	opts.Duplicates.Enabled = false
+	opts.Intake.CodeSender = m.sms
+	if configure != nil {
+		configure(&opts.Intake)
+	}
+	return NewUrgencyServiceWithOptions(utils.NewTestLogger(), m.repo, repositories.NewMockNotificationRepository(ctrl), m.emp, nil, opts), m
+}
+
+func TestUrgencyService_CreateUrgency_Intake(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it refuses reports over the phone limit", func(t *testing.T) {
+		svc, m := newIntakeService(t, func(p *IntakePolicy) {
+			p.Limiter = ratelimit.NewMemoryLimiter()
+			p.PhoneLimit = 1
+			p.SuspectPhoneReports = 0
+		})
+		m.repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		m.emp.EXPECT().GetOnCallEmployees(gomock.Any(), gomock.Any()).Return([]employeeV1.EmployeeResponse{}, nil)
+
+		require.NoError(t, svc.CreateUrgency(context.Background(), &model.Urgency{ContactPhone: "+381 64 111"}))
+		err := svc.CreateUrgency(context.Background(), &model.Urgency{ContactPhone: "+38164111"})
+		assert.Equal(t, "URGENCY_ERRORS.RATE_LIMITED", appErrorCode(t, err))
+		assert.Positive(t, err.(*commonv1.AppError).Details["retryAfterSeconds"])
+	})
+
+	t.Run("it quarantines reports with links without notifying anyone", func(t *testing.T) {
+		svc, m := newIntakeService(t, nil)
+		m.repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u *model.Urgency) error {
+			assert.Equal(t, urgencyV1.IntakeQuarantined, u.IntakeStatus)
+			u.ID = 7
+			return nil
+		})
+		m.repo.EXPECT().CreateEvent(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, e *model.UrgencyEvent) error {
+			assert.Equal(t, uint(7), e.UrgencyID)
+			assert.Equal(t, model.UrgencyEventIntakeQuarantined, e.Type)
+			return nil
+		})
+
+		urg := &model.Urgency{ContactPhone: "064111", Description: "cheap pills at https://spam.example"}
+		require.NoError(t, svc.CreateUrgency(context.Background(), urg))
+		assert.Equal(t, "report contains a link", urg.IntakeReason)
+	})
+
+	t.Run("it quarantines repeated incidents from the same phone", func(t *testing.T) {
+		svc, m := newIntakeService(t, func(p *IntakePolicy) {
+			p.Limiter = ratelimit.NewMemoryLimiter()
+			p.SuspectPhoneReports = 1
+		})
+		m.repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		m.emp.EXPECT().GetOnCallEmployees(gomock.Any(), gomock.Any()).Return([]employeeV1.EmployeeResponse{}, nil)
+		m.repo.EXPECT().CreateEvent(gomock.Any(), gomock.Any()).Return(nil)
+
+		require.NoError(t, svc.CreateUrgency(context.Background(), &model.Urgency{ContactPhone: "064111"}))
+		second := &model.Urgency{ContactPhone: "064111"}
+		require.NoError(t, svc.CreateUrgency(context.Background(), second))
+		assert.Equal(t, urgencyV1.IntakeQuarantined, second.IntakeStatus)
+	})
+
+	t.Run("it holds low reports until the phone is verified", func(t *testing.T) {
+		svc, m := newIntakeService(t, func(p *IntakePolicy) { p.VerifyPhone = true })
+		var stored model.Urgency
+		m.repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u *model.Urgency) error {
+			stored = *u
+			return nil
+		})
+		var code string
+		m.sms.EXPECT().SendSMS(gomock.Any(), "064111", gomock.Any()).DoAndReturn(func(_ context.Context, _, message string) error {
+			code = regexp.MustCompile(`\d{6}`).FindString(message)
+			return nil
+		})
+
+		urg := &model.Urgency{ContactPhone: "064111", Level: urgencyV1.Low}
+		require.NoError(t, svc.CreateUrgency(context.Background(), urg))
+		assert.Equal(t, urgencyV1.IntakePendingVerification, stored.IntakeStatus)
+		require.NotEmpty(t, code)
+		assert.Equal(t, model.HashVerificationCode(stored.TrackingTokenHash, code), stored.VerificationCodeHash)
+		assert.False(t, stored.VerificationExpired(time.Now()))
+		assert.Equal(t, urgencyV1.IntakePendingVerification, urg.ToResponse().IntakeStatus)
+	})
+
+	t.Run("it lets reports through when the code cannot be sent", func(t *testing.T) {
+		svc, m := newIntakeService(t, func(p *IntakePolicy) { p.VerifyPhone = true })
+		m.repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u *model.Urgency) error {
+			u.ID = 3
+			return nil
+		})
+		m.sms.EXPECT().SendSMS(gomock.Any(), gomock.Any(), gomock.Any()).Return(assert.AnError)
+		m.repo.EXPECT().UpdateIntake(gomock.Any(), uint(3), []urgencyV1.IntakeStatus{urgencyV1.IntakePendingVerification}, urgencyV1.IntakeAccepted, gomock.Any()).Return(true, nil)
+		m.emp.EXPECT().GetOnCallEmployees(gomock.Any(), gomock.Any()).Return([]employeeV1.EmployeeResponse{}, nil)
+
+		urg := &model.Urgency{ContactPhone: "064111", Level: urgencyV1.Medium}
+		require.NoError(t, svc.CreateUrgency(context.Background(), urg))
+		assert.Equal(t, urgencyV1.IntakeAccepted, urg.IntakeStatus)
+	})
+
+	t.Run("it never delays high and critical reports", func(t *testing.T) {
+		svc, m := newIntakeService(t, func(p *IntakePolicy) { p.VerifyPhone = true })
+		m.repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u *model.Urgency) error {
+			assert.Equal(t, urgencyV1.IntakeAccepted, u.IntakeStatus)
+			return nil
+		})
+		m.emp.EXPECT().GetOnCallEmployees(gomock.Any(), gomock.Any()).Return([]employeeV1.EmployeeResponse{}, nil)
+
+		require.NoError(t, svc.CreateUrgency(context.Background(), &model.Urgency{ContactPhone: "064111", Level: urgencyV1.Critical}))
+	})
+}
+
+func TestUrgencyService_VerifyPhone(t *testing.T) {
+	t.Parallel()
+	tokenHash := model.HashTrackingToken("tok")
+	pending := func() model.Urgency {
+		expiresAt := time.Now().Add(5 * time.Minute)
+		return model.Urgency{ID: 1, ContactPhone: "064111", TrackingTokenHash: tokenHash, IntakeStatus: urgencyV1.IntakePendingVerification,
+			VerificationCodeHash: model.HashVerificationCode(tokenHash, "123456"), VerificationExpiresAt: &expiresAt}
+	}
+
+	t.Run("it accepts the report and notifies the on-call employees", func(t *testing.T) {
+		svc, m := newIntakeService(t, nil)
+		expectTrackedUrgency(m.repo, "tok", pending())
+		m.repo.EXPECT().UpdateIntake(gomock.Any(), uint(1), []urgencyV1.IntakeStatus{urgencyV1.IntakePendingVerification}, urgencyV1.IntakeAccepted, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uint, _ []urgencyV1.IntakeStatus, _ urgencyV1.IntakeStatus, e *model.UrgencyEvent) (bool, error) {
+				assert.Equal(t, model.UrgencyEventIntakeVerified, e.Type)
+				return true, nil
+			})
+		m.emp.EXPECT().GetOnCallEmployees(gomock.Any(), gomock.Any()).Return([]employeeV1.EmployeeResponse{}, nil)
+
+		require.NoError(t, svc.VerifyPhone(context.Background(), "tok", urgencyV1.UrgencyVerificationRequest{Code: "123456"}))
+	})
+
+	t.Run("it counts wrong codes and quarantines after the last attempt", func(t *testing.T) {
+		svc, m := newIntakeService(t, func(p *IntakePolicy) { p.MaxCodeAttempts = 2 })
+		expectTrackedUrgency(m.repo, "tok", pending())
+		m.repo.EXPECT().RecordVerificationAttempt(gomock.Any(), uint(1)).Return(1, nil)
+
+		err := svc.VerifyPhone(context.Background(), "tok", urgencyV1.UrgencyVerificationRequest{Code: "654321"})
+		assert.Equal(t, "URGENCY_ERRORS.VERIFICATION_FAILED", appErrorCode(t, err))
+		assert.Equal(t, 1, err.(*commonv1.AppError).Details["attemptsLeft"])
+
+		expectTrackedUrgency(m.repo, "tok", pending())
+		m.repo.EXPECT().RecordVerificationAttempt(gomock.Any(), uint(1)).Return(2, nil)
+		m.repo.EXPECT().UpdateIntake(gomock.Any(), uint(1), gomock.Any(), urgencyV1.IntakeQuarantined, gomock.Any()).Return(true, nil)
+
+		err = svc.VerifyPhone(context.Background(), "tok", urgencyV1.UrgencyVerificationRequest{Code: "654321"})
+		assert.Equal(t, "URGENCY_ERRORS.VERIFICATION_FAILED", appErrorCode(t, err))
+	})
+
+	t.Run("it rejects expired codes and reports that do not wait for verification", func(t *testing.T) {
+		svc, m := newIntakeService(t, nil)
+		expired := pending()
+		past := time.Now().Add(-time.Minute)
+		expired.VerificationExpiresAt = &past
+		expectTrackedUrgency(m.repo, "tok", expired)
+		err := svc.VerifyPhone(context.Background(), "tok", urgencyV1.UrgencyVerificationRequest{Code: "123456"})
+		assert.Equal(t, "URGENCY_ERRORS.VERIFICATION_EXPIRED", appErrorCode(t, err))
+
+		quarantined := pending()
+		quarantined.IntakeStatus = urgencyV1.IntakeQuarantined
+		expectTrackedUrgency(m.repo, "tok", quarantined)
+		err = svc.VerifyPhone(context.Background(), "tok", urgencyV1.UrgencyVerificationRequest{Code: "123456"})
+		assert.Equal(t, "URGENCY_ERRORS.INVALID_TRANSITION", appErrorCode(t, err))
+
+		accepted := pending()
+		accepted.IntakeStatus = urgencyV1.IntakeAccepted
+		expectTrackedUrgency(m.repo, "tok", accepted)
+		assert.NoError(t, svc.VerifyPhone(context.Background(), "tok", urgencyV1.UrgencyVerificationRequest{Code: "123456"}))
+
+		err = svc.VerifyPhone(context.Background(), "tok", urgencyV1.UrgencyVerificationRequest{Code: "12"})
+		assert.Equal(t, "VALIDATION.INVALID_REQUEST", appErrorCode(t, err))
+	})
+}
+
+func TestUrgencyService_ResendVerificationCode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it replaces the code and sends it", func(t *testing.T) {
+		svc, m := newIntakeService(t, func(p *IntakePolicy) { p.Limiter = ratelimit.NewMemoryLimiter() })
+		tokenHash := model.HashTrackingToken("tok")
+		expectTrackedUrgency(m.repo, "tok", model.Urgency{ID: 1, ContactPhone: "064111", TrackingTokenHash: tokenHash, IntakeStatus: urgencyV1.IntakePendingVerification})
+		var storedHash string
+		m.repo.EXPECT().SetVerificationCode(gomock.Any(), uint(1), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ uint, hash string, _ time.Time) (bool, error) {
+			storedHash = hash
+			return true, nil
+		})
+		m.sms.EXPECT().SendSMS(gomock.Any(), "064111", gomock.Any()).DoAndReturn(func(_ context.Context, _, message string) error {
+			assert.Equal(t, model.HashVerificationCode(tokenHash, regexp.MustCompile(`\d{6}`).FindString(message)), storedHash)
+			return nil
+		})
+
+		require.NoError(t, svc.ResendVerificationCode(context.Background(), "tok"))
+	})
+
+	t.Run("it limits how many codes one phone is sent", func(t *testing.T) {
+		svc, m := newIntakeService(t, func(p *IntakePolicy) { p.Limiter = ratelimit.NewMemoryLimiter() })
+		urg := model.Urgency{ID: 1, ContactPhone: "064111", IntakeStatus: urgencyV1.IntakePendingVerification}
+		m.repo.EXPECT().GetByTrackingTokenHash(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ string, u *model.Urgency) error {
+			*u = urg
+			return nil
+		}).Times(maxCodeSendsPerTTL + 1)
+		m.repo.EXPECT().SetVerificationCode(gomock.Any(), uint(1), gomock.Any(), gomock.Any()).Return(true, nil).Times(maxCodeSendsPerTTL)
+		m.sms.EXPECT().SendSMS(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(maxCodeSendsPerTTL)
+
+		for i := 0; i < maxCodeSendsPerTTL; i++ {
+			require.NoError(t, svc.ResendVerificationCode(context.Background(), "tok"))
+		}
+		err := svc.ResendVerificationCode(context.Background(), "tok")
+		assert.Equal(t, "URGENCY_ERRORS.RATE_LIMITED", appErrorCode(t, err))
+	})
+
+	t.Run("it refuses reports that do not wait for verification", func(t *testing.T) {
+		svc, m := newIntakeService(t, nil)
+		expectTrackedUrgency(m.repo, "tok", model.Urgency{ID: 1, IntakeStatus: urgencyV1.IntakeAccepted})
+
+		err := svc.ResendVerificationCode(context.Background(), "tok")
+		assert.Equal(t, "URGENCY_ERRORS.INVALID_TRANSITION", appErrorCode(t, err))
+	})
+}
+
+func TestUrgencyService_IntakeReview(t *testing.T) {
+	t.Parallel()
+	expectUrgency := func(repo *repositories.MockUrgencyRepository, urg model.Urgency) {
+		repo.EXPECT().GetByIDPrimary(gomock.Any(), urg.ID, gomock.Any()).DoAndReturn(func(_ context.Context, _ uint, u *model.Urgency) error {
+			*u = urg
+			return nil
+		})
+	}
+
+	t.Run("it lists the reports waiting for review", func(t *testing.T) {
+		svc, m := newIntakeService(t, nil)
+		m.repo.EXPECT().ListIntakeReview(gomock.Any()).Return([]model.Urgency{{ID: 1, IntakeStatus: urgencyV1.IntakeQuarantined, IntakeReason: "report contains a link"}}, nil)
+
+		resp, err := svc.ListIntakeReview(context.Background())
+		require.NoError(t, err)
+		require.Len(t, resp.Urgencies, 1)
+		assert.Equal(t, "report contains a link", resp.Urgencies[0].IntakeReason)
+	})
+
+	t.Run("it releases a quarantined report and notifies the on-call employees", func(t *testing.T) {
+		svc, m := newIntakeService(t, nil)
+		expectUrgency(m.repo, model.Urgency{ID: 2, IntakeStatus: urgencyV1.IntakeQuarantined})
+		m.repo.EXPECT().UpdateIntake(gomock.Any(), uint(2), reviewableIntake(), urgencyV1.IntakeAccepted, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uint, _ []urgencyV1.IntakeStatus, _ urgencyV1.IntakeStatus, e *model.UrgencyEvent) (bool, error) {
+				assert.Equal(t, model.UrgencyEventIntakeReleased, e.Type)
+				assert.Equal(t, uint(9), *e.ActorID)
+				return true, nil
+			})
+		m.emp.EXPECT().GetOnCallEmployees(gomock.Any(), gomock.Any()).Return([]employeeV1.EmployeeResponse{}, nil)
+
+		require.NoError(t, svc.ReleaseIntake(context.Background(), 2, 9, true))
+		err := svc.ReleaseIntake(context.Background(), 2, 9, false)
+		assert.Equal(t, "AUTH_ERRORS.FORBIDDEN", appErrorCode(t, err))
+	})
+
+	t.Run("it refuses to release a report that is not in review", func(t *testing.T) {
+		svc, m := newIntakeService(t, nil)
+		expectUrgency(m.repo, model.Urgency{ID: 2, IntakeStatus: urgencyV1.IntakeAccepted})
+		m.repo.EXPECT().UpdateIntake(gomock.Any(), uint(2), gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+
+		err := svc.ReleaseIntake(context.Background(), 2, 9, true)
+		assert.Equal(t, "URGENCY_ERRORS.INVALID_TRANSITION", appErrorCode(t, err))
+	})
+
+	t.Run("it rejects a report with the reason", func(t *testing.T) {
+		svc, m := newIntakeService(t, nil)
+		expectUrgency(m.repo, model.Urgency{ID: 2, IntakeStatus: urgencyV1.IntakePendingVerification})
+		m.repo.EXPECT().UpdateIntake(gomock.Any(), uint(2), reviewableIntake(), urgencyV1.IntakeRejected, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uint, _ []urgencyV1.IntakeStatus, _ urgencyV1.IntakeStatus, e *model.UrgencyEvent) (bool, error) {
+				assert.Equal(t, "prank call", e.Reason)
+				return true, nil
+			})
+
+		require.NoError(t, svc.RejectIntake(context.Background(), 2, 9, true, "prank call"))
+		err := svc.RejectIntake(context.Background(), 2, 9, false, "prank call")
+		assert.Equal(t, "AUTH_ERRORS.FORBIDDEN", appErrorCode(t, err))
+	})
+}
@@ -23,6 +23,7 @@ import (
 	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
 	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
 	"github.com/pd120424d/mountain-service/api/shared/auth"
+	"github.com/pd120424d/mountain-service/api/shared/models"
 	"github.com/pd120424d/mountain-service/api/shared/utils"
 	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
 	"github.com/pd120424d/mountain-service/api/urgency/internal/repositories"
@@ -119,8 +120,8 @@ func TestIntegration_UrgencyLifecycle(t *testing.T) {
 		assert.Equal(t, "Service", getResponse.LastName)
 
 		updateReq := urgencyV1.UrgencyUpdateRequest{
-			Status: urgencyV1.InProgress,
-			Email:  "updated@example.com",
+			Level: urgencyV1.High,
+			Email: "updated@example.com",
 		}
 
 		body, _ = json.Marshal(updateReq)
@@ -136,9 +137,20 @@ func TestIntegration_UrgencyLifecycle(t *testing.T) {
 		var updateResponse urgencyV1.UrgencyResponse
 		err = json.Unmarshal(w.Body.Bytes(), &updateResponse)
 		require.NoError(t, err)
-		assert.Equal(t, "in_progress", string(updateResponse.Status))
+		assert.Equal(t, "high", string(updateResponse.Level))
 		assert.Equal(t, "updated@example.com", updateResponse.Email)
 
+		body, _ = json.Marshal(urgencyV1.UrgencyUpdateRequest{Status: urgencyV1.Closed})
+		w = httptest.NewRecorder()
+		req = httptest.NewRequest("PUT", fmt.Sprintf("/api/v1/urgencies/%d", urgencyID), bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", authHeader)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "URGENCY_ERRORS.INVALID_TRANSITION")
+
 		w = httptest.NewRecorder()
 		req = httptest.NewRequest("DELETE", fmt.Sprintf("/api/v1/urgencies/%d", urgencyID), nil)
 		req.Header.Set("Authorization", authHeader)
@@ -276,7 +288,7 @@ func setupIntegrationTest(t *testing.T) (*gin.Engine, *gorm.DB, func()) {
 	db, err := gorm.Open(sqlite.Dialector{Conn: sqlDB}, &gorm.Config{})
 	require.NoError(t, err)
 
-	err = db.AutoMigrate(&model.Urgency{}, &model.Notification{})
+	err = db.AutoMigrate(&model.Urgency{}, &model.Notification{}, &model.UrgencyEscalation{}, &model.UrgencyEvent{}, &model.UrgencyAssignment{}, &model.ReporterFollowUp{}, &model.Attachment{}, &models.OutboxEvent{})
 	require.NoError(t, err)
 
 	log := utils.NewTestLogger()
@@ -288,7 +300,7 @@ func setupIntegrationTest(t *testing.T) (*gin.Engine, *gorm.DB, func()) {
 	// Create a mock employee client for testing
 	mockEmployeeClient := &mockEmployeeClient{}
 
-	svc := NewUrgencyService(log, urgencyRepo, notificationRepo, mockEmployeeClient)
+	svc := NewUrgencyService(log, urgencyRepo, notificationRepo, mockEmployeeClient, nil)
 	urgencyHandler := NewUrgencyHandler(log, svc)
 
 	gin.SetMode(gin.TestMode)
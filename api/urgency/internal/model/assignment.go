@@ -0,0 +1,32 @@
+package model
+
+import (
+	"time"
+
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+)
+
+// UrgencyAssignment is one responder's membership on an urgency team.
+// Leaving sets LeftAt instead of deleting the row so the team history is kept. The active lead always
+// matches Urgency.AssignedEmployeeID.
+type UrgencyAssignment struct {
+	ID         uint                     `gorm:"primaryKey"`
+	UrgencyID  uint                     `gorm:"not null;index:ix_urgency_assignments_urgency_employee"`
+	EmployeeID uint                     `gorm:"not null;index:ix_urgency_assignments_urgency_employee;index"`
+	Role       urgencyV1.AssignmentRole `gorm:"type:text;not null"`
+	AddedBy    *uint
+	JoinedAt   time.Time `gorm:"not null"`
+	LeftAt     *time.Time
+}
+
+func (a *UrgencyAssignment) ToResponse() urgencyV1.TeamMemberResponse {
+	resp := urgencyV1.TeamMemberResponse{
+		EmployeeID: a.EmployeeID,
+		Role:       a.Role,
+		JoinedAt:   a.JoinedAt.Format(time.RFC3339),
+	}
+	if a.LeftAt != nil {
+		resp.LeftAt = a.LeftAt.Format(time.RFC3339)
+	}
+	return resp
+}
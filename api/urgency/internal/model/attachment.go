@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+)
+
+// Attachment is a photo or document stored for an urgency, optionally tied to one of its activities.
+// The file lives in the blob store under BlobName; the row holds what is shown to users.
+type Attachment struct {
+	ID          uint   `gorm:"primaryKey"`
+	UrgencyID   uint   `gorm:"not null;index"`
+	ActivityID  *uint  `gorm:"index"`
+	FileName    string `gorm:"type:text;not null"`
+	ContentType string `gorm:"type:text;not null"`
+	Size        int64  `gorm:"not null"`
+	BlobName    string `gorm:"type:text;not null;uniqueIndex"`
+	UploadedBy  *uint  // nil when the reporter uploaded through the tracking link
+	CreatedAt   time.Time
+}
+
+func (a *Attachment) ToResponse() urgencyV1.AttachmentResponse {
+	return urgencyV1.AttachmentResponse{
+		ID:          a.ID,
+		UrgencyID:   a.UrgencyID,
+		ActivityID:  a.ActivityID,
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		UploadedBy:  a.UploadedBy,
+		CreatedAt:   a.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
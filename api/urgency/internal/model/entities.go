@@ -5,6 +5,7 @@ import (
 	"time"
 
 	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
 	"gorm.io/gorm"
 )
 
@@ -27,8 +28,54 @@ type Urgency struct {
 
 	// SortPriority is a denormalized, indexed field used to implement sorting efficiently.
 	// Note: values are shifted by +1 to avoid zero (GORM zero-value omission with DB defaults).
-	// 1: open & unassigned, 2: open & assigned, 3: in_progress, 4: resolved, 5: closed, 6: other
+	// 1: open & unassigned, 2: open & assigned, 3: in_progress, 4: resolved, 5: closed/cancelled, 6: other
 	SortPriority int `gorm:"not null;default:5;index"`
+
+	// EscalationLevel is the last escalation step fired while the urgency stayed unacknowledged
+	EscalationLevel EscalationStep `gorm:"not null;default:0"`
+	LastEscalatedAt *time.Time
+
+	ResolutionNote string `gorm:"type:text"`
+	ResolvedAt     *time.Time
+	ClosedAt       *time.Time
+
+	// Latitude and Longitude are parsed from Location; nil when it is not in coordinate format
+	Latitude  *float64 `gorm:"index:ix_urgencies_lat_lng"`
+	Longitude *float64 `gorm:"index:ix_urgencies_lat_lng"`
+
+	// DuplicateOfID links a repeated report, or a merged urgency, to the urgency that is being worked on
+	DuplicateOfID *uint `gorm:"index"`
+
+	// TrackingTokenHash identifies the reporter's public tracking link; see NewTrackingToken
+	TrackingTokenHash string `gorm:"size:64;index"`
+	// TrackingToken holds the plain token right after creation so it can be returned once
+	TrackingToken string `gorm:"-"`
+
+	EtaNote      string `gorm:"type:text"`
+	EtaUpdatedAt *time.Time
+
+	// IntakeStatus keeps unverified and suspicious public reports away from responders until they are let through
+	IntakeStatus urgencyV1.IntakeStatus `gorm:"type:text;not null;default:'accepted';index"`
+	IntakeReason string                 `gorm:"type:text"`
+	// VerificationCodeHash holds the one-time code sent to ContactPhone; see HashVerificationCode
+	VerificationCodeHash  string `gorm:"size:64"`
+	VerificationExpiresAt *time.Time
+	VerificationAttempts  int `gorm:"not null;default:0"`
+}
+
+// SyncCoordinates refreshes Latitude and Longitude from the free-text Location
+func (u *Urgency) SyncCoordinates() {
+	lat, lng, err := utils.ParseCoordinates(u.Location)
+	if err != nil {
+		u.Latitude, u.Longitude = nil, nil
+		return
+	}
+	u.Latitude, u.Longitude = &lat, &lng
+}
+
+// Title is the reporter's full name, which is how urgencies are titled in the activity read model
+func (u *Urgency) Title() string {
+	return strings.TrimSpace(strings.TrimSpace(u.FirstName) + " " + strings.TrimSpace(u.LastName))
 }
 
 type (
@@ -45,6 +92,14 @@ const (
 	NotificationEmail NotificationType = "email"
 )
 
+// NotificationReply is the notified employee's answer to an urgency alert
+type NotificationReply string
+
+const (
+	NotificationAccepted NotificationReply = "accepted"
+	NotificationDeclined NotificationReply = "declined"
+)
+
 // Notification represents a notification to be sent to an employee
 type Notification struct {
 	gorm.Model
@@ -52,13 +107,21 @@ type Notification struct {
 	UrgencyID        uint               `gorm:"not null;index"`
 	EmployeeID       uint               `gorm:"not null;index"`
 	NotificationType NotificationType   `gorm:"type:text;not null"`
-	Recipient        string             `gorm:"not null"` // phone or email
+	Recipient        string             `gorm:"not null"`  // phone or email
+	Subject          string             `gorm:"type:text"` // email only; rendered together with Message
 	Message          string             `gorm:"type:text;not null"`
 	Status           NotificationStatus `gorm:"type:text;not null;default:'pending'"`
 	Attempts         int                `gorm:"default:0"`
 	LastAttemptAt    *time.Time
-	SentAt           *time.Time
-	ErrorMessage     string `gorm:"type:text"`
+	// NextAttemptAt holds back the notification while a dispatcher owns it or it waits out a retry backoff
+	NextAttemptAt *time.Time `gorm:"index"`
+	SentAt        *time.Time
+	ErrorMessage  string `gorm:"type:text"`
+
+	// Reply is recorded on every notification the employee received for the urgency
+	Reply         NotificationReply `gorm:"type:text;index"`
+	RepliedAt     *time.Time
+	DeclineReason string `gorm:"type:text"`
 
 	Urgency *Urgency `gorm:"foreignKey:UrgencyID"`
 }
@@ -98,6 +161,27 @@ func (u *Urgency) ToResponse() urgencyV1.UrgencyResponse {
 	if u.AssignedAt != nil {
 		resp.AssignedAt = u.AssignedAt.Format(time.RFC3339)
 	}
+	resp.EscalationLevel = int(u.EscalationLevel)
+	if u.LastEscalatedAt != nil {
+		resp.LastEscalatedAt = u.LastEscalatedAt.Format(time.RFC3339)
+	}
+	resp.ResolutionNote = u.ResolutionNote
+	if u.ResolvedAt != nil {
+		resp.ResolvedAt = u.ResolvedAt.Format(time.RFC3339)
+	}
+	if u.ClosedAt != nil {
+		resp.ClosedAt = u.ClosedAt.Format(time.RFC3339)
+	}
+	resp.Latitude = u.Latitude
+	resp.Longitude = u.Longitude
+	resp.DuplicateOfId = u.DuplicateOfID
+	resp.EtaNote = u.EtaNote
+	if u.EtaUpdatedAt != nil {
+		resp.EtaUpdatedAt = u.EtaUpdatedAt.Format(time.RFC3339)
+	}
+	resp.IntakeStatus = u.IntakeStatus
+	resp.IntakeReason = u.IntakeReason
+	resp.TrackingToken = u.TrackingToken
 	return resp
 }
 
@@ -108,10 +192,13 @@ func (n *Notification) ToResponse() urgencyV1.NotificationResponse {
 		EmployeeID:       n.EmployeeID,
 		NotificationType: string(n.NotificationType),
 		Recipient:        n.Recipient,
+		Subject:          n.Subject,
 		Message:          n.Message,
 		Status:           string(n.Status),
 		Attempts:         n.Attempts,
 		ErrorMessage:     n.ErrorMessage,
+		Reply:            string(n.Reply),
+		DeclineReason:    n.DeclineReason,
 		CreatedAt:        n.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:        n.UpdatedAt.Format(time.RFC3339),
 	}
@@ -124,6 +211,10 @@ func (n *Notification) ToResponse() urgencyV1.NotificationResponse {
 		response.SentAt = n.SentAt.Format(time.RFC3339)
 	}
 
+	if n.RepliedAt != nil {
+		response.RepliedAt = n.RepliedAt.Format(time.RFC3339)
+	}
+
 	return response
 }
 
@@ -152,4 +243,9 @@ func (u *Urgency) UpdateWithRequest(req *urgencyV1.UrgencyUpdateRequest) {
 	if req.Status != "" {
 		u.Status = urgencyV1.UrgencyStatus(req.Status)
 	}
+	if req.EtaNote != "" && req.EtaNote != u.EtaNote {
+		now := time.Now().UTC()
+		u.EtaNote = req.EtaNote
+		u.EtaUpdatedAt = &now
+	}
 }
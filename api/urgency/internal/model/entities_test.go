@@ -1,6 +1,7 @@
 package model
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -91,6 +92,7 @@ func TestNotification_ToResponse(t *testing.T) {
 			EmployeeID:       456,
 			NotificationType: NotificationSMS,
 			Recipient:        "+1234567890",
+			Subject:          "Urgency #123",
 			Message:          "Emergency notification",
 			Status:           NotificationSent,
 			Attempts:         2,
@@ -106,6 +108,7 @@ func TestNotification_ToResponse(t *testing.T) {
 		assert.Equal(t, uint(456), response.EmployeeID)
 		assert.Equal(t, string(NotificationSMS), response.NotificationType)
 		assert.Equal(t, "+1234567890", response.Recipient)
+		assert.Equal(t, "Urgency #123", response.Subject)
 		assert.Equal(t, "Emergency notification", response.Message)
 		assert.Equal(t, string(NotificationSent), response.Status)
 		assert.Equal(t, 2, response.Attempts)
@@ -192,5 +195,134 @@ func TestUrgency_UpdateWithRequest(t *testing.T) {
 		assert.Equal(t, "Updated Description", urgency.Description)
 		assert.Equal(t, urgencyV1.UrgencyLevel(urgencyV1.Critical), urgency.Level)
 		assert.Equal(t, urgencyV1.UrgencyStatus(urgencyV1.InProgress), urgency.Status)
+		assert.Empty(t, urgency.EtaNote)
+		assert.Nil(t, urgency.EtaUpdatedAt)
 	})
+
+	t.Run("it stamps a changed eta note", func(t *testing.T) {
+		urgency := &Urgency{EtaNote: "team leaves the base"}
+
+		urgency.UpdateWithRequest(&urgencyV1.UrgencyUpdateRequest{EtaNote: "team leaves the base"})
+		assert.Nil(t, urgency.EtaUpdatedAt)
+
+		urgency.UpdateWithRequest(&urgencyV1.UrgencyUpdateRequest{EtaNote: "arrival in ~40 min"})
+		assert.Equal(t, "arrival in ~40 min", urgency.EtaNote)
+		assert.NotNil(t, urgency.EtaUpdatedAt)
+		assert.NotEmpty(t, urgency.ToResponse().EtaUpdatedAt)
+	})
+}
+
+func TestNewTrackingToken(t *testing.T) {
+	t.Parallel()
+
+	token, hash, err := NewTrackingToken()
+	assert.NoError(t, err)
+	assert.Len(t, token, 43)
+	assert.NotContains(t, token, "+")
+	assert.NotContains(t, token, "/")
+	assert.Equal(t, HashTrackingToken(token), hash)
+	assert.Len(t, hash, 64)
+
+	other, _, err := NewTrackingToken()
+	assert.NoError(t, err)
+	assert.NotEqual(t, token, other)
+}
+
+func TestVerificationCode(t *testing.T) {
+	t.Parallel()
+
+	code, err := NewVerificationCode()
+	assert.NoError(t, err)
+	assert.Regexp(t, `^[0-9]{6}$`, code)
+
+	hash := HashVerificationCode("salt-a", code)
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, HashVerificationCode("salt-a", code))
+	assert.NotEqual(t, hash, HashVerificationCode("salt-b", code))
+}
+
+func TestUrgency_Intake(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, (&Urgency{}).IntakeAccepted())
+	assert.True(t, (&Urgency{IntakeStatus: urgencyV1.IntakeAccepted}).IntakeAccepted())
+	assert.False(t, (&Urgency{IntakeStatus: urgencyV1.IntakeQuarantined}).IntakeAccepted())
+
+	now := time.Now()
+	later := now.Add(time.Minute)
+	assert.True(t, (&Urgency{}).VerificationExpired(now))
+	assert.False(t, (&Urgency{VerificationExpiresAt: &later}).VerificationExpired(now))
+	assert.True(t, (&Urgency{VerificationExpiresAt: &later}).VerificationExpired(later))
+}
+
+func TestUrgency_SyncCoordinates(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it parses coordinates from location", func(t *testing.T) {
+		urgency := &Urgency{Location: "N 43.401 E 22.662"}
+		urgency.SyncCoordinates()
+
+		if assert.NotNil(t, urgency.Latitude) && assert.NotNil(t, urgency.Longitude) {
+			assert.InDelta(t, 43.401, *urgency.Latitude, 1e-9)
+			assert.InDelta(t, 22.662, *urgency.Longitude, 1e-9)
+		}
+		resp := urgency.ToResponse()
+		assert.Equal(t, urgency.Latitude, resp.Latitude)
+	})
+
+	t.Run("it clears coordinates when location is free text", func(t *testing.T) {
+		lat, lng := 1.0, 2.0
+		urgency := &Urgency{Location: "Below the summit", Latitude: &lat, Longitude: &lng}
+		urgency.SyncCoordinates()
+
+		assert.Nil(t, urgency.Latitude)
+		assert.Nil(t, urgency.Longitude)
+	})
+}
+
+func TestNewOutboxEvent(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it carries the urgency state and title", func(t *testing.T) {
+		at := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		assignee, actor := uint(5), uint(9)
+		urgency := &Urgency{ID: 7, FirstName: " Marko ", LastName: "Markovic", Level: urgencyV1.High, Status: urgencyV1.InProgress, AssignedEmployeeID: &assignee}
+
+		outbox, err := NewOutboxEvent(urgencyV1.EventUrgencyAssigned, urgency, &actor, at)
+		assert.NoError(t, err)
+		assert.Equal(t, "urgency-7", outbox.AggregateID)
+
+		var event urgencyV1.UrgencyEvent
+		assert.NoError(t, json.Unmarshal([]byte(outbox.EventData), &event))
+		assert.Equal(t, urgencyV1.EventUrgencyAssigned, event.Type)
+		assert.Equal(t, "Marko Markovic", event.Title)
+		assert.Equal(t, urgencyV1.High, event.Level)
+		assert.Equal(t, &assignee, event.AssignedEmployeeID)
+		assert.Equal(t, &actor, event.ActorID)
+		assert.True(t, at.Equal(event.OccurredAt))
+	})
+}
+
+func TestOutboxEventType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		history  UrgencyEventType
+		expected urgencyV1.UrgencyEventType
+	}{
+		{UrgencyEventUpdated, urgencyV1.EventUrgencyUpdated},
+		{UrgencyEventResolved, urgencyV1.EventUrgencyUpdated},
+		{UrgencyEventAccepted, urgencyV1.EventUrgencyAssigned},
+		{UrgencyEventUnassigned, urgencyV1.EventUrgencyUnassigned},
+		{UrgencyEventClosed, urgencyV1.EventUrgencyClosed},
+		{UrgencyEventTeamJoined, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.history), func(t *testing.T) {
+			eventType, ok := OutboxEventType(test.history)
+			assert.Equal(t, test.expected, eventType)
+			assert.Equal(t, test.expected != "", ok)
+		})
+	}
 }
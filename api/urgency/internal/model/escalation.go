@@ -0,0 +1,52 @@
+package model
+
+import (
+	"time"
+
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+)
+
+// EscalationStep identifies how far an unacknowledged urgency has been escalated.
+type EscalationStep int
+
+const (
+	EscalationNone     EscalationStep = 0
+	EscalationRenotify EscalationStep = 1 // re-notify the current on-call list
+	EscalationWiden    EscalationStep = 2 // notify the next shift as well
+	EscalationAdmins   EscalationStep = 3 // alert administrators
+)
+
+// MaxEscalationStep is the last step of the escalation ladder
+const MaxEscalationStep = EscalationAdmins
+
+func (s EscalationStep) String() string {
+	switch s {
+	case EscalationRenotify:
+		return "renotify_on_call"
+	case EscalationWiden:
+		return "widen_to_next_shift"
+	case EscalationAdmins:
+		return "alert_admins"
+	default:
+		return "none"
+	}
+}
+
+// UrgencyEscalation is an audit record of a single escalation step fired for an urgency
+type UrgencyEscalation struct {
+	ID            uint           `gorm:"primaryKey"`
+	UrgencyID     uint           `gorm:"not null;index"`
+	Step          EscalationStep `gorm:"not null"`
+	Action        string         `gorm:"type:text;not null"`
+	NotifiedCount int            `gorm:"not null;default:0"`
+	CreatedAt     time.Time
+}
+
+func (e *UrgencyEscalation) ToResponse() urgencyV1.UrgencyEscalationResponse {
+	return urgencyV1.UrgencyEscalationResponse{
+		Step:          int(e.Step),
+		Action:        e.Action,
+		NotifiedCount: e.NotifiedCount,
+		CreatedAt:     e.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"time"
+
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+)
+
+// UrgencyEventType describes which state change produced an urgency event.
+type UrgencyEventType string
+
+const (
+	UrgencyEventUpdated    UrgencyEventType = "updated"
+	UrgencyEventAssigned   UrgencyEventType = "assigned"
+	UrgencyEventAccepted   UrgencyEventType = "accepted"
+	UrgencyEventUnassigned UrgencyEventType = "unassigned"
+	UrgencyEventClosed     UrgencyEventType = "closed"
+	UrgencyEventResolved   UrgencyEventType = "resolved"
+	UrgencyEventReopened   UrgencyEventType = "reopened"
+	UrgencyEventCancelled  UrgencyEventType = "cancelled"
+	// UrgencyEventDuplicateReported is recorded on the original urgency when a repeated report is linked to it
+	UrgencyEventDuplicateReported UrgencyEventType = "duplicate_reported"
+	UrgencyEventMerged            UrgencyEventType = "merged"
+	UrgencyEventTeamJoined        UrgencyEventType = "team_joined"
+	UrgencyEventTeamLeft          UrgencyEventType = "team_left"
+	// Intake events record how a public report left the verification or review step
+	UrgencyEventIntakeVerified    UrgencyEventType = "intake_verified"
+	UrgencyEventIntakeQuarantined UrgencyEventType = "intake_quarantined"
+	UrgencyEventIntakeReleased    UrgencyEventType = "intake_released"
+	UrgencyEventIntakeRejected    UrgencyEventType = "intake_rejected"
+)
+
+// UrgencyEvent is an append-only history record written in the same transaction as the state change.
+// Rows are never updated or deleted by the application.
+type UrgencyEvent struct {
+	ID            uint             `gorm:"primaryKey"`
+	UrgencyID     uint             `gorm:"not null;index"`
+	Type          UrgencyEventType `gorm:"type:text;not null"`
+	ActorID       *uint
+	OldStatus     urgencyV1.UrgencyStatus `gorm:"type:text"`
+	NewStatus     urgencyV1.UrgencyStatus `gorm:"type:text"`
+	OldAssigneeID *uint
+	NewAssigneeID *uint
+	// Reason carries the resolution note or the reopen/cancel reason
+	Reason    string    `gorm:"type:text"`
+	CreatedAt time.Time `gorm:"index"`
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+)
+
+// UrgencyFilter represents filters, search and ordering for listing urgencies
+type UrgencyFilter struct {
+	Page     int
+	PageSize int
+
+	Statuses []urgencyV1.UrgencyStatus
+	Levels   []urgencyV1.UrgencyLevel
+	// From is inclusive and To is exclusive
+	From               *time.Time
+	To                 *time.Time
+	AssignedEmployeeID *uint
+	// TeamMemberID matches urgencies the employee leads or is an active team member of
+	TeamMemberID   *uint
+	UnassignedOnly bool
+	// AcceptedIntakeOnly leaves out reports still held back by the intake checks
+	AcceptedIntakeOnly bool
+	// Query is matched case-insensitively against name, location and description
+	Query string
+	// Sort defaults to SortPriority, or to newest first when listing one employee's urgencies
+	Sort urgencyV1.UrgencySort
+}
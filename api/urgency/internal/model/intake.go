@@ -0,0 +1,40 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"time"
+
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+)
+
+// IntakeAccepted reports whether the urgency has been let through to responders.
+// Urgencies stored before intake checks existed have no status and count as accepted.
+func (u *Urgency) IntakeAccepted() bool {
+	return u.IntakeStatus == "" || u.IntakeStatus == urgencyV1.IntakeAccepted
+}
+
+// VerificationExpired reports whether the one-time code can no longer be used at the given time
+func (u *Urgency) VerificationExpired(at time.Time) bool {
+	return u.VerificationExpiresAt == nil || !at.Before(*u.VerificationExpiresAt)
+}
+
+// NewVerificationCode returns a random numeric one-time code of urgencyV1.VerificationCodeLength digits
+func NewVerificationCode() (string, error) {
+	limit := new(big.Int).Exp(big.NewInt(10), big.NewInt(urgencyV1.VerificationCodeLength), nil)
+	n, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", urgencyV1.VerificationCodeLength, n), nil
+}
+
+// HashVerificationCode returns the hex SHA-256 of the code salted with the urgency's tracking token hash.
+// Codes are short, so the salt keeps one precomputed table from covering every urgency.
+func HashVerificationCode(salt, code string) string {
+	sum := sha256.Sum256([]byte(salt + ":" + code))
+	return hex.EncodeToString(sum[:])
+}
@@ -18,7 +18,7 @@ func ComputeSortPriority(status urgencyV1.UrgencyStatus, assignedEmployeeID *uin
 		return 3
 	case urgencyV1.Resolved:
 		return 4
-	case urgencyV1.Closed:
+	case urgencyV1.Closed, urgencyV1.Cancelled:
 		return 5
 	default:
 		return 6
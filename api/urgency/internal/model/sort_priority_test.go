@@ -22,6 +22,7 @@ func TestComputeSortPriority_FromDBStatusStrings(t *testing.T) {
 		{"in_progress maps to 3", string(urgencyV1.InProgress), nil, 3},
 		{"resolved maps to 4", string(urgencyV1.Resolved), nil, 4},
 		{"closed maps to 5", string(urgencyV1.Closed), nil, 5},
+		{"cancelled maps to 5", string(urgencyV1.Cancelled), nil, 5},
 		{"unknown maps to 6", "something_else", nil, 6},
 	}
 
@@ -0,0 +1,30 @@
+package model
+
+import urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+
+// urgencyTransitions lists the statuses reachable from each status.
+// in_progress may still go straight to closed so the existing close flow keeps working.
+var urgencyTransitions = map[urgencyV1.UrgencyStatus][]urgencyV1.UrgencyStatus{
+	urgencyV1.Open:       {urgencyV1.InProgress, urgencyV1.Cancelled},
+	urgencyV1.InProgress: {urgencyV1.Resolved, urgencyV1.Closed, urgencyV1.Cancelled},
+	urgencyV1.Resolved:   {urgencyV1.Closed, urgencyV1.Open},
+	urgencyV1.Closed:     {urgencyV1.Open},
+	urgencyV1.Cancelled:  {urgencyV1.Open},
+}
+
+// CanTransition reports whether an urgency may move from one status to another.
+func CanTransition(from, to urgencyV1.UrgencyStatus) bool {
+	for _, next := range urgencyTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// CanTransitionViaUpdate reports whether a status change may be made through a plain urgency update.
+// Only resolving without a note is allowed there; assignment, closing, reopening and cancelling have
+// dedicated flows with their own checks.
+func CanTransitionViaUpdate(from, to urgencyV1.UrgencyStatus) bool {
+	return from == urgencyV1.InProgress && to == urgencyV1.Resolved
+}
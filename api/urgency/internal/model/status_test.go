@@ -0,0 +1,49 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+)
+
+func TestCanTransition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		from, to urgencyV1.UrgencyStatus
+		expected bool
+	}{
+		{urgencyV1.Open, urgencyV1.InProgress, true},
+		{urgencyV1.Open, urgencyV1.Cancelled, true},
+		{urgencyV1.Open, urgencyV1.Resolved, false},
+		{urgencyV1.Open, urgencyV1.Closed, false},
+		{urgencyV1.InProgress, urgencyV1.Resolved, true},
+		{urgencyV1.InProgress, urgencyV1.Closed, true},
+		{urgencyV1.InProgress, urgencyV1.Open, false},
+		{urgencyV1.Resolved, urgencyV1.Closed, true},
+		{urgencyV1.Resolved, urgencyV1.Open, true},
+		{urgencyV1.Resolved, urgencyV1.InProgress, false},
+		{urgencyV1.Closed, urgencyV1.Open, true},
+		{urgencyV1.Closed, urgencyV1.InProgress, false},
+		{urgencyV1.Cancelled, urgencyV1.Open, true},
+		{urgencyV1.Cancelled, urgencyV1.Closed, false},
+		{urgencyV1.Closed, urgencyV1.Closed, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(string(tc.from)+"->"+string(tc.to), func(t *testing.T) {
+			assert.Equal(t, tc.expected, CanTransition(tc.from, tc.to))
+		})
+	}
+}
+
+func TestCanTransitionViaUpdate(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, CanTransitionViaUpdate(urgencyV1.InProgress, urgencyV1.Resolved))
+	assert.False(t, CanTransitionViaUpdate(urgencyV1.InProgress, urgencyV1.Closed))
+	assert.False(t, CanTransitionViaUpdate(urgencyV1.Closed, urgencyV1.Open))
+	assert.False(t, CanTransitionViaUpdate(urgencyV1.Open, urgencyV1.Cancelled))
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+)
+
+// trackingTokenBytes gives tracking tokens 256 bits of entropy
+const trackingTokenBytes = 32
+
+// NewTrackingToken returns a random URL-safe tracking token and the hash that is stored in its place
+func NewTrackingToken() (token, hash string, err error) {
+	buf := make([]byte, trackingTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token = base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashTrackingToken(token), nil
+}
+
+// HashTrackingToken returns the hex SHA-256 of a tracking token. Tokens are random, so an unsalted
+// hash is enough to keep a database leak from exposing working links.
+func HashTrackingToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// ReporterFollowUp is additional information the reporter sent through the tracking link.
+// Like activities, follow-ups are only appended and show up in the urgency timeline.
+type ReporterFollowUp struct {
+	ID          uint   `gorm:"primaryKey"`
+	UrgencyID   uint   `gorm:"not null;index"`
+	Location    string `gorm:"type:text"`
+	Description string `gorm:"type:text"`
+	CreatedAt   time.Time
+}
+
+func (f *ReporterFollowUp) ToResponse() urgencyV1.UrgencyFollowUpResponse {
+	return urgencyV1.UrgencyFollowUpResponse{
+		ID:          f.ID,
+		Location:    f.Location,
+		Description: f.Description,
+		CreatedAt:   f.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,168 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	"github.com/pd120424d/mountain-service/api/shared/models"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookSubscription is an external endpoint that receives urgency lifecycle events
+type WebhookSubscription struct {
+	ID     uint   `gorm:"primaryKey"`
+	URL    string `gorm:"type:text;not null"`
+	Secret string `gorm:"type:text;not null"`
+	// EventTypes is a comma separated list of urgencyV1.UrgencyEventType values
+	EventTypes string `gorm:"type:text;not null"`
+	Active     bool   `gorm:"not null;index"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// WebhookDelivery is one event sent to one subscription; the rows double as the delivery log.
+// An event is delivered to a subscription at most once, even if it is fanned out again.
+type WebhookDelivery struct {
+	ID             uint                       `gorm:"primaryKey"`
+	SubscriptionID uint                       `gorm:"not null;uniqueIndex:ux_webhook_deliveries_subscription_event,priority:1"`
+	EventID        uint                       `gorm:"not null;uniqueIndex:ux_webhook_deliveries_subscription_event,priority:2"` // outbox event the delivery was fanned out from
+	EventType      urgencyV1.UrgencyEventType `gorm:"type:text;not null"`
+	Payload        string                     `gorm:"type:text;not null"`
+	Status         WebhookDeliveryStatus      `gorm:"type:text;not null;default:'pending';index:ix_webhook_deliveries_due,priority:1"`
+	Attempts       int                        `gorm:"default:0"`
+	NextAttemptAt  *time.Time                 `gorm:"index:ix_webhook_deliveries_due,priority:2"`
+	LastAttemptAt  *time.Time
+	ResponseStatus int
+	Error          string `gorm:"type:text"`
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+}
+
+// WebhookSubscriptionFromRequest builds a subscription from the admin request
+func WebhookSubscriptionFromRequest(req urgencyV1.WebhookSubscriptionRequest) WebhookSubscription {
+	sub := WebhookSubscription{Active: true}
+	sub.Apply(req)
+	return sub
+}
+
+// Apply replaces the subscription settings with the ones from the request
+func (s *WebhookSubscription) Apply(req urgencyV1.WebhookSubscriptionRequest) {
+	types := make([]string, 0, len(req.EventTypes))
+	for _, t := range req.EventTypes {
+		types = append(types, string(t))
+	}
+	s.URL = req.URL
+	s.Secret = req.Secret
+	s.EventTypes = strings.Join(types, ",")
+	if req.Active != nil {
+		s.Active = *req.Active
+	}
+}
+
+// Events returns the event types the subscription receives
+func (s *WebhookSubscription) Events() []urgencyV1.UrgencyEventType {
+	var out []urgencyV1.UrgencyEventType
+	for _, t := range strings.Split(s.EventTypes, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			out = append(out, urgencyV1.UrgencyEventType(t))
+		}
+	}
+	return out
+}
+
+// Subscribes reports whether the subscription is active and receives the event type
+func (s *WebhookSubscription) Subscribes(eventType urgencyV1.UrgencyEventType) bool {
+	if !s.Active {
+		return false
+	}
+	for _, t := range s.Events() {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *WebhookSubscription) ToResponse() urgencyV1.WebhookSubscriptionResponse {
+	return urgencyV1.WebhookSubscriptionResponse{
+		ID:         s.ID,
+		URL:        s.URL,
+		EventTypes: s.Events(),
+		Active:     s.Active,
+		CreatedAt:  s.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  s.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func (d *WebhookDelivery) ToResponse() urgencyV1.WebhookDeliveryResponse {
+	resp := urgencyV1.WebhookDeliveryResponse{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		ResponseStatus: d.ResponseStatus,
+		Error:          d.Error,
+		CreatedAt:      d.CreatedAt.Format(time.RFC3339),
+	}
+	if d.LastAttemptAt != nil {
+		resp.LastAttemptAt = d.LastAttemptAt.Format(time.RFC3339)
+	}
+	if d.NextAttemptAt != nil {
+		resp.NextAttemptAt = d.NextAttemptAt.Format(time.RFC3339)
+	}
+	if d.DeliveredAt != nil {
+		resp.DeliveredAt = d.DeliveredAt.Format(time.RFC3339)
+	}
+	return resp
+}
+
+// OutboxEventType maps a history event to the lifecycle event published for it, if any.
+// Status changes other than closing are published as updates.
+func OutboxEventType(t UrgencyEventType) (urgencyV1.UrgencyEventType, bool) {
+	switch t {
+	case UrgencyEventUpdated, UrgencyEventResolved, UrgencyEventReopened, UrgencyEventCancelled:
+		return urgencyV1.EventUrgencyUpdated, true
+	case UrgencyEventAssigned, UrgencyEventAccepted:
+		return urgencyV1.EventUrgencyAssigned, true
+	case UrgencyEventUnassigned:
+		return urgencyV1.EventUrgencyUnassigned, true
+	case UrgencyEventClosed:
+		return urgencyV1.EventUrgencyClosed, true
+	default:
+		return "", false
+	}
+}
+
+// NewOutboxEvent builds the outbox row for a lifecycle event of the urgency
+func NewOutboxEvent(eventType urgencyV1.UrgencyEventType, urgency *Urgency, actorID *uint, at time.Time) (*models.OutboxEvent, error) {
+	data, err := json.Marshal(urgencyV1.UrgencyEvent{
+		Type:               eventType,
+		UrgencyID:          urgency.ID,
+		Title:              urgency.Title(),
+		Level:              urgency.Level,
+		Status:             urgency.Status,
+		AssignedEmployeeID: urgency.AssignedEmployeeID,
+		ActorID:            actorID,
+		OccurredAt:         at,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &models.OutboxEvent{
+		AggregateID: fmt.Sprintf("urgency-%d", urgency.ID),
+		EventData:   string(data),
+		CreatedAt:   at,
+	}, nil
+}
@@ -0,0 +1,62 @@
+package internal
+
+import (
+	"context"
+
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
+	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/templates"
+	"gorm.io/gorm"
+)
+
+// previewUrgency and previewRecipient fill templates in admin previews so no real data is exposed
+var (
+	previewUrgency = model.Urgency{
+		Model:        gorm.Model{ID: 42},
+		ID:           42,
+		FirstName:    "Petar",
+		LastName:     "Petrović",
+		ContactPhone: "+381641234567",
+		Location:     "N 43.401123 E 22.662756",
+		Description:  "Povređen planinar kod vrha Midžor",
+		Level:        urgencyV1.High,
+	}
+	previewRecipient = employeeV1.EmployeeResponse{ID: 1, FirstName: "Marko", LastName: "Marković"}
+)
+
+// PreviewNotificationTemplate renders a template with sample data, including any configured overrides
+func (s *urgencyService) PreviewNotificationTemplate(ctx context.Context, query urgencyV1.NotificationTemplatePreviewQuery) (*urgencyV1.NotificationTemplatePreviewResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.PreviewNotificationTemplate")()
+
+	event := templates.Event(query.Event)
+	if !isKnownEvent(event) {
+		return nil, commonv1.NewAppError("VALIDATION.INVALID_REQUEST", "unknown notification event", map[string]interface{}{"event": query.Event})
+	}
+	recipient := previewRecipient
+	recipient.PreferredLanguage = query.Locale
+	msg, err := s.renderNotification(&previewUrgency, recipient, templates.Channel(query.Type), event)
+	if err != nil {
+		log.Errorf("Failed to render %s %s template for locale %q: %v", query.Event, query.Type, query.Locale, err)
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.TEMPLATE_RENDER_FAILED", "failed to render notification template", map[string]interface{}{"cause": err.Error()})
+	}
+	return &urgencyV1.NotificationTemplatePreviewResponse{
+		Type:    query.Type,
+		Event:   query.Event,
+		Locale:  msg.Locale,
+		Subject: msg.Subject,
+		Body:    msg.Body,
+	}, nil
+}
+
+func isKnownEvent(event templates.Event) bool {
+	for _, e := range templates.Events() {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
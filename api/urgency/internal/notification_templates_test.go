@@ -0,0 +1,59 @@
+package internal
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/templates"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUrgencyService_PreviewNotificationTemplate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it renders the template with sample data in the requested locale", func(t *testing.T) {
+		svc := &urgencyService{log: utils.NewTestLogger()}
+
+		resp, err := svc.PreviewNotificationTemplate(context.Background(), urgencyV1.NotificationTemplatePreviewQuery{Type: "email", Event: "new_urgency", Locale: "sr-cyr"})
+		require.NoError(t, err)
+		assert.Equal(t, "sr-cyr", resp.Locale)
+		assert.Equal(t, "Горска служба - хитан случај #42 (висок)", resp.Subject)
+		assert.Contains(t, resp.Body, "Здраво Marko Marković")
+		assert.Contains(t, resp.Body, "Petar Petrović (+381641234567)")
+	})
+
+	t.Run("it falls back to the default locale when none is requested", func(t *testing.T) {
+		svc := &urgencyService{log: utils.NewTestLogger()}
+
+		resp, err := svc.PreviewNotificationTemplate(context.Background(), urgencyV1.NotificationTemplatePreviewQuery{Type: "sms", Event: "escalation"})
+		require.NoError(t, err)
+		assert.Equal(t, "en", resp.Locale)
+		assert.Empty(t, resp.Subject)
+		assert.Contains(t, resp.Body, "STILL UNANSWERED")
+	})
+
+	t.Run("it shows configured overrides", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "sr-lat.tmpl"), []byte(`{{define "closed.sms"}}Akcija #{{.UrgencyID}} završena{{end}}`), 0o644))
+		registry, err := templates.New(dir, utils.LocaleSerbianLatin)
+		require.NoError(t, err)
+		svc := &urgencyService{log: utils.NewTestLogger(), templates: registry}
+
+		resp, err := svc.PreviewNotificationTemplate(context.Background(), urgencyV1.NotificationTemplatePreviewQuery{Type: "sms", Event: "closed"})
+		require.NoError(t, err)
+		assert.Equal(t, "sr-lat", resp.Locale)
+		assert.Equal(t, "Akcija #42 završena", resp.Body)
+	})
+
+	t.Run("it rejects an unknown event", func(t *testing.T) {
+		svc := &urgencyService{log: utils.NewTestLogger()}
+
+		_, err := svc.PreviewNotificationTemplate(context.Background(), urgencyV1.NotificationTemplatePreviewQuery{Type: "sms", Event: "lunch"})
+		assertAppErrorCode(t, err, "VALIDATION.INVALID_REQUEST")
+	})
+}
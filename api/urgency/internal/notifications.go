@@ -0,0 +1,98 @@
+package internal
+
+import (
+	"context"
+	"errors"
+
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
+	"gorm.io/gorm"
+)
+
+// ListUrgencyNotifications returns every notification sent for an urgency with its delivery state, oldest first.
+// The history includes recipients' contact details, so only admins and employees working on the urgency may see it.
+func (s *urgencyService) ListUrgencyNotifications(ctx context.Context, urgencyID, actorID uint, isAdmin bool) ([]urgencyV1.NotificationResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.ListUrgencyNotifications")()
+
+	urg, err := s.GetUrgencyByID(ctx, urgencyID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeResponderAccess(ctx, urg, actorID, isAdmin, "view its notification history"); err != nil {
+		return nil, err
+	}
+	notifications, err := s.notificationRepo.GetByUrgencyID(ctx, urgencyID)
+	if err != nil {
+		log.Errorf("Failed to get notifications for urgency %d: %v", urgencyID, err)
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.DB_ERROR", "failed to fetch notifications", map[string]interface{}{"cause": err.Error()})
+	}
+	return toNotificationResponses(notifications), nil
+}
+
+// ListEmployeeNotifications returns the notifications sent to an employee, newest first.
+// Employees can only see their own history; admins can see anyone's.
+func (s *urgencyService) ListEmployeeNotifications(ctx context.Context, employeeID, actorID uint, isAdmin bool) ([]urgencyV1.NotificationResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.ListEmployeeNotifications")()
+
+	if !isAdmin && employeeID != actorID {
+		return nil, commonv1.NewAppError("AUTH_ERRORS.FORBIDDEN", "only the employee or an admin can view notification history", map[string]interface{}{"employeeId": employeeID})
+	}
+	notifications, err := s.notificationRepo.GetByEmployeeID(ctx, employeeID)
+	if err != nil {
+		log.Errorf("Failed to get notifications for employee %d: %v", employeeID, err)
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.DB_ERROR", "failed to fetch notifications", map[string]interface{}{"cause": err.Error()})
+	}
+	return toNotificationResponses(notifications), nil
+}
+
+// RetryNotification queues a failed notification for delivery again; the dispatcher picks it up on its next run.
+func (s *urgencyService) RetryNotification(ctx context.Context, notificationID uint) (*urgencyV1.NotificationResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.RetryNotification")()
+
+	var notification model.Notification
+	if err := s.notificationRepo.GetByID(ctx, notificationID, &notification); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, commonv1.NewAppError("URGENCY_ERRORS.NOTIFICATION_NOT_FOUND", "notification not found", map[string]interface{}{"notificationId": notificationID})
+		}
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.DB_ERROR", "failed to fetch notification", map[string]interface{}{"cause": err.Error()})
+	}
+	if notification.Status != model.NotificationFailed {
+		return nil, notificationNotFailedError(notification)
+	}
+
+	reset, err := s.notificationRepo.ResetForRetry(ctx, notificationID)
+	if err != nil {
+		log.Errorf("Failed to reset notification %d for retry: %v", notificationID, err)
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.UPDATE_FAILED", "failed to reset notification", map[string]interface{}{"cause": err.Error()})
+	}
+	if !reset {
+		// another admin retried it in the meantime
+		return nil, notificationNotFailedError(notification)
+	}
+	log.Infof("Notification %d for urgency %d queued for retry", notificationID, notification.UrgencyID)
+
+	notification.Status = model.NotificationPending
+	notification.Attempts = 0
+	notification.LastAttemptAt = nil
+	notification.NextAttemptAt = nil
+	notification.ErrorMessage = ""
+	resp := notification.ToResponse()
+	return &resp, nil
+}
+
+func notificationNotFailedError(n model.Notification) error {
+	return commonv1.NewAppError("URGENCY_ERRORS.NOTIFICATION_NOT_FAILED", "only failed notifications can be retried", map[string]interface{}{"notificationId": n.ID, "status": n.Status})
+}
+
+func toNotificationResponses(notifications []model.Notification) []urgencyV1.NotificationResponse {
+	resp := make([]urgencyV1.NotificationResponse, 0, len(notifications))
+	for i := range notifications {
+		resp = append(resp, notifications[i].ToResponse())
+	}
+	return resp
+}
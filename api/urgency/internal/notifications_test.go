@@ -0,0 +1,188 @@
+package internal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/repositories"
+)
+
+func TestUrgencyService_ListUrgencyNotifications(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it returns not found when the urgency does not exist", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		repo.EXPECT().GetByID(gomock.Any(), uint(1), gomock.Any()).Return(gorm.ErrRecordNotFound)
+		svc := &urgencyService{log: utils.NewTestLogger(), repo: repo}
+
+		_, err := svc.ListUrgencyNotifications(context.Background(), 1, 9, true)
+		assertAppErrorCode(t, err, "URGENCY_ERRORS.NOT_FOUND")
+	})
+
+	t.Run("it returns the delivery state of every notification", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		nrepo := repositories.NewMockNotificationRepository(ctrl)
+		repo.EXPECT().GetByID(gomock.Any(), uint(1), gomock.Any()).Return(nil)
+		nrepo.EXPECT().GetByUrgencyID(gomock.Any(), uint(1)).Return([]model.Notification{
+			{ID: 1, UrgencyID: 1, EmployeeID: 5, NotificationType: model.NotificationSMS, Status: model.NotificationSent, Attempts: 1},
+			{ID: 2, UrgencyID: 1, EmployeeID: 5, NotificationType: model.NotificationEmail, Status: model.NotificationFailed, Attempts: 5, ErrorMessage: "mailbox full"},
+		}, nil)
+		svc := &urgencyService{log: utils.NewTestLogger(), repo: repo, notificationRepo: nrepo}
+
+		resp, err := svc.ListUrgencyNotifications(context.Background(), 1, 9, true)
+		require.NoError(t, err)
+		require.Len(t, resp, 2)
+		assert.Equal(t, "sent", resp[0].Status)
+		assert.Equal(t, "failed", resp[1].Status)
+		assert.Equal(t, 5, resp[1].Attempts)
+		assert.Equal(t, "mailbox full", resp[1].ErrorMessage)
+	})
+
+	t.Run("it forbids employees who are not working on the urgency", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		nrepo := repositories.NewMockNotificationRepository(ctrl)
+		repo.EXPECT().GetByID(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, u *model.Urgency) error {
+			*u = model.Urgency{ID: id}
+			return nil
+		})
+		repo.EXPECT().ListTeam(gomock.Any(), uint(1)).Return(nil, nil)
+		nrepo.EXPECT().GetByUrgencyID(gomock.Any(), uint(1)).Return([]model.Notification{{EmployeeID: 5}}, nil)
+		svc := &urgencyService{log: utils.NewTestLogger(), repo: repo, notificationRepo: nrepo}
+
+		_, err := svc.ListUrgencyNotifications(context.Background(), 1, 9, false)
+		assertAppErrorCode(t, err, "AUTH_ERRORS.FORBIDDEN")
+	})
+
+	t.Run("it lets team members see the history", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		nrepo := repositories.NewMockNotificationRepository(ctrl)
+		repo.EXPECT().GetByID(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, u *model.Urgency) error {
+			*u = model.Urgency{ID: id}
+			return nil
+		})
+		repo.EXPECT().ListTeam(gomock.Any(), uint(1)).Return([]model.UrgencyAssignment{{UrgencyID: 1, EmployeeID: 9}}, nil)
+		nrepo.EXPECT().GetByUrgencyID(gomock.Any(), uint(1)).Return([]model.Notification{{ID: 1, UrgencyID: 1, EmployeeID: 5}}, nil)
+		svc := &urgencyService{log: utils.NewTestLogger(), repo: repo, notificationRepo: nrepo}
+
+		resp, err := svc.ListUrgencyNotifications(context.Background(), 1, 9, false)
+		require.NoError(t, err)
+		assert.Len(t, resp, 1)
+	})
+
+	t.Run("it returns a DB error when notifications cannot be loaded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		nrepo := repositories.NewMockNotificationRepository(ctrl)
+		repo.EXPECT().GetByID(gomock.Any(), uint(1), gomock.Any()).Return(nil)
+		nrepo.EXPECT().GetByUrgencyID(gomock.Any(), uint(1)).Return(nil, assert.AnError)
+		svc := &urgencyService{log: utils.NewTestLogger(), repo: repo, notificationRepo: nrepo}
+
+		_, err := svc.ListUrgencyNotifications(context.Background(), 1, 9, true)
+		assertAppErrorCode(t, err, "URGENCY_ERRORS.DB_ERROR")
+	})
+}
+
+func TestUrgencyService_ListEmployeeNotifications(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it forbids viewing another employee's history", func(t *testing.T) {
+		svc := &urgencyService{log: utils.NewTestLogger()}
+
+		_, err := svc.ListEmployeeNotifications(context.Background(), 5, 6, false)
+		assertAppErrorCode(t, err, "AUTH_ERRORS.FORBIDDEN")
+	})
+
+	t.Run("it returns the history to the employee and to admins", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		nrepo := repositories.NewMockNotificationRepository(ctrl)
+		nrepo.EXPECT().GetByEmployeeID(gomock.Any(), uint(5)).Return([]model.Notification{{ID: 3, EmployeeID: 5, Status: model.NotificationPending}}, nil).Times(2)
+		svc := &urgencyService{log: utils.NewTestLogger(), notificationRepo: nrepo}
+
+		resp, err := svc.ListEmployeeNotifications(context.Background(), 5, 5, false)
+		require.NoError(t, err)
+		assert.Len(t, resp, 1)
+
+		resp, err = svc.ListEmployeeNotifications(context.Background(), 5, 1, true)
+		require.NoError(t, err)
+		assert.Equal(t, uint(3), resp[0].ID)
+	})
+}
+
+func TestUrgencyService_RetryNotification(t *testing.T) {
+	t.Parallel()
+
+	failed := func(_ context.Context, id uint, n *model.Notification) error {
+		*n = model.Notification{ID: id, UrgencyID: 1, Status: model.NotificationFailed, Attempts: 5, ErrorMessage: "gateway down"}
+		return nil
+	}
+
+	t.Run("it returns not found for an unknown notification", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		nrepo := repositories.NewMockNotificationRepository(ctrl)
+		nrepo.EXPECT().GetByID(gomock.Any(), uint(9), gomock.Any()).Return(gorm.ErrRecordNotFound)
+		svc := &urgencyService{log: utils.NewTestLogger(), notificationRepo: nrepo}
+
+		_, err := svc.RetryNotification(context.Background(), 9)
+		assertAppErrorCode(t, err, "URGENCY_ERRORS.NOTIFICATION_NOT_FOUND")
+	})
+
+	t.Run("it refuses to retry a notification that did not fail", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		nrepo := repositories.NewMockNotificationRepository(ctrl)
+		nrepo.EXPECT().GetByID(gomock.Any(), uint(9), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, n *model.Notification) error {
+			*n = model.Notification{ID: id, Status: model.NotificationSent}
+			return nil
+		})
+		svc := &urgencyService{log: utils.NewTestLogger(), notificationRepo: nrepo}
+
+		_, err := svc.RetryNotification(context.Background(), 9)
+		assertAppErrorCode(t, err, "URGENCY_ERRORS.NOTIFICATION_NOT_FAILED")
+	})
+
+	t.Run("it refuses when the notification was retried concurrently", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		nrepo := repositories.NewMockNotificationRepository(ctrl)
+		nrepo.EXPECT().GetByID(gomock.Any(), uint(9), gomock.Any()).DoAndReturn(failed)
+		nrepo.EXPECT().ResetForRetry(gomock.Any(), uint(9)).Return(false, nil)
+		svc := &urgencyService{log: utils.NewTestLogger(), notificationRepo: nrepo}
+
+		_, err := svc.RetryNotification(context.Background(), 9)
+		assertAppErrorCode(t, err, "URGENCY_ERRORS.NOTIFICATION_NOT_FAILED")
+	})
+
+	t.Run("it queues a failed notification again", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		nrepo := repositories.NewMockNotificationRepository(ctrl)
+		nrepo.EXPECT().GetByID(gomock.Any(), uint(9), gomock.Any()).DoAndReturn(failed)
+		nrepo.EXPECT().ResetForRetry(gomock.Any(), uint(9)).Return(true, nil)
+		svc := &urgencyService{log: utils.NewTestLogger(), notificationRepo: nrepo}
+
+		resp, err := svc.RetryNotification(context.Background(), 9)
+		require.NoError(t, err)
+		assert.Equal(t, "pending", resp.Status)
+		assert.Zero(t, resp.Attempts)
+		assert.Empty(t, resp.ErrorMessage)
+	})
+
+	t.Run("it returns an update error when the reset fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		nrepo := repositories.NewMockNotificationRepository(ctrl)
+		nrepo.EXPECT().GetByID(gomock.Any(), uint(9), gomock.Any()).DoAndReturn(failed)
+		nrepo.EXPECT().ResetForRetry(gomock.Any(), uint(9)).Return(false, assert.AnError)
+		svc := &urgencyService{log: utils.NewTestLogger(), notificationRepo: nrepo}
+
+		_, err := svc.RetryNotification(context.Background(), 9)
+		assertAppErrorCode(t, err, "URGENCY_ERRORS.UPDATE_FAILED")
+	})
+}
@@ -0,0 +1,185 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/repositories"
+)
+
+// Config controls how often pending notifications are polled and how failed deliveries are retried.
+type Config struct {
+	Interval    time.Duration
+	BatchSize   int
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// ClaimLease is how long a claimed notification stays hidden from other dispatchers; it must outlast a send
+	ClaimLease time.Duration
+}
+
+// Dispatcher delivers pending notifications created for on-call employees.
+type Dispatcher struct {
+	log    utils.Logger
+	repo   repositories.NotificationRepository
+	sms    SMSSender
+	email  EmailSender
+	config Config
+	now    func() time.Time
+}
+
+func NewDispatcher(log utils.Logger, repo repositories.NotificationRepository, sms SMSSender, email EmailSender, cfg Config) *Dispatcher {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 5 * time.Second
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 50
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 5
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = 10 * time.Second
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = 10 * time.Minute
+	}
+	if cfg.MaxBackoff < cfg.BaseBackoff {
+		cfg.MaxBackoff = cfg.BaseBackoff
+	}
+	if cfg.ClaimLease <= 0 {
+		cfg.ClaimLease = 2 * time.Minute
+	}
+	return &Dispatcher{
+		log:    log.WithName("notificationDispatcher"),
+		repo:   repo,
+		sms:    sms,
+		email:  email,
+		config: cfg,
+		now:    time.Now,
+	}
+}
+
+// Start runs the polling loop in a background goroutine until ctx is cancelled.
+func (d *Dispatcher) Start(ctx context.Context) {
+	ctx, _ = utils.EnsureRequestID(ctx)
+	d.log.Infof("Starting notification dispatcher: interval=%s batch=%d maxAttempts=%d baseBackoff=%s",
+		d.config.Interval, d.config.BatchSize, d.config.MaxAttempts, d.config.BaseBackoff)
+
+	go func() {
+		ticker := time.NewTicker(d.config.Interval)
+		defer ticker.Stop()
+		for {
+			if _, err := d.ProcessOnce(ctx); err != nil {
+				d.log.WithContext(ctx).Errorf("notification dispatch cycle error: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+				d.log.WithContext(ctx).Info("Stopping notification dispatcher")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// ProcessOnce runs a single dispatch cycle and returns the number of delivered notifications.
+func (d *Dispatcher) ProcessOnce(ctx context.Context) (int, error) {
+	log := d.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "Dispatcher.ProcessOnce")()
+
+	// only due notifications are claimed, so ones waiting out a backoff never crowd fresh alerts out of
+	// the batch, and a notification claimed by another replica is not sent twice
+	notifications, err := d.repo.ClaimDueNotifications(ctx, d.now(), d.config.ClaimLease, d.config.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("claim due notifications: %w", err)
+	}
+	if len(notifications) == 0 {
+		return 0, nil
+	}
+
+	sent := 0
+	for i := range notifications {
+		if d.dispatch(ctx, &notifications[i]) {
+			sent++
+		}
+	}
+
+	log.Infof("Dispatched %d/%d due notifications", sent, len(notifications))
+	return sent, nil
+}
+
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.config.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= d.config.MaxBackoff {
+			return d.config.MaxBackoff
+		}
+	}
+	return delay
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, n *model.Notification) bool {
+	log := d.log.WithContext(ctx)
+
+	sendErr := d.send(ctx, n)
+
+	if err := d.repo.IncrementAttempts(ctx, n.ID); err != nil {
+		log.Errorf("Failed to increment attempts for notification %d: %v", n.ID, err)
+	}
+	attempts := n.Attempts + 1
+
+	if sendErr == nil {
+		if err := d.repo.MarkAsSent(ctx, n.ID, d.now()); err != nil {
+			log.Errorf("Notification %d delivered but could not be marked as sent: %v", n.ID, err)
+			return false
+		}
+		return true
+	}
+
+	if attempts >= d.config.MaxAttempts {
+		log.Warnf("Notification %d failed permanently after %d attempts: %v", n.ID, attempts, sendErr)
+		if err := d.repo.MarkAsFailed(ctx, n.ID, sendErr.Error()); err != nil {
+			log.Errorf("Failed to mark notification %d as failed: %v", n.ID, err)
+		}
+		return false
+	}
+
+	backoff := d.backoff(attempts)
+	log.Warnf("Notification %d attempt %d/%d failed, retrying in %s: %v", n.ID, attempts, d.config.MaxAttempts, backoff, sendErr)
+	if err := d.repo.ScheduleRetry(ctx, n.ID, d.now().Add(backoff), sendErr.Error()); err != nil {
+		log.Errorf("Failed to schedule retry for notification %d: %v", n.ID, err)
+	}
+	return false
+}
+
+func (d *Dispatcher) send(ctx context.Context, n *model.Notification) error {
+	switch n.NotificationType {
+	case model.NotificationSMS:
+		if d.sms == nil {
+			return fmt.Errorf("no SMS sender configured")
+		}
+		return d.sms.SendSMS(ctx, n.Recipient, n.Message)
+	case model.NotificationEmail:
+		if d.email == nil {
+			return fmt.Errorf("no email sender configured")
+		}
+		return d.email.SendEmail(ctx, n.Recipient, emailSubject(n), n.Message)
+	default:
+		return fmt.Errorf("unsupported notification type %q", n.NotificationType)
+	}
+}
+
+func emailSubject(n *model.Notification) string {
+	if n.Subject != "" {
+		return n.Subject
+	}
+	if n.Urgency == nil {
+		return fmt.Sprintf("Mountain Service urgency #%d", n.UrgencyID)
+	}
+	return fmt.Sprintf("Mountain Service urgency #%d (%s)", n.UrgencyID, n.Urgency.Level)
+}
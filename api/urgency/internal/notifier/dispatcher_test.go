@@ -0,0 +1,137 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/repositories"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestDispatcher(t *testing.T, cfg Config) (*Dispatcher, *repositories.MockNotificationRepository, *MockSMSSender, *MockEmailSender, time.Time) {
+	ctrl := gomock.NewController(t)
+	repo := repositories.NewMockNotificationRepository(ctrl)
+	sms := NewMockSMSSender(ctrl)
+	email := NewMockEmailSender(ctrl)
+	d := NewDispatcher(utils.NewTestLogger(), repo, sms, email, cfg)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	d.now = func() time.Time { return now }
+	return d, repo, sms, email, now
+}
+
+func TestNewDispatcher(t *testing.T) {
+	t.Run("it applies defaults for zero config values", func(t *testing.T) {
+		d := NewDispatcher(utils.NewTestLogger(), nil, nil, nil, Config{})
+		assert.Equal(t, 5*time.Second, d.config.Interval)
+		assert.Equal(t, 50, d.config.BatchSize)
+		assert.Equal(t, 5, d.config.MaxAttempts)
+		assert.Equal(t, 10*time.Second, d.config.BaseBackoff)
+		assert.Equal(t, 10*time.Minute, d.config.MaxBackoff)
+		assert.Equal(t, 2*time.Minute, d.config.ClaimLease)
+	})
+}
+
+func TestDispatcher_ProcessOnce(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it sends pending SMS and email notifications and marks them as sent", func(t *testing.T) {
+		d, repo, sms, email, now := newTestDispatcher(t, Config{BatchSize: 10})
+		pending := []model.Notification{
+			{ID: 1, UrgencyID: 7, NotificationType: model.NotificationSMS, Recipient: "+381641234567", Message: "sms body"},
+			{ID: 2, UrgencyID: 7, NotificationType: model.NotificationEmail, Recipient: "rescuer@example.com", Message: "email body",
+				Urgency: &model.Urgency{Level: urgencyV1.Critical}},
+		}
+		repo.EXPECT().ClaimDueNotifications(ctx, now, 2*time.Minute, 10).Return(pending, nil)
+		sms.EXPECT().SendSMS(ctx, "+381641234567", "sms body").Return(nil)
+		email.EXPECT().SendEmail(ctx, "rescuer@example.com", "Mountain Service urgency #7 (critical)", "email body").Return(nil)
+		repo.EXPECT().IncrementAttempts(ctx, uint(1)).Return(nil)
+		repo.EXPECT().IncrementAttempts(ctx, uint(2)).Return(nil)
+		repo.EXPECT().MarkAsSent(ctx, uint(1), now).Return(nil)
+		repo.EXPECT().MarkAsSent(ctx, uint(2), now).Return(nil)
+
+		sent, err := d.ProcessOnce(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, sent)
+	})
+
+	t.Run("it uses the rendered subject when the notification has one", func(t *testing.T) {
+		d, repo, _, email, now := newTestDispatcher(t, Config{BatchSize: 10})
+		pending := []model.Notification{
+			{ID: 3, UrgencyID: 7, NotificationType: model.NotificationEmail, Recipient: "rescuer@example.com", Subject: "Хитан случај #7", Message: "email body",
+				Urgency: &model.Urgency{Level: urgencyV1.Critical}},
+		}
+		repo.EXPECT().ClaimDueNotifications(ctx, now, 2*time.Minute, 10).Return(pending, nil)
+		email.EXPECT().SendEmail(ctx, "rescuer@example.com", "Хитан случај #7", "email body").Return(nil)
+		repo.EXPECT().IncrementAttempts(ctx, uint(3)).Return(nil)
+		repo.EXPECT().MarkAsSent(ctx, uint(3), now).Return(nil)
+
+		sent, err := d.ProcessOnce(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, sent)
+	})
+
+	t.Run("it keeps a failed notification pending until its backoff is over", func(t *testing.T) {
+		d, repo, sms, _, now := newTestDispatcher(t, Config{MaxAttempts: 3, BaseBackoff: 10 * time.Second})
+		pending := []model.Notification{{ID: 1, NotificationType: model.NotificationSMS, Recipient: "123456", Message: "m", Attempts: 1}}
+		repo.EXPECT().ClaimDueNotifications(ctx, now, 2*time.Minute, 50).Return(pending, nil)
+		sms.EXPECT().SendSMS(ctx, "123456", "m").Return(errors.New("gateway down"))
+		repo.EXPECT().IncrementAttempts(ctx, uint(1)).Return(nil)
+		// the second attempt failed, so the third one waits 20s
+		repo.EXPECT().ScheduleRetry(ctx, uint(1), now.Add(20*time.Second), "gateway down").Return(nil)
+
+		sent, err := d.ProcessOnce(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, sent)
+	})
+
+	t.Run("it marks a notification as failed when the attempt cap is reached", func(t *testing.T) {
+		d, repo, sms, _, now := newTestDispatcher(t, Config{MaxAttempts: 3, BaseBackoff: time.Second})
+		last := now.Add(-time.Hour)
+		pending := []model.Notification{{ID: 1, NotificationType: model.NotificationSMS, Recipient: "123456", Message: "m", Attempts: 2, LastAttemptAt: &last}}
+		repo.EXPECT().ClaimDueNotifications(ctx, gomock.Any(), 2*time.Minute, 50).Return(pending, nil)
+		sms.EXPECT().SendSMS(ctx, "123456", "m").Return(errors.New("gateway down"))
+		repo.EXPECT().IncrementAttempts(ctx, uint(1)).Return(nil)
+		repo.EXPECT().MarkAsFailed(ctx, uint(1), "gateway down").Return(nil)
+
+		sent, err := d.ProcessOnce(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, sent)
+	})
+
+	t.Run("it fails unsupported notification types without calling senders", func(t *testing.T) {
+		d, repo, _, _, _ := newTestDispatcher(t, Config{MaxAttempts: 1})
+		pending := []model.Notification{{ID: 3, NotificationType: "pigeon"}}
+		repo.EXPECT().ClaimDueNotifications(ctx, gomock.Any(), 2*time.Minute, 50).Return(pending, nil)
+		repo.EXPECT().IncrementAttempts(ctx, uint(3)).Return(nil)
+		repo.EXPECT().MarkAsFailed(ctx, uint(3), `unsupported notification type "pigeon"`).Return(nil)
+
+		sent, err := d.ProcessOnce(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, sent)
+	})
+
+	t.Run("it returns an error when due notifications cannot be claimed", func(t *testing.T) {
+		d, repo, _, _, _ := newTestDispatcher(t, Config{})
+		repo.EXPECT().ClaimDueNotifications(ctx, gomock.Any(), 2*time.Minute, 50).Return(nil, errors.New("db down"))
+
+		_, err := d.ProcessOnce(ctx)
+		assert.ErrorContains(t, err, "db down")
+	})
+}
+
+func TestDispatcher_backoff(t *testing.T) {
+	t.Run("it doubles the delay per attempt and caps it at the maximum", func(t *testing.T) {
+		d := NewDispatcher(utils.NewTestLogger(), nil, nil, nil, Config{BaseBackoff: time.Second, MaxBackoff: 5 * time.Second})
+		assert.Equal(t, time.Second, d.backoff(1))
+		assert.Equal(t, 2*time.Second, d.backoff(2))
+		assert.Equal(t, 4*time.Second, d.backoff(3))
+		assert.Equal(t, 5*time.Second, d.backoff(4))
+		assert.Equal(t, 5*time.Second, d.backoff(10))
+	})
+}
@@ -0,0 +1,73 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+// LogSender is a stand-in for real providers used in local and test environments.
+// It logs every message and, when a file path is configured, appends it as a JSON line.
+type LogSender struct {
+	log      utils.Logger
+	filePath string
+	mu       sync.Mutex
+}
+
+var (
+	_ SMSSender   = (*LogSender)(nil)
+	_ EmailSender = (*LogSender)(nil)
+)
+
+type sentRecord struct {
+	Channel string    `json:"channel"`
+	To      string    `json:"to"`
+	Subject string    `json:"subject,omitempty"`
+	Body    string    `json:"body"`
+	SentAt  time.Time `json:"sentAt"`
+}
+
+// NewLogSender creates a LogSender. An empty filePath only logs.
+func NewLogSender(log utils.Logger, filePath string) *LogSender {
+	return &LogSender{log: log.WithName("logSender"), filePath: filePath}
+}
+
+func (s *LogSender) SendSMS(ctx context.Context, phone, message string) error {
+	s.log.WithContext(ctx).Infof("SMS to %s: %s", phone, message)
+	return s.append(sentRecord{Channel: "sms", To: phone, Body: message, SentAt: time.Now().UTC()})
+}
+
+func (s *LogSender) SendEmail(ctx context.Context, to, subject, body string) error {
+	s.log.WithContext(ctx).Infof("Email to %s [%s]: %s", to, subject, body)
+	return s.append(sentRecord{Channel: "email", To: to, Subject: subject, Body: body, SentAt: time.Now().UTC()})
+}
+
+func (s *LogSender) append(rec sentRecord) error {
+	if s.filePath == "" {
+		return nil
+	}
+
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification record: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open notification sink %s: %w", s.filePath, err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write notification sink %s: %w", s.filePath, err)
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+package notifier
+
+//go:generate mockgen -source=sender.go -destination=sender_gomock.go -package=notifier mountain_service/urgency/internal/notifier -imports=gomock=go.uber.org/mock/gomock -typed
+
+import "context"
+
+// SMSSender delivers a text message to a phone number.
+type SMSSender interface {
+	SendSMS(ctx context.Context, phone, message string) error
+}
+
+// EmailSender delivers an email message to a single recipient.
+type EmailSender interface {
+	SendEmail(ctx context.Context, to, subject, body string) error
+}
@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sender.go
+//
+// Generated by this command:
+//
+//	mockgen -source=sender.go -destination=sender_gomock.go -package=notifier mountain_service/urgency/internal/notifier -imports=gomock=go.uber.org/mock/gomock -typed
+//
+
+// Package notifier is a generated GoMock package.
+package notifier
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSMSSender is a mock of SMSSender interface.
+type MockSMSSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockSMSSenderMockRecorder
+	isgomock struct{}
+}
+
+// MockSMSSenderMockRecorder is the mock recorder for MockSMSSender.
+type MockSMSSenderMockRecorder struct {
+	mock *MockSMSSender
+}
+
+// NewMockSMSSender creates a new mock instance.
+func NewMockSMSSender(ctrl *gomock.Controller) *MockSMSSender {
+	mock := &MockSMSSender{ctrl: ctrl}
+	mock.recorder = &MockSMSSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSMSSender) EXPECT() *MockSMSSenderMockRecorder {
+	return m.recorder
+}
+
+// SendSMS mocks base method.
+func (m *MockSMSSender) SendSMS(ctx context.Context, phone, message string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendSMS", ctx, phone, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendSMS indicates an expected call of SendSMS.
+func (mr *MockSMSSenderMockRecorder) SendSMS(ctx, phone, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendSMS", reflect.TypeOf((*MockSMSSender)(nil).SendSMS), ctx, phone, message)
+}
+
+// MockEmailSender is a mock of EmailSender interface.
+type MockEmailSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailSenderMockRecorder
+	isgomock struct{}
+}
+
+// MockEmailSenderMockRecorder is the mock recorder for MockEmailSender.
+type MockEmailSenderMockRecorder struct {
+	mock *MockEmailSender
+}
+
+// NewMockEmailSender creates a new mock instance.
+func NewMockEmailSender(ctrl *gomock.Controller) *MockEmailSender {
+	mock := &MockEmailSender{ctrl: ctrl}
+	mock.recorder = &MockEmailSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailSender) EXPECT() *MockEmailSenderMockRecorder {
+	return m.recorder
+}
+
+// SendEmail mocks base method.
+func (m *MockEmailSender) SendEmail(ctx context.Context, to, subject, body string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEmail", ctx, to, subject, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendEmail indicates an expected call of SendEmail.
+func (mr *MockEmailSenderMockRecorder) SendEmail(ctx, to, subject, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEmail", reflect.TypeOf((*MockEmailSender)(nil).SendEmail), ctx, to, subject, body)
+}
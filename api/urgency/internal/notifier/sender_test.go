@@ -0,0 +1,88 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSMTPEmailSender(t *testing.T) {
+	t.Run("it requires host and from address", func(t *testing.T) {
+		_, err := NewSMTPEmailSender(utils.NewTestLogger(), SMTPConfig{From: "a@b.com"})
+		assert.Error(t, err)
+		_, err = NewSMTPEmailSender(utils.NewTestLogger(), SMTPConfig{Host: "smtp.example.com"})
+		assert.Error(t, err)
+	})
+}
+
+func TestSMTPEmailSender_SendEmail(t *testing.T) {
+	newSender := func(t *testing.T, fn func(addr string, a smtp.Auth, from string, to []string, msg []byte) error) EmailSender {
+		s, err := NewSMTPEmailSender(utils.NewTestLogger(), SMTPConfig{Host: "smtp.example.com", Username: "user", Password: "pass", From: "noreply@example.com"})
+		require.NoError(t, err)
+		s.(*smtpEmailSender).sendMail = fn
+		return s
+	}
+
+	t.Run("it sends a MIME message to the relay", func(t *testing.T) {
+		var gotAddr string
+		var gotMsg string
+		s := newSender(t, func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+			gotAddr = addr
+			gotMsg = string(msg)
+			assert.NotNil(t, a)
+			assert.Equal(t, []string{"rescuer@example.com"}, to)
+			return nil
+		})
+
+		err := s.SendEmail(context.Background(), "rescuer@example.com", "Alert\r\nBcc: x@y.z", "line1\nline2")
+		assert.NoError(t, err)
+		assert.Equal(t, "smtp.example.com:587", gotAddr)
+		assert.Contains(t, gotMsg, "Subject: Alert  Bcc: x@y.z\r\n")
+		assert.True(t, strings.HasSuffix(gotMsg, "line1\r\nline2"))
+	})
+
+	t.Run("it rejects invalid recipients", func(t *testing.T) {
+		s := newSender(t, func(string, smtp.Auth, string, []string, []byte) error {
+			t.Fatal("sendMail must not be called")
+			return nil
+		})
+		assert.Error(t, s.SendEmail(context.Background(), "not-an-email", "s", "b"))
+	})
+
+	t.Run("it wraps relay errors", func(t *testing.T) {
+		s := newSender(t, func(string, smtp.Auth, string, []string, []byte) error {
+			return errors.New("connection refused")
+		})
+		assert.ErrorContains(t, s.SendEmail(context.Background(), "rescuer@example.com", "s", "b"), "connection refused")
+	})
+}
+
+func TestLogSender(t *testing.T) {
+	t.Run("it appends sent messages to the sink file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "notifications.log")
+		s := NewLogSender(utils.NewTestLogger(), path)
+
+		require.NoError(t, s.SendSMS(context.Background(), "123456", "hello"))
+		require.NoError(t, s.SendEmail(context.Background(), "a@b.com", "subj", "body"))
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		assert.Len(t, lines, 2)
+		assert.Contains(t, lines[0], `"channel":"sms"`)
+		assert.Contains(t, lines[1], `"subject":"subj"`)
+	})
+
+	t.Run("it only logs when no sink file is configured", func(t *testing.T) {
+		s := NewLogSender(utils.NewTestLogger(), "")
+		assert.NoError(t, s.SendSMS(context.Background(), "123456", "hello"))
+	})
+}
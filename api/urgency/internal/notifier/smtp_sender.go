@@ -0,0 +1,80 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+// SMTPConfig holds connection settings for the SMTP email sender.
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+type smtpEmailSender struct {
+	log      utils.Logger
+	config   SMTPConfig
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewSMTPEmailSender creates an EmailSender that delivers messages through an SMTP relay.
+func NewSMTPEmailSender(log utils.Logger, cfg SMTPConfig) (EmailSender, error) {
+	if cfg.Host == "" {
+		return nil, fmt.Errorf("smtp host is required")
+	}
+	if cfg.From == "" {
+		return nil, fmt.Errorf("smtp from address is required")
+	}
+	if cfg.Port == "" {
+		cfg.Port = "587"
+	}
+	return &smtpEmailSender{log: log.WithName("smtpEmailSender"), config: cfg, sendMail: smtp.SendMail}, nil
+}
+
+func (s *smtpEmailSender) SendEmail(ctx context.Context, to, subject, body string) error {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "SMTPEmailSender.SendEmail")()
+
+	if err := utils.ValidateEmail(to); err != nil {
+		return fmt.Errorf("invalid recipient %q: %w", to, err)
+	}
+
+	var auth smtp.Auth
+	if s.config.Username != "" {
+		auth = smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+	}
+
+	addr := net.JoinHostPort(s.config.Host, s.config.Port)
+	if err := s.sendMail(addr, auth, s.config.From, []string{to}, buildMIMEMessage(s.config.From, to, subject, body)); err != nil {
+		log.Errorf("Failed to send email to %s via %s: %v", to, addr, err)
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	log.Infof("Email sent to %s", to)
+	return nil
+}
+
+func buildMIMEMessage(from, to, subject, body string) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + to + "\r\n")
+	b.WriteString("Subject: " + sanitizeHeader(subject) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=\"UTF-8\"\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+// sanitizeHeader strips line breaks so user-provided text cannot inject extra headers
+func sanitizeHeader(v string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(v)
+}
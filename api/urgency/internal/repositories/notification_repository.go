@@ -15,6 +15,8 @@ type NotificationRepository interface {
 	Create(ctx context.Context, notification *model.Notification) error
 	GetByID(ctx context.Context, id uint, notification *model.Notification) error
 	GetPendingNotifications(ctx context.Context, limit int) ([]model.Notification, error)
+	ClaimDueNotifications(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.Notification, error)
+	ScheduleRetry(ctx context.Context, id uint, nextAttemptAt time.Time, errorMessage string) error
 	GetByUrgencyID(ctx context.Context, urgencyID uint) ([]model.Notification, error)
 	GetByEmployeeID(ctx context.Context, employeeID uint) ([]model.Notification, error)
 	Update(ctx context.Context, notification *model.Notification) error
@@ -22,6 +24,8 @@ type NotificationRepository interface {
 	MarkAsSent(ctx context.Context, id uint, sentAt time.Time) error
 	MarkAsFailed(ctx context.Context, id uint, errorMessage string) error
 	IncrementAttempts(ctx context.Context, id uint) error
+	ResetForRetry(ctx context.Context, id uint) (bool, error)
+	RecordReply(ctx context.Context, urgencyID, employeeID uint, reply model.NotificationReply, reason string, repliedAt time.Time) (int64, error)
 }
 
 type notificationRepository struct {
@@ -82,13 +86,64 @@ func (r *notificationRepository) GetPendingNotifications(ctx context.Context, li
 	return notifications, nil
 }
 
+// ClaimDueNotifications returns up to limit pending notifications whose next attempt time has come, oldest
+// first, and holds each one back for the lease so another dispatcher does not send it as well. A row
+// claimed concurrently by another dispatcher is skipped.
+func (r *notificationRepository) ClaimDueNotifications(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.Notification, error) {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "NotificationRepository.ClaimDueNotifications")()
+
+	const due = "status = ? AND (next_attempt_at IS NULL OR next_attempt_at <= ?)"
+	var candidates []model.Notification
+	if err := r.db.WithContext(ctx).Where(due, model.NotificationPending, now).
+		Order("created_at ASC, id ASC").Limit(limit).Preload("Urgency").Find(&candidates).Error; err != nil {
+		log.Errorf("Failed to get due notifications: %v", err)
+		return nil, err
+	}
+
+	leaseUntil := now.Add(lease)
+	claimed := make([]model.Notification, 0, len(candidates))
+	for i := range candidates {
+		res := r.db.WithContext(ctx).Model(&model.Notification{}).
+			Where("id = ?", candidates[i].ID).Where(due, model.NotificationPending, now).
+			Update("next_attempt_at", leaseUntil)
+		if res.Error != nil {
+			log.Errorf("Failed to claim notification %d: %v", candidates[i].ID, res.Error)
+			return claimed, res.Error
+		}
+		if res.RowsAffected == 0 {
+			continue
+		}
+		candidates[i].NextAttemptAt = &leaseUntil
+		claimed = append(claimed, candidates[i])
+	}
+
+	log.Infof("Claimed due notifications: count=%d/%d", len(claimed), len(candidates))
+	return claimed, nil
+}
+
+// ScheduleRetry keeps a notification pending and holds it back until the next attempt time
+func (r *notificationRepository) ScheduleRetry(ctx context.Context, id uint, nextAttemptAt time.Time, errorMessage string) error {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "NotificationRepository.ScheduleRetry")()
+
+	if err := r.db.WithContext(ctx).Model(&model.Notification{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"next_attempt_at": nextAttemptAt,
+		"error_message":   errorMessage,
+	}).Error; err != nil {
+		log.Errorf("Failed to schedule retry for notification %d: %v", id, err)
+		return err
+	}
+	return nil
+}
+
 func (r *notificationRepository) GetByUrgencyID(ctx context.Context, urgencyID uint) ([]model.Notification, error) {
 	log := r.log.WithContext(ctx)
 	defer utils.TimeOperation(log, "NotificationRepository.GetByUrgencyID")()
 	log.Infof("Getting notifications by urgency ID: %d", urgencyID)
 
 	var notifications []model.Notification
-	if err := r.db.Where("urgency_id = ?", urgencyID).Find(&notifications).Error; err != nil {
+	if err := r.db.Where("urgency_id = ?", urgencyID).Order("id ASC").Find(&notifications).Error; err != nil {
 		log.Errorf("Failed to get notifications by urgency ID %d: %v", urgencyID, err)
 		return nil, err
 	}
@@ -102,7 +157,7 @@ func (r *notificationRepository) GetByEmployeeID(ctx context.Context, employeeID
 	log.Infof("Getting notifications by employee ID: %d", employeeID)
 
 	var notifications []model.Notification
-	if err := r.db.Preload("Urgency").Where("employee_id = ?", employeeID).Find(&notifications).Error; err != nil {
+	if err := r.db.Preload("Urgency").Where("employee_id = ?", employeeID).Order("id DESC").Find(&notifications).Error; err != nil {
 		log.Errorf("Failed to get notifications by employee ID %d: %v", employeeID, err)
 		return nil, err
 	}
@@ -193,3 +248,48 @@ func (r *notificationRepository) IncrementAttempts(ctx context.Context, id uint)
 	log.Infof("Notification attempts incremented successfully: %d", id)
 	return nil
 }
+
+// ResetForRetry puts a failed notification back in the dispatch queue with a fresh attempt budget.
+// It returns false when the notification does not exist or is not failed.
+func (r *notificationRepository) ResetForRetry(ctx context.Context, id uint) (bool, error) {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "NotificationRepository.ResetForRetry")()
+	log.Infof("Resetting failed notification for retry: %d", id)
+
+	res := r.db.Model(&model.Notification{}).
+		Where("id = ? AND status = ?", id, model.NotificationFailed).
+		Updates(map[string]interface{}{
+			"status":          model.NotificationPending,
+			"attempts":        0,
+			"last_attempt_at": nil,
+			"next_attempt_at": nil,
+			"error_message":   "",
+		})
+	if res.Error != nil {
+		log.Errorf("Failed to reset notification %d for retry: %v", id, res.Error)
+		return false, res.Error
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+// RecordReply stores the employee's reply on all notifications they received for the urgency
+func (r *notificationRepository) RecordReply(ctx context.Context, urgencyID, employeeID uint, reply model.NotificationReply, reason string, repliedAt time.Time) (int64, error) {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "NotificationRepository.RecordReply")()
+	log.Infof("Recording reply: urgencyID=%d, employeeID=%d, reply=%s", urgencyID, employeeID, reply)
+
+	res := r.db.Model(&model.Notification{}).
+		Where("urgency_id = ? AND employee_id = ?", urgencyID, employeeID).
+		Updates(map[string]interface{}{
+			"reply":          reply,
+			"replied_at":     repliedAt,
+			"decline_reason": reason,
+		})
+	if res.Error != nil {
+		log.Errorf("Failed to record reply for urgency %d employee %d: %v", urgencyID, employeeID, res.Error)
+		return 0, res.Error
+	}
+
+	return res.RowsAffected, nil
+}
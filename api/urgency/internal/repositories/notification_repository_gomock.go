@@ -1,11 +1,16 @@
 // Code generated by MockGen. DO NOT EDIT.
 // Source: notification_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=notification_repository.go -destination=notification_repository_gomock.go -package=repositories mountain_service/urgency/internal/repositories -imports=gomock=go.uber.org/mock/gomock -typed
+//
 
 // Package repositories is a generated GoMock package.
 package repositories
 
 import (
-	"context"
+	context "context"
 	reflect "reflect"
 	time "time"
 
@@ -17,6 +22,7 @@ import (
 type MockNotificationRepository struct {
 	ctrl     *gomock.Controller
 	recorder *MockNotificationRepositoryMockRecorder
+	isgomock struct{}
 }
 
 // MockNotificationRepositoryMockRecorder is the mock recorder for MockNotificationRepository.
@@ -36,6 +42,21 @@ func (m *MockNotificationRepository) EXPECT() *MockNotificationRepositoryMockRec
 	return m.recorder
 }
 
+// ClaimDueNotifications mocks base method.
+func (m *MockNotificationRepository) ClaimDueNotifications(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueNotifications", ctx, now, lease, limit)
+	ret0, _ := ret[0].([]model.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueNotifications indicates an expected call of ClaimDueNotifications.
+func (mr *MockNotificationRepositoryMockRecorder) ClaimDueNotifications(ctx, now, lease, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueNotifications", reflect.TypeOf((*MockNotificationRepository)(nil).ClaimDueNotifications), ctx, now, lease, limit)
+}
+
 // Create mocks base method.
 func (m *MockNotificationRepository) Create(ctx context.Context, notification *model.Notification) error {
 	m.ctrl.T.Helper()
@@ -45,7 +66,7 @@ func (m *MockNotificationRepository) Create(ctx context.Context, notification *m
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockNotificationRepositoryMockRecorder) Create(ctx, notification interface{}) *gomock.Call {
+func (mr *MockNotificationRepositoryMockRecorder) Create(ctx, notification any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockNotificationRepository)(nil).Create), ctx, notification)
 }
@@ -59,7 +80,7 @@ func (m *MockNotificationRepository) Delete(ctx context.Context, id uint) error
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockNotificationRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+func (mr *MockNotificationRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockNotificationRepository)(nil).Delete), ctx, id)
 }
@@ -74,7 +95,7 @@ func (m *MockNotificationRepository) GetByEmployeeID(ctx context.Context, employ
 }
 
 // GetByEmployeeID indicates an expected call of GetByEmployeeID.
-func (mr *MockNotificationRepositoryMockRecorder) GetByEmployeeID(ctx, employeeID interface{}) *gomock.Call {
+func (mr *MockNotificationRepositoryMockRecorder) GetByEmployeeID(ctx, employeeID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmployeeID", reflect.TypeOf((*MockNotificationRepository)(nil).GetByEmployeeID), ctx, employeeID)
 }
@@ -88,7 +109,7 @@ func (m *MockNotificationRepository) GetByID(ctx context.Context, id uint, notif
 }
 
 // GetByID indicates an expected call of GetByID.
-func (mr *MockNotificationRepositoryMockRecorder) GetByID(ctx, id, notification interface{}) *gomock.Call {
+func (mr *MockNotificationRepositoryMockRecorder) GetByID(ctx, id, notification any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockNotificationRepository)(nil).GetByID), ctx, id, notification)
 }
@@ -103,7 +124,7 @@ func (m *MockNotificationRepository) GetByUrgencyID(ctx context.Context, urgency
 }
 
 // GetByUrgencyID indicates an expected call of GetByUrgencyID.
-func (mr *MockNotificationRepositoryMockRecorder) GetByUrgencyID(ctx, urgencyID interface{}) *gomock.Call {
+func (mr *MockNotificationRepositoryMockRecorder) GetByUrgencyID(ctx, urgencyID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUrgencyID", reflect.TypeOf((*MockNotificationRepository)(nil).GetByUrgencyID), ctx, urgencyID)
 }
@@ -118,7 +139,7 @@ func (m *MockNotificationRepository) GetPendingNotifications(ctx context.Context
 }
 
 // GetPendingNotifications indicates an expected call of GetPendingNotifications.
-func (mr *MockNotificationRepositoryMockRecorder) GetPendingNotifications(ctx, limit interface{}) *gomock.Call {
+func (mr *MockNotificationRepositoryMockRecorder) GetPendingNotifications(ctx, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingNotifications", reflect.TypeOf((*MockNotificationRepository)(nil).GetPendingNotifications), ctx, limit)
 }
@@ -132,7 +153,7 @@ func (m *MockNotificationRepository) IncrementAttempts(ctx context.Context, id u
 }
 
 // IncrementAttempts indicates an expected call of IncrementAttempts.
-func (mr *MockNotificationRepositoryMockRecorder) IncrementAttempts(ctx, id interface{}) *gomock.Call {
+func (mr *MockNotificationRepositoryMockRecorder) IncrementAttempts(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementAttempts", reflect.TypeOf((*MockNotificationRepository)(nil).IncrementAttempts), ctx, id)
 }
@@ -146,7 +167,7 @@ func (m *MockNotificationRepository) MarkAsFailed(ctx context.Context, id uint,
 }
 
 // MarkAsFailed indicates an expected call of MarkAsFailed.
-func (mr *MockNotificationRepositoryMockRecorder) MarkAsFailed(ctx, id, errorMessage interface{}) *gomock.Call {
+func (mr *MockNotificationRepositoryMockRecorder) MarkAsFailed(ctx, id, errorMessage any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsFailed", reflect.TypeOf((*MockNotificationRepository)(nil).MarkAsFailed), ctx, id, errorMessage)
 }
@@ -160,11 +181,55 @@ func (m *MockNotificationRepository) MarkAsSent(ctx context.Context, id uint, se
 }
 
 // MarkAsSent indicates an expected call of MarkAsSent.
-func (mr *MockNotificationRepositoryMockRecorder) MarkAsSent(ctx, id, sentAt interface{}) *gomock.Call {
+func (mr *MockNotificationRepositoryMockRecorder) MarkAsSent(ctx, id, sentAt any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsSent", reflect.TypeOf((*MockNotificationRepository)(nil).MarkAsSent), ctx, id, sentAt)
 }
 
+// RecordReply mocks base method.
+func (m *MockNotificationRepository) RecordReply(ctx context.Context, urgencyID, employeeID uint, reply model.NotificationReply, reason string, repliedAt time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordReply", ctx, urgencyID, employeeID, reply, reason, repliedAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordReply indicates an expected call of RecordReply.
+func (mr *MockNotificationRepositoryMockRecorder) RecordReply(ctx, urgencyID, employeeID, reply, reason, repliedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordReply", reflect.TypeOf((*MockNotificationRepository)(nil).RecordReply), ctx, urgencyID, employeeID, reply, reason, repliedAt)
+}
+
+// ResetForRetry mocks base method.
+func (m *MockNotificationRepository) ResetForRetry(ctx context.Context, id uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetForRetry", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetForRetry indicates an expected call of ResetForRetry.
+func (mr *MockNotificationRepositoryMockRecorder) ResetForRetry(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetForRetry", reflect.TypeOf((*MockNotificationRepository)(nil).ResetForRetry), ctx, id)
+}
+
+// ScheduleRetry mocks base method.
+func (m *MockNotificationRepository) ScheduleRetry(ctx context.Context, id uint, nextAttemptAt time.Time, errorMessage string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleRetry", ctx, id, nextAttemptAt, errorMessage)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ScheduleRetry indicates an expected call of ScheduleRetry.
+func (mr *MockNotificationRepositoryMockRecorder) ScheduleRetry(ctx, id, nextAttemptAt, errorMessage any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleRetry", reflect.TypeOf((*MockNotificationRepository)(nil).ScheduleRetry), ctx, id, nextAttemptAt, errorMessage)
+}
+
 // Update mocks base method.
 func (m *MockNotificationRepository) Update(ctx context.Context, notification *model.Notification) error {
 	m.ctrl.T.Helper()
@@ -174,7 +239,7 @@ func (m *MockNotificationRepository) Update(ctx context.Context, notification *m
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockNotificationRepositoryMockRecorder) Update(ctx, notification interface{}) *gomock.Call {
+func (mr *MockNotificationRepositoryMockRecorder) Update(ctx, notification any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockNotificationRepository)(nil).Update), ctx, notification)
 }
@@ -251,6 +251,71 @@ func TestNotificationRepository_GetPendingNotifications(t *testing.T) {
 	})
 }
 
+func TestNotificationRepository_ClaimDueNotifications(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("it claims a fresh notification behind more backed-off ones than the limit", func(t *testing.T) {
+		db := setupNotificationTestDB(t)
+		repo := NewNotificationRepository(utils.NewTestLogger(), db)
+		urgency := createTestUrgencyForNotification(t, db)
+
+		retryAt := now.Add(time.Minute)
+		for i := 0; i < 5; i++ {
+			require.NoError(t, db.Create(&model.Notification{UrgencyID: urgency.ID, EmployeeID: uint(i + 1), NotificationType: model.NotificationSMS,
+				Recipient: "+1234567890", Message: "backed off", Status: model.NotificationPending, Attempts: 2, NextAttemptAt: &retryAt}).Error)
+		}
+		fresh := &model.Notification{UrgencyID: urgency.ID, EmployeeID: 9, NotificationType: model.NotificationSMS, Recipient: "+1234567899", Message: "fresh", Status: model.NotificationPending}
+		require.NoError(t, db.Create(fresh).Error)
+
+		claimed, err := repo.ClaimDueNotifications(ctx, now, time.Minute, 3)
+
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+		assert.Equal(t, fresh.ID, claimed[0].ID)
+		assert.NotNil(t, claimed[0].Urgency)
+	})
+
+	t.Run("it does not hand the same notification to a second dispatcher during the lease", func(t *testing.T) {
+		db := setupNotificationTestDB(t)
+		repo := NewNotificationRepository(utils.NewTestLogger(), db)
+		urgency := createTestUrgencyForNotification(t, db)
+		require.NoError(t, db.Create(&model.Notification{UrgencyID: urgency.ID, EmployeeID: 1, NotificationType: model.NotificationSMS, Recipient: "+1234567890", Message: "m", Status: model.NotificationPending}).Error)
+
+		claimed, err := repo.ClaimDueNotifications(ctx, now, time.Minute, 10)
+		require.NoError(t, err)
+		assert.Len(t, claimed, 1)
+
+		claimed, err = repo.ClaimDueNotifications(ctx, now.Add(30*time.Second), time.Minute, 10)
+		require.NoError(t, err)
+		assert.Empty(t, claimed)
+
+		// a dispatcher that died mid-send gives the notification up once the lease runs out
+		claimed, err = repo.ClaimDueNotifications(ctx, now.Add(time.Minute), time.Minute, 10)
+		require.NoError(t, err)
+		assert.Len(t, claimed, 1)
+	})
+
+	t.Run("it waits for the scheduled retry", func(t *testing.T) {
+		db := setupNotificationTestDB(t)
+		repo := NewNotificationRepository(utils.NewTestLogger(), db)
+		urgency := createTestUrgencyForNotification(t, db)
+		notification := &model.Notification{UrgencyID: urgency.ID, EmployeeID: 1, NotificationType: model.NotificationSMS, Recipient: "+1234567890", Message: "m", Status: model.NotificationPending}
+		require.NoError(t, db.Create(notification).Error)
+
+		require.NoError(t, repo.ScheduleRetry(ctx, notification.ID, now.Add(20*time.Second), "gateway down"))
+
+		claimed, err := repo.ClaimDueNotifications(ctx, now.Add(19*time.Second), time.Minute, 10)
+		require.NoError(t, err)
+		assert.Empty(t, claimed)
+		claimed, err = repo.ClaimDueNotifications(ctx, now.Add(20*time.Second), time.Minute, 10)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+		assert.Equal(t, "gateway down", claimed[0].ErrorMessage)
+	})
+}
+
 func TestNotificationRepository_GetByUrgencyID(t *testing.T) {
 	t.Parallel()
 
@@ -728,6 +793,66 @@ func TestNotificationRepository_IncrementAttempts(t *testing.T) {
 	})
 }
 
+func TestNotificationRepository_ResetForRetry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it puts a failed notification back to pending with a fresh attempt budget", func(t *testing.T) {
+		db := setupNotificationTestDB(t)
+		repo := NewNotificationRepository(utils.NewTestLogger(), db)
+		urgency := createTestUrgencyForNotification(t, db)
+
+		lastAttempt := time.Now().Add(-time.Minute)
+		notification := &model.Notification{
+			UrgencyID:        urgency.ID,
+			EmployeeID:       1,
+			NotificationType: model.NotificationSMS,
+			Recipient:        "+1234567890",
+			Message:          "Test notification",
+			Status:           model.NotificationFailed,
+			Attempts:         5,
+			LastAttemptAt:    &lastAttempt,
+			ErrorMessage:     "gateway down",
+		}
+		require.NoError(t, db.Create(notification).Error)
+
+		ok, err := repo.ResetForRetry(context.Background(), notification.ID)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		var updated model.Notification
+		require.NoError(t, db.First(&updated, notification.ID).Error)
+		assert.Equal(t, model.NotificationPending, updated.Status)
+		assert.Zero(t, updated.Attempts)
+		assert.Nil(t, updated.LastAttemptAt)
+		assert.Empty(t, updated.ErrorMessage)
+	})
+
+	t.Run("it leaves notifications that did not fail untouched", func(t *testing.T) {
+		db := setupNotificationTestDB(t)
+		repo := NewNotificationRepository(utils.NewTestLogger(), db)
+		urgency := createTestUrgencyForNotification(t, db)
+
+		notification := &model.Notification{
+			UrgencyID:        urgency.ID,
+			EmployeeID:       1,
+			NotificationType: model.NotificationEmail,
+			Recipient:        "rescuer@example.com",
+			Message:          "Test notification",
+			Status:           model.NotificationSent,
+			Attempts:         1,
+		}
+		require.NoError(t, db.Create(notification).Error)
+
+		ok, err := repo.ResetForRetry(context.Background(), notification.ID)
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		ok, err = repo.ResetForRetry(context.Background(), 999)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
+
 func setupNotificationTestDB(t *testing.T) *gorm.DB {
 	sqlDB, err := sql.Open("sqlite", ":memory:")
 	require.NoError(t, err)
@@ -755,3 +880,38 @@ func createTestUrgencyForNotification(t *testing.T, db *gorm.DB) *model.Urgency
 	require.NoError(t, err)
 	return urgency
 }
+
+func TestNotificationRepository_RecordReply(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it records the reply on all notifications of the employee for the urgency", func(t *testing.T) {
+		db := setupNotificationTestDB(t)
+		repo := NewNotificationRepository(utils.NewTestLogger(), db)
+		urgency := createTestUrgencyForNotification(t, db)
+
+		for _, n := range []*model.Notification{
+			{UrgencyID: urgency.ID, EmployeeID: 1, NotificationType: model.NotificationSMS, Recipient: "+1234567890", Message: "m", Status: model.NotificationSent},
+			{UrgencyID: urgency.ID, EmployeeID: 1, NotificationType: model.NotificationEmail, Recipient: "a@b.com", Message: "m", Status: model.NotificationSent},
+			{UrgencyID: urgency.ID, EmployeeID: 2, NotificationType: model.NotificationSMS, Recipient: "+1234567891", Message: "m", Status: model.NotificationSent},
+		} {
+			require.NoError(t, db.Create(n).Error)
+		}
+
+		at := time.Now().UTC()
+		updated, err := repo.RecordReply(context.Background(), urgency.ID, 1, model.NotificationDeclined, "injured", at)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), updated)
+
+		notifications, err := repo.GetByUrgencyID(context.Background(), urgency.ID)
+		require.NoError(t, err)
+		for _, n := range notifications {
+			if n.EmployeeID == 1 {
+				assert.Equal(t, model.NotificationDeclined, n.Reply)
+				assert.Equal(t, "injured", n.DeclineReason)
+				assert.NotNil(t, n.RepliedAt)
+			} else {
+				assert.Empty(t, n.Reply)
+			}
+		}
+	})
+}
@@ -8,7 +8,10 @@ import (
 	"fmt"
 	"maps"
 	"slices"
+	"strings"
+	"time"
 
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
 	"github.com/pd120424d/mountain-service/api/shared/utils"
 	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
 
@@ -21,11 +24,42 @@ type UrgencyRepository interface {
 	GetByID(ctx context.Context, id uint, urgency *model.Urgency) error
 	GetByIDPrimary(ctx context.Context, id uint, urgency *model.Urgency) error
 	Update(ctx context.Context, urgency *model.Urgency) error
+	SaveWithEvent(ctx context.Context, urgency *model.Urgency, from urgencyV1.UrgencyStatus, event *model.UrgencyEvent) (bool, error)
+	CreateEvent(ctx context.Context, event *model.UrgencyEvent) error
+	MergeInto(ctx context.Context, source *model.Urgency, targetID uint, sourceEvent, targetEvent *model.UrgencyEvent) (int64, error)
+	ListEvents(ctx context.Context, urgencyID uint) ([]model.UrgencyEvent, error)
+	AssignIfUnassigned(ctx context.Context, urgencyID, employeeID uint, assignedAt time.Time, event *model.UrgencyEvent) (bool, error)
+	ListTeam(ctx context.Context, urgencyID uint) ([]model.UrgencyAssignment, error)
+	AddTeamMember(ctx context.Context, member *model.UrgencyAssignment, event *model.UrgencyEvent) (bool, error)
+	RemoveTeamMember(ctx context.Context, urgencyID, employeeID uint, at time.Time, event *model.UrgencyEvent) (bool, error)
 	Delete(ctx context.Context, urgencyID uint) error
-	ListPaginated(ctx context.Context, page int, pageSize int, assignedEmployeeID *uint) ([]model.Urgency, int64, error)
+	ListPaginated(ctx context.Context, filter model.UrgencyFilter) ([]model.Urgency, int64, error)
 	List(ctx context.Context, filters map[string]interface{}) ([]model.Urgency, error)
 	ListUnassignedIDs(ctx context.Context) ([]uint, error)
+	ListActiveIDsForEmployee(ctx context.Context, employeeID uint) ([]uint, error)
+	ListWithinBounds(ctx context.Context, minLat, maxLat, minLng, maxLng float64, limit int) ([]model.Urgency, error)
+	ListRecentActive(ctx context.Context, since time.Time) ([]model.Urgency, error)
+	ListForStats(ctx context.Context, from, to time.Time) ([]model.Urgency, error)
 	ResetAllData(ctx context.Context) error
+
+	ListEscalationCandidates(ctx context.Context) ([]model.Urgency, error)
+	ClaimEscalationStep(ctx context.Context, escalation *model.UrgencyEscalation) (bool, error)
+	ListEscalations(ctx context.Context, urgencyID uint) ([]model.UrgencyEscalation, error)
+
+	GetByTrackingTokenHash(ctx context.Context, hash string, urgency *model.Urgency) error
+	CreateFollowUp(ctx context.Context, followUp *model.ReporterFollowUp) error
+	ListFollowUps(ctx context.Context, urgencyID uint) ([]model.ReporterFollowUp, error)
+
+	CreateAttachment(ctx context.Context, attachment *model.Attachment) error
+	GetAttachment(ctx context.Context, urgencyID, attachmentID uint, attachment *model.Attachment) error
+	ListAttachments(ctx context.Context, urgencyID uint, activityID *uint) ([]model.Attachment, error)
+	CountAttachments(ctx context.Context, urgencyID uint) (int64, error)
+	DeleteAttachment(ctx context.Context, attachmentID uint) error
+
+	ListIntakeReview(ctx context.Context) ([]model.Urgency, error)
+	UpdateIntake(ctx context.Context, urgencyID uint, from []urgencyV1.IntakeStatus, to urgencyV1.IntakeStatus, event *model.UrgencyEvent) (bool, error)
+	SetVerificationCode(ctx context.Context, urgencyID uint, hash string, expiresAt time.Time) (bool, error)
+	RecordVerificationAttempt(ctx context.Context, urgencyID uint) (int, error)
 }
 
 type urgencyRepository struct {
@@ -45,10 +79,20 @@ func NewUrgencyRepositoryRW(log utils.Logger, writeDB *gorm.DB, readDB *gorm.DB)
 	return &urgencyRepository{log: log.WithName("urgencyRepository"), dbWrite: writeDB, dbRead: readDB}
 }
 
+// Create stores a new urgency together with its urgency.created outbox event.
+// Urgencies held back by the intake checks are published once UpdateIntake accepts them.
 func (r *urgencyRepository) Create(ctx context.Context, urgency *model.Urgency) error {
 	log := r.log.WithContext(ctx)
 	defer utils.TimeOperation(log, "UrgencyRepository.Create")()
-	return r.dbWrite.WithContext(ctx).Create(urgency).Error
+	return r.dbWrite.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(urgency).Error; err != nil {
+			return err
+		}
+		if !urgency.IntakeAccepted() {
+			return nil
+		}
+		return appendOutbox(tx, urgencyV1.EventUrgencyCreated, urgency, nil, urgency.CreatedAt)
+	})
 }
 
 func (r *urgencyRepository) GetAll(ctx context.Context) ([]model.Urgency, error) {
@@ -75,8 +119,373 @@ func (r *urgencyRepository) Update(ctx context.Context, urgency *model.Urgency)
 	return r.dbWrite.WithContext(ctx).Save(urgency).Error
 }
 
+// SaveWithEvent persists the urgency and appends a history event in the same transaction, but only
+// while the stored urgency still has the status the change was checked against. It reports false when
+// another request changed the status first. Previous status and assignee are read from the stored row
+// inside the transaction.
+func (r *urgencyRepository) SaveWithEvent(ctx context.Context, urgency *model.Urgency, from urgencyV1.UrgencyStatus, event *model.UrgencyEvent) (bool, error) {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyRepository.SaveWithEvent")()
+
+	saved := false
+	err := r.dbWrite.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var prev model.Urgency
+		if err := tx.Select("id", "status", "assigned_employee_id").First(&prev, "id = ?", urgency.ID).Error; err != nil {
+			return err
+		}
+		if prev.Status != from {
+			return nil
+		}
+		res := tx.Model(urgency).Where("status = ?", from).Select("*").Updates(urgency)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected != 1 {
+			return nil
+		}
+		saved = true
+		at := time.Now().UTC()
+		if event.Type == model.UrgencyEventReopened {
+			// a reopened urgency starts over with a new team
+			if err := endTeamMemberships(tx, urgency.ID, nil, at); err != nil {
+				return err
+			}
+		} else if err := syncLead(tx, urgency.ID, prev.AssignedEmployeeID, urgency.AssignedEmployeeID, event.ActorID, at); err != nil {
+			return err
+		}
+		event.UrgencyID = urgency.ID
+		event.OldStatus = prev.Status
+		event.NewStatus = urgency.Status
+		event.OldAssigneeID = prev.AssignedEmployeeID
+		event.NewAssigneeID = urgency.AssignedEmployeeID
+		if err := tx.Create(event).Error; err != nil {
+			return err
+		}
+		if eventType, ok := model.OutboxEventType(event.Type); ok {
+			return appendOutbox(tx, eventType, urgency, event.ActorID, event.CreatedAt)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return saved, nil
+}
+
+// appendOutbox writes a lifecycle event to the outbox inside the caller's transaction, so
+// the event is published if and only if the state change is committed
+func appendOutbox(tx *gorm.DB, eventType urgencyV1.UrgencyEventType, urgency *model.Urgency, actorID *uint, at time.Time) error {
+	if at.IsZero() {
+		at = time.Now().UTC()
+	}
+	outbox, err := model.NewOutboxEvent(eventType, urgency, actorID, at)
+	if err != nil {
+		return err
+	}
+	return tx.Create(outbox).Error
+}
+
+// appendStoredOutbox reloads the urgency inside the transaction before appending the event, for
+// changes made with column updates rather than by saving the whole urgency
+func appendStoredOutbox(tx *gorm.DB, eventType urgencyV1.UrgencyEventType, urgencyID uint, actorID *uint, at time.Time) error {
+	var urgency model.Urgency
+	if err := tx.First(&urgency, "id = ?", urgencyID).Error; err != nil {
+		return err
+	}
+	return appendOutbox(tx, eventType, &urgency, actorID, at)
+}
+
+// syncLead keeps the lead team membership in line with the urgency assignee. An existing member
+// promoted to lead leaves their previous role.
+func syncLead(tx *gorm.DB, urgencyID uint, oldLead, newLead, addedBy *uint, at time.Time) error {
+	if oldLead == nil && newLead == nil || oldLead != nil && newLead != nil && *oldLead == *newLead {
+		return nil
+	}
+	if oldLead != nil {
+		if err := tx.Model(&model.UrgencyAssignment{}).
+			Where("urgency_id = ? AND employee_id = ? AND role = ? AND left_at IS NULL", urgencyID, *oldLead, urgencyV1.RoleLead).
+			Update("left_at", at).Error; err != nil {
+			return err
+		}
+	}
+	if newLead == nil {
+		return nil
+	}
+	if err := endTeamMemberships(tx, urgencyID, newLead, at); err != nil {
+		return err
+	}
+	return tx.Create(&model.UrgencyAssignment{UrgencyID: urgencyID, EmployeeID: *newLead, Role: urgencyV1.RoleLead, AddedBy: addedBy, JoinedAt: at}).Error
+}
+
+// endTeamMemberships ends the active memberships of one employee, or of the whole team when employeeID is nil
+func endTeamMemberships(tx *gorm.DB, urgencyID uint, employeeID *uint, at time.Time) error {
+	q := tx.Model(&model.UrgencyAssignment{}).Where("urgency_id = ? AND left_at IS NULL", urgencyID)
+	if employeeID != nil {
+		q = q.Where("employee_id = ?", *employeeID)
+	}
+	return q.Update("left_at", at).Error
+}
+
+func (r *urgencyRepository) CreateEvent(ctx context.Context, event *model.UrgencyEvent) error {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyRepository.CreateEvent")()
+	return r.dbWrite.WithContext(ctx).Create(event).Error
+}
+
+// MergeInto moves the source urgency's notifications onto the target, saves the already updated
+// source and records an event on both urgencies in one transaction. It returns how many
+// notifications were moved.
+func (r *urgencyRepository) MergeInto(ctx context.Context, source *model.Urgency, targetID uint, sourceEvent, targetEvent *model.UrgencyEvent) (int64, error) {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyRepository.MergeInto")()
+
+	var moved int64
+	err := r.dbWrite.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var prev model.Urgency
+		if err := tx.Select("id", "status", "assigned_employee_id").First(&prev, "id = ?", source.ID).Error; err != nil {
+			return err
+		}
+		res := tx.Model(&model.Notification{}).Where("urgency_id = ?", source.ID).Update("urgency_id", targetID)
+		if res.Error != nil {
+			return res.Error
+		}
+		moved = res.RowsAffected
+		if err := tx.Save(source).Error; err != nil {
+			return err
+		}
+		sourceEvent.UrgencyID = source.ID
+		sourceEvent.OldStatus = prev.Status
+		sourceEvent.NewStatus = source.Status
+		sourceEvent.OldAssigneeID = prev.AssignedEmployeeID
+		sourceEvent.NewAssigneeID = source.AssignedEmployeeID
+		targetEvent.UrgencyID = targetID
+		if err := tx.Create([]*model.UrgencyEvent{sourceEvent, targetEvent}).Error; err != nil {
+			return err
+		}
+		return appendOutbox(tx, urgencyV1.EventUrgencyUpdated, source, sourceEvent.ActorID, sourceEvent.CreatedAt)
+	})
+	return moved, err
+}
+
+func (r *urgencyRepository) ListEvents(ctx context.Context, urgencyID uint) ([]model.UrgencyEvent, error) {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyRepository.ListEvents")()
+	var events []model.UrgencyEvent
+	err := r.withRead(ctx, func(db *gorm.DB) error {
+		return db.Where("urgency_id = ?", urgencyID).Order("created_at ASC, id ASC").Find(&events).Error
+	})
+	return events, err
+}
+
+// AssignIfUnassigned atomically assigns the urgency to the employee only if nobody holds it yet
+// and it is still active and let through by the intake checks. It reports false when another
+// assignment won the race. On success the given event, accepted or assigned, is appended in the
+// same transaction.
+func (r *urgencyRepository) AssignIfUnassigned(ctx context.Context, urgencyID, employeeID uint, assignedAt time.Time, event *model.UrgencyEvent) (bool, error) {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyRepository.AssignIfUnassigned")()
+
+	won := false
+	err := r.dbWrite.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var prev model.Urgency
+		if err := tx.Select("id", "status").First(&prev, "id = ?", urgencyID).Error; err != nil {
+			return err
+		}
+		res := tx.Model(&model.Urgency{}).
+			Where("id = ? AND assigned_employee_id IS NULL AND status IN ? AND intake_status = ?", urgencyID, []urgencyV1.UrgencyStatus{urgencyV1.Open, urgencyV1.InProgress}, urgencyV1.IntakeAccepted).
+			Updates(map[string]interface{}{
+				"assigned_employee_id": employeeID,
+				"assigned_at":          assignedAt,
+				"status":               urgencyV1.InProgress,
+				"sort_priority":        model.ComputeSortPriority(urgencyV1.InProgress, &employeeID),
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected != 1 {
+			return nil
+		}
+		won = true
+		if err := syncLead(tx, urgencyID, nil, &employeeID, event.ActorID, assignedAt); err != nil {
+			return err
+		}
+		event.UrgencyID = urgencyID
+		event.OldStatus = prev.Status
+		event.NewStatus = urgencyV1.InProgress
+		event.NewAssigneeID = &employeeID
+		event.CreatedAt = assignedAt
+		if err := tx.Create(event).Error; err != nil {
+			return err
+		}
+		return appendStoredOutbox(tx, urgencyV1.EventUrgencyAssigned, urgencyID, event.ActorID, assignedAt)
+	})
+	if err != nil {
+		return false, err
+	}
+	return won, nil
+}
+
+// ListTeam returns the active team members of an urgency in the order they joined
+func (r *urgencyRepository) ListTeam(ctx context.Context, urgencyID uint) ([]model.UrgencyAssignment, error) {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyRepository.ListTeam")()
+	var members []model.UrgencyAssignment
+	err := r.withRead(ctx, func(db *gorm.DB) error {
+		return db.Where("urgency_id = ? AND left_at IS NULL", urgencyID).
+			Order("joined_at ASC, id ASC").
+			Find(&members).Error
+	})
+	return members, err
+}
+
+// AddTeamMember adds a responder to an active urgency and records the event in one transaction.
+// A lead also becomes the urgency assignee. It returns false without changes when the employee is
+// already on the team or, for a lead, when the urgency got a lead or stopped being active meanwhile.
+func (r *urgencyRepository) AddTeamMember(ctx context.Context, member *model.UrgencyAssignment, event *model.UrgencyEvent) (bool, error) {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyRepository.AddTeamMember")()
+
+	added := false
+	err := r.dbWrite.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var prev model.Urgency
+		if err := tx.Select("id", "status", "assigned_employee_id").First(&prev, "id = ?", member.UrgencyID).Error; err != nil {
+			return err
+		}
+		var existing int64
+		if err := tx.Model(&model.UrgencyAssignment{}).
+			Where("urgency_id = ? AND employee_id = ? AND left_at IS NULL", member.UrgencyID, member.EmployeeID).
+			Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return nil
+		}
+
+		event.UrgencyID = member.UrgencyID
+		event.OldStatus = prev.Status
+		event.NewStatus = prev.Status
+		event.OldAssigneeID = prev.AssignedEmployeeID
+		event.NewAssigneeID = prev.AssignedEmployeeID
+		if member.Role == urgencyV1.RoleLead {
+			res := tx.Model(&model.Urgency{}).
+				Where("id = ? AND assigned_employee_id IS NULL AND status IN ? AND intake_status = ?", member.UrgencyID, []urgencyV1.UrgencyStatus{urgencyV1.Open, urgencyV1.InProgress}, urgencyV1.IntakeAccepted).
+				Updates(map[string]interface{}{
+					"assigned_employee_id": member.EmployeeID,
+					"assigned_at":          member.JoinedAt,
+					"status":               urgencyV1.InProgress,
+					"sort_priority":        model.ComputeSortPriority(urgencyV1.InProgress, &member.EmployeeID),
+				})
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected != 1 {
+				return nil
+			}
+			event.NewStatus = urgencyV1.InProgress
+			event.NewAssigneeID = &member.EmployeeID
+		}
+
+		if err := tx.Create(member).Error; err != nil {
+			return err
+		}
+		added = true
+		if err := tx.Create(event).Error; err != nil {
+			return err
+		}
+		if member.Role != urgencyV1.RoleLead {
+			return nil
+		}
+		return appendStoredOutbox(tx, urgencyV1.EventUrgencyAssigned, member.UrgencyID, event.ActorID, member.JoinedAt)
+	})
+	if err != nil {
+		return false, err
+	}
+	return added, nil
+}
+
+// RemoveTeamMember ends an employee's active membership and records the event in one transaction.
+// Removing the lead also clears the urgency assignee. It returns false without changes when the employee is
+// not on the team or the urgency is no longer open or in progress.
+func (r *urgencyRepository) RemoveTeamMember(ctx context.Context, urgencyID, employeeID uint, at time.Time, event *model.UrgencyEvent) (bool, error) {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyRepository.RemoveTeamMember")()
+
+	removed := false
+	err := r.dbWrite.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var member model.UrgencyAssignment
+		res := tx.Where("urgency_id = ? AND employee_id = ? AND left_at IS NULL", urgencyID, employeeID).Limit(1).Find(&member)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+		var prev model.Urgency
+		if err := tx.Select("id", "status", "assigned_employee_id").First(&prev, "id = ?", urgencyID).Error; err != nil {
+			return err
+		}
+		if prev.Status != urgencyV1.Open && prev.Status != urgencyV1.InProgress {
+			return nil
+		}
+
+		event.UrgencyID = urgencyID
+		event.OldStatus = prev.Status
+		event.NewStatus = prev.Status
+		event.OldAssigneeID = prev.AssignedEmployeeID
+		event.NewAssigneeID = prev.AssignedEmployeeID
+		if member.Role == urgencyV1.RoleLead {
+			res := tx.Model(&model.Urgency{}).
+				Where("id = ? AND status IN ?", urgencyID, []urgencyV1.UrgencyStatus{urgencyV1.Open, urgencyV1.InProgress}).
+				Updates(map[string]interface{}{
+					"assigned_employee_id": nil,
+					"assigned_at":          nil,
+					"sort_priority":        model.ComputeSortPriority(prev.Status, nil),
+				})
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected != 1 {
+				return nil
+			}
+			event.NewAssigneeID = nil
+		}
+		if err := endTeamMemberships(tx, urgencyID, &employeeID, at); err != nil {
+			return err
+		}
+		removed = true
+		if err := tx.Create(event).Error; err != nil {
+			return err
+		}
+		if member.Role != urgencyV1.RoleLead {
+			return nil
+		}
+		return appendStoredOutbox(tx, urgencyV1.EventUrgencyUnassigned, urgencyID, event.ActorID, at)
+	})
+	if err != nil {
+		return false, err
+	}
+	return removed, nil
+}
+
+// Delete soft deletes the urgency and appends its urgency.deleted outbox event in one transaction.
+// Deleting an urgency that does not exist is a no-op.
 func (r *urgencyRepository) Delete(ctx context.Context, urgencyID uint) error {
-	return r.dbWrite.WithContext(ctx).Delete(&model.Urgency{}, urgencyID).Error
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyRepository.Delete")()
+	return r.dbWrite.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var urgency model.Urgency
+		res := tx.Limit(1).Find(&urgency, "id = ?", urgencyID)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+		if err := tx.Delete(&urgency).Error; err != nil {
+			return err
+		}
+		return appendOutbox(tx, urgencyV1.EventUrgencyDeleted, &urgency, nil, time.Now().UTC())
+	})
 }
 
 func (r *urgencyRepository) List(ctx context.Context, filters map[string]interface{}) ([]model.Urgency, error) {
@@ -107,12 +516,23 @@ func (r *urgencyRepository) List(ctx context.Context, filters map[string]interfa
 	return urgencies, nil
 }
 
-func (r *urgencyRepository) ListPaginated(ctx context.Context, page int, pageSize int, assignedEmployeeID *uint) ([]model.Urgency, int64, error) {
+// searchExpr must stay in sync with idx_urgency_search_trgm in migrations/004_urgency_list_filters.sql
+const searchExpr = "LOWER(COALESCE(first_name, '') || ' ' || COALESCE(last_name, '') || ' ' || COALESCE(location, '') || ' ' || COALESCE(description, ''))"
+
+// teamMemberExpr matches urgencies an employee leads or is an active team member of; it takes the employee ID twice
+const teamMemberExpr = "(assigned_employee_id = ? OR id IN (SELECT urgency_id FROM urgency_assignments WHERE employee_id = ? AND left_at IS NULL))"
+
+const levelRankExpr = "CASE level WHEN 'critical' THEN 4 WHEN 'high' THEN 3 WHEN 'medium' THEN 2 WHEN 'low' THEN 1 ELSE 0 END DESC"
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func (r *urgencyRepository) ListPaginated(ctx context.Context, filter model.UrgencyFilter) ([]model.Urgency, int64, error) {
 	log := r.log.WithContext(ctx)
 	defer utils.TimeOperation(log, "UrgencyRepository.ListPaginated")()
 	var urgencies []model.Urgency
 	var total int64
 
+	page, pageSize := filter.Page, filter.PageSize
 	if page < 1 {
 		page = 1
 	}
@@ -126,18 +546,11 @@ func (r *urgencyRepository) ListPaginated(ctx context.Context, page int, pageSiz
 	offset := (page - 1) * pageSize
 
 	if err := r.withRead(ctx, func(db *gorm.DB) error {
-		q := db.Model(&model.Urgency{}).Where("deleted_at IS NULL")
-		if assignedEmployeeID != nil {
-			q = q.Where("assigned_employee_id = ?", *assignedEmployeeID)
-		}
+		q := applyUrgencyFilter(db.Model(&model.Urgency{}).Where("deleted_at IS NULL"), filter)
 		if err := q.Count(&total).Error; err != nil {
 			return err
 		}
-		orderExpr := "sort_priority ASC, created_at DESC"
-		if assignedEmployeeID != nil {
-			orderExpr = "created_at DESC, CASE level WHEN 'Critical' THEN 4 WHEN 'High' THEN 3 WHEN 'Medium' THEN 2 WHEN 'Low' THEN 1 ELSE 0 END DESC"
-		}
-		return q.Order(orderExpr).Limit(pageSize).Offset(offset).Find(&urgencies).Error
+		return q.Order(urgencyOrder(filter)).Limit(pageSize).Offset(offset).Find(&urgencies).Error
 	}); err != nil {
 		return nil, 0, err
 	}
@@ -145,13 +558,77 @@ func (r *urgencyRepository) ListPaginated(ctx context.Context, page int, pageSiz
 	return urgencies, total, nil
 }
 
+func applyUrgencyFilter(q *gorm.DB, filter model.UrgencyFilter) *gorm.DB {
+	if len(filter.Statuses) > 0 {
+		q = q.Where("status IN ?", filter.Statuses)
+	}
+	if len(filter.Levels) > 0 {
+		q = q.Where("level IN ?", filter.Levels)
+	}
+	if filter.From != nil {
+		q = q.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		q = q.Where("created_at < ?", *filter.To)
+	}
+	if filter.AssignedEmployeeID != nil {
+		q = q.Where("assigned_employee_id = ?", *filter.AssignedEmployeeID)
+	}
+	if filter.TeamMemberID != nil {
+		q = q.Where(teamMemberExpr, *filter.TeamMemberID, *filter.TeamMemberID)
+	}
+	if filter.UnassignedOnly {
+		q = q.Where("assigned_employee_id IS NULL")
+	}
+	if filter.AcceptedIntakeOnly {
+		q = q.Where("intake_status = ?", urgencyV1.IntakeAccepted)
+	}
+	if text := strings.ToLower(strings.TrimSpace(filter.Query)); text != "" {
+		q = q.Where(searchExpr+` LIKE ? ESCAPE '\'`, "%"+likeEscaper.Replace(text)+"%")
+	}
+	return q
+}
+
+func urgencyOrder(filter model.UrgencyFilter) string {
+	switch filter.Sort {
+	case urgencyV1.SortNewest:
+		return "created_at DESC, id DESC"
+	case urgencyV1.SortOldest:
+		return "created_at ASC, id ASC"
+	case urgencyV1.SortLevel:
+		return levelRankExpr + ", created_at DESC"
+	case urgencyV1.SortPriority:
+		return "sort_priority ASC, created_at DESC"
+	}
+	if filter.AssignedEmployeeID != nil || filter.TeamMemberID != nil {
+		return "created_at DESC, " + levelRankExpr
+	}
+	return "sort_priority ASC, created_at DESC"
+}
+
+// ListActiveIDsForEmployee returns the in-progress urgencies the employee leads or is an active team member of.
+// It reads from the primary because callers use it to guard deleting the employee or removing their shifts.
+func (r *urgencyRepository) ListActiveIDsForEmployee(ctx context.Context, employeeID uint) ([]uint, error) {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyRepository.ListActiveIDsForEmployee")()
+	var ids []uint
+	if err := r.dbWrite.WithContext(ctx).Model(&model.Urgency{}).
+		Where("status = ?", urgencyV1.InProgress).
+		Where(teamMemberExpr, employeeID, employeeID).
+		Order("id ASC").
+		Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
 func (r *urgencyRepository) ListUnassignedIDs(ctx context.Context) ([]uint, error) {
 	log := r.log.WithContext(ctx)
 	defer utils.TimeOperation(log, "UrgencyRepository.ListUnassignedIDs")()
 	var ids []uint
 	if err := r.withRead(ctx, func(db *gorm.DB) error {
 		return db.Model(&model.Urgency{}).
-			Where("deleted_at IS NULL AND sort_priority = ?", 1).
+			Where("deleted_at IS NULL AND sort_priority = ? AND intake_status = ?", 1, urgencyV1.IntakeAccepted).
 			Pluck("id", &ids).Error
 	}); err != nil {
 		return nil, err
@@ -159,10 +636,291 @@ func (r *urgencyRepository) ListUnassignedIDs(ctx context.Context) ([]uint, erro
 	return ids, nil
 }
 
+// ListForStats returns the timing columns of urgencies created in [from, to), oldest first.
+// Linked duplicate reports are left out so that one incident is counted once.
+func (r *urgencyRepository) ListForStats(ctx context.Context, from, to time.Time) ([]model.Urgency, error) {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyRepository.ListForStats")()
+	var urgencies []model.Urgency
+	err := r.withRead(ctx, func(db *gorm.DB) error {
+		return db.Model(&model.Urgency{}).
+			Select("id", "level", "status", "assigned_employee_id", "assigned_at", "resolved_at", "closed_at", "created_at").
+			Where("deleted_at IS NULL AND duplicate_of_id IS NULL AND created_at >= ? AND created_at < ?", from, to).
+			Order("created_at ASC").
+			Find(&urgencies).Error
+	})
+	return urgencies, err
+}
+
+// ListWithinBounds returns up to limit urgencies with coordinates inside the given bounding box, newest first
+func (r *urgencyRepository) ListWithinBounds(ctx context.Context, minLat, maxLat, minLng, maxLng float64, limit int) ([]model.Urgency, error) {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyRepository.ListWithinBounds")()
+	var urgencies []model.Urgency
+	err := r.withRead(ctx, func(db *gorm.DB) error {
+		return db.Where("deleted_at IS NULL AND latitude BETWEEN ? AND ? AND longitude BETWEEN ? AND ?", minLat, maxLat, minLng, maxLng).
+			Order("created_at DESC").
+			Limit(limit).
+			Find(&urgencies).Error
+	})
+	return urgencies, err
+}
+
+// ListRecentActive returns open or in-progress urgencies created since the given time that are not
+// duplicates themselves. It reads from primary so that reports arriving seconds apart see each other.
+func (r *urgencyRepository) ListRecentActive(ctx context.Context, since time.Time) ([]model.Urgency, error) {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyRepository.ListRecentActive")()
+	var urgencies []model.Urgency
+	err := r.dbWrite.WithContext(ctx).
+		Where("deleted_at IS NULL AND duplicate_of_id IS NULL AND intake_status = ? AND status IN ? AND created_at >= ?", urgencyV1.IntakeAccepted, []urgencyV1.UrgencyStatus{urgencyV1.Open, urgencyV1.InProgress}, since).
+		Order("created_at DESC").
+		Find(&urgencies).Error
+	return urgencies, err
+}
+
 func (r *urgencyRepository) ResetAllData(ctx context.Context) error {
 	return r.dbWrite.WithContext(ctx).Unscoped().Delete(&model.Urgency{}, "1 = 1").Error
 }
 
+// ListEscalationCandidates returns open, unassigned urgencies that have not reached the last escalation step.
+// Reports linked to another urgency as duplicates, or not yet let through by the intake checks, are never escalated.
+// It always reads from primary so that escalation decisions are based on the latest state.
+func (r *urgencyRepository) ListEscalationCandidates(ctx context.Context) ([]model.Urgency, error) {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyRepository.ListEscalationCandidates")()
+	var urgencies []model.Urgency
+	err := r.dbWrite.WithContext(ctx).
+		Where("deleted_at IS NULL AND status = ? AND assigned_employee_id IS NULL AND duplicate_of_id IS NULL AND intake_status = ? AND escalation_level < ?", urgencyV1.Open, urgencyV1.IntakeAccepted, model.MaxEscalationStep).
+		Order("created_at ASC").
+		Find(&urgencies).Error
+	return urgencies, err
+}
+
+// ClaimEscalationStep advances the urgency to the step of the escalation only if it is still at the previous
+// step and unacknowledged, so concurrent workers never fire the same step twice. The escalation record and
+// its outbox event are written in the same transaction as the claim.
+func (r *urgencyRepository) ClaimEscalationStep(ctx context.Context, escalation *model.UrgencyEscalation) (bool, error) {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyRepository.ClaimEscalationStep")()
+
+	claimed := false
+	err := r.dbWrite.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&model.Urgency{}).
+			Where("id = ? AND escalation_level = ? AND status = ? AND assigned_employee_id IS NULL", escalation.UrgencyID, escalation.Step-1, urgencyV1.Open).
+			Updates(map[string]interface{}{"escalation_level": escalation.Step, "last_escalated_at": escalation.CreatedAt})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected != 1 {
+			return nil
+		}
+		claimed = true
+		if err := tx.Create(escalation).Error; err != nil {
+			return err
+		}
+		return appendStoredOutbox(tx, urgencyV1.EventUrgencyUpdated, escalation.UrgencyID, nil, escalation.CreatedAt)
+	})
+	if err != nil {
+		return false, err
+	}
+	return claimed, nil
+}
+
+func (r *urgencyRepository) ListEscalations(ctx context.Context, urgencyID uint) ([]model.UrgencyEscalation, error) {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyRepository.ListEscalations")()
+	var escalations []model.UrgencyEscalation
+	err := r.withRead(ctx, func(db *gorm.DB) error {
+		return db.Where("urgency_id = ?", urgencyID).Order("step ASC").Find(&escalations).Error
+	})
+	return escalations, err
+}
+
+// GetByTrackingTokenHash finds the urgency a public tracking link belongs to
+func (r *urgencyRepository) GetByTrackingTokenHash(ctx context.Context, hash string, urgency *model.Urgency) error {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyRepository.GetByTrackingTokenHash")()
+	if hash == "" {
+		return gorm.ErrRecordNotFound
+	}
+	return r.withRead(ctx, func(db *gorm.DB) error {
+		return db.First(urgency, "tracking_token_hash = ?", hash).Error
+	})
+}
+
+func (r *urgencyRepository) CreateFollowUp(ctx context.Context, followUp *model.ReporterFollowUp) error {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyRepository.CreateFollowUp")()
+	return r.dbWrite.WithContext(ctx).Create(followUp).Error
+}
+
+func (r *urgencyRepository) ListFollowUps(ctx context.Context, urgencyID uint) ([]model.ReporterFollowUp, error) {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyRepository.ListFollowUps")()
+	var followUps []model.ReporterFollowUp
+	err := r.withRead(ctx, func(db *gorm.DB) error {
+		return db.Where("urgency_id = ?", urgencyID).Order("created_at ASC, id ASC").Find(&followUps).Error
+	})
+	return followUps, err
+}
+
+func (r *urgencyRepository) CreateAttachment(ctx context.Context, attachment *model.Attachment) error {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyRepository.CreateAttachment")()
+	return r.dbWrite.WithContext(ctx).Create(attachment).Error
+}
+
+// GetAttachment finds an attachment of the given urgency; attachments of other urgencies are not found
+func (r *urgencyRepository) GetAttachment(ctx context.Context, urgencyID, attachmentID uint, attachment *model.Attachment) error {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyRepository.GetAttachment")()
+	return r.withRead(ctx, func(db *gorm.DB) error {
+		return db.First(attachment, "id = ? AND urgency_id = ?", attachmentID, urgencyID).Error
+	})
+}
+
+// ListAttachments returns the attachments of an urgency in upload order, only those of one activity when activityID is set
+func (r *urgencyRepository) ListAttachments(ctx context.Context, urgencyID uint, activityID *uint) ([]model.Attachment, error) {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyRepository.ListAttachments")()
+	var attachments []model.Attachment
+	err := r.withRead(ctx, func(db *gorm.DB) error {
+		q := db.Where("urgency_id = ?", urgencyID)
+		if activityID != nil {
+			q = q.Where("activity_id = ?", *activityID)
+		}
+		return q.Order("created_at ASC, id ASC").Find(&attachments).Error
+	})
+	return attachments, err
+}
+
+// CountAttachments counts on the primary so a burst of uploads cannot slip past the per-urgency cap through replica lag
+func (r *urgencyRepository) CountAttachments(ctx context.Context, urgencyID uint) (int64, error) {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyRepository.CountAttachments")()
+	var count int64
+	err := r.dbWrite.WithContext(ctx).Model(&model.Attachment{}).Where("urgency_id = ?", urgencyID).Count(&count).Error
+	return count, err
+}
+
+func (r *urgencyRepository) DeleteAttachment(ctx context.Context, attachmentID uint) error {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyRepository.DeleteAttachment")()
+	res := r.dbWrite.WithContext(ctx).Delete(&model.Attachment{}, "id = ?", attachmentID)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ListIntakeReview returns the reports still waiting for phone verification or admin review, oldest first
+func (r *urgencyRepository) ListIntakeReview(ctx context.Context) ([]model.Urgency, error) {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyRepository.ListIntakeReview")()
+	var urgencies []model.Urgency
+	err := r.withRead(ctx, func(db *gorm.DB) error {
+		return db.Where("deleted_at IS NULL AND intake_status IN ?", []urgencyV1.IntakeStatus{urgencyV1.IntakePendingVerification, urgencyV1.IntakeQuarantined}).
+			Order("created_at ASC, id ASC").
+			Find(&urgencies).Error
+	})
+	return urgencies, err
+}
+
+// UpdateIntake moves the urgency to another intake status only while it is in one of from, and appends the
+// event in the same transaction. Quarantine stores the event reason, rejection cancels the urgency while it is
+// still open and unassigned, and acceptance writes the urgency.created outbox event that Create held back.
+func (r *urgencyRepository) UpdateIntake(ctx context.Context, urgencyID uint, from []urgencyV1.IntakeStatus, to urgencyV1.IntakeStatus, event *model.UrgencyEvent) (bool, error) {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyRepository.UpdateIntake")()
+
+	moved := false
+	err := r.dbWrite.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var prev model.Urgency
+		if err := tx.Select("id", "status").First(&prev, "id = ?", urgencyID).Error; err != nil {
+			return err
+		}
+		changes := map[string]interface{}{
+			"intake_status":          to,
+			"verification_code_hash": "",
+		}
+		switch to {
+		case urgencyV1.IntakeQuarantined:
+			changes["intake_reason"] = event.Reason
+		case urgencyV1.IntakeRejected:
+			changes["status"] = urgencyV1.Cancelled
+			changes["sort_priority"] = model.ComputeSortPriority(urgencyV1.Cancelled, nil)
+			changes["resolution_note"] = event.Reason
+		}
+		q := tx.Model(&model.Urgency{}).Where("id = ? AND intake_status IN ?", urgencyID, from)
+		if to == urgencyV1.IntakeRejected {
+			// only a report nobody is working on can be discarded
+			q = q.Where("status = ? AND assigned_employee_id IS NULL", urgencyV1.Open)
+		}
+		res := q.Updates(changes)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected != 1 {
+			return nil
+		}
+		moved = true
+
+		var urgency model.Urgency
+		if err := tx.First(&urgency, "id = ?", urgencyID).Error; err != nil {
+			return err
+		}
+		event.UrgencyID = urgencyID
+		event.OldStatus = prev.Status
+		event.NewStatus = urgency.Status
+		if err := tx.Create(event).Error; err != nil {
+			return err
+		}
+		if to == urgencyV1.IntakeAccepted {
+			return appendOutbox(tx, urgencyV1.EventUrgencyCreated, &urgency, event.ActorID, event.CreatedAt)
+		}
+		return nil
+	})
+	return moved, err
+}
+
+// SetVerificationCode replaces the one-time code of an urgency that still waits for verification
+// and resets the attempt counter
+func (r *urgencyRepository) SetVerificationCode(ctx context.Context, urgencyID uint, hash string, expiresAt time.Time) (bool, error) {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyRepository.SetVerificationCode")()
+	res := r.dbWrite.WithContext(ctx).Model(&model.Urgency{}).
+		Where("id = ? AND intake_status = ?", urgencyID, urgencyV1.IntakePendingVerification).
+		Updates(map[string]interface{}{
+			"verification_code_hash":  hash,
+			"verification_expires_at": expiresAt,
+			"verification_attempts":   0,
+		})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+// RecordVerificationAttempt counts a wrong code and returns the number of wrong codes so far.
+// The increment happens in the database so concurrent guesses are all counted.
+func (r *urgencyRepository) RecordVerificationAttempt(ctx context.Context, urgencyID uint) (int, error) {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyRepository.RecordVerificationAttempt")()
+	attempts := 0
+	err := r.dbWrite.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Urgency{}).Where("id = ?", urgencyID).
+			UpdateColumn("verification_attempts", gorm.Expr("verification_attempts + 1")).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.Urgency{}).Where("id = ?", urgencyID).Pluck("verification_attempts", &attempts).Error
+	})
+	return attempts, err
+}
+
 func (r *urgencyRepository) getReadDB(ctx context.Context) *gorm.DB {
 	if utils.IsFreshRequired(ctx) {
 		// Read-Your-Writes: route to primary within fresh window
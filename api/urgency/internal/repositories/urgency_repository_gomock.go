@@ -12,7 +12,9 @@ package repositories
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
+	v1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
 	model "github.com/pd120424d/mountain-service/api/urgency/internal/model"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -41,6 +43,66 @@ func (m *MockUrgencyRepository) EXPECT() *MockUrgencyRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddTeamMember mocks base method.
+func (m *MockUrgencyRepository) AddTeamMember(ctx context.Context, member *model.UrgencyAssignment, event *model.UrgencyEvent) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTeamMember", ctx, member, event)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTeamMember indicates an expected call of AddTeamMember.
+func (mr *MockUrgencyRepositoryMockRecorder) AddTeamMember(ctx, member, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTeamMember", reflect.TypeOf((*MockUrgencyRepository)(nil).AddTeamMember), ctx, member, event)
+}
+
+// AssignIfUnassigned mocks base method.
+func (m *MockUrgencyRepository) AssignIfUnassigned(ctx context.Context, urgencyID, employeeID uint, assignedAt time.Time, event *model.UrgencyEvent) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignIfUnassigned", ctx, urgencyID, employeeID, assignedAt, event)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignIfUnassigned indicates an expected call of AssignIfUnassigned.
+func (mr *MockUrgencyRepositoryMockRecorder) AssignIfUnassigned(ctx, urgencyID, employeeID, assignedAt, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignIfUnassigned", reflect.TypeOf((*MockUrgencyRepository)(nil).AssignIfUnassigned), ctx, urgencyID, employeeID, assignedAt, event)
+}
+
+// ClaimEscalationStep mocks base method.
+func (m *MockUrgencyRepository) ClaimEscalationStep(ctx context.Context, escalation *model.UrgencyEscalation) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimEscalationStep", ctx, escalation)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimEscalationStep indicates an expected call of ClaimEscalationStep.
+func (mr *MockUrgencyRepositoryMockRecorder) ClaimEscalationStep(ctx, escalation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimEscalationStep", reflect.TypeOf((*MockUrgencyRepository)(nil).ClaimEscalationStep), ctx, escalation)
+}
+
+// CountAttachments mocks base method.
+func (m *MockUrgencyRepository) CountAttachments(ctx context.Context, urgencyID uint) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAttachments", ctx, urgencyID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAttachments indicates an expected call of CountAttachments.
+func (mr *MockUrgencyRepositoryMockRecorder) CountAttachments(ctx, urgencyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAttachments", reflect.TypeOf((*MockUrgencyRepository)(nil).CountAttachments), ctx, urgencyID)
+}
+
 // Create mocks base method.
 func (m *MockUrgencyRepository) Create(ctx context.Context, urgency *model.Urgency) error {
 	m.ctrl.T.Helper()
@@ -55,6 +117,48 @@ func (mr *MockUrgencyRepositoryMockRecorder) Create(ctx, urgency any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUrgencyRepository)(nil).Create), ctx, urgency)
 }
 
+// CreateAttachment mocks base method.
+func (m *MockUrgencyRepository) CreateAttachment(ctx context.Context, attachment *model.Attachment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAttachment", ctx, attachment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAttachment indicates an expected call of CreateAttachment.
+func (mr *MockUrgencyRepositoryMockRecorder) CreateAttachment(ctx, attachment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAttachment", reflect.TypeOf((*MockUrgencyRepository)(nil).CreateAttachment), ctx, attachment)
+}
+
+// CreateEvent mocks base method.
+func (m *MockUrgencyRepository) CreateEvent(ctx context.Context, event *model.UrgencyEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEvent indicates an expected call of CreateEvent.
+func (mr *MockUrgencyRepositoryMockRecorder) CreateEvent(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvent", reflect.TypeOf((*MockUrgencyRepository)(nil).CreateEvent), ctx, event)
+}
+
+// CreateFollowUp mocks base method.
+func (m *MockUrgencyRepository) CreateFollowUp(ctx context.Context, followUp *model.ReporterFollowUp) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFollowUp", ctx, followUp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateFollowUp indicates an expected call of CreateFollowUp.
+func (mr *MockUrgencyRepositoryMockRecorder) CreateFollowUp(ctx, followUp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFollowUp", reflect.TypeOf((*MockUrgencyRepository)(nil).CreateFollowUp), ctx, followUp)
+}
+
 // Delete mocks base method.
 func (m *MockUrgencyRepository) Delete(ctx context.Context, urgencyID uint) error {
 	m.ctrl.T.Helper()
@@ -69,6 +173,20 @@ func (mr *MockUrgencyRepositoryMockRecorder) Delete(ctx, urgencyID any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUrgencyRepository)(nil).Delete), ctx, urgencyID)
 }
 
+// DeleteAttachment mocks base method.
+func (m *MockUrgencyRepository) DeleteAttachment(ctx context.Context, attachmentID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", ctx, attachmentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockUrgencyRepositoryMockRecorder) DeleteAttachment(ctx, attachmentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockUrgencyRepository)(nil).DeleteAttachment), ctx, attachmentID)
+}
+
 // GetAll mocks base method.
 func (m *MockUrgencyRepository) GetAll(ctx context.Context) ([]model.Urgency, error) {
 	m.ctrl.T.Helper()
@@ -84,6 +202,20 @@ func (mr *MockUrgencyRepositoryMockRecorder) GetAll(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockUrgencyRepository)(nil).GetAll), ctx)
 }
 
+// GetAttachment mocks base method.
+func (m *MockUrgencyRepository) GetAttachment(ctx context.Context, urgencyID, attachmentID uint, attachment *model.Attachment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachment", ctx, urgencyID, attachmentID, attachment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetAttachment indicates an expected call of GetAttachment.
+func (mr *MockUrgencyRepositoryMockRecorder) GetAttachment(ctx, urgencyID, attachmentID, attachment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachment", reflect.TypeOf((*MockUrgencyRepository)(nil).GetAttachment), ctx, urgencyID, attachmentID, attachment)
+}
+
 // GetByID mocks base method.
 func (m *MockUrgencyRepository) GetByID(ctx context.Context, id uint, urgency *model.Urgency) error {
 	m.ctrl.T.Helper()
@@ -112,6 +244,20 @@ func (mr *MockUrgencyRepositoryMockRecorder) GetByIDPrimary(ctx, id, urgency any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDPrimary", reflect.TypeOf((*MockUrgencyRepository)(nil).GetByIDPrimary), ctx, id, urgency)
 }
 
+// GetByTrackingTokenHash mocks base method.
+func (m *MockUrgencyRepository) GetByTrackingTokenHash(ctx context.Context, hash string, urgency *model.Urgency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTrackingTokenHash", ctx, hash, urgency)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetByTrackingTokenHash indicates an expected call of GetByTrackingTokenHash.
+func (mr *MockUrgencyRepositoryMockRecorder) GetByTrackingTokenHash(ctx, hash, urgency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTrackingTokenHash", reflect.TypeOf((*MockUrgencyRepository)(nil).GetByTrackingTokenHash), ctx, hash, urgency)
+}
+
 // List mocks base method.
 func (m *MockUrgencyRepository) List(ctx context.Context, filters map[string]any) ([]model.Urgency, error) {
 	m.ctrl.T.Helper()
@@ -127,10 +273,130 @@ func (mr *MockUrgencyRepositoryMockRecorder) List(ctx, filters any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUrgencyRepository)(nil).List), ctx, filters)
 }
 
+// ListActiveIDsForEmployee mocks base method.
+func (m *MockUrgencyRepository) ListActiveIDsForEmployee(ctx context.Context, employeeID uint) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveIDsForEmployee", ctx, employeeID)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveIDsForEmployee indicates an expected call of ListActiveIDsForEmployee.
+func (mr *MockUrgencyRepositoryMockRecorder) ListActiveIDsForEmployee(ctx, employeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveIDsForEmployee", reflect.TypeOf((*MockUrgencyRepository)(nil).ListActiveIDsForEmployee), ctx, employeeID)
+}
+
+// ListAttachments mocks base method.
+func (m *MockUrgencyRepository) ListAttachments(ctx context.Context, urgencyID uint, activityID *uint) ([]model.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAttachments", ctx, urgencyID, activityID)
+	ret0, _ := ret[0].([]model.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAttachments indicates an expected call of ListAttachments.
+func (mr *MockUrgencyRepositoryMockRecorder) ListAttachments(ctx, urgencyID, activityID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttachments", reflect.TypeOf((*MockUrgencyRepository)(nil).ListAttachments), ctx, urgencyID, activityID)
+}
+
+// ListEscalationCandidates mocks base method.
+func (m *MockUrgencyRepository) ListEscalationCandidates(ctx context.Context) ([]model.Urgency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEscalationCandidates", ctx)
+	ret0, _ := ret[0].([]model.Urgency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEscalationCandidates indicates an expected call of ListEscalationCandidates.
+func (mr *MockUrgencyRepositoryMockRecorder) ListEscalationCandidates(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEscalationCandidates", reflect.TypeOf((*MockUrgencyRepository)(nil).ListEscalationCandidates), ctx)
+}
+
+// ListEscalations mocks base method.
+func (m *MockUrgencyRepository) ListEscalations(ctx context.Context, urgencyID uint) ([]model.UrgencyEscalation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEscalations", ctx, urgencyID)
+	ret0, _ := ret[0].([]model.UrgencyEscalation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEscalations indicates an expected call of ListEscalations.
+func (mr *MockUrgencyRepositoryMockRecorder) ListEscalations(ctx, urgencyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEscalations", reflect.TypeOf((*MockUrgencyRepository)(nil).ListEscalations), ctx, urgencyID)
+}
+
+// ListEvents mocks base method.
+func (m *MockUrgencyRepository) ListEvents(ctx context.Context, urgencyID uint) ([]model.UrgencyEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEvents", ctx, urgencyID)
+	ret0, _ := ret[0].([]model.UrgencyEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEvents indicates an expected call of ListEvents.
+func (mr *MockUrgencyRepositoryMockRecorder) ListEvents(ctx, urgencyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvents", reflect.TypeOf((*MockUrgencyRepository)(nil).ListEvents), ctx, urgencyID)
+}
+
+// ListFollowUps mocks base method.
+func (m *MockUrgencyRepository) ListFollowUps(ctx context.Context, urgencyID uint) ([]model.ReporterFollowUp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFollowUps", ctx, urgencyID)
+	ret0, _ := ret[0].([]model.ReporterFollowUp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFollowUps indicates an expected call of ListFollowUps.
+func (mr *MockUrgencyRepositoryMockRecorder) ListFollowUps(ctx, urgencyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFollowUps", reflect.TypeOf((*MockUrgencyRepository)(nil).ListFollowUps), ctx, urgencyID)
+}
+
+// ListForStats mocks base method.
+func (m *MockUrgencyRepository) ListForStats(ctx context.Context, from, to time.Time) ([]model.Urgency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListForStats", ctx, from, to)
+	ret0, _ := ret[0].([]model.Urgency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListForStats indicates an expected call of ListForStats.
+func (mr *MockUrgencyRepositoryMockRecorder) ListForStats(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListForStats", reflect.TypeOf((*MockUrgencyRepository)(nil).ListForStats), ctx, from, to)
+}
+
+// ListIntakeReview mocks base method.
+func (m *MockUrgencyRepository) ListIntakeReview(ctx context.Context) ([]model.Urgency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIntakeReview", ctx)
+	ret0, _ := ret[0].([]model.Urgency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIntakeReview indicates an expected call of ListIntakeReview.
+func (mr *MockUrgencyRepositoryMockRecorder) ListIntakeReview(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIntakeReview", reflect.TypeOf((*MockUrgencyRepository)(nil).ListIntakeReview), ctx)
+}
+
 // ListPaginated mocks base method.
-func (m *MockUrgencyRepository) ListPaginated(ctx context.Context, page, pageSize int, assignedEmployeeID *uint) ([]model.Urgency, int64, error) {
+func (m *MockUrgencyRepository) ListPaginated(ctx context.Context, filter model.UrgencyFilter) ([]model.Urgency, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPaginated", ctx, page, pageSize, assignedEmployeeID)
+	ret := m.ctrl.Call(m, "ListPaginated", ctx, filter)
 	ret0, _ := ret[0].([]model.Urgency)
 	ret1, _ := ret[1].(int64)
 	ret2, _ := ret[2].(error)
@@ -138,9 +404,39 @@ func (m *MockUrgencyRepository) ListPaginated(ctx context.Context, page, pageSiz
 }
 
 // ListPaginated indicates an expected call of ListPaginated.
-func (mr *MockUrgencyRepositoryMockRecorder) ListPaginated(ctx, page, pageSize, assignedEmployeeID any) *gomock.Call {
+func (mr *MockUrgencyRepositoryMockRecorder) ListPaginated(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaginated", reflect.TypeOf((*MockUrgencyRepository)(nil).ListPaginated), ctx, filter)
+}
+
+// ListRecentActive mocks base method.
+func (m *MockUrgencyRepository) ListRecentActive(ctx context.Context, since time.Time) ([]model.Urgency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecentActive", ctx, since)
+	ret0, _ := ret[0].([]model.Urgency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecentActive indicates an expected call of ListRecentActive.
+func (mr *MockUrgencyRepositoryMockRecorder) ListRecentActive(ctx, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecentActive", reflect.TypeOf((*MockUrgencyRepository)(nil).ListRecentActive), ctx, since)
+}
+
+// ListTeam mocks base method.
+func (m *MockUrgencyRepository) ListTeam(ctx context.Context, urgencyID uint) ([]model.UrgencyAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTeam", ctx, urgencyID)
+	ret0, _ := ret[0].([]model.UrgencyAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTeam indicates an expected call of ListTeam.
+func (mr *MockUrgencyRepositoryMockRecorder) ListTeam(ctx, urgencyID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaginated", reflect.TypeOf((*MockUrgencyRepository)(nil).ListPaginated), ctx, page, pageSize, assignedEmployeeID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTeam", reflect.TypeOf((*MockUrgencyRepository)(nil).ListTeam), ctx, urgencyID)
 }
 
 // ListUnassignedIDs mocks base method.
@@ -158,6 +454,66 @@ func (mr *MockUrgencyRepositoryMockRecorder) ListUnassignedIDs(ctx any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnassignedIDs", reflect.TypeOf((*MockUrgencyRepository)(nil).ListUnassignedIDs), ctx)
 }
 
+// ListWithinBounds mocks base method.
+func (m *MockUrgencyRepository) ListWithinBounds(ctx context.Context, minLat, maxLat, minLng, maxLng float64, limit int) ([]model.Urgency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWithinBounds", ctx, minLat, maxLat, minLng, maxLng, limit)
+	ret0, _ := ret[0].([]model.Urgency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWithinBounds indicates an expected call of ListWithinBounds.
+func (mr *MockUrgencyRepositoryMockRecorder) ListWithinBounds(ctx, minLat, maxLat, minLng, maxLng, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWithinBounds", reflect.TypeOf((*MockUrgencyRepository)(nil).ListWithinBounds), ctx, minLat, maxLat, minLng, maxLng, limit)
+}
+
+// MergeInto mocks base method.
+func (m *MockUrgencyRepository) MergeInto(ctx context.Context, source *model.Urgency, targetID uint, sourceEvent, targetEvent *model.UrgencyEvent) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeInto", ctx, source, targetID, sourceEvent, targetEvent)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeInto indicates an expected call of MergeInto.
+func (mr *MockUrgencyRepositoryMockRecorder) MergeInto(ctx, source, targetID, sourceEvent, targetEvent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeInto", reflect.TypeOf((*MockUrgencyRepository)(nil).MergeInto), ctx, source, targetID, sourceEvent, targetEvent)
+}
+
+// RecordVerificationAttempt mocks base method.
+func (m *MockUrgencyRepository) RecordVerificationAttempt(ctx context.Context, urgencyID uint) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordVerificationAttempt", ctx, urgencyID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordVerificationAttempt indicates an expected call of RecordVerificationAttempt.
+func (mr *MockUrgencyRepositoryMockRecorder) RecordVerificationAttempt(ctx, urgencyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordVerificationAttempt", reflect.TypeOf((*MockUrgencyRepository)(nil).RecordVerificationAttempt), ctx, urgencyID)
+}
+
+// RemoveTeamMember mocks base method.
+func (m *MockUrgencyRepository) RemoveTeamMember(ctx context.Context, urgencyID, employeeID uint, at time.Time, event *model.UrgencyEvent) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTeamMember", ctx, urgencyID, employeeID, at, event)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveTeamMember indicates an expected call of RemoveTeamMember.
+func (mr *MockUrgencyRepositoryMockRecorder) RemoveTeamMember(ctx, urgencyID, employeeID, at, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTeamMember", reflect.TypeOf((*MockUrgencyRepository)(nil).RemoveTeamMember), ctx, urgencyID, employeeID, at, event)
+}
+
 // ResetAllData mocks base method.
 func (m *MockUrgencyRepository) ResetAllData(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -172,6 +528,36 @@ func (mr *MockUrgencyRepositoryMockRecorder) ResetAllData(ctx any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetAllData", reflect.TypeOf((*MockUrgencyRepository)(nil).ResetAllData), ctx)
 }
 
+// SaveWithEvent mocks base method.
+func (m *MockUrgencyRepository) SaveWithEvent(ctx context.Context, urgency *model.Urgency, from v1.UrgencyStatus, event *model.UrgencyEvent) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveWithEvent", ctx, urgency, from, event)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveWithEvent indicates an expected call of SaveWithEvent.
+func (mr *MockUrgencyRepositoryMockRecorder) SaveWithEvent(ctx, urgency, from, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveWithEvent", reflect.TypeOf((*MockUrgencyRepository)(nil).SaveWithEvent), ctx, urgency, from, event)
+}
+
+// SetVerificationCode mocks base method.
+func (m *MockUrgencyRepository) SetVerificationCode(ctx context.Context, urgencyID uint, hash string, expiresAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVerificationCode", ctx, urgencyID, hash, expiresAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetVerificationCode indicates an expected call of SetVerificationCode.
+func (mr *MockUrgencyRepositoryMockRecorder) SetVerificationCode(ctx, urgencyID, hash, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVerificationCode", reflect.TypeOf((*MockUrgencyRepository)(nil).SetVerificationCode), ctx, urgencyID, hash, expiresAt)
+}
+
 // Update mocks base method.
 func (m *MockUrgencyRepository) Update(ctx context.Context, urgency *model.Urgency) error {
 	m.ctrl.T.Helper()
@@ -185,3 +571,18 @@ func (mr *MockUrgencyRepositoryMockRecorder) Update(ctx, urgency any) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUrgencyRepository)(nil).Update), ctx, urgency)
 }
+
+// UpdateIntake mocks base method.
+func (m *MockUrgencyRepository) UpdateIntake(ctx context.Context, urgencyID uint, from []v1.IntakeStatus, to v1.IntakeStatus, event *model.UrgencyEvent) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIntake", ctx, urgencyID, from, to, event)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateIntake indicates an expected call of UpdateIntake.
+func (mr *MockUrgencyRepositoryMockRecorder) UpdateIntake(ctx, urgencyID, from, to, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIntake", reflect.TypeOf((*MockUrgencyRepository)(nil).UpdateIntake), ctx, urgencyID, from, to, event)
+}
@@ -3,9 +3,13 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"fmt"
 	"testing"
+	"time"
 
 	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	"github.com/pd120424d/mountain-service/api/shared/models"
 	"github.com/pd120424d/mountain-service/api/shared/utils"
 	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
 	"github.com/stretchr/testify/assert"
@@ -24,7 +28,7 @@ func setupTestDB(t *testing.T) *gorm.DB {
 	db, err := gorm.Open(sqlite.Dialector{Conn: sqlDB}, &gorm.Config{})
 	require.NoError(t, err)
 
-	err = db.AutoMigrate(&model.Urgency{}, &model.Notification{})
+	err = db.AutoMigrate(&model.Urgency{}, &model.Notification{}, &model.UrgencyEscalation{}, &model.UrgencyEvent{}, &model.UrgencyAssignment{}, &model.ReporterFollowUp{}, &model.Attachment{}, &models.OutboxEvent{})
 	require.NoError(t, err)
 
 	return db
@@ -48,6 +52,31 @@ func TestUrgencyRepository_Create(t *testing.T) {
 	err := repo.Create(context.Background(), urgency)
 	assert.NoError(t, err)
 	assert.NotZero(t, urgency.ID)
+
+	var outbox []models.OutboxEvent
+	require.NoError(t, db.Find(&outbox).Error)
+	require.Len(t, outbox, 1)
+	assert.Equal(t, fmt.Sprintf("urgency-%d", urgency.ID), outbox[0].AggregateID)
+	assert.False(t, outbox[0].Published)
+
+	var event urgencyV1.UrgencyEvent
+	require.NoError(t, json.Unmarshal([]byte(outbox[0].EventData), &event))
+	assert.Equal(t, urgencyV1.EventUrgencyCreated, event.Type)
+	assert.Equal(t, urgency.ID, event.UrgencyID)
+	assert.Equal(t, urgencyV1.High, event.Level)
+}
+
+// outboxEventTypes returns the event types written to the outbox, oldest first
+func outboxEventTypes(t *testing.T, db *gorm.DB) []urgencyV1.UrgencyEventType {
+	var outbox []models.OutboxEvent
+	require.NoError(t, db.Order("id ASC").Find(&outbox).Error)
+	types := make([]urgencyV1.UrgencyEventType, 0, len(outbox))
+	for _, o := range outbox {
+		var event urgencyV1.UrgencyEvent
+		require.NoError(t, json.Unmarshal([]byte(o.EventData), &event))
+		types = append(types, event.Type)
+	}
+	return types
 }
 
 func TestUrgencyRepository_GetAll(t *testing.T) {
@@ -167,6 +196,11 @@ func TestUrgencyRepository_Delete(t *testing.T) {
 	var deleted model.Urgency
 	err = repo.GetByID(context.Background(), urgency.ID, &deleted)
 	assert.Error(t, err) // Should not find deleted record
+	assert.Equal(t, []urgencyV1.UrgencyEventType{urgencyV1.EventUrgencyCreated, urgencyV1.EventUrgencyDeleted}, outboxEventTypes(t, db))
+
+	// deleting again is a no-op and publishes nothing
+	assert.NoError(t, repo.Delete(context.Background(), urgency.ID))
+	assert.Len(t, outboxEventTypes(t, db), 2)
 }
 
 func TestUrgencyRepository_List(t *testing.T) {
@@ -373,18 +407,136 @@ func TestUrgencyRepository_ListPaginated(t *testing.T) {
 
 	page := 2
 	pageSize := 10
-	items, total, err := repo.ListPaginated(context.Background(), page, pageSize, nil)
+	items, total, err := repo.ListPaginated(context.Background(), model.UrgencyFilter{Page: page, PageSize: pageSize})
 	assert.NoError(t, err)
 	assert.Equal(t, int64(35), total)
 	assert.Len(t, items, 10)
 
 	// Page beyond total pages returns empty slice
-	items, total, err = repo.ListPaginated(context.Background(), 4, 10, nil)
+	items, total, err = repo.ListPaginated(context.Background(), model.UrgencyFilter{Page: 4, PageSize: 10})
 	assert.NoError(t, err)
 	assert.Equal(t, int64(35), total)
 	assert.Len(t, items, 5)
 }
 
+func TestUrgencyRepository_ListPaginated_Filters(t *testing.T) {
+	db := setupTestDB(t)
+	log := utils.NewTestLogger()
+	repo := NewUrgencyRepository(log, db)
+	ctx := context.Background()
+
+	emp := uint(10)
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	seed := []*model.Urgency{
+		{FirstName: "Ana", LastName: "Jovic", ContactPhone: "1", Location: "Kopaonik ski resort", Description: "Broken leg", Level: urgencyV1.High, Status: urgencyV1.Open, SortPriority: 1},
+		{FirstName: "Ivan", LastName: "Petrovic", ContactPhone: "1", Location: "Zlatibor", Description: "Lost on 100% wrong trail", Level: urgencyV1.Critical, Status: urgencyV1.InProgress, AssignedEmployeeID: &emp, SortPriority: 3},
+		{FirstName: "Mila", LastName: "Ilic", ContactPhone: "1", Location: "Tara", Description: "Hypothermia", Level: urgencyV1.Low, Status: urgencyV1.Resolved, SortPriority: 4},
+	}
+	for i, u := range seed {
+		u.CreatedAt = base.Add(time.Duration(i) * 24 * time.Hour)
+		require.NoError(t, repo.Create(ctx, u))
+	}
+	ids := func(items []model.Urgency) []uint {
+		out := make([]uint, 0, len(items))
+		for _, u := range items {
+			out = append(out, u.ID)
+		}
+		return out
+	}
+
+	t.Run("it filters by status and level", func(t *testing.T) {
+		items, total, err := repo.ListPaginated(ctx, model.UrgencyFilter{Statuses: []urgencyV1.UrgencyStatus{urgencyV1.Open, urgencyV1.InProgress}, Levels: []urgencyV1.UrgencyLevel{urgencyV1.Critical}})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, []uint{seed[1].ID}, ids(items))
+	})
+
+	t.Run("it filters by creation time range", func(t *testing.T) {
+		from, to := base.Add(time.Hour), base.Add(48*time.Hour)
+		items, _, err := repo.ListPaginated(ctx, model.UrgencyFilter{From: &from, To: &to})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{seed[1].ID}, ids(items))
+	})
+
+	t.Run("it filters by assignee or unassigned only", func(t *testing.T) {
+		items, _, err := repo.ListPaginated(ctx, model.UrgencyFilter{AssignedEmployeeID: &emp})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{seed[1].ID}, ids(items))
+
+		items, _, err = repo.ListPaginated(ctx, model.UrgencyFilter{UnassignedOnly: true, Sort: urgencyV1.SortOldest})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{seed[0].ID, seed[2].ID}, ids(items))
+	})
+
+	t.Run("it searches name, location and description case-insensitively", func(t *testing.T) {
+		for query, want := range map[string][]uint{
+			"kopaonik": {seed[0].ID},
+			"PETROVIC": {seed[1].ID},
+			"hypo":     {seed[2].ID},
+			"100%":     {seed[1].ID},
+			"_":        {},
+		} {
+			items, _, err := repo.ListPaginated(ctx, model.UrgencyFilter{Query: query})
+			require.NoError(t, err)
+			assert.Equal(t, want, ids(items), query)
+		}
+	})
+
+	t.Run("it leaves out reports held back by the intake checks", func(t *testing.T) {
+		held := &model.Urgency{FirstName: "Held", LastName: "Back", ContactPhone: "1", Location: "Rtanj", Description: "Unverified", Level: urgencyV1.Low, Status: urgencyV1.Open, SortPriority: 1, IntakeStatus: urgencyV1.IntakeQuarantined}
+		require.NoError(t, repo.Create(ctx, held))
+		defer db.Unscoped().Delete(held)
+
+		items, _, err := repo.ListPaginated(ctx, model.UrgencyFilter{Query: "rtanj"})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{held.ID}, ids(items))
+
+		items, _, err = repo.ListPaginated(ctx, model.UrgencyFilter{Query: "rtanj", AcceptedIntakeOnly: true})
+		require.NoError(t, err)
+		assert.Empty(t, items)
+	})
+
+	t.Run("it applies the requested sort", func(t *testing.T) {
+		items, _, err := repo.ListPaginated(ctx, model.UrgencyFilter{Sort: urgencyV1.SortLevel})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{seed[1].ID, seed[0].ID, seed[2].ID}, ids(items))
+
+		items, _, err = repo.ListPaginated(ctx, model.UrgencyFilter{Sort: urgencyV1.SortNewest})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{seed[2].ID, seed[1].ID, seed[0].ID}, ids(items))
+
+		items, _, err = repo.ListPaginated(ctx, model.UrgencyFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{seed[0].ID, seed[1].ID, seed[2].ID}, ids(items))
+	})
+}
+
+func TestUrgencyRepository_ListForStats(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewUrgencyRepository(utils.NewTestLogger(), db)
+	ctx := context.Background()
+
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	create := func(createdAt time.Time, duplicateOf *uint) *model.Urgency {
+		u := &model.Urgency{FirstName: "A", LastName: "B", ContactPhone: "1", Location: "L", Description: "d", Level: urgencyV1.High, Status: urgencyV1.Open, SortPriority: 1, DuplicateOfID: duplicateOf}
+		u.CreatedAt = createdAt
+		require.NoError(t, repo.Create(ctx, u))
+		return u
+	}
+	inRange := create(base, nil)
+	create(base.Add(time.Hour), &inRange.ID)
+	create(base.Add(-time.Hour), nil)
+	create(base.Add(24*time.Hour), nil)
+
+	urgencies, err := repo.ListForStats(ctx, base, base.Add(24*time.Hour))
+	require.NoError(t, err)
+	if assert.Len(t, urgencies, 1) {
+		assert.Equal(t, inRange.ID, urgencies[0].ID)
+		assert.Equal(t, urgencyV1.High, urgencies[0].Level)
+		assert.Equal(t, base, urgencies[0].CreatedAt.UTC())
+	}
+}
+
 func TestUrgencyRepository_ListUnassignedIDs(t *testing.T) {
 	db := setupTestDB(t)
 	log := utils.NewTestLogger()
@@ -410,6 +562,35 @@ func TestUrgencyRepository_ListUnassignedIDs(t *testing.T) {
 	}
 }
 
+func TestUrgencyRepository_ListWithinBounds(t *testing.T) {
+	db := setupTestDB(t)
+	log := utils.NewTestLogger()
+	repo := NewUrgencyRepository(log, db)
+
+	create := func(location string) *model.Urgency {
+		u := &model.Urgency{FirstName: "A", LastName: "B", ContactPhone: "1", Location: location, Description: "d", Level: urgencyV1.Medium, Status: urgencyV1.Open, SortPriority: 1}
+		u.SyncCoordinates()
+		require.NoError(t, repo.Create(context.Background(), u))
+		return u
+	}
+	inside := create("N 43.40 E 22.66")
+	create("N 45.00 E 20.00")
+	create("Somewhere on the trail")
+
+	urgencies, err := repo.ListWithinBounds(context.Background(), 43, 44, 22, 23, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, urgencies, 1) {
+		assert.Equal(t, inside.ID, urgencies[0].ID)
+	}
+
+	newer := create("N 43.50 E 22.70")
+	urgencies, err = repo.ListWithinBounds(context.Background(), 43, 44, 22, 23, 1)
+	assert.NoError(t, err)
+	if assert.Len(t, urgencies, 1) {
+		assert.Equal(t, newer.ID, urgencies[0].ID)
+	}
+}
+
 func TestUrgencyRepository_DBErrors(t *testing.T) {
 	log := utils.NewTestLogger()
 
@@ -427,7 +608,7 @@ func TestUrgencyRepository_DBErrors(t *testing.T) {
 		repo := NewUrgencyRepository(log, db)
 		sqlDB, _ := db.DB()
 		_ = sqlDB.Close()
-		_, _, err := repo.ListPaginated(context.Background(), 1, 10, nil)
+		_, _, err := repo.ListPaginated(context.Background(), model.UrgencyFilter{Page: 1, PageSize: 10})
 		assert.Error(t, err)
 	})
 
@@ -467,7 +648,7 @@ func TestUrgencyRepository_withRead(t *testing.T) {
 		require.NoError(t, err)
 		db, err := gorm.Open(sqlite.Dialector{Conn: sdb}, &gorm.Config{})
 		require.NoError(t, err)
-		require.NoError(t, db.AutoMigrate(&model.Urgency{}))
+		require.NoError(t, db.AutoMigrate(&model.Urgency{}, &models.OutboxEvent{}))
 		return db
 	}
 
@@ -534,3 +715,691 @@ func TestUrgencyRepository_withRead(t *testing.T) {
 		assert.Equal(t, "OnlyWrite", items[0].FirstName)
 	})
 }
+
+func acceptedBy(employeeID uint) *model.UrgencyEvent {
+	return &model.UrgencyEvent{Type: model.UrgencyEventAccepted, ActorID: &employeeID}
+}
+
+func TestUrgencyRepository_AssignIfUnassigned(t *testing.T) {
+	log := utils.NewTestLogger()
+
+	t.Run("the first assignment wins and later ones are rejected", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := NewUrgencyRepository(log, db)
+		u := &model.Urgency{FirstName: "A", LastName: "B", ContactPhone: "1", Description: "d", Level: urgencyV1.High, Status: urgencyV1.Open, SortPriority: 1}
+		require.NoError(t, db.Create(u).Error)
+
+		won, err := repo.AssignIfUnassigned(context.Background(), u.ID, 7, time.Now().UTC(), acceptedBy(7))
+		require.NoError(t, err)
+		assert.True(t, won)
+
+		won, err = repo.AssignIfUnassigned(context.Background(), u.ID, 8, time.Now().UTC(), acceptedBy(8))
+		require.NoError(t, err)
+		assert.False(t, won)
+
+		var got model.Urgency
+		require.NoError(t, db.First(&got, u.ID).Error)
+		require.NotNil(t, got.AssignedEmployeeID)
+		assert.Equal(t, uint(7), *got.AssignedEmployeeID)
+		assert.Equal(t, urgencyV1.InProgress, got.Status)
+		assert.NotNil(t, got.AssignedAt)
+
+		events, err := repo.ListEvents(context.Background(), u.ID)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, model.UrgencyEventAccepted, events[0].Type)
+		assert.Equal(t, urgencyV1.Open, events[0].OldStatus)
+		assert.Equal(t, uint(7), *events[0].ActorID)
+
+		assert.Equal(t, []urgencyV1.UrgencyEventType{urgencyV1.EventUrgencyAssigned}, outboxEventTypes(t, db))
+	})
+
+	t.Run("an admin assignment records the admin and cannot replace whoever accepted first", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := NewUrgencyRepository(log, db)
+		u := &model.Urgency{FirstName: "A", LastName: "B", ContactPhone: "1", Description: "d", Level: urgencyV1.High, Status: urgencyV1.Open, SortPriority: 1}
+		require.NoError(t, db.Create(u).Error)
+
+		admin := uint(1)
+		won, err := repo.AssignIfUnassigned(context.Background(), u.ID, 7, time.Now().UTC(), &model.UrgencyEvent{Type: model.UrgencyEventAssigned, ActorID: &admin})
+		require.NoError(t, err)
+		assert.True(t, won)
+		won, err = repo.AssignIfUnassigned(context.Background(), u.ID, 8, time.Now().UTC(), acceptedBy(8))
+		require.NoError(t, err)
+		assert.False(t, won)
+
+		var got model.Urgency
+		require.NoError(t, db.First(&got, u.ID).Error)
+		assert.Equal(t, uint(7), *got.AssignedEmployeeID)
+		events, err := repo.ListEvents(context.Background(), u.ID)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, model.UrgencyEventAssigned, events[0].Type)
+		assert.Equal(t, admin, *events[0].ActorID)
+	})
+
+	t.Run("urgencies held back by the intake checks cannot be taken", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := NewUrgencyRepository(log, db)
+		u := &model.Urgency{FirstName: "A", LastName: "B", ContactPhone: "1", Description: "d", Level: urgencyV1.High, Status: urgencyV1.Open, SortPriority: 1, IntakeStatus: urgencyV1.IntakePendingVerification}
+		require.NoError(t, db.Create(u).Error)
+
+		won, err := repo.AssignIfUnassigned(context.Background(), u.ID, 7, time.Now().UTC(), acceptedBy(7))
+		require.NoError(t, err)
+		assert.False(t, won)
+
+		var got model.Urgency
+		require.NoError(t, db.First(&got, u.ID).Error)
+		assert.Nil(t, got.AssignedEmployeeID)
+		assert.Equal(t, urgencyV1.Open, got.Status)
+	})
+
+	t.Run("it does not assign closed urgencies", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := NewUrgencyRepository(log, db)
+		u := &model.Urgency{FirstName: "A", LastName: "B", ContactPhone: "1", Description: "d", Level: urgencyV1.High, Status: urgencyV1.Closed, SortPriority: 5}
+		require.NoError(t, db.Create(u).Error)
+
+		won, err := repo.AssignIfUnassigned(context.Background(), u.ID, 7, time.Now().UTC(), acceptedBy(7))
+		require.NoError(t, err)
+		assert.False(t, won)
+	})
+}
+
+func TestUrgencyRepository_SaveWithEvent(t *testing.T) {
+	log := utils.NewTestLogger()
+
+	t.Run("it saves the urgency and records previous and new state", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := NewUrgencyRepository(log, db)
+		u := &model.Urgency{FirstName: "A", LastName: "B", ContactPhone: "1", Description: "d", Level: urgencyV1.High, Status: urgencyV1.Open, SortPriority: 1}
+		require.NoError(t, db.Create(u).Error)
+
+		assignee, actor := uint(4), uint(9)
+		u.AssignedEmployeeID = &assignee
+		u.Status = urgencyV1.InProgress
+		saved, err := repo.SaveWithEvent(context.Background(), u, urgencyV1.Open, &model.UrgencyEvent{Type: model.UrgencyEventAssigned, ActorID: &actor})
+		require.NoError(t, err)
+		assert.True(t, saved)
+
+		var got model.Urgency
+		require.NoError(t, db.First(&got, u.ID).Error)
+		assert.Equal(t, urgencyV1.InProgress, got.Status)
+
+		events, err := repo.ListEvents(context.Background(), u.ID)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, u.ID, events[0].UrgencyID)
+		assert.Equal(t, urgencyV1.Open, events[0].OldStatus)
+		assert.Equal(t, urgencyV1.InProgress, events[0].NewStatus)
+		assert.Nil(t, events[0].OldAssigneeID)
+		assert.Equal(t, assignee, *events[0].NewAssigneeID)
+		assert.Equal(t, actor, *events[0].ActorID)
+		assert.Equal(t, []urgencyV1.UrgencyEventType{urgencyV1.EventUrgencyAssigned}, outboxEventTypes(t, db))
+	})
+
+	t.Run("it writes the lifecycle event of each published change", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := NewUrgencyRepository(log, db)
+		assignee := uint(4)
+		u := &model.Urgency{FirstName: "A", LastName: "B", ContactPhone: "1", Description: "d", Level: urgencyV1.High, Status: urgencyV1.InProgress, AssignedEmployeeID: &assignee, SortPriority: 3}
+		require.NoError(t, db.Create(u).Error)
+
+		u.AssignedEmployeeID = nil
+		_, err := repo.SaveWithEvent(context.Background(), u, urgencyV1.InProgress, &model.UrgencyEvent{Type: model.UrgencyEventUnassigned})
+		require.NoError(t, err)
+		u.AssignedEmployeeID = &assignee
+		u.Status = urgencyV1.Closed
+		_, err = repo.SaveWithEvent(context.Background(), u, urgencyV1.InProgress, &model.UrgencyEvent{Type: model.UrgencyEventClosed})
+		require.NoError(t, err)
+		_, err = repo.SaveWithEvent(context.Background(), u, urgencyV1.Closed, &model.UrgencyEvent{Type: model.UrgencyEventDuplicateReported})
+		require.NoError(t, err)
+
+		assert.Equal(t, []urgencyV1.UrgencyEventType{urgencyV1.EventUrgencyUnassigned, urgencyV1.EventUrgencyClosed}, outboxEventTypes(t, db))
+	})
+
+	t.Run("only the first of two changes checked against the same status is saved", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := NewUrgencyRepository(log, db)
+		assignee := uint(4)
+		u := &model.Urgency{FirstName: "A", LastName: "B", ContactPhone: "1", Description: "d", Level: urgencyV1.High, Status: urgencyV1.InProgress, AssignedEmployeeID: &assignee, SortPriority: 3}
+		require.NoError(t, db.Create(u).Error)
+
+		resolved, cancelled := *u, *u
+		resolved.Status = urgencyV1.Resolved
+		cancelled.Status = urgencyV1.Cancelled
+		saved, err := repo.SaveWithEvent(context.Background(), &resolved, urgencyV1.InProgress, &model.UrgencyEvent{Type: model.UrgencyEventResolved})
+		require.NoError(t, err)
+		assert.True(t, saved)
+		saved, err = repo.SaveWithEvent(context.Background(), &cancelled, urgencyV1.InProgress, &model.UrgencyEvent{Type: model.UrgencyEventCancelled})
+		require.NoError(t, err)
+		assert.False(t, saved)
+
+		var got model.Urgency
+		require.NoError(t, db.First(&got, u.ID).Error)
+		assert.Equal(t, urgencyV1.Resolved, got.Status)
+		events, err := repo.ListEvents(context.Background(), u.ID)
+		require.NoError(t, err)
+		assert.Len(t, events, 1)
+		assert.Len(t, outboxEventTypes(t, db), 1)
+	})
+
+	t.Run("it writes nothing when the urgency does not exist", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := NewUrgencyRepository(log, db)
+
+		_, err := repo.SaveWithEvent(context.Background(), &model.Urgency{ID: 42, Status: urgencyV1.Closed}, urgencyV1.InProgress, &model.UrgencyEvent{Type: model.UrgencyEventClosed})
+		assert.Error(t, err)
+
+		events, err := repo.ListEvents(context.Background(), 42)
+		require.NoError(t, err)
+		assert.Empty(t, events)
+		assert.Empty(t, outboxEventTypes(t, db))
+	})
+}
+
+func TestUrgencyRepository_ListRecentActive(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewUrgencyRepository(utils.NewTestLogger(), db)
+	now := time.Now().UTC()
+
+	create := func(status urgencyV1.UrgencyStatus, createdAt time.Time, duplicateOf *uint) *model.Urgency {
+		u := &model.Urgency{FirstName: "A", LastName: "B", ContactPhone: "1", Location: "L", Description: "d", Level: urgencyV1.High, Status: status, SortPriority: 1, DuplicateOfID: duplicateOf}
+		u.CreatedAt = createdAt
+		require.NoError(t, db.Create(u).Error)
+		return u
+	}
+	recent := create(urgencyV1.InProgress, now.Add(-10*time.Minute), nil)
+	create(urgencyV1.Open, now.Add(-3*time.Hour), nil)
+	create(urgencyV1.Resolved, now.Add(-5*time.Minute), nil)
+	create(urgencyV1.Open, now.Add(-time.Minute), &recent.ID)
+
+	urgencies, err := repo.ListRecentActive(context.Background(), now.Add(-time.Hour))
+	assert.NoError(t, err)
+	if assert.Len(t, urgencies, 1) {
+		assert.Equal(t, recent.ID, urgencies[0].ID)
+	}
+}
+
+func TestUrgencyRepository_MergeInto(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewUrgencyRepository(utils.NewTestLogger(), db)
+
+	target := &model.Urgency{FirstName: "A", LastName: "B", ContactPhone: "1", Location: "L", Description: "d", Level: urgencyV1.High, Status: urgencyV1.InProgress, SortPriority: 3}
+	require.NoError(t, db.Create(target).Error)
+	source := &model.Urgency{FirstName: "C", LastName: "D", ContactPhone: "2", Location: "L", Description: "d", Level: urgencyV1.High, Status: urgencyV1.Open, SortPriority: 1}
+	require.NoError(t, db.Create(source).Error)
+	require.NoError(t, db.Create(&model.Notification{UrgencyID: source.ID, EmployeeID: 1, NotificationType: model.NotificationSMS, Recipient: "1", Message: "m"}).Error)
+	require.NoError(t, db.Create(&model.Notification{UrgencyID: source.ID, EmployeeID: 2, NotificationType: model.NotificationSMS, Recipient: "2", Message: "m"}).Error)
+
+	source.Status = urgencyV1.Cancelled
+	source.DuplicateOfID = &target.ID
+	moved, err := repo.MergeInto(context.Background(), source, target.ID,
+		&model.UrgencyEvent{Type: model.UrgencyEventMerged}, &model.UrgencyEvent{Type: model.UrgencyEventMerged})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), moved)
+
+	var count int64
+	require.NoError(t, db.Model(&model.Notification{}).Where("urgency_id = ?", target.ID).Count(&count).Error)
+	assert.Equal(t, int64(2), count)
+
+	var got model.Urgency
+	require.NoError(t, db.First(&got, source.ID).Error)
+	assert.Equal(t, urgencyV1.Cancelled, got.Status)
+	assert.Equal(t, target.ID, *got.DuplicateOfID)
+
+	sourceEvents, err := repo.ListEvents(context.Background(), source.ID)
+	require.NoError(t, err)
+	if assert.Len(t, sourceEvents, 1) {
+		assert.Equal(t, urgencyV1.Open, sourceEvents[0].OldStatus)
+		assert.Equal(t, urgencyV1.Cancelled, sourceEvents[0].NewStatus)
+	}
+	targetEvents, err := repo.ListEvents(context.Background(), target.ID)
+	require.NoError(t, err)
+	assert.Len(t, targetEvents, 1)
+	assert.Equal(t, []urgencyV1.UrgencyEventType{urgencyV1.EventUrgencyUpdated}, outboxEventTypes(t, db))
+}
+
+func TestUrgencyRepository_Escalations(t *testing.T) {
+	log := utils.NewTestLogger()
+
+	t.Run("it lists candidates, claims steps once and records history", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := NewUrgencyRepository(log, db)
+		open := &model.Urgency{FirstName: "A", LastName: "B", ContactPhone: "1", Description: "d", Level: urgencyV1.Critical, Status: urgencyV1.Open, SortPriority: 1}
+		require.NoError(t, db.Create(open).Error)
+		assignedTo := uint(3)
+		assigned := &model.Urgency{FirstName: "C", LastName: "D", ContactPhone: "1", Description: "d", Level: urgencyV1.Critical, Status: urgencyV1.InProgress, AssignedEmployeeID: &assignedTo, SortPriority: 3}
+		require.NoError(t, db.Create(assigned).Error)
+		duplicate := &model.Urgency{FirstName: "E", LastName: "F", ContactPhone: "1", Description: "d", Level: urgencyV1.Critical, Status: urgencyV1.Open, DuplicateOfID: &open.ID, SortPriority: 1}
+		require.NoError(t, db.Create(duplicate).Error)
+
+		candidates, err := repo.ListEscalationCandidates(context.Background())
+		require.NoError(t, err)
+		require.Len(t, candidates, 1)
+		assert.Equal(t, open.ID, candidates[0].ID)
+
+		at := time.Now().UTC()
+		claimed, err := repo.ClaimEscalationStep(context.Background(), &model.UrgencyEscalation{UrgencyID: open.ID, Step: model.EscalationRenotify, Action: model.EscalationRenotify.String(), NotifiedCount: 2, CreatedAt: at})
+		require.NoError(t, err)
+		assert.True(t, claimed)
+		// a second worker firing the same step writes neither the record nor the event
+		claimed, err = repo.ClaimEscalationStep(context.Background(), &model.UrgencyEscalation{UrgencyID: open.ID, Step: model.EscalationRenotify, Action: model.EscalationRenotify.String(), NotifiedCount: 3, CreatedAt: at})
+		require.NoError(t, err)
+		assert.False(t, claimed)
+		assert.Equal(t, []urgencyV1.UrgencyEventType{urgencyV1.EventUrgencyUpdated}, outboxEventTypes(t, db))
+
+		history, err := repo.ListEscalations(context.Background(), open.ID)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		assert.Equal(t, 2, history[0].NotifiedCount)
+
+		var got model.Urgency
+		require.NoError(t, db.First(&got, open.ID).Error)
+		assert.Equal(t, model.EscalationRenotify, got.EscalationLevel)
+		assert.NotNil(t, got.LastEscalatedAt)
+	})
+}
+
+func TestUrgencyRepository_Team(t *testing.T) {
+	log := utils.NewTestLogger()
+	ctx := context.Background()
+	newUrgency := func(t *testing.T, db *gorm.DB) *model.Urgency {
+		u := &model.Urgency{FirstName: "A", LastName: "B", ContactPhone: "1", Description: "d", Level: urgencyV1.High, Status: urgencyV1.Open, SortPriority: 1}
+		require.NoError(t, db.Create(u).Error)
+		return u
+	}
+	member := func(urgencyID, employeeID uint, role urgencyV1.AssignmentRole) *model.UrgencyAssignment {
+		return &model.UrgencyAssignment{UrgencyID: urgencyID, EmployeeID: employeeID, Role: role, JoinedAt: time.Now().UTC()}
+	}
+
+	t.Run("adding a lead assigns the urgency and a second lead is rejected", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := NewUrgencyRepository(log, db)
+		u := newUrgency(t, db)
+
+		added, err := repo.AddTeamMember(ctx, member(u.ID, 7, urgencyV1.RoleLead), &model.UrgencyEvent{Type: model.UrgencyEventTeamJoined})
+		require.NoError(t, err)
+		assert.True(t, added)
+		added, err = repo.AddTeamMember(ctx, member(u.ID, 8, urgencyV1.RoleLead), &model.UrgencyEvent{Type: model.UrgencyEventTeamJoined})
+		require.NoError(t, err)
+		assert.False(t, added)
+
+		var got model.Urgency
+		require.NoError(t, db.First(&got, u.ID).Error)
+		require.NotNil(t, got.AssignedEmployeeID)
+		assert.Equal(t, uint(7), *got.AssignedEmployeeID)
+		assert.Equal(t, urgencyV1.InProgress, got.Status)
+
+		team, err := repo.ListTeam(ctx, u.ID)
+		require.NoError(t, err)
+		require.Len(t, team, 1)
+		assert.Equal(t, urgencyV1.RoleLead, team[0].Role)
+
+		events, err := repo.ListEvents(ctx, u.ID)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, urgencyV1.Open, events[0].OldStatus)
+		assert.Equal(t, uint(7), *events[0].NewAssigneeID)
+		assert.Equal(t, []urgencyV1.UrgencyEventType{urgencyV1.EventUrgencyAssigned}, outboxEventTypes(t, db))
+	})
+
+	t.Run("an employee cannot join the same team twice", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := NewUrgencyRepository(log, db)
+		u := newUrgency(t, db)
+		_, err := repo.AddTeamMember(ctx, member(u.ID, 7, urgencyV1.RoleLead), &model.UrgencyEvent{Type: model.UrgencyEventTeamJoined})
+		require.NoError(t, err)
+
+		added, err := repo.AddTeamMember(ctx, member(u.ID, 8, urgencyV1.RoleMedic), &model.UrgencyEvent{Type: model.UrgencyEventTeamJoined})
+		require.NoError(t, err)
+		assert.True(t, added)
+		added, err = repo.AddTeamMember(ctx, member(u.ID, 8, urgencyV1.RoleSupport), &model.UrgencyEvent{Type: model.UrgencyEventTeamJoined})
+		require.NoError(t, err)
+		assert.False(t, added)
+
+		team, err := repo.ListTeam(ctx, u.ID)
+		require.NoError(t, err)
+		assert.Len(t, team, 2)
+		// only lead changes are published
+		assert.Equal(t, []urgencyV1.UrgencyEventType{urgencyV1.EventUrgencyAssigned}, outboxEventTypes(t, db))
+	})
+
+	t.Run("removing the lead clears the assignee and keeps the history", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := NewUrgencyRepository(log, db)
+		u := newUrgency(t, db)
+		_, err := repo.AddTeamMember(ctx, member(u.ID, 7, urgencyV1.RoleLead), &model.UrgencyEvent{Type: model.UrgencyEventTeamJoined})
+		require.NoError(t, err)
+
+		removed, err := repo.RemoveTeamMember(ctx, u.ID, 7, time.Now().UTC(), &model.UrgencyEvent{Type: model.UrgencyEventTeamLeft})
+		require.NoError(t, err)
+		assert.True(t, removed)
+		removed, err = repo.RemoveTeamMember(ctx, u.ID, 7, time.Now().UTC(), &model.UrgencyEvent{Type: model.UrgencyEventTeamLeft})
+		require.NoError(t, err)
+		assert.False(t, removed)
+
+		var got model.Urgency
+		require.NoError(t, db.First(&got, u.ID).Error)
+		assert.Nil(t, got.AssignedEmployeeID)
+		assert.Nil(t, got.AssignedAt)
+
+		team, err := repo.ListTeam(ctx, u.ID)
+		require.NoError(t, err)
+		assert.Empty(t, team)
+		var rows int64
+		require.NoError(t, db.Model(&model.UrgencyAssignment{}).Where("urgency_id = ? AND left_at IS NOT NULL", u.ID).Count(&rows).Error)
+		assert.Equal(t, int64(1), rows)
+		assert.Equal(t, []urgencyV1.UrgencyEventType{urgencyV1.EventUrgencyAssigned, urgencyV1.EventUrgencyUnassigned}, outboxEventTypes(t, db))
+	})
+
+	t.Run("the lead of a resolved urgency cannot be removed", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := NewUrgencyRepository(log, db)
+		u := newUrgency(t, db)
+		_, err := repo.AddTeamMember(ctx, member(u.ID, 7, urgencyV1.RoleLead), &model.UrgencyEvent{Type: model.UrgencyEventTeamJoined})
+		require.NoError(t, err)
+		require.NoError(t, db.Model(&model.Urgency{}).Where("id = ?", u.ID).Updates(map[string]interface{}{"status": urgencyV1.Resolved, "sort_priority": 4}).Error)
+
+		removed, err := repo.RemoveTeamMember(ctx, u.ID, 7, time.Now().UTC(), &model.UrgencyEvent{Type: model.UrgencyEventTeamLeft})
+		require.NoError(t, err)
+		assert.False(t, removed)
+
+		var got model.Urgency
+		require.NoError(t, db.First(&got, u.ID).Error)
+		require.NotNil(t, got.AssignedEmployeeID)
+		assert.Equal(t, uint(7), *got.AssignedEmployeeID)
+		assert.NotNil(t, got.AssignedAt)
+		assert.Equal(t, 4, got.SortPriority)
+		team, err := repo.ListTeam(ctx, u.ID)
+		require.NoError(t, err)
+		assert.Len(t, team, 1)
+		assert.Equal(t, []urgencyV1.UrgencyEventType{urgencyV1.EventUrgencyAssigned}, outboxEventTypes(t, db))
+	})
+
+	t.Run("assignment through accept and save keeps the lead in sync", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := NewUrgencyRepository(log, db)
+		u := newUrgency(t, db)
+
+		won, err := repo.AssignIfUnassigned(ctx, u.ID, 7, time.Now().UTC(), acceptedBy(7))
+		require.NoError(t, err)
+		require.True(t, won)
+		team, err := repo.ListTeam(ctx, u.ID)
+		require.NoError(t, err)
+		require.Len(t, team, 1)
+		assert.Equal(t, uint(7), team[0].EmployeeID)
+
+		require.NoError(t, db.First(u, u.ID).Error)
+		next := uint(9)
+		u.AssignedEmployeeID = &next
+		_, err = repo.SaveWithEvent(ctx, u, u.Status, &model.UrgencyEvent{Type: model.UrgencyEventAssigned})
+		require.NoError(t, err)
+		team, err = repo.ListTeam(ctx, u.ID)
+		require.NoError(t, err)
+		require.Len(t, team, 1)
+		assert.Equal(t, uint(9), team[0].EmployeeID)
+		assert.Equal(t, urgencyV1.RoleLead, team[0].Role)
+	})
+
+	t.Run("reopening ends every membership", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := NewUrgencyRepository(log, db)
+		u := newUrgency(t, db)
+		_, err := repo.AddTeamMember(ctx, member(u.ID, 7, urgencyV1.RoleLead), &model.UrgencyEvent{Type: model.UrgencyEventTeamJoined})
+		require.NoError(t, err)
+		_, err = repo.AddTeamMember(ctx, member(u.ID, 8, urgencyV1.RoleMedic), &model.UrgencyEvent{Type: model.UrgencyEventTeamJoined})
+		require.NoError(t, err)
+
+		require.NoError(t, db.First(u, u.ID).Error)
+		from := u.Status
+		u.Status = urgencyV1.Open
+		u.AssignedEmployeeID = nil
+		u.AssignedAt = nil
+		_, err = repo.SaveWithEvent(ctx, u, from, &model.UrgencyEvent{Type: model.UrgencyEventReopened})
+		require.NoError(t, err)
+
+		team, err := repo.ListTeam(ctx, u.ID)
+		require.NoError(t, err)
+		assert.Empty(t, team)
+	})
+
+	t.Run("the team member filter includes urgencies the employee supports", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := NewUrgencyRepository(log, db)
+		led, supported, other := newUrgency(t, db), newUrgency(t, db), newUrgency(t, db)
+		_, err := repo.AddTeamMember(ctx, member(led.ID, 7, urgencyV1.RoleLead), &model.UrgencyEvent{Type: model.UrgencyEventTeamJoined})
+		require.NoError(t, err)
+		_, err = repo.AddTeamMember(ctx, member(supported.ID, 8, urgencyV1.RoleLead), &model.UrgencyEvent{Type: model.UrgencyEventTeamJoined})
+		require.NoError(t, err)
+		_, err = repo.AddTeamMember(ctx, member(supported.ID, 7, urgencyV1.RoleTechnical), &model.UrgencyEvent{Type: model.UrgencyEventTeamJoined})
+		require.NoError(t, err)
+
+		me := uint(7)
+		items, total, err := repo.ListPaginated(ctx, model.UrgencyFilter{TeamMemberID: &me})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		got := []uint{items[0].ID, items[1].ID}
+		assert.ElementsMatch(t, []uint{led.ID, supported.ID}, got)
+		assert.NotContains(t, got, other.ID)
+	})
+
+	t.Run("active urgencies of an employee only include in-progress ones", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := NewUrgencyRepository(log, db)
+		led, supported, resolved := newUrgency(t, db), newUrgency(t, db), newUrgency(t, db)
+		_, err := repo.AddTeamMember(ctx, member(led.ID, 7, urgencyV1.RoleLead), &model.UrgencyEvent{Type: model.UrgencyEventTeamJoined})
+		require.NoError(t, err)
+		_, err = repo.AddTeamMember(ctx, member(supported.ID, 8, urgencyV1.RoleLead), &model.UrgencyEvent{Type: model.UrgencyEventTeamJoined})
+		require.NoError(t, err)
+		_, err = repo.AddTeamMember(ctx, member(supported.ID, 7, urgencyV1.RoleMedic), &model.UrgencyEvent{Type: model.UrgencyEventTeamJoined})
+		require.NoError(t, err)
+		_, err = repo.AddTeamMember(ctx, member(resolved.ID, 7, urgencyV1.RoleLead), &model.UrgencyEvent{Type: model.UrgencyEventTeamJoined})
+		require.NoError(t, err)
+		require.NoError(t, db.Model(&model.Urgency{}).Where("id = ?", resolved.ID).Update("status", urgencyV1.Resolved).Error)
+
+		ids, err := repo.ListActiveIDsForEmployee(ctx, 7)
+		require.NoError(t, err)
+		assert.Equal(t, []uint{led.ID, supported.ID}, ids)
+
+		ids, err = repo.ListActiveIDsForEmployee(ctx, 99)
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+	})
+}
+
+func TestUrgencyRepository_Tracking(t *testing.T) {
+	log := utils.NewTestLogger()
+
+	t.Run("it finds an urgency by tracking token hash and keeps its follow-ups in order", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := NewUrgencyRepository(log, db)
+		token, hash, err := model.NewTrackingToken()
+		require.NoError(t, err)
+		urg := &model.Urgency{FirstName: "A", LastName: "B", ContactPhone: "1", Description: "d", Level: urgencyV1.High, Status: urgencyV1.Open, SortPriority: 1, TrackingTokenHash: hash}
+		require.NoError(t, repo.Create(context.Background(), urg))
+
+		var got model.Urgency
+		require.NoError(t, repo.GetByTrackingTokenHash(context.Background(), model.HashTrackingToken(token), &got))
+		assert.Equal(t, urg.ID, got.ID)
+		assert.ErrorIs(t, repo.GetByTrackingTokenHash(context.Background(), model.HashTrackingToken("guess"), &got), gorm.ErrRecordNotFound)
+		assert.ErrorIs(t, repo.GetByTrackingTokenHash(context.Background(), "", &got), gorm.ErrRecordNotFound)
+
+		require.NoError(t, repo.CreateFollowUp(context.Background(), &model.ReporterFollowUp{UrgencyID: urg.ID, Description: "first"}))
+		require.NoError(t, repo.CreateFollowUp(context.Background(), &model.ReporterFollowUp{UrgencyID: urg.ID, Location: "N 43.401123 E 22.662756"}))
+		require.NoError(t, repo.CreateFollowUp(context.Background(), &model.ReporterFollowUp{UrgencyID: urg.ID + 1, Description: "other"}))
+
+		followUps, err := repo.ListFollowUps(context.Background(), urg.ID)
+		require.NoError(t, err)
+		require.Len(t, followUps, 2)
+		assert.Equal(t, "first", followUps[0].Description)
+		assert.Equal(t, "N 43.401123 E 22.662756", followUps[1].Location)
+	})
+}
+
+func TestUrgencyRepository_Attachments(t *testing.T) {
+	log := utils.NewTestLogger()
+
+	t.Run("it stores, filters and deletes attachments per urgency", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := NewUrgencyRepository(log, db)
+		ctx := context.Background()
+		activityID := uint(7)
+		uploader := uint(3)
+
+		first := &model.Attachment{UrgencyID: 1, FileName: "terrain.jpg", ContentType: "image/jpeg", Size: 10, BlobName: "urgency-1/a.jpg", UploadedBy: &uploader}
+		second := &model.Attachment{UrgencyID: 1, ActivityID: &activityID, FileName: "report.pdf", ContentType: "application/pdf", Size: 20, BlobName: "urgency-1/b.pdf"}
+		other := &model.Attachment{UrgencyID: 2, FileName: "x.png", ContentType: "image/png", Size: 5, BlobName: "urgency-2/c.png"}
+		for _, a := range []*model.Attachment{first, second, other} {
+			require.NoError(t, repo.CreateAttachment(ctx, a))
+		}
+
+		all, err := repo.ListAttachments(ctx, 1, nil)
+		require.NoError(t, err)
+		require.Len(t, all, 2)
+		assert.Equal(t, first.ID, all[0].ID)
+
+		forActivity, err := repo.ListAttachments(ctx, 1, &activityID)
+		require.NoError(t, err)
+		require.Len(t, forActivity, 1)
+		assert.Equal(t, second.ID, forActivity[0].ID)
+
+		count, err := repo.CountAttachments(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+
+		var got model.Attachment
+		require.NoError(t, repo.GetAttachment(ctx, 1, first.ID, &got))
+		assert.Equal(t, "urgency-1/a.jpg", got.BlobName)
+		assert.ErrorIs(t, repo.GetAttachment(ctx, 2, first.ID, &got), gorm.ErrRecordNotFound)
+
+		require.NoError(t, repo.DeleteAttachment(ctx, first.ID))
+		assert.ErrorIs(t, repo.GetAttachment(ctx, 1, first.ID, &got), gorm.ErrRecordNotFound)
+		assert.ErrorIs(t, repo.DeleteAttachment(ctx, first.ID), gorm.ErrRecordNotFound)
+	})
+}
+
+func TestUrgencyRepository_Intake(t *testing.T) {
+	log := utils.NewTestLogger()
+	newUrgency := func(intake urgencyV1.IntakeStatus) *model.Urgency {
+		return &model.Urgency{FirstName: "A", LastName: "B", ContactPhone: "1", Description: "d", Level: urgencyV1.Low, Status: urgencyV1.Open, SortPriority: 1, IntakeStatus: intake}
+	}
+
+	t.Run("it holds back unaccepted urgencies and publishes them once accepted", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := NewUrgencyRepository(log, db)
+		held := newUrgency(urgencyV1.IntakePendingVerification)
+		require.NoError(t, repo.Create(context.Background(), held))
+		quarantined := newUrgency(urgencyV1.IntakeQuarantined)
+		require.NoError(t, repo.Create(context.Background(), quarantined))
+		require.NoError(t, repo.Create(context.Background(), newUrgency(urgencyV1.IntakeAccepted)))
+
+		assert.Equal(t, []urgencyV1.UrgencyEventType{urgencyV1.EventUrgencyCreated}, outboxEventTypes(t, db))
+		review, err := repo.ListIntakeReview(context.Background())
+		require.NoError(t, err)
+		require.Len(t, review, 2)
+		assert.Equal(t, held.ID, review[0].ID)
+		ids, err := repo.ListUnassignedIDs(context.Background())
+		require.NoError(t, err)
+		assert.Len(t, ids, 1)
+		candidates, err := repo.ListEscalationCandidates(context.Background())
+		require.NoError(t, err)
+		assert.Len(t, candidates, 1)
+		recent, err := repo.ListRecentActive(context.Background(), time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Len(t, recent, 1)
+
+		moved, err := repo.UpdateIntake(context.Background(), held.ID, []urgencyV1.IntakeStatus{urgencyV1.IntakePendingVerification}, urgencyV1.IntakeAccepted, &model.UrgencyEvent{Type: model.UrgencyEventIntakeVerified})
+		require.NoError(t, err)
+		assert.True(t, moved)
+		moved, err = repo.UpdateIntake(context.Background(), held.ID, []urgencyV1.IntakeStatus{urgencyV1.IntakePendingVerification}, urgencyV1.IntakeAccepted, &model.UrgencyEvent{Type: model.UrgencyEventIntakeVerified})
+		require.NoError(t, err)
+		assert.False(t, moved, "already accepted")
+		assert.Equal(t, []urgencyV1.UrgencyEventType{urgencyV1.EventUrgencyCreated, urgencyV1.EventUrgencyCreated}, outboxEventTypes(t, db))
+
+		events, err := repo.ListEvents(context.Background(), held.ID)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, model.UrgencyEventIntakeVerified, events[0].Type)
+	})
+
+	t.Run("it quarantines with a reason and cancels rejected urgencies", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := NewUrgencyRepository(log, db)
+		urg := newUrgency(urgencyV1.IntakePendingVerification)
+		require.NoError(t, repo.Create(context.Background(), urg))
+
+		moved, err := repo.UpdateIntake(context.Background(), urg.ID, []urgencyV1.IntakeStatus{urgencyV1.IntakePendingVerification}, urgencyV1.IntakeQuarantined, &model.UrgencyEvent{Type: model.UrgencyEventIntakeQuarantined, Reason: "too many wrong codes"})
+		require.NoError(t, err)
+		require.True(t, moved)
+		var got model.Urgency
+		require.NoError(t, repo.GetByIDPrimary(context.Background(), urg.ID, &got))
+		assert.Equal(t, urgencyV1.IntakeQuarantined, got.IntakeStatus)
+		assert.Equal(t, "too many wrong codes", got.IntakeReason)
+
+		actor := uint(9)
+		moved, err = repo.UpdateIntake(context.Background(), urg.ID, []urgencyV1.IntakeStatus{urgencyV1.IntakeQuarantined}, urgencyV1.IntakeRejected, &model.UrgencyEvent{Type: model.UrgencyEventIntakeRejected, ActorID: &actor, Reason: "spam"})
+		require.NoError(t, err)
+		require.True(t, moved)
+		require.NoError(t, repo.GetByIDPrimary(context.Background(), urg.ID, &got))
+		assert.Equal(t, urgencyV1.IntakeRejected, got.IntakeStatus)
+		assert.Equal(t, urgencyV1.Cancelled, got.Status)
+		assert.Equal(t, "spam", got.ResolutionNote)
+		assert.Empty(t, outboxEventTypes(t, db))
+
+		events, err := repo.ListEvents(context.Background(), urg.ID)
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		assert.Equal(t, urgencyV1.Open, events[1].OldStatus)
+		assert.Equal(t, urgencyV1.Cancelled, events[1].NewStatus)
+	})
+
+	t.Run("it does not reject an urgency someone is already working on", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := NewUrgencyRepository(log, db)
+		lead := uint(7)
+		urg := newUrgency(urgencyV1.IntakeQuarantined)
+		urg.Status = urgencyV1.InProgress
+		urg.AssignedEmployeeID = &lead
+		require.NoError(t, repo.Create(context.Background(), urg))
+
+		actor := uint(9)
+		moved, err := repo.UpdateIntake(context.Background(), urg.ID, []urgencyV1.IntakeStatus{urgencyV1.IntakeQuarantined}, urgencyV1.IntakeRejected, &model.UrgencyEvent{Type: model.UrgencyEventIntakeRejected, ActorID: &actor, Reason: "spam"})
+		require.NoError(t, err)
+		assert.False(t, moved)
+
+		var got model.Urgency
+		require.NoError(t, repo.GetByIDPrimary(context.Background(), urg.ID, &got))
+		assert.Equal(t, urgencyV1.IntakeQuarantined, got.IntakeStatus)
+		assert.Equal(t, urgencyV1.InProgress, got.Status)
+		require.NotNil(t, got.AssignedEmployeeID)
+		assert.Equal(t, lead, *got.AssignedEmployeeID)
+	})
+
+	t.Run("it replaces codes and counts wrong attempts only while verification is pending", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := NewUrgencyRepository(log, db)
+		urg := newUrgency(urgencyV1.IntakePendingVerification)
+		require.NoError(t, repo.Create(context.Background(), urg))
+
+		for i := 1; i <= 2; i++ {
+			attempts, err := repo.RecordVerificationAttempt(context.Background(), urg.ID)
+			require.NoError(t, err)
+			assert.Equal(t, i, attempts)
+		}
+		expiresAt := time.Now().Add(10 * time.Minute).UTC()
+		set, err := repo.SetVerificationCode(context.Background(), urg.ID, "hash", expiresAt)
+		require.NoError(t, err)
+		assert.True(t, set)
+		var got model.Urgency
+		require.NoError(t, repo.GetByIDPrimary(context.Background(), urg.ID, &got))
+		assert.Equal(t, "hash", got.VerificationCodeHash)
+		assert.Equal(t, 0, got.VerificationAttempts)
+
+		accepted := newUrgency(urgencyV1.IntakeAccepted)
+		require.NoError(t, repo.Create(context.Background(), accepted))
+		set, err = repo.SetVerificationCode(context.Background(), accepted.ID, "hash", expiresAt)
+		require.NoError(t, err)
+		assert.False(t, set)
+	})
+}
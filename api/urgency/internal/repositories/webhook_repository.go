@@ -0,0 +1,166 @@
+package repositories
+
+//go:generate mockgen -source=webhook_repository.go -destination=webhook_repository_gomock.go -package=repositories mountain_service/urgency/internal/repositories -imports=gomock=go.uber.org/mock/gomock -typed
+
+import (
+	"context"
+	"time"
+
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, sub *model.WebhookSubscription) error
+	GetSubscription(ctx context.Context, id uint, sub *model.WebhookSubscription) error
+	ListSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error)
+	ListActiveSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, sub *model.WebhookSubscription) error
+	DeleteSubscription(ctx context.Context, id uint) error
+
+	EnqueueDeliveries(ctx context.Context, deliveries []model.WebhookDelivery) error
+	ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *model.WebhookDelivery) error
+	ListDeliveries(ctx context.Context, subscriptionID uint, limit int) ([]model.WebhookDelivery, error)
+}
+
+type webhookRepository struct {
+	log utils.Logger
+	db  *gorm.DB
+}
+
+func NewWebhookRepository(log utils.Logger, db *gorm.DB) WebhookRepository {
+	return &webhookRepository{log: log.WithName("webhookRepository"), db: db}
+}
+
+func (r *webhookRepository) CreateSubscription(ctx context.Context, sub *model.WebhookSubscription) error {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "WebhookRepository.CreateSubscription")()
+	return r.db.WithContext(ctx).Create(sub).Error
+}
+
+func (r *webhookRepository) GetSubscription(ctx context.Context, id uint, sub *model.WebhookSubscription) error {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "WebhookRepository.GetSubscription")()
+	return r.db.WithContext(ctx).First(sub, "id = ?", id).Error
+}
+
+func (r *webhookRepository) ListSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error) {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "WebhookRepository.ListSubscriptions")()
+	var subs []model.WebhookSubscription
+	err := r.db.WithContext(ctx).Order("id ASC").Find(&subs).Error
+	return subs, err
+}
+
+func (r *webhookRepository) ListActiveSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error) {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "WebhookRepository.ListActiveSubscriptions")()
+	var subs []model.WebhookSubscription
+	err := r.db.WithContext(ctx).Where("active = ?", true).Order("id ASC").Find(&subs).Error
+	return subs, err
+}
+
+func (r *webhookRepository) UpdateSubscription(ctx context.Context, sub *model.WebhookSubscription) error {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "WebhookRepository.UpdateSubscription")()
+	return r.db.WithContext(ctx).Save(sub).Error
+}
+
+// DeleteSubscription removes the subscription and its pending deliveries; the delivery log of
+// finished deliveries is kept
+func (r *webhookRepository) DeleteSubscription(ctx context.Context, id uint) error {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "WebhookRepository.DeleteSubscription")()
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Delete(&model.WebhookSubscription{}, "id = ?", id)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Where("subscription_id = ? AND status = ?", id, model.WebhookDeliveryPending).Delete(&model.WebhookDelivery{}).Error
+	})
+}
+
+// EnqueueDeliveries stores new deliveries. Deliveries of an event that was already fanned out to the
+// same subscription are skipped, so an outbox event that is published again is not delivered twice.
+func (r *webhookRepository) EnqueueDeliveries(ctx context.Context, deliveries []model.WebhookDelivery) error {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "WebhookRepository.EnqueueDeliveries")()
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "subscription_id"}, {Name: "event_id"}}, DoNothing: true}).
+		Create(&deliveries).Error
+}
+
+// ClaimDueDeliveries returns up to limit pending deliveries whose next attempt time has come, oldest
+// first, and holds each one back for the lease so another dispatcher does not send it as well. A row
+// claimed concurrently by another dispatcher is skipped.
+func (r *webhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.WebhookDelivery, error) {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "WebhookRepository.ClaimDueDeliveries")()
+
+	const due = "status = ? AND (next_attempt_at IS NULL OR next_attempt_at <= ?)"
+	var candidates []model.WebhookDelivery
+	if err := r.db.WithContext(ctx).Where(due, model.WebhookDeliveryPending, now).
+		Order("id ASC").Limit(limit).Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+
+	leaseUntil := now.Add(lease)
+	claimed := make([]model.WebhookDelivery, 0, len(candidates))
+	for i := range candidates {
+		res := r.db.WithContext(ctx).Model(&model.WebhookDelivery{}).
+			Where("id = ?", candidates[i].ID).Where(due, model.WebhookDeliveryPending, now).
+			Update("next_attempt_at", leaseUntil)
+		if res.Error != nil {
+			return claimed, res.Error
+		}
+		if res.RowsAffected == 0 {
+			continue
+		}
+		candidates[i].NextAttemptAt = &leaseUntil
+		claimed = append(claimed, candidates[i])
+	}
+	return claimed, nil
+}
+
+// UpdateDelivery records the outcome of an attempt on a delivery that is still pending. It returns
+// gorm.ErrRecordNotFound when the delivery was removed or already finished in the meantime.
+func (r *webhookRepository) UpdateDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "WebhookRepository.UpdateDelivery")()
+	res := r.db.WithContext(ctx).Model(&model.WebhookDelivery{}).
+		Where("id = ? AND status = ?", delivery.ID, model.WebhookDeliveryPending).
+		Updates(map[string]interface{}{
+			"status":          delivery.Status,
+			"attempts":        delivery.Attempts,
+			"last_attempt_at": delivery.LastAttemptAt,
+			"next_attempt_at": delivery.NextAttemptAt,
+			"response_status": delivery.ResponseStatus,
+			"error":           delivery.Error,
+			"delivered_at":    delivery.DeliveredAt,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ListDeliveries returns the delivery log of a subscription, newest first
+func (r *webhookRepository) ListDeliveries(ctx context.Context, subscriptionID uint, limit int) ([]model.WebhookDelivery, error) {
+	log := r.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "WebhookRepository.ListDeliveries")()
+	var deliveries []model.WebhookDelivery
+	err := r.db.WithContext(ctx).Where("subscription_id = ?", subscriptionID).Order("id DESC").Limit(limit).Find(&deliveries).Error
+	return deliveries, err
+}
@@ -0,0 +1,197 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupWebhookTestDB(t *testing.T) *gorm.DB {
+	sqlDB, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	db, err := gorm.Open(sqlite.Dialector{Conn: sqlDB}, &gorm.Config{})
+	require.NoError(t, err)
+
+	require.NoError(t, db.AutoMigrate(&model.WebhookSubscription{}, &model.WebhookDelivery{}))
+	return db
+}
+
+func TestWebhookRepository_Subscriptions(t *testing.T) {
+	t.Parallel()
+	log := utils.NewTestLogger()
+
+	t.Run("it creates, lists, updates and deletes subscriptions", func(t *testing.T) {
+		db := setupWebhookTestDB(t)
+		repo := NewWebhookRepository(log, db)
+		ctx := context.Background()
+
+		active := &model.WebhookSubscription{URL: "https://a.example.com", Secret: "s", EventTypes: "urgency.created", Active: true}
+		inactive := &model.WebhookSubscription{URL: "https://b.example.com", Secret: "s", EventTypes: "urgency.closed"}
+		require.NoError(t, repo.CreateSubscription(ctx, active))
+		require.NoError(t, repo.CreateSubscription(ctx, inactive))
+
+		all, err := repo.ListSubscriptions(ctx)
+		require.NoError(t, err)
+		assert.Len(t, all, 2)
+
+		onlyActive, err := repo.ListActiveSubscriptions(ctx)
+		require.NoError(t, err)
+		require.Len(t, onlyActive, 1)
+		assert.Equal(t, active.ID, onlyActive[0].ID)
+
+		inactive.Active = true
+		require.NoError(t, repo.UpdateSubscription(ctx, inactive))
+		var got model.WebhookSubscription
+		require.NoError(t, repo.GetSubscription(ctx, inactive.ID, &got))
+		assert.True(t, got.Active)
+
+		require.NoError(t, repo.DeleteSubscription(ctx, inactive.ID))
+		assert.ErrorIs(t, repo.GetSubscription(ctx, inactive.ID, &got), gorm.ErrRecordNotFound)
+		assert.ErrorIs(t, repo.DeleteSubscription(ctx, inactive.ID), gorm.ErrRecordNotFound)
+	})
+
+	t.Run("it keeps finished deliveries of a deleted subscription", func(t *testing.T) {
+		db := setupWebhookTestDB(t)
+		repo := NewWebhookRepository(log, db)
+		ctx := context.Background()
+
+		sub := &model.WebhookSubscription{URL: "https://a.example.com", Secret: "s", EventTypes: "urgency.created", Active: true}
+		require.NoError(t, repo.CreateSubscription(ctx, sub))
+		require.NoError(t, repo.EnqueueDeliveries(ctx, []model.WebhookDelivery{
+			{SubscriptionID: sub.ID, EventID: 1, EventType: urgencyV1.EventUrgencyCreated, Payload: "{}", Status: model.WebhookDeliveryDelivered},
+			{SubscriptionID: sub.ID, EventID: 2, EventType: urgencyV1.EventUrgencyCreated, Payload: "{}", Status: model.WebhookDeliveryPending},
+		}))
+
+		require.NoError(t, repo.DeleteSubscription(ctx, sub.ID))
+
+		deliveries, err := repo.ListDeliveries(ctx, sub.ID, 10)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, model.WebhookDeliveryDelivered, deliveries[0].Status)
+	})
+}
+
+func TestWebhookRepository_Deliveries(t *testing.T) {
+	t.Parallel()
+	log := utils.NewTestLogger()
+
+	t.Run("it enqueues each event once per subscription", func(t *testing.T) {
+		db := setupWebhookTestDB(t)
+		repo := NewWebhookRepository(log, db)
+		ctx := context.Background()
+
+		require.NoError(t, repo.EnqueueDeliveries(ctx, []model.WebhookDelivery{
+			{SubscriptionID: 1, EventID: 5, EventType: urgencyV1.EventUrgencyCreated, Payload: "{}", Status: model.WebhookDeliveryPending},
+			{SubscriptionID: 2, EventID: 5, EventType: urgencyV1.EventUrgencyCreated, Payload: "{}", Status: model.WebhookDeliveryPending},
+		}))
+		// the same event fanned out again after a failed publish
+		require.NoError(t, repo.EnqueueDeliveries(ctx, []model.WebhookDelivery{
+			{SubscriptionID: 1, EventID: 5, EventType: urgencyV1.EventUrgencyCreated, Payload: "{}", Status: model.WebhookDeliveryPending},
+			{SubscriptionID: 1, EventID: 6, EventType: urgencyV1.EventUrgencyClosed, Payload: "{}", Status: model.WebhookDeliveryPending},
+		}))
+		require.NoError(t, repo.EnqueueDeliveries(ctx, nil))
+
+		deliveries, err := repo.ListDeliveries(ctx, 1, 10)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 2)
+		assert.Equal(t, uint(6), deliveries[0].EventID)
+		assert.Equal(t, uint(5), deliveries[1].EventID)
+	})
+
+	t.Run("it claims only pending deliveries that are due", func(t *testing.T) {
+		db := setupWebhookTestDB(t)
+		repo := NewWebhookRepository(log, db)
+		ctx := context.Background()
+		now := time.Now().UTC()
+		later := now.Add(time.Minute)
+		earlier := now.Add(-time.Minute)
+
+		require.NoError(t, repo.EnqueueDeliveries(ctx, []model.WebhookDelivery{
+			{SubscriptionID: 1, EventID: 1, EventType: urgencyV1.EventUrgencyCreated, Payload: "{}", Status: model.WebhookDeliveryPending},
+			{SubscriptionID: 1, EventID: 2, EventType: urgencyV1.EventUrgencyCreated, Payload: "{}", Status: model.WebhookDeliveryPending, NextAttemptAt: &earlier},
+			{SubscriptionID: 1, EventID: 3, EventType: urgencyV1.EventUrgencyCreated, Payload: "{}", Status: model.WebhookDeliveryPending, NextAttemptAt: &later},
+			{SubscriptionID: 1, EventID: 4, EventType: urgencyV1.EventUrgencyCreated, Payload: "{}", Status: model.WebhookDeliveryFailed},
+		}))
+
+		due, err := repo.ClaimDueDeliveries(ctx, now, time.Minute, 10)
+		require.NoError(t, err)
+		require.Len(t, due, 2)
+		assert.Equal(t, uint(1), due[0].EventID)
+		assert.Equal(t, uint(2), due[1].EventID)
+	})
+
+	t.Run("it does not hand the same delivery to a second dispatcher during the lease", func(t *testing.T) {
+		db := setupWebhookTestDB(t)
+		repo := NewWebhookRepository(log, db)
+		ctx := context.Background()
+		now := time.Now().UTC()
+
+		require.NoError(t, repo.EnqueueDeliveries(ctx, []model.WebhookDelivery{
+			{SubscriptionID: 1, EventID: 1, EventType: urgencyV1.EventUrgencyCreated, Payload: "{}", Status: model.WebhookDeliveryPending},
+		}))
+
+		claimed, err := repo.ClaimDueDeliveries(ctx, now, time.Minute, 10)
+		require.NoError(t, err)
+		assert.Len(t, claimed, 1)
+
+		claimed, err = repo.ClaimDueDeliveries(ctx, now.Add(30*time.Second), time.Minute, 10)
+		require.NoError(t, err)
+		assert.Empty(t, claimed)
+
+		// a dispatcher that died mid-send gives the delivery up once the lease runs out
+		claimed, err = repo.ClaimDueDeliveries(ctx, now.Add(time.Minute), time.Minute, 10)
+		require.NoError(t, err)
+		assert.Len(t, claimed, 1)
+	})
+
+	t.Run("it records an attempt only while the delivery is still pending", func(t *testing.T) {
+		db := setupWebhookTestDB(t)
+		repo := NewWebhookRepository(log, db)
+		ctx := context.Background()
+		now := time.Now().UTC()
+
+		require.NoError(t, repo.EnqueueDeliveries(ctx, []model.WebhookDelivery{
+			{SubscriptionID: 1, EventID: 1, EventType: urgencyV1.EventUrgencyCreated, Payload: "{}", Status: model.WebhookDeliveryPending},
+		}))
+		due, err := repo.ClaimDueDeliveries(ctx, now, time.Minute, 10)
+		require.NoError(t, err)
+		require.Len(t, due, 1)
+
+		delivered := due[0]
+		delivered.Status = model.WebhookDeliveryDelivered
+		delivered.Attempts = 1
+		delivered.DeliveredAt = &now
+		delivered.NextAttemptAt = nil
+		require.NoError(t, repo.UpdateDelivery(ctx, &delivered))
+
+		// a second dispatcher finishing the same attempt late does not overwrite the outcome
+		failed := due[0]
+		failed.Status = model.WebhookDeliveryFailed
+		failed.Attempts = 1
+		failed.Error = "timeout"
+		assert.ErrorIs(t, repo.UpdateDelivery(ctx, &failed), gorm.ErrRecordNotFound)
+		// an update never creates a row
+		assert.ErrorIs(t, repo.UpdateDelivery(ctx, &model.WebhookDelivery{ID: 99, Status: model.WebhookDeliveryFailed}), gorm.ErrRecordNotFound)
+
+		deliveries, err := repo.ListDeliveries(ctx, 1, 10)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, model.WebhookDeliveryDelivered, deliveries[0].Status)
+		assert.Empty(t, deliveries[0].Error)
+		assert.Nil(t, deliveries[0].NextAttemptAt)
+
+		due, err = repo.ClaimDueDeliveries(ctx, now.Add(time.Hour), time.Minute, 10)
+		require.NoError(t, err)
+		assert.Empty(t, due)
+	})
+}
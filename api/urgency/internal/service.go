@@ -6,6 +6,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"sort"
+	"strings"
 	"time"
 
 	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
@@ -15,23 +18,63 @@ import (
 	"github.com/pd120424d/mountain-service/api/urgency/internal/clients"
 	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
 	"github.com/pd120424d/mountain-service/api/urgency/internal/repositories"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/templates"
 	"gorm.io/gorm"
 )
 
+// maxAreaUrgencies caps the urgencies returned for an area; the newest ones are kept
+const maxAreaUrgencies = 500
+
 type UrgencyService interface {
 	CreateUrgency(ctx context.Context, urgency *model.Urgency) error
 	GetAllUrgencies(ctx context.Context) ([]model.Urgency, error)
-	ListUrgencies(ctx context.Context, page int, pageSize int, assignedEmployeeID *uint) ([]model.Urgency, int64, error)
+	ListUrgencies(ctx context.Context, filter model.UrgencyFilter) ([]model.Urgency, int64, error)
 	GetUrgencyByID(ctx context.Context, id uint) (*model.Urgency, error)
-	UpdateUrgency(ctx context.Context, urgency *model.Urgency) error
+	UpdateUrgency(ctx context.Context, id uint, req *urgencyV1.UrgencyUpdateRequest) (*model.Urgency, error)
 	DeleteUrgency(ctx context.Context, id uint) error
 	ResetAllData(ctx context.Context) error
 	ListUnassignedIDs(ctx context.Context) ([]uint, error)
+	GetEmployeeActiveUrgencies(ctx context.Context, employeeID uint) (*urgencyV1.EmployeeActiveUrgenciesResponse, error)
+	ListUrgenciesInArea(ctx context.Context, query urgencyV1.UrgencyGeoQuery, isAdmin bool) (*urgencyV1.UrgencyList, error)
+	SuggestResponders(ctx context.Context, urgencyID uint) ([]urgencyV1.ResponderSuggestion, error)
 
 	AssignUrgency(ctx context.Context, urgencyID, employeeID uint) error
 	UnassignUrgency(ctx context.Context, urgencyID uint, actorID uint, isAdmin bool) error
 	CloseUrgency(ctx context.Context, urgencyID uint, actorID uint, isAdmin bool) error
+	ResolveUrgency(ctx context.Context, urgencyID uint, actorID uint, isAdmin bool, note string) error
+	ReopenUrgency(ctx context.Context, urgencyID uint, actorID uint, isAdmin bool, reason string) error
+	CancelUrgency(ctx context.Context, urgencyID uint, actorID uint, isAdmin bool, reason string) error
+	MergeUrgency(ctx context.Context, targetID, sourceID uint) (*urgencyV1.UrgencyMergeResponse, error)
 	GetAssignment(ctx context.Context, urgencyID uint) (*urgencyV1.AssignmentResponse, error)
+	GetTeam(ctx context.Context, urgencyID uint) (*urgencyV1.UrgencyTeamResponse, error)
+	AddTeamMember(ctx context.Context, urgencyID, actorID uint, isAdmin bool, req urgencyV1.TeamMemberRequest) error
+	RemoveTeamMember(ctx context.Context, urgencyID, employeeID, actorID uint, isAdmin bool) error
+
+	AcceptUrgency(ctx context.Context, urgencyID, employeeID uint) error
+	DeclineUrgency(ctx context.Context, urgencyID, employeeID uint, reason string) error
+	ListReplies(ctx context.Context, urgencyID uint) ([]urgencyV1.UrgencyReplyResponse, error)
+	ListUrgencyNotifications(ctx context.Context, urgencyID, actorID uint, isAdmin bool) ([]urgencyV1.NotificationResponse, error)
+	ListEmployeeNotifications(ctx context.Context, employeeID, actorID uint, isAdmin bool) ([]urgencyV1.NotificationResponse, error)
+	RetryNotification(ctx context.Context, notificationID uint) (*urgencyV1.NotificationResponse, error)
+	ListEscalations(ctx context.Context, urgencyID uint) ([]model.UrgencyEscalation, error)
+	GetTimeline(ctx context.Context, urgencyID uint) (*urgencyV1.UrgencyTimelineResponse, error)
+	GetStats(ctx context.Context, query urgencyV1.UrgencyStatsQuery) (*urgencyV1.UrgencyStatsResponse, error)
+	PreviewNotificationTemplate(ctx context.Context, query urgencyV1.NotificationTemplatePreviewQuery) (*urgencyV1.NotificationTemplatePreviewResponse, error)
+
+	GetTrackingStatus(ctx context.Context, token string) (*urgencyV1.UrgencyTrackingResponse, error)
+	AddFollowUp(ctx context.Context, token string, req urgencyV1.UrgencyFollowUpRequest) (*urgencyV1.UrgencyFollowUpResponse, error)
+	VerifyPhone(ctx context.Context, token string, req urgencyV1.UrgencyVerificationRequest) error
+	ResendVerificationCode(ctx context.Context, token string) error
+
+	ListIntakeReview(ctx context.Context) (*urgencyV1.IntakeReviewList, error)
+	ReleaseIntake(ctx context.Context, urgencyID uint, actorID uint, isAdmin bool) error
+	RejectIntake(ctx context.Context, urgencyID uint, actorID uint, isAdmin bool, reason string) error
+
+	UploadAttachment(ctx context.Context, urgencyID, actorID uint, isAdmin bool, upload AttachmentUpload) (*urgencyV1.AttachmentResponse, error)
+	UploadReporterAttachment(ctx context.Context, token string, upload AttachmentUpload) (*urgencyV1.AttachmentResponse, error)
+	ListAttachments(ctx context.Context, urgencyID, actorID uint, isAdmin bool, activityID *uint) ([]urgencyV1.AttachmentResponse, error)
+	OpenAttachment(ctx context.Context, urgencyID, attachmentID, actorID uint, isAdmin bool) (*urgencyV1.AttachmentResponse, io.ReadCloser, error)
+	DeleteAttachment(ctx context.Context, urgencyID, attachmentID, actorID uint, isAdmin bool) error
 }
 
 type urgencyService struct {
@@ -39,6 +82,27 @@ type urgencyService struct {
 	repo             repositories.UrgencyRepository
 	notificationRepo repositories.NotificationRepository
 	employeeClient   clients.EmployeeClient
+	activityClient   clients.ActivityClient
+	duplicates       DuplicatePolicy
+	sla              SLAPolicy
+	templates        *templates.Registry
+	intake           IntakePolicy
+	attachments      AttachmentPolicy
+}
+
+// UrgencyServiceOptions holds the configurable policies of the urgency service
+type UrgencyServiceOptions struct {
+	Duplicates DuplicatePolicy
+	SLA        SLAPolicy
+	// Templates renders notification messages; nil uses the embedded defaults
+	Templates   *templates.Registry
+	Intake      IntakePolicy
+	Attachments AttachmentPolicy
+}
+
+// DefaultUrgencyServiceOptions returns the policies used when no overrides are configured
+func DefaultUrgencyServiceOptions() UrgencyServiceOptions {
+	return UrgencyServiceOptions{Duplicates: DefaultDuplicatePolicy(), SLA: DefaultSLAPolicy(), Templates: templates.Default(), Intake: DefaultIntakePolicy(), Attachments: DefaultAttachmentPolicy()}
 }
 
 func NewUrgencyService(
@@ -46,12 +110,31 @@ func NewUrgencyService(
 	repo repositories.UrgencyRepository,
 	notificationRepo repositories.NotificationRepository,
 	employeeClient clients.EmployeeClient,
+	activityClient clients.ActivityClient,
+) UrgencyService {
+	return NewUrgencyServiceWithOptions(log, repo, notificationRepo, employeeClient, activityClient, DefaultUrgencyServiceOptions())
+}
+
+// NewUrgencyServiceWithOptions allows overriding duplicate detection thresholds and SLA targets
+func NewUrgencyServiceWithOptions(
+	log utils.Logger,
+	repo repositories.UrgencyRepository,
+	notificationRepo repositories.NotificationRepository,
+	employeeClient clients.EmployeeClient,
+	activityClient clients.ActivityClient,
+	opts UrgencyServiceOptions,
 ) UrgencyService {
 	return &urgencyService{
 		log:              log.WithName("urgencyService"),
 		repo:             repo,
 		notificationRepo: notificationRepo,
 		employeeClient:   employeeClient,
+		activityClient:   activityClient,
+		duplicates:       opts.Duplicates,
+		sla:              opts.SLA,
+		templates:        opts.Templates,
+		intake:           opts.Intake,
+		attachments:      opts.Attachments,
 	}
 }
 
@@ -59,19 +142,74 @@ func (s *urgencyService) CreateUrgency(ctx context.Context, urgency *model.Urgen
 	log := s.log.WithContext(ctx)
 	defer utils.TimeOperation(log, "UrgencyService.CreateUrgency")()
 	log.Infof("Creating urgency: %s %s", urgency.FirstName, urgency.LastName)
+	urgency.SyncCoordinates()
 	urgency.SortPriority = model.ComputeSortPriority(urgency.Status, urgency.AssignedEmployeeID)
 	if urgency.SortPriority == 0 { // safety guard against zero triggering DB defaults
 		urgency.SortPriority = 1
 	}
-	err := s.repo.Create(ctx, urgency)
+	if err := s.checkPhoneLimit(ctx, urgency); err != nil {
+		return err
+	}
+	original := s.findDuplicate(ctx, urgency)
+	if original != nil {
+		urgency.DuplicateOfID = &original.ID
+	}
+	token, hash, err := model.NewTrackingToken()
+	if err != nil {
+		log.Errorf("Failed to generate tracking token: %v", err)
+		return commonv1.NewAppError("URGENCY_ERRORS.CREATE_FAILED", "failed to create urgency", map[string]interface{}{"cause": err.Error()})
+	}
+	urgency.TrackingToken, urgency.TrackingTokenHash = token, hash
+	// Linked duplicates never reach responders on their own, so only new incidents are screened
+	code := ""
+	if original == nil {
+		if code, err = s.screenIntake(ctx, urgency); err != nil {
+			log.Errorf("Failed to generate verification code: %v", err)
+			return commonv1.NewAppError("URGENCY_ERRORS.CREATE_FAILED", "failed to create urgency", map[string]interface{}{"cause": err.Error()})
+		}
+	} else {
+		urgency.IntakeStatus = urgencyV1.IntakeAccepted
+	}
+	err = s.repo.Create(ctx, urgency)
 
 	if err != nil {
 		log.Errorf("Failed to create urgency: %v", err)
 		return commonv1.NewAppError("URGENCY_ERRORS.CREATE_FAILED", "failed to create urgency", map[string]interface{}{"cause": err.Error()})
 	}
 
-	shiftBuffer := 1 * time.Hour // Include employees from next shift if current shift ends within 1 hour
-	onCallEmployees, err := s.employeeClient.GetOnCallEmployees(ctx, shiftBuffer)
+	// Responders were already alerted for the original report, so a duplicate is only linked
+	if original != nil {
+		log.Infof("Urgency %d looks like a duplicate of urgency %d, skipping notifications", urgency.ID, original.ID)
+		event := &model.UrgencyEvent{UrgencyID: original.ID, Type: model.UrgencyEventDuplicateReported, Reason: fmt.Sprintf("report #%d", urgency.ID)}
+		if err := s.repo.CreateEvent(ctx, event); err != nil {
+			log.Errorf("Failed to record duplicate report on urgency %d: %v", original.ID, err)
+		}
+		return nil
+	}
+
+	switch urgency.IntakeStatus {
+	case urgencyV1.IntakeQuarantined:
+		log.Warnf("Urgency %d quarantined for review: %s", urgency.ID, urgency.IntakeReason)
+		event := &model.UrgencyEvent{UrgencyID: urgency.ID, Type: model.UrgencyEventIntakeQuarantined, Reason: urgency.IntakeReason}
+		if err := s.repo.CreateEvent(ctx, event); err != nil {
+			log.Errorf("Failed to record quarantine of urgency %d: %v", urgency.ID, err)
+		}
+		return nil
+	case urgencyV1.IntakePendingVerification:
+		if err := s.sendVerificationCode(ctx, urgency, code); err == nil {
+			return nil
+		}
+		return s.acceptUnverified(ctx, urgency)
+	}
+	return s.notifyOnCall(ctx, urgency)
+}
+
+// notifyOnCall alerts the on-call employees about an urgency that has been let through to responders
+func (s *urgencyService) notifyOnCall(ctx context.Context, urgency *model.Urgency) error {
+	log := s.log.WithContext(ctx)
+
+	// Include employees from next shift if current shift ends within the buffer
+	onCallEmployees, err := s.employeeClient.GetOnCallEmployees(ctx, defaultShiftBuffer)
 	if err != nil {
 		log.Errorf("Failed to fetch on-call employees: %v", err)
 		return commonv1.NewAppError("URGENCY_ERRORS.ON_CALL_FETCH_FAILED", "failed to fetch on-call employees", map[string]interface{}{"cause": err.Error()})
@@ -80,7 +218,7 @@ func (s *urgencyService) CreateUrgency(ctx context.Context, urgency *model.Urgen
 	log.Infof("Found %d on-call employees for urgency %d", len(onCallEmployees), urgency.ID)
 
 	for _, employee := range onCallEmployees {
-		if err := s.createAssignmentAndNotification(ctx, urgency, employee); err != nil {
+		if err := s.createAssignmentAndNotification(ctx, urgency, employee, templates.EventNewUrgency); err != nil {
 			log.Errorf("Failed to create assignment/notification for employee %d: %v", employee.ID, err)
 			// Continue with other employees even if one fails
 		}
@@ -89,10 +227,10 @@ func (s *urgencyService) CreateUrgency(ctx context.Context, urgency *model.Urgen
 	return nil
 }
 
-func (s *urgencyService) ListUrgencies(ctx context.Context, page int, pageSize int, assignedEmployeeID *uint) ([]model.Urgency, int64, error) {
+func (s *urgencyService) ListUrgencies(ctx context.Context, filter model.UrgencyFilter) ([]model.Urgency, int64, error) {
 	log := s.log.WithContext(ctx)
 	defer utils.TimeOperation(log, "UrgencyService.ListUrgencies")()
-	return s.repo.ListPaginated(ctx, page, pageSize, assignedEmployeeID)
+	return s.repo.ListPaginated(ctx, filter)
 }
 
 func (s *urgencyService) ListUnassignedIDs(ctx context.Context) ([]uint, error) {
@@ -101,6 +239,96 @@ func (s *urgencyService) ListUnassignedIDs(ctx context.Context) ([]uint, error)
 	return s.repo.ListUnassignedIDs(ctx)
 }
 
+// ListUrgenciesInArea returns urgencies inside a bounding box, or within a radius ordered by distance.
+// Radius queries prefilter on the enclosing bounding box so the coordinate index can be used. Reports held
+// back by the intake checks are only shown to admins. Only the newest maxAreaUrgencies in the box are
+// considered, and the list is marked as truncated when there were more.
+func (s *urgencyService) ListUrgenciesInArea(ctx context.Context, query urgencyV1.UrgencyGeoQuery, isAdmin bool) (*urgencyV1.UrgencyList, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.ListUrgenciesInArea")()
+
+	var minLat, maxLat, minLng, maxLng float64
+	if query.IsRadius() {
+		minLat, maxLat, minLng, maxLng = utils.BoundingBox(*query.Lat, *query.Lng, *query.RadiusKm)
+	} else {
+		minLat, maxLat, minLng, maxLng = *query.MinLat, *query.MaxLat, *query.MinLng, *query.MaxLng
+	}
+
+	urgencies, err := s.repo.ListWithinBounds(ctx, minLat, maxLat, minLng, maxLng, maxAreaUrgencies+1)
+	if err != nil {
+		log.Errorf("Failed to list urgencies in area: %v", err)
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.LIST_FAILED", "failed to list urgencies", map[string]interface{}{"cause": err.Error()})
+	}
+	truncated := len(urgencies) > maxAreaUrgencies
+	if truncated {
+		urgencies = urgencies[:maxAreaUrgencies]
+	}
+
+	result := make([]urgencyV1.UrgencyResponse, 0, len(urgencies))
+	for _, u := range urgencies {
+		if !isAdmin && !u.IntakeAccepted() {
+			continue
+		}
+		resp := u.ToResponse()
+		if query.IsRadius() {
+			d := utils.DistanceKm(*query.Lat, *query.Lng, *u.Latitude, *u.Longitude)
+			if d > *query.RadiusKm {
+				continue
+			}
+			resp.DistanceKm = &d
+		}
+		result = append(result, resp)
+	}
+	if query.IsRadius() {
+		sort.SliceStable(result, func(i, j int) bool { return *result[i].DistanceKm < *result[j].DistanceKm })
+	}
+	return &urgencyV1.UrgencyList{Urgencies: result, Truncated: truncated}, nil
+}
+
+// SuggestResponders ranks on-call employees by distance from the urgency.
+// Employees without a reported position, or all of them when the urgency has no coordinates, come last.
+func (s *urgencyService) SuggestResponders(ctx context.Context, urgencyID uint) ([]urgencyV1.ResponderSuggestion, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.SuggestResponders")()
+
+	urg, err := s.GetUrgencyByID(ctx, urgencyID)
+	if err != nil {
+		return nil, err
+	}
+
+	onCall, err := s.employeeClient.GetOnCallEmployees(ctx, defaultShiftBuffer)
+	if err != nil {
+		log.Errorf("Failed to fetch on-call employees: %v", err)
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.ON_CALL_FETCH_FAILED", "failed to fetch on-call employees", map[string]interface{}{"cause": err.Error()})
+	}
+
+	suggestions := make([]urgencyV1.ResponderSuggestion, 0, len(onCall))
+	for _, emp := range onCall {
+		suggestion := urgencyV1.ResponderSuggestion{
+			EmployeeID:     emp.ID,
+			FirstName:      emp.FirstName,
+			LastName:       emp.LastName,
+			ProfileType:    emp.ProfileType,
+			Phone:          emp.Phone,
+			LastPositionAt: emp.LastPositionAt,
+		}
+		if urg.Latitude != nil && urg.Longitude != nil && emp.LastLatitude != nil && emp.LastLongitude != nil {
+			d := utils.DistanceKm(*urg.Latitude, *urg.Longitude, *emp.LastLatitude, *emp.LastLongitude)
+			suggestion.DistanceKm = &d
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		a, b := suggestions[i].DistanceKm, suggestions[j].DistanceKm
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return *a < *b
+	})
+	return suggestions, nil
+}
+
 func (s *urgencyService) GetAllUrgencies(ctx context.Context) ([]model.Urgency, error) {
 	log := s.log.WithContext(ctx)
 	defer utils.TimeOperation(log, "UrgencyService.GetAllUrgencies")()
@@ -142,18 +370,43 @@ func (s *urgencyService) GetUrgencyByIDPrimary(ctx context.Context, id uint) (*m
 	return &urgency, nil
 }
 
-func (s *urgencyService) UpdateUrgency(ctx context.Context, urgency *model.Urgency) error {
+// UpdateUrgency applies the request to the urgency as stored on the primary, so fields the request
+// does not touch keep their current values
+func (s *urgencyService) UpdateUrgency(ctx context.Context, id uint, req *urgencyV1.UrgencyUpdateRequest) (*model.Urgency, error) {
 	log := s.log.WithContext(ctx)
 	defer utils.TimeOperation(log, "UrgencyService.UpdateUrgency")()
+
+	urgency, err := s.GetUrgencyByIDPrimary(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	from := urgency.Status
+	urgency.UpdateWithRequest(req)
+	if urgency.Status != from {
+		if !model.CanTransition(from, urgency.Status) || !model.CanTransitionViaUpdate(from, urgency.Status) {
+			return nil, invalidTransitionError(from, urgency.Status)
+		}
+		if urgency.Status == urgencyV1.Resolved {
+			now := time.Now().UTC()
+			urgency.ResolvedAt = &now
+		}
+	}
+
+	urgency.SyncCoordinates()
 	urgency.SortPriority = model.ComputeSortPriority(urgency.Status, urgency.AssignedEmployeeID)
 	if urgency.SortPriority == 0 { // safety guard against zero triggering DB defaults
 		urgency.SortPriority = 1
 	}
-	if err := s.repo.Update(ctx, urgency); err != nil {
+	event := &model.UrgencyEvent{Type: model.UrgencyEventUpdated, ActorID: actorFromContext(ctx)}
+	saved, err := s.repo.SaveWithEvent(ctx, urgency, from, event)
+	if err != nil {
 		log.Errorf("Failed to update urgency: %v", err)
-		return commonv1.NewAppError("URGENCY_ERRORS.UPDATE_FAILED", "failed to update urgency", map[string]interface{}{"cause": err.Error()})
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.UPDATE_FAILED", "failed to update urgency", map[string]interface{}{"cause": err.Error()})
 	}
-	return nil
+	if !saved {
+		return nil, statusChangedError(from)
+	}
+	return urgency, nil
 }
 
 func (s *urgencyService) DeleteUrgency(ctx context.Context, id uint) error {
@@ -189,23 +442,38 @@ func (s *urgencyService) AssignUrgency(ctx context.Context, urgencyID, employeeI
 	if err != nil {
 		return err
 	}
+	if !urg.IntakeAccepted() {
+		return intakeNotAcceptedError(urg)
+	}
 	if urg.AssignedEmployeeID != nil {
 		return commonv1.NewAppError("URGENCY_ERRORS.ALREADY_ASSIGNED", "urgency already assigned", nil)
 	}
+	if urg.Status != urgencyV1.InProgress && !model.CanTransition(urg.Status, urgencyV1.InProgress) {
+		return invalidTransitionError(urg.Status, urgencyV1.InProgress)
+	}
 
-	if _, err := s.employeeClient.GetEmployeeByID(ctx, employeeID); err != nil {
+	assignee, err := s.employeeClient.GetEmployeeByID(ctx, employeeID)
+	if err != nil {
 		return commonv1.NewAppError("URGENCY_ERRORS.INVALID_ASSIGNEE", "employee does not exist or is not accessible", map[string]interface{}{"cause": err.Error(), "employeeId": employeeID})
 	}
 	now := time.Now().UTC()
+	actor := actorFromContext(ctx)
+	// the same conditional assignment as accepting, so an admin cannot overwrite whoever accepted first
+	won, err := s.repo.AssignIfUnassigned(ctx, urgencyID, employeeID, now, &model.UrgencyEvent{Type: model.UrgencyEventAssigned, ActorID: actor})
+	if err != nil {
+		return commonv1.NewAppError("URGENCY_ERRORS.UPDATE_FAILED", "failed to update urgency with assignment", map[string]interface{}{"cause": err.Error()})
+	}
+	if !won {
+		return commonv1.NewAppError("URGENCY_ERRORS.ALREADY_ASSIGNED", "urgency already assigned", nil)
+	}
 	urg.AssignedEmployeeID = &employeeID
 	urg.AssignedAt = &now
 	urg.Status = urgencyV1.InProgress
-	urg.SortPriority = model.ComputeSortPriority(urg.Status, urg.AssignedEmployeeID)
-	if urg.SortPriority == 0 { // safety guard against zero triggering DB defaults
-		urg.SortPriority = 1
-	}
-	if err := s.repo.Update(ctx, urg); err != nil {
-		return commonv1.NewAppError("URGENCY_ERRORS.UPDATE_FAILED", "failed to update urgency with assignment", map[string]interface{}{"cause": err.Error()})
+	// someone taking the urgency themselves already knows about it
+	if actor == nil || *actor != employeeID {
+		if err := s.createAssignmentAndNotification(ctx, urg, *assignee, templates.EventReassigned); err != nil {
+			log.Errorf("Failed to notify new assignee %d of urgency %d: %v", employeeID, urg.ID, err)
+		}
 	}
 	return nil
 }
@@ -233,9 +501,14 @@ func (s *urgencyService) UnassignUrgency(ctx context.Context, urgencyID uint, ac
 	if urg.SortPriority == 0 { // safety guard against zero triggering DB defaults
 		urg.SortPriority = 1
 	}
-	if err := s.repo.Update(ctx, urg); err != nil {
+	event := &model.UrgencyEvent{Type: model.UrgencyEventUnassigned, ActorID: &actorID}
+	saved, err := s.repo.SaveWithEvent(ctx, urg, urg.Status, event)
+	if err != nil {
 		return commonv1.NewAppError("URGENCY_ERRORS.UNASSIGN_FAILED", "failed to unassign", map[string]interface{}{"cause": err.Error()})
 	}
+	if !saved {
+		return statusChangedError(urg.Status)
+	}
 	return nil
 }
 
@@ -252,28 +525,223 @@ func (s *urgencyService) CloseUrgency(ctx context.Context, urgencyID uint, actor
 	if urg.AssignedEmployeeID == nil {
 		return commonv1.NewAppError("URGENCY_ERRORS.NOT_ASSIGNED", "urgency has no assigned employee", nil)
 	}
-	if urg.Status != urgencyV1.InProgress {
-		return commonv1.NewAppError("URGENCY_ERRORS.INVALID_STATE", "only in_progress urgencies can be closed", map[string]interface{}{"status": urg.Status})
+	if !model.CanTransition(urg.Status, urgencyV1.Closed) {
+		return invalidTransitionError(urg.Status, urgencyV1.Closed)
 	}
-	// Authorization: only assignee or admin can close
+	// Authorization: only the team lead (the assignee) or an admin can close
 	if !isAdmin && *urg.AssignedEmployeeID != actorID {
-		return commonv1.NewAppError("AUTH_ERRORS.FORBIDDEN", "only assignee or admin can close", map[string]interface{}{"assignee": *urg.AssignedEmployeeID})
+		return commonv1.NewAppError("AUTH_ERRORS.FORBIDDEN", "only the team lead or admin can close", map[string]interface{}{"lead": *urg.AssignedEmployeeID})
 	}
 	// Validate that assigned employee still exists
-	if _, err := s.employeeClient.GetEmployeeByID(ctx, *urg.AssignedEmployeeID); err != nil {
+	lead, err := s.employeeClient.GetEmployeeByID(ctx, *urg.AssignedEmployeeID)
+	if err != nil {
 		return commonv1.NewAppError("URGENCY_ERRORS.INVALID_ASSIGNEE", "assigned employee does not exist or is not accessible", map[string]interface{}{"cause": err.Error(), "employeeId": *urg.AssignedEmployeeID})
 	}
+	now := time.Now().UTC()
+	from := urg.Status
 	urg.Status = urgencyV1.Closed
+	urg.ClosedAt = &now
 	urg.SortPriority = model.ComputeSortPriority(urg.Status, urg.AssignedEmployeeID)
 	if urg.SortPriority == 0 { // safety guard against zero triggering DB defaults
 		urg.SortPriority = 1
 	}
-	if err := s.repo.Update(ctx, urg); err != nil {
+	event := &model.UrgencyEvent{Type: model.UrgencyEventClosed, ActorID: &actorID}
+	saved, err := s.repo.SaveWithEvent(ctx, urg, from, event)
+	if err != nil {
 		return commonv1.NewAppError("URGENCY_ERRORS.UPDATE_FAILED", "failed to close urgency", map[string]interface{}{"cause": err.Error()})
 	}
+	if !saved {
+		return statusChangedError(from)
+	}
+	s.notifyTeamClosed(ctx, urg, *lead, actorID)
+	return nil
+}
+
+// notifyTeamClosed tells the lead and the other active team members that the urgency was closed.
+// The employee who closed it is skipped; failures are only logged since the urgency is already closed.
+func (s *urgencyService) notifyTeamClosed(ctx context.Context, urg *model.Urgency, lead employeeV1.EmployeeResponse, actorID uint) {
+	log := s.log.WithContext(ctx)
+	recipients := []employeeV1.EmployeeResponse{lead}
+	members, err := s.repo.ListTeam(ctx, urg.ID)
+	if err != nil {
+		log.Errorf("Failed to list team of urgency %d for closing notifications: %v", urg.ID, err)
+	}
+	for _, m := range members {
+		if m.EmployeeID == lead.ID || m.EmployeeID == actorID {
+			continue
+		}
+		employee, err := s.employeeClient.GetEmployeeByID(ctx, m.EmployeeID)
+		if err != nil {
+			log.Errorf("Failed to fetch team member %d of urgency %d: %v", m.EmployeeID, urg.ID, err)
+			continue
+		}
+		recipients = append(recipients, *employee)
+	}
+	for _, employee := range recipients {
+		if employee.ID == actorID {
+			continue
+		}
+		if err := s.createAssignmentAndNotification(ctx, urg, employee, templates.EventClosed); err != nil {
+			log.Errorf("Failed to notify employee %d that urgency %d was closed: %v", employee.ID, urg.ID, err)
+		}
+	}
+}
+
+// ResolveUrgency marks an in-progress urgency as resolved and stores the resolution note.
+func (s *urgencyService) ResolveUrgency(ctx context.Context, urgencyID uint, actorID uint, isAdmin bool, note string) error {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.ResolveUrgency")()
+
+	urg, err := s.GetUrgencyByIDPrimary(ctx, urgencyID)
+	if err != nil {
+		return err
+	}
+	if err := authorizeAssigneeOrAdmin(urg, actorID, isAdmin, "resolve"); err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	urg.ResolutionNote = note
+	urg.ResolvedAt = &now
+	return s.transition(ctx, urg, urgencyV1.Resolved, &model.UrgencyEvent{Type: model.UrgencyEventResolved, ActorID: &actorID, Reason: note})
+}
+
+// ReopenUrgency moves a resolved, closed or cancelled urgency back to open and clears the assignment,
+// so it can be accepted again. Escalation restarts from the first step after the regular delay.
+func (s *urgencyService) ReopenUrgency(ctx context.Context, urgencyID uint, actorID uint, isAdmin bool, reason string) error {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.ReopenUrgency")()
+
+	urg, err := s.GetUrgencyByIDPrimary(ctx, urgencyID)
+	if err != nil {
+		return err
+	}
+	if err := authorizeAssigneeOrAdmin(urg, actorID, isAdmin, "reopen"); err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	urg.AssignedEmployeeID = nil
+	urg.AssignedAt = nil
+	urg.ResolutionNote = ""
+	urg.ResolvedAt = nil
+	urg.ClosedAt = nil
+	urg.EscalationLevel = model.EscalationNone
+	urg.LastEscalatedAt = &now
+	return s.transition(ctx, urg, urgencyV1.Open, &model.UrgencyEvent{Type: model.UrgencyEventReopened, ActorID: &actorID, Reason: reason})
+}
+
+// CancelUrgency cancels an urgency that is still open or in progress, e.g. a false alarm.
+func (s *urgencyService) CancelUrgency(ctx context.Context, urgencyID uint, actorID uint, isAdmin bool, reason string) error {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.CancelUrgency")()
+
+	urg, err := s.GetUrgencyByIDPrimary(ctx, urgencyID)
+	if err != nil {
+		return err
+	}
+	if err := authorizeAssigneeOrAdmin(urg, actorID, isAdmin, "cancel"); err != nil {
+		return err
+	}
+	return s.transition(ctx, urg, urgencyV1.Cancelled, &model.UrgencyEvent{Type: model.UrgencyEventCancelled, ActorID: &actorID, Reason: reason})
+}
+
+// MergeUrgency folds a duplicate urgency into the surviving one. The duplicate is cancelled and linked,
+// its notifications move in the same transaction and its activities are moved through the activity service.
+// Merging an already merged pair again only retries moving the activities.
+func (s *urgencyService) MergeUrgency(ctx context.Context, targetID, sourceID uint) (*urgencyV1.UrgencyMergeResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.MergeUrgency")()
+
+	if targetID == 0 || sourceID == 0 || targetID == sourceID {
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.INVALID_MERGE", "an urgency can only be merged into a different urgency", nil)
+	}
+	target, err := s.GetUrgencyByIDPrimary(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+	source, err := s.GetUrgencyByIDPrimary(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	if target.DuplicateOfID != nil || target.Status == urgencyV1.Cancelled {
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.INVALID_MERGE", "cannot merge into a cancelled or merged urgency", map[string]interface{}{"urgencyId": targetID})
+	}
+
+	resp := &urgencyV1.UrgencyMergeResponse{UrgencyID: targetID, MergedID: sourceID}
+	alreadyMerged := source.DuplicateOfID != nil && *source.DuplicateOfID == targetID && source.Status == urgencyV1.Cancelled
+	if !alreadyMerged {
+		if !model.CanTransition(source.Status, urgencyV1.Cancelled) {
+			return nil, invalidTransitionError(source.Status, urgencyV1.Cancelled)
+		}
+		source.Status = urgencyV1.Cancelled
+		source.DuplicateOfID = &targetID
+		source.ResolutionNote = fmt.Sprintf("merged into #%d", targetID)
+		source.SortPriority = model.ComputeSortPriority(source.Status, source.AssignedEmployeeID)
+		actor := actorFromContext(ctx)
+		sourceEvent := &model.UrgencyEvent{Type: model.UrgencyEventMerged, ActorID: actor, Reason: source.ResolutionNote}
+		targetEvent := &model.UrgencyEvent{Type: model.UrgencyEventMerged, ActorID: actor, Reason: fmt.Sprintf("merged #%d", sourceID), OldStatus: target.Status, NewStatus: target.Status}
+		moved, err := s.repo.MergeInto(ctx, source, targetID, sourceEvent, targetEvent)
+		if err != nil {
+			log.Errorf("Failed to merge urgency %d into %d: %v", sourceID, targetID, err)
+			return nil, commonv1.NewAppError("URGENCY_ERRORS.UPDATE_FAILED", "failed to merge urgencies", map[string]interface{}{"cause": err.Error()})
+		}
+		resp.NotificationsMoved = moved
+	}
+
+	if s.activityClient == nil {
+		resp.Partial = true
+	} else if moved, err := s.activityClient.ReassignActivities(ctx, sourceID, targetID); err != nil {
+		log.Warnf("Merged urgency %d into %d but failed to move activities: %v", sourceID, targetID, err)
+		resp.Partial = true
+	} else {
+		resp.ActivitiesMoved = moved
+	}
+
+	log.Infof("Merged urgency %d into %d: notifications=%d activities=%d partial=%t", sourceID, targetID, resp.NotificationsMoved, resp.ActivitiesMoved, resp.Partial)
+	return resp, nil
+}
+
+// transition validates the status change against the transition table and persists it with its history event.
+func (s *urgencyService) transition(ctx context.Context, urg *model.Urgency, to urgencyV1.UrgencyStatus, event *model.UrgencyEvent) error {
+	from := urg.Status
+	if !model.CanTransition(from, to) {
+		return invalidTransitionError(from, to)
+	}
+	urg.Status = to
+	urg.SortPriority = model.ComputeSortPriority(urg.Status, urg.AssignedEmployeeID)
+	if urg.SortPriority == 0 { // safety guard against zero triggering DB defaults
+		urg.SortPriority = 1
+	}
+	saved, err := s.repo.SaveWithEvent(ctx, urg, from, event)
+	if err != nil {
+		s.log.WithContext(ctx).Errorf("Failed to move urgency %d from %s to %s: %v", urg.ID, from, to, err)
+		return commonv1.NewAppError("URGENCY_ERRORS.UPDATE_FAILED", "failed to update urgency status", map[string]interface{}{"cause": err.Error()})
+	}
+	if !saved {
+		return statusChangedError(from)
+	}
+	return nil
+}
+
+func authorizeAssigneeOrAdmin(urg *model.Urgency, actorID uint, isAdmin bool, action string) error {
+	if isAdmin {
+		return nil
+	}
+	if urg.AssignedEmployeeID == nil || *urg.AssignedEmployeeID != actorID {
+		return commonv1.NewAppError("AUTH_ERRORS.FORBIDDEN", fmt.Sprintf("only assignee or admin can %s", action), nil)
+	}
 	return nil
 }
 
+func invalidTransitionError(from, to urgencyV1.UrgencyStatus) error {
+	return commonv1.NewAppError("URGENCY_ERRORS.INVALID_TRANSITION", fmt.Sprintf("cannot change status from %s to %s", from, to), map[string]interface{}{"from": from, "to": to})
+}
+
+// statusChangedError reports a change that lost the race against another request moving the urgency
+// out of the status the change was checked against
+func statusChangedError(from urgencyV1.UrgencyStatus) error {
+	return commonv1.NewAppError("URGENCY_ERRORS.INVALID_TRANSITION", fmt.Sprintf("urgency is no longer %s", from), map[string]interface{}{"from": from})
+}
+
 func (s *urgencyService) GetAssignment(ctx context.Context, urgencyID uint) (*urgencyV1.AssignmentResponse, error) {
 	log := s.log.WithContext(ctx)
 	defer utils.TimeOperation(log, "UrgencyService.GetAssignment")()
@@ -295,79 +763,330 @@ func (s *urgencyService) GetAssignment(ctx context.Context, urgencyID uint) (*ur
 	}, nil
 }
 
-func (s *urgencyService) createAssignmentAndNotification(ctx context.Context, urgency *model.Urgency, employee employeeV1.EmployeeResponse) error {
+// AcceptUrgency lets a notified employee take the urgency. The first acceptance wins;
+// later ones get URGENCY_ERRORS.ALREADY_ASSIGNED.
+func (s *urgencyService) AcceptUrgency(ctx context.Context, urgencyID, employeeID uint) error {
 	log := s.log.WithContext(ctx)
-	defer utils.TimeOperation(log, "UrgencyService.createAssignmentAndNotification")()
-	if employee.Phone != "" {
-		smsNotification := &model.Notification{
-			UrgencyID:        urgency.ID,
-			EmployeeID:       employee.ID,
-			NotificationType: model.NotificationSMS,
-			Recipient:        employee.Phone,
-			Message:          s.buildNotificationMessage(urgency, employee, "SMS"),
-			Status:           model.NotificationPending,
+	defer utils.TimeOperation(log, "UrgencyService.AcceptUrgency")()
+
+	if urgencyID == 0 || employeeID == 0 {
+		return commonv1.NewAppError("VALIDATION.INVALID_REQUEST", "urgencyId and employeeId are required", nil)
+	}
+	if err := s.ensureNotified(ctx, urgencyID, employeeID); err != nil {
+		return err
+	}
+
+	urg, err := s.GetUrgencyByIDPrimary(ctx, urgencyID)
+	if err != nil {
+		return err
+	}
+	if !urg.IntakeAccepted() {
+		return intakeNotAcceptedError(urg)
+	}
+	if urg.AssignedEmployeeID != nil {
+		if *urg.AssignedEmployeeID == employeeID {
+			return nil
 		}
+		return commonv1.NewAppError("URGENCY_ERRORS.ALREADY_ASSIGNED", "urgency already accepted by another employee", map[string]interface{}{"assignee": *urg.AssignedEmployeeID})
+	}
 
-		if err := s.notificationRepo.Create(ctx, smsNotification); err != nil {
-			log.Errorf("Failed to create SMS notification: %v", err)
-			// don't fail the whole flow; we log and continue
-		} else {
-			log.Infof("Created SMS notification %d for employee %d", smsNotification.ID, employee.ID)
+	now := time.Now().UTC()
+	won, err := s.repo.AssignIfUnassigned(ctx, urgencyID, employeeID, now, &model.UrgencyEvent{Type: model.UrgencyEventAccepted, ActorID: &employeeID})
+	if err != nil {
+		log.Errorf("Failed to assign urgency %d to employee %d: %v", urgencyID, employeeID, err)
+		return commonv1.NewAppError("URGENCY_ERRORS.UPDATE_FAILED", "failed to accept urgency", map[string]interface{}{"cause": err.Error()})
+	}
+	if !won {
+		return commonv1.NewAppError("URGENCY_ERRORS.ALREADY_ASSIGNED", "urgency already accepted by another employee", nil)
+	}
+
+	if _, err := s.notificationRepo.RecordReply(ctx, urgencyID, employeeID, model.NotificationAccepted, "", now); err != nil {
+		// assignment already happened; the reply is informational
+		log.Errorf("Failed to record acceptance for urgency %d employee %d: %v", urgencyID, employeeID, err)
+	}
+
+	log.Infof("Employee %d accepted urgency %d", employeeID, urgencyID)
+	return nil
+}
+
+// DeclineUrgency records that a notified employee refused the urgency and why.
+func (s *urgencyService) DeclineUrgency(ctx context.Context, urgencyID, employeeID uint, reason string) error {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.DeclineUrgency")()
+
+	if urgencyID == 0 || employeeID == 0 {
+		return commonv1.NewAppError("VALIDATION.INVALID_REQUEST", "urgencyId and employeeId are required", nil)
+	}
+	if err := s.ensureNotified(ctx, urgencyID, employeeID); err != nil {
+		return err
+	}
+
+	urg, err := s.GetUrgencyByIDPrimary(ctx, urgencyID)
+	if err != nil {
+		return err
+	}
+	if urg.AssignedEmployeeID != nil && *urg.AssignedEmployeeID == employeeID {
+		return commonv1.NewAppError("URGENCY_ERRORS.INVALID_STATE", "assignee must unassign instead of declining", nil)
+	}
+
+	if _, err := s.notificationRepo.RecordReply(ctx, urgencyID, employeeID, model.NotificationDeclined, strings.TrimSpace(reason), time.Now().UTC()); err != nil {
+		log.Errorf("Failed to record decline for urgency %d employee %d: %v", urgencyID, employeeID, err)
+		return commonv1.NewAppError("URGENCY_ERRORS.UPDATE_FAILED", "failed to record decline", map[string]interface{}{"cause": err.Error()})
+	}
+
+	log.Infof("Employee %d declined urgency %d", employeeID, urgencyID)
+	return nil
+}
+
+// ListReplies returns one entry per notified employee with their reply, if any.
+func (s *urgencyService) ListReplies(ctx context.Context, urgencyID uint) ([]urgencyV1.UrgencyReplyResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.ListReplies")()
+
+	notifications, err := s.notificationRepo.GetByUrgencyID(ctx, urgencyID)
+	if err != nil {
+		log.Errorf("Failed to get notifications for urgency %d: %v", urgencyID, err)
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.DB_ERROR", "failed to fetch notifications", map[string]interface{}{"cause": err.Error()})
+	}
+
+	replies := make([]urgencyV1.UrgencyReplyResponse, 0, len(notifications))
+	seen := make(map[uint]bool, len(notifications))
+	for _, n := range notifications {
+		if seen[n.EmployeeID] {
+			continue
+		}
+		seen[n.EmployeeID] = true
+		reply := urgencyV1.UrgencyReplyResponse{
+			EmployeeID:    n.EmployeeID,
+			Reply:         string(n.Reply),
+			DeclineReason: n.DeclineReason,
+		}
+		if n.RepliedAt != nil {
+			reply.RepliedAt = n.RepliedAt.Format(time.RFC3339)
 		}
+		replies = append(replies, reply)
 	}
+	return replies, nil
+}
+
+func (s *urgencyService) ListEscalations(ctx context.Context, urgencyID uint) ([]model.UrgencyEscalation, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.ListEscalations")()
 
-	if employee.Email != "" {
-		emailNotification := &model.Notification{
-			UrgencyID:        urgency.ID,
-			EmployeeID:       employee.ID,
-			NotificationType: model.NotificationEmail,
-			Recipient:        employee.Email,
-			Message:          s.buildNotificationMessage(urgency, employee, "Email"),
-			Status:           model.NotificationPending,
+	escalations, err := s.repo.ListEscalations(ctx, urgencyID)
+	if err != nil {
+		log.Errorf("Failed to get escalations for urgency %d: %v", urgencyID, err)
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.DB_ERROR", "failed to fetch escalations", map[string]interface{}{"cause": err.Error()})
+	}
+	return escalations, nil
+}
+
+// GetTimeline merges urgency state changes, escalations, reporter follow-ups and activity service entries in chronological order.
+// When activities cannot be fetched the urgency-side history is still returned and the response is marked partial.
+func (s *urgencyService) GetTimeline(ctx context.Context, urgencyID uint) (*urgencyV1.UrgencyTimelineResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.GetTimeline")()
+
+	urg, err := s.GetUrgencyByID(ctx, urgencyID)
+	if err != nil {
+		return nil, err
+	}
+	events, err := s.repo.ListEvents(ctx, urgencyID)
+	if err != nil {
+		log.Errorf("Failed to get events for urgency %d: %v", urgencyID, err)
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.DB_ERROR", "failed to fetch urgency events", map[string]interface{}{"cause": err.Error()})
+	}
+	escalations, err := s.repo.ListEscalations(ctx, urgencyID)
+	if err != nil {
+		log.Errorf("Failed to get escalations for urgency %d: %v", urgencyID, err)
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.DB_ERROR", "failed to fetch escalations", map[string]interface{}{"cause": err.Error()})
+	}
+	followUps, err := s.repo.ListFollowUps(ctx, urgencyID)
+	if err != nil {
+		log.Errorf("Failed to get reporter follow-ups for urgency %d: %v", urgencyID, err)
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.DB_ERROR", "failed to fetch reporter follow-ups", map[string]interface{}{"cause": err.Error()})
+	}
+
+	type timedEntry struct {
+		at    time.Time
+		entry urgencyV1.TimelineEntry
+	}
+	entries := []timedEntry{{
+		at:    urg.CreatedAt,
+		entry: urgencyV1.TimelineEntry{Type: "created", Source: "urgency", NewStatus: urgencyV1.Open},
+	}}
+	for _, ev := range events {
+		entries = append(entries, timedEntry{at: ev.CreatedAt, entry: urgencyV1.TimelineEntry{
+			Type:          string(ev.Type),
+			Source:        "urgency",
+			ActorID:       ev.ActorID,
+			OldStatus:     ev.OldStatus,
+			NewStatus:     ev.NewStatus,
+			OldAssigneeID: ev.OldAssigneeID,
+			NewAssigneeID: ev.NewAssigneeID,
+			Description:   ev.Reason,
+		}})
+	}
+	for _, esc := range escalations {
+		entries = append(entries, timedEntry{at: esc.CreatedAt, entry: urgencyV1.TimelineEntry{
+			Type:        "escalated",
+			Source:      "urgency",
+			Description: fmt.Sprintf("%s (notified %d)", esc.Action, esc.NotifiedCount),
+		}})
+	}
+	for _, f := range followUps {
+		entries = append(entries, timedEntry{at: f.CreatedAt, entry: urgencyV1.TimelineEntry{
+			Type:        "follow_up",
+			Source:      "reporter",
+			Description: followUpDescription(f),
+		}})
+	}
+
+	partial := false
+	if s.activityClient == nil {
+		partial = true
+	} else if activities, err := s.activityClient.GetActivitiesByUrgency(ctx, urgencyID); err != nil {
+		log.Warnf("Failed to get activities for urgency %d, returning partial timeline: %v", urgencyID, err)
+		partial = true
+	} else {
+		for _, a := range activities {
+			at, perr := time.Parse(time.RFC3339, a.CreatedAt)
+			if perr != nil {
+				log.Warnf("Skipping activity %d with invalid createdAt %q", a.ID, a.CreatedAt)
+				continue
+			}
+			actorID := a.EmployeeID
+			entries = append(entries, timedEntry{at: at, entry: urgencyV1.TimelineEntry{
+				Type:        "activity",
+				Source:      "activity",
+				ActorID:     &actorID,
+				Description: a.Description,
+			}})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].at.Before(entries[j].at) })
+	resp := &urgencyV1.UrgencyTimelineResponse{
+		UrgencyID: urgencyID,
+		Entries:   make([]urgencyV1.TimelineEntry, 0, len(entries)),
+		Partial:   partial,
+	}
+	for _, e := range entries {
+		e.entry.OccurredAt = e.at.UTC().Format(time.RFC3339)
+		resp.Entries = append(resp.Entries, e.entry)
+	}
+	return resp, nil
+}
+
+func (s *urgencyService) ensureNotified(ctx context.Context, urgencyID, employeeID uint) error {
+	notifications, err := s.notificationRepo.GetByUrgencyID(ctx, urgencyID)
+	if err != nil {
+		return commonv1.NewAppError("URGENCY_ERRORS.DB_ERROR", "failed to fetch notifications", map[string]interface{}{"cause": err.Error()})
+	}
+	for _, n := range notifications {
+		if n.EmployeeID == employeeID {
+			return nil
+		}
+	}
+	return commonv1.NewAppError("URGENCY_ERRORS.NOT_NOTIFIED", "employee was not notified about this urgency", map[string]interface{}{"employeeId": employeeID})
+}
+
+func (s *urgencyService) createAssignmentAndNotification(ctx context.Context, urgency *model.Urgency, employee employeeV1.EmployeeResponse, event templates.Event) error {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.createAssignmentAndNotification")()
+	now := time.Now().UTC()
+	if employee.Phone != "" && notificationAllowed(employee, templates.ChannelSMS, urgency.Level, now) {
+		if msg, err := s.renderNotification(urgency, employee, templates.ChannelSMS, event); err != nil {
+			log.Errorf("Failed to render %s SMS for employee %d: %v", event, employee.ID, err)
+		} else {
+			smsNotification := &model.Notification{
+				UrgencyID:        urgency.ID,
+				EmployeeID:       employee.ID,
+				NotificationType: model.NotificationSMS,
+				Recipient:        employee.Phone,
+				Message:          msg.Body,
+				Status:           model.NotificationPending,
+			}
+
+			if err := s.notificationRepo.Create(ctx, smsNotification); err != nil {
+				log.Errorf("Failed to create SMS notification: %v", err)
+				// don't fail the whole flow; we log and continue
+			} else {
+				log.Infof("Created SMS notification %d for employee %d", smsNotification.ID, employee.ID)
+			}
 		}
+	}
 
-		if err := s.notificationRepo.Create(ctx, emailNotification); err != nil {
-			log.Errorf("Failed to create email notification: %v", err)
-			// don't fail the whole flow; we log and continue
+	if employee.Email != "" && notificationAllowed(employee, templates.ChannelEmail, urgency.Level, now) {
+		if msg, err := s.renderNotification(urgency, employee, templates.ChannelEmail, event); err != nil {
+			log.Errorf("Failed to render %s email for employee %d: %v", event, employee.ID, err)
 		} else {
-			log.Infof("Created email notification %d for employee %d", emailNotification.ID, employee.ID)
+			emailNotification := &model.Notification{
+				UrgencyID:        urgency.ID,
+				EmployeeID:       employee.ID,
+				NotificationType: model.NotificationEmail,
+				Recipient:        employee.Email,
+				Subject:          msg.Subject,
+				Message:          msg.Body,
+				Status:           model.NotificationPending,
+			}
+
+			if err := s.notificationRepo.Create(ctx, emailNotification); err != nil {
+				log.Errorf("Failed to create email notification: %v", err)
+				// don't fail the whole flow; we log and continue
+			} else {
+				log.Infof("Created email notification %d for employee %d", emailNotification.ID, employee.ID)
+			}
 		}
 	}
 
 	return nil
 }
 
-func (s *urgencyService) buildNotificationMessage(urgency *model.Urgency, employee employeeV1.EmployeeResponse, notificationType string) string {
-	// TODO: this is temporary and will have to be moved to a template which will be rendered by a frontend and translated accordingly
-	baseMessage := fmt.Sprintf(
-		"🚨 EMERGENCY ALERT 🚨\n\n"+
-			"Hello %s %s,\n\n"+
-			"You have been assigned to an emergency situation:\n\n"+
-			"📍 Location: %s\n"+
-			"📞 Contact: %s (%s)\n"+
-			"📝 Description: %s\n"+
-			"⚠️ Priority: %s\n\n"+
-			"Please respond immediately by accepting or declining this assignment.",
-		employee.FirstName,
-		employee.LastName,
-		urgency.Location,
-		urgency.FirstName+" "+urgency.LastName,
-		urgency.ContactPhone,
-		urgency.Description,
-		urgency.Level,
-	)
-
-	if notificationType == "SMS" {
-		// TODO: this is temporary and will have to be moved to a template which will be rendered by a frontend and translated accordingly
-		return fmt.Sprintf(
-			"🚨 EMERGENCY: %s at %s. Contact: %s (%s). Priority: %s. Please respond ASAP.",
-			urgency.Description,
-			urgency.Location,
-			urgency.FirstName+" "+urgency.LastName,
-			urgency.ContactPhone,
-			urgency.Level,
-		)
-	}
-
-	return baseMessage
+// notificationAllowed applies the employee's notification preferences to a single channel.
+// Employees without stored preferences receive everything; critical urgencies and
+// on-call employees are not subject to quiet hours.
+func notificationAllowed(employee employeeV1.EmployeeResponse, channel templates.Channel, level urgencyV1.UrgencyLevel, now time.Time) bool {
+	prefs := employee.NotificationPreferences
+	if prefs == nil {
+		return true
+	}
+
+	channelPrefs := prefs.SMS
+	if channel == templates.ChannelEmail {
+		channelPrefs = prefs.Email
+	}
+	if !channelPrefs.Enabled {
+		return false
+	}
+	if channelPrefs.MinLevel != "" && level.Rank() < urgencyV1.UrgencyLevel(channelPrefs.MinLevel).Rank() {
+		return false
+	}
+
+	if level == urgencyV1.Critical || employee.OnCall {
+		return true
+	}
+	return !prefs.QuietHours.Contains(now)
+}
+
+// renderNotification renders the message in the recipient's preferred language
+func (s *urgencyService) renderNotification(urgency *model.Urgency, employee employeeV1.EmployeeResponse, channel templates.Channel, event templates.Event) (templates.Message, error) {
+	registry := s.templates
+	if registry == nil {
+		registry = templates.Default()
+	}
+	return registry.Render(channel, event, employee.PreferredLanguage, notificationData(urgency, employee))
+}
+
+func notificationData(urgency *model.Urgency, employee employeeV1.EmployeeResponse) templates.Data {
+	return templates.Data{
+		UrgencyID:          urgency.ID,
+		RecipientFirstName: employee.FirstName,
+		RecipientLastName:  employee.LastName,
+		ContactName:        urgency.FirstName + " " + urgency.LastName,
+		ContactPhone:       urgency.ContactPhone,
+		Location:           urgency.Location,
+		Description:        urgency.Description,
+		Level:              urgency.Level,
+	}
 }
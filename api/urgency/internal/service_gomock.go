@@ -11,6 +11,7 @@ package internal
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 
 	v1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
@@ -42,6 +43,49 @@ func (m *MockUrgencyService) EXPECT() *MockUrgencyServiceMockRecorder {
 	return m.recorder
 }
 
+// AcceptUrgency mocks base method.
+func (m *MockUrgencyService) AcceptUrgency(ctx context.Context, urgencyID, employeeID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptUrgency", ctx, urgencyID, employeeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptUrgency indicates an expected call of AcceptUrgency.
+func (mr *MockUrgencyServiceMockRecorder) AcceptUrgency(ctx, urgencyID, employeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptUrgency", reflect.TypeOf((*MockUrgencyService)(nil).AcceptUrgency), ctx, urgencyID, employeeID)
+}
+
+// AddFollowUp mocks base method.
+func (m *MockUrgencyService) AddFollowUp(ctx context.Context, token string, req v1.UrgencyFollowUpRequest) (*v1.UrgencyFollowUpResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFollowUp", ctx, token, req)
+	ret0, _ := ret[0].(*v1.UrgencyFollowUpResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddFollowUp indicates an expected call of AddFollowUp.
+func (mr *MockUrgencyServiceMockRecorder) AddFollowUp(ctx, token, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFollowUp", reflect.TypeOf((*MockUrgencyService)(nil).AddFollowUp), ctx, token, req)
+}
+
+// AddTeamMember mocks base method.
+func (m *MockUrgencyService) AddTeamMember(ctx context.Context, urgencyID, actorID uint, isAdmin bool, req v1.TeamMemberRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTeamMember", ctx, urgencyID, actorID, isAdmin, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTeamMember indicates an expected call of AddTeamMember.
+func (mr *MockUrgencyServiceMockRecorder) AddTeamMember(ctx, urgencyID, actorID, isAdmin, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTeamMember", reflect.TypeOf((*MockUrgencyService)(nil).AddTeamMember), ctx, urgencyID, actorID, isAdmin, req)
+}
+
 // AssignUrgency mocks base method.
 func (m *MockUrgencyService) AssignUrgency(ctx context.Context, urgencyID, employeeID uint) error {
 	m.ctrl.T.Helper()
@@ -56,6 +100,20 @@ func (mr *MockUrgencyServiceMockRecorder) AssignUrgency(ctx, urgencyID, employee
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignUrgency", reflect.TypeOf((*MockUrgencyService)(nil).AssignUrgency), ctx, urgencyID, employeeID)
 }
 
+// CancelUrgency mocks base method.
+func (m *MockUrgencyService) CancelUrgency(ctx context.Context, urgencyID, actorID uint, isAdmin bool, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelUrgency", ctx, urgencyID, actorID, isAdmin, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelUrgency indicates an expected call of CancelUrgency.
+func (mr *MockUrgencyServiceMockRecorder) CancelUrgency(ctx, urgencyID, actorID, isAdmin, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelUrgency", reflect.TypeOf((*MockUrgencyService)(nil).CancelUrgency), ctx, urgencyID, actorID, isAdmin, reason)
+}
+
 // CloseUrgency mocks base method.
 func (m *MockUrgencyService) CloseUrgency(ctx context.Context, urgencyID, actorID uint, isAdmin bool) error {
 	m.ctrl.T.Helper()
@@ -84,6 +142,34 @@ func (mr *MockUrgencyServiceMockRecorder) CreateUrgency(ctx, urgency any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUrgency", reflect.TypeOf((*MockUrgencyService)(nil).CreateUrgency), ctx, urgency)
 }
 
+// DeclineUrgency mocks base method.
+func (m *MockUrgencyService) DeclineUrgency(ctx context.Context, urgencyID, employeeID uint, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineUrgency", ctx, urgencyID, employeeID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeclineUrgency indicates an expected call of DeclineUrgency.
+func (mr *MockUrgencyServiceMockRecorder) DeclineUrgency(ctx, urgencyID, employeeID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineUrgency", reflect.TypeOf((*MockUrgencyService)(nil).DeclineUrgency), ctx, urgencyID, employeeID, reason)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockUrgencyService) DeleteAttachment(ctx context.Context, urgencyID, attachmentID, actorID uint, isAdmin bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", ctx, urgencyID, attachmentID, actorID, isAdmin)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockUrgencyServiceMockRecorder) DeleteAttachment(ctx, urgencyID, attachmentID, actorID, isAdmin any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockUrgencyService)(nil).DeleteAttachment), ctx, urgencyID, attachmentID, actorID, isAdmin)
+}
+
 // DeleteUrgency mocks base method.
 func (m *MockUrgencyService) DeleteUrgency(ctx context.Context, id uint) error {
 	m.ctrl.T.Helper()
@@ -128,6 +214,81 @@ func (mr *MockUrgencyServiceMockRecorder) GetAssignment(ctx, urgencyID any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignment", reflect.TypeOf((*MockUrgencyService)(nil).GetAssignment), ctx, urgencyID)
 }
 
+// GetEmployeeActiveUrgencies mocks base method.
+func (m *MockUrgencyService) GetEmployeeActiveUrgencies(ctx context.Context, employeeID uint) (*v1.EmployeeActiveUrgenciesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmployeeActiveUrgencies", ctx, employeeID)
+	ret0, _ := ret[0].(*v1.EmployeeActiveUrgenciesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmployeeActiveUrgencies indicates an expected call of GetEmployeeActiveUrgencies.
+func (mr *MockUrgencyServiceMockRecorder) GetEmployeeActiveUrgencies(ctx, employeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployeeActiveUrgencies", reflect.TypeOf((*MockUrgencyService)(nil).GetEmployeeActiveUrgencies), ctx, employeeID)
+}
+
+// GetStats mocks base method.
+func (m *MockUrgencyService) GetStats(ctx context.Context, query v1.UrgencyStatsQuery) (*v1.UrgencyStatsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats", ctx, query)
+	ret0, _ := ret[0].(*v1.UrgencyStatsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockUrgencyServiceMockRecorder) GetStats(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockUrgencyService)(nil).GetStats), ctx, query)
+}
+
+// GetTeam mocks base method.
+func (m *MockUrgencyService) GetTeam(ctx context.Context, urgencyID uint) (*v1.UrgencyTeamResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeam", ctx, urgencyID)
+	ret0, _ := ret[0].(*v1.UrgencyTeamResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeam indicates an expected call of GetTeam.
+func (mr *MockUrgencyServiceMockRecorder) GetTeam(ctx, urgencyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeam", reflect.TypeOf((*MockUrgencyService)(nil).GetTeam), ctx, urgencyID)
+}
+
+// GetTimeline mocks base method.
+func (m *MockUrgencyService) GetTimeline(ctx context.Context, urgencyID uint) (*v1.UrgencyTimelineResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimeline", ctx, urgencyID)
+	ret0, _ := ret[0].(*v1.UrgencyTimelineResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTimeline indicates an expected call of GetTimeline.
+func (mr *MockUrgencyServiceMockRecorder) GetTimeline(ctx, urgencyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeline", reflect.TypeOf((*MockUrgencyService)(nil).GetTimeline), ctx, urgencyID)
+}
+
+// GetTrackingStatus mocks base method.
+func (m *MockUrgencyService) GetTrackingStatus(ctx context.Context, token string) (*v1.UrgencyTrackingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrackingStatus", ctx, token)
+	ret0, _ := ret[0].(*v1.UrgencyTrackingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrackingStatus indicates an expected call of GetTrackingStatus.
+func (mr *MockUrgencyServiceMockRecorder) GetTrackingStatus(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrackingStatus", reflect.TypeOf((*MockUrgencyService)(nil).GetTrackingStatus), ctx, token)
+}
+
 // GetUrgencyByID mocks base method.
 func (m *MockUrgencyService) GetUrgencyByID(ctx context.Context, id uint) (*model.Urgency, error) {
 	m.ctrl.T.Helper()
@@ -143,6 +304,81 @@ func (mr *MockUrgencyServiceMockRecorder) GetUrgencyByID(ctx, id any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUrgencyByID", reflect.TypeOf((*MockUrgencyService)(nil).GetUrgencyByID), ctx, id)
 }
 
+// ListAttachments mocks base method.
+func (m *MockUrgencyService) ListAttachments(ctx context.Context, urgencyID, actorID uint, isAdmin bool, activityID *uint) ([]v1.AttachmentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAttachments", ctx, urgencyID, actorID, isAdmin, activityID)
+	ret0, _ := ret[0].([]v1.AttachmentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAttachments indicates an expected call of ListAttachments.
+func (mr *MockUrgencyServiceMockRecorder) ListAttachments(ctx, urgencyID, actorID, isAdmin, activityID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttachments", reflect.TypeOf((*MockUrgencyService)(nil).ListAttachments), ctx, urgencyID, actorID, isAdmin, activityID)
+}
+
+// ListEmployeeNotifications mocks base method.
+func (m *MockUrgencyService) ListEmployeeNotifications(ctx context.Context, employeeID, actorID uint, isAdmin bool) ([]v1.NotificationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEmployeeNotifications", ctx, employeeID, actorID, isAdmin)
+	ret0, _ := ret[0].([]v1.NotificationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEmployeeNotifications indicates an expected call of ListEmployeeNotifications.
+func (mr *MockUrgencyServiceMockRecorder) ListEmployeeNotifications(ctx, employeeID, actorID, isAdmin any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEmployeeNotifications", reflect.TypeOf((*MockUrgencyService)(nil).ListEmployeeNotifications), ctx, employeeID, actorID, isAdmin)
+}
+
+// ListEscalations mocks base method.
+func (m *MockUrgencyService) ListEscalations(ctx context.Context, urgencyID uint) ([]model.UrgencyEscalation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEscalations", ctx, urgencyID)
+	ret0, _ := ret[0].([]model.UrgencyEscalation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEscalations indicates an expected call of ListEscalations.
+func (mr *MockUrgencyServiceMockRecorder) ListEscalations(ctx, urgencyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEscalations", reflect.TypeOf((*MockUrgencyService)(nil).ListEscalations), ctx, urgencyID)
+}
+
+// ListIntakeReview mocks base method.
+func (m *MockUrgencyService) ListIntakeReview(ctx context.Context) (*v1.IntakeReviewList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIntakeReview", ctx)
+	ret0, _ := ret[0].(*v1.IntakeReviewList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIntakeReview indicates an expected call of ListIntakeReview.
+func (mr *MockUrgencyServiceMockRecorder) ListIntakeReview(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIntakeReview", reflect.TypeOf((*MockUrgencyService)(nil).ListIntakeReview), ctx)
+}
+
+// ListReplies mocks base method.
+func (m *MockUrgencyService) ListReplies(ctx context.Context, urgencyID uint) ([]v1.UrgencyReplyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReplies", ctx, urgencyID)
+	ret0, _ := ret[0].([]v1.UrgencyReplyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReplies indicates an expected call of ListReplies.
+func (mr *MockUrgencyServiceMockRecorder) ListReplies(ctx, urgencyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReplies", reflect.TypeOf((*MockUrgencyService)(nil).ListReplies), ctx, urgencyID)
+}
+
 // ListUnassignedIDs mocks base method.
 func (m *MockUrgencyService) ListUnassignedIDs(ctx context.Context) ([]uint, error) {
 	m.ctrl.T.Helper()
@@ -159,9 +395,9 @@ func (mr *MockUrgencyServiceMockRecorder) ListUnassignedIDs(ctx any) *gomock.Cal
 }
 
 // ListUrgencies mocks base method.
-func (m *MockUrgencyService) ListUrgencies(ctx context.Context, page, pageSize int, assignedEmployeeID *uint) ([]model.Urgency, int64, error) {
+func (m *MockUrgencyService) ListUrgencies(ctx context.Context, filter model.UrgencyFilter) ([]model.Urgency, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUrgencies", ctx, page, pageSize, assignedEmployeeID)
+	ret := m.ctrl.Call(m, "ListUrgencies", ctx, filter)
 	ret0, _ := ret[0].([]model.Urgency)
 	ret1, _ := ret[1].(int64)
 	ret2, _ := ret[2].(error)
@@ -169,9 +405,155 @@ func (m *MockUrgencyService) ListUrgencies(ctx context.Context, page, pageSize i
 }
 
 // ListUrgencies indicates an expected call of ListUrgencies.
-func (mr *MockUrgencyServiceMockRecorder) ListUrgencies(ctx, page, pageSize, assignedEmployeeID any) *gomock.Call {
+func (mr *MockUrgencyServiceMockRecorder) ListUrgencies(ctx, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUrgencies", reflect.TypeOf((*MockUrgencyService)(nil).ListUrgencies), ctx, page, pageSize, assignedEmployeeID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUrgencies", reflect.TypeOf((*MockUrgencyService)(nil).ListUrgencies), ctx, filter)
+}
+
+// ListUrgenciesInArea mocks base method.
+func (m *MockUrgencyService) ListUrgenciesInArea(ctx context.Context, query v1.UrgencyGeoQuery, isAdmin bool) (*v1.UrgencyList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUrgenciesInArea", ctx, query, isAdmin)
+	ret0, _ := ret[0].(*v1.UrgencyList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUrgenciesInArea indicates an expected call of ListUrgenciesInArea.
+func (mr *MockUrgencyServiceMockRecorder) ListUrgenciesInArea(ctx, query, isAdmin any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUrgenciesInArea", reflect.TypeOf((*MockUrgencyService)(nil).ListUrgenciesInArea), ctx, query, isAdmin)
+}
+
+// ListUrgencyNotifications mocks base method.
+func (m *MockUrgencyService) ListUrgencyNotifications(ctx context.Context, urgencyID, actorID uint, isAdmin bool) ([]v1.NotificationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUrgencyNotifications", ctx, urgencyID, actorID, isAdmin)
+	ret0, _ := ret[0].([]v1.NotificationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUrgencyNotifications indicates an expected call of ListUrgencyNotifications.
+func (mr *MockUrgencyServiceMockRecorder) ListUrgencyNotifications(ctx, urgencyID, actorID, isAdmin any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUrgencyNotifications", reflect.TypeOf((*MockUrgencyService)(nil).ListUrgencyNotifications), ctx, urgencyID, actorID, isAdmin)
+}
+
+// MergeUrgency mocks base method.
+func (m *MockUrgencyService) MergeUrgency(ctx context.Context, targetID, sourceID uint) (*v1.UrgencyMergeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeUrgency", ctx, targetID, sourceID)
+	ret0, _ := ret[0].(*v1.UrgencyMergeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeUrgency indicates an expected call of MergeUrgency.
+func (mr *MockUrgencyServiceMockRecorder) MergeUrgency(ctx, targetID, sourceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeUrgency", reflect.TypeOf((*MockUrgencyService)(nil).MergeUrgency), ctx, targetID, sourceID)
+}
+
+// OpenAttachment mocks base method.
+func (m *MockUrgencyService) OpenAttachment(ctx context.Context, urgencyID, attachmentID, actorID uint, isAdmin bool) (*v1.AttachmentResponse, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenAttachment", ctx, urgencyID, attachmentID, actorID, isAdmin)
+	ret0, _ := ret[0].(*v1.AttachmentResponse)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OpenAttachment indicates an expected call of OpenAttachment.
+func (mr *MockUrgencyServiceMockRecorder) OpenAttachment(ctx, urgencyID, attachmentID, actorID, isAdmin any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenAttachment", reflect.TypeOf((*MockUrgencyService)(nil).OpenAttachment), ctx, urgencyID, attachmentID, actorID, isAdmin)
+}
+
+// PreviewNotificationTemplate mocks base method.
+func (m *MockUrgencyService) PreviewNotificationTemplate(ctx context.Context, query v1.NotificationTemplatePreviewQuery) (*v1.NotificationTemplatePreviewResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewNotificationTemplate", ctx, query)
+	ret0, _ := ret[0].(*v1.NotificationTemplatePreviewResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewNotificationTemplate indicates an expected call of PreviewNotificationTemplate.
+func (mr *MockUrgencyServiceMockRecorder) PreviewNotificationTemplate(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewNotificationTemplate", reflect.TypeOf((*MockUrgencyService)(nil).PreviewNotificationTemplate), ctx, query)
+}
+
+// RejectIntake mocks base method.
+func (m *MockUrgencyService) RejectIntake(ctx context.Context, urgencyID, actorID uint, isAdmin bool, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectIntake", ctx, urgencyID, actorID, isAdmin, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RejectIntake indicates an expected call of RejectIntake.
+func (mr *MockUrgencyServiceMockRecorder) RejectIntake(ctx, urgencyID, actorID, isAdmin, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectIntake", reflect.TypeOf((*MockUrgencyService)(nil).RejectIntake), ctx, urgencyID, actorID, isAdmin, reason)
+}
+
+// ReleaseIntake mocks base method.
+func (m *MockUrgencyService) ReleaseIntake(ctx context.Context, urgencyID, actorID uint, isAdmin bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseIntake", ctx, urgencyID, actorID, isAdmin)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseIntake indicates an expected call of ReleaseIntake.
+func (mr *MockUrgencyServiceMockRecorder) ReleaseIntake(ctx, urgencyID, actorID, isAdmin any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseIntake", reflect.TypeOf((*MockUrgencyService)(nil).ReleaseIntake), ctx, urgencyID, actorID, isAdmin)
+}
+
+// RemoveTeamMember mocks base method.
+func (m *MockUrgencyService) RemoveTeamMember(ctx context.Context, urgencyID, employeeID, actorID uint, isAdmin bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTeamMember", ctx, urgencyID, employeeID, actorID, isAdmin)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTeamMember indicates an expected call of RemoveTeamMember.
+func (mr *MockUrgencyServiceMockRecorder) RemoveTeamMember(ctx, urgencyID, employeeID, actorID, isAdmin any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTeamMember", reflect.TypeOf((*MockUrgencyService)(nil).RemoveTeamMember), ctx, urgencyID, employeeID, actorID, isAdmin)
+}
+
+// ReopenUrgency mocks base method.
+func (m *MockUrgencyService) ReopenUrgency(ctx context.Context, urgencyID, actorID uint, isAdmin bool, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReopenUrgency", ctx, urgencyID, actorID, isAdmin, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReopenUrgency indicates an expected call of ReopenUrgency.
+func (mr *MockUrgencyServiceMockRecorder) ReopenUrgency(ctx, urgencyID, actorID, isAdmin, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReopenUrgency", reflect.TypeOf((*MockUrgencyService)(nil).ReopenUrgency), ctx, urgencyID, actorID, isAdmin, reason)
+}
+
+// ResendVerificationCode mocks base method.
+func (m *MockUrgencyService) ResendVerificationCode(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendVerificationCode", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendVerificationCode indicates an expected call of ResendVerificationCode.
+func (mr *MockUrgencyServiceMockRecorder) ResendVerificationCode(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerificationCode", reflect.TypeOf((*MockUrgencyService)(nil).ResendVerificationCode), ctx, token)
 }
 
 // ResetAllData mocks base method.
@@ -188,6 +570,50 @@ func (mr *MockUrgencyServiceMockRecorder) ResetAllData(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetAllData", reflect.TypeOf((*MockUrgencyService)(nil).ResetAllData), ctx)
 }
 
+// ResolveUrgency mocks base method.
+func (m *MockUrgencyService) ResolveUrgency(ctx context.Context, urgencyID, actorID uint, isAdmin bool, note string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveUrgency", ctx, urgencyID, actorID, isAdmin, note)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolveUrgency indicates an expected call of ResolveUrgency.
+func (mr *MockUrgencyServiceMockRecorder) ResolveUrgency(ctx, urgencyID, actorID, isAdmin, note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveUrgency", reflect.TypeOf((*MockUrgencyService)(nil).ResolveUrgency), ctx, urgencyID, actorID, isAdmin, note)
+}
+
+// RetryNotification mocks base method.
+func (m *MockUrgencyService) RetryNotification(ctx context.Context, notificationID uint) (*v1.NotificationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryNotification", ctx, notificationID)
+	ret0, _ := ret[0].(*v1.NotificationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetryNotification indicates an expected call of RetryNotification.
+func (mr *MockUrgencyServiceMockRecorder) RetryNotification(ctx, notificationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryNotification", reflect.TypeOf((*MockUrgencyService)(nil).RetryNotification), ctx, notificationID)
+}
+
+// SuggestResponders mocks base method.
+func (m *MockUrgencyService) SuggestResponders(ctx context.Context, urgencyID uint) ([]v1.ResponderSuggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestResponders", ctx, urgencyID)
+	ret0, _ := ret[0].([]v1.ResponderSuggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuggestResponders indicates an expected call of SuggestResponders.
+func (mr *MockUrgencyServiceMockRecorder) SuggestResponders(ctx, urgencyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestResponders", reflect.TypeOf((*MockUrgencyService)(nil).SuggestResponders), ctx, urgencyID)
+}
+
 // UnassignUrgency mocks base method.
 func (m *MockUrgencyService) UnassignUrgency(ctx context.Context, urgencyID, actorID uint, isAdmin bool) error {
 	m.ctrl.T.Helper()
@@ -203,15 +629,60 @@ func (mr *MockUrgencyServiceMockRecorder) UnassignUrgency(ctx, urgencyID, actorI
 }
 
 // UpdateUrgency mocks base method.
-func (m *MockUrgencyService) UpdateUrgency(ctx context.Context, urgency *model.Urgency) error {
+func (m *MockUrgencyService) UpdateUrgency(ctx context.Context, id uint, req *v1.UrgencyUpdateRequest) (*model.Urgency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUrgency", ctx, id, req)
+	ret0, _ := ret[0].(*model.Urgency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUrgency indicates an expected call of UpdateUrgency.
+func (mr *MockUrgencyServiceMockRecorder) UpdateUrgency(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUrgency", reflect.TypeOf((*MockUrgencyService)(nil).UpdateUrgency), ctx, id, req)
+}
+
+// UploadAttachment mocks base method.
+func (m *MockUrgencyService) UploadAttachment(ctx context.Context, urgencyID, actorID uint, isAdmin bool, upload AttachmentUpload) (*v1.AttachmentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadAttachment", ctx, urgencyID, actorID, isAdmin, upload)
+	ret0, _ := ret[0].(*v1.AttachmentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadAttachment indicates an expected call of UploadAttachment.
+func (mr *MockUrgencyServiceMockRecorder) UploadAttachment(ctx, urgencyID, actorID, isAdmin, upload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadAttachment", reflect.TypeOf((*MockUrgencyService)(nil).UploadAttachment), ctx, urgencyID, actorID, isAdmin, upload)
+}
+
+// UploadReporterAttachment mocks base method.
+func (m *MockUrgencyService) UploadReporterAttachment(ctx context.Context, token string, upload AttachmentUpload) (*v1.AttachmentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadReporterAttachment", ctx, token, upload)
+	ret0, _ := ret[0].(*v1.AttachmentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadReporterAttachment indicates an expected call of UploadReporterAttachment.
+func (mr *MockUrgencyServiceMockRecorder) UploadReporterAttachment(ctx, token, upload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadReporterAttachment", reflect.TypeOf((*MockUrgencyService)(nil).UploadReporterAttachment), ctx, token, upload)
+}
+
+// VerifyPhone mocks base method.
+func (m *MockUrgencyService) VerifyPhone(ctx context.Context, token string, req v1.UrgencyVerificationRequest) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUrgency", ctx, urgency)
+	ret := m.ctrl.Call(m, "VerifyPhone", ctx, token, req)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateUrgency indicates an expected call of UpdateUrgency.
-func (mr *MockUrgencyServiceMockRecorder) UpdateUrgency(ctx, urgency any) *gomock.Call {
+// VerifyPhone indicates an expected call of VerifyPhone.
+func (mr *MockUrgencyServiceMockRecorder) VerifyPhone(ctx, token, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUrgency", reflect.TypeOf((*MockUrgencyService)(nil).UpdateUrgency), ctx, urgency)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyPhone", reflect.TypeOf((*MockUrgencyService)(nil).VerifyPhone), ctx, token, req)
 }
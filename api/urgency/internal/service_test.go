@@ -5,14 +5,19 @@ import (
 	"testing"
 	"time"
 
+	activityV1 "github.com/pd120424d/mountain-service/api/contracts/activity/v1"
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
 	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
 	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
 	"github.com/pd120424d/mountain-service/api/shared/utils"
 	"github.com/pd120424d/mountain-service/api/urgency/internal/clients"
 	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
 	"github.com/pd120424d/mountain-service/api/urgency/internal/repositories"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/templates"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
 )
 
 func TestUrgencyService_CreateUrgency(t *testing.T) {
@@ -27,15 +32,37 @@ func TestUrgencyService_CreateUrgency(t *testing.T) {
 		mockNotificationRepo := repositories.NewMockNotificationRepository(mockCtrl)
 		mockEmployeeClient := clients.NewMockEmployeeClient(mockCtrl)
 
+		mockRepo.EXPECT().ListRecentActive(gomock.Any(), gomock.Any()).Return(nil, nil)
 		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 		mockEmployeeClient.EXPECT().GetOnCallEmployees(gomock.Any(), gomock.Any()).Return([]employeeV1.EmployeeResponse{}, nil)
 
-		svc := NewUrgencyService(log, mockRepo, mockNotificationRepo, mockEmployeeClient)
+		svc := NewUrgencyService(log, mockRepo, mockNotificationRepo, mockEmployeeClient, nil)
 
 		err := svc.CreateUrgency(context.Background(), &model.Urgency{})
 		assert.NoError(t, err)
 	})
 
+	t.Run("it stores coordinates parsed from the location", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		mockRepo := repositories.NewMockUrgencyRepository(mockCtrl)
+		mockEmployeeClient := clients.NewMockEmployeeClient(mockCtrl)
+
+		mockRepo.EXPECT().ListRecentActive(gomock.Any(), gomock.Any()).Return(nil, nil)
+		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u *model.Urgency) error {
+			if assert.NotNil(t, u.Latitude) && assert.NotNil(t, u.Longitude) {
+				assert.InDelta(t, 43.40, *u.Latitude, 1e-9)
+				assert.InDelta(t, -22.66, *u.Longitude, 1e-9)
+			}
+			return nil
+		})
+		mockEmployeeClient.EXPECT().GetOnCallEmployees(gomock.Any(), gomock.Any()).Return([]employeeV1.EmployeeResponse{}, nil)
+
+		svc := NewUrgencyService(utils.NewTestLogger(), mockRepo, repositories.NewMockNotificationRepository(mockCtrl), mockEmployeeClient, nil)
+
+		err := svc.CreateUrgency(context.Background(), &model.Urgency{Location: "N 43.40 W 22.66"})
+		assert.NoError(t, err)
+	})
+
 	t.Run("it returns an error when repository call fails", func(t *testing.T) {
 		log := utils.NewTestLogger()
 		mockCtrl := gomock.NewController(t)
@@ -45,9 +72,10 @@ func TestUrgencyService_CreateUrgency(t *testing.T) {
 		mockNotificationRepo := repositories.NewMockNotificationRepository(mockCtrl)
 		mockEmployeeClient := clients.NewMockEmployeeClient(mockCtrl)
 
+		mockRepo.EXPECT().ListRecentActive(gomock.Any(), gomock.Any()).Return(nil, nil)
 		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(assert.AnError)
 
-		svc := NewUrgencyService(log, mockRepo, mockNotificationRepo, mockEmployeeClient)
+		svc := NewUrgencyService(log, mockRepo, mockNotificationRepo, mockEmployeeClient, nil)
 
 		err := svc.CreateUrgency(context.Background(), &model.Urgency{})
 		assert.Error(t, err)
@@ -62,10 +90,11 @@ func TestUrgencyService_CreateUrgency(t *testing.T) {
 		mockNotificationRepo := repositories.NewMockNotificationRepository(mockCtrl)
 		mockEmployeeClient := clients.NewMockEmployeeClient(mockCtrl)
 
+		mockRepo.EXPECT().ListRecentActive(gomock.Any(), gomock.Any()).Return(nil, nil)
 		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 		mockEmployeeClient.EXPECT().GetOnCallEmployees(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
 
-		svc := NewUrgencyService(log, mockRepo, mockNotificationRepo, mockEmployeeClient)
+		svc := NewUrgencyService(log, mockRepo, mockNotificationRepo, mockEmployeeClient, nil)
 
 		err := svc.CreateUrgency(context.Background(), &model.Urgency{})
 		assert.Error(t, err)
@@ -80,6 +109,7 @@ func TestUrgencyService_CreateUrgency(t *testing.T) {
 		mockNotificationRepo := repositories.NewMockNotificationRepository(mockCtrl)
 		mockEmployeeClient := clients.NewMockEmployeeClient(mockCtrl)
 
+		mockRepo.EXPECT().ListRecentActive(gomock.Any(), gomock.Any()).Return(nil, nil)
 		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 		mockEmployeeClient.EXPECT().GetOnCallEmployees(gomock.Any(), gomock.Any()).Return([]employeeV1.EmployeeResponse{
 			{
@@ -96,7 +126,7 @@ func TestUrgencyService_CreateUrgency(t *testing.T) {
 		// Simulate failures in notification creation and ensure service logs and continues without error.
 		mockNotificationRepo.EXPECT().Create(gomock.Any(), gomock.Any()).AnyTimes().Return(assert.AnError)
 
-		svc := NewUrgencyService(log, mockRepo, mockNotificationRepo, mockEmployeeClient)
+		svc := NewUrgencyService(log, mockRepo, mockNotificationRepo, mockEmployeeClient, nil)
 
 		err := svc.CreateUrgency(context.Background(), &model.Urgency{})
 		assert.NoError(t, err)
@@ -156,10 +186,11 @@ func TestUrgencyService_ListUrgencies(t *testing.T) {
 	defer ctrl.Finish()
 
 	repo := repositories.NewMockUrgencyRepository(ctrl)
-	repo.EXPECT().ListPaginated(gomock.Any(), 2, 10, gomock.Nil()).Return([]model.Urgency{{ID: 1}}, int64(11), nil)
+	filter := model.UrgencyFilter{Page: 2, PageSize: 10, Statuses: []urgencyV1.UrgencyStatus{urgencyV1.Open}, Query: "ski"}
+	repo.EXPECT().ListPaginated(gomock.Any(), filter).Return([]model.Urgency{{ID: 1}}, int64(11), nil)
 
 	svc := &urgencyService{log: log, repo: repo}
-	items, total, err := svc.ListUrgencies(context.Background(), 2, 10, nil)
+	items, total, err := svc.ListUrgencies(context.Background(), filter)
 	assert.NoError(t, err)
 	assert.Equal(t, int64(11), total)
 	assert.Len(t, items, 1)
@@ -206,33 +237,96 @@ func TestUrgencyService_GetUrgencyByID(t *testing.T) {
 func TestUrgencyService_UpdateUrgency(t *testing.T) {
 	t.Parallel()
 
+	stored := func(status urgencyV1.UrgencyStatus) func(context.Context, uint, *model.Urgency) error {
+		return func(_ context.Context, id uint, u *model.Urgency) error {
+			*u = model.Urgency{ID: id, Status: status}
+			return nil
+		}
+	}
+
 	t.Run("it successfully updates an urgency", func(t *testing.T) {
 		log := utils.NewTestLogger()
 		mockCtrl := gomock.NewController(t)
 		defer mockCtrl.Finish()
 
 		mockRepo := repositories.NewMockUrgencyRepository(mockCtrl)
-		mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().GetByIDPrimary(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(stored(urgencyV1.Open))
+		mockRepo.EXPECT().SaveWithEvent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
 
 		svc := &urgencyService{log: log, repo: mockRepo}
 
-		err := svc.UpdateUrgency(context.Background(), &model.Urgency{})
+		_, err := svc.UpdateUrgency(context.Background(), 1, &urgencyV1.UrgencyUpdateRequest{Status: urgencyV1.Open})
 		assert.NoError(t, err)
 	})
 
+	t.Run("it applies the request to the stored urgency and keeps the fields it does not touch", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		mockRepo := repositories.NewMockUrgencyRepository(mockCtrl)
+		lead := uint(7)
+		mockRepo.EXPECT().GetByIDPrimary(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, u *model.Urgency) error {
+			*u = model.Urgency{ID: id, Status: urgencyV1.InProgress, AssignedEmployeeID: &lead, EscalationLevel: 2, IntakeStatus: urgencyV1.IntakeAccepted, Description: "old"}
+			return nil
+		})
+		mockRepo.EXPECT().SaveWithEvent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u *model.Urgency, _ urgencyV1.UrgencyStatus, _ *model.UrgencyEvent) (bool, error) {
+			assert.Equal(t, "new", u.Description)
+			require.NotNil(t, u.AssignedEmployeeID)
+			assert.Equal(t, lead, *u.AssignedEmployeeID)
+			assert.Equal(t, model.EscalationStep(2), u.EscalationLevel)
+			assert.Equal(t, urgencyV1.IntakeAccepted, u.IntakeStatus)
+			return true, nil
+		})
+		svc := &urgencyService{log: utils.NewTestLogger(), repo: mockRepo}
+
+		urg, err := svc.UpdateUrgency(context.Background(), 1, &urgencyV1.UrgencyUpdateRequest{Description: "new"})
+		require.NoError(t, err)
+		assert.Equal(t, urgencyV1.InProgress, urg.Status)
+	})
+
 	t.Run("it returns an error when repository call fails", func(t *testing.T) {
 		log := utils.NewTestLogger()
 		mockCtrl := gomock.NewController(t)
 		defer mockCtrl.Finish()
 
 		mockRepo := repositories.NewMockUrgencyRepository(mockCtrl)
-		mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(assert.AnError)
+		mockRepo.EXPECT().GetByIDPrimary(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(stored(urgencyV1.Open))
+		mockRepo.EXPECT().SaveWithEvent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(false, assert.AnError)
 
 		svc := &urgencyService{log: log, repo: mockRepo}
 
-		err := svc.UpdateUrgency(context.Background(), &model.Urgency{})
+		_, err := svc.UpdateUrgency(context.Background(), 1, &urgencyV1.UrgencyUpdateRequest{Status: urgencyV1.Open})
 		assert.Error(t, err)
 	})
+
+	t.Run("it allows resolving an in progress urgency and stamps the resolution time", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		mockRepo := repositories.NewMockUrgencyRepository(mockCtrl)
+		mockRepo.EXPECT().GetByIDPrimary(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(stored(urgencyV1.InProgress))
+		mockRepo.EXPECT().SaveWithEvent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+		svc := &urgencyService{log: utils.NewTestLogger(), repo: mockRepo}
+
+		urg, err := svc.UpdateUrgency(context.Background(), 1, &urgencyV1.UrgencyUpdateRequest{Status: urgencyV1.Resolved})
+		require.NoError(t, err)
+		assert.NotNil(t, urg.ResolvedAt)
+		assert.Equal(t, 4, urg.SortPriority)
+	})
+
+	t.Run("it rejects status jumps that bypass the dedicated flows", func(t *testing.T) {
+		cases := []struct{ from, to urgencyV1.UrgencyStatus }{
+			{urgencyV1.Closed, urgencyV1.Open},
+			{urgencyV1.InProgress, urgencyV1.Closed},
+			{urgencyV1.Open, urgencyV1.Cancelled},
+			{urgencyV1.Open, urgencyV1.Resolved},
+		}
+		for _, tc := range cases {
+			mockCtrl := gomock.NewController(t)
+			mockRepo := repositories.NewMockUrgencyRepository(mockCtrl)
+			mockRepo.EXPECT().GetByIDPrimary(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(stored(tc.from))
+			svc := &urgencyService{log: utils.NewTestLogger(), repo: mockRepo}
+
+			_, err := svc.UpdateUrgency(context.Background(), 1, &urgencyV1.UrgencyUpdateRequest{Status: tc.to})
+			assertAppErrorCode(t, err, "URGENCY_ERRORS.INVALID_TRANSITION")
+		}
+	})
 }
 
 func TestUrgencyService_DeleteUrgency(t *testing.T) {
@@ -311,7 +405,7 @@ func TestNewUrgencyService(t *testing.T) {
 		mockNotificationRepo := repositories.NewMockNotificationRepository(mockCtrl)
 		mockEmployeeClient := clients.NewMockEmployeeClient(mockCtrl)
 
-		svc := NewUrgencyService(log, mockRepo, mockNotificationRepo, mockEmployeeClient)
+		svc := NewUrgencyService(log, mockRepo, mockNotificationRepo, mockEmployeeClient, nil)
 		assert.NotNil(t, svc)
 		assert.IsType(t, &urgencyService{}, svc)
 	})
@@ -376,8 +470,9 @@ func TestUrgencyService_createAssignmentAndNotification(t *testing.T) {
 			return nil
 		})
 
-		svc := NewUrgencyService(log, mockRepo, mockNotificationRepo, mockEmployeeClient)
+		svc := NewUrgencyService(log, mockRepo, mockNotificationRepo, mockEmployeeClient, nil)
 
+		mockRepo.EXPECT().ListRecentActive(gomock.Any(), gomock.Any()).Return(nil, nil)
 		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 
 		err := svc.CreateUrgency(context.Background(), urgency)
@@ -420,9 +515,10 @@ func TestUrgencyService_createAssignmentAndNotification(t *testing.T) {
 			return nil
 		})
 
+		mockRepo.EXPECT().ListRecentActive(gomock.Any(), gomock.Any()).Return(nil, nil)
 		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 
-		svc := NewUrgencyService(log, mockRepo, mockNotificationRepo, mockEmployeeClient)
+		svc := NewUrgencyService(log, mockRepo, mockNotificationRepo, mockEmployeeClient, nil)
 
 		err := svc.CreateUrgency(context.Background(), urgency)
 		assert.NoError(t, err)
@@ -459,11 +555,12 @@ func TestUrgencyService_createAssignmentAndNotification(t *testing.T) {
 			Level:        "High",
 		}
 
+		mockRepo.EXPECT().ListRecentActive(gomock.Any(), gomock.Any()).Return(nil, nil)
 		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 		// Notifications are created on create (no assignments). Accept any number of creates.
 		mockNotificationRepo.EXPECT().Create(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
 
-		svc := NewUrgencyService(log, mockRepo, mockNotificationRepo, mockEmployeeClient)
+		svc := NewUrgencyService(log, mockRepo, mockNotificationRepo, mockEmployeeClient, nil)
 
 		err := svc.CreateUrgency(context.Background(), urgency)
 		assert.NoError(t, err) // CreateUrgency should not return error, it logs and continues
@@ -500,10 +597,11 @@ func TestUrgencyService_createAssignmentAndNotification(t *testing.T) {
 			Level:        "High",
 		}
 
+		mockRepo.EXPECT().ListRecentActive(gomock.Any(), gomock.Any()).Return(nil, nil)
 		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 		mockNotificationRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(assert.AnError).AnyTimes()
 
-		svc := NewUrgencyService(log, mockRepo, mockNotificationRepo, mockEmployeeClient)
+		svc := NewUrgencyService(log, mockRepo, mockNotificationRepo, mockEmployeeClient, nil)
 
 		err := svc.CreateUrgency(context.Background(), urgency)
 		assert.NoError(t, err) // CreateUrgency should not return error, it logs and continues
@@ -540,10 +638,11 @@ func TestUrgencyService_createAssignmentAndNotification(t *testing.T) {
 			Level:        "High",
 		}
 
+		mockRepo.EXPECT().ListRecentActive(gomock.Any(), gomock.Any()).Return(nil, nil)
 		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 		mockNotificationRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(assert.AnError)
 
-		svc := NewUrgencyService(log, mockRepo, mockNotificationRepo, mockEmployeeClient)
+		svc := NewUrgencyService(log, mockRepo, mockNotificationRepo, mockEmployeeClient, nil)
 
 		err := svc.CreateUrgency(context.Background(), urgency)
 		assert.NoError(t, err) // CreateUrgency should not return error, it logs and continues
@@ -580,10 +679,11 @@ func TestUrgencyService_createAssignmentAndNotification(t *testing.T) {
 			Level:        "High",
 		}
 
+		mockRepo.EXPECT().ListRecentActive(gomock.Any(), gomock.Any()).Return(nil, nil)
 		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 		// No notification expectations since employee has no contact info
 
-		svc := NewUrgencyService(log, mockRepo, mockNotificationRepo, mockEmployeeClient)
+		svc := NewUrgencyService(log, mockRepo, mockNotificationRepo, mockEmployeeClient, nil)
 
 		err := svc.CreateUrgency(context.Background(), urgency)
 		assert.NoError(t, err)
@@ -620,6 +720,7 @@ func TestUrgencyService_createAssignmentAndNotification(t *testing.T) {
 			Level:        "High",
 		}
 
+		mockRepo.EXPECT().ListRecentActive(gomock.Any(), gomock.Any()).Return(nil, nil)
 		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 
 		// Expect two notification creations: SMS and Email
@@ -634,7 +735,7 @@ func TestUrgencyService_createAssignmentAndNotification(t *testing.T) {
 			return nil
 		})
 
-		svc := NewUrgencyService(log, mockRepo, mockNotificationRepo, mockEmployeeClient)
+		svc := NewUrgencyService(log, mockRepo, mockNotificationRepo, mockEmployeeClient, nil)
 
 		err := svc.CreateUrgency(context.Background(), urgency)
 		assert.NoError(t, err)
@@ -678,6 +779,7 @@ func TestUrgencyService_createAssignmentAndNotification(t *testing.T) {
 			Level:        "High",
 		}
 
+		mockRepo.EXPECT().ListRecentActive(gomock.Any(), gomock.Any()).Return(nil, nil)
 		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 
 		// First employee - notifications succeed
@@ -686,13 +788,92 @@ func TestUrgencyService_createAssignmentAndNotification(t *testing.T) {
 		// Second employee - notifications fail (both SMS and Email), service should log and continue
 		mockNotificationRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(assert.AnError).Times(2)
 
-		svc := NewUrgencyService(log, mockRepo, mockNotificationRepo, mockEmployeeClient)
+		svc := NewUrgencyService(log, mockRepo, mockNotificationRepo, mockEmployeeClient, nil)
 
 		err := svc.CreateUrgency(context.Background(), urgency)
 		assert.NoError(t, err) // Should not return error even if some notifications fail
 	})
 }
 
+func TestNotificationAllowed(t *testing.T) {
+	t.Parallel()
+
+	quietNight := time.Date(2025, 3, 1, 2, 0, 0, 0, time.UTC)
+	daytime := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	prefs := &employeeV1.NotificationPreferences{
+		SMS:        employeeV1.ChannelPreference{Enabled: false},
+		Email:      employeeV1.ChannelPreference{Enabled: true, MinLevel: "medium"},
+		QuietHours: &employeeV1.QuietHours{Start: "22:00", End: "07:00"},
+	}
+	employee := employeeV1.EmployeeResponse{ID: 1, NotificationPreferences: prefs}
+
+	t.Run("it allows every channel when the employee has no preferences", func(t *testing.T) {
+		plain := employeeV1.EmployeeResponse{ID: 1}
+		assert.True(t, notificationAllowed(plain, templates.ChannelSMS, urgencyV1.Low, quietNight))
+		assert.True(t, notificationAllowed(plain, templates.ChannelEmail, urgencyV1.Low, quietNight))
+	})
+
+	t.Run("it skips disabled channels even for critical urgencies", func(t *testing.T) {
+		assert.False(t, notificationAllowed(employee, templates.ChannelSMS, urgencyV1.Critical, daytime))
+	})
+
+	t.Run("it skips urgencies below the channel minimum level", func(t *testing.T) {
+		assert.False(t, notificationAllowed(employee, templates.ChannelEmail, urgencyV1.Low, daytime))
+		assert.True(t, notificationAllowed(employee, templates.ChannelEmail, urgencyV1.Medium, daytime))
+	})
+
+	t.Run("it suppresses non-critical urgencies during quiet hours", func(t *testing.T) {
+		assert.False(t, notificationAllowed(employee, templates.ChannelEmail, urgencyV1.High, quietNight))
+	})
+
+	t.Run("it lets critical urgencies bypass quiet hours", func(t *testing.T) {
+		assert.True(t, notificationAllowed(employee, templates.ChannelEmail, urgencyV1.Critical, quietNight))
+	})
+
+	t.Run("it ignores quiet hours while the employee is on call", func(t *testing.T) {
+		onCall := employee
+		onCall.OnCall = true
+		assert.True(t, notificationAllowed(onCall, templates.ChannelEmail, urgencyV1.High, quietNight))
+	})
+
+	t.Run("it holds employees of the next shift during quiet hours", func(t *testing.T) {
+		// the employee service leaves OnCall unset for employees pulled in by the shift buffer
+		nextShift := employee
+		nextShift.OnCall = false
+		assert.False(t, notificationAllowed(nextShift, templates.ChannelEmail, urgencyV1.High, quietNight))
+	})
+}
+
+func TestUrgencyService_createAssignmentAndNotification_Preferences(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it only creates notifications for enabled channels", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mockNotificationRepo := repositories.NewMockNotificationRepository(mockCtrl)
+		svc := &urgencyService{log: utils.NewTestLogger(), notificationRepo: mockNotificationRepo}
+
+		employee := employeeV1.EmployeeResponse{
+			ID:    1,
+			Phone: "+1987654321",
+			Email: "marko@example.com",
+			NotificationPreferences: &employeeV1.NotificationPreferences{
+				SMS:   employeeV1.ChannelPreference{Enabled: false},
+				Email: employeeV1.ChannelPreference{Enabled: true},
+			},
+		}
+
+		mockNotificationRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, notification *model.Notification) error {
+			assert.Equal(t, model.NotificationEmail, notification.NotificationType)
+			return nil
+		})
+
+		err := svc.createAssignmentAndNotification(context.Background(), &model.Urgency{ID: 1, Level: urgencyV1.High}, employee, templates.EventNewUrgency)
+		assert.NoError(t, err)
+	})
+}
+
 func TestUrgencyService_buildNotificationMessage(t *testing.T) {
 	t.Parallel()
 
@@ -751,9 +932,10 @@ func TestUrgencyService_buildNotificationMessage(t *testing.T) {
 			return nil
 		})
 
+		mockRepo.EXPECT().ListRecentActive(gomock.Any(), gomock.Any()).Return(nil, nil)
 		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 
-		svc := NewUrgencyService(log, mockRepo, mockNotificationRepo, mockEmployeeClient)
+		svc := NewUrgencyService(log, mockRepo, mockNotificationRepo, mockEmployeeClient, nil)
 
 		err := svc.CreateUrgency(context.Background(), urgency)
 		assert.NoError(t, err)
@@ -810,13 +992,39 @@ func TestUrgencyService_buildNotificationMessage(t *testing.T) {
 			return nil
 		})
 
+		mockRepo.EXPECT().ListRecentActive(gomock.Any(), gomock.Any()).Return(nil, nil)
 		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 
-		svc := NewUrgencyService(log, mockRepo, mockNotificationRepo, mockEmployeeClient)
+		svc := NewUrgencyService(log, mockRepo, mockNotificationRepo, mockEmployeeClient, nil)
 
 		err := svc.CreateUrgency(context.Background(), urgency)
 		assert.NoError(t, err)
 	})
+
+	t.Run("it renders the message in the employee's preferred language", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repositories.NewMockUrgencyRepository(ctrl)
+		mockNotificationRepo := repositories.NewMockNotificationRepository(ctrl)
+		mockEmployeeClient := clients.NewMockEmployeeClient(ctrl)
+		mockEmployeeClient.EXPECT().GetOnCallEmployees(gomock.Any(), gomock.Any()).Return([]employeeV1.EmployeeResponse{
+			{ID: 1, FirstName: "Марко", LastName: "Марковић", Email: "marko@example.com", PreferredLanguage: "sr-cyr"},
+		}, nil)
+		mockRepo.EXPECT().ListRecentActive(gomock.Any(), gomock.Any()).Return(nil, nil)
+		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u *model.Urgency) error {
+			u.ID = 3
+			return nil
+		})
+		mockNotificationRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, n *model.Notification) error {
+			assert.Equal(t, "Горска служба - хитан случај #3 (критичан)", n.Subject)
+			assert.Contains(t, n.Message, "Здраво Марко Марковић")
+			assert.Contains(t, n.Message, "⚠️ Приоритет: критичан")
+			return nil
+		})
+
+		svc := NewUrgencyService(utils.NewTestLogger(), mockRepo, mockNotificationRepo, mockEmployeeClient, nil)
+		err := svc.CreateUrgency(context.Background(), &model.Urgency{FirstName: "Ana", LastName: "Jovanovic", Location: "Midzor", Description: "Lost hiker", Level: urgencyV1.Critical})
+		assert.NoError(t, err)
+	})
 }
 
 func TestUrgencyService_AssignUrgency(t *testing.T) {
@@ -831,7 +1039,7 @@ func TestUrgencyService_AssignUrgency(t *testing.T) {
 		nrepo := repositories.NewMockNotificationRepository(ctrl)
 		ecli := clients.NewMockEmployeeClient(ctrl)
 
-		svc := NewUrgencyService(log, repo, nrepo, ecli)
+		svc := NewUrgencyService(log, repo, nrepo, ecli, nil)
 		err := svc.AssignUrgency(context.Background(), 0, 10)
 		assert.Error(t, err)
 		err = svc.AssignUrgency(context.Background(), 10, 0)
@@ -848,7 +1056,7 @@ func TestUrgencyService_AssignUrgency(t *testing.T) {
 		ecli := clients.NewMockEmployeeClient(ctrl)
 
 		repo.EXPECT().GetByIDPrimary(gomock.Any(), uint(1), gomock.Any()).Return(assert.AnError)
-		svc := NewUrgencyService(log, repo, nrepo, ecli)
+		svc := NewUrgencyService(log, repo, nrepo, ecli, nil)
 		err := svc.AssignUrgency(context.Background(), 1, 2)
 		assert.Error(t, err)
 	})
@@ -868,11 +1076,23 @@ func TestUrgencyService_AssignUrgency(t *testing.T) {
 			u.ID = id
 			return nil
 		})
-		svc := NewUrgencyService(log, repo, nrepo, ecli)
+		svc := NewUrgencyService(log, repo, nrepo, ecli, nil)
 		err := svc.AssignUrgency(context.Background(), 1, 2)
 		assert.Error(t, err)
 	})
 
+	t.Run("it refuses urgencies held back by the intake checks", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		repo.EXPECT().GetByIDPrimary(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, u *model.Urgency) error {
+			*u = model.Urgency{ID: id, Status: urgencyV1.Open, IntakeStatus: urgencyV1.IntakeQuarantined}
+			return nil
+		})
+
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, repositories.NewMockNotificationRepository(ctrl), clients.NewMockEmployeeClient(ctrl), nil)
+		assertAppErrorCode(t, svc.AssignUrgency(context.Background(), 1, 2), "URGENCY_ERRORS.INVALID_TRANSITION")
+	})
+
 	t.Run("it succeeds when urgency is unassigned and updates with assigned fields", func(t *testing.T) {
 		log := utils.NewTestLogger()
 		ctrl := gomock.NewController(t)
@@ -882,14 +1102,79 @@ func TestUrgencyService_AssignUrgency(t *testing.T) {
 		nrepo := repositories.NewMockNotificationRepository(ctrl)
 		ecli := clients.NewMockEmployeeClient(ctrl)
 
-		repo.EXPECT().GetByIDPrimary(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, u *model.Urgency) error { *u = model.Urgency{ID: id}; return nil })
-		repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+		repo.EXPECT().GetByIDPrimary(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, u *model.Urgency) error {
+			*u = model.Urgency{ID: id, Status: urgencyV1.Open}
+			return nil
+		})
+		repo.EXPECT().AssignIfUnassigned(gomock.Any(), uint(1), uint(2), gomock.Any(), gomock.Any()).Return(true, nil)
 		ecli.EXPECT().GetEmployeeByID(gomock.Any(), uint(2)).Return(&employeeV1.EmployeeResponse{ID: 2}, nil)
 
-		svc := NewUrgencyService(log, repo, nrepo, ecli)
+		svc := NewUrgencyService(log, repo, nrepo, ecli, nil)
 		err := svc.AssignUrgency(context.Background(), 1, 2)
 		assert.NoError(t, err)
 
+		t.Run("it notifies the assignee when someone else assigned them", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := repositories.NewMockUrgencyRepository(ctrl)
+			nrepo := repositories.NewMockNotificationRepository(ctrl)
+			ecli := clients.NewMockEmployeeClient(ctrl)
+
+			repo.EXPECT().GetByIDPrimary(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, u *model.Urgency) error {
+				*u = model.Urgency{ID: id, Status: urgencyV1.Open, Level: urgencyV1.Critical}
+				return nil
+			})
+			repo.EXPECT().AssignIfUnassigned(gomock.Any(), uint(1), uint(2), gomock.Any(), gomock.Any()).Return(true, nil)
+			ecli.EXPECT().GetEmployeeByID(gomock.Any(), uint(2)).Return(&employeeV1.EmployeeResponse{ID: 2, Phone: "+381641111111", PreferredLanguage: "ru"}, nil)
+			nrepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, n *model.Notification) error {
+				assert.Equal(t, uint(2), n.EmployeeID)
+				assert.Equal(t, model.NotificationSMS, n.NotificationType)
+				assert.Contains(t, n.Message, "Теперь вы руководите вызовом #1")
+				assert.Contains(t, n.Message, "критический")
+				return nil
+			})
+
+			svc := NewUrgencyService(utils.NewTestLogger(), repo, nrepo, ecli, nil)
+			assert.NoError(t, svc.AssignUrgency(withActor(context.Background(), 50), 1, 2))
+		})
+
+		t.Run("it does not notify an employee who assigned themselves", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := repositories.NewMockUrgencyRepository(ctrl)
+			ecli := clients.NewMockEmployeeClient(ctrl)
+
+			repo.EXPECT().GetByIDPrimary(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, u *model.Urgency) error {
+				*u = model.Urgency{ID: id, Status: urgencyV1.Open}
+				return nil
+			})
+			repo.EXPECT().AssignIfUnassigned(gomock.Any(), uint(1), uint(2), gomock.Any(), gomock.Any()).Return(true, nil)
+			ecli.EXPECT().GetEmployeeByID(gomock.Any(), uint(2)).Return(&employeeV1.EmployeeResponse{ID: 2, Phone: "+381641111111"}, nil)
+
+			svc := NewUrgencyService(utils.NewTestLogger(), repo, repositories.NewMockNotificationRepository(ctrl), ecli, nil)
+			assert.NoError(t, svc.AssignUrgency(withActor(context.Background(), 2), 1, 2))
+		})
+
+		t.Run("it refuses an assignment that lost the race against an accept", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := repositories.NewMockUrgencyRepository(ctrl)
+			ecli := clients.NewMockEmployeeClient(ctrl)
+
+			repo.EXPECT().GetByIDPrimary(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, u *model.Urgency) error {
+				*u = model.Urgency{ID: id, Status: urgencyV1.Open}
+				return nil
+			})
+			ecli.EXPECT().GetEmployeeByID(gomock.Any(), uint(2)).Return(&employeeV1.EmployeeResponse{ID: 2}, nil)
+			repo.EXPECT().AssignIfUnassigned(gomock.Any(), uint(1), uint(2), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _, _ uint, _ time.Time, event *model.UrgencyEvent) (bool, error) {
+				assert.Equal(t, model.UrgencyEventAssigned, event.Type)
+				if assert.NotNil(t, event.ActorID) {
+					assert.Equal(t, uint(50), *event.ActorID)
+				}
+				return false, nil
+			})
+
+			svc := NewUrgencyService(utils.NewTestLogger(), repo, repositories.NewMockNotificationRepository(ctrl), ecli, nil)
+			assertAppErrorCode(t, svc.AssignUrgency(withActor(context.Background(), 50), 1, 2), "URGENCY_ERRORS.ALREADY_ASSIGNED")
+		})
+
 		t.Run("it returns error when employee does not exist", func(t *testing.T) {
 			log := utils.NewTestLogger()
 			ctrl := gomock.NewController(t)
@@ -899,10 +1184,13 @@ func TestUrgencyService_AssignUrgency(t *testing.T) {
 			nrepo := repositories.NewMockNotificationRepository(ctrl)
 			ecli := clients.NewMockEmployeeClient(ctrl)
 
-			repo.EXPECT().GetByIDPrimary(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, u *model.Urgency) error { *u = model.Urgency{ID: id}; return nil })
+			repo.EXPECT().GetByIDPrimary(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, u *model.Urgency) error {
+				*u = model.Urgency{ID: id, Status: urgencyV1.Open}
+				return nil
+			})
 			ecli.EXPECT().GetEmployeeByID(gomock.Any(), uint(2)).Return(nil, assert.AnError)
 
-			svc := NewUrgencyService(log, repo, nrepo, ecli)
+			svc := NewUrgencyService(log, repo, nrepo, ecli, nil)
 			err := svc.AssignUrgency(context.Background(), 1, 2)
 			assert.Error(t, err)
 		})
@@ -975,7 +1263,7 @@ func TestUrgencyService_UnassignUrgency(t *testing.T) {
 			*u = model.Urgency{ID: id, AssignedEmployeeID: &emp}
 			return nil
 		})
-		repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(assert.AnError)
+		repo.EXPECT().SaveWithEvent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(false, assert.AnError)
 		err := svc.UnassignUrgency(context.Background(), 1, 55, false)
 		assert.Error(t, err)
 	})
@@ -992,7 +1280,7 @@ func TestUrgencyService_UnassignUrgency(t *testing.T) {
 			*u = model.Urgency{ID: id, AssignedEmployeeID: &emp}
 			return nil
 		})
-		repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+		repo.EXPECT().SaveWithEvent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
 		err := svc.UnassignUrgency(context.Background(), 1, 55, false)
 		assert.NoError(t, err)
 	})
@@ -1009,7 +1297,7 @@ func TestUrgencyService_UnassignUrgency(t *testing.T) {
 			*u = model.Urgency{ID: id, AssignedEmployeeID: &emp}
 			return nil
 		})
-		repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+		repo.EXPECT().SaveWithEvent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
 		err := svc.UnassignUrgency(context.Background(), 1, 99, true)
 		assert.NoError(t, err)
 	})
@@ -1023,7 +1311,7 @@ func TestUrgencyService_GetAssignment(t *testing.T) {
 	repo := repositories.NewMockUrgencyRepository(ctrl)
 	nrepo := repositories.NewMockNotificationRepository(ctrl)
 	ecli := clients.NewMockEmployeeClient(ctrl)
-	svc := NewUrgencyService(log, repo, nrepo, ecli)
+	svc := NewUrgencyService(log, repo, nrepo, ecli, nil)
 
 	t.Run("it returns nil when unassigned", func(t *testing.T) {
 		repo.EXPECT().GetByID(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, u *model.Urgency) error {
@@ -1144,7 +1432,7 @@ func TestUrgencyService_CloseUrgency(t *testing.T) {
 			return nil
 		})
 		ecli.EXPECT().GetEmployeeByID(gomock.Any(), emp).Return(&employeeV1.EmployeeResponse{ID: emp}, nil)
-		repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(assert.AnError)
+		repo.EXPECT().SaveWithEvent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(false, assert.AnError)
 		err := svc.CloseUrgency(context.Background(), 5, emp, true)
 		assert.Error(t, err)
 	})
@@ -1162,7 +1450,12 @@ func TestUrgencyService_CloseUrgency(t *testing.T) {
 			return nil
 		})
 		ecli.EXPECT().GetEmployeeByID(gomock.Any(), emp).Return(&employeeV1.EmployeeResponse{ID: emp}, nil)
-		repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+		repo.EXPECT().SaveWithEvent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u *model.Urgency, _ urgencyV1.UrgencyStatus, _ *model.UrgencyEvent) (bool, error) {
+			assert.Equal(t, urgencyV1.Closed, u.Status)
+			assert.NotNil(t, u.ClosedAt)
+			return true, nil
+		})
+		repo.EXPECT().ListTeam(gomock.Any(), uint(6)).Return(nil, nil)
 		err := svc.CloseUrgency(context.Background(), 6, emp, false)
 		assert.NoError(t, err)
 	})
@@ -1180,8 +1473,659 @@ func TestUrgencyService_CloseUrgency(t *testing.T) {
 			return nil
 		})
 		ecli.EXPECT().GetEmployeeByID(gomock.Any(), emp).Return(&employeeV1.EmployeeResponse{ID: emp}, nil)
-		repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+		repo.EXPECT().SaveWithEvent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+		repo.EXPECT().ListTeam(gomock.Any(), uint(7)).Return(nil, nil)
 		err := svc.CloseUrgency(context.Background(), 7, 999, true)
 		assert.NoError(t, err)
 	})
+
+	t.Run("it notifies the lead and the rest of the team except the employee who closed it", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		nrepo := repositories.NewMockNotificationRepository(ctrl)
+		ecli := clients.NewMockEmployeeClient(ctrl)
+		svc := &urgencyService{log: utils.NewTestLogger(), repo: repo, notificationRepo: nrepo, employeeClient: ecli}
+		lead := uint(9)
+		repo.EXPECT().GetByIDPrimary(gomock.Any(), uint(8), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, u *model.Urgency) error {
+			*u = model.Urgency{ID: id, AssignedEmployeeID: &lead, Status: urgencyV1.InProgress, Location: "Midzor"}
+			return nil
+		})
+		ecli.EXPECT().GetEmployeeByID(gomock.Any(), lead).Return(&employeeV1.EmployeeResponse{ID: lead, Phone: "+381641111111"}, nil)
+		repo.EXPECT().SaveWithEvent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+		repo.EXPECT().ListTeam(gomock.Any(), uint(8)).Return([]model.UrgencyAssignment{
+			{EmployeeID: lead, Role: urgencyV1.RoleLead},
+			{EmployeeID: 10, Role: urgencyV1.RoleMedic},
+			{EmployeeID: 999, Role: urgencyV1.RoleSupport},
+		}, nil)
+		ecli.EXPECT().GetEmployeeByID(gomock.Any(), uint(10)).Return(&employeeV1.EmployeeResponse{ID: 10, Email: "medic@example.com", PreferredLanguage: "sr-lat"}, nil)
+
+		var sent []model.Notification
+		nrepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, n *model.Notification) error {
+			sent = append(sent, *n)
+			return nil
+		}).Times(2)
+
+		require.NoError(t, svc.CloseUrgency(context.Background(), 8, 999, true))
+		require.Len(t, sent, 2)
+		assert.Equal(t, lead, sent[0].EmployeeID)
+		assert.Contains(t, sent[0].Message, "has been closed")
+		assert.Equal(t, uint(10), sent[1].EmployeeID)
+		assert.Equal(t, "Hitan slučaj #8 Gorske službe je zatvoren", sent[1].Subject)
+		assert.Contains(t, sent[1].Message, "je zatvoren")
+	})
+}
+
+func TestUrgencyService_AcceptUrgency(t *testing.T) {
+	t.Parallel()
+
+	notified := []model.Notification{{UrgencyID: 1, EmployeeID: 2}, {UrgencyID: 1, EmployeeID: 3}}
+
+	t.Run("it returns error on invalid parameters", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		svc := NewUrgencyService(utils.NewTestLogger(), repositories.NewMockUrgencyRepository(ctrl), repositories.NewMockNotificationRepository(ctrl), clients.NewMockEmployeeClient(ctrl), nil)
+		assert.Error(t, svc.AcceptUrgency(context.Background(), 0, 2))
+		assert.Error(t, svc.AcceptUrgency(context.Background(), 1, 0))
+	})
+
+	t.Run("it rejects employees that were not notified", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		nrepo := repositories.NewMockNotificationRepository(ctrl)
+		nrepo.EXPECT().GetByUrgencyID(gomock.Any(), uint(1)).Return(notified, nil)
+
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, nrepo, clients.NewMockEmployeeClient(ctrl), nil)
+		err := svc.AcceptUrgency(context.Background(), 1, 9)
+		assertAppErrorCode(t, err, "URGENCY_ERRORS.NOT_NOTIFIED")
+	})
+
+	t.Run("it assigns the urgency and records the acceptance", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		nrepo := repositories.NewMockNotificationRepository(ctrl)
+		nrepo.EXPECT().GetByUrgencyID(gomock.Any(), uint(1)).Return(notified, nil)
+		repo.EXPECT().GetByIDPrimary(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, u *model.Urgency) error {
+			*u = model.Urgency{ID: id, Status: urgencyV1.Open}
+			return nil
+		})
+		repo.EXPECT().AssignIfUnassigned(gomock.Any(), uint(1), uint(2), gomock.Any(), gomock.Any()).Return(true, nil)
+		nrepo.EXPECT().RecordReply(gomock.Any(), uint(1), uint(2), model.NotificationAccepted, "", gomock.Any()).Return(int64(2), nil)
+
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, nrepo, clients.NewMockEmployeeClient(ctrl), nil)
+		assert.NoError(t, svc.AcceptUrgency(context.Background(), 1, 2))
+	})
+
+	t.Run("it refuses urgencies held back by the intake checks", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		nrepo := repositories.NewMockNotificationRepository(ctrl)
+		nrepo.EXPECT().GetByUrgencyID(gomock.Any(), uint(1)).Return(notified, nil)
+		repo.EXPECT().GetByIDPrimary(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, u *model.Urgency) error {
+			*u = model.Urgency{ID: id, Status: urgencyV1.Open, IntakeStatus: urgencyV1.IntakePendingVerification}
+			return nil
+		})
+
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, nrepo, clients.NewMockEmployeeClient(ctrl), nil)
+		assertAppErrorCode(t, svc.AcceptUrgency(context.Background(), 1, 2), "URGENCY_ERRORS.INVALID_TRANSITION")
+	})
+
+	t.Run("it returns ALREADY_ASSIGNED when another employee wins the race", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		nrepo := repositories.NewMockNotificationRepository(ctrl)
+		nrepo.EXPECT().GetByUrgencyID(gomock.Any(), uint(1)).Return(notified, nil)
+		repo.EXPECT().GetByIDPrimary(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, u *model.Urgency) error {
+			*u = model.Urgency{ID: id, Status: urgencyV1.Open}
+			return nil
+		})
+		repo.EXPECT().AssignIfUnassigned(gomock.Any(), uint(1), uint(2), gomock.Any(), gomock.Any()).Return(false, nil)
+
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, nrepo, clients.NewMockEmployeeClient(ctrl), nil)
+		assertAppErrorCode(t, svc.AcceptUrgency(context.Background(), 1, 2), "URGENCY_ERRORS.ALREADY_ASSIGNED")
+	})
+
+	t.Run("it returns ALREADY_ASSIGNED when urgency is held by someone else", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		nrepo := repositories.NewMockNotificationRepository(ctrl)
+		nrepo.EXPECT().GetByUrgencyID(gomock.Any(), uint(1)).Return(notified, nil)
+		repo.EXPECT().GetByIDPrimary(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, u *model.Urgency) error {
+			other := uint(3)
+			*u = model.Urgency{ID: id, AssignedEmployeeID: &other}
+			return nil
+		})
+
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, nrepo, clients.NewMockEmployeeClient(ctrl), nil)
+		assertAppErrorCode(t, svc.AcceptUrgency(context.Background(), 1, 2), "URGENCY_ERRORS.ALREADY_ASSIGNED")
+	})
+
+	t.Run("it is idempotent for the current assignee", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		nrepo := repositories.NewMockNotificationRepository(ctrl)
+		nrepo.EXPECT().GetByUrgencyID(gomock.Any(), uint(1)).Return(notified, nil)
+		repo.EXPECT().GetByIDPrimary(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, u *model.Urgency) error {
+			me := uint(2)
+			*u = model.Urgency{ID: id, AssignedEmployeeID: &me}
+			return nil
+		})
+
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, nrepo, clients.NewMockEmployeeClient(ctrl), nil)
+		assert.NoError(t, svc.AcceptUrgency(context.Background(), 1, 2))
+	})
+}
+
+func TestUrgencyService_DeclineUrgency(t *testing.T) {
+	t.Parallel()
+
+	notified := []model.Notification{{UrgencyID: 1, EmployeeID: 2}}
+
+	t.Run("it records the decline with a trimmed reason", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		nrepo := repositories.NewMockNotificationRepository(ctrl)
+		nrepo.EXPECT().GetByUrgencyID(gomock.Any(), uint(1)).Return(notified, nil)
+		repo.EXPECT().GetByIDPrimary(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, u *model.Urgency) error {
+			*u = model.Urgency{ID: id, Status: urgencyV1.Open}
+			return nil
+		})
+		nrepo.EXPECT().RecordReply(gomock.Any(), uint(1), uint(2), model.NotificationDeclined, "too far away", gomock.Any()).Return(int64(1), nil)
+
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, nrepo, clients.NewMockEmployeeClient(ctrl), nil)
+		assert.NoError(t, svc.DeclineUrgency(context.Background(), 1, 2, "  too far away "))
+	})
+
+	t.Run("it rejects declines from the current assignee", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		nrepo := repositories.NewMockNotificationRepository(ctrl)
+		nrepo.EXPECT().GetByUrgencyID(gomock.Any(), uint(1)).Return(notified, nil)
+		repo.EXPECT().GetByIDPrimary(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, u *model.Urgency) error {
+			me := uint(2)
+			*u = model.Urgency{ID: id, AssignedEmployeeID: &me}
+			return nil
+		})
+
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, nrepo, clients.NewMockEmployeeClient(ctrl), nil)
+		assertAppErrorCode(t, svc.DeclineUrgency(context.Background(), 1, 2, ""), "URGENCY_ERRORS.INVALID_STATE")
+	})
+
+	t.Run("it returns error when recording fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		nrepo := repositories.NewMockNotificationRepository(ctrl)
+		nrepo.EXPECT().GetByUrgencyID(gomock.Any(), uint(1)).Return(notified, nil)
+		repo.EXPECT().GetByIDPrimary(gomock.Any(), uint(1), gomock.Any()).Return(nil)
+		nrepo.EXPECT().RecordReply(gomock.Any(), uint(1), uint(2), model.NotificationDeclined, "", gomock.Any()).Return(int64(0), assert.AnError)
+
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, nrepo, clients.NewMockEmployeeClient(ctrl), nil)
+		assertAppErrorCode(t, svc.DeclineUrgency(context.Background(), 1, 2, ""), "URGENCY_ERRORS.UPDATE_FAILED")
+	})
+}
+
+func TestUrgencyService_ListReplies(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it returns one reply per notified employee", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		nrepo := repositories.NewMockNotificationRepository(ctrl)
+		at := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+		nrepo.EXPECT().GetByUrgencyID(gomock.Any(), uint(1)).Return([]model.Notification{
+			{EmployeeID: 2, NotificationType: model.NotificationSMS, Reply: model.NotificationDeclined, DeclineReason: "sick", RepliedAt: &at},
+			{EmployeeID: 2, NotificationType: model.NotificationEmail, Reply: model.NotificationDeclined, DeclineReason: "sick", RepliedAt: &at},
+			{EmployeeID: 3, NotificationType: model.NotificationSMS},
+		}, nil)
+
+		svc := NewUrgencyService(utils.NewTestLogger(), repositories.NewMockUrgencyRepository(ctrl), nrepo, clients.NewMockEmployeeClient(ctrl), nil)
+		replies, err := svc.ListReplies(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []urgencyV1.UrgencyReplyResponse{
+			{EmployeeID: 2, Reply: "declined", DeclineReason: "sick", RepliedAt: "2025-01-01T10:00:00Z"},
+			{EmployeeID: 3},
+		}, replies)
+	})
+}
+
+func TestUrgencyService_StatusTransitions(t *testing.T) {
+	t.Parallel()
+	assignee := uint(5)
+
+	setup := func(t *testing.T, status urgencyV1.UrgencyStatus, assigned *uint) (*repositories.MockUrgencyRepository, UrgencyService) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		repo.EXPECT().GetByIDPrimary(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, u *model.Urgency) error {
+			*u = model.Urgency{ID: id, Status: status, AssignedEmployeeID: assigned}
+			return nil
+		})
+		return repo, NewUrgencyService(utils.NewTestLogger(), repo, nil, nil, nil)
+	}
+
+	t.Run("it resolves an in progress urgency with a note", func(t *testing.T) {
+		repo, svc := setup(t, urgencyV1.InProgress, &assignee)
+		repo.EXPECT().SaveWithEvent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u *model.Urgency, _ urgencyV1.UrgencyStatus, ev *model.UrgencyEvent) (bool, error) {
+			assert.Equal(t, urgencyV1.Resolved, u.Status)
+			assert.Equal(t, "handed over", u.ResolutionNote)
+			assert.NotNil(t, u.ResolvedAt)
+			assert.Equal(t, model.UrgencyEventResolved, ev.Type)
+			assert.Equal(t, "handed over", ev.Reason)
+			return true, nil
+		})
+		assert.NoError(t, svc.ResolveUrgency(context.Background(), 1, assignee, false, "handed over"))
+	})
+
+	t.Run("it reports a transition that another request beat", func(t *testing.T) {
+		repo, svc := setup(t, urgencyV1.InProgress, &assignee)
+		repo.EXPECT().SaveWithEvent(gomock.Any(), gomock.Any(), urgencyV1.InProgress, gomock.Any()).Return(false, nil)
+		assertAppErrorCode(t, svc.ResolveUrgency(context.Background(), 1, assignee, false, "n"), "URGENCY_ERRORS.INVALID_TRANSITION")
+	})
+
+	t.Run("it rejects resolving an open urgency", func(t *testing.T) {
+		_, svc := setup(t, urgencyV1.Open, &assignee)
+		assertAppErrorCode(t, svc.ResolveUrgency(context.Background(), 1, assignee, false, "n"), "URGENCY_ERRORS.INVALID_TRANSITION")
+	})
+
+	t.Run("it forbids resolving by someone other than the assignee", func(t *testing.T) {
+		_, svc := setup(t, urgencyV1.InProgress, &assignee)
+		assertAppErrorCode(t, svc.ResolveUrgency(context.Background(), 1, 99, false, "n"), "AUTH_ERRORS.FORBIDDEN")
+	})
+
+	t.Run("it reopens a closed urgency and clears assignment", func(t *testing.T) {
+		repo, svc := setup(t, urgencyV1.Closed, &assignee)
+		repo.EXPECT().SaveWithEvent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u *model.Urgency, _ urgencyV1.UrgencyStatus, ev *model.UrgencyEvent) (bool, error) {
+			assert.Equal(t, urgencyV1.Open, u.Status)
+			assert.Nil(t, u.AssignedEmployeeID)
+			assert.Equal(t, model.EscalationNone, u.EscalationLevel)
+			assert.Equal(t, 1, u.SortPriority)
+			assert.Equal(t, "patient still missing", ev.Reason)
+			return true, nil
+		})
+		assert.NoError(t, svc.ReopenUrgency(context.Background(), 1, 7, true, "patient still missing"))
+	})
+
+	t.Run("it rejects reopening an urgency that is in progress", func(t *testing.T) {
+		_, svc := setup(t, urgencyV1.InProgress, &assignee)
+		assertAppErrorCode(t, svc.ReopenUrgency(context.Background(), 1, 7, true, "r"), "URGENCY_ERRORS.INVALID_TRANSITION")
+	})
+
+	t.Run("it cancels an open urgency as admin", func(t *testing.T) {
+		repo, svc := setup(t, urgencyV1.Open, nil)
+		repo.EXPECT().SaveWithEvent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u *model.Urgency, _ urgencyV1.UrgencyStatus, ev *model.UrgencyEvent) (bool, error) {
+			assert.Equal(t, urgencyV1.Cancelled, u.Status)
+			assert.Equal(t, model.UrgencyEventCancelled, ev.Type)
+			return true, nil
+		})
+		assert.NoError(t, svc.CancelUrgency(context.Background(), 1, 7, true, "false alarm"))
+	})
+
+	t.Run("it rejects cancelling a closed urgency", func(t *testing.T) {
+		_, svc := setup(t, urgencyV1.Closed, &assignee)
+		assertAppErrorCode(t, svc.CancelUrgency(context.Background(), 1, assignee, false, "r"), "URGENCY_ERRORS.INVALID_TRANSITION")
+	})
+
+	t.Run("it wraps persistence failures", func(t *testing.T) {
+		repo, svc := setup(t, urgencyV1.Open, nil)
+		repo.EXPECT().SaveWithEvent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(false, assert.AnError)
+		assertAppErrorCode(t, svc.CancelUrgency(context.Background(), 1, 7, true, "r"), "URGENCY_ERRORS.UPDATE_FAILED")
+	})
+}
+
+func TestUrgencyService_GetTimeline(t *testing.T) {
+	t.Parallel()
+	created := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	actor, assignee := uint(2), uint(3)
+
+	setup := func(t *testing.T) (*repositories.MockUrgencyRepository, *clients.MockActivityClient, UrgencyService) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		acli := clients.NewMockActivityClient(ctrl)
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, repositories.NewMockNotificationRepository(ctrl), clients.NewMockEmployeeClient(ctrl), acli)
+		repo.EXPECT().GetByID(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(func(_ context.Context, _ uint, u *model.Urgency) error {
+			u.ID = 1
+			u.CreatedAt = created
+			return nil
+		})
+		repo.EXPECT().ListEvents(gomock.Any(), uint(1)).Return([]model.UrgencyEvent{
+			{Type: model.UrgencyEventAssigned, ActorID: &actor, OldStatus: urgencyV1.Open, NewStatus: urgencyV1.InProgress, NewAssigneeID: &assignee, CreatedAt: created.Add(10 * time.Minute)},
+		}, nil)
+		repo.EXPECT().ListEscalations(gomock.Any(), uint(1)).Return([]model.UrgencyEscalation{
+			{Step: model.EscalationRenotify, Action: "renotify_on_call", NotifiedCount: 2, CreatedAt: created.Add(5 * time.Minute)},
+		}, nil)
+		repo.EXPECT().ListFollowUps(gomock.Any(), uint(1)).Return([]model.ReporterFollowUp{
+			{UrgencyID: 1, Location: "N 43.401123 E 22.662756", Description: "we moved below the ridge", CreatedAt: created.Add(15 * time.Minute)},
+		}, nil)
+		return repo, acli, svc
+	}
+
+	t.Run("it merges events, escalations, follow-ups and activities chronologically", func(t *testing.T) {
+		_, acli, svc := setup(t)
+		acli.EXPECT().GetActivitiesByUrgency(gomock.Any(), uint(1)).Return([]activityV1.ActivityResponse{
+			{ID: 1, Description: "reached the location", EmployeeID: 3, UrgencyID: 1, CreatedAt: created.Add(20 * time.Minute).Format(time.RFC3339)},
+			{ID: 2, Description: "on the way", EmployeeID: 3, UrgencyID: 1, CreatedAt: created.Add(12 * time.Minute).Format(time.RFC3339)},
+		}, nil)
+
+		timeline, err := svc.GetTimeline(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.False(t, timeline.Partial)
+		var types []string
+		for _, e := range timeline.Entries {
+			types = append(types, e.Type)
+		}
+		assert.Equal(t, []string{"created", "escalated", "assigned", "activity", "follow_up", "activity"}, types)
+		assert.Equal(t, "on the way", timeline.Entries[3].Description)
+		assert.Equal(t, "reporter", timeline.Entries[4].Source)
+		assert.Equal(t, "we moved below the ridge (location: N 43.401123 E 22.662756)", timeline.Entries[4].Description)
+		assert.Equal(t, "2025-01-01T10:10:00Z", timeline.Entries[2].OccurredAt)
+		assert.Equal(t, &actor, timeline.Entries[2].ActorID)
+	})
+
+	t.Run("it returns a partial timeline when the activity service fails", func(t *testing.T) {
+		_, acli, svc := setup(t)
+		acli.EXPECT().GetActivitiesByUrgency(gomock.Any(), uint(1)).Return(nil, assert.AnError)
+
+		timeline, err := svc.GetTimeline(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.True(t, timeline.Partial)
+		assert.Len(t, timeline.Entries, 4)
+	})
+
+	t.Run("it returns not found for unknown urgency", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		repo.EXPECT().GetByID(gomock.Any(), uint(9), gomock.Any()).Return(gorm.ErrRecordNotFound)
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, nil, nil, nil)
+
+		_, err := svc.GetTimeline(context.Background(), 9)
+		assertAppErrorCode(t, err, "URGENCY_ERRORS.NOT_FOUND")
+	})
+}
+
+func TestUrgencyService_ListUrgenciesInArea(t *testing.T) {
+	t.Parallel()
+	f := func(v float64) *float64 { return &v }
+
+	t.Run("it filters a radius query by distance and sorts by it", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		repo.EXPECT().ListWithinBounds(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), maxAreaUrgencies+1).Return([]model.Urgency{
+			{ID: 1, Latitude: f(43.50), Longitude: f(22.66)}, // ~11 km north
+			{ID: 2, Latitude: f(43.41), Longitude: f(22.66)}, // ~1 km north
+			{ID: 3, Latitude: f(43.49), Longitude: f(22.77)}, // box corner, outside the circle
+		}, nil)
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, nil, nil, nil)
+
+		result, err := svc.ListUrgenciesInArea(context.Background(), urgencyV1.UrgencyGeoQuery{Lat: f(43.40), Lng: f(22.66), RadiusKm: f(12)}, false)
+		assert.NoError(t, err)
+		assert.False(t, result.Truncated)
+		if assert.Len(t, result.Urgencies, 2) {
+			assert.Equal(t, uint(2), result.Urgencies[0].ID)
+			assert.Equal(t, uint(1), result.Urgencies[1].ID)
+			assert.InDelta(t, 1.1, *result.Urgencies[0].DistanceKm, 0.1)
+		}
+	})
+
+	t.Run("it passes a bounding box query straight through", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		repo.EXPECT().ListWithinBounds(gomock.Any(), 43.0, 44.0, 22.0, 23.0, maxAreaUrgencies+1).Return([]model.Urgency{{ID: 1, Latitude: f(43.5), Longitude: f(22.5)}}, nil)
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, nil, nil, nil)
+
+		result, err := svc.ListUrgenciesInArea(context.Background(), urgencyV1.UrgencyGeoQuery{MinLat: f(43), MinLng: f(22), MaxLat: f(44), MaxLng: f(23)}, false)
+		assert.NoError(t, err)
+		if assert.Len(t, result.Urgencies, 1) {
+			assert.Nil(t, result.Urgencies[0].DistanceKm)
+		}
+	})
+
+	t.Run("it shows reports held back by the intake checks only to admins", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		repo.EXPECT().ListWithinBounds(gomock.Any(), 43.0, 44.0, 22.0, 23.0, maxAreaUrgencies+1).Return([]model.Urgency{
+			{ID: 1, Latitude: f(43.5), Longitude: f(22.5), IntakeStatus: urgencyV1.IntakeAccepted},
+			{ID: 2, Latitude: f(43.5), Longitude: f(22.5), IntakeStatus: urgencyV1.IntakeQuarantined},
+		}, nil).Times(2)
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, nil, nil, nil)
+		box := urgencyV1.UrgencyGeoQuery{MinLat: f(43), MinLng: f(22), MaxLat: f(44), MaxLng: f(23)}
+
+		result, err := svc.ListUrgenciesInArea(context.Background(), box, false)
+		require.NoError(t, err)
+		if assert.Len(t, result.Urgencies, 1) {
+			assert.Equal(t, uint(1), result.Urgencies[0].ID)
+		}
+		result, err = svc.ListUrgenciesInArea(context.Background(), box, true)
+		require.NoError(t, err)
+		assert.Len(t, result.Urgencies, 2)
+	})
+
+	t.Run("it caps the list and reports that it was truncated", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		found := make([]model.Urgency, maxAreaUrgencies+1)
+		for i := range found {
+			found[i] = model.Urgency{ID: uint(i + 1), Latitude: f(43.5), Longitude: f(22.5), IntakeStatus: urgencyV1.IntakeAccepted}
+		}
+		repo.EXPECT().ListWithinBounds(gomock.Any(), 43.0, 44.0, 22.0, 23.0, maxAreaUrgencies+1).Return(found, nil)
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, nil, nil, nil)
+
+		result, err := svc.ListUrgenciesInArea(context.Background(), urgencyV1.UrgencyGeoQuery{MinLat: f(43), MinLng: f(22), MaxLat: f(44), MaxLng: f(23)}, false)
+		require.NoError(t, err)
+		assert.True(t, result.Truncated)
+		assert.Len(t, result.Urgencies, maxAreaUrgencies)
+	})
+
+	t.Run("it returns an error when the repository fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		repo.EXPECT().ListWithinBounds(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), maxAreaUrgencies+1).Return(nil, assert.AnError)
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, nil, nil, nil)
+
+		_, err := svc.ListUrgenciesInArea(context.Background(), urgencyV1.UrgencyGeoQuery{MinLat: f(43), MinLng: f(22), MaxLat: f(44), MaxLng: f(23)}, false)
+		assertAppErrorCode(t, err, "URGENCY_ERRORS.LIST_FAILED")
+	})
+}
+
+func TestUrgencyService_SuggestResponders(t *testing.T) {
+	t.Parallel()
+	f := func(v float64) *float64 { return &v }
+	onCall := []employeeV1.EmployeeResponse{
+		{ID: 1, FirstName: "Far", LastLatitude: f(44.0), LastLongitude: f(22.66)},
+		{ID: 2, FirstName: "Unknown"},
+		{ID: 3, FirstName: "Near", LastLatitude: f(43.41), LastLongitude: f(22.66), LastPositionAt: "2025-01-01T10:00:00Z"},
+	}
+
+	t.Run("it ranks on-call employees by distance with unknown positions last", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		emp := clients.NewMockEmployeeClient(ctrl)
+		repo.EXPECT().GetByID(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(func(_ context.Context, _ uint, u *model.Urgency) error {
+			u.ID, u.Latitude, u.Longitude = 1, f(43.40), f(22.66)
+			return nil
+		})
+		emp.EXPECT().GetOnCallEmployees(gomock.Any(), defaultShiftBuffer).Return(onCall, nil)
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, nil, emp, nil)
+
+		responders, err := svc.SuggestResponders(context.Background(), 1)
+		assert.NoError(t, err)
+		if assert.Len(t, responders, 3) {
+			assert.Equal(t, []uint{3, 1, 2}, []uint{responders[0].EmployeeID, responders[1].EmployeeID, responders[2].EmployeeID})
+			assert.Equal(t, "2025-01-01T10:00:00Z", responders[0].LastPositionAt)
+			assert.Nil(t, responders[2].DistanceKm)
+		}
+	})
+
+	t.Run("it keeps on-call order when the urgency has no coordinates", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		emp := clients.NewMockEmployeeClient(ctrl)
+		repo.EXPECT().GetByID(gomock.Any(), uint(1), gomock.Any()).Return(nil)
+		emp.EXPECT().GetOnCallEmployees(gomock.Any(), defaultShiftBuffer).Return(onCall, nil)
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, nil, emp, nil)
+
+		responders, err := svc.SuggestResponders(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{1, 2, 3}, []uint{responders[0].EmployeeID, responders[1].EmployeeID, responders[2].EmployeeID})
+	})
+
+	t.Run("it returns an error when on-call employees cannot be fetched", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		emp := clients.NewMockEmployeeClient(ctrl)
+		repo.EXPECT().GetByID(gomock.Any(), uint(1), gomock.Any()).Return(nil)
+		emp.EXPECT().GetOnCallEmployees(gomock.Any(), defaultShiftBuffer).Return(nil, assert.AnError)
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, nil, emp, nil)
+
+		_, err := svc.SuggestResponders(context.Background(), 1)
+		assertAppErrorCode(t, err, "URGENCY_ERRORS.ON_CALL_FETCH_FAILED")
+	})
+}
+
+func TestUrgencyService_actorAttribution(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it records the request actor on update events", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		repo.EXPECT().GetByIDPrimary(gomock.Any(), uint(1), gomock.Any()).Return(nil)
+		repo.EXPECT().SaveWithEvent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ *model.Urgency, _ urgencyV1.UrgencyStatus, ev *model.UrgencyEvent) (bool, error) {
+			assert.Equal(t, model.UrgencyEventUpdated, ev.Type)
+			require.NotNil(t, ev.ActorID)
+			assert.Equal(t, uint(11), *ev.ActorID)
+			return true, nil
+		})
+		svc := &urgencyService{log: utils.NewTestLogger(), repo: repo}
+
+		_, err := svc.UpdateUrgency(withActor(context.Background(), 11), 1, &urgencyV1.UrgencyUpdateRequest{})
+		assert.NoError(t, err)
+	})
+}
+
+func assertAppErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+	var appErr *commonv1.AppError
+	if assert.ErrorAs(t, err, &appErr) {
+		assert.Equal(t, code, appErr.Code)
+	}
+}
+
+func TestUrgencyService_CreateUrgency_Duplicate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it links a repeated report to the original and skips the fan-out", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		emp := clients.NewMockEmployeeClient(ctrl)
+		original := model.Urgency{ID: 7, ContactPhone: "+381641234567", Model: gorm.Model{CreatedAt: time.Now().UTC().Add(-5 * time.Minute)}}
+		repo.EXPECT().ListRecentActive(gomock.Any(), gomock.Any()).Return([]model.Urgency{original}, nil)
+		repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u *model.Urgency) error {
+			require.NotNil(t, u.DuplicateOfID)
+			assert.Equal(t, uint(7), *u.DuplicateOfID)
+			u.ID = 9
+			return nil
+		})
+		repo.EXPECT().CreateEvent(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, ev *model.UrgencyEvent) error {
+			assert.Equal(t, uint(7), ev.UrgencyID)
+			assert.Equal(t, model.UrgencyEventDuplicateReported, ev.Type)
+			assert.Equal(t, "report #9", ev.Reason)
+			return nil
+		})
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, repositories.NewMockNotificationRepository(ctrl), emp, nil)
+
+		assert.NoError(t, svc.CreateUrgency(context.Background(), &model.Urgency{ContactPhone: "+381 64 1234567"}))
+	})
+
+	t.Run("it creates a regular urgency when the duplicate lookup fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		emp := clients.NewMockEmployeeClient(ctrl)
+		repo.EXPECT().ListRecentActive(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+		repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u *model.Urgency) error {
+			assert.Nil(t, u.DuplicateOfID)
+			return nil
+		})
+		emp.EXPECT().GetOnCallEmployees(gomock.Any(), gomock.Any()).Return([]employeeV1.EmployeeResponse{}, nil)
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, repositories.NewMockNotificationRepository(ctrl), emp, nil)
+
+		assert.NoError(t, svc.CreateUrgency(context.Background(), &model.Urgency{ContactPhone: "123"}))
+	})
+}
+
+func TestUrgencyService_MergeUrgency(t *testing.T) {
+	t.Parallel()
+
+	expectLoad := func(repo *repositories.MockUrgencyRepository, stored model.Urgency) {
+		repo.EXPECT().GetByIDPrimary(gomock.Any(), stored.ID, gomock.Any()).DoAndReturn(func(_ context.Context, _ uint, u *model.Urgency) error {
+			*u = stored
+			return nil
+		})
+	}
+
+	t.Run("it cancels the duplicate and moves notifications and activities", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		acli := clients.NewMockActivityClient(ctrl)
+		expectLoad(repo, model.Urgency{ID: 1, Status: urgencyV1.InProgress})
+		expectLoad(repo, model.Urgency{ID: 2, Status: urgencyV1.Open})
+		repo.EXPECT().MergeInto(gomock.Any(), gomock.Any(), uint(1), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, source *model.Urgency, _ uint, sourceEvent, targetEvent *model.UrgencyEvent) (int64, error) {
+				assert.Equal(t, urgencyV1.Cancelled, source.Status)
+				require.NotNil(t, source.DuplicateOfID)
+				assert.Equal(t, uint(1), *source.DuplicateOfID)
+				assert.Equal(t, "merged into #1", source.ResolutionNote)
+				assert.Equal(t, model.UrgencyEventMerged, sourceEvent.Type)
+				assert.Equal(t, "merged #2", targetEvent.Reason)
+				return 3, nil
+			})
+		acli.EXPECT().ReassignActivities(gomock.Any(), uint(2), uint(1)).Return(4, nil)
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, nil, nil, acli)
+
+		resp, err := svc.MergeUrgency(context.Background(), 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, urgencyV1.UrgencyMergeResponse{UrgencyID: 1, MergedID: 2, NotificationsMoved: 3, ActivitiesMoved: 4}, *resp)
+	})
+
+	t.Run("it reports a partial merge when activities cannot be moved", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		acli := clients.NewMockActivityClient(ctrl)
+		expectLoad(repo, model.Urgency{ID: 1, Status: urgencyV1.Open})
+		expectLoad(repo, model.Urgency{ID: 2, Status: urgencyV1.Open})
+		repo.EXPECT().MergeInto(gomock.Any(), gomock.Any(), uint(1), gomock.Any(), gomock.Any()).Return(int64(0), nil)
+		acli.EXPECT().ReassignActivities(gomock.Any(), uint(2), uint(1)).Return(0, assert.AnError)
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, nil, nil, acli)
+
+		resp, err := svc.MergeUrgency(context.Background(), 1, 2)
+		require.NoError(t, err)
+		assert.True(t, resp.Partial)
+	})
+
+	t.Run("it only retries moving activities for an already merged pair", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		acli := clients.NewMockActivityClient(ctrl)
+		target := uint(1)
+		expectLoad(repo, model.Urgency{ID: 1, Status: urgencyV1.Open})
+		expectLoad(repo, model.Urgency{ID: 2, Status: urgencyV1.Cancelled, DuplicateOfID: &target})
+		acli.EXPECT().ReassignActivities(gomock.Any(), uint(2), uint(1)).Return(2, nil)
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, nil, nil, acli)
+
+		resp, err := svc.MergeUrgency(context.Background(), 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, 2, resp.ActivitiesMoved)
+		assert.False(t, resp.Partial)
+	})
+
+	t.Run("it rejects merging an urgency into itself", func(t *testing.T) {
+		svc := NewUrgencyService(utils.NewTestLogger(), nil, nil, nil, nil)
+		_, err := svc.MergeUrgency(context.Background(), 1, 1)
+		assertAppErrorCode(t, err, "URGENCY_ERRORS.INVALID_MERGE")
+	})
+
+	t.Run("it rejects merging a resolved urgency", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		expectLoad(repo, model.Urgency{ID: 1, Status: urgencyV1.Open})
+		expectLoad(repo, model.Urgency{ID: 2, Status: urgencyV1.Resolved})
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, nil, nil, nil)
+
+		_, err := svc.MergeUrgency(context.Background(), 1, 2)
+		assertAppErrorCode(t, err, "URGENCY_ERRORS.INVALID_TRANSITION")
+	})
 }
@@ -0,0 +1,198 @@
+package internal
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
+)
+
+// maxListedSLABreaches caps the breached urgencies returned with the stats; the breach count is not capped
+const maxListedSLABreaches = 200
+
+// SLAPolicy defines how soon after creation an urgency of each level must be assigned to a responder.
+type SLAPolicy struct {
+	Targets map[urgencyV1.UrgencyLevel]time.Duration
+}
+
+// DefaultSLAPolicy returns the SLA targets used when no overrides are configured.
+func DefaultSLAPolicy() SLAPolicy {
+	return SLAPolicy{
+		Targets: map[urgencyV1.UrgencyLevel]time.Duration{
+			urgencyV1.Critical: 10 * time.Minute,
+			urgencyV1.High:     30 * time.Minute,
+			urgencyV1.Medium:   60 * time.Minute,
+			urgencyV1.Low:      240 * time.Minute,
+		},
+	}
+}
+
+func (p SLAPolicy) targetFor(level urgencyV1.UrgencyLevel) (time.Duration, bool) {
+	d, ok := p.Targets[level]
+	return d, ok && d > 0
+}
+
+// GetStats returns response-time and SLA statistics for urgencies created within the query range.
+func (s *urgencyService) GetStats(ctx context.Context, query urgencyV1.UrgencyStatsQuery) (*urgencyV1.UrgencyStatsResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.GetStats")()
+
+	now := time.Now().UTC()
+	from, to := query.Range(now)
+	urgencies, err := s.repo.ListForStats(ctx, from, to)
+	if err != nil {
+		log.Errorf("Failed to load urgencies for stats: %v", err)
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.LIST_FAILED", "failed to load urgency statistics", map[string]interface{}{"cause": err.Error()})
+	}
+	return computeUrgencyStats(urgencies, s.sla, from, to, now), nil
+}
+
+// computeUrgencyStats aggregates urgencies into status and level counts, time-to-assign and time-to-close
+// distributions, per-employee handled counts and time-to-assign SLA breaches. Time-to-close runs until the
+// urgency was resolved, or closed when it was never resolved. Urgencies still waiting for a responder
+// breach their SLA once the target has passed; cancelled or closed urgencies nobody took are not counted.
+func computeUrgencyStats(urgencies []model.Urgency, policy SLAPolicy, from, to, now time.Time) *urgencyV1.UrgencyStatsResponse {
+	resp := &urgencyV1.UrgencyStatsResponse{
+		From:      from.Format(time.RFC3339),
+		To:        to.Format(time.RFC3339),
+		Total:     len(urgencies),
+		ByStatus:  make(map[urgencyV1.UrgencyStatus]int),
+		ByLevel:   make(map[urgencyV1.UrgencyLevel]int),
+		Employees: []urgencyV1.EmployeeHandledStats{},
+		SLA:       urgencyV1.SLAStats{Levels: []urgencyV1.SLALevelStats{}, Breached: []urgencyV1.SLABreach{}},
+	}
+
+	var toAssign, toClose []time.Duration
+	employees := make(map[uint]*urgencyV1.EmployeeHandledStats)
+	slaOrder := []urgencyV1.UrgencyLevel{urgencyV1.Critical, urgencyV1.High, urgencyV1.Medium, urgencyV1.Low}
+	slaLevels := make(map[urgencyV1.UrgencyLevel]*urgencyV1.SLALevelStats)
+	for _, level := range slaOrder {
+		if target, ok := policy.targetFor(level); ok {
+			slaLevels[level] = &urgencyV1.SLALevelStats{Level: level, TargetSeconds: int64(target.Seconds())}
+		}
+	}
+
+	for i := range urgencies {
+		u := &urgencies[i]
+		resp.ByStatus[u.Status]++
+		resp.ByLevel[u.Level]++
+
+		if u.AssignedAt != nil {
+			toAssign = append(toAssign, nonNegative(u.AssignedAt.Sub(u.CreatedAt)))
+		}
+		if end := closedAt(u); end != nil {
+			toClose = append(toClose, nonNegative(end.Sub(u.CreatedAt)))
+		}
+		if u.AssignedEmployeeID != nil {
+			e, ok := employees[*u.AssignedEmployeeID]
+			if !ok {
+				e = &urgencyV1.EmployeeHandledStats{EmployeeID: *u.AssignedEmployeeID}
+				employees[*u.AssignedEmployeeID] = e
+			}
+			e.Handled++
+			if u.Status == urgencyV1.Resolved || u.Status == urgencyV1.Closed {
+				e.Completed++
+			}
+		}
+
+		levelStats, ok := slaLevels[u.Level]
+		if !ok {
+			continue
+		}
+		wait, counted := waitForResponder(u, now)
+		if !counted {
+			continue
+		}
+		levelStats.Total++
+		target := time.Duration(levelStats.TargetSeconds) * time.Second
+		if wait <= target {
+			continue
+		}
+		levelStats.Breached++
+		resp.SLA.Breaches++
+		if len(resp.SLA.Breached) < maxListedSLABreaches {
+			breach := urgencyV1.SLABreach{
+				UrgencyID:     u.ID,
+				Level:         u.Level,
+				Status:        u.Status,
+				CreatedAt:     u.CreatedAt.Format(time.RFC3339),
+				WaitSeconds:   int64(wait.Seconds()),
+				TargetSeconds: levelStats.TargetSeconds,
+			}
+			if u.AssignedAt != nil {
+				breach.AssignedAt = u.AssignedAt.Format(time.RFC3339)
+			}
+			resp.SLA.Breached = append(resp.SLA.Breached, breach)
+		}
+	}
+
+	for _, level := range slaOrder {
+		if levelStats, ok := slaLevels[level]; ok {
+			resp.SLA.Levels = append(resp.SLA.Levels, *levelStats)
+		}
+	}
+	resp.TimeToAssign = durationStats(toAssign)
+	resp.TimeToClose = durationStats(toClose)
+	for _, e := range employees {
+		resp.Employees = append(resp.Employees, *e)
+	}
+	sort.Slice(resp.Employees, func(i, j int) bool {
+		if resp.Employees[i].Handled != resp.Employees[j].Handled {
+			return resp.Employees[i].Handled > resp.Employees[j].Handled
+		}
+		return resp.Employees[i].EmployeeID < resp.Employees[j].EmployeeID
+	})
+	return resp
+}
+
+func closedAt(u *model.Urgency) *time.Time {
+	if u.ResolvedAt != nil {
+		return u.ResolvedAt
+	}
+	return u.ClosedAt
+}
+
+// waitForResponder returns how long the urgency waited, or is still waiting, for a responder
+func waitForResponder(u *model.Urgency, now time.Time) (time.Duration, bool) {
+	if u.AssignedAt != nil {
+		return nonNegative(u.AssignedAt.Sub(u.CreatedAt)), true
+	}
+	if u.Status == urgencyV1.Open || u.Status == urgencyV1.InProgress {
+		return nonNegative(now.Sub(u.CreatedAt)), true
+	}
+	return 0, false
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+func durationStats(durations []time.Duration) urgencyV1.DurationStats {
+	stats := urgencyV1.DurationStats{Count: len(durations)}
+	if len(durations) == 0 {
+		return stats
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	median := percentile(durations, 0.5)
+	p90 := percentile(durations, 0.9)
+	stats.MedianSeconds = &median
+	stats.P90Seconds = &p90
+	return stats
+}
+
+// percentile uses the nearest-rank method on sorted durations and returns seconds
+func percentile(sorted []time.Duration, p float64) float64 {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank].Seconds()
+}
@@ -0,0 +1,113 @@
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestComputeUrgencyStats(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	from, to := now.Add(-24*time.Hour), now
+	at := func(minutes int) *time.Time {
+		v := from.Add(time.Duration(minutes) * time.Minute)
+		return &v
+	}
+	emp := func(id uint) *uint { return &id }
+	policy := SLAPolicy{Targets: map[urgencyV1.UrgencyLevel]time.Duration{urgencyV1.Critical: 10 * time.Minute, urgencyV1.High: 30 * time.Minute}}
+
+	urgencies := []model.Urgency{
+		// assigned after 5 minutes and resolved after 60
+		{ID: 1, Model: gorm.Model{CreatedAt: *at(0)}, Level: urgencyV1.Critical, Status: urgencyV1.Resolved, AssignedEmployeeID: emp(7), AssignedAt: at(5), ResolvedAt: at(60)},
+		// assigned after 20 minutes, breaching the critical target, closed without resolving after 90
+		{ID: 2, Model: gorm.Model{CreatedAt: *at(0)}, Level: urgencyV1.Critical, Status: urgencyV1.Closed, AssignedEmployeeID: emp(7), AssignedAt: at(20), ClosedAt: at(90)},
+		// still waiting since far beyond the high target
+		{ID: 3, Model: gorm.Model{CreatedAt: *at(60)}, Level: urgencyV1.High, Status: urgencyV1.Open},
+		// cancelled before anyone took it, not counted for SLA
+		{ID: 4, Model: gorm.Model{CreatedAt: *at(0)}, Level: urgencyV1.High, Status: urgencyV1.Cancelled},
+		// level without a target
+		{ID: 5, Model: gorm.Model{CreatedAt: *at(0)}, Level: urgencyV1.Low, Status: urgencyV1.InProgress, AssignedEmployeeID: emp(9), AssignedAt: at(40)},
+	}
+
+	stats := computeUrgencyStats(urgencies, policy, from, to, now)
+
+	assert.Equal(t, 5, stats.Total)
+	assert.Equal(t, "2025-02-28T12:00:00Z", stats.From)
+	assert.Equal(t, map[urgencyV1.UrgencyStatus]int{urgencyV1.Resolved: 1, urgencyV1.Closed: 1, urgencyV1.Open: 1, urgencyV1.Cancelled: 1, urgencyV1.InProgress: 1}, stats.ByStatus)
+	assert.Equal(t, map[urgencyV1.UrgencyLevel]int{urgencyV1.Critical: 2, urgencyV1.High: 2, urgencyV1.Low: 1}, stats.ByLevel)
+
+	assert.Equal(t, 3, stats.TimeToAssign.Count)
+	require.NotNil(t, stats.TimeToAssign.MedianSeconds)
+	assert.Equal(t, float64(20*60), *stats.TimeToAssign.MedianSeconds)
+	assert.Equal(t, float64(40*60), *stats.TimeToAssign.P90Seconds)
+	assert.Equal(t, 2, stats.TimeToClose.Count)
+	assert.Equal(t, float64(60*60), *stats.TimeToClose.MedianSeconds)
+	assert.Equal(t, float64(90*60), *stats.TimeToClose.P90Seconds)
+
+	assert.Equal(t, []urgencyV1.EmployeeHandledStats{{EmployeeID: 7, Handled: 2, Completed: 2}, {EmployeeID: 9, Handled: 1}}, stats.Employees)
+
+	assert.Equal(t, []urgencyV1.SLALevelStats{
+		{Level: urgencyV1.Critical, TargetSeconds: 600, Total: 2, Breached: 1},
+		{Level: urgencyV1.High, TargetSeconds: 1800, Total: 1, Breached: 1},
+	}, stats.SLA.Levels)
+	assert.Equal(t, 2, stats.SLA.Breaches)
+	if assert.Len(t, stats.SLA.Breached, 2) {
+		assert.Equal(t, uint(2), stats.SLA.Breached[0].UrgencyID)
+		assert.Equal(t, int64(20*60), stats.SLA.Breached[0].WaitSeconds)
+		assert.NotEmpty(t, stats.SLA.Breached[0].AssignedAt)
+		assert.Equal(t, uint(3), stats.SLA.Breached[1].UrgencyID)
+		assert.Equal(t, int64(23*3600), stats.SLA.Breached[1].WaitSeconds)
+		assert.Empty(t, stats.SLA.Breached[1].AssignedAt)
+	}
+}
+
+func TestComputeUrgencyStats_Empty(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it returns empty collections and no percentiles", func(t *testing.T) {
+		now := time.Now().UTC()
+		stats := computeUrgencyStats(nil, DefaultSLAPolicy(), now.Add(-time.Hour), now, now)
+		assert.Equal(t, 0, stats.Total)
+		assert.Nil(t, stats.TimeToAssign.MedianSeconds)
+		assert.NotNil(t, stats.Employees)
+		assert.NotNil(t, stats.SLA.Breached)
+		assert.Len(t, stats.SLA.Levels, 4)
+	})
+}
+
+func TestUrgencyService_GetStats(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it loads urgencies for the requested range", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+		repo.EXPECT().ListForStats(gomock.Any(), from, to).Return([]model.Urgency{{ID: 1, Level: urgencyV1.Low, Status: urgencyV1.Closed}}, nil)
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, nil, nil, nil)
+
+		stats, err := svc.GetStats(context.Background(), urgencyV1.UrgencyStatsQuery{From: "2025-01-01", To: "2025-01-31"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, stats.Total)
+	})
+
+	t.Run("it returns an error when urgencies cannot be loaded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		repo.EXPECT().ListForStats(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, nil, nil, nil)
+
+		_, err := svc.GetStats(context.Background(), urgencyV1.UrgencyStatsQuery{})
+		assertAppErrorCode(t, err, "URGENCY_ERRORS.LIST_FAILED")
+	})
+}
@@ -0,0 +1,137 @@
+package internal
+
+import (
+	"context"
+	"time"
+
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
+)
+
+// GetTeam returns the responders currently working on an urgency.
+func (s *urgencyService) GetTeam(ctx context.Context, urgencyID uint) (*urgencyV1.UrgencyTeamResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.GetTeam")()
+
+	if _, err := s.GetUrgencyByID(ctx, urgencyID); err != nil {
+		return nil, err
+	}
+	members, err := s.repo.ListTeam(ctx, urgencyID)
+	if err != nil {
+		log.Errorf("Failed to list team of urgency %d: %v", urgencyID, err)
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.LIST_FAILED", "failed to list urgency team", map[string]interface{}{"cause": err.Error()})
+	}
+	resp := &urgencyV1.UrgencyTeamResponse{UrgencyID: urgencyID, Members: make([]urgencyV1.TeamMemberResponse, 0, len(members))}
+	for i := range members {
+		resp.Members = append(resp.Members, members[i].ToResponse())
+	}
+	return resp, nil
+}
+
+// AddTeamMember adds a responder to an open or in-progress urgency that was let through by the intake
+// checks. Anyone may take the lead of an urgency without one, which assigns it like AssignUrgency; other
+// roles are added by the lead or an admin.
+func (s *urgencyService) AddTeamMember(ctx context.Context, urgencyID, actorID uint, isAdmin bool, req urgencyV1.TeamMemberRequest) error {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.AddTeamMember")()
+
+	if urgencyID == 0 || req.EmployeeID == 0 {
+		return commonv1.NewAppError("VALIDATION.INVALID_REQUEST", "urgencyId and employeeId are required", nil)
+	}
+	urg, err := s.GetUrgencyByIDPrimary(ctx, urgencyID)
+	if err != nil {
+		return err
+	}
+	if urg.Status != urgencyV1.Open && urg.Status != urgencyV1.InProgress {
+		return commonv1.NewAppError("URGENCY_ERRORS.INVALID_TRANSITION", "team can only change while the urgency is open or in progress", map[string]interface{}{"status": urg.Status})
+	}
+	if !urg.IntakeAccepted() {
+		return intakeNotAcceptedError(urg)
+	}
+	if req.Role == urgencyV1.RoleLead {
+		if urg.AssignedEmployeeID != nil {
+			return commonv1.NewAppError("URGENCY_ERRORS.ALREADY_ASSIGNED", "urgency already has a lead", map[string]interface{}{"lead": *urg.AssignedEmployeeID})
+		}
+	} else {
+		if urg.AssignedEmployeeID == nil {
+			return commonv1.NewAppError("URGENCY_ERRORS.NOT_ASSIGNED", "urgency needs a lead before other responders join", nil)
+		}
+		if err := authorizeAssigneeOrAdmin(urg, actorID, isAdmin, "add team members"); err != nil {
+			return err
+		}
+	}
+	if _, err := s.employeeClient.GetEmployeeByID(ctx, req.EmployeeID); err != nil {
+		return commonv1.NewAppError("URGENCY_ERRORS.INVALID_ASSIGNEE", "employee does not exist or is not accessible", map[string]interface{}{"cause": err.Error(), "employeeId": req.EmployeeID})
+	}
+
+	member := &model.UrgencyAssignment{UrgencyID: urgencyID, EmployeeID: req.EmployeeID, Role: req.Role, AddedBy: &actorID, JoinedAt: time.Now().UTC()}
+	event := &model.UrgencyEvent{Type: model.UrgencyEventTeamJoined, ActorID: &actorID, Reason: string(req.Role)}
+	added, err := s.repo.AddTeamMember(ctx, member, event)
+	if err != nil {
+		log.Errorf("Failed to add employee %d to urgency %d: %v", req.EmployeeID, urgencyID, err)
+		return commonv1.NewAppError("URGENCY_ERRORS.UPDATE_FAILED", "failed to add team member", map[string]interface{}{"cause": err.Error()})
+	}
+	if !added {
+		return commonv1.NewAppError("URGENCY_ERRORS.ALREADY_ON_TEAM", "employee is already on the team or the urgency already has a lead", map[string]interface{}{"employeeId": req.EmployeeID})
+	}
+	log.Infof("Employee %d joined urgency %d as %s", req.EmployeeID, urgencyID, req.Role)
+	return nil
+}
+
+// RemoveTeamMember takes a responder off an urgency team. The lead and admins may remove anyone and
+// members may leave themselves while the urgency is open or in progress. Removing the lead leaves the
+// urgency without an assignee.
+func (s *urgencyService) RemoveTeamMember(ctx context.Context, urgencyID, employeeID, actorID uint, isAdmin bool) error {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.RemoveTeamMember")()
+
+	if urgencyID == 0 || employeeID == 0 {
+		return commonv1.NewAppError("VALIDATION.INVALID_REQUEST", "urgencyId and employeeId are required", nil)
+	}
+	urg, err := s.GetUrgencyByIDPrimary(ctx, urgencyID)
+	if err != nil {
+		return err
+	}
+	if urg.Status != urgencyV1.Open && urg.Status != urgencyV1.InProgress {
+		return commonv1.NewAppError("URGENCY_ERRORS.INVALID_TRANSITION", "team can only change while the urgency is open or in progress", map[string]interface{}{"status": urg.Status})
+	}
+	if actorID != employeeID {
+		if err := authorizeAssigneeOrAdmin(urg, actorID, isAdmin, "remove team members"); err != nil {
+			return err
+		}
+	}
+
+	event := &model.UrgencyEvent{Type: model.UrgencyEventTeamLeft, ActorID: &actorID}
+	removed, err := s.repo.RemoveTeamMember(ctx, urgencyID, employeeID, time.Now().UTC(), event)
+	if err != nil {
+		log.Errorf("Failed to remove employee %d from urgency %d: %v", employeeID, urgencyID, err)
+		return commonv1.NewAppError("URGENCY_ERRORS.UPDATE_FAILED", "failed to remove team member", map[string]interface{}{"cause": err.Error()})
+	}
+	if !removed {
+		return commonv1.NewAppError("URGENCY_ERRORS.NOT_ON_TEAM", "employee is not on the urgency team", map[string]interface{}{"employeeId": employeeID})
+	}
+	log.Infof("Employee %d left urgency %d", employeeID, urgencyID)
+	return nil
+}
+
+// GetEmployeeActiveUrgencies returns the in-progress urgencies an employee leads or supports. The employee
+// service uses it before deleting an employee or removing their shift.
+func (s *urgencyService) GetEmployeeActiveUrgencies(ctx context.Context, employeeID uint) (*urgencyV1.EmployeeActiveUrgenciesResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.GetEmployeeActiveUrgencies")()
+
+	if employeeID == 0 {
+		return nil, commonv1.NewAppError("VALIDATION.INVALID_REQUEST", "employeeId is required", nil)
+	}
+	ids, err := s.repo.ListActiveIDsForEmployee(ctx, employeeID)
+	if err != nil {
+		log.Errorf("Failed to list active urgencies of employee %d: %v", employeeID, err)
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.LIST_FAILED", "failed to list active urgencies", map[string]interface{}{"cause": err.Error()})
+	}
+	if ids == nil {
+		ids = []uint{}
+	}
+	return &urgencyV1.EmployeeActiveUrgenciesResponse{EmployeeID: employeeID, HasActive: len(ids) > 0, UrgencyIDs: ids}, nil
+}
@@ -0,0 +1,254 @@
+package internal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
+	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/clients"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/repositories"
+)
+
+func expectUrgency(repo *repositories.MockUrgencyRepository, status urgencyV1.UrgencyStatus, lead *uint) {
+	repo.EXPECT().GetByIDPrimary(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, u *model.Urgency) error {
+		*u = model.Urgency{ID: id, Status: status, AssignedEmployeeID: lead}
+		return nil
+	})
+}
+
+func appErrorCode(t *testing.T, err error) string {
+	t.Helper()
+	var aerr *commonv1.AppError
+	require.ErrorAs(t, err, &aerr)
+	return aerr.Code
+}
+
+func TestUrgencyService_GetTeam(t *testing.T) {
+	t.Parallel()
+	log := utils.NewTestLogger()
+
+	t.Run("it returns the active members", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		svc := &urgencyService{log: log, repo: repo}
+		repo.EXPECT().GetByID(gomock.Any(), uint(1), gomock.Any()).Return(nil)
+		repo.EXPECT().ListTeam(gomock.Any(), uint(1)).Return([]model.UrgencyAssignment{{EmployeeID: 5, Role: urgencyV1.RoleLead}, {EmployeeID: 6, Role: urgencyV1.RoleMedic}}, nil)
+
+		team, err := svc.GetTeam(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, uint(1), team.UrgencyID)
+		require.Len(t, team.Members, 2)
+		assert.Equal(t, urgencyV1.RoleMedic, team.Members[1].Role)
+	})
+
+	t.Run("it returns an error when listing fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		svc := &urgencyService{log: log, repo: repo}
+		repo.EXPECT().GetByID(gomock.Any(), uint(1), gomock.Any()).Return(nil)
+		repo.EXPECT().ListTeam(gomock.Any(), uint(1)).Return(nil, assert.AnError)
+
+		_, err := svc.GetTeam(context.Background(), 1)
+		assert.Equal(t, "URGENCY_ERRORS.LIST_FAILED", appErrorCode(t, err))
+	})
+}
+
+func TestUrgencyService_AddTeamMember(t *testing.T) {
+	t.Parallel()
+	log := utils.NewTestLogger()
+	lead := uint(5)
+
+	t.Run("anyone can take the lead of an unassigned urgency", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		ecli := clients.NewMockEmployeeClient(ctrl)
+		svc := &urgencyService{log: log, repo: repo, employeeClient: ecli}
+		expectUrgency(repo, urgencyV1.Open, nil)
+		ecli.EXPECT().GetEmployeeByID(gomock.Any(), uint(7)).Return(&employeeV1.EmployeeResponse{ID: 7}, nil)
+		repo.EXPECT().AddTeamMember(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, m *model.UrgencyAssignment, e *model.UrgencyEvent) (bool, error) {
+			assert.Equal(t, uint(7), m.EmployeeID)
+			assert.Equal(t, urgencyV1.RoleLead, m.Role)
+			assert.Equal(t, uint(7), *m.AddedBy)
+			assert.Equal(t, model.UrgencyEventTeamJoined, e.Type)
+			return true, nil
+		})
+
+		err := svc.AddTeamMember(context.Background(), 1, 7, false, urgencyV1.TeamMemberRequest{EmployeeID: 7, Role: urgencyV1.RoleLead})
+		assert.NoError(t, err)
+	})
+
+	t.Run("nobody can join an urgency held back by the intake checks", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		svc := &urgencyService{log: log, repo: repo}
+		repo.EXPECT().GetByIDPrimary(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, u *model.Urgency) error {
+			*u = model.Urgency{ID: id, Status: urgencyV1.Open, IntakeStatus: urgencyV1.IntakeQuarantined}
+			return nil
+		})
+
+		err := svc.AddTeamMember(context.Background(), 1, 7, true, urgencyV1.TeamMemberRequest{EmployeeID: 7, Role: urgencyV1.RoleLead})
+		assert.Equal(t, "URGENCY_ERRORS.INVALID_TRANSITION", appErrorCode(t, err))
+	})
+
+	t.Run("it rejects a second lead", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		svc := &urgencyService{log: log, repo: repo}
+		expectUrgency(repo, urgencyV1.InProgress, &lead)
+
+		err := svc.AddTeamMember(context.Background(), 1, 7, false, urgencyV1.TeamMemberRequest{EmployeeID: 7, Role: urgencyV1.RoleLead})
+		assert.Equal(t, "URGENCY_ERRORS.ALREADY_ASSIGNED", appErrorCode(t, err))
+	})
+
+	t.Run("other roles need a lead", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		svc := &urgencyService{log: log, repo: repo}
+		expectUrgency(repo, urgencyV1.Open, nil)
+
+		err := svc.AddTeamMember(context.Background(), 1, 7, true, urgencyV1.TeamMemberRequest{EmployeeID: 7, Role: urgencyV1.RoleMedic})
+		assert.Equal(t, "URGENCY_ERRORS.NOT_ASSIGNED", appErrorCode(t, err))
+	})
+
+	t.Run("only the lead or an admin can add other roles", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		svc := &urgencyService{log: log, repo: repo}
+		expectUrgency(repo, urgencyV1.InProgress, &lead)
+
+		err := svc.AddTeamMember(context.Background(), 1, 7, false, urgencyV1.TeamMemberRequest{EmployeeID: 7, Role: urgencyV1.RoleMedic})
+		assert.Equal(t, "AUTH_ERRORS.FORBIDDEN", appErrorCode(t, err))
+	})
+
+	t.Run("it rejects inactive urgencies", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		svc := &urgencyService{log: log, repo: repo}
+		expectUrgency(repo, urgencyV1.Resolved, &lead)
+
+		err := svc.AddTeamMember(context.Background(), 1, lead, false, urgencyV1.TeamMemberRequest{EmployeeID: 7, Role: urgencyV1.RoleMedic})
+		assert.Equal(t, "URGENCY_ERRORS.INVALID_TRANSITION", appErrorCode(t, err))
+	})
+
+	t.Run("it rejects unknown employees", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		ecli := clients.NewMockEmployeeClient(ctrl)
+		svc := &urgencyService{log: log, repo: repo, employeeClient: ecli}
+		expectUrgency(repo, urgencyV1.InProgress, &lead)
+		ecli.EXPECT().GetEmployeeByID(gomock.Any(), uint(7)).Return(nil, assert.AnError)
+
+		err := svc.AddTeamMember(context.Background(), 1, lead, false, urgencyV1.TeamMemberRequest{EmployeeID: 7, Role: urgencyV1.RoleMedic})
+		assert.Equal(t, "URGENCY_ERRORS.INVALID_ASSIGNEE", appErrorCode(t, err))
+	})
+
+	t.Run("it reports members already on the team", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		ecli := clients.NewMockEmployeeClient(ctrl)
+		svc := &urgencyService{log: log, repo: repo, employeeClient: ecli}
+		expectUrgency(repo, urgencyV1.InProgress, &lead)
+		ecli.EXPECT().GetEmployeeByID(gomock.Any(), uint(7)).Return(&employeeV1.EmployeeResponse{ID: 7}, nil)
+		repo.EXPECT().AddTeamMember(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+
+		err := svc.AddTeamMember(context.Background(), 1, lead, false, urgencyV1.TeamMemberRequest{EmployeeID: 7, Role: urgencyV1.RoleMedic})
+		assert.Equal(t, "URGENCY_ERRORS.ALREADY_ON_TEAM", appErrorCode(t, err))
+	})
+}
+
+func TestUrgencyService_RemoveTeamMember(t *testing.T) {
+	t.Parallel()
+	log := utils.NewTestLogger()
+	lead := uint(5)
+
+	t.Run("members can leave the team themselves", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		svc := &urgencyService{log: log, repo: repo}
+		expectUrgency(repo, urgencyV1.InProgress, &lead)
+		repo.EXPECT().RemoveTeamMember(gomock.Any(), uint(1), uint(7), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _, _ uint, _ interface{}, e *model.UrgencyEvent) (bool, error) {
+			assert.Equal(t, model.UrgencyEventTeamLeft, e.Type)
+			return true, nil
+		})
+
+		assert.NoError(t, svc.RemoveTeamMember(context.Background(), 1, 7, 7, false))
+	})
+
+	t.Run("other members cannot remove each other", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		svc := &urgencyService{log: log, repo: repo}
+		expectUrgency(repo, urgencyV1.InProgress, &lead)
+
+		err := svc.RemoveTeamMember(context.Background(), 1, 7, 8, false)
+		assert.Equal(t, "AUTH_ERRORS.FORBIDDEN", appErrorCode(t, err))
+	})
+
+	t.Run("the team of a resolved urgency cannot change", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		svc := &urgencyService{log: log, repo: repo}
+		expectUrgency(repo, urgencyV1.Resolved, &lead)
+
+		err := svc.RemoveTeamMember(context.Background(), 1, lead, lead, false)
+		assert.Equal(t, "URGENCY_ERRORS.INVALID_TRANSITION", appErrorCode(t, err))
+	})
+
+	t.Run("it reports employees not on the team", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		svc := &urgencyService{log: log, repo: repo}
+		expectUrgency(repo, urgencyV1.InProgress, &lead)
+		repo.EXPECT().RemoveTeamMember(gomock.Any(), uint(1), uint(7), gomock.Any(), gomock.Any()).Return(false, nil)
+
+		err := svc.RemoveTeamMember(context.Background(), 1, 7, lead, false)
+		assert.Equal(t, "URGENCY_ERRORS.NOT_ON_TEAM", appErrorCode(t, err))
+	})
+}
+
+func TestUrgencyService_GetEmployeeActiveUrgencies(t *testing.T) {
+	t.Parallel()
+	log := utils.NewTestLogger()
+
+	t.Run("it reports the active urgencies", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		svc := &urgencyService{log: log, repo: repo}
+		repo.EXPECT().ListActiveIDsForEmployee(gomock.Any(), uint(7)).Return([]uint{3, 4}, nil)
+
+		resp, err := svc.GetEmployeeActiveUrgencies(context.Background(), 7)
+		require.NoError(t, err)
+		assert.True(t, resp.HasActive)
+		assert.Equal(t, []uint{3, 4}, resp.UrgencyIDs)
+	})
+
+	t.Run("it returns an empty list when the employee is free", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		svc := &urgencyService{log: log, repo: repo}
+		repo.EXPECT().ListActiveIDsForEmployee(gomock.Any(), uint(7)).Return(nil, nil)
+
+		resp, err := svc.GetEmployeeActiveUrgencies(context.Background(), 7)
+		require.NoError(t, err)
+		assert.False(t, resp.HasActive)
+		assert.NotNil(t, resp.UrgencyIDs)
+	})
+
+	t.Run("it returns an error when listing fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		svc := &urgencyService{log: log, repo: repo}
+		repo.EXPECT().ListActiveIDsForEmployee(gomock.Any(), uint(7)).Return(nil, assert.AnError)
+
+		_, err := svc.GetEmployeeActiveUrgencies(context.Background(), 7)
+		assert.Equal(t, "URGENCY_ERRORS.LIST_FAILED", appErrorCode(t, err))
+	})
+}
@@ -0,0 +1,222 @@
+// Package templates renders urgency notification messages from text/template definitions.
+//
+// Each locale is one template set. A set defines "<event>.<channel>" bodies, an optional
+// "<event>.email.subject" and "level.<level>" labels. Embedded defaults ship with the service and an
+// override directory may redefine any of them by adding a "<locale>.tmpl" file.
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+//go:embed defaults/*.tmpl
+var defaultFiles embed.FS
+
+type (
+	Channel string
+	Event   string
+)
+
+const (
+	ChannelSMS   Channel = "sms"
+	ChannelEmail Channel = "email"
+
+	EventNewUrgency Event = "new_urgency"
+	EventEscalation Event = "escalation"
+	EventReassigned Event = "reassigned"
+	EventClosed     Event = "closed"
+)
+
+// Channels lists the channels every locale has to provide a body for
+func Channels() []Channel {
+	return []Channel{ChannelSMS, ChannelEmail}
+}
+
+// Events lists the events every locale has to provide a body for
+func Events() []Event {
+	return []Event{EventNewUrgency, EventEscalation, EventReassigned, EventClosed}
+}
+
+// Data is what templates can reference
+type Data struct {
+	UrgencyID          uint
+	RecipientFirstName string
+	RecipientLastName  string
+	ContactName        string
+	ContactPhone       string
+	Location           string
+	Description        string
+	Level              urgencyV1.UrgencyLevel
+}
+
+// Message is a rendered notification
+type Message struct {
+	Locale  string
+	Subject string
+	Body    string
+}
+
+// Registry holds one parsed template set per supported locale
+type Registry struct {
+	sets          map[string]*template.Template
+	defaultLocale string
+}
+
+// New parses the embedded defaults and, when dir is set, the "<locale>.tmpl" overrides found in it.
+// Messages for employees without a usable preference are rendered in defaultLocale.
+func New(dir, defaultLocale string) (*Registry, error) {
+	if !utils.IsSupportedLocale(defaultLocale) {
+		return nil, fmt.Errorf("unsupported default locale %q", defaultLocale)
+	}
+	r := &Registry{sets: make(map[string]*template.Template), defaultLocale: defaultLocale}
+
+	for _, locale := range utils.SupportedLocales() {
+		src, err := defaultFiles.ReadFile("defaults/" + locale + ".tmpl")
+		if err != nil {
+			return nil, fmt.Errorf("read default templates for %s: %w", locale, err)
+		}
+		set, err := template.New(locale).Funcs(r.funcs(locale)).Parse(string(src))
+		if err != nil {
+			return nil, fmt.Errorf("parse default templates for %s: %w", locale, err)
+		}
+		r.sets[locale] = set
+	}
+
+	if dir != "" {
+		if err := r.loadOverrides(dir); err != nil {
+			return nil, err
+		}
+	}
+
+	// every locale must be able to render every message, otherwise a bad override only shows up at send time
+	for _, locale := range utils.SupportedLocales() {
+		for _, event := range Events() {
+			for _, channel := range Channels() {
+				if r.sets[locale].Lookup(bodyName(event, channel)) == nil {
+					return nil, fmt.Errorf("locale %s is missing template %q", locale, bodyName(event, channel))
+				}
+			}
+		}
+	}
+	return r, nil
+}
+
+var (
+	defaultOnce     sync.Once
+	defaultRegistry *Registry
+)
+
+// Default returns the shared registry with only the embedded templates and English as the fallback locale
+func Default() *Registry {
+	defaultOnce.Do(func() {
+		r, err := New("", utils.LocaleEnglish)
+		if err != nil {
+			panic(fmt.Sprintf("embedded notification templates are invalid: %v", err))
+		}
+		defaultRegistry = r
+	})
+	return defaultRegistry
+}
+
+func (r *Registry) loadOverrides(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("read template directory %s: %w", dir, err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".tmpl" {
+			continue
+		}
+		locale := strings.TrimSuffix(entry.Name(), ".tmpl")
+		set, ok := r.sets[locale]
+		if !ok {
+			return fmt.Errorf("template override %s does not match a supported locale", entry.Name())
+		}
+		src, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("read template override %s: %w", entry.Name(), err)
+		}
+		// parsing into the existing set replaces only the templates the file redefines
+		if _, err := set.Parse(string(src)); err != nil {
+			return fmt.Errorf("parse template override %s: %w", entry.Name(), err)
+		}
+	}
+	return nil
+}
+
+// DefaultLocale is the locale used when the recipient has no supported preference
+func (r *Registry) DefaultLocale() string {
+	return r.defaultLocale
+}
+
+// ResolveLocale returns locale when templates exist for it, otherwise the default locale
+func (r *Registry) ResolveLocale(locale string) string {
+	if _, ok := r.sets[locale]; ok {
+		return locale
+	}
+	return r.defaultLocale
+}
+
+// Render renders the message for an event on a channel in the requested locale
+func (r *Registry) Render(channel Channel, event Event, locale string, data Data) (Message, error) {
+	locale = r.ResolveLocale(locale)
+	set := r.sets[locale]
+	msg := Message{Locale: locale}
+
+	body, err := execute(set, bodyName(event, channel), data)
+	if err != nil {
+		return Message{}, err
+	}
+	msg.Body = body
+
+	if channel == ChannelEmail && set.Lookup(subjectName(event)) != nil {
+		subject, err := execute(set, subjectName(event), data)
+		if err != nil {
+			return Message{}, err
+		}
+		msg.Subject = strings.TrimSpace(subject)
+	}
+	return msg, nil
+}
+
+// funcs are bound per locale so "level" resolves labels from the same set
+func (r *Registry) funcs(locale string) template.FuncMap {
+	return template.FuncMap{
+		"level": func(level urgencyV1.UrgencyLevel) (string, error) {
+			name := "level." + string(level)
+			if r.sets[locale] == nil || r.sets[locale].Lookup(name) == nil {
+				return string(level), nil
+			}
+			return execute(r.sets[locale], name, nil)
+		},
+	}
+}
+
+func execute(set *template.Template, name string, data any) (string, error) {
+	if set.Lookup(name) == nil {
+		return "", fmt.Errorf("template %q is not defined for locale %s", name, set.Name())
+	}
+	var buf bytes.Buffer
+	if err := set.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", fmt.Errorf("render template %q: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+func bodyName(event Event, channel Channel) string {
+	return string(event) + "." + string(channel)
+}
+
+func subjectName(event Event) string {
+	return string(event) + ".email.subject"
+}
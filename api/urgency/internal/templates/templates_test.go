@@ -0,0 +1,126 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleData() Data {
+	return Data{
+		UrgencyID:          7,
+		RecipientFirstName: "Marko",
+		RecipientLastName:  "Markovic",
+		ContactName:        "Ana Jovanovic",
+		ContactPhone:       "+381601234567",
+		Location:           "N 43.401123 E 22.662756",
+		Description:        "Lost hiker",
+		Level:              urgencyV1.High,
+	}
+}
+
+func TestRegistry_Render(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it renders every event and channel in every locale", func(t *testing.T) {
+		r := Default()
+		for _, locale := range utils.SupportedLocales() {
+			for _, event := range Events() {
+				for _, channel := range Channels() {
+					msg, err := r.Render(channel, event, locale, sampleData())
+					require.NoError(t, err, "%s %s %s", locale, event, channel)
+					assert.Equal(t, locale, msg.Locale)
+					assert.NotEmpty(t, msg.Body)
+					assert.NotContains(t, msg.Body, "<no value>")
+					if channel == ChannelEmail {
+						assert.Contains(t, msg.Subject, "#7")
+					} else {
+						assert.Empty(t, msg.Subject)
+					}
+				}
+			}
+		}
+	})
+
+	t.Run("it keeps the English alert wording", func(t *testing.T) {
+		msg, err := Default().Render(ChannelSMS, EventNewUrgency, utils.LocaleEnglish, sampleData())
+		require.NoError(t, err)
+		assert.Equal(t, "🚨 EMERGENCY: Lost hiker at N 43.401123 E 22.662756. Contact: Ana Jovanovic (+381601234567). Priority: high. Please respond ASAP.", msg.Body)
+	})
+
+	t.Run("it translates the urgency level", func(t *testing.T) {
+		msg, err := Default().Render(ChannelEmail, EventNewUrgency, utils.LocaleSerbianCyrillic, sampleData())
+		require.NoError(t, err)
+		assert.Contains(t, msg.Body, "Приоритет: висок")
+		assert.Contains(t, msg.Body, "Здраво Marko Markovic")
+	})
+
+	t.Run("it falls back to the default locale for unknown or empty preferences", func(t *testing.T) {
+		r, err := New("", utils.LocaleSerbianLatin)
+		require.NoError(t, err)
+
+		for _, locale := range []string{"", "de"} {
+			msg, err := r.Render(ChannelSMS, EventClosed, locale, sampleData())
+			require.NoError(t, err)
+			assert.Equal(t, utils.LocaleSerbianLatin, msg.Locale)
+			assert.Contains(t, msg.Body, "je zatvoren")
+		}
+	})
+
+	t.Run("it returns an error for an unknown event", func(t *testing.T) {
+		_, err := Default().Render(ChannelSMS, Event("unknown"), utils.LocaleEnglish, sampleData())
+		assert.Error(t, err)
+	})
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it rejects an unsupported default locale", func(t *testing.T) {
+		_, err := New("", "de")
+		assert.Error(t, err)
+	})
+
+	t.Run("it applies overrides only to the templates they redefine", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "en.tmpl"), []byte(`{{define "closed.sms"}}Case {{.UrgencyID}} done{{end}}`), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("ignored"), 0o644))
+
+		r, err := New(dir, utils.LocaleEnglish)
+		require.NoError(t, err)
+
+		msg, err := r.Render(ChannelSMS, EventClosed, utils.LocaleEnglish, sampleData())
+		require.NoError(t, err)
+		assert.Equal(t, "Case 7 done", msg.Body)
+
+		msg, err = r.Render(ChannelEmail, EventClosed, utils.LocaleEnglish, sampleData())
+		require.NoError(t, err)
+		assert.Contains(t, msg.Body, "has been closed")
+	})
+
+	t.Run("it rejects an override for an unsupported locale", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "de.tmpl"), []byte(`{{define "closed.sms"}}x{{end}}`), 0o644))
+
+		_, err := New(dir, utils.LocaleEnglish)
+		assert.Error(t, err)
+	})
+
+	t.Run("it rejects an override that does not parse", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "ru.tmpl"), []byte(`{{define "closed.sms"}}{{.UrgencyID}`), 0o644))
+
+		_, err := New(dir, utils.LocaleEnglish)
+		assert.Error(t, err)
+	})
+
+	t.Run("it returns an error when the directory does not exist", func(t *testing.T) {
+		_, err := New(filepath.Join(t.TempDir(), "missing"), utils.LocaleEnglish)
+		assert.Error(t, err)
+	})
+}
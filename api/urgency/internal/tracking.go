@@ -0,0 +1,123 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
+	"gorm.io/gorm"
+)
+
+// GetTrackingStatus returns what the reporter may see about their urgency. A report linked as a
+// duplicate shows the urgency responders are actually working on.
+func (s *urgencyService) GetTrackingStatus(ctx context.Context, token string) (*urgencyV1.UrgencyTrackingResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.GetTrackingStatus")()
+
+	urg, err := s.trackedUrgency(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	team, err := s.repo.ListTeam(ctx, urg.ID)
+	if err != nil {
+		log.Errorf("Failed to list team of urgency %d: %v", urg.ID, err)
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.DB_ERROR", "failed to fetch urgency status", map[string]interface{}{"cause": err.Error()})
+	}
+	followUps, err := s.repo.ListFollowUps(ctx, urg.ID)
+	if err != nil {
+		log.Errorf("Failed to list reporter follow-ups of urgency %d: %v", urg.ID, err)
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.DB_ERROR", "failed to fetch urgency status", map[string]interface{}{"cause": err.Error()})
+	}
+
+	resp := &urgencyV1.UrgencyTrackingResponse{
+		Status:    urg.Status,
+		Level:     urg.Level,
+		TeamSize:  len(team),
+		EtaNote:   urg.EtaNote,
+		FollowUps: len(followUps),
+		CreatedAt: urg.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt: urg.UpdatedAt.UTC().Format(time.RFC3339),
+
+		VerificationRequired: urg.IntakeStatus == urgencyV1.IntakePendingVerification,
+	}
+	if urg.EtaUpdatedAt != nil {
+		resp.EtaUpdatedAt = urg.EtaUpdatedAt.UTC().Format(time.RFC3339)
+	}
+	return resp, nil
+}
+
+// AddFollowUp stores information the reporter sends after the initial report. It is only accepted
+// while responders are still working on the urgency.
+func (s *urgencyService) AddFollowUp(ctx context.Context, token string, req urgencyV1.UrgencyFollowUpRequest) (*urgencyV1.UrgencyFollowUpResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "UrgencyService.AddFollowUp")()
+
+	if err := req.Validate(); err != nil {
+		return nil, commonv1.NewAppError("VALIDATION.INVALID_REQUEST", err.Error(), nil)
+	}
+	urg, err := s.trackedUrgency(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if urg.Status != urgencyV1.Open && urg.Status != urgencyV1.InProgress {
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.INVALID_TRANSITION", "follow-ups are only accepted while the urgency is open or in progress", map[string]interface{}{"status": urg.Status})
+	}
+
+	followUp := &model.ReporterFollowUp{UrgencyID: urg.ID, Location: req.Location, Description: req.Description}
+	if err := s.repo.CreateFollowUp(ctx, followUp); err != nil {
+		log.Errorf("Failed to store reporter follow-up for urgency %d: %v", urg.ID, err)
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.UPDATE_FAILED", "failed to store follow-up", map[string]interface{}{"cause": err.Error()})
+	}
+	log.Infof("Reporter added follow-up %d to urgency %d", followUp.ID, urg.ID)
+	resp := followUp.ToResponse()
+	return &resp, nil
+}
+
+// trackedUrgency resolves a tracking token to the urgency it follows, which is the original urgency
+// when the report was linked as a duplicate
+func (s *urgencyService) trackedUrgency(ctx context.Context, token string) (*model.Urgency, error) {
+	urg, err := s.urgencyByTrackingToken(ctx, token)
+	if err != nil || urg.DuplicateOfID == nil {
+		return urg, err
+	}
+	original, err := s.GetUrgencyByID(ctx, *urg.DuplicateOfID)
+	if err != nil {
+		s.log.WithContext(ctx).Warnf("Failed to load urgency %d that report %d duplicates, tracking the report itself: %v", *urg.DuplicateOfID, urg.ID, err)
+		return urg, nil
+	}
+	return original, nil
+}
+
+// urgencyByTrackingToken resolves a tracking token to the reporter's own urgency. Unknown tokens and
+// deleted urgencies look the same so the endpoints do not reveal which tokens ever existed.
+func (s *urgencyService) urgencyByTrackingToken(ctx context.Context, token string) (*model.Urgency, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.NOT_FOUND", "urgency not found", nil)
+	}
+	var urg model.Urgency
+	if err := s.repo.GetByTrackingTokenHash(ctx, model.HashTrackingToken(token), &urg); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, commonv1.NewAppError("URGENCY_ERRORS.NOT_FOUND", "urgency not found", nil)
+		}
+		s.log.WithContext(ctx).Errorf("DB error fetching urgency by tracking token: %v", err)
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.DB_ERROR", "failed to fetch urgency", map[string]interface{}{"cause": err.Error()})
+	}
+	return &urg, nil
+}
+
+func followUpDescription(f model.ReporterFollowUp) string {
+	switch {
+	case f.Location != "" && f.Description != "":
+		return f.Description + " (location: " + f.Location + ")"
+	case f.Location != "":
+		return "location: " + f.Location
+	default:
+		return f.Description
+	}
+}
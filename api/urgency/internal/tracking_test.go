@@ -0,0 +1,145 @@
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+
+	employeeV1 "github.com/pd120424d/mountain-service/api/contracts/employee/v1"
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/clients"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/repositories"
+)
+
+func expectTrackedUrgency(repo *repositories.MockUrgencyRepository, token string, urg model.Urgency) {
+	repo.EXPECT().GetByTrackingTokenHash(gomock.Any(), model.HashTrackingToken(token), gomock.Any()).DoAndReturn(func(_ context.Context, _ string, u *model.Urgency) error {
+		*u = urg
+		return nil
+	})
+}
+
+func TestUrgencyService_CreateUrgency_TrackingToken(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	repo := repositories.NewMockUrgencyRepository(ctrl)
+	emp := clients.NewMockEmployeeClient(ctrl)
+	repo.EXPECT().ListRecentActive(gomock.Any(), gomock.Any()).Return(nil, nil)
+	repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u *model.Urgency) error {
+		require.NotEmpty(t, u.TrackingToken)
+		assert.Equal(t, model.HashTrackingToken(u.TrackingToken), u.TrackingTokenHash)
+		return nil
+	})
+	emp.EXPECT().GetOnCallEmployees(gomock.Any(), gomock.Any()).Return([]employeeV1.EmployeeResponse{}, nil)
+	svc := NewUrgencyService(utils.NewTestLogger(), repo, repositories.NewMockNotificationRepository(ctrl), emp, nil)
+
+	urg := &model.Urgency{}
+	require.NoError(t, svc.CreateUrgency(context.Background(), urg))
+	assert.NotEmpty(t, urg.ToResponse().TrackingToken)
+}
+
+func TestUrgencyService_GetTrackingStatus(t *testing.T) {
+	t.Parallel()
+	created := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	eta := created.Add(30 * time.Minute)
+
+	t.Run("it shows status, team size and eta without responder details", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		lead := uint(4)
+		expectTrackedUrgency(repo, "tok", model.Urgency{ID: 1, Status: urgencyV1.InProgress, Level: urgencyV1.High, AssignedEmployeeID: &lead, EtaNote: "arrival in ~40 min", EtaUpdatedAt: &eta, Model: gorm.Model{CreatedAt: created, UpdatedAt: eta}})
+		repo.EXPECT().ListTeam(gomock.Any(), uint(1)).Return([]model.UrgencyAssignment{{EmployeeID: 4, Role: urgencyV1.RoleLead}, {EmployeeID: 5, Role: urgencyV1.RoleMedic}}, nil)
+		repo.EXPECT().ListFollowUps(gomock.Any(), uint(1)).Return([]model.ReporterFollowUp{{UrgencyID: 1}}, nil)
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, nil, nil, nil)
+
+		status, err := svc.GetTrackingStatus(context.Background(), " tok ")
+		require.NoError(t, err)
+		assert.Equal(t, urgencyV1.UrgencyTrackingResponse{
+			Status:       urgencyV1.InProgress,
+			Level:        urgencyV1.High,
+			TeamSize:     2,
+			EtaNote:      "arrival in ~40 min",
+			EtaUpdatedAt: "2025-01-01T10:30:00Z",
+			FollowUps:    1,
+			CreatedAt:    "2025-01-01T10:00:00Z",
+			UpdatedAt:    "2025-01-01T10:30:00Z",
+		}, *status)
+	})
+
+	t.Run("it follows a duplicate report to the urgency being worked on", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		original := uint(7)
+		expectTrackedUrgency(repo, "tok", model.Urgency{ID: 9, Status: urgencyV1.Open, DuplicateOfID: &original})
+		repo.EXPECT().GetByID(gomock.Any(), uint(7), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, u *model.Urgency) error {
+			*u = model.Urgency{ID: id, Status: urgencyV1.InProgress}
+			return nil
+		})
+		repo.EXPECT().ListTeam(gomock.Any(), uint(7)).Return(nil, nil)
+		repo.EXPECT().ListFollowUps(gomock.Any(), uint(7)).Return(nil, nil)
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, nil, nil, nil)
+
+		status, err := svc.GetTrackingStatus(context.Background(), "tok")
+		require.NoError(t, err)
+		assert.Equal(t, urgencyV1.InProgress, status.Status)
+	})
+
+	t.Run("it returns not found for unknown and empty tokens", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		repo.EXPECT().GetByTrackingTokenHash(gomock.Any(), gomock.Any(), gomock.Any()).Return(gorm.ErrRecordNotFound)
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, nil, nil, nil)
+
+		_, err := svc.GetTrackingStatus(context.Background(), "guess")
+		assertAppErrorCode(t, err, "URGENCY_ERRORS.NOT_FOUND")
+		_, err = svc.GetTrackingStatus(context.Background(), "  ")
+		assertAppErrorCode(t, err, "URGENCY_ERRORS.NOT_FOUND")
+	})
+}
+
+func TestUrgencyService_AddFollowUp(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it stores the follow-up on the tracked urgency", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		expectTrackedUrgency(repo, "tok", model.Urgency{ID: 1, Status: urgencyV1.Open})
+		repo.EXPECT().CreateFollowUp(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, f *model.ReporterFollowUp) error {
+			assert.Equal(t, uint(1), f.UrgencyID)
+			assert.Equal(t, "N 43.401123 E 22.662756", f.Location)
+			assert.Equal(t, "we moved below the ridge", f.Description)
+			f.ID = 3
+			return nil
+		})
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, nil, nil, nil)
+
+		resp, err := svc.AddFollowUp(context.Background(), "tok", urgencyV1.UrgencyFollowUpRequest{Location: "N 43.401123 E 22.662756", Description: " we moved below the ridge "})
+		require.NoError(t, err)
+		assert.Equal(t, uint(3), resp.ID)
+	})
+
+	t.Run("it rejects follow-ups once the urgency is finished", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositories.NewMockUrgencyRepository(ctrl)
+		expectTrackedUrgency(repo, "tok", model.Urgency{ID: 1, Status: urgencyV1.Resolved})
+		svc := NewUrgencyService(utils.NewTestLogger(), repo, nil, nil, nil)
+
+		_, err := svc.AddFollowUp(context.Background(), "tok", urgencyV1.UrgencyFollowUpRequest{Description: "still here"})
+		assertAppErrorCode(t, err, "URGENCY_ERRORS.INVALID_TRANSITION")
+	})
+
+	t.Run("it rejects an empty follow-up before looking up the token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		svc := NewUrgencyService(utils.NewTestLogger(), repositories.NewMockUrgencyRepository(ctrl), nil, nil, nil)
+
+		_, err := svc.AddFollowUp(context.Background(), "tok", urgencyV1.UrgencyFollowUpRequest{})
+		assertAppErrorCode(t, err, "VALIDATION.INVALID_REQUEST")
+	})
+}
@@ -0,0 +1,299 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	"github.com/pd120424d/mountain-service/api/shared/models"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/repositories"
+	"gorm.io/gorm"
+)
+
+// Config controls how often due deliveries are polled and how failed deliveries are retried.
+type Config struct {
+	Interval    time.Duration
+	BatchSize   int
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// ClaimLease is how long a claimed delivery stays hidden from other dispatchers; it must outlast a send
+	ClaimLease time.Duration
+	// Timeout bounds a single HTTP request to a subscriber
+	Timeout time.Duration
+}
+
+// Dispatcher fans urgency outbox events out to the matching webhook subscriptions and delivers them.
+// It is registered as a sink of the urgency outbox publisher, which hands it new events; the
+// deliveries themselves are made by its own polling loop so that retries do not hold up the outbox.
+type Dispatcher struct {
+	log    utils.Logger
+	repo   repositories.WebhookRepository
+	client *http.Client
+	config Config
+	now    func() time.Time
+}
+
+func NewDispatcher(log utils.Logger, repo repositories.WebhookRepository, cfg Config) *Dispatcher {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 5 * time.Second
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 50
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 8
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = 30 * time.Second
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = time.Hour
+	}
+	if cfg.MaxBackoff < cfg.BaseBackoff {
+		cfg.MaxBackoff = cfg.BaseBackoff
+	}
+	if cfg.ClaimLease <= 0 {
+		cfg.ClaimLease = 2 * time.Minute
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	return &Dispatcher{
+		log:    log.WithName("webhookDispatcher"),
+		repo:   repo,
+		client: &http.Client{Timeout: cfg.Timeout},
+		config: cfg,
+		now:    time.Now,
+	}
+}
+
+// Start runs the polling loop in a background goroutine until ctx is cancelled.
+func (d *Dispatcher) Start(ctx context.Context) {
+	ctx, _ = utils.EnsureRequestID(ctx)
+	d.log.Infof("Starting webhook dispatcher: interval=%s batch=%d maxAttempts=%d baseBackoff=%s",
+		d.config.Interval, d.config.BatchSize, d.config.MaxAttempts, d.config.BaseBackoff)
+
+	go func() {
+		ticker := time.NewTicker(d.config.Interval)
+		defer ticker.Stop()
+		for {
+			if _, err := d.ProcessOnce(ctx); err != nil {
+				d.log.WithContext(ctx).Errorf("webhook dispatch cycle error: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+				d.log.WithContext(ctx).Info("Stopping webhook dispatcher")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// ProcessOnce attempts the deliveries that are due and returns the number of successful deliveries.
+func (d *Dispatcher) ProcessOnce(ctx context.Context) (int, error) {
+	log := d.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "WebhookDispatcher.ProcessOnce")()
+
+	return d.deliverDue(ctx)
+}
+
+// Publish implements outbox.Sink: it turns each outbox event into one delivery per subscription
+// that wants it. Enqueueing is idempotent, so events handed over again are not delivered twice.
+func (d *Dispatcher) Publish(ctx context.Context, events []*models.OutboxEvent) []error {
+	log := d.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "WebhookDispatcher.Publish")()
+
+	errs := make([]error, len(events))
+	subs, err := d.repo.ListActiveSubscriptions(ctx)
+	if err != nil {
+		for i := range errs {
+			errs[i] = fmt.Errorf("list active subscriptions: %w", err)
+		}
+		return errs
+	}
+
+	for i, event := range events {
+		deliveries := d.deliveriesFor(ctx, event, subs)
+		if err := d.repo.EnqueueDeliveries(ctx, deliveries); err != nil {
+			errs[i] = fmt.Errorf("enqueue deliveries for event %d: %w", event.ID, err)
+			continue
+		}
+		if len(deliveries) > 0 {
+			log.Infof("Outbox event %d fanned out to %d webhook subscriptions", event.ID, len(deliveries))
+		}
+	}
+	return errs
+}
+
+func (d *Dispatcher) deliveriesFor(ctx context.Context, outbox *models.OutboxEvent, subs []model.WebhookSubscription) []model.WebhookDelivery {
+	log := d.log.WithContext(ctx)
+
+	var event urgencyV1.UrgencyEvent
+	if err := json.Unmarshal([]byte(outbox.EventData), &event); err != nil {
+		// a malformed row would otherwise block the outbox forever
+		log.Errorf("Skipping malformed outbox event %d: %v", outbox.ID, err)
+		return nil
+	}
+	event.ID = outbox.ID
+	// the title is the reporter's name, which stays inside the platform
+	event.Title = ""
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Errorf("Skipping outbox event %d: %v", outbox.ID, err)
+		return nil
+	}
+
+	var deliveries []model.WebhookDelivery
+	for i := range subs {
+		if !subs[i].Subscribes(event.Type) {
+			continue
+		}
+		deliveries = append(deliveries, model.WebhookDelivery{
+			SubscriptionID: subs[i].ID,
+			EventID:        outbox.ID,
+			EventType:      event.Type,
+			Payload:        string(payload),
+			Status:         model.WebhookDeliveryPending,
+		})
+	}
+	return deliveries
+}
+
+func (d *Dispatcher) deliverDue(ctx context.Context) (int, error) {
+	log := d.log.WithContext(ctx)
+
+	deliveries, err := d.repo.ClaimDueDeliveries(ctx, d.now().UTC(), d.config.ClaimLease, d.config.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("claim due deliveries: %w", err)
+	}
+	if len(deliveries) == 0 {
+		return 0, nil
+	}
+
+	subs := make(map[uint]*model.WebhookSubscription)
+	sent := 0
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		sub, err := d.subscription(ctx, subs, delivery.SubscriptionID)
+		if err != nil {
+			log.Errorf("Failed to load subscription %d for delivery %d: %v", delivery.SubscriptionID, delivery.ID, err)
+			continue
+		}
+		if d.deliver(ctx, sub, delivery) {
+			sent++
+		}
+	}
+
+	log.Infof("Delivered %d/%d due webhook deliveries", sent, len(deliveries))
+	return sent, nil
+}
+
+// subscription loads a subscription once per cycle; a deleted subscription is returned as nil
+func (d *Dispatcher) subscription(ctx context.Context, cache map[uint]*model.WebhookSubscription, id uint) (*model.WebhookSubscription, error) {
+	if sub, ok := cache[id]; ok {
+		return sub, nil
+	}
+	var sub model.WebhookSubscription
+	if err := d.repo.GetSubscription(ctx, id, &sub); err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		cache[id] = nil
+		return nil, nil
+	}
+	cache[id] = &sub
+	return &sub, nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, sub *model.WebhookSubscription, delivery *model.WebhookDelivery) bool {
+	log := d.log.WithContext(ctx)
+
+	now := d.now().UTC()
+	if sub == nil || !sub.Active {
+		delivery.Status = model.WebhookDeliveryFailed
+		delivery.Error = "subscription was removed or deactivated"
+		delivery.NextAttemptAt = nil
+		if err := d.repo.UpdateDelivery(ctx, delivery); err != nil {
+			log.Errorf("Failed to update webhook delivery %d: %v", delivery.ID, err)
+		}
+		return false
+	}
+
+	status, sendErr := d.send(ctx, sub, delivery, now)
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.ResponseStatus = status
+
+	switch {
+	case sendErr == nil:
+		delivery.Status = model.WebhookDeliveryDelivered
+		delivery.DeliveredAt = &now
+		delivery.NextAttemptAt = nil
+		delivery.Error = ""
+	case delivery.Attempts >= d.config.MaxAttempts:
+		log.Warnf("Webhook delivery %d to subscription %d failed permanently after %d attempts: %v", delivery.ID, sub.ID, delivery.Attempts, sendErr)
+		delivery.Status = model.WebhookDeliveryFailed
+		delivery.NextAttemptAt = nil
+		delivery.Error = sendErr.Error()
+	default:
+		next := now.Add(d.backoff(delivery.Attempts))
+		log.Warnf("Webhook delivery %d attempt %d/%d failed, retrying at %s: %v", delivery.ID, delivery.Attempts, d.config.MaxAttempts, next.Format(time.RFC3339), sendErr)
+		delivery.NextAttemptAt = &next
+		delivery.Error = sendErr.Error()
+	}
+
+	if err := d.repo.UpdateDelivery(ctx, delivery); err != nil {
+		log.Errorf("Failed to update webhook delivery %d: %v", delivery.ID, err)
+		return false
+	}
+	return sendErr == nil
+}
+
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.config.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= d.config.MaxBackoff {
+			return d.config.MaxBackoff
+		}
+	}
+	return delay
+}
+
+// send posts the signed payload and returns the response status; any non-2xx answer is an error
+func (d *Dispatcher) send(ctx context.Context, sub *model.WebhookSubscription, delivery *model.WebhookDelivery, now time.Time) (int, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("build request: %w", err)
+	}
+	timestamp := now.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, string(delivery.EventType))
+	req.Header.Set(HeaderDelivery, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(sub.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("subscriber responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
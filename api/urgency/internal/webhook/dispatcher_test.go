@@ -0,0 +1,217 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	"github.com/pd120424d/mountain-service/api/shared/models"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func newTestDispatcher(t *testing.T, cfg Config) (*Dispatcher, *repositories.MockWebhookRepository, time.Time) {
+	ctrl := gomock.NewController(t)
+	repo := repositories.NewMockWebhookRepository(ctrl)
+	d := NewDispatcher(utils.NewTestLogger(), repo, cfg)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	d.now = func() time.Time { return now }
+	return d, repo, now
+}
+
+func TestNewDispatcher(t *testing.T) {
+	t.Run("it applies defaults for zero config values", func(t *testing.T) {
+		d := NewDispatcher(utils.NewTestLogger(), nil, Config{})
+		assert.Equal(t, 5*time.Second, d.config.Interval)
+		assert.Equal(t, 50, d.config.BatchSize)
+		assert.Equal(t, 8, d.config.MaxAttempts)
+		assert.Equal(t, 30*time.Second, d.config.BaseBackoff)
+		assert.Equal(t, time.Hour, d.config.MaxBackoff)
+		assert.Equal(t, 2*time.Minute, d.config.ClaimLease)
+		assert.Equal(t, 10*time.Second, d.client.Timeout)
+	})
+}
+
+func TestDispatcher_Publish(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it creates a delivery for every active subscription of the event type", func(t *testing.T) {
+		d, repo, _ := newTestDispatcher(t, Config{BatchSize: 10})
+		data, _ := json.Marshal(urgencyV1.UrgencyEvent{Type: urgencyV1.EventUrgencyCreated, UrgencyID: 7, Title: "Marko Markovic"})
+		repo.EXPECT().ListActiveSubscriptions(ctx).Return([]model.WebhookSubscription{
+			{ID: 1, EventTypes: "urgency.created,urgency.closed", Active: true},
+			{ID: 2, EventTypes: "urgency.closed", Active: true},
+		}, nil)
+		repo.EXPECT().EnqueueDeliveries(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, deliveries []model.WebhookDelivery) error {
+			require.Len(t, deliveries, 1)
+			assert.Equal(t, uint(1), deliveries[0].SubscriptionID)
+			assert.Equal(t, uint(3), deliveries[0].EventID)
+			assert.Equal(t, urgencyV1.EventUrgencyCreated, deliveries[0].EventType)
+			var payload urgencyV1.UrgencyEvent
+			require.NoError(t, json.Unmarshal([]byte(deliveries[0].Payload), &payload))
+			assert.Equal(t, uint(3), payload.ID)
+			assert.Equal(t, uint(7), payload.UrgencyID)
+			assert.Empty(t, payload.Title)
+			return nil
+		})
+
+		errs := d.Publish(ctx, []*models.OutboxEvent{{ID: 3, AggregateID: "urgency-7", EventData: string(data)}})
+		assert.Equal(t, []error{nil}, errs)
+	})
+
+	t.Run("it accepts malformed events without deliveries", func(t *testing.T) {
+		d, repo, _ := newTestDispatcher(t, Config{BatchSize: 10})
+		repo.EXPECT().ListActiveSubscriptions(ctx).Return([]model.WebhookSubscription{{ID: 1, EventTypes: "urgency.created", Active: true}}, nil)
+		repo.EXPECT().EnqueueDeliveries(ctx, gomock.Len(0)).Return(nil)
+
+		errs := d.Publish(ctx, []*models.OutboxEvent{{ID: 4, EventData: "not json"}})
+		assert.Equal(t, []error{nil}, errs)
+	})
+
+	t.Run("it reports the events whose deliveries cannot be enqueued", func(t *testing.T) {
+		d, repo, _ := newTestDispatcher(t, Config{BatchSize: 10})
+		repo.EXPECT().ListActiveSubscriptions(ctx).Return(nil, nil)
+		repo.EXPECT().EnqueueDeliveries(ctx, gomock.Any()).Return(assert.AnError)
+		repo.EXPECT().EnqueueDeliveries(ctx, gomock.Any()).Return(nil)
+
+		errs := d.Publish(ctx, []*models.OutboxEvent{{ID: 5, EventData: "{}"}, {ID: 6, EventData: "{}"}})
+		require.Len(t, errs, 2)
+		assert.ErrorIs(t, errs[0], assert.AnError)
+		assert.NoError(t, errs[1])
+	})
+
+	t.Run("it fails every event when subscriptions cannot be listed", func(t *testing.T) {
+		d, repo, _ := newTestDispatcher(t, Config{BatchSize: 10})
+		repo.EXPECT().ListActiveSubscriptions(ctx).Return(nil, assert.AnError)
+
+		errs := d.Publish(ctx, []*models.OutboxEvent{{ID: 5}, {ID: 6}})
+		require.Len(t, errs, 2)
+		assert.ErrorIs(t, errs[0], assert.AnError)
+		assert.ErrorIs(t, errs[1], assert.AnError)
+	})
+}
+
+func TestDispatcher_Deliver(t *testing.T) {
+	ctx := context.Background()
+	const secret = "0123456789abcdef"
+
+	t.Run("it posts a signed payload and marks the delivery as delivered", func(t *testing.T) {
+		var received *http.Request
+		var body []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		d, repo, now := newTestDispatcher(t, Config{BatchSize: 10})
+		repo.EXPECT().ClaimDueDeliveries(ctx, now, 2*time.Minute, 10).Return([]model.WebhookDelivery{
+			{ID: 9, SubscriptionID: 1, EventType: urgencyV1.EventUrgencyClosed, Payload: `{"type":"urgency.closed"}`, Status: model.WebhookDeliveryPending},
+		}, nil)
+		repo.EXPECT().GetSubscription(ctx, uint(1), gomock.Any()).SetArg(2, model.WebhookSubscription{ID: 1, URL: server.URL, Secret: secret, Active: true}).Return(nil)
+		repo.EXPECT().UpdateDelivery(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, delivery *model.WebhookDelivery) error {
+			assert.Equal(t, model.WebhookDeliveryDelivered, delivery.Status)
+			assert.Equal(t, 1, delivery.Attempts)
+			assert.Equal(t, http.StatusNoContent, delivery.ResponseStatus)
+			assert.Equal(t, now, *delivery.DeliveredAt)
+			return nil
+		})
+
+		sent, err := d.ProcessOnce(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+
+		require.NotNil(t, received)
+		assert.Equal(t, "urgency.closed", received.Header.Get(HeaderEvent))
+		assert.Equal(t, "9", received.Header.Get(HeaderDelivery))
+		timestamp, err := strconv.ParseInt(received.Header.Get(HeaderTimestamp), 10, 64)
+		require.NoError(t, err)
+		assert.Equal(t, now.Unix(), timestamp)
+		assert.True(t, Verify(secret, timestamp, body, received.Header.Get(HeaderSignature)))
+	})
+
+	t.Run("it schedules a retry with backoff when the subscriber fails", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		d, repo, now := newTestDispatcher(t, Config{BatchSize: 10, MaxAttempts: 5, BaseBackoff: time.Minute})
+		repo.EXPECT().ClaimDueDeliveries(ctx, now, 2*time.Minute, 10).Return([]model.WebhookDelivery{
+			{ID: 9, SubscriptionID: 1, Payload: "{}", Status: model.WebhookDeliveryPending, Attempts: 2},
+		}, nil)
+		repo.EXPECT().GetSubscription(ctx, uint(1), gomock.Any()).SetArg(2, model.WebhookSubscription{ID: 1, URL: server.URL, Secret: secret, Active: true}).Return(nil)
+		repo.EXPECT().UpdateDelivery(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, delivery *model.WebhookDelivery) error {
+			assert.Equal(t, model.WebhookDeliveryPending, delivery.Status)
+			assert.Equal(t, 3, delivery.Attempts)
+			assert.Equal(t, http.StatusBadGateway, delivery.ResponseStatus)
+			assert.Equal(t, now.Add(4*time.Minute), *delivery.NextAttemptAt)
+			assert.Contains(t, delivery.Error, "502")
+			return nil
+		})
+
+		sent, err := d.ProcessOnce(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, sent)
+	})
+
+	t.Run("it gives up after the last attempt", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		d, repo, now := newTestDispatcher(t, Config{BatchSize: 10, MaxAttempts: 3})
+		repo.EXPECT().ClaimDueDeliveries(ctx, now, 2*time.Minute, 10).Return([]model.WebhookDelivery{
+			{ID: 9, SubscriptionID: 1, Payload: "{}", Status: model.WebhookDeliveryPending, Attempts: 2},
+		}, nil)
+		repo.EXPECT().GetSubscription(ctx, uint(1), gomock.Any()).SetArg(2, model.WebhookSubscription{ID: 1, URL: server.URL, Secret: secret, Active: true}).Return(nil)
+		repo.EXPECT().UpdateDelivery(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, delivery *model.WebhookDelivery) error {
+			assert.Equal(t, model.WebhookDeliveryFailed, delivery.Status)
+			assert.Nil(t, delivery.NextAttemptAt)
+			return nil
+		})
+
+		_, err := d.ProcessOnce(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("it fails deliveries of removed subscriptions without sending them", func(t *testing.T) {
+		d, repo, now := newTestDispatcher(t, Config{BatchSize: 10})
+		repo.EXPECT().ClaimDueDeliveries(ctx, now, 2*time.Minute, 10).Return([]model.WebhookDelivery{
+			{ID: 9, SubscriptionID: 1, Payload: "{}", Status: model.WebhookDeliveryPending},
+			{ID: 10, SubscriptionID: 1, Payload: "{}", Status: model.WebhookDeliveryPending},
+		}, nil)
+		// the subscription is looked up once per cycle
+		repo.EXPECT().GetSubscription(ctx, uint(1), gomock.Any()).Return(gorm.ErrRecordNotFound)
+		repo.EXPECT().UpdateDelivery(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, delivery *model.WebhookDelivery) error {
+			assert.Equal(t, model.WebhookDeliveryFailed, delivery.Status)
+			assert.Equal(t, 0, delivery.Attempts)
+			return nil
+		}).Times(2)
+
+		sent, err := d.ProcessOnce(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, sent)
+	})
+}
+
+func TestDispatcher_backoff(t *testing.T) {
+	d := NewDispatcher(utils.NewTestLogger(), nil, Config{BaseBackoff: time.Minute, MaxBackoff: 5 * time.Minute})
+	assert.Equal(t, time.Minute, d.backoff(1))
+	assert.Equal(t, 2*time.Minute, d.backoff(2))
+	assert.Equal(t, 4*time.Minute, d.backoff(3))
+	assert.Equal(t, 5*time.Minute, d.backoff(4))
+}
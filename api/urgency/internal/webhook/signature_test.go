@@ -0,0 +1,26 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"type":"urgency.created"}`)
+
+	t.Run("it produces a stable sha256 signature", func(t *testing.T) {
+		sig := Sign("secret", 1700000000, body)
+		assert.Equal(t, sig, Sign("secret", 1700000000, body))
+		assert.Contains(t, sig, "sha256=")
+		assert.Len(t, sig, len("sha256=")+64)
+	})
+
+	t.Run("it depends on the secret, timestamp and body", func(t *testing.T) {
+		sig := Sign("secret", 1700000000, body)
+		assert.True(t, Verify("secret", 1700000000, body, sig))
+		assert.False(t, Verify("other", 1700000000, body, sig))
+		assert.False(t, Verify("secret", 1700000001, body, sig))
+		assert.False(t, Verify("secret", 1700000000, []byte(`{}`), sig))
+	})
+}
@@ -0,0 +1,231 @@
+package internal
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	"github.com/pd120424d/mountain-service/api/shared/config"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+type WebhookHandler interface {
+	CreateSubscription(ctx *gin.Context)
+	ListSubscriptions(ctx *gin.Context)
+	GetSubscription(ctx *gin.Context)
+	UpdateSubscription(ctx *gin.Context)
+	DeleteSubscription(ctx *gin.Context)
+	ListDeliveries(ctx *gin.Context)
+}
+
+type webhookHandler struct {
+	log utils.Logger
+	svc WebhookService
+}
+
+func NewWebhookHandler(log utils.Logger, svc WebhookService) WebhookHandler {
+	return &webhookHandler{log: log.WithName("webhookHandler"), svc: svc}
+}
+
+// CreateSubscription Креирање webhook претплате
+// @Summary Креирање webhook претплате
+// @Description Спољни систем добија потписане догађаје о ургентним ситуацијама на задати URL (само за администраторе)
+// @Tags webhooks
+// @Security OAuth2Password
+// @Accept  json
+// @Produce  json
+// @Param subscription body urgencyV1.WebhookSubscriptionRequest true "Subscription data"
+// @Success 201 {object} urgencyV1.WebhookSubscriptionResponse
+// @Failure 400 {object} map[string]interface{}
+// @Router /admin/webhooks [post]
+func (h *webhookHandler) CreateSubscription(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "WebhookHandler.CreateSubscription")()
+	log.Info("Received Create Webhook Subscription request")
+
+	var req urgencyV1.WebhookSubscriptionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorf("invalid webhook subscription payload: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sub, err := h.svc.CreateSubscription(requestContext(ctx), req)
+	if err != nil {
+		log.Errorf("create webhook subscription failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+
+	utils.WriteFreshWindow(ctx, config.DefaultFreshWindow)
+	ctx.JSON(http.StatusCreated, sub)
+}
+
+// ListSubscriptions Листа webhook претплата
+// @Summary Листа webhook претплата
+// @Description Све webhook претплате, без тајних кључева (само за администраторе)
+// @Tags webhooks
+// @Security OAuth2Password
+// @Produce  json
+// @Success 200 {object} urgencyV1.WebhookSubscriptionListResponse
+// @Router /admin/webhooks [get]
+func (h *webhookHandler) ListSubscriptions(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "WebhookHandler.ListSubscriptions")()
+	log.Info("Received List Webhook Subscriptions request")
+
+	subs, err := h.svc.ListSubscriptions(requestContext(ctx))
+	if err != nil {
+		log.Errorf("list webhook subscriptions failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, urgencyV1.WebhookSubscriptionListResponse{Subscriptions: subs})
+}
+
+// GetSubscription Преузимање webhook претплате
+// @Summary Преузимање webhook претплате
+// @Description Преузимање webhook претплате по ID-ју (само за администраторе)
+// @Tags webhooks
+// @Security OAuth2Password
+// @Produce  json
+// @Param id path int true "Subscription ID"
+// @Success 200 {object} urgencyV1.WebhookSubscriptionResponse
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/webhooks/{id} [get]
+func (h *webhookHandler) GetSubscription(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "WebhookHandler.GetSubscription")()
+	log.Info("Received Get Webhook Subscription request")
+
+	id, ok := h.subscriptionID(ctx)
+	if !ok {
+		return
+	}
+	sub, err := h.svc.GetSubscription(requestContext(ctx), id)
+	if err != nil {
+		log.Errorf("get webhook subscription failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, sub)
+}
+
+// UpdateSubscription Измена webhook претплате
+// @Summary Измена webhook претплате
+// @Description Замена URL-а, тајног кључа и типова догађаја; претплата се може и искључити (само за администраторе)
+// @Tags webhooks
+// @Security OAuth2Password
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Subscription ID"
+// @Param subscription body urgencyV1.WebhookSubscriptionRequest true "Subscription data"
+// @Success 200 {object} urgencyV1.WebhookSubscriptionResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/webhooks/{id} [put]
+func (h *webhookHandler) UpdateSubscription(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "WebhookHandler.UpdateSubscription")()
+	log.Info("Received Update Webhook Subscription request")
+
+	id, ok := h.subscriptionID(ctx)
+	if !ok {
+		return
+	}
+	var req urgencyV1.WebhookSubscriptionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorf("invalid webhook subscription payload: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sub, err := h.svc.UpdateSubscription(requestContext(ctx), id, req)
+	if err != nil {
+		log.Errorf("update webhook subscription failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+
+	utils.WriteFreshWindow(ctx, config.DefaultFreshWindow)
+	ctx.JSON(http.StatusOK, sub)
+}
+
+// DeleteSubscription Брисање webhook претплате
+// @Summary Брисање webhook претплате
+// @Description Брисање претплате и њених неиспоручених догађаја; историја испорука остаје сачувана (само за администраторе)
+// @Tags webhooks
+// @Security OAuth2Password
+// @Param id path int true "Subscription ID"
+// @Success 204
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/webhooks/{id} [delete]
+func (h *webhookHandler) DeleteSubscription(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "WebhookHandler.DeleteSubscription")()
+	log.Info("Received Delete Webhook Subscription request")
+
+	id, ok := h.subscriptionID(ctx)
+	if !ok {
+		return
+	}
+	if err := h.svc.DeleteSubscription(requestContext(ctx), id); err != nil {
+		log.Errorf("delete webhook subscription failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+
+	utils.WriteFreshWindow(ctx, config.DefaultFreshWindow)
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// ListDeliveries Историја испорука webhook претплате
+// @Summary Историја испорука webhook претплате
+// @Description Последње испоруке са статусом, бројем покушаја и одговором примаоца (само за администраторе)
+// @Tags webhooks
+// @Security OAuth2Password
+// @Produce  json
+// @Param id path int true "Subscription ID"
+// @Param limit query int false "Maximum number of deliveries (default and max 200)"
+// @Success 200 {object} urgencyV1.WebhookDeliveryListResponse
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/webhooks/{id}/deliveries [get]
+func (h *webhookHandler) ListDeliveries(ctx *gin.Context) {
+	log := h.log.WithContext(requestContext(ctx))
+	defer utils.TimeOperation(log, "WebhookHandler.ListDeliveries")()
+	log.Info("Received List Webhook Deliveries request")
+
+	id, ok := h.subscriptionID(ctx)
+	if !ok {
+		return
+	}
+	limit := 0
+	if raw := ctx.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive number"})
+			return
+		}
+		limit = parsed
+	}
+
+	deliveries, err := h.svc.ListDeliveries(requestContext(ctx), id, limit)
+	if err != nil {
+		log.Errorf("list webhook deliveries failed: %v", err)
+		writeAppError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, urgencyV1.WebhookDeliveryListResponse{Deliveries: deliveries})
+}
+
+func (h *webhookHandler) subscriptionID(ctx *gin.Context) (uint, bool) {
+	id64, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil || id64 == 0 {
+		h.log.WithContext(requestContext(ctx)).Errorf("invalid webhook subscription ID: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid webhook subscription ID"})
+		return 0, false
+	}
+	return uint(id64), true
+}
@@ -0,0 +1,117 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+)
+
+func TestWebhookHandler(t *testing.T) {
+	t.Parallel()
+	log := utils.NewTestLogger()
+
+	newCtx := func(method, id, body string) (*gin.Context, *httptest.ResponseRecorder) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		if id != "" {
+			ctx.Params = []gin.Param{{Key: "id", Value: id}}
+		}
+		ctx.Request = httptest.NewRequest(method, "/admin/webhooks", strings.NewReader(body))
+		ctx.Request.Header.Set("Content-Type", "application/json")
+		return ctx, w
+	}
+
+	t.Run("it creates a subscription", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx(http.MethodPost, "", `{"url":"https://partner.example.com","secret":"0123456789abcdef","eventTypes":["urgency.created"]}`)
+		svc := NewMockWebhookService(ctrl)
+		svc.EXPECT().CreateSubscription(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, req urgencyV1.WebhookSubscriptionRequest) (*urgencyV1.WebhookSubscriptionResponse, error) {
+			assert.Equal(t, []urgencyV1.UrgencyEventType{urgencyV1.EventUrgencyCreated}, req.EventTypes)
+			return &urgencyV1.WebhookSubscriptionResponse{ID: 1, URL: req.URL, EventTypes: req.EventTypes, Active: true}, nil
+		})
+		NewWebhookHandler(log, svc).CreateSubscription(ctx)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.NotContains(t, w.Body.String(), "secret")
+	})
+
+	t.Run("it returns 400 for a malformed payload or validation error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx(http.MethodPost, "", `{"url":`)
+		NewWebhookHandler(log, NewMockWebhookService(ctrl)).CreateSubscription(ctx)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		ctx, w = newCtx(http.MethodPost, "", `{"url":"https://x","secret":"s","eventTypes":["urgency.created"]}`)
+		svc := NewMockWebhookService(ctrl)
+		svc.EXPECT().CreateSubscription(gomock.Any(), gomock.Any()).Return(nil, commonv1.NewAppError("VALIDATION.INVALID_REQUEST", "secret too short", nil))
+		NewWebhookHandler(log, svc).CreateSubscription(ctx)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("it lists subscriptions", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx(http.MethodGet, "", "")
+		svc := NewMockWebhookService(ctrl)
+		svc.EXPECT().ListSubscriptions(gomock.Any()).Return([]urgencyV1.WebhookSubscriptionResponse{}, nil)
+		NewWebhookHandler(log, svc).ListSubscriptions(ctx)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"subscriptions":[]}`, w.Body.String())
+	})
+
+	t.Run("it maps a missing subscription to 404", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx, w := newCtx(http.MethodGet, "4", "")
+		svc := NewMockWebhookService(ctrl)
+		svc.EXPECT().GetSubscription(gomock.Any(), uint(4)).Return(nil, webhookNotFoundError(4))
+		NewWebhookHandler(log, svc).GetSubscription(ctx)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("it rejects an invalid subscription ID", func(t *testing.T) {
+		ctx, w := newCtx(http.MethodDelete, "abc", "")
+		NewWebhookHandler(log, NewMockWebhookService(gomock.NewController(t))).DeleteSubscription(ctx)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("it updates and deletes a subscription", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		svc := NewMockWebhookService(ctrl)
+		svc.EXPECT().UpdateSubscription(gomock.Any(), uint(4), gomock.Any()).Return(&urgencyV1.WebhookSubscriptionResponse{ID: 4, Active: false}, nil)
+		svc.EXPECT().DeleteSubscription(gomock.Any(), uint(4)).Return(nil)
+		h := NewWebhookHandler(log, svc)
+
+		ctx, w := newCtx(http.MethodPut, "4", `{"url":"https://partner.example.com","secret":"0123456789abcdef","eventTypes":["urgency.closed"],"active":false}`)
+		h.UpdateSubscription(ctx)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		ctx, w = newCtx(http.MethodDelete, "4", "")
+		h.DeleteSubscription(ctx)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("it lists deliveries with an optional limit", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		svc := NewMockWebhookService(ctrl)
+		svc.EXPECT().ListDeliveries(gomock.Any(), uint(4), 20).Return([]urgencyV1.WebhookDeliveryResponse{{ID: 1, Status: "delivered"}}, nil)
+		h := NewWebhookHandler(log, svc)
+
+		ctx, w := newCtx(http.MethodGet, "4", "")
+		ctx.Request.URL.RawQuery = "limit=20"
+		h.ListDeliveries(ctx)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"status":"delivered"`)
+
+		ctx, w = newCtx(http.MethodGet, "4", "")
+		ctx.Request.URL.RawQuery = "limit=-1"
+		h.ListDeliveries(ctx)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
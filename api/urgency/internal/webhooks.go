@@ -0,0 +1,157 @@
+package internal
+
+//go:generate mockgen -source=webhooks.go -destination=webhooks_gomock.go -package=internal mountain_service/urgency/internal -imports=gomock=go.uber.org/mock/gomock -typed
+
+import (
+	"context"
+	"errors"
+
+	commonv1 "github.com/pd120424d/mountain-service/api/contracts/common/v1"
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/repositories"
+	"gorm.io/gorm"
+)
+
+// maxWebhookDeliveries caps how much of the delivery log is returned at once
+const maxWebhookDeliveries = 200
+
+// WebhookService manages the webhook subscriptions of external partners. Deliveries themselves
+// are made by the webhook dispatcher from the urgency outbox.
+type WebhookService interface {
+	CreateSubscription(ctx context.Context, req urgencyV1.WebhookSubscriptionRequest) (*urgencyV1.WebhookSubscriptionResponse, error)
+	ListSubscriptions(ctx context.Context) ([]urgencyV1.WebhookSubscriptionResponse, error)
+	GetSubscription(ctx context.Context, id uint) (*urgencyV1.WebhookSubscriptionResponse, error)
+	UpdateSubscription(ctx context.Context, id uint, req urgencyV1.WebhookSubscriptionRequest) (*urgencyV1.WebhookSubscriptionResponse, error)
+	DeleteSubscription(ctx context.Context, id uint) error
+	ListDeliveries(ctx context.Context, id uint, limit int) ([]urgencyV1.WebhookDeliveryResponse, error)
+}
+
+type webhookService struct {
+	log  utils.Logger
+	repo repositories.WebhookRepository
+}
+
+func NewWebhookService(log utils.Logger, repo repositories.WebhookRepository) WebhookService {
+	return &webhookService{log: log.WithName("webhookService"), repo: repo}
+}
+
+func (s *webhookService) CreateSubscription(ctx context.Context, req urgencyV1.WebhookSubscriptionRequest) (*urgencyV1.WebhookSubscriptionResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "WebhookService.CreateSubscription")()
+
+	if err := req.Validate(); err != nil {
+		return nil, commonv1.NewAppError("VALIDATION.INVALID_REQUEST", err.Error(), nil)
+	}
+	sub := model.WebhookSubscriptionFromRequest(req)
+	if err := s.repo.CreateSubscription(ctx, &sub); err != nil {
+		log.Errorf("Failed to create webhook subscription: %v", err)
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.DB_ERROR", "failed to create webhook subscription", map[string]interface{}{"cause": err.Error()})
+	}
+	log.Infof("Created webhook subscription %d for %s", sub.ID, sub.URL)
+	resp := sub.ToResponse()
+	return &resp, nil
+}
+
+func (s *webhookService) ListSubscriptions(ctx context.Context) ([]urgencyV1.WebhookSubscriptionResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "WebhookService.ListSubscriptions")()
+
+	subs, err := s.repo.ListSubscriptions(ctx)
+	if err != nil {
+		log.Errorf("Failed to list webhook subscriptions: %v", err)
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.DB_ERROR", "failed to list webhook subscriptions", map[string]interface{}{"cause": err.Error()})
+	}
+	resp := make([]urgencyV1.WebhookSubscriptionResponse, 0, len(subs))
+	for i := range subs {
+		resp = append(resp, subs[i].ToResponse())
+	}
+	return resp, nil
+}
+
+func (s *webhookService) GetSubscription(ctx context.Context, id uint) (*urgencyV1.WebhookSubscriptionResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "WebhookService.GetSubscription")()
+
+	sub, err := s.getSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	resp := sub.ToResponse()
+	return &resp, nil
+}
+
+// UpdateSubscription replaces the URL, secret and event types; the active flag only changes when it is sent
+func (s *webhookService) UpdateSubscription(ctx context.Context, id uint, req urgencyV1.WebhookSubscriptionRequest) (*urgencyV1.WebhookSubscriptionResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "WebhookService.UpdateSubscription")()
+
+	if err := req.Validate(); err != nil {
+		return nil, commonv1.NewAppError("VALIDATION.INVALID_REQUEST", err.Error(), nil)
+	}
+	sub, err := s.getSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	sub.Apply(req)
+	if err := s.repo.UpdateSubscription(ctx, sub); err != nil {
+		log.Errorf("Failed to update webhook subscription %d: %v", id, err)
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.UPDATE_FAILED", "failed to update webhook subscription", map[string]interface{}{"cause": err.Error()})
+	}
+	resp := sub.ToResponse()
+	return &resp, nil
+}
+
+func (s *webhookService) DeleteSubscription(ctx context.Context, id uint) error {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "WebhookService.DeleteSubscription")()
+
+	if err := s.repo.DeleteSubscription(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return webhookNotFoundError(id)
+		}
+		log.Errorf("Failed to delete webhook subscription %d: %v", id, err)
+		return commonv1.NewAppError("URGENCY_ERRORS.DB_ERROR", "failed to delete webhook subscription", map[string]interface{}{"cause": err.Error()})
+	}
+	log.Infof("Deleted webhook subscription %d", id)
+	return nil
+}
+
+// ListDeliveries returns the most recent deliveries of a subscription, newest first
+func (s *webhookService) ListDeliveries(ctx context.Context, id uint, limit int) ([]urgencyV1.WebhookDeliveryResponse, error) {
+	log := s.log.WithContext(ctx)
+	defer utils.TimeOperation(log, "WebhookService.ListDeliveries")()
+
+	if _, err := s.getSubscription(ctx, id); err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > maxWebhookDeliveries {
+		limit = maxWebhookDeliveries
+	}
+	deliveries, err := s.repo.ListDeliveries(ctx, id, limit)
+	if err != nil {
+		log.Errorf("Failed to list deliveries of webhook subscription %d: %v", id, err)
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.DB_ERROR", "failed to list webhook deliveries", map[string]interface{}{"cause": err.Error()})
+	}
+	resp := make([]urgencyV1.WebhookDeliveryResponse, 0, len(deliveries))
+	for i := range deliveries {
+		resp = append(resp, deliveries[i].ToResponse())
+	}
+	return resp, nil
+}
+
+func (s *webhookService) getSubscription(ctx context.Context, id uint) (*model.WebhookSubscription, error) {
+	var sub model.WebhookSubscription
+	if err := s.repo.GetSubscription(ctx, id, &sub); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, webhookNotFoundError(id)
+		}
+		return nil, commonv1.NewAppError("URGENCY_ERRORS.DB_ERROR", "failed to fetch webhook subscription", map[string]interface{}{"cause": err.Error()})
+	}
+	return &sub, nil
+}
+
+func webhookNotFoundError(id uint) error {
+	return commonv1.NewAppError("URGENCY_ERRORS.WEBHOOK_NOT_FOUND", "webhook subscription not found", map[string]interface{}{"subscriptionId": id})
+}
@@ -0,0 +1,132 @@
+package internal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+
+	urgencyV1 "github.com/pd120424d/mountain-service/api/contracts/urgency/v1"
+	"github.com/pd120424d/mountain-service/api/shared/utils"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/model"
+	"github.com/pd120424d/mountain-service/api/urgency/internal/repositories"
+)
+
+func validWebhookRequest() urgencyV1.WebhookSubscriptionRequest {
+	return urgencyV1.WebhookSubscriptionRequest{
+		URL:        "https://partner.example.com/hooks",
+		Secret:     "0123456789abcdef",
+		EventTypes: []urgencyV1.UrgencyEventType{urgencyV1.EventUrgencyCreated, urgencyV1.EventUrgencyClosed},
+	}
+}
+
+func TestWebhookService_CreateSubscription(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it rejects an invalid request", func(t *testing.T) {
+		svc := NewWebhookService(utils.NewTestLogger(), repositories.NewMockWebhookRepository(gomock.NewController(t)))
+
+		_, err := svc.CreateSubscription(context.Background(), urgencyV1.WebhookSubscriptionRequest{URL: "https://x"})
+		assertAppErrorCode(t, err, "VALIDATION.INVALID_REQUEST")
+	})
+
+	t.Run("it stores an active subscription and never returns the secret", func(t *testing.T) {
+		repo := repositories.NewMockWebhookRepository(gomock.NewController(t))
+		repo.EXPECT().CreateSubscription(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, sub *model.WebhookSubscription) error {
+			assert.Equal(t, "urgency.created,urgency.closed", sub.EventTypes)
+			assert.Equal(t, "0123456789abcdef", sub.Secret)
+			assert.True(t, sub.Active)
+			sub.ID = 3
+			return nil
+		})
+		svc := NewWebhookService(utils.NewTestLogger(), repo)
+
+		resp, err := svc.CreateSubscription(context.Background(), validWebhookRequest())
+		require.NoError(t, err)
+		assert.Equal(t, uint(3), resp.ID)
+		assert.True(t, resp.Active)
+		assert.Equal(t, []urgencyV1.UrgencyEventType{urgencyV1.EventUrgencyCreated, urgencyV1.EventUrgencyClosed}, resp.EventTypes)
+	})
+}
+
+func TestWebhookService_UpdateSubscription(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it returns not found for an unknown subscription", func(t *testing.T) {
+		repo := repositories.NewMockWebhookRepository(gomock.NewController(t))
+		repo.EXPECT().GetSubscription(gomock.Any(), uint(3), gomock.Any()).Return(gorm.ErrRecordNotFound)
+		svc := NewWebhookService(utils.NewTestLogger(), repo)
+
+		_, err := svc.UpdateSubscription(context.Background(), 3, validWebhookRequest())
+		assertAppErrorCode(t, err, "URGENCY_ERRORS.WEBHOOK_NOT_FOUND")
+	})
+
+	t.Run("it keeps the active flag unless the request sets it", func(t *testing.T) {
+		repo := repositories.NewMockWebhookRepository(gomock.NewController(t))
+		repo.EXPECT().GetSubscription(gomock.Any(), uint(3), gomock.Any()).
+			SetArg(2, model.WebhookSubscription{ID: 3, URL: "https://old.example.com", EventTypes: "urgency.assigned", Active: false}).Return(nil)
+		repo.EXPECT().UpdateSubscription(gomock.Any(), gomock.Any()).Return(nil)
+		svc := NewWebhookService(utils.NewTestLogger(), repo)
+
+		resp, err := svc.UpdateSubscription(context.Background(), 3, validWebhookRequest())
+		require.NoError(t, err)
+		assert.False(t, resp.Active)
+		assert.Equal(t, "https://partner.example.com/hooks", resp.URL)
+	})
+
+	t.Run("it reactivates a subscription", func(t *testing.T) {
+		repo := repositories.NewMockWebhookRepository(gomock.NewController(t))
+		repo.EXPECT().GetSubscription(gomock.Any(), uint(3), gomock.Any()).SetArg(2, model.WebhookSubscription{ID: 3}).Return(nil)
+		repo.EXPECT().UpdateSubscription(gomock.Any(), gomock.Any()).Return(nil)
+		svc := NewWebhookService(utils.NewTestLogger(), repo)
+
+		active := true
+		req := validWebhookRequest()
+		req.Active = &active
+		resp, err := svc.UpdateSubscription(context.Background(), 3, req)
+		require.NoError(t, err)
+		assert.True(t, resp.Active)
+	})
+}
+
+func TestWebhookService_DeleteSubscription(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it maps a missing subscription to not found", func(t *testing.T) {
+		repo := repositories.NewMockWebhookRepository(gomock.NewController(t))
+		repo.EXPECT().DeleteSubscription(gomock.Any(), uint(3)).Return(gorm.ErrRecordNotFound)
+		svc := NewWebhookService(utils.NewTestLogger(), repo)
+
+		assertAppErrorCode(t, svc.DeleteSubscription(context.Background(), 3), "URGENCY_ERRORS.WEBHOOK_NOT_FOUND")
+	})
+
+	t.Run("it reports database errors", func(t *testing.T) {
+		repo := repositories.NewMockWebhookRepository(gomock.NewController(t))
+		repo.EXPECT().DeleteSubscription(gomock.Any(), uint(3)).Return(assert.AnError)
+		svc := NewWebhookService(utils.NewTestLogger(), repo)
+
+		assertAppErrorCode(t, svc.DeleteSubscription(context.Background(), 3), "URGENCY_ERRORS.DB_ERROR")
+	})
+}
+
+func TestWebhookService_ListDeliveries(t *testing.T) {
+	t.Parallel()
+
+	t.Run("it caps the limit and maps the delivery log", func(t *testing.T) {
+		repo := repositories.NewMockWebhookRepository(gomock.NewController(t))
+		repo.EXPECT().GetSubscription(gomock.Any(), uint(3), gomock.Any()).Return(nil)
+		repo.EXPECT().ListDeliveries(gomock.Any(), uint(3), maxWebhookDeliveries).Return([]model.WebhookDelivery{
+			{ID: 8, SubscriptionID: 3, EventID: 2, EventType: urgencyV1.EventUrgencyClosed, Status: model.WebhookDeliveryFailed, Attempts: 8, ResponseStatus: 500, Error: "subscriber responded with status 500"},
+		}, nil)
+		svc := NewWebhookService(utils.NewTestLogger(), repo)
+
+		deliveries, err := svc.ListDeliveries(context.Background(), 3, 5000)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, "failed", deliveries[0].Status)
+		assert.Equal(t, 500, deliveries[0].ResponseStatus)
+	})
+}
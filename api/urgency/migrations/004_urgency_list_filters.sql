@@ -0,0 +1,36 @@
+-- Migration: Add indexes backing urgency list filters, search and sort keys
+-- Date: 2026-10-16
+-- Notes:
+-- - These CREATE INDEX statements use CONCURRENTLY to avoid long locks.
+-- - CONCURRENTLY cannot run inside a transaction; keep statements standalone.
+-- - Safe to run multiple times thanks to IF NOT EXISTS.
+-- - The search index expression must match searchExpr in internal/repositories/urgency_repository.go.
+
+-- 1) For status filters combined with newest/oldest ordering
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_urgency_status_created
+    ON urgencies (status, created_at DESC)
+    WHERE deleted_at IS NULL;
+
+-- 2) For level filters and the level sort key
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_urgency_level_created
+    ON urgencies (level, created_at DESC)
+    WHERE deleted_at IS NULL;
+
+-- 3) For date range filters and the newest/oldest sort keys without other filters
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_urgency_created
+    ON urgencies (created_at DESC)
+    WHERE deleted_at IS NULL;
+
+-- 4) For the unassigned-only filter ordered by newest first
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_urgency_unassigned_created
+    ON urgencies (created_at DESC)
+    WHERE deleted_at IS NULL AND assigned_employee_id IS NULL;
+
+-- 5) For free-text search (q) with LIKE '%...%' over name, location and description
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_urgency_search_trgm
+    ON urgencies USING gin (
+        LOWER(COALESCE(first_name, '') || ' ' || COALESCE(last_name, '') || ' ' || COALESCE(location, '') || ' ' || COALESCE(description, '')) gin_trgm_ops
+    )
+    WHERE deleted_at IS NULL;
@@ -0,0 +1,23 @@
+-- Migration: Backfill urgency team leads and index active team memberships
+-- Date: 2026-10-16
+-- Notes:
+-- - The urgency_assignments table itself is created by AutoMigrate on service start.
+-- - Every urgency that already has an assignee gets an active lead row so it shows up in team views.
+-- - Safe to run multiple times thanks to NOT EXISTS and IF NOT EXISTS.
+-- - CONCURRENTLY cannot run inside a transaction; keep statements standalone.
+
+-- 1) Existing assignees become team leads
+INSERT INTO urgency_assignments (urgency_id, employee_id, role, joined_at)
+SELECT u.id, u.assigned_employee_id, 'lead', COALESCE(u.assigned_at, u.created_at)
+FROM urgencies u
+WHERE u.deleted_at IS NULL
+  AND u.assigned_employee_id IS NOT NULL
+  AND NOT EXISTS (
+      SELECT 1 FROM urgency_assignments a
+      WHERE a.urgency_id = u.id AND a.employee_id = u.assigned_employee_id AND a.left_at IS NULL
+  );
+
+-- 2) For the myUrgencies filter, which looks up active memberships by employee
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_urgency_assignments_active_employee
+    ON urgency_assignments (employee_id, urgency_id)
+    WHERE left_at IS NULL;